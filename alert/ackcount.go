@@ -0,0 +1,85 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+)
+
+// recordAcksTx will record an acknowledgement from the current user for alerts whose current escalation
+// step requires acknowledgement from multiple users. It returns the set of alerts that still require more
+// acknowledgements, which should remain triggered.
+//
+// Acknowledgements from a user that is on call for the current step only as a shadow participant are
+// logged, but not counted, and the alert remains triggered.
+//
+// The user will no longer be notified about held alerts. Acknowledgements not made by a user (e.g., from an
+// integration key) are not recorded, and are treated as a full acknowledgement.
+func (s *Store) recordAcksTx(ctx context.Context, tx *sql.Tx, alertIDs []int64) (map[int64]bool, error) {
+	userID := permission.UserNullUUID(ctx)
+	if !userID.Valid {
+		return nil, nil
+	}
+
+	db := gadb.New(tx)
+	shadowIDs, err := db.Alert_ShadowAckAlerts(ctx, gadb.Alert_ShadowAckAlertsParams{
+		AlertIds: alertIDs,
+		UserID:   userID.UUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find shadow acks: %w", err)
+	}
+
+	held := make(map[int64]bool)
+	var heldIDs []int64
+	for _, id := range shadowIDs {
+		held[id] = true
+		heldIDs = append(heldIDs, id)
+		err = s.logDB.LogTx(ctx, tx, int(id), alertlog.TypeAckRecorded, &alertlog.AckRecordedMetaData{Shadow: true})
+		if err != nil {
+			return nil, err
+		}
+	}
+	alertIDs = slices.DeleteFunc(slices.Clone(alertIDs), func(id int64) bool { return held[id] })
+
+	rows, err := db.Alert_RecordAcks(ctx, gadb.Alert_RecordAcksParams{
+		AlertIds: alertIDs,
+		UserID:   userID.UUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("record acks: %w", err)
+	}
+
+	for _, r := range rows {
+		if r.Acks >= r.Required {
+			continue
+		}
+		held[r.AlertID] = true
+		heldIDs = append(heldIDs, r.AlertID)
+		err = s.logDB.LogTx(ctx, tx, int(r.AlertID), alertlog.TypeAckRecorded, &alertlog.AckRecordedMetaData{
+			Count:    int(r.Acks),
+			Required: int(r.Required),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(heldIDs) == 0 {
+		return held, nil
+	}
+
+	err = db.Alert_StopUserCycles(ctx, gadb.Alert_StopUserCyclesParams{
+		AlertIds: heldIDs,
+		UserID:   userID.UUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("stop user notifications: %w", err)
+	}
+
+	return held, nil
+}
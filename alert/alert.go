@@ -2,6 +2,7 @@ package alert
 
 import (
 	"crypto/sha512"
+	"database/sql"
 	"encoding/hex"
 	"strings"
 	"time"
@@ -15,6 +16,9 @@ const (
 	MaxDetailsLength = 6 * 1024 // 6KiB
 )
 
+// MaxSnoozeMinutes is the longest an alert can be snoozed for.
+const MaxSnoozeMinutes = 7 * 24 * 60 // 7 days
+
 // An Alert represents an ongoing situation.
 type Alert struct {
 	ID        int       `json:"_id"`
@@ -25,6 +29,13 @@ type Alert struct {
 	ServiceID string    `json:"service_id"`
 	CreatedAt time.Time `json:"created_at"`
 	Dedup     *DedupID  `json:"dedup"`
+	Priority  Priority  `json:"priority,omitempty"`
+
+	// SnoozedUntil is set while the alert is snoozed (acknowledged for a fixed duration).
+	SnoozedUntil time.Time `json:"snoozed_until,omitzero"`
+
+	// FlappingUntil is set while the alert is flapping, and notifications are held.
+	FlappingUntil time.Time `json:"flapping_until,omitzero"`
 }
 
 // DedupKey will return the de-duplication key for the alert.
@@ -45,7 +56,14 @@ func (a *Alert) DedupKey() *DedupID {
 }
 
 func (a *Alert) scanFrom(scanFn func(...interface{}) error) error {
-	return scanFn(&a.ID, &a.Summary, &a.Details, &a.ServiceID, &a.Source, &a.Status, &a.CreatedAt, &a.Dedup)
+	var snoozedUntil, flappingUntil sql.NullTime
+	err := scanFn(&a.ID, &a.Summary, &a.Details, &a.ServiceID, &a.Source, &a.Status, &a.CreatedAt, &a.Dedup, &a.Priority, &snoozedUntil, &flappingUntil)
+	if err != nil {
+		return err
+	}
+	a.SnoozedUntil = snoozedUntil.Time
+	a.FlappingUntil = flappingUntil.Time
+	return nil
 }
 
 func (a Alert) Normalize() (*Alert, error) {
@@ -64,6 +82,7 @@ func (a Alert) Normalize() (*Alert, error) {
 		validate.OneOf("Source", a.Source, SourceManual, SourceGrafana, SourceSite24x7, SourcePrometheusAlertmanager, SourceEmail, SourceGeneric, SourceUniversal),
 		validate.OneOf("Status", a.Status, StatusTriggered, StatusActive, StatusClosed),
 		validate.UUID("ServiceID", a.ServiceID),
+		validate.Range("Priority", int(a.Priority), int(PriorityNone), int(PriorityP5)),
 	)
 	if err != nil {
 		return nil, err
@@ -43,7 +43,17 @@ func (e Entry) Meta(ctx context.Context) interface{} {
 	case TypeCreated:
 		dest = &CreatedMetaData{}
 	case TypeClosed:
+		var drop MaintenanceDropMetaData
+		if json.Unmarshal(e.meta, &drop) == nil && drop.MaintenanceWindowID != "" {
+			return &drop
+		}
 		dest = &AutoClose{}
+	case TypeSnoozed:
+		dest = &SnoozeMetaData{}
+	case TypeFlapping:
+		dest = &FlappingMetaData{}
+	case TypeAckRecorded:
+		dest = &AckRecordedMetaData{}
 	default:
 		return nil
 	}
@@ -126,6 +136,15 @@ func escalationMsg(m *EscalationMetaData) string {
 	if m.Repeat {
 		msg += " (policy repeat)"
 	}
+	switch {
+	case m.SkippedSteps == 1:
+		msg += " (skipped 1 inactive step)"
+	case m.SkippedSteps > 1:
+		msg += fmt.Sprintf(" (skipped %d inactive steps)", m.SkippedSteps)
+	}
+	if m.NoActiveStep {
+		msg += " (no active step)"
+	}
 	if m.Forced {
 		msg += " due to manual escalation"
 	} else if m.Deleted {
@@ -143,13 +162,26 @@ func (e Entry) String(ctx context.Context) string {
 	switch e.Type() {
 	case TypeCreated:
 		msg = "Created"
+		meta, ok := e.Meta(ctx).(*CreatedMetaData)
+		if ok && meta.MaintenanceHeld {
+			msg += " (escalation held for maintenance"
+			if meta.MaintenanceReason != "" {
+				msg += ": " + meta.MaintenanceReason
+			}
+			msg += ")"
+		}
 	case TypeAcknowledged:
 		msg = "Acknowledged"
 	case TypeClosed:
 		msg = "Closed"
-		meta, ok := e.Meta(ctx).(*AutoClose)
-		if ok {
+		switch meta := e.Meta(ctx).(type) {
+		case *AutoClose:
 			msg = "Closed due to inactivity (unacknowledged for  " + strconv.Itoa(meta.AlertAutoCloseDays) + " days)"
+		case *MaintenanceDropMetaData:
+			msg = "Closed at end of maintenance window"
+			if meta.Reason != "" {
+				msg += fmt.Sprintf(" (%s)", meta.Reason)
+			}
 		}
 
 	case TypeEscalated:
@@ -170,6 +202,31 @@ func (e Entry) String(ctx context.Context) string {
 		msg = "Suppressed duplicate: created"
 	case TypeEscalationRequest:
 		msg = "Escalation requested"
+	case TypeSnoozed:
+		msg = "Snoozed"
+		meta, ok := e.Meta(ctx).(*SnoozeMetaData)
+		if ok {
+			msg += fmt.Sprintf(" for %d minutes", meta.DurationMinutes)
+		}
+	case TypeUnsnoozed:
+		msg = "Snooze expired, re-triggered"
+	case TypeReopened:
+		msg = "Reopened"
+	case TypeFlapping:
+		msg = "Flapping detected"
+		meta, ok := e.Meta(ctx).(*FlappingMetaData)
+		if ok {
+			msg += fmt.Sprintf(" (%d open/close cycles in %d minutes)", meta.Cycles, meta.WindowMinutes)
+		}
+		msg += ", notifications held"
+	case TypeAckRecorded:
+		msg = "Acknowledgement recorded"
+		meta, ok := e.Meta(ctx).(*AckRecordedMetaData)
+		if ok && meta.Shadow {
+			msg += " (shadow participant, not counted)"
+		} else if ok {
+			msg += fmt.Sprintf(" (%d of %d required)", meta.Count, meta.Required)
+		}
 	default:
 		return "Error"
 	}
@@ -7,6 +7,28 @@ type EscalationMetaData struct {
 	Deleted         bool
 	OldDelayMinutes int
 	NoOneOnCall     bool
+	SkippedSteps    int
+	NoActiveStep    bool
+}
+
+type SnoozeMetaData struct {
+	DurationMinutes int
+}
+
+// FlappingMetaData is recorded when an alert is detected as flapping.
+type FlappingMetaData struct {
+	Cycles        int
+	WindowMinutes int
+}
+
+// AckRecordedMetaData is recorded when a user acknowledges an alert on a step that
+// requires acknowledgement from multiple users, or when a shadow participant acknowledges an alert.
+type AckRecordedMetaData struct {
+	Count    int
+	Required int
+
+	// Shadow indicates the acknowledgement was from a shadow participant, and was not counted.
+	Shadow bool `json:",omitempty"`
 }
 
 type NotificationMetaData struct {
@@ -15,6 +37,16 @@ type NotificationMetaData struct {
 
 type CreatedMetaData struct {
 	EPNoSteps bool
+
+	// MaintenanceReason is set if escalation was held by an active maintenance window when the alert was created.
+	MaintenanceReason string `json:",omitempty"`
+	MaintenanceHeld   bool   `json:",omitempty"`
+}
+
+// MaintenanceDropMetaData is recorded when an alert is closed at the end of a maintenance window.
+type MaintenanceDropMetaData struct {
+	MaintenanceWindowID string
+	Reason              string
 }
 
 type AutoClose struct {
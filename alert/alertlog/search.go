@@ -23,6 +23,9 @@ type SearchOptions struct {
 	// FilterServiceID restricts the log entries to those alerts that belong to a specific service.
 	FilterServiceID *uuid.UUID `json:"i,omitempty"`
 
+	// FilterIncidentID restricts the log entries to those alerts attached to a specific incident.
+	FilterIncidentID int `json:"n,omitempty"`
+
 	// Limit restricts the maximum number of rows returned. Default is 15.
 	Limit int `json:"-"`
 
@@ -61,6 +64,9 @@ var searchTemplate = template.Must(template.New("search").Parse(`
 	{{- if .FilterServiceID}}
 	JOIN alerts a ON a.id = log.alert_id and a.service_id = :serviceID
 	{{- end}}
+	{{- if .FilterIncidentID}}
+	JOIN incident_alerts ia ON ia.alert_id = log.alert_id and ia.incident_id = :incidentID
+	{{- end}}
 	WHERE TRUE
 	{{- if .FilterAlertIDs}}
 		AND log.alert_id = ANY(:alertIDs)
@@ -99,6 +105,7 @@ func (opts renderData) QueryArgs() []sql.NamedArg {
 		sql.Named("alertIDs", sqlutil.IntArray(opts.FilterAlertIDs)),
 		sql.Named("since", opts.Since),
 		sql.Named("serviceID", opts.FilterServiceID),
+		sql.Named("incidentID", opts.FilterIncidentID),
 	}
 }
 
@@ -16,6 +16,11 @@ const (
 	TypePolicyUpdated      Type = "policy_updated"
 	TypeDuplicateSupressed Type = "duplicate_suppressed"
 	TypeEscalationRequest  Type = "escalation_request"
+	TypeSnoozed            Type = "snoozed"
+	TypeUnsnoozed          Type = "unsnoozed"
+	TypeReopened           Type = "reopened"
+	TypeFlapping           Type = "flapping"
+	TypeAckRecorded        Type = "ack_recorded"
 
 	// not exported, status_changed will be turned into an acknowledged where appropriate
 	_TypeStatusChanged Type = "status_changed"
@@ -0,0 +1,179 @@
+package alertrule
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/target/goalert/gadb"
+)
+
+// Fields that can be changed by a rule.
+const (
+	FieldSummary  = "summary"
+	FieldDetails  = "details"
+	FieldDedup    = "dedup"
+	FieldPriority = "priority"
+
+	// MetaPrefix is the prefix for setting alert metadata (e.g., `meta.team`).
+	MetaPrefix = "meta."
+)
+
+// Input contains the alert fields available to rule expressions under the `alert` variable.
+type Input struct {
+	ServiceID string
+	Source    string
+	Status    string
+	Summary   string
+	Details   string
+	Dedup     string
+	Priority  string
+	Meta      map[string]string
+}
+
+// Result is the combined outcome of all matching rules.
+type Result struct {
+	// Drop indicates a matching rule requested the alert be discarded.
+	Drop bool
+
+	// Matched contains the names of all matching rules, in order.
+	Matched []string
+
+	// Set contains the final value of all fields changed by matching rules.
+	Set map[string]string
+}
+
+// Meta returns the metadata values set by matching rules, if any.
+func (r Result) Meta() map[string]string {
+	var meta map[string]string
+	for k, v := range r.Set {
+		key, ok := strings.CutPrefix(k, MetaPrefix)
+		if !ok {
+			continue
+		}
+		if meta == nil {
+			meta = make(map[string]string)
+		}
+		meta[key] = v
+	}
+	return meta
+}
+
+// RuleError is an error that occurred while processing a rule.
+type RuleError struct {
+	Index int
+	Name  string
+	Err   error
+}
+
+func (r *RuleError) Error() string {
+	return fmt.Sprintf("rule %d (%s): %s", r.Index, r.Name, r.Err)
+}
+
+type compiledRule struct {
+	gadb.SvcAlertRuleV1
+	cond *vm.Program
+	set  map[string]*vm.Program
+}
+
+// Rules is a compiled set of service alert rules.
+type Rules struct {
+	rules []compiledRule
+}
+
+// Compile will compile all rules in the config.
+func Compile(cfg gadb.SvcAlertRulesV1) (*Rules, error) {
+	res := &Rules{rules: make([]compiledRule, len(cfg.Rules))}
+	for i, r := range cfg.Rules {
+		cond, err := expr.Compile(r.ConditionExpr, expr.AllowUndefinedVariables(), expr.Optimize(true), expr.AsBool())
+		if err != nil {
+			return nil, &RuleError{Index: i, Name: r.Name, Err: fmt.Errorf("compile condition: %w", err)}
+		}
+
+		c := compiledRule{SvcAlertRuleV1: r, cond: cond, set: make(map[string]*vm.Program, len(r.Set))}
+		for k, v := range r.Set {
+			p, err := expr.Compile(v, expr.AllowUndefinedVariables(), expr.Optimize(true))
+			if err != nil {
+				return nil, &RuleError{Index: i, Name: r.Name, Err: fmt.Errorf("compile %s: %w", k, err)}
+			}
+			c.set[k] = p
+		}
+		res.rules[i] = c
+	}
+
+	return res, nil
+}
+
+func (in Input) alertEnv() map[string]any {
+	meta := make(map[string]any, len(in.Meta))
+	for k, v := range in.Meta {
+		meta[k] = v
+	}
+
+	return map[string]any{
+		"serviceID": in.ServiceID,
+		"source":    in.Source,
+		"status":    in.Status,
+		"summary":   in.Summary,
+		"details":   in.Details,
+		"dedup":     in.Dedup,
+		"priority":  in.Priority,
+		"meta":      meta,
+	}
+}
+
+// Run will evaluate all rules, in order, against the input.
+//
+// Changes made by a matching rule are visible to the expressions of later rules.
+func (r *Rules) Run(in Input) (*Result, error) {
+	alertEnv := in.alertEnv()
+	env := map[string]any{
+		"sprintf": fmt.Sprintf,
+		"alert":   alertEnv,
+	}
+
+	var vm vm.VM
+	res := &Result{Set: make(map[string]string)}
+	for i, rule := range r.rules {
+		ok, err := vm.Run(rule.cond, env)
+		if err != nil {
+			return nil, &RuleError{Index: i, Name: rule.Name, Err: fmt.Errorf("run condition: %w", err)}
+		}
+		if !ok.(bool) {
+			continue
+		}
+
+		res.Matched = append(res.Matched, rule.Name)
+		if rule.Drop {
+			res.Drop = true
+			return res, nil
+		}
+
+		changes := make(map[string]string, len(rule.set))
+		for k, p := range rule.set {
+			val, err := vm.Run(p, env)
+			if err != nil {
+				return nil, &RuleError{Index: i, Name: rule.Name, Err: fmt.Errorf("run %s: %w", k, err)}
+			}
+			changes[k] = fmt.Sprintf("%v", val)
+		}
+
+		// apply after evaluating all expressions so they all see the same input
+		maps.Copy(res.Set, changes)
+		for k, v := range changes {
+			if key, ok := strings.CutPrefix(k, MetaPrefix); ok {
+				alertEnv["meta"].(map[string]any)[key] = v
+				continue
+			}
+			alertEnv[k] = v
+		}
+
+		if !rule.ContinueAfterMatch {
+			break
+		}
+	}
+
+	return res, nil
+}
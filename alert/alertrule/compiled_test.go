@@ -0,0 +1,73 @@
+package alertrule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/gadb"
+)
+
+func TestRules_Run(t *testing.T) {
+	cfg := gadb.SvcAlertRulesV1{Rules: []gadb.SvcAlertRuleV1{
+		{
+			Name:          "drop test",
+			ConditionExpr: `alert.summary startsWith "TEST"`,
+			Drop:          true,
+		},
+		{
+			Name:               "tag email",
+			ConditionExpr:      `alert.source == "email"`,
+			Set:                map[string]string{"summary": `"[email] " + alert.summary`, "meta.team": `"ops"`},
+			ContinueAfterMatch: true,
+		},
+		{
+			Name:          "dedup",
+			ConditionExpr: `alert.summary startsWith "[email]"`,
+			Set:           map[string]string{"dedup": `alert.meta.team + ":" + alert.details`},
+		},
+		{
+			Name:          "never reached",
+			ConditionExpr: "true",
+			Set:           map[string]string{"priority": `"P1"`},
+		},
+	}}
+
+	rules, err := Compile(cfg)
+	require.NoError(t, err, "should compile valid rules")
+
+	res, err := rules.Run(Input{Summary: "TEST alert", Source: "email"})
+	require.NoError(t, err)
+	require.True(t, res.Drop, "should drop matching alerts")
+	require.Equal(t, []string{"drop test"}, res.Matched)
+
+	res, err = rules.Run(Input{Summary: "disk full", Details: "host1", Source: "email"})
+	require.NoError(t, err)
+	require.False(t, res.Drop)
+	require.Equal(t, []string{"tag email", "dedup"}, res.Matched, "should stop after a rule without ContinueAfterMatch")
+	require.Equal(t, "[email] disk full", res.Set[FieldSummary])
+	require.Equal(t, "ops:host1", res.Set[FieldDedup], "should see changes from earlier rules")
+	require.Equal(t, map[string]string{"team": "ops"}, res.Meta())
+
+	res, err = rules.Run(Input{Summary: "disk full", Source: "grafana"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"never reached"}, res.Matched)
+	require.Equal(t, map[string]string{"priority": "P1"}, res.Set)
+}
+
+func TestValidate(t *testing.T) {
+	valid := gadb.SvcAlertRuleV1{Name: "rule", ConditionExpr: "true", Set: map[string]string{"meta.foo": `"bar"`}}
+	require.NoError(t, Validate(gadb.SvcAlertRulesV1{Rules: []gadb.SvcAlertRuleV1{valid}}))
+
+	check := func(desc string, fn func(r *gadb.SvcAlertRuleV1)) {
+		t.Helper()
+		r := valid
+		r.Set = map[string]string{}
+		fn(&r)
+		require.Error(t, Validate(gadb.SvcAlertRulesV1{Rules: []gadb.SvcAlertRuleV1{r}}), desc)
+	}
+
+	check("unknown field", func(r *gadb.SvcAlertRuleV1) { r.Set["status"] = `"closed"` })
+	check("drop with set", func(r *gadb.SvcAlertRuleV1) { r.Drop = true; r.Set["summary"] = `"foo"` })
+	check("bad condition", func(r *gadb.SvcAlertRuleV1) { r.ConditionExpr = "foo +" })
+	check("non-bool condition", func(r *gadb.SvcAlertRuleV1) { r.ConditionExpr = `"foo"` })
+}
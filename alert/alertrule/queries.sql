@@ -0,0 +1,19 @@
+-- name: SvcAlertRulesGetConfig :one
+SELECT
+    config
+FROM
+    service_alert_rules
+WHERE
+    service_id = $1;
+
+-- name: SvcAlertRulesSetConfig :exec
+INSERT INTO service_alert_rules(service_id, config)
+    VALUES ($1, $2)
+ON CONFLICT (service_id)
+    DO UPDATE SET
+        config = $2;
+
+-- name: SvcAlertRulesDeleteConfig :exec
+DELETE FROM service_alert_rules
+WHERE service_id = $1;
+
@@ -0,0 +1,171 @@
+package alertrule
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+const (
+	MaxRules = 100
+	MaxSet   = 10
+)
+
+// Store manages the alert rules of services.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore creates a new Store.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	return &Store{db: db}, nil
+}
+
+func validSetKey(key string) bool {
+	switch key {
+	case FieldSummary, FieldDetails, FieldDedup, FieldPriority:
+		return true
+	}
+
+	metaKey, ok := strings.CutPrefix(key, MetaPrefix)
+	return ok && validate.ASCII("Key", metaKey, 1, 255) == nil
+}
+
+// Validate will validate the rules config, including compiling all expressions.
+func Validate(cfg gadb.SvcAlertRulesV1) error {
+	err := validate.Len("Rules", cfg.Rules, 0, MaxRules)
+	if err != nil {
+		return err
+	}
+
+	for i, r := range cfg.Rules {
+		field := fmt.Sprintf("Rules[%d]", i)
+		err := validate.Many(
+			validate.Name(field+".Name", r.Name),
+			validate.Text(field+".Description", r.Description, 0, 255),
+			validate.Text(field+".ConditionExpr", r.ConditionExpr, 1, 1024),
+		)
+		if err != nil {
+			return err
+		}
+		if len(r.Set) > MaxSet {
+			return validation.NewFieldErrorf(field+".Set", "cannot set more than %d fields", MaxSet)
+		}
+		if r.Drop && len(r.Set) > 0 {
+			return validation.NewFieldError(field+".Set", "cannot set fields on a rule that drops alerts")
+		}
+		for k, v := range r.Set {
+			if !validSetKey(k) {
+				return validation.NewFieldErrorf(field+".Set", "unknown field '%s'", k)
+			}
+			err = validate.Text(field+".Set."+k, v, 1, 1024)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = Compile(cfg)
+	if err != nil {
+		return validation.WrapError(err)
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	if len(data) > 64*1024 {
+		return validation.NewFieldError("Config", "must be less than 64KiB in total")
+	}
+
+	return nil
+}
+
+// Load will return the compiled rules for a service, or nil if none are configured.
+//
+// It does not perform permission checks, and is intended to be used while processing incoming alerts.
+func Load(ctx context.Context, db gadb.DBTX, serviceID uuid.UUID) (*Rules, error) {
+	cfg, err := gadb.New(db).SvcAlertRulesGetConfig(ctx, serviceID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Version != 1 {
+		return nil, fmt.Errorf("unsupported alert rules version: %d", cfg.Version)
+	}
+	if len(cfg.V1.Rules) == 0 {
+		return nil, nil
+	}
+
+	return Compile(cfg.V1)
+}
+
+// Config returns the alert rules for a service.
+func (s *Store) Config(ctx context.Context, serviceID string) (*gadb.SvcAlertRulesV1, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := validate.ParseUUID("ServiceID", serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := gadb.New(s.db).SvcAlertRulesGetConfig(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &gadb.SvcAlertRulesV1{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Version != 1 {
+		return nil, fmt.Errorf("unsupported alert rules version: %d", cfg.Version)
+	}
+
+	return &cfg.V1, nil
+}
+
+// SetConfigTx will replace the alert rules for a service. An empty config removes all rules.
+func (s *Store) SetConfigTx(ctx context.Context, tx *sql.Tx, serviceID string, cfg gadb.SvcAlertRulesV1) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	id, err := validate.ParseUUID("ServiceID", serviceID)
+	if err != nil {
+		return err
+	}
+	err = Validate(cfg)
+	if err != nil {
+		return err
+	}
+
+	db := gadb.New(tx)
+	if len(cfg.Rules) == 0 {
+		return db.SvcAlertRulesDeleteConfig(ctx, id)
+	}
+
+	for i := range cfg.Rules {
+		if cfg.Rules[i].ID == uuid.Nil {
+			cfg.Rules[i].ID = uuid.New()
+		}
+	}
+
+	return db.SvcAlertRulesSetConfig(ctx, gadb.SvcAlertRulesSetConfigParams{
+		ServiceID: id,
+		Config:    gadb.SvcAlertRules{Version: 1, V1: cfg},
+	})
+}
@@ -16,6 +16,7 @@ type LogEntryFetcher interface {
 type logError struct {
 	isAlreadyAcknowledged bool
 	isAlreadyClosed       bool
+	isAckPending          bool
 	alertID               int
 	_type                 alertlog.Type
 	logDB                 *alertlog.Store
@@ -34,6 +35,9 @@ func (e logError) Error() string {
 	if e.isAlreadyClosed {
 		return "alert is already closed"
 	}
+	if e.isAckPending {
+		return "acknowledgement recorded, alert requires more acknowledgements"
+	}
 	return "unknown status update"
 }
 
@@ -61,3 +65,13 @@ func IsAlreadyClosed(err error) bool {
 	}
 	return false
 }
+
+// IsAckPending returns true if the acknowledgement was recorded, but the alert
+// requires acknowledgement from more users and remains triggered.
+func IsAckPending(err error) bool {
+	var e logError
+	if errors.As(err, &e) {
+		return e.isAckPending
+	}
+	return false
+}
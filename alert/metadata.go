@@ -104,7 +104,11 @@ func (s Store) SetMetadataTx(ctx context.Context, db gadb.DBTX, alertID int, met
 		return err
 	}
 
-	err = ValidateMetadata(meta)
+	return s.setMetadataTx(ctx, db, alertID, meta)
+}
+
+func (s Store) setMetadataTx(ctx context.Context, db gadb.DBTX, alertID int, meta map[string]string) error {
+	err := ValidateMetadata(meta)
 	if err != nil {
 		return err
 	}
@@ -12,4 +12,11 @@ var (
 		Name:      "created_total",
 		Help:      "The total number of created alerts.",
 	}, []string{"service_id"})
+
+	metricDroppedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "goalert",
+		Subsystem: "alert",
+		Name:      "rule_dropped_total",
+		Help:      "The total number of incoming alerts dropped by service alert rules.",
+	}, []string{"service_id"})
 )
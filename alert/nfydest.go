@@ -10,10 +10,11 @@ import (
 const (
 	DestTypeAlert = "builtin-alert"
 
-	ParamSummary = "summary"
-	ParamDetails = "details"
-	ParamDedup   = "dedup"
-	ParamClose   = "close"
+	ParamSummary  = "summary"
+	ParamDetails  = "details"
+	ParamDedup    = "dedup"
+	ParamClose    = "close"
+	ParamPriority = "priority"
 
 	FallbackIconURL = "builtin://alert"
 )
@@ -43,6 +44,10 @@ func (s *Store) TypeInfo(ctx context.Context) (*nfydest.TypeInfo, error) {
 			ParamID: ParamClose,
 			Label:   "Close",
 			Hint:    "If true, close an existing alert.",
+		}, {
+			ParamID: ParamPriority,
+			Label:   "Priority",
+			Hint:    "Alert priority, P1 (most urgent) through P5, or a severity name like critical or warning.",
 		}},
 	}, nil
 }
@@ -0,0 +1,77 @@
+package alert
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/target/goalert/validation"
+)
+
+// Priority indicates the urgency of an alert. P1 is the most urgent and P5 the
+// least. The zero value indicates no priority was provided.
+type Priority int
+
+// Alert priorities
+const (
+	PriorityNone Priority = iota
+	PriorityP1
+	PriorityP2
+	PriorityP3
+	PriorityP4
+	PriorityP5
+)
+
+// String returns the priority in `P<n>` form, or an empty string if unset.
+func (p Priority) String() string {
+	if p == PriorityNone {
+		return ""
+	}
+
+	return "P" + strconv.Itoa(int(p))
+}
+
+// IsSet returns true if a priority was provided.
+func (p Priority) IsSet() bool { return p != PriorityNone }
+
+// ParsePriority will parse a priority or severity value from an upstream source.
+//
+// It accepts `P1`-`P5`, `1`-`5`, as well as common severity names
+// (e.g., `critical`, `high`, `warning`, `low`, `info`). An empty string
+// results in PriorityNone.
+func ParsePriority(s string) (Priority, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "":
+		return PriorityNone, nil
+	case "critical", "crit", "fatal", "emergency", "emerg", "alert", "disaster", "sev1":
+		return PriorityP1, nil
+	case "high", "major", "error", "err", "sev2":
+		return PriorityP2, nil
+	case "warning", "warn", "medium", "moderate", "average", "sev3":
+		return PriorityP3, nil
+	case "low", "minor", "notice", "sev4":
+		return PriorityP4, nil
+	case "info", "informational", "information", "debug", "sev5":
+		return PriorityP5, nil
+	}
+
+	n, err := strconv.Atoi(strings.TrimPrefix(s, "p"))
+	if err != nil || n < int(PriorityP1) || n > int(PriorityP5) {
+		return PriorityNone, validation.NewFieldErrorf("Priority", "unknown priority '%s'", s)
+	}
+
+	return Priority(n), nil
+}
+
+// PriorityFromLabels returns the priority from the first of the `priority` or `severity`
+// keys that contains a valid value. PriorityNone is returned if neither is present or valid.
+func PriorityFromLabels(labels map[string]string) Priority {
+	for _, key := range []string{"priority", "severity"} {
+		p, err := ParsePriority(labels[key])
+		if err == nil && p.IsSet() {
+			return p
+		}
+	}
+
+	return PriorityNone
+}
@@ -0,0 +1,44 @@
+package alert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePriority(t *testing.T) {
+	check := func(input string, expected Priority) {
+		t.Helper()
+		p, err := ParsePriority(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, p, input)
+	}
+
+	check("", PriorityNone)
+	check("P1", PriorityP1)
+	check("p5", PriorityP5)
+	check("3", PriorityP3)
+	check(" Critical ", PriorityP1)
+	check("high", PriorityP2)
+	check("warning", PriorityP3)
+	check("low", PriorityP4)
+	check("info", PriorityP5)
+
+	for _, input := range []string{"0", "6", "P0", "urgent-ish"} {
+		_, err := ParsePriority(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestPriorityFromLabels(t *testing.T) {
+	assert.Equal(t, PriorityNone, PriorityFromLabels(nil))
+	assert.Equal(t, PriorityP1, PriorityFromLabels(map[string]string{"severity": "critical"}))
+	assert.Equal(t, PriorityP4, PriorityFromLabels(map[string]string{"severity": "critical", "priority": "P4"}))
+	assert.Equal(t, PriorityP3, PriorityFromLabels(map[string]string{"severity": "warning", "priority": "bogus"}))
+}
+
+func TestPriority_String(t *testing.T) {
+	assert.Equal(t, "", PriorityNone.String())
+	assert.Equal(t, "P2", PriorityP2.String())
+}
@@ -1,7 +1,8 @@
 -- name: Alert_LockOneAlertService :one
 -- Locks the service associated with the alert.
 SELECT
-    maintenance_expires_at NOTNULL::bool AS is_maint_mode,
+    (maintenance_expires_at NOTNULL
+        OR svc_maint_window_active(svc.id))::bool AS is_maint_mode,
     alerts.status
 FROM
     services svc
@@ -158,3 +159,166 @@ FROM
 WHERE
     a.id = @id::bigint;
 
+
+-- name: Alert_ServiceMaintWindowReason :one
+-- Returns the reason of an active maintenance window that applies to the service, if any.
+SELECT
+    w.reason
+FROM
+    service_maintenance_windows w
+WHERE
+    w.active_since NOTNULL
+    AND (w.service_id = @service_id
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                labels l
+            WHERE
+                l.tgt_service_id = @service_id
+                AND l.key = w.label_key
+                AND (w.label_value = ''
+                    OR l.value = w.label_value)))
+ORDER BY
+    w.active_since
+LIMIT 1;
+
+-- name: Alert_ReopenRecent :one
+-- Re-opens the most recently closed alert with the given dedup key, if it was closed within the reopen window of the service and no open alert with the same key exists.
+UPDATE
+    alerts a
+SET
+    status = 'triggered',
+    dedup_key = h.dedup_key,
+    reopened_at = now(),
+    escalation_level = 0,
+    last_escalation = now()
+FROM
+    alert_dedup_history h
+    JOIN services svc ON svc.id = h.service_id
+WHERE
+    h.service_id = @service_id
+    AND h.dedup_key = @dedup_key::text
+    AND a.id = h.alert_id
+    AND a.status = 'closed'
+    AND h.closed_at > now() - '1 minute'::interval * svc.reopen_window_minutes
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            alerts o
+        WHERE
+            o.service_id = @service_id
+            AND o.dedup_key = @dedup_key::text)
+RETURNING
+    a.id,
+    a.summary,
+    a.details,
+    a.source,
+    a.created_at,
+    a.priority;
+
+-- name: Alert_FlapState :one
+-- Returns the flap window of the alert's service, the number of times the alert's dedup key was closed within it, and if the alert is already flapping.
+-- No rows are returned unless the number of cycles has reached the flap threshold of the service.
+SELECT
+    svc.flap_window_minutes,
+    (
+        SELECT
+            count(*)
+        FROM
+            unnest(h.close_times) t
+        WHERE
+            t > now() - '1 minute'::interval * svc.flap_window_minutes)::int AS cycles,
+    coalesce(a.flapping_until > now(), FALSE)::bool AS is_flapping
+FROM
+    alerts a
+    JOIN services svc ON svc.id = a.service_id
+    JOIN alert_dedup_history h ON h.service_id = a.service_id
+        AND h.dedup_key = a.dedup_key
+WHERE
+    a.id = @id::bigint
+    AND svc.flap_threshold > 0
+    AND svc.flap_window_minutes > 0
+    AND (
+        SELECT
+            count(*)
+        FROM
+            unnest(h.close_times) t
+        WHERE
+            t > now() - '1 minute'::interval * svc.flap_window_minutes) >= svc.flap_threshold;
+
+-- name: Alert_SetFlapping :exec
+-- Marks an alert as flapping until the flap window has passed without another open/close cycle.
+UPDATE
+    alerts
+SET
+    flapping_until = now() + '1 minute'::interval * @window_minutes::int
+WHERE
+    id = @id::bigint;
+
+-- name: Alert_ShadowAckAlerts :many
+-- Returns the alerts whose current escalation step has the user on call only as a shadow participant.
+SELECT
+    state.alert_id
+FROM
+    escalation_policy_state state
+    JOIN ep_step_on_call_users oc ON oc.ep_step_id = state.escalation_policy_step_id
+        AND oc.user_id = @user_id::uuid
+        AND oc.end_time ISNULL
+        AND oc.is_shadow
+    JOIN alerts a ON a.id = state.alert_id
+        AND a.status = 'triggered'
+WHERE
+    state.alert_id = ANY (@alert_ids::bigint[]);
+
+-- name: Alert_RecordAcks :many
+-- Records an acknowledgement from a user for alerts whose current escalation step requires multiple,
+-- returning the number of acknowledgements (including this one) and the number required.
+WITH steps AS (
+    SELECT
+        state.alert_id,
+        step.ack_count
+    FROM
+        escalation_policy_state state
+        JOIN escalation_policy_steps step ON step.id = state.escalation_policy_step_id
+            AND step.mode = 'ack_count'
+        JOIN alerts a ON a.id = state.alert_id
+            AND a.status = 'triggered'
+    WHERE
+        state.alert_id = ANY (@alert_ids::bigint[])
+),
+inserted AS (
+INSERT INTO alert_acks(alert_id, user_id)
+    SELECT
+        alert_id,
+        @user_id::uuid
+    FROM
+        steps
+    ON CONFLICT
+        DO NOTHING
+    RETURNING
+        alert_id
+)
+SELECT
+    steps.alert_id,
+    steps.ack_count::int AS required,
+((
+        SELECT
+            count(*)
+        FROM alert_acks ack
+        WHERE
+            ack.alert_id = steps.alert_id) +(
+        SELECT
+            count(*)
+        FROM inserted i
+        WHERE
+            i.alert_id = steps.alert_id))::int AS acks
+FROM
+    steps;
+
+-- name: Alert_StopUserCycles :exec
+-- Stops notifying a user about the given alerts.
+DELETE FROM notification_policy_cycles
+WHERE alert_id = ANY (@alert_ids::bigint[])
+    AND user_id = @user_id::uuid;
@@ -0,0 +1,78 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/gadb"
+)
+
+// reopenTx will re-open a recently closed alert with the same dedup key, if the service has a reopen window configured.
+// It returns false if no alert was re-opened.
+func (s *Store) reopenTx(ctx context.Context, tx *sql.Tx, n *Alert) (bool, error) {
+	row, err := gadb.New(tx).Alert_ReopenRecent(ctx, gadb.Alert_ReopenRecentParams{
+		ServiceID: uuid.MustParse(n.ServiceID),
+		DedupKey:  n.DedupKey().String(),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("reopen alert: %w", err)
+	}
+
+	n.ID = int(row.ID)
+	n.Summary = row.Summary
+	n.Details = row.Details
+	n.Source = Source(row.Source)
+	n.CreatedAt = row.CreatedAt
+	n.Priority = Priority(row.Priority)
+	n.Status = StatusTriggered
+
+	return true, nil
+}
+
+// logOpenedTx will record the creation or re-opening of an alert, and check if it is flapping.
+//
+// All paths that open an alert must use it, so flap detection applies regardless of how the alert was created.
+func (s *Store) logOpenedTx(ctx context.Context, tx *sql.Tx, alertID int, t alertlog.Type, meta interface{}) error {
+	s.logDB.MustLogTx(ctx, tx, alertID, t, meta)
+
+	return s.checkFlappingTx(ctx, tx, alertID)
+}
+
+// checkFlappingTx will mark a newly opened alert as flapping if its dedup key has been closed at least
+// as many times as the flap threshold of the service, within the flap window.
+//
+// While flapping, escalation and notifications for the alert are held.
+func (s *Store) checkFlappingTx(ctx context.Context, tx *sql.Tx, alertID int) error {
+	db := gadb.New(tx)
+	state, err := db.Alert_FlapState(ctx, int64(alertID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("check flap state: %w", err)
+	}
+
+	err = db.Alert_SetFlapping(ctx, gadb.Alert_SetFlappingParams{
+		ID:            int64(alertID),
+		WindowMinutes: state.FlapWindowMinutes,
+	})
+	if err != nil {
+		return fmt.Errorf("set flapping: %w", err)
+	}
+	if state.IsFlapping {
+		// only log the start of flapping
+		return nil
+	}
+
+	return s.logDB.LogTx(ctx, tx, alertID, alertlog.TypeFlapping, &alertlog.FlappingMetaData{
+		Cycles:        int(state.Cycles),
+		WindowMinutes: int(state.FlapWindowMinutes),
+	})
+}
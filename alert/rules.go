@@ -0,0 +1,94 @@
+package alert
+
+import (
+	"context"
+	"maps"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/alert/alertrule"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/util/log"
+)
+
+// applyRules will evaluate the alert rules of the service against a normalized alert, returning the
+// resulting alert and metadata. If a rule drops the alert, nil is returned.
+//
+// Rules that fail to evaluate are logged and ignored so that a bad expression never prevents an alert
+// from being created.
+func applyRules(ctx context.Context, db gadb.DBTX, a *Alert, meta map[string]string) (*Alert, map[string]string, error) {
+	rules, err := alertrule.Load(ctx, db, uuid.MustParse(a.ServiceID))
+	if err != nil {
+		return nil, nil, err
+	}
+	if rules == nil {
+		return a, meta, nil
+	}
+
+	in := alertrule.Input{
+		ServiceID: a.ServiceID,
+		Source:    string(a.Source),
+		Status:    string(a.Status),
+		Summary:   a.Summary,
+		Details:   a.Details,
+		Priority:  a.Priority.String(),
+		Meta:      meta,
+	}
+	if a.Dedup != nil && a.Dedup.Type == DedupTypeUser {
+		in.Dedup = a.Dedup.Payload
+	}
+
+	ctx = log.WithField(ctx, "ServiceID", a.ServiceID)
+	res, err := rules.Run(in)
+	if err != nil {
+		log.Log(ctx, err)
+		return a, meta, nil
+	}
+	if len(res.Matched) == 0 {
+		return a, meta, nil
+	}
+	ctx = log.WithField(ctx, "Rules", res.Matched)
+	if res.Drop {
+		log.Logf(ctx, "Alert dropped by service rule.")
+		metricDroppedTotal.WithLabelValues(a.ServiceID).Inc()
+		return nil, nil, nil
+	}
+
+	n := *a
+	for k, v := range res.Set {
+		switch k {
+		case alertrule.FieldSummary:
+			n.Summary = v
+		case alertrule.FieldDetails:
+			n.Details = v
+		case alertrule.FieldDedup:
+			n.Dedup = NewUserDedup(v)
+		case alertrule.FieldPriority:
+			n.Priority, err = ParsePriority(v)
+			if err != nil {
+				log.Log(ctx, err)
+				return a, meta, nil
+			}
+		}
+	}
+
+	if m := res.Meta(); m != nil {
+		newMeta := make(map[string]string, len(meta)+len(m))
+		maps.Copy(newMeta, meta)
+		maps.Copy(newMeta, m)
+		err = ValidateMetadata(newMeta)
+		if err != nil {
+			log.Log(ctx, err)
+			return a, meta, nil
+		}
+		meta = newMeta
+	}
+
+	result, err := n.Normalize()
+	if err != nil {
+		log.Log(ctx, err)
+		return a, meta, nil
+	}
+	log.Debugf(ctx, "Alert modified by service rules.")
+
+	return result, meta, nil
+}
@@ -38,6 +38,9 @@ type SearchOptions struct {
 	// Status, if specified, will restrict alerts to those with a matching status.
 	Status []Status `json:"t,omitempty"`
 
+	// Priority, if specified, will restrict alerts to those with a matching priority.
+	Priority []Priority `json:"p,omitempty"`
+
 	// ServiceFilter, if specified, will restrict alerts to those with a matching ServiceID on IDs, if valid.
 	ServiceFilter IDFilter `json:"v,omitempty"`
 
@@ -99,7 +102,10 @@ var searchTemplate = template.Must(template.New("alert-search").Funcs(search.Hel
 		a.source,
 		a.status,
 		created_at,
-		a.dedup_key
+		a.dedup_key,
+		a.priority,
+		a.snoozed_until,
+		a.flapping_until
 	FROM alerts a
 	WHERE true
 	{{ if .Omit }}
@@ -113,6 +119,9 @@ var searchTemplate = template.Must(template.New("alert-search").Funcs(search.Hel
 	{{ if .Status }}
 		AND a.status = any(:status::enum_alert_status[])
 	{{ end }}
+	{{ if .Priority }}
+		AND a.priority = any(:priority)
+	{{ end }}
 	{{ if .ServiceFilter.Valid }}
 		AND (a.service_id = any(:services)
 			{{ if .NotifiedUserID }}
@@ -173,6 +182,7 @@ func (opts renderData) Normalize() (*renderData, error) {
 		validate.Search("Search", opts.Search),
 		validate.Range("Limit", opts.Limit, 0, 1001),
 		validate.Range("Status", len(opts.Status), 0, 3),
+		validate.Range("Priority", len(opts.Priority), 0, int(PriorityP5)+1),
 		validate.ManyUUID("Services", opts.ServiceFilter.IDs, 50),
 		validate.Range("Omit", len(opts.Omit), 0, 50),
 		validate.OneOf("Sort", opts.Sort, SortModeStatusID, SortModeDateID, SortModeDateIDReverse),
@@ -191,6 +201,13 @@ func (opts renderData) Normalize() (*renderData, error) {
 		}
 	}
 
+	for i, p := range opts.Priority {
+		err = validate.Range("Priority["+strconv.Itoa(i)+"]", int(p), int(PriorityNone), int(PriorityP5))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &opts, err
 }
 
@@ -206,10 +223,16 @@ func (opts renderData) QueryArgs() []sql.NamedArg {
 		stat[i] = string(opts.Status[i])
 	}
 
+	prio := make(sqlutil.IntArray, len(opts.Priority))
+	for i := range opts.Priority {
+		prio[i] = int(opts.Priority[i])
+	}
+
 	return []sql.NamedArg{
 		sql.Named("search", opts.Search),
 		sql.Named("searchID", searchID),
 		sql.Named("status", stat),
+		sql.Named("priority", prio),
 		sql.Named("services", sqlutil.UUIDArray(opts.ServiceFilter.IDs)),
 		sql.Named("svcNameMatchIDs", sqlutil.UUIDArray(opts.serviceNameIDs)),
 		sql.Named("afterID", opts.After.ID),
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -37,6 +38,7 @@ type Store struct {
 
 	updateByStatusAndService *sql.Stmt
 	updateByIDAndStatus      *sql.Stmt
+	snoozeByID               *sql.Stmt
 
 	escalate *sql.Stmt
 	epState  *sql.Stmt
@@ -58,7 +60,7 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 		logDB: logDB,
 
 		insert: p(`
-			INSERT INTO alerts (summary, details, service_id, source, status, dedup_key) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at
+			INSERT INTO alerts (summary, details, service_id, source, status, dedup_key, priority) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created_at
 		`),
 		update: p("UPDATE alerts SET status = $2 WHERE id = $1"),
 		logs:   p("SELECT timestamp, event, message FROM alert_logs WHERE alert_id = $1"),
@@ -72,13 +74,16 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 				a.source,
 				a.status,
 				created_at,
-				a.dedup_key
+				a.dedup_key,
+				a.priority,
+				a.snoozed_until,
+				a.flapping_until
 			FROM alerts a
 			WHERE a.id = ANY ($1)
 		`),
 		createUpdNew: p(`
 			WITH existing as (
-				SELECT id, summary, details, status, source, created_at, false
+				SELECT id, summary, details, status, source, created_at, priority, false
 				FROM alerts
 				WHERE service_id = $3 AND dedup_key = $5
 			), to_insert as (
@@ -88,11 +93,11 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 				FROM existing
 			), inserted as (
 				INSERT INTO alerts (
-					summary, details, service_id, source, dedup_key
+					summary, details, service_id, source, dedup_key, priority
 				)
-				SELECT $1, $2, $3, $4, $5
+				SELECT $1, $2, $3, $4, $5, $6
 				FROM to_insert
-				RETURNING id, summary, details, status, source, created_at, true
+				RETURNING id, summary, details, status, source, created_at, priority, true
 			)
 			SELECT * FROM existing
 			UNION
@@ -107,7 +112,7 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 				a.service_id = $1 AND
 				a.dedup_key = $2 AND
 				a.status != 'closed'
-			RETURNING a.id, a.summary, a.details, old.status, a.created_at
+			RETURNING a.id, a.summary, a.details, old.status, a.created_at, a.priority
 		`),
 		createUpdClose: p(`
 			UPDATE alerts a
@@ -116,7 +121,7 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 				service_id = $1 and
 				dedup_key = $2 and
 				status != 'closed'
-			RETURNING id, summary, details, created_at
+			RETURNING id, summary, details, created_at, priority
 		`),
 
 		getServiceID: p("SELECT service_id FROM alerts WHERE id = $1"),
@@ -140,6 +145,17 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 			RETURNING id
 		`),
 
+		snoozeByID: p(`
+			UPDATE alerts
+			SET
+				status = 'active',
+				snoozed_until = now() + make_interval(mins => $2)
+			WHERE
+				id = ANY ($1) AND
+				status != 'closed'
+			RETURNING id
+		`),
+
 		escalate: p(`
 			UPDATE escalation_policy_state state
 			SET force_escalation = true
@@ -149,7 +165,8 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 				state.force_escalation = false AND
 				a.id = state.alert_id AND
 				svc.id = a.service_id AND
-				svc.maintenance_expires_at ISNULL
+				svc.maintenance_expires_at ISNULL AND
+				NOT svc_maint_window_active(svc.id)
 			RETURNING state.alert_id
 		`),
 
@@ -389,7 +406,103 @@ func (s *Store) UpdateStatusByService(ctx context.Context, serviceID string, sta
 	return nil
 }
 
-func (s *Store) UpdateManyAlertStatus(ctx context.Context, status Status, alertIDs []int, logMeta interface{}) ([]int, error) {
+func (s *Store) UpdateManyAlertStatus(ctx context.Context, status Status, alertIDs []int, logMeta interface{}) (updatedIDs, heldIDs []int, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer sqlutil.Rollback(ctx, "alert: update status", tx)
+
+	updatedIDs, heldIDs, err = s.UpdateManyAlertStatusTx(ctx, tx, status, alertIDs, logMeta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return updatedIDs, heldIDs, nil
+}
+
+// UpdateManyAlertStatusTx is the same as UpdateManyAlertStatus, but uses the provided transaction.
+//
+// The returned heldIDs are alerts where the acknowledgement was recorded, but that require acknowledgement
+// from more users and remain triggered. They are not included in updatedIDs.
+func (s *Store) UpdateManyAlertStatusTx(ctx context.Context, tx *sql.Tx, status Status, alertIDs []int, logMeta interface{}) (updatedIDs, heldIDs []int, err error) {
+	err = permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(alertIDs) == 0 {
+		return nil, nil, nil
+	}
+
+	err = validate.Many(
+		validate.Range("AlertIDs", len(alertIDs), 1, maxBatch),
+		validate.OneOf("Status", status, StatusActive, StatusClosed),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]int64, len(alertIDs))
+	for i, id := range alertIDs {
+		ids[i] = int64(id)
+	}
+
+	t := alertlog.TypeAcknowledged
+	if status == StatusClosed {
+		t = alertlog.TypeClosed
+	}
+
+	err = gadb.New(tx).Alert_LockManyAlertServices(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if status == StatusActive {
+		held, err := s.recordAcksTx(ctx, tx, ids)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids = slices.DeleteFunc(ids, func(id int64) bool { return held[id] })
+		for id := range held {
+			heldIDs = append(heldIDs, int(id))
+		}
+		slices.Sort(heldIDs)
+	}
+
+	rows, err := tx.StmtContext(ctx, s.updateByIDAndStatus).QueryContext(ctx, status, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, nil, err
+		}
+		updatedIDs = append(updatedIDs, id)
+	}
+
+	// Logging Batch Updates for every alertID whose status was updated
+	err = s.logDB.LogManyTx(ctx, tx, updatedIDs, t, logMeta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return updatedIDs, heldIDs, nil
+}
+
+// SnoozeManyAlerts will acknowledge the given alerts for the provided duration, after which they
+// will be re-triggered and escalation will restart from the first step. Snoozing an already-snoozed
+// alert will replace the existing snooze.
+func (s *Store) SnoozeManyAlerts(ctx context.Context, alertIDs []int, dur time.Duration) ([]int, error) {
 	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
 	if err != nil {
 		return nil, err
@@ -399,9 +512,10 @@ func (s *Store) UpdateManyAlertStatus(ctx context.Context, status Status, alertI
 		return nil, nil
 	}
 
+	mins := int(dur / time.Minute)
 	err = validate.Many(
 		validate.Range("AlertIDs", len(alertIDs), 1, maxBatch),
-		validate.OneOf("Status", status, StatusActive, StatusClosed),
+		validate.Range("DurationMinutes", mins, 1, MaxSnoozeMinutes),
 	)
 	if err != nil {
 		return nil, err
@@ -416,26 +530,20 @@ func (s *Store) UpdateManyAlertStatus(ctx context.Context, status Status, alertI
 	if err != nil {
 		return nil, err
 	}
-	defer sqlutil.Rollback(ctx, "alert: update status", tx)
-
-	t := alertlog.TypeAcknowledged
-	if status == StatusClosed {
-		t = alertlog.TypeClosed
-	}
-
-	var updatedIDs []int
+	defer sqlutil.Rollback(ctx, "alert: snooze", tx)
 
 	err = gadb.New(tx).Alert_LockManyAlertServices(ctx, ids)
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := tx.StmtContext(ctx, s.updateByIDAndStatus).QueryContext(ctx, status, ids)
+	rows, err := tx.StmtContext(ctx, s.snoozeByID).QueryContext(ctx, ids, mins)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	var updatedIDs []int
 	for rows.Next() {
 		var id int
 		err = rows.Scan(&id)
@@ -445,8 +553,7 @@ func (s *Store) UpdateManyAlertStatus(ctx context.Context, status Status, alertI
 		updatedIDs = append(updatedIDs, id)
 	}
 
-	// Logging Batch Updates for every alertID whose status was updated
-	err = s.logDB.LogManyTx(ctx, tx, updatedIDs, t, logMeta)
+	err = s.logDB.LogManyTx(ctx, tx, updatedIDs, alertlog.TypeSnoozed, &alertlog.SnoozeMetaData{DurationMinutes: mins})
 	if err != nil {
 		return nil, err
 	}
@@ -459,7 +566,30 @@ func (s *Store) UpdateManyAlertStatus(ctx context.Context, status Status, alertI
 	return updatedIDs, nil
 }
 
+// SnoozeAlert will snooze a single alert for the provided duration. An error is returned if the alert is already closed.
+func (s *Store) SnoozeAlert(ctx context.Context, id int, dur time.Duration) error {
+	ids, err := s.SnoozeManyAlerts(ctx, []int{id}, dur)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return logError{isAlreadyClosed: true, alertID: id, _type: alertlog.TypeClosed, logDB: s.logDB}
+	}
+
+	return nil
+}
+
+// CreateTx will create a new alert. If the alert is dropped by a service alert rule, nil is returned.
 func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, a *Alert) (*Alert, error) {
+	return s.createTx(ctx, tx, a, nil)
+}
+
+// CreateWithMetaTx behaves the same as CreateTx, but also sets metadata on the new alert.
+func (s *Store) CreateWithMetaTx(ctx context.Context, tx *sql.Tx, a *Alert, meta map[string]string) (*Alert, error) {
+	return s.createTx(ctx, tx, a, meta)
+}
+
+func (s *Store) createTx(ctx context.Context, tx *sql.Tx, a *Alert, meta map[string]string) (*Alert, error) {
 	n, err := a.Normalize() // validation
 	if err != nil {
 		return nil, err
@@ -483,12 +613,32 @@ func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, a *Alert) (*Alert, err
 		return nil, err
 	}
 
-	n, meta, err := s._create(ctx, tx, *n)
+	n, meta, err = applyRules(ctx, tx, n, meta)
+	if err != nil {
+		return nil, err
+	}
+	if n == nil {
+		return nil, nil
+	}
+	if n.Status == StatusClosed {
+		return nil, validation.NewFieldError("Status", "Cannot create a closed alert.")
+	}
+
+	n, logMeta, err := s._create(ctx, tx, *n)
 	if err != nil {
 		return nil, err
 	}
+	if meta != nil {
+		err = s.setMetadataTx(ctx, tx, n.ID, meta)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	s.logDB.MustLogTx(ctx, tx, n.ID, alertlog.TypeCreated, meta)
+	err = s.logOpenedTx(ctx, tx, n.ID, alertlog.TypeCreated, logMeta)
+	if err != nil {
+		return nil, err
+	}
 
 	ctx = log.WithFields(ctx, log.Fields{"AlertID": n.ID, "ServiceID": n.ServiceID})
 	log.Logf(ctx, "Alert created.")
@@ -500,7 +650,7 @@ func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, a *Alert) (*Alert, err
 func (s *Store) _create(ctx context.Context, tx *sql.Tx, a Alert) (*Alert, *alertlog.CreatedMetaData, error) {
 	var meta alertlog.CreatedMetaData
 
-	row := tx.StmtContext(ctx, s.insert).QueryRowContext(ctx, a.Summary, a.Details, a.ServiceID, a.Source, a.Status, a.DedupKey())
+	row := tx.StmtContext(ctx, s.insert).QueryRowContext(ctx, a.Summary, a.Details, a.ServiceID, a.Source, a.Status, a.DedupKey(), a.Priority)
 	err := row.Scan(&a.ID, &a.CreatedAt)
 	if err != nil {
 		return nil, nil, err
@@ -511,12 +661,40 @@ func (s *Store) _create(ctx context.Context, tx *sql.Tx, a Alert) (*Alert, *aler
 		return nil, nil, err
 	}
 	meta.EPNoSteps = !hasSteps
+	err = maintMeta(ctx, tx, a.ServiceID, &meta)
+	if err != nil {
+		return nil, nil, err
+	}
 	return &a, &meta, nil
 }
 
+// maintMeta will record if escalation is being held by an active maintenance window for the service.
+func maintMeta(ctx context.Context, tx *sql.Tx, serviceID string, meta *alertlog.CreatedMetaData) error {
+	reason, err := gadb.New(tx).Alert_ServiceMaintWindowReason(ctx, uuid.NullUUID{UUID: uuid.MustParse(serviceID), Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("check maintenance windows: %w", err)
+	}
+
+	meta.MaintenanceHeld = true
+	meta.MaintenanceReason = reason
+	return nil
+}
+
 // CreateOrUpdateTx returns `isNew` to indicate if the returned alert was a new one.
 // It is the caller's responsibility to log alert creation if the transaction is committed (and isNew is true).
+//
+// If the service has a reopen window, a recently closed alert with the same dedup key is re-opened instead of
+// creating a new one; in that case isNew is false.
+//
+// If the alert is dropped by a service alert rule, nil is returned.
 func (s *Store) CreateOrUpdateTx(ctx context.Context, tx *sql.Tx, a *Alert) (*Alert, bool, error) {
+	return s.createOrUpdateTx(ctx, tx, a, nil)
+}
+
+func (s *Store) createOrUpdateTx(ctx context.Context, tx *sql.Tx, a *Alert, meta map[string]string) (*Alert, bool, error) {
 	err := permission.LimitCheckAny(ctx,
 		permission.System,
 		permission.Admin,
@@ -547,15 +725,31 @@ func (s *Store) CreateOrUpdateTx(ctx context.Context, tx *sql.Tx, a *Alert) (*Al
 		return nil, false, err
 	}
 
-	var inserted bool
+	n, meta, err = applyRules(ctx, tx, n, meta)
+	if err != nil {
+		return nil, false, err
+	}
+	if n == nil {
+		return nil, false, nil
+	}
+
+	var inserted, reopened bool
 	var logType alertlog.Type
-	var meta interface{}
-	switch n.Status {
-	case StatusTriggered:
+	var logMeta interface{}
+	if n.Status == StatusTriggered {
+		reopened, err = s.reopenTx(ctx, tx, n)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	switch {
+	case reopened:
+		logType = alertlog.TypeReopened
+	case n.Status == StatusTriggered:
 		var m alertlog.CreatedMetaData
 		err = tx.Stmt(s.createUpdNew).
-			QueryRowContext(ctx, n.Summary, n.Details, n.ServiceID, n.Source, n.DedupKey()).
-			Scan(&n.ID, &n.Summary, &n.Details, &n.Status, &n.Source, &n.CreatedAt, &inserted)
+			QueryRowContext(ctx, n.Summary, n.Details, n.ServiceID, n.Source, n.DedupKey(), n.Priority).
+			Scan(&n.ID, &n.Summary, &n.Details, &n.Status, &n.Source, &n.CreatedAt, &n.Priority, &inserted)
 		if !inserted {
 			logType = alertlog.TypeDuplicateSupressed
 		} else {
@@ -565,20 +759,24 @@ func (s *Store) CreateOrUpdateTx(ctx context.Context, tx *sql.Tx, a *Alert) (*Al
 				return nil, false, err
 			}
 			m.EPNoSteps = !hasSteps
+			err = maintMeta(ctx, tx, n.ServiceID, &m)
+			if err != nil {
+				return nil, false, err
+			}
 		}
-		meta = &m
-	case StatusActive:
+		logMeta = &m
+	case n.Status == StatusActive:
 		var oldStatus Status
 		err = tx.Stmt(s.createUpdAck).
 			QueryRowContext(ctx, n.ServiceID, n.DedupKey()).
-			Scan(&n.ID, &n.Summary, &n.Details, &oldStatus, &n.CreatedAt)
+			Scan(&n.ID, &n.Summary, &n.Details, &oldStatus, &n.CreatedAt, &n.Priority)
 		if oldStatus != n.Status {
 			logType = alertlog.TypeAcknowledged
 		}
-	case StatusClosed:
+	case n.Status == StatusClosed:
 		err = tx.Stmt(s.createUpdClose).
 			QueryRowContext(ctx, n.ServiceID, n.DedupKey()).
-			Scan(&n.ID, &n.Summary, &n.Details, &n.CreatedAt)
+			Scan(&n.ID, &n.Summary, &n.Details, &n.CreatedAt, &n.Priority)
 		logType = alertlog.TypeClosed
 	}
 	if errors.Is(err, sql.ErrNoRows) {
@@ -588,8 +786,20 @@ func (s *Store) CreateOrUpdateTx(ctx context.Context, tx *sql.Tx, a *Alert) (*Al
 	if err != nil {
 		return nil, false, err
 	}
-	if logType != "" {
-		s.logDB.MustLogTx(ctx, tx, n.ID, logType, meta)
+	switch {
+	case inserted || reopened:
+		err = s.logOpenedTx(ctx, tx, n.ID, logType, logMeta)
+		if err != nil {
+			return nil, false, err
+		}
+	case logType != "":
+		s.logDB.MustLogTx(ctx, tx, n.ID, logType, logMeta)
+	}
+	if meta != nil && inserted {
+		err = s.setMetadataTx(ctx, tx, n.ID, meta)
+		if err != nil {
+			return nil, false, err
+		}
 	}
 
 	return n, inserted, nil
@@ -626,19 +836,11 @@ func (s *Store) createOrUpdate(ctx context.Context, a *Alert, meta map[string]st
 	}
 	defer sqlutil.Rollback(ctx, "alert: upsert", tx)
 
-	n, isNew, err := s.CreateOrUpdateTx(ctx, tx, a)
+	n, isNew, err := s.createOrUpdateTx(ctx, tx, a, meta)
 	if err != nil {
 		return nil, false, err
 	}
 
-	// Set metadata only if meta is not nil and isNew is true
-	if meta != nil && isNew {
-		err = s.SetMetadataTx(ctx, tx, n.ID, meta)
-		if err != nil {
-			return nil, false, err
-		}
-	}
-
 	err = tx.Commit()
 	if err != nil {
 		return nil, false, err
@@ -668,6 +870,15 @@ func (s *Store) UpdateStatusTx(ctx context.Context, tx *sql.Tx, id int, stat Sta
 	if _stat == gadb.EnumAlertStatusActive && stat == StatusActive {
 		return logError{isAlreadyAcknowledged: true, alertID: id, _type: alertlog.TypeAcknowledged, logDB: s.logDB}
 	}
+	if _stat == gadb.EnumAlertStatusTriggered && stat == StatusActive {
+		held, err := s.recordAcksTx(ctx, tx, []int64{int64(id)})
+		if err != nil {
+			return err
+		}
+		if held[int64(id)] {
+			return logError{isAckPending: true, alertID: id, _type: alertlog.TypeAckRecorded, logDB: s.logDB}
+		}
+	}
 
 	_, err = tx.Stmt(s.update).ExecContext(ctx, id, stat)
 	if err != nil {
@@ -701,6 +912,14 @@ func (s *Store) UpdateStatus(ctx context.Context, id int, stat Status) error {
 	defer sqlutil.Rollback(ctx, "alert: update status", tx)
 
 	err = s.UpdateStatusTx(ctx, tx, id, stat)
+	if IsAckPending(err) {
+		// the acknowledgement is recorded, even though the alert remains triggered
+		cErr := tx.Commit()
+		if cErr != nil {
+			return cErr
+		}
+		return err
+	}
 	if err != nil {
 		return err
 	}
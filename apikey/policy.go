@@ -14,4 +14,7 @@ type GQLPolicy struct {
 	Version int
 	Query   string
 	Role    permission.Role
+
+	// REST indicates the key may also be used with the REST API.
+	REST bool `json:",omitempty"`
 }
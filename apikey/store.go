@@ -59,6 +59,7 @@ type APIKeyInfo struct {
 	UpdatedBy   *uuid.UUID
 	Query       string
 	Role        permission.Role
+	REST        bool
 }
 
 func (s *Store) FindAllAdminGraphQLKeys(ctx context.Context) ([]APIKeyInfo, error) {
@@ -112,6 +113,7 @@ func (s *Store) FindAllAdminGraphQLKeys(ctx context.Context) ([]APIKeyInfo, erro
 			UpdatedBy:   &k.UpdatedBy.UUID,
 			Query:       p.Query,
 			Role:        p.Role,
+			REST:        p.REST,
 		})
 	}
 
@@ -189,6 +191,15 @@ func (s *Store) DeleteAdminGraphQLKey(ctx context.Context, id uuid.UUID) error {
 }
 
 func (s *Store) AuthorizeGraphQL(ctx context.Context, tok, ua, ip string) (context.Context, error) {
+	return s.authorize(ctx, tok, ua, ip, false)
+}
+
+// AuthorizeREST will authorize a request to the REST API. Only keys created with REST access are allowed.
+func (s *Store) AuthorizeREST(ctx context.Context, tok, ua, ip string) (context.Context, error) {
+	return s.authorize(ctx, tok, ua, ip, true)
+}
+
+func (s *Store) authorize(ctx context.Context, tok, ua, ip string, rest bool) (context.Context, error) {
 	var claims Claims
 	_, err := s.key.VerifyJWT(tok, &claims, Issuer, Audience)
 	if err != nil {
@@ -216,6 +227,9 @@ func (s *Store) AuthorizeGraphQL(ctx context.Context, tok, ua, ip string) (conte
 		log.Log(ctx, fmt.Errorf("apikey: policy hash mismatch for key %s", id))
 		return ctx, permission.Unauthorized()
 	}
+	if rest && !info.Policy.REST {
+		return ctx, permission.Unauthorized()
+	}
 
 	err = s.lastUsedCache.RecordUsage(ctx, id, ua, ip)
 	if err != nil {
@@ -240,6 +254,9 @@ type NewAdminGQLKeyOpts struct {
 	Expires time.Time
 	Role    permission.Role
 	Query   string
+
+	// REST allows the key to be used with the REST API. If set, Query may be empty to disallow GraphQL use.
+	REST bool
 }
 
 // CreateAdminGraphQLKey will create a new GraphQL API key returning the ID and token.
@@ -249,7 +266,10 @@ func (s *Store) CreateAdminGraphQLKey(ctx context.Context, opt NewAdminGQLKeyOpt
 		return uuid.Nil, "", err
 	}
 
-	_, qErr := graphql2.QueryFields(opt.Query)
+	var qErr error
+	if opt.Query != "" || !opt.REST {
+		_, qErr = graphql2.QueryFields(opt.Query)
+	}
 	err = validate.Many(
 		qErr,
 		validate.IDName("Name", opt.Name),
@@ -267,6 +287,7 @@ func (s *Store) CreateAdminGraphQLKey(ctx context.Context, opt NewAdminGQLKeyOpt
 		Version: 1,
 		Query:   opt.Query,
 		Role:    opt.Role,
+		REST:    opt.REST,
 	})
 	if err != nil {
 		return uuid.Nil, "", err
@@ -17,32 +17,42 @@ import (
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/alert/alertmetrics"
+	"github.com/target/goalert/alert/alertrule"
 	"github.com/target/goalert/apikey"
 	"github.com/target/goalert/app/lifecycle"
 	"github.com/target/goalert/auth"
 	"github.com/target/goalert/auth/authlink"
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/auth/nonce"
+	"github.com/target/goalert/availability"
 	"github.com/target/goalert/calsub"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/engine"
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/graphql2/graphqlapp"
 	"github.com/target/goalert/heartbeat"
+	"github.com/target/goalert/holiday"
+	"github.com/target/goalert/incident"
 	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/integrationkey/uik"
 	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
+	"github.com/target/goalert/maintenance"
 	"github.com/target/goalert/notice"
 	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/msteams"
 	"github.com/target/goalert/notification/nfydest"
 	"github.com/target/goalert/notification/slack"
+	"github.com/target/goalert/notification/smpp"
 	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/notification/webhook"
+	"github.com/target/goalert/notification/webpush"
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/quiethours"
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
@@ -56,6 +66,7 @@ import (
 	"github.com/target/goalert/util/calllimiter"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/workload"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"riverqueue.com/riverui"
@@ -96,7 +107,12 @@ type App struct {
 	twilioVoice  *twilio.Voice
 	twilioConfig *twilio.Config
 
+	smpp *smpp.Sender
+
 	slackChan *slack.ChannelSender
+	msteams   *msteams.Sender
+	webhook   *webhook.Sender
+	webpush   *webpush.Sender
 
 	ConfigStore *config.Store
 
@@ -124,21 +140,31 @@ type App struct {
 	OverrideStore  *override.Store
 	LimitStore     *limit.Store
 	HeartbeatStore *heartbeat.Store
+	IncidentStore  *incident.Store
+	MaintStore     *maintenance.Store
+	AlertRuleStore *alertrule.Store
 
 	OAuthKeyring    keyring.Keyring
 	SessionKeyring  keyring.Keyring
 	APIKeyring      keyring.Keyring
 	AuthLinkKeyring keyring.Keyring
 
-	NonceStore    *nonce.Store
-	LabelStore    *label.Store
-	OnCallStore   *oncall.Store
-	NCStore       *notificationchannel.Store
-	TimeZoneStore *timezone.Store
-	NoticeStore   *notice.Store
-	AuthLinkStore *authlink.Store
-	APIKeyStore   *apikey.Store
-	River         *river.Client[pgx.Tx]
+	WebhookActionKeyring keyring.Keyring
+	WebPushKeyring       *keyring.DB
+
+	NonceStore      *nonce.Store
+	LabelStore      *label.Store
+	OnCallStore     *oncall.Store
+	NCStore         *notificationchannel.Store
+	TimeZoneStore   *timezone.Store
+	NoticeStore     *notice.Store
+	AuthLinkStore   *authlink.Store
+	APIKeyStore     *apikey.Store
+	WorkloadStore   *workload.Store
+	HolidayStore    *holiday.Store
+	AvailStore      *availability.Store
+	QuietHoursStore *quiethours.Store
+	River           *river.Client[pgx.Tx]
 
 	// RiverDBSQL is a river client that uses the old sql.DB driver for use while transitioning to pgx.
 	//
@@ -749,8 +749,9 @@ func getConfig(ctx context.Context) (Config, error) {
 
 		HTTPPrefix: viper.GetString("http-prefix"),
 
-		SlackBaseURL:  viper.GetString("slack-base-url"),
-		TwilioBaseURL: viper.GetString("twilio-base-url"),
+		SlackBaseURL:   viper.GetString("slack-base-url"),
+		TwilioBaseURL:  viper.GetString("twilio-base-url"),
+		MSTeamsBaseURL: viper.GetString("msteams-base-url"),
 
 		DBURL:     viper.GetString("db-url"),
 		DBURLNext: viper.GetString("db-url-next"),
@@ -900,6 +901,7 @@ func init() {
 
 	RootCmd.Flags().String("twilio-base-url", def.TwilioBaseURL, "Override the Twilio API URL.")
 	RootCmd.Flags().String("slack-base-url", def.SlackBaseURL, "Override the Slack base URL.")
+	RootCmd.Flags().String("msteams-base-url", def.MSTeamsBaseURL, "Override the Microsoft login and Bot Framework metadata URL for Teams.")
 
 	RootCmd.Flags().String("region-name", def.RegionName, "Name of region for message processing (case sensitive). Only one instance per-region-name will process outgoing messages.")
 
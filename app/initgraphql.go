@@ -24,6 +24,10 @@ func (app *App) initGraphQL(ctx context.Context) error {
 		CalSubStore:         app.CalSubStore,
 		RotationStore:       app.RotationStore,
 		OnCallStore:         app.OnCallStore,
+		WorkloadStore:       app.WorkloadStore,
+		HolidayStore:        app.HolidayStore,
+		AvailStore:          app.AvailStore,
+		QuietHoursStore:     app.QuietHoursStore,
 		TimeZoneStore:       app.TimeZoneStore,
 		IntKeyStore:         app.IntegrationKeyStore,
 		LabelStore:          app.LabelStore,
@@ -34,6 +38,9 @@ func (app *App) initGraphQL(ctx context.Context) error {
 		NotificationStore:   app.NotificationStore,
 		SlackStore:          app.slackChan,
 		HeartbeatStore:      app.HeartbeatStore,
+		IncidentStore:       app.IncidentStore,
+		MaintStore:          app.MaintStore,
+		AlertRuleStore:      app.AlertRuleStore,
 		NoticeStore:         app.NoticeStore,
 		Twilio:              app.twilioConfig,
 		AuthHandler:         app.AuthHandler,
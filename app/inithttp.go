@@ -17,6 +17,7 @@ import (
 	"github.com/target/goalert/notification/twilio"
 	"github.com/target/goalert/permission"
 	prometheus "github.com/target/goalert/prometheusalertmanager"
+	"github.com/target/goalert/restapi"
 	"github.com/target/goalert/site24x7"
 	"github.com/target/goalert/util/errutil"
 	"github.com/target/goalert/util/log"
@@ -124,6 +125,17 @@ func (app *App) initHTTP(ctx context.Context) error {
 
 	mux.Handle("POST /api/graphql", app.graphql2.Handler())
 
+	restapi.NewHandler(restapi.Config{
+		DB:            app.db,
+		AlertStore:    app.AlertStore,
+		ServiceStore:  app.ServiceStore,
+		PolicyStore:   app.EscalationStore,
+		ScheduleStore: app.ScheduleStore,
+		RotationStore: app.RotationStore,
+		OverrideStore: app.OverrideStore,
+		UserStore:     app.UserStore,
+	}).Register(mux)
+
 	mux.HandleFunc("GET /api/v2/config", app.ConfigStore.ServeConfig)
 	mux.HandleFunc("PUT /api/v2/config", app.ConfigStore.ServeConfig)
 
@@ -153,6 +165,7 @@ func (app *App) initHTTP(ctx context.Context) error {
 	mux.HandleFunc("POST /api/v2/heartbeat/{heartbeatID}", generic.ServeHeartbeatCheck)
 	mux.HandleFunc("GET /api/v2/user-avatar/{userID}", generic.ServeUserAvatar)
 	mux.HandleFunc("GET /api/v2/calendar", app.CalSubStore.ServeICalData)
+	mux.HandleFunc("GET /api/v2/reports/workload.csv", app.WorkloadStore.ServeCSV)
 
 	mux.HandleFunc("POST /api/v2/twilio/message", app.twilioSMS.ServeMessage)
 	mux.HandleFunc("POST /api/v2/twilio/message/status", app.twilioSMS.ServeStatusCallback)
@@ -161,6 +174,15 @@ func (app *App) initHTTP(ctx context.Context) error {
 
 	mux.HandleFunc("POST /api/v2/slack/message-action", app.slackChan.ServeMessageAction)
 
+	mux.HandleFunc("POST /api/v2/msteams/messages", app.msteams.ServeMessages)
+
+	mux.HandleFunc("POST /api/v2/webhook/action", app.webhook.ServeAction)
+
+	mux.HandleFunc("GET /api/v2/webpush/sw.js", app.webpush.ServeWorker)
+	mux.HandleFunc("GET /api/v2/webpush/key", app.webpush.ServeKey)
+	mux.HandleFunc("POST /api/v2/webpush/subscription", app.webpush.ServeSubscription)
+	mux.HandleFunc("POST /api/v2/webpush/action", app.webpush.ServeAction)
+
 	middleware = append(middleware,
 		httpRewrite(app.cfg.HTTPPrefix, "/v1/graphql2", "/api/graphql"),
 		httpRedirect(app.cfg.HTTPPrefix, "/v1/graphql2/explore", "/api/graphql/explore"),
@@ -0,0 +1,20 @@
+package app
+
+import (
+	"context"
+
+	"github.com/target/goalert/notification/msteams"
+)
+
+func (app *App) initMSTeams(ctx context.Context) error {
+	var err error
+	app.msteams, err = msteams.NewSender(ctx, msteams.Config{
+		BaseURL: app.cfg.MSTeamsBaseURL,
+		Client:  app.httpClient,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package app
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/notification/smpp"
+)
+
+func (app *App) initSMPP(ctx context.Context) error {
+	var err error
+	app.smpp, err = smpp.NewSender(ctx, app.db, app.ConfigStore)
+	if err != nil {
+		return errors.Wrap(err, "init SMPP")
+	}
+
+	return nil
+}
@@ -2,24 +2,30 @@ package app
 
 import (
 	"context"
+	"crypto/elliptic"
 	"net/url"
 
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/alert/alertmetrics"
+	"github.com/target/goalert/alert/alertrule"
 	"github.com/target/goalert/apikey"
 	"github.com/target/goalert/auth/authlink"
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/auth/nonce"
+	"github.com/target/goalert/availability"
 	"github.com/target/goalert/calsub"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/heartbeat"
+	"github.com/target/goalert/holiday"
+	"github.com/target/goalert/incident"
 	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/integrationkey/uik"
 	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
+	"github.com/target/goalert/maintenance"
 	"github.com/target/goalert/notice"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notification/nfydest"
@@ -28,6 +34,7 @@ import (
 	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/quiethours"
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
@@ -37,6 +44,7 @@ import (
 	"github.com/target/goalert/user/contactmethod"
 	"github.com/target/goalert/user/favorite"
 	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/workload"
 
 	"github.com/pkg/errors"
 )
@@ -126,6 +134,31 @@ func (app *App) initStores(ctx context.Context) error {
 		return errors.Wrap(err, "init API keyring")
 	}
 
+	if app.WebhookActionKeyring == nil {
+		app.WebhookActionKeyring, err = keyring.NewDB(ctx, app.cfg.LegacyLogger, app.db, &keyring.Config{
+			Name:         "webhook-actions",
+			RotationDays: 1,
+			MaxOldKeys:   2,
+			Keys:         app.cfg.EncryptionKeys,
+		})
+	}
+	if err != nil {
+		return errors.Wrap(err, "init webhook action keyring")
+	}
+
+	if app.WebPushKeyring == nil {
+		// VAPID keys are never rotated, as existing push subscriptions are bound to the public key.
+		app.WebPushKeyring, err = keyring.NewDB(ctx, app.cfg.LegacyLogger, app.db, &keyring.Config{
+			Name:       "webpush-vapid",
+			MaxOldKeys: 1,
+			Keys:       app.cfg.EncryptionKeys,
+			Curve:      elliptic.P256(),
+		})
+	}
+	if err != nil {
+		return errors.Wrap(err, "init web push keyring")
+	}
+
 	if app.AuthLinkStore == nil {
 		app.AuthLinkStore, err = authlink.NewStore(ctx, app.db, app.AuthLinkKeyring)
 	}
@@ -265,6 +298,24 @@ func (app *App) initStores(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "init heartbeat store")
 	}
+	if app.IncidentStore == nil {
+		app.IncidentStore, err = incident.NewStore(ctx, app.db, app.AlertStore)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init incident store")
+	}
+	if app.MaintStore == nil {
+		app.MaintStore, err = maintenance.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init maintenance window store")
+	}
+	if app.AlertRuleStore == nil {
+		app.AlertRuleStore, err = alertrule.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init alert rule store")
+	}
 	if app.LabelStore == nil {
 		app.LabelStore, err = label.NewStore(ctx, app.db)
 	}
@@ -290,6 +341,34 @@ func (app *App) initStores(ctx context.Context) error {
 		return errors.Wrap(err, "init calendar subscription store")
 	}
 
+	if app.WorkloadStore == nil {
+		app.WorkloadStore, err = workload.NewStore(ctx, app.db, app.OnCallStore, app.RotationStore)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init workload store")
+	}
+
+	if app.HolidayStore == nil {
+		app.HolidayStore, err = holiday.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init holiday store")
+	}
+
+	if app.AvailStore == nil {
+		app.AvailStore, err = availability.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init availability store")
+	}
+
+	if app.QuietHoursStore == nil {
+		app.QuietHoursStore, err = quiethours.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init quiet hours store")
+	}
+
 	if app.NoticeStore == nil {
 		app.NoticeStore, err = notice.NewStore(ctx, app.db)
 	}
@@ -0,0 +1,17 @@
+package app
+
+import (
+	"context"
+
+	"github.com/target/goalert/notification/webhook"
+)
+
+func (app *App) initWebhook(ctx context.Context) error {
+	app.webhook = webhook.NewSender(ctx, webhook.Config{
+		Client:     app.httpClient,
+		Keys:       app.cfg.EncryptionKeys,
+		ActionKeys: app.WebhookActionKeyring,
+	})
+
+	return nil
+}
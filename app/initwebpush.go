@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+
+	"github.com/target/goalert/notification/webpush"
+	"github.com/target/goalert/util/calllimiter"
+	"github.com/target/goalert/util/icalutil"
+)
+
+func (app *App) initWebPush(ctx context.Context) error {
+	// subscription endpoints are provided by users, so only public addresses are allowed
+	client := icalutil.NewPublicClient()
+	client.Transport = calllimiter.RoundTripper(client.Transport)
+
+	var err error
+	app.webpush, err = webpush.NewSender(ctx, webpush.Config{
+		Client:  client,
+		Keys:    app.WebPushKeyring,
+		DB:      app.db,
+		CMStore: app.ContactMethodStore,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
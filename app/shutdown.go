@@ -59,12 +59,15 @@ func (app *App) _Shutdown(ctx context.Context) error {
 	// that would still need to process them.
 	shut(app.smtpsrv, "SMTP receiver server")
 	shut(app.srv, "HTTP server")
+	shut(app.smpp, "SMPP session")
 	shut(app.Engine, "engine")
 	shut(app.events, "event listener")
 	shut(app.SessionKeyring, "session keyring")
 	shut(app.OAuthKeyring, "oauth keyring")
 	shut(app.APIKeyring, "API keyring")
 	shut(app.AuthLinkKeyring, "auth link keyring")
+	shut(app.WebhookActionKeyring, "webhook action keyring")
+	shut(app.WebPushKeyring, "web push keyring")
 	shut(app.NonceStore, "nonce store")
 	shut(app.ConfigStore, "config store")
 
@@ -8,7 +8,6 @@ import (
 	"github.com/target/goalert/app/lifecycle"
 	"github.com/target/goalert/expflag"
 	"github.com/target/goalert/notification/email"
-	"github.com/target/goalert/notification/webhook"
 	"github.com/target/goalert/retry"
 
 	"github.com/pkg/errors"
@@ -68,8 +67,12 @@ func (app *App) startup(ctx context.Context) error {
 	// init twilio before engine
 	app.initStartup(
 		ctx, "Startup.Twilio", app.initTwilio)
+	app.initStartup(ctx, "Startup.SMPP", app.initSMPP)
 
 	app.initStartup(ctx, "Startup.Slack", app.initSlack)
+	app.initStartup(ctx, "Startup.MSTeams", app.initMSTeams)
+	app.initStartup(ctx, "Startup.Webhook", app.initWebhook)
+	app.initStartup(ctx, "Startup.WebPush", app.initWebPush)
 
 	app.initStartup(ctx, "Startup.Engine", app.initEngine)
 	app.initStartup(ctx, "Startup.Auth", app.initAuth)
@@ -86,6 +89,7 @@ func (app *App) startup(ctx context.Context) error {
 
 	app.DestRegistry.RegisterProvider(ctx, app.twilioSMS)
 	app.DestRegistry.RegisterProvider(ctx, app.twilioVoice)
+	app.DestRegistry.RegisterProvider(ctx, app.smpp)
 	app.DestRegistry.RegisterProvider(ctx, email.NewSender(ctx))
 	app.DestRegistry.RegisterProvider(ctx, app.ScheduleStore)
 	app.DestRegistry.RegisterProvider(ctx, app.UserStore)
@@ -94,7 +98,10 @@ func (app *App) startup(ctx context.Context) error {
 	app.DestRegistry.RegisterProvider(ctx, app.slackChan)
 	app.DestRegistry.RegisterProvider(ctx, app.slackChan.DMSender())
 	app.DestRegistry.RegisterProvider(ctx, app.slackChan.UserGroupSender())
-	app.DestRegistry.RegisterProvider(ctx, webhook.NewSender(ctx, app.httpClient))
+	app.DestRegistry.RegisterProvider(ctx, app.msteams)
+	app.DestRegistry.RegisterProvider(ctx, app.msteams.WebhookSender())
+	app.DestRegistry.RegisterProvider(ctx, app.webhook)
+	app.DestRegistry.RegisterProvider(ctx, app.webpush)
 	if app.cfg.StubNotifiers {
 		app.DestRegistry.StubNotifiers()
 	}
@@ -564,6 +564,15 @@ func (h *Handler) authWithToken(w http.ResponseWriter, req *http.Request, next h
 		next.ServeHTTP(w, req.WithContext(ctx))
 		return true
 	}
+	if strings.HasPrefix(req.URL.Path, "/api/rest/") && strings.HasPrefix(tokStr, "ey") {
+		ctx, err = h.cfg.APIKeyStore.AuthorizeREST(ctx, tokStr, req.UserAgent(), req.RemoteAddr)
+		if errutil.HTTPError(req.Context(), w, err) {
+			return true
+		}
+
+		next.ServeHTTP(w, req.WithContext(ctx))
+		return true
+	}
 	if req.URL.Path == "/api/v2/uik" && strings.HasPrefix(tokStr, "ey") {
 		ctx, err = h.cfg.IntKeyStore.AuthorizeUIK(ctx, tokStr)
 		if errutil.HTTPError(req.Context(), w, err) {
@@ -666,6 +675,20 @@ func (h *Handler) WrapHandler(wrapped http.Handler) http.Handler {
 			wrapped.ServeHTTP(w, req)
 			return
 		}
+		if req.URL.Path == "/api/v2/msteams/messages" {
+			// Activities from Teams are authorized by the Bot Framework
+			// JWT in the Authorization header, which is verified by the
+			// handler, so we pass it through untouched.
+			wrapped.ServeHTTP(w, req)
+			return
+		}
+		if req.URL.Path == "/api/v2/webhook/action" || req.URL.Path == "/api/v2/webpush/action" {
+			// Action URLs are authorized by the signed JWT in the `token`
+			// parameter, which is not an auth token, so we pass it through
+			// untouched.
+			wrapped.ServeHTTP(w, req)
+			return
+		}
 		if h.authWithToken(w, req, wrapped) {
 			return
 		}
@@ -0,0 +1,65 @@
+// Package availability imports out-of-office periods for users from external calendar (ICS) feeds.
+package availability
+
+import (
+	"time"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxFeedsPerUser is the maximum number of availability feeds a single user can have.
+const MaxFeedsPerUser = 5
+
+// A Feed is an external calendar that indicates when a user is out of office.
+type Feed struct {
+	ID     string
+	UserID string
+	Name   string
+	URL    string
+
+	// Keyword, if set, limits out-of-office events to those with a summary containing it (case-insensitive).
+	// Events marked with an out-of-office busy status are always included.
+	Keyword string
+
+	// TimeZone is the time zone all-day events are in.
+	TimeZone *time.Location
+
+	// SubstituteUserID, if set, will cause overrides to be created automatically, replacing the user with the
+	// substitute on each of their schedules while they are out of office.
+	SubstituteUserID string
+
+	LastSyncAt time.Time
+	LastError  string
+}
+
+// A Period is a span of time a user is out of office.
+type Period struct {
+	Summary    string
+	Start, End time.Time
+}
+
+// Normalize will validate and return a normalized copy of the Feed.
+func (f Feed) Normalize() (*Feed, error) {
+	err := validate.Many(
+		validate.UUID("UserID", f.UserID),
+		validate.IDName("Name", f.Name),
+		validate.AbsoluteURL("URL", f.URL),
+		validate.Text("Keyword", f.Keyword, 0, 255),
+	)
+	if f.SubstituteUserID != "" {
+		err = validate.Many(err, validate.UUID("SubstituteUserID", f.SubstituteUserID))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if f.TimeZone == nil {
+		return nil, validation.NewFieldError("TimeZone", "must be specified")
+	}
+	if f.SubstituteUserID == f.UserID {
+		return nil, validation.NewFieldError("SubstituteUserID", "cannot be the same as the user")
+	}
+
+	return &f, nil
+}
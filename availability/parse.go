@@ -0,0 +1,100 @@
+package availability
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/util/icalutil"
+)
+
+const (
+	// MaxFileSize is the maximum size of a feed's ICS file.
+	MaxFileSize = icalutil.MaxFileSize
+
+	// Lookahead is how far ahead of time out-of-office periods are imported.
+	Lookahead = 90 * 24 * time.Hour
+
+	// MaxPeriods is the maximum number of out-of-office periods imported from a single feed.
+	MaxPeriods = 500
+)
+
+// IsOutOfOffice returns true if the event indicates the user is out of office.
+func IsOutOfOffice(e icalutil.Event, keyword string) bool {
+	switch e.BusyStatus {
+	case "OOF":
+		return true
+	case "FREE":
+		return false
+	}
+	if keyword == "" {
+		return true
+	}
+
+	return strings.Contains(strings.ToLower(e.Summary), strings.ToLower(keyword))
+}
+
+// ParseFeed will read the out-of-office periods from an ICS file that have not ended by now and start
+// within Lookahead. All-day events cover whole days in loc. Recurring events with an unsupported
+// recurrence rule only use the first occurrence.
+func ParseFeed(r io.Reader, keyword string, loc *time.Location, now time.Time) ([]Period, error) {
+	events, err := icalutil.Parse(io.LimitReader(r, MaxFileSize))
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		start, end int64
+		summary    string
+	}
+	seen := make(map[key]bool)
+	var result []Period
+	until := now.Add(Lookahead)
+	for _, e := range events {
+		if !IsOutOfOffice(e, keyword) {
+			continue
+		}
+
+		occurrences, err := e.Expand(until)
+		if errors.Is(err, icalutil.ErrUnsupportedRRule) {
+			occurrences, err = icalutil.Event{Start: e.Start, End: e.End, Summary: e.Summary, AllDay: e.AllDay}.Expand(until)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("event '%s': %w", e.UID, err)
+		}
+
+		for _, occ := range occurrences {
+			p := Period{Summary: occ.Summary, Start: occ.Start, End: occ.End}
+			if occ.AllDay {
+				p.Start = inLocation(occ.Start, loc)
+				p.End = inLocation(occ.End, loc)
+			}
+			if !p.End.After(now) || !p.End.After(p.Start) {
+				continue
+			}
+
+			k := key{start: p.Start.Unix(), end: p.End.Unix(), summary: p.Summary}
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			if len(result) >= MaxPeriods {
+				return nil, fmt.Errorf("too many out-of-office periods (max %d)", MaxPeriods)
+			}
+			result = append(result, p)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Start.Before(result[j].Start) })
+
+	return result, nil
+}
+
+// inLocation returns the same wall-clock date and time as t in loc.
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), 0, loc)
+}
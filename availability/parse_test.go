@@ -0,0 +1,80 @@
+package availability
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/util/icalutil"
+)
+
+const testFeed = `BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VEVENT
+UID:vacation
+DTSTART;VALUE=DATE:20240610
+DTEND;VALUE=DATE:20240615
+SUMMARY:Vacation
+END:VEVENT
+BEGIN:VEVENT
+UID:appointment
+DTSTART:20240604T150000Z
+DTEND:20240604T170000Z
+SUMMARY:Doctor
+X-MICROSOFT-CDO-BUSYSTATUS:OOF
+END:VEVENT
+BEGIN:VEVENT
+UID:meeting
+DTSTART:20240603T150000Z
+DTEND:20240603T160000Z
+SUMMARY:Vacation planning
+X-MICROSOFT-CDO-BUSYSTATUS:FREE
+RRULE:FREQ=WEEKLY
+END:VEVENT
+BEGIN:VEVENT
+UID:old
+DTSTART;VALUE=DATE:20240101
+SUMMARY:Vacation
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestIsOutOfOffice(t *testing.T) {
+	assert.True(t, IsOutOfOffice(icalutil.Event{Summary: "Lunch"}, ""))
+	assert.False(t, IsOutOfOffice(icalutil.Event{Summary: "Lunch"}, "pto"))
+	assert.True(t, IsOutOfOffice(icalutil.Event{Summary: "PTO - beach"}, "pto"))
+	assert.True(t, IsOutOfOffice(icalutil.Event{Summary: "Lunch", BusyStatus: "OOF"}, "pto"))
+	assert.False(t, IsOutOfOffice(icalutil.Event{Summary: "PTO", BusyStatus: "FREE"}, ""))
+}
+
+func TestParseFeed(t *testing.T) {
+	chi, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	periods, err := ParseFeed(strings.NewReader(testFeed), "vacation", chi, now)
+	require.NoError(t, err)
+	assert.Equal(t, []Period{
+		{
+			Summary: "Doctor",
+			Start:   time.Date(2024, 6, 4, 15, 0, 0, 0, time.UTC),
+			End:     time.Date(2024, 6, 4, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			Summary: "Vacation",
+			Start:   time.Date(2024, 6, 10, 0, 0, 0, 0, chi),
+			End:     time.Date(2024, 6, 15, 0, 0, 0, 0, chi),
+		},
+	}, periods)
+
+	// periods that have ended are skipped
+	periods, err = ParseFeed(strings.NewReader(testFeed), "vacation", chi, time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, periods, 1)
+	assert.Equal(t, "Vacation", periods[0].Summary)
+
+	_, err = ParseFeed(strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VEVENT\n"), "", chi, now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,97 @@
+-- name: AvailFeedCreate :exec
+INSERT INTO user_availability_feeds(id, user_id, name, url, keyword, time_zone, substitute_user_id)
+    VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: AvailFeedUpdate :exec
+UPDATE
+    user_availability_feeds
+SET
+    name = $2,
+    url = $3,
+    keyword = $4,
+    time_zone = $5,
+    substitute_user_id = $6,
+    last_sync_at = NULL
+WHERE
+    id = $1;
+
+-- name: AvailFeedDelete :exec
+DELETE FROM user_availability_feeds
+WHERE id = $1;
+
+-- name: AvailFeedFindOne :one
+SELECT
+    *
+FROM
+    user_availability_feeds
+WHERE
+    id = $1;
+
+-- name: AvailFeedFindOneForUpdate :one
+SELECT
+    *
+FROM
+    user_availability_feeds
+WHERE
+    id = $1
+FOR UPDATE;
+
+-- name: AvailFeedFindByUser :many
+SELECT
+    *
+FROM
+    user_availability_feeds
+WHERE
+    user_id = $1
+ORDER BY
+    lower(name);
+
+-- name: AvailFeedCountByUser :one
+SELECT
+    count(*)
+FROM
+    user_availability_feeds
+WHERE
+    user_id = $1;
+
+-- name: AvailFeedPeriods :many
+SELECT
+    id,
+    summary,
+    start_time,
+    end_time
+FROM
+    user_unavailability
+WHERE
+    feed_id = $1
+ORDER BY
+    start_time;
+
+-- name: AvailDeleteFutureOverrides :exec
+-- Deletes overrides created for the given out-of-office periods that have not started.
+DELETE FROM user_overrides
+WHERE id IN (
+        SELECT
+            override_id
+        FROM
+            user_unavailability_overrides
+        WHERE
+            unavailability_id = ANY (@unavailability_ids::uuid[]))
+    AND start_time > now();
+
+-- name: AvailEndActiveOverrides :exec
+-- Ends overrides created for the given out-of-office periods that are in progress.
+UPDATE
+    user_overrides
+SET
+    end_time = now()
+WHERE
+    id IN (
+        SELECT
+            override_id
+        FROM
+            user_unavailability_overrides
+        WHERE
+            unavailability_id = ANY (@unavailability_ids::uuid[]))
+    AND start_time < now()
+    AND end_time > now();
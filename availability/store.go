@@ -0,0 +1,265 @@
+package availability
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store manages availability feeds of users.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore will create a new Store.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	return &Store{db: db}, nil
+}
+
+func nullUUID(id string) uuid.NullUUID {
+	if id == "" {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: uuid.MustParse(id), Valid: true}
+}
+
+func feedFromDB(f gadb.UserAvailabilityFeed) (*Feed, error) {
+	loc, err := util.LoadLocation(f.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("load time zone: %w", err)
+	}
+
+	feed := &Feed{
+		ID:         f.ID.String(),
+		UserID:     f.UserID.String(),
+		Name:       f.Name,
+		URL:        f.Url,
+		Keyword:    f.Keyword,
+		TimeZone:   loc,
+		LastSyncAt: f.LastSyncAt.Time,
+		LastError:  f.LastError,
+	}
+	if f.SubstituteUserID.Valid {
+		feed.SubstituteUserID = f.SubstituteUserID.UUID.String()
+	}
+
+	return feed, nil
+}
+
+// CreateTx will create a new feed. Out-of-office periods are imported by the engine shortly after.
+func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, f *Feed) (*Feed, error) {
+	n, err := f.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(n.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	db := gadb.New(tx)
+	count, err := db.AvailFeedCountByUser(ctx, uuid.MustParse(n.UserID))
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxFeedsPerUser {
+		return nil, validation.NewFieldError("UserID", fmt.Sprintf("must not have more than %d availability feeds", MaxFeedsPerUser))
+	}
+
+	n.ID = uuid.NewString()
+	err = db.AvailFeedCreate(ctx, gadb.AvailFeedCreateParams{
+		ID:               uuid.MustParse(n.ID),
+		UserID:           uuid.MustParse(n.UserID),
+		Name:             n.Name,
+		Url:              n.URL,
+		Keyword:          n.Keyword,
+		TimeZone:         n.TimeZone.String(),
+		SubstituteUserID: nullUUID(n.SubstituteUserID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// findForUpdate will lock and return the feed with the given ID, checking that the current user owns it.
+func (s *Store) findForUpdate(ctx context.Context, db *gadb.Queries, id string) (*gadb.UserAvailabilityFeed, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("FeedID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := db.AvailFeedFindOneForUpdate(ctx, uuid.MustParse(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, validation.NewFieldError("FeedID", "not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(row.UserID.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &row, nil
+}
+
+// UpdateTx will update a feed. The UserID of a feed cannot be changed. Out-of-office periods are
+// re-imported by the engine shortly after.
+func (s *Store) UpdateTx(ctx context.Context, tx *sql.Tx, f *Feed) error {
+	db := gadb.New(tx)
+	row, err := s.findForUpdate(ctx, db, f.ID)
+	if err != nil {
+		return err
+	}
+
+	f.UserID = row.UserID.String()
+	n, err := f.Normalize()
+	if err != nil {
+		return err
+	}
+
+	return db.AvailFeedUpdate(ctx, gadb.AvailFeedUpdateParams{
+		ID:               row.ID,
+		Name:             n.Name,
+		Url:              n.URL,
+		Keyword:          n.Keyword,
+		TimeZone:         n.TimeZone.String(),
+		SubstituteUserID: nullUUID(n.SubstituteUserID),
+	})
+}
+
+// DeleteTx will delete a feed. Overrides created for it are removed, or ended if already in progress.
+func (s *Store) DeleteTx(ctx context.Context, tx *sql.Tx, id string) error {
+	db := gadb.New(tx)
+	row, err := s.findForUpdate(ctx, db, id)
+	if err != nil {
+		return err
+	}
+
+	periods, err := db.AvailFeedPeriods(ctx, row.ID)
+	if err != nil {
+		return err
+	}
+	ids := make([]uuid.UUID, len(periods))
+	for i, p := range periods {
+		ids[i] = p.ID
+	}
+
+	err = EndOverrides(ctx, db, ids)
+	if err != nil {
+		return err
+	}
+
+	return db.AvailFeedDelete(ctx, row.ID)
+}
+
+// EndOverrides will remove overrides created for the given out-of-office periods, or end them now if they
+// are in progress.
+func EndOverrides(ctx context.Context, db *gadb.Queries, periodIDs []uuid.UUID) error {
+	if len(periodIDs) == 0 {
+		return nil
+	}
+
+	err := db.AvailDeleteFutureOverrides(ctx, periodIDs)
+	if err != nil {
+		return fmt.Errorf("delete future overrides: %w", err)
+	}
+	err = db.AvailEndActiveOverrides(ctx, periodIDs)
+	if err != nil {
+		return fmt.Errorf("end active overrides: %w", err)
+	}
+
+	return nil
+}
+
+// FindOne will return the feed with the given ID.
+func (s *Store) FindOne(ctx context.Context, id string) (*Feed, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("FeedID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := gadb.New(s.db).AvailFeedFindOne(ctx, uuid.MustParse(id))
+	if err != nil {
+		return nil, err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(row.UserID.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	return feedFromDB(row)
+}
+
+// FindAllByUser will return all feeds of a user, sorted by name.
+func (s *Store) FindAllByUser(ctx context.Context, userID string) ([]Feed, error) {
+	err := validate.UUID("UserID", userID)
+	if err != nil {
+		return nil, err
+	}
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).AvailFeedFindByUser(ctx, uuid.MustParse(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Feed, 0, len(rows))
+	for _, row := range rows {
+		f, err := feedFromDB(row)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *f)
+	}
+
+	return result, nil
+}
+
+// Periods will return the out-of-office periods imported from a feed that have not ended.
+func (s *Store) Periods(ctx context.Context, feedID string) ([]Period, error) {
+	f, err := s.FindOne(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).AvailFeedPeriods(ctx, uuid.MustParse(f.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]Period, 0, len(rows))
+	for _, row := range rows {
+		if !row.EndTime.After(now) {
+			continue
+		}
+		result = append(result, Period{Summary: row.Summary, Start: row.StartTime, End: row.EndTime})
+	}
+
+	return result, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"mime"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,6 +21,9 @@ import (
 // PayloadType is the embedded type & version for calendar subscription payloads.
 const PayloadType = "calendar-subscription/v1"
 
+// AlertFeedDays is how many days of alerts are included in service alert feeds.
+const AlertFeedDays = 30
+
 // JSONResponseV1 is the JSON response format for calendar subscription requests.
 type JSONResponseV1 struct {
 	AppName    string
@@ -48,32 +52,210 @@ type JSONShiftV1 struct {
 	Truncated bool
 }
 
-func (s *Store) userNameMap(ctx context.Context, shifts []oncall.Shift) (map[string]string, error) {
-	names := make(map[string]string)
+// userInfo will set the names and emails of all users referenced by the shifts of data.
+func (s *Store) userInfo(ctx context.Context, data *renderData) error {
+	data.UserNames = make(map[string]string)
+	data.UserEmails = make(map[string]string)
 	var uniqueIDs []uuid.UUID
-	for _, s := range shifts {
-
+	add := func(id string) {
 		// We'll use the map to track which IDs we've already seen.
 		// That way we don't ask the DB for the same user multiple times.
-		if _, ok := names[s.UserID]; ok {
-			continue
+		if _, ok := data.UserNames[id]; ok {
+			return
+		}
+		data.UserNames[id] = "Unknown User"
+		uniqueIDs = append(uniqueIDs, uuid.MustParse(id))
+	}
+	for _, s := range data.Shifts {
+		add(s.UserID)
+	}
+	for _, d := range data.Details {
+		for _, id := range d.HandoffFrom {
+			add(id)
+		}
+		for _, id := range d.HandoffTo {
+			add(id)
 		}
-		names[s.UserID] = "Unknown User"
-		uniqueIDs = append(uniqueIDs, uuid.MustParse(s.UserID))
 	}
 
 	users, err := gadb.New(s.db).CalSubUserNames(ctx, uniqueIDs)
 	if err != nil {
-		return nil, fmt.Errorf("lookup user names: %w", err)
+		return fmt.Errorf("lookup user names: %w", err)
 	}
 
 	for _, u := range users {
-		names[u.ID.String()] = u.Name
+		data.UserNames[u.ID.String()] = u.Name
+		data.UserEmails[u.ID.String()] = u.Email
+	}
+	return nil
+}
+
+// handoffDetails returns details for each shift with the users on call immediately before and after it.
+func handoffDetails(shifts []oncall.Shift) []shiftDetails {
+	details := make([]shiftDetails, len(shifts))
+	for i, s := range shifts {
+		for _, o := range shifts {
+			if o.UserID == s.UserID {
+				continue
+			}
+			if !o.Truncated && o.End.Equal(s.Start) {
+				details[i].HandoffFrom = append(details[i].HandoffFrom, o.UserID)
+			}
+			if !s.Truncated && o.Start.Equal(s.End) {
+				details[i].HandoffTo = append(details[i].HandoffTo, o.UserID)
+			}
+		}
+	}
+
+	return details
+}
+
+// markOverrides will set Override for each shift that overlaps an override adding the same user.
+func markOverrides(shifts []oncall.Shift, details []shiftDetails, overrides []gadb.CalSubOverridesRow) {
+	for i, s := range shifts {
+		for _, o := range overrides {
+			if o.AddUserID.UUID.String() != s.UserID || !o.StartTime.Before(s.End) || !o.EndTime.After(s.Start) {
+				continue
+			}
+			details[i].Override = true
+			break
+		}
 	}
-	return names, nil
 }
 
-// ServeICalData will return an iCal file for the subscription associated with the current request.
+// filterUser will return only the shifts (and their details) of the given user.
+func filterUser(userID string, shifts []oncall.Shift, details []shiftDetails) ([]oncall.Shift, []shiftDetails) {
+	var resShifts []oncall.Shift
+	var resDetails []shiftDetails
+	for i, s := range shifts {
+		if s.UserID != userID {
+			continue
+		}
+		resShifts = append(resShifts, s)
+		resDetails = append(resDetails, details[i])
+	}
+
+	return resShifts, resDetails
+}
+
+// scheduleShifts will return the shifts of a schedule with handoffs and overrides.
+func (s *Store) scheduleShifts(ctx context.Context, schedID uuid.UUID, start, end time.Time) ([]oncall.Shift, []shiftDetails, error) {
+	shifts, err := s.oc.HistoryBySchedule(ctx, schedID.String(), start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	overrides, err := gadb.New(s.db).CalSubOverrides(ctx, gadb.CalSubOverridesParams{
+		TgtScheduleID: schedID,
+		StartTime:     start,
+		EndTime:       end,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("lookup overrides: %w", err)
+	}
+
+	details := handoffDetails(shifts)
+	markOverrides(shifts, details, overrides)
+	return shifts, details, nil
+}
+
+// feedData will return the data to render for a subscription.
+func (s *Store) feedData(ctx context.Context, info gadb.CalSubRenderInfoRow, subCfg SubscriptionConfig) (*renderData, error) {
+	cfg := config.FromContext(ctx)
+	start, end := info.Now, info.Now.AddDate(1, 0, 0)
+	data := &renderData{
+		ApplicationName: cfg.ApplicationName(),
+		ScheduleName:    info.TargetName,
+		ReminderMinutes: subCfg.ReminderMinutes,
+		Version:         version.GitVersion(),
+		GeneratedAt:     info.Now,
+		FullSchedule:    subCfg.FullSchedule,
+	}
+
+	var err error
+	switch {
+	case info.ScheduleID.Valid:
+		data.ScheduleID = info.ScheduleID.UUID
+		data.Shifts, data.Details, err = s.scheduleShifts(ctx, info.ScheduleID.UUID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		if !subCfg.FullSchedule {
+			data.Shifts, data.Details = filterUser(info.UserID.String(), data.Shifts, data.Details)
+		}
+	case info.RotationID.Valid:
+		data.ScheduleID = info.RotationID.UUID
+		data.FullSchedule = true
+		data.Shifts, err = s.oc.ShiftsByRotation(ctx, info.RotationID.UUID.String(), start, end)
+		if err != nil {
+			return nil, err
+		}
+		data.Details = handoffDetails(data.Shifts)
+	case info.ServiceID.Valid:
+		data.ScheduleID = info.ServiceID.UUID
+		rows, err := gadb.New(s.db).CalSubServiceAlerts(ctx, gadb.CalSubServiceAlertsParams{
+			ServiceID: uuid.NullUUID{UUID: info.ServiceID.UUID, Valid: true},
+			Since:     info.Now.AddDate(0, 0, -AlertFeedDays),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("lookup alerts: %w", err)
+		}
+		for _, a := range rows {
+			data.Alerts = append(data.Alerts, alertEvent{
+				ID:      a.ID,
+				Summary: a.Summary,
+				Status:  string(a.Status),
+				Start:   a.CreatedAt,
+				End:     a.EndTime,
+				URL:     cfg.CallbackURL(fmt.Sprintf("/alerts/%d", a.ID)),
+			})
+		}
+	default:
+		// all schedules the user is on
+		data.FullSchedule = false
+		scheds, err := gadb.New(s.db).ScheduleFindManyByUser(ctx, uuid.NullUUID{UUID: info.UserID, Valid: true})
+		if err != nil {
+			return nil, fmt.Errorf("lookup schedules: %w", err)
+		}
+		for _, sched := range scheds {
+			shifts, details, err := s.scheduleShifts(ctx, sched.ID, start, end)
+			if err != nil {
+				return nil, err
+			}
+			for i := range details {
+				details[i].ScheduleID = sched.ID
+				details[i].ScheduleName = sched.Name
+			}
+			shifts, details = filterUser(info.UserID.String(), shifts, details)
+			data.Shifts = append(data.Shifts, shifts...)
+			data.Details = append(data.Details, details...)
+		}
+		sort.Stable(byShiftStart{data})
+	}
+
+	err = s.userInfo(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// byShiftStart sorts the shifts (and details) of renderData by start time.
+type byShiftStart struct{ *renderData }
+
+func (b byShiftStart) Len() int { return len(b.Shifts) }
+func (b byShiftStart) Less(i, j int) bool {
+	return b.Shifts[i].Start.Before(b.Shifts[j].Start)
+}
+
+func (b byShiftStart) Swap(i, j int) {
+	b.Shifts[i], b.Shifts[j] = b.Shifts[j], b.Shifts[i]
+	b.Details[i], b.Details[j] = b.Details[j], b.Details[i]
+}
+
+// ServeICalData will return an iCal file for the subscription associated with the current request. JSON
+// is only supported for schedule subscriptions.
 func (s *Store) ServeICalData(w http.ResponseWriter, req *http.Request) {
 	ctx := req.Context()
 	src := permission.Source(ctx)
@@ -92,85 +274,50 @@ func (s *Store) ServeICalData(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	shifts, err := s.oc.HistoryBySchedule(ctx, info.ScheduleID.String(), info.Now, info.Now.AddDate(1, 0, 0))
-	if errutil.HTTPError(ctx, w, err) {
-		return
-	}
-
 	var subCfg SubscriptionConfig
 	err = json.Unmarshal(info.Config, &subCfg)
 	if errutil.HTTPError(ctx, w, err) {
 		return
 	}
 
-	if !subCfg.FullSchedule {
-		// filter out other users
-		filtered := shifts[:0]
-		for _, s := range shifts {
-			if s.UserID != info.UserID.String() {
-				continue
-			}
-			filtered = append(filtered, s)
-		}
-		shifts = filtered
+	data, err := s.feedData(ctx, info, subCfg)
+	if errutil.HTTPError(ctx, w, err) {
+		return
 	}
 
 	ct, _, _ := mime.ParseMediaType(req.Header.Get("Accept"))
-	if ct == "application/json" {
-		data := JSONResponseV1{
+	if ct == "application/json" && info.ScheduleID.Valid {
+		resp := JSONResponseV1{
 			AppName:      cfg.ApplicationName(),
 			AppVersion:   version.GitVersion(),
 			Type:         PayloadType,
-			ScheduleID:   info.ScheduleID,
-			ScheduleName: info.ScheduleName,
-			ScheduleURL:  cfg.CallbackURL("/schedules/" + info.ScheduleID.String()),
+			ScheduleID:   info.ScheduleID.UUID,
+			ScheduleName: info.TargetName,
+			ScheduleURL:  cfg.CallbackURL("/schedules/" + info.ScheduleID.UUID.String()),
 			Start:        info.Now,
 			End:          info.Now.AddDate(1, 0, 0),
 		}
-		m, err := s.userNameMap(ctx, shifts)
-		if errutil.HTTPError(ctx, w, err) {
-			return
-		}
-		for _, s := range shifts {
-			data.Shifts = append(data.Shifts, JSONShiftV1{
+		for _, s := range data.Shifts {
+			resp.Shifts = append(resp.Shifts, JSONShiftV1{
 				Start:     s.Start,
 				End:       s.End,
 				Truncated: s.Truncated,
 				UserID:    uuid.MustParse(s.UserID),
-				UserName:  m[s.UserID],
+				UserName:  data.UserNames[s.UserID],
 				UserURL:   cfg.CallbackURL("/users/" + s.UserID),
 			})
 		}
-		if len(data.Shifts) == 0 {
-			data.Shifts = []JSONShiftV1{}
+		if len(resp.Shifts) == 0 {
+			resp.Shifts = []JSONShiftV1{}
 		}
 		w.Header().Set("Content-Type", "application/json")
-		err = json.NewEncoder(w).Encode(data)
+		err = json.NewEncoder(w).Encode(resp)
 		if errutil.HTTPError(ctx, w, err) {
 			return
 		}
 		return
 	}
 
-	data := renderData{
-		ApplicationName: cfg.ApplicationName(),
-		ScheduleID:      info.ScheduleID,
-		ScheduleName:    info.ScheduleName,
-		Shifts:          shifts,
-		ReminderMinutes: subCfg.ReminderMinutes,
-		Version:         version.GitVersion(),
-		GeneratedAt:     info.Now,
-		FullSchedule:    subCfg.FullSchedule,
-	}
-
-	if subCfg.FullSchedule {
-		// When rendering the full schedule, we need to fetch the names of all users.
-		data.UserNames, err = s.userNameMap(ctx, shifts)
-		if errutil.HTTPError(ctx, w, err) {
-			return
-		}
-	}
-
 	calData, err := data.renderICal()
 	if errutil.HTTPError(ctx, w, err) {
 		return
@@ -5,6 +5,8 @@ SELECT
     user_id,
     disabled,
     schedule_id,
+    rotation_id,
+    service_id,
     config,
     last_access
 FROM
@@ -15,7 +17,8 @@ WHERE
 -- name: CalSubUserNames :many
 SELECT
     id,
-    name
+    name,
+    email
 FROM
     users
 WHERE
@@ -25,15 +28,55 @@ WHERE
 SELECT
     now()::timestamptz AS now,
     sub.schedule_id,
-    sched.name AS schedule_name,
+    sub.rotation_id,
+    sub.service_id,
+    coalesce(sched.name, rot.name, svc.name, '')::text AS target_name,
     sub.config,
     sub.user_id
 FROM
     user_calendar_subscriptions sub
-    JOIN schedules sched ON sched.id = schedule_id
+    LEFT JOIN schedules sched ON sched.id = sub.schedule_id
+    LEFT JOIN rotations rot ON rot.id = sub.rotation_id
+    LEFT JOIN services svc ON svc.id = sub.service_id
 WHERE
     sub.id = $1;
 
+-- name: CalSubOverrides :many
+SELECT
+    add_user_id,
+    start_time,
+    end_time
+FROM
+    user_overrides
+WHERE
+    tgt_schedule_id = $1
+    AND add_user_id NOTNULL
+    AND end_time > @start_time::timestamptz
+    AND start_time < @end_time::timestamptz;
+
+-- name: CalSubServiceAlerts :many
+SELECT
+    a.id,
+    a.summary,
+    a.status,
+    a.created_at,
+    coalesce(m.closed_at, (
+            SELECT
+                max(l.timestamp)
+            FROM alert_logs l
+            WHERE
+                l.alert_id = a.id
+                AND l.event = 'closed'), now())::timestamptz AS end_time
+FROM
+    alerts a
+    LEFT JOIN alert_metrics m ON m.alert_id = a.id
+WHERE
+    a.service_id = $1
+    AND a.created_at > @since::timestamptz
+ORDER BY
+    a.created_at DESC
+LIMIT 500;
+
 -- name: FindOneCalSubForUpdate :one
 SELECT
     id,
@@ -41,6 +84,8 @@ SELECT
     user_id,
     disabled,
     schedule_id,
+    rotation_id,
+    service_id,
     config,
     last_access
 FROM
@@ -56,6 +101,8 @@ SELECT
     user_id,
     disabled,
     schedule_id,
+    rotation_id,
+    service_id,
     config,
     last_access
 FROM
@@ -69,8 +116,8 @@ WHERE id = ANY ($1::uuid[])
     AND user_id = $2;
 
 -- name: CreateCalSub :one
-INSERT INTO user_calendar_subscriptions(id, NAME, user_id, disabled, schedule_id, config)
-    VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO user_calendar_subscriptions(id, NAME, user_id, disabled, schedule_id, rotation_id, service_id, config)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING
     created_at;
 
@@ -17,4 +17,36 @@ type renderData struct {
 	GeneratedAt     time.Time
 	FullSchedule    bool
 	UserNames       map[string]string
+
+	// Details, if set, contains additional information for each shift in Shifts.
+	Details []shiftDetails
+
+	// UserEmails is used to include contact information for handoffs.
+	UserEmails map[string]string
+
+	// Alerts are rendered as events, in addition to any shifts.
+	Alerts []alertEvent
+}
+
+// shiftDetails is additional information about a shift.
+type shiftDetails struct {
+	// ScheduleID and ScheduleName, if set, are used instead of the ones for the whole calendar.
+	ScheduleID   uuid.UUID
+	ScheduleName string
+
+	// Override is true if the user is on call because of an override.
+	Override bool
+
+	// HandoffFrom and HandoffTo are the IDs of users on call immediately before and after the shift.
+	HandoffFrom []string
+	HandoffTo   []string
+}
+
+// alertEvent is an alert rendered as a calendar event, from when it was created until it was closed.
+type alertEvent struct {
+	ID         int64
+	Summary    string
+	Status     string
+	Start, End time.Time
+	URL        string
 }
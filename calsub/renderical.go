@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
-	"html/template"
+	"fmt"
+	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
 
@@ -19,16 +22,23 @@ CALSCALE:GREGORIAN
 METHOD:PUBLISH
 {{- $mins := .ReminderMinutes }}
 {{- $genTime := .GeneratedAt }}
-{{- $eventUIDs := .EventUIDs}}
-{{- range $i, $s := .Shifts}}
+{{- range .Events}}
 BEGIN:VEVENT
-UID:{{index $eventUIDs $i}}
-SUMMARY:{{if $.FullSchedule}}{{index $.UserNames $s.UserID}} {{end}}On-Call ({{$.ApplicationName}}: {{$.ScheduleName}}){{if $s.Truncated}} Begins*
-DESCRIPTION:The end time of this shift is unknown and will continue beyond what is displayed.
-{{- end }}
+UID:{{.UID}}
+SUMMARY:{{.Summary}}
+{{- if .Description}}
+DESCRIPTION:{{.Description}}
+{{- end}}
+{{- if .Categories}}
+CATEGORIES:{{.Categories}}
+{{- end}}
+{{- if .URL}}
+URL:{{.URL}}
+{{- end}}
 DTSTAMP:{{$genTime.UTC.Format "20060102T150405Z"}}
 DTSTART:{{.Start.UTC.Format "20060102T150405Z"}}
 DTEND:{{.End.UTC.Format "20060102T150405Z"}}
+{{- if .Reminders}}
 {{- range $mins}}
 BEGIN:VALARM
 ACTION:DISPLAY
@@ -36,26 +46,135 @@ DESCRIPTION:REMINDER
 TRIGGER:-PT{{.}}M
 END:VALARM
 {{- end}}
+{{- end}}
 END:VEVENT
 {{- end}}
 END:VCALENDAR
 `, "\n", "\r\n")))
 
-// renderICal will generate an iCal file from the renderData.
-func (r renderData) renderICal() ([]byte, error) {
-	var icalRender struct {
-		renderData
-		EventUIDs []string
+type icalEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Categories  string
+	URL         string
+	Start, End  time.Time
+	Reminders   bool
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escapeText will escape s for use as an iCalendar TEXT value.
+func escapeText(s string) string { return textEscaper.Replace(s) }
+
+func eventUID(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "")))
+	return hex.EncodeToString(sum[:])
+}
+
+// userContact returns the name of a user, with their email if known.
+func (r renderData) userContact(id string) string {
+	name := r.UserNames[id]
+	if name == "" {
+		name = "Unknown User"
 	}
-	icalRender.renderData = r
-	for _, s := range r.Shifts {
+	if email := r.UserEmails[id]; email != "" {
+		return fmt.Sprintf("%s <%s>", name, email)
+	}
+
+	return name
+}
+
+func (r renderData) userContacts(ids []string) string {
+	contacts := make([]string, len(ids))
+	for i, id := range ids {
+		contacts[i] = r.userContact(id)
+	}
+
+	return strings.Join(contacts, ", ")
+}
+
+// events returns the calendar events for all shifts and alerts.
+func (r renderData) events() []icalEvent {
+	events := make([]icalEvent, 0, len(r.Shifts)+len(r.Alerts))
+	for i, s := range r.Shifts {
+		var d shiftDetails
+		if i < len(r.Details) {
+			d = r.Details[i]
+		}
+		schedID, schedName := r.ScheduleID, r.ScheduleName
+		if d.ScheduleID != uuid.Nil {
+			schedID, schedName = d.ScheduleID, d.ScheduleName
+		}
+
 		t := s.End
 		if s.Truncated {
 			t = s.Start
 		}
-		sum := sha256.Sum256([]byte(s.UserID + r.ScheduleID.String() + t.Format(time.RFC3339)))
-		icalRender.EventUIDs = append(icalRender.EventUIDs, hex.EncodeToString(sum[:]))
+
+		var summary strings.Builder
+		if r.FullSchedule {
+			summary.WriteString(r.UserNames[s.UserID] + " ")
+		}
+		if d.Override {
+			summary.WriteString("[Override] ")
+		}
+		fmt.Fprintf(&summary, "On-Call (%s: %s)", r.ApplicationName, schedName)
+		if s.Truncated {
+			summary.WriteString(" Begins*")
+		}
+
+		var desc []string
+		if s.Truncated {
+			desc = append(desc, "The end time of this shift is unknown and will continue beyond what is displayed.")
+		}
+		if d.Override {
+			desc = append(desc, "This shift is from an override.")
+		}
+		if len(d.HandoffFrom) > 0 {
+			desc = append(desc, "Handoff from: "+r.userContacts(d.HandoffFrom))
+		}
+		if len(d.HandoffTo) > 0 && !s.Truncated {
+			desc = append(desc, "Handoff to: "+r.userContacts(d.HandoffTo))
+		}
+
+		e := icalEvent{
+			UID:         eventUID(s.UserID, schedID.String(), t.Format(time.RFC3339)),
+			Summary:     escapeText(summary.String()),
+			Description: escapeText(strings.Join(desc, "\n")),
+			Start:       s.Start,
+			End:         s.End,
+			Reminders:   true,
+		}
+		if d.Override {
+			e.Categories = "OVERRIDE"
+		}
+		events = append(events, e)
+	}
+
+	for _, a := range r.Alerts {
+		events = append(events, icalEvent{
+			UID:         eventUID("alert", strconv.FormatInt(a.ID, 10), r.ScheduleID.String()),
+			Summary:     escapeText(fmt.Sprintf("Alert #%d: %s (%s: %s)", a.ID, a.Summary, r.ApplicationName, r.ScheduleName)),
+			Description: escapeText("Status: " + a.Status),
+			Categories:  "ALERT",
+			URL:         a.URL,
+			Start:       a.Start,
+			End:         a.End,
+		})
+	}
+
+	return events
+}
+
+// renderICal will generate an iCal file from the renderData.
+func (r renderData) renderICal() ([]byte, error) {
+	var icalRender struct {
+		renderData
+		Events []icalEvent
 	}
+	icalRender.renderData = r
+	icalRender.Events = r.events()
 
 	buf := bytes.NewBuffer(nil)
 	err := iCalTemplate.Execute(buf, icalRender)
@@ -80,3 +80,59 @@ func TestRenderData_RenderICal(t *testing.T) {
 	}, "\r\n")
 	assert.Equal(t, expected, string(iCal))
 }
+
+func TestHandoffDetails(t *testing.T) {
+	a, b, c := "user-a", "user-b", "user-c"
+	t0 := time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC)
+	shifts := []oncall.Shift{
+		{UserID: a, Start: t0, End: t0.Add(24 * time.Hour)},
+		{UserID: b, Start: t0.Add(24 * time.Hour), End: t0.Add(48 * time.Hour)},
+		{UserID: c, Start: t0.Add(24 * time.Hour), End: t0.Add(36 * time.Hour)},
+		{UserID: a, Start: t0.Add(48 * time.Hour), End: t0.Add(72 * time.Hour), Truncated: true},
+	}
+
+	d := handoffDetails(shifts)
+	require.Len(t, d, 4)
+	assert.Empty(t, d[0].HandoffFrom)
+	assert.Equal(t, []string{b, c}, d[0].HandoffTo)
+	assert.Equal(t, []string{a}, d[1].HandoffFrom)
+	assert.Equal(t, []string{a}, d[1].HandoffTo)
+	assert.Equal(t, []string{a}, d[2].HandoffFrom)
+	assert.Empty(t, d[2].HandoffTo)
+	assert.Equal(t, []string{b}, d[3].HandoffFrom)
+	assert.Empty(t, d[3].HandoffTo, "truncated shifts have no known handoff")
+}
+
+func TestRenderData_RenderICal_Details(t *testing.T) {
+	userA := "01020304-0506-0708-090a-0b0c0d0e0f10"
+	userB := "01020304-0506-0708-090a-0b0c0d0e0f11"
+	t0 := time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC)
+	r := renderData{
+		ApplicationName: "GoAlert",
+		ScheduleID:      uuid.MustParse("100f0e0d-0c0b-0a09-0807-060504030201"),
+		ScheduleName:    "Sched",
+		Shifts:          []oncall.Shift{{UserID: userA, Start: t0, End: t0.Add(time.Hour)}},
+		Details:         []shiftDetails{{Override: true, HandoffTo: []string{userB}}},
+		UserNames:       map[string]string{userB: "Bob, Jr."},
+		UserEmails:      map[string]string{userB: "bob@example.com"},
+		Alerts: []alertEvent{{
+			ID:      42,
+			Summary: "CPU high; again",
+			Status:  "closed",
+			Start:   t0,
+			End:     t0.Add(time.Hour),
+			URL:     "http://example.com/alerts/42",
+		}},
+		Version:     "dev",
+		GeneratedAt: t0,
+	}
+
+	iCal, err := r.renderICal()
+	require.NoError(t, err)
+	s := string(iCal)
+	assert.Contains(t, s, "SUMMARY:[Override] On-Call (GoAlert: Sched)\r\n")
+	assert.Contains(t, s, `DESCRIPTION:This shift is from an override.\nHandoff to: Bob\, Jr. <bob@example.com>`+"\r\n")
+	assert.Contains(t, s, "CATEGORIES:OVERRIDE\r\n")
+	assert.Contains(t, s, `SUMMARY:Alert #42: CPU high\; again (GoAlert: Sched)`+"\r\n")
+	assert.Contains(t, s, "DESCRIPTION:Status: closed\r\nCATEGORIES:ALERT\r\nURL:http://example.com/alerts/42\r\n")
+}
@@ -34,6 +34,20 @@ func NewStore(ctx context.Context, db *sql.DB, apiKeyring keyring.Keyring, oc *o
 	}, nil
 }
 
+func nullUUID(id string) uuid.NullUUID {
+	if id == "" {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: uuid.MustParse(id), Valid: true}
+}
+
+func nullUUIDString(id uuid.NullUUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return id.UUID.String()
+}
+
 // Authorize will return an authorized context associated with the given token. If the token is invalid
 // or otherwise can not be authenticated, an error is returned.
 func (s *Store) Authorize(ctx context.Context, tok authtoken.Token) (context.Context, error) {
@@ -92,7 +106,9 @@ func (s *Store) _FindOne(ctx context.Context, q *gadb.Queries, id string, upd bo
 		Name:       sub.Name,
 		UserID:     sub.UserID.String(),
 		Disabled:   sub.Disabled,
-		ScheduleID: sub.ScheduleID.String(),
+		ScheduleID: nullUUIDString(sub.ScheduleID),
+		RotationID: nullUUIDString(sub.RotationID),
+		ServiceID:  nullUUIDString(sub.ServiceID),
 		LastAccess: sub.LastAccess.Time,
 	}
 	err = json.Unmarshal(sub.Config, &cs.Config)
@@ -170,7 +186,9 @@ func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, cs *Subscription) (*Su
 		Name:       n.Name,
 		UserID:     uuid.MustParse(n.UserID),
 		Disabled:   n.Disabled,
-		ScheduleID: uuid.MustParse(n.ScheduleID),
+		ScheduleID: nullUUID(n.ScheduleID),
+		RotationID: nullUUID(n.RotationID),
+		ServiceID:  nullUUID(n.ServiceID),
 		Config:     cfgData,
 	})
 	if err != nil {
@@ -214,7 +232,9 @@ func (s *Store) FindAllByUser(ctx context.Context, userID string) ([]Subscriptio
 			Name:       sub.Name,
 			UserID:     sub.UserID.String(),
 			Disabled:   sub.Disabled,
-			ScheduleID: sub.ScheduleID.String(),
+			ScheduleID: nullUUIDString(sub.ScheduleID),
+			RotationID: nullUUIDString(sub.RotationID),
+			ServiceID:  nullUUIDString(sub.ServiceID),
 			LastAccess: sub.LastAccess.Time,
 		}
 		err = json.Unmarshal(sub.Config, &cs[i].Config)
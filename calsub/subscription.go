@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -13,6 +14,8 @@ type Subscription struct {
 	Name       string
 	UserID     string
 	ScheduleID string
+	RotationID string
+	ServiceID  string
 	LastUpdate time.Time
 	LastAccess time.Time
 	Disabled   bool
@@ -23,6 +26,20 @@ type Subscription struct {
 	token string
 }
 
+// Type returns the kind of events provided by the subscription, based on which target is set.
+func (cs Subscription) Type() Type {
+	switch {
+	case cs.ScheduleID != "":
+		return TypeSchedule
+	case cs.RotationID != "":
+		return TypeRotation
+	case cs.ServiceID != "":
+		return TypeServiceAlerts
+	}
+
+	return TypeUserSchedules
+}
+
 // Token returns the authorization token associated with this CalendarSubscription. It
 // is only available when calling CreateTx.
 func (cs Subscription) Token() string { return cs.token }
@@ -43,5 +60,24 @@ func (cs Subscription) Normalize() (*Subscription, error) {
 		return nil, err
 	}
 
+	var targets int
+	for _, t := range []struct{ name, id string }{
+		{"ScheduleID", cs.ScheduleID},
+		{"RotationID", cs.RotationID},
+		{"ServiceID", cs.ServiceID},
+	} {
+		if t.id == "" {
+			continue
+		}
+		targets++
+		err = validate.UUID(t.name, t.id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if targets > 1 {
+		return nil, validation.NewGenericError("only one of ScheduleID, RotationID, or ServiceID can be set")
+	}
+
 	return &cs, nil
 }
@@ -0,0 +1,18 @@
+package calsub
+
+// Type is the kind of events provided by a calendar subscription.
+type Type string
+
+const (
+	// TypeSchedule provides the shifts of a schedule.
+	TypeSchedule Type = "schedule"
+
+	// TypeRotation provides the projected shifts of every participant of a rotation.
+	TypeRotation Type = "rotation"
+
+	// TypeUserSchedules provides the user's shifts on every schedule they are on.
+	TypeUserSchedules Type = "userSchedules"
+
+	// TypeServiceAlerts provides the recent alerts of a service.
+	TypeServiceAlerts Type = "serviceAlerts"
+)
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
@@ -38,6 +39,10 @@ type Config struct {
 		RequiredLabels []string `public:"true" info:"List of label names to require new services to define."`
 	}
 
+	Schedules struct {
+		CoverageGapNoticeHours int `public:"true" info:"Send a notice to a schedule's on-call notification channels when no one will be on call within this many hours (0 means disable)."`
+	}
+
 	Maintenance struct {
 		AlertCleanupDays     int  `public:"true" info:"Closed alerts will be deleted after this many days (0 means disable cleanup)."`
 		AlertAutoCloseDays   int  `public:"true" info:"Unacknowledged alerts will automatically be closed after this many days of inactivity. (0 means disable auto-close)."`
@@ -102,6 +107,17 @@ type Config struct {
 		InteractiveMessages bool   `info:"Enable interactive messages (e.g. buttons)."`
 	}
 
+	MSTeams struct {
+		Enable bool `public:"true" info:"Enables Microsoft Teams destinations. Incoming webhooks (Workflows) only require this, channels require a bot (App ID)."`
+
+		AppID     string `info:"Microsoft App ID of the Teams bot. Required for Teams channel destinations."`
+		AppSecret string `password:"true" info:"Client secret of the bot's app registration."`
+		TenantID  string `info:"Microsoft Entra tenant ID the bot is registered in."`
+
+		ServiceURL          string `info:"Bot Framework service URL for your region (e.g., https://smba.trafficmanager.net/amer/)."`
+		InteractiveMessages bool   `info:"Enable interactive messages (e.g. Acknowledge and Close buttons). Requires the bot messaging endpoint to be set."`
+	}
+
 	Twilio struct {
 		Enable bool `public:"true" info:"Enables sending and processing of Voice and SMS messages through the Twilio notification provider."`
 
@@ -123,6 +139,22 @@ type Config struct {
 		SMSFromNumberOverride []string `info:"List of 'carrier=number' pairs, SMS messages to numbers of the provided carrier string (exact match) will use the alternate From Number."`
 	}
 
+	SMPP struct {
+		Enable bool `public:"true" info:"Enables sending and processing of SMS messages through an SMPP 3.4 gateway (SMSC)."`
+
+		Address    string `info:"The host:port of the SMSC."`
+		EnableTLS  bool   `info:"Connect to the SMSC using TLS."`
+		SkipVerify bool   `info:"Disables certificate validation for TLS (insecure)."`
+
+		SystemID   string `info:"The system_id used to bind to the SMSC."`
+		Password   string `password:"true" info:"The password used to bind to the SMSC."`
+		SystemType string `info:"The system_type used to bind to the SMSC, if required by the carrier."`
+
+		SourceAddr string `public:"true" info:"The number (E.164 format) or alphanumeric sender ID to use for outgoing messages."`
+
+		DisableTwoWaySMS bool `info:"Disables SMS reply codes for alert messages."`
+	}
+
 	SMTP struct {
 		Enable bool `public:"true" info:"Enables email as a contact method."`
 
@@ -139,6 +171,12 @@ type Config struct {
 	Webhook struct {
 		Enable      bool     `public:"true" info:"Enables webhook as a contact method."`
 		AllowedURLs []string `public:"true" info:"If set, allows webhooks for these domains only."`
+		ActionURLs  bool     `public:"true" info:"Include signed URLs in alert notifications that can be used to acknowledge, close, or escalate the alert."`
+	}
+
+	WebPush struct {
+		Enable  bool   `public:"true" info:"Enables browser push notifications (Web Push) as a contact method."`
+		Subject string `info:"Contact URI (mailto: or https:) push services can use to reach the operator. Defaults to the public URL."`
 	}
 
 	Feedback struct {
@@ -476,6 +514,7 @@ func (cfg Config) Validate() error {
 		validateKey("GitHub.ClientID", cfg.GitHub.ClientID),
 		validateKey("GitHub.ClientSecret", cfg.GitHub.ClientSecret),
 		validateKey("Slack.AccessToken", cfg.Slack.AccessToken),
+		validate.Range("Schedules.CoverageGapNoticeHours", cfg.Schedules.CoverageGapNoticeHours, 0, 720),
 		validate.Range("Maintenance.AlertCleanupDays", cfg.Maintenance.AlertCleanupDays, 0, 9000),
 		validate.Range("Maintenance.AlertAutoCloseDays", cfg.Maintenance.AlertAutoCloseDays, 0, 9000),
 		validate.Range("Maintenance.APIKeyExpireDays", cfg.Maintenance.APIKeyExpireDays, 0, 9000),
@@ -485,6 +524,12 @@ func (cfg Config) Validate() error {
 		validatePath("OIDC.UserInfoEmailVerifiedPath", cfg.OIDC.UserInfoEmailVerifiedPath),
 		validatePath("OIDC.UserInfoNamePath", cfg.OIDC.UserInfoNamePath),
 		validateKey("Slack.SigningSecret", cfg.Slack.SigningSecret),
+		validateKey("MSTeams.AppID", cfg.MSTeams.AppID),
+		validateKey("MSTeams.AppSecret", cfg.MSTeams.AppSecret),
+		validateKey("MSTeams.TenantID", cfg.MSTeams.TenantID),
+		validate.ASCII("SMPP.SystemID", cfg.SMPP.SystemID, 0, 15),
+		validate.ASCII("SMPP.Password", cfg.SMPP.Password, 0, 8),
+		validate.ASCII("SMPP.SystemType", cfg.SMPP.SystemType, 0, 12),
 	)
 
 	if cfg.General.GoogleAnalyticsID != "" {
@@ -517,6 +562,18 @@ func (cfg Config) Validate() error {
 		}
 	}
 
+	if cfg.SMPP.Address != "" {
+		_, _, splitErr := net.SplitHostPort(cfg.SMPP.Address)
+		if splitErr != nil {
+			err = validate.Many(err, validation.NewFieldError("SMPP.Address", "must be in host:port format"))
+		}
+	}
+	if strings.HasPrefix(cfg.SMPP.SourceAddr, "+") {
+		err = validate.Many(err, validate.Phone("SMPP.SourceAddr", cfg.SMPP.SourceAddr))
+	} else if cfg.SMPP.SourceAddr != "" {
+		err = validate.Many(err, validate.ASCII("SMPP.SourceAddr", cfg.SMPP.SourceAddr, 1, 11))
+	}
+
 	if cfg.Mailgun.EmailDomain != "" {
 		err = validate.Many(err, validate.Email("Mailgun.EmailDomain", "example@"+cfg.Mailgun.EmailDomain))
 	}
@@ -526,6 +583,26 @@ func (cfg Config) Validate() error {
 	if cfg.Slack.InteractiveMessages && cfg.Slack.SigningSecret == "" {
 		err = validate.Many(err, validation.NewFieldError("Slack.SigningSecret", "required to enable Slack interactive messages"))
 	}
+	if cfg.MSTeams.AppID != "" {
+		if cfg.MSTeams.AppSecret == "" {
+			err = validate.Many(err, validation.NewFieldError("MSTeams.AppSecret", "required when MSTeams.AppID is set"))
+		}
+		if cfg.MSTeams.TenantID == "" {
+			err = validate.Many(err, validation.NewFieldError("MSTeams.TenantID", "required when MSTeams.AppID is set"))
+		}
+		if cfg.MSTeams.ServiceURL == "" {
+			err = validate.Many(err, validation.NewFieldError("MSTeams.ServiceURL", "required when MSTeams.AppID is set"))
+		}
+	}
+	if cfg.MSTeams.ServiceURL != "" {
+		err = validate.Many(err, validate.AbsoluteURL("MSTeams.ServiceURL", cfg.MSTeams.ServiceURL))
+	}
+	if cfg.MSTeams.InteractiveMessages && cfg.MSTeams.AppID == "" {
+		err = validate.Many(err, validation.NewFieldError("MSTeams.AppID", "required to enable Teams interactive messages"))
+	}
+	if cfg.WebPush.Subject != "" && !strings.HasPrefix(cfg.WebPush.Subject, "mailto:") && !strings.HasPrefix(cfg.WebPush.Subject, "https://") {
+		err = validate.Many(err, validation.NewFieldError("WebPush.Subject", "must be a mailto: or https:// URI"))
+	}
 
 	err = validate.Many(
 		err,
@@ -546,6 +623,12 @@ func (cfg Config) Validate() error {
 			"FromNumber", cfg.Twilio.FromNumber,
 		),
 
+		validateEnable("SMPP", cfg.SMPP.Enable,
+			"Address", cfg.SMPP.Address,
+			"SystemID", cfg.SMPP.SystemID,
+			"SourceAddr", cfg.SMPP.SourceAddr,
+		),
+
 		validateEnable("GitHub", cfg.GitHub.Enable,
 			"ClientID", cfg.GitHub.ClientID,
 			"ClientSecret", cfg.GitHub.ClientSecret,
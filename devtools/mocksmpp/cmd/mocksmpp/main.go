@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/devtools/mocksmpp"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:2775", "Address to listen on.")
+	systemID := flag.String("system-id", "goalert", "Required system_id for binding.")
+	password := flag.String("password", "secret", "Required password for binding.")
+	receiptDelay := flag.Duration("receipt-delay", time.Second, "Delay before sending delivery receipts, 0 to disable.")
+	flag.Parse()
+
+	log.SetFlags(log.Lshortfile)
+
+	srv, err := mocksmpp.NewServer(*addr, mocksmpp.Config{
+		SystemID:     *systemID,
+		Password:     *password,
+		ReceiptDelay: *receiptDelay,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("SystemID = %s", *systemID)
+	log.Printf("Password = %s", *password)
+	log.Println("Listening:", srv.Addr())
+	log.Println("Send replies on stdin as: <from> <to> <message>")
+
+	go func() {
+		for msg := range srv.Messages() {
+			log.Printf("Message %s: %s -> %s: %s", msg.ID, msg.From, msg.To, msg.Text)
+		}
+	}()
+
+	s := bufio.NewScanner(os.Stdin)
+	for s.Scan() {
+		parts := strings.SplitN(strings.TrimSpace(s.Text()), " ", 3)
+		if len(parts) != 3 {
+			log.Println("ERROR: expected <from> <to> <message>")
+			continue
+		}
+		err := srv.SendMessage(parts[0], parts[1], parts[2])
+		if err != nil {
+			log.Println("ERROR:", err)
+		}
+	}
+
+	// keep running if stdin is closed (e.g., in the background)
+	select {}
+}
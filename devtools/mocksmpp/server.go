@@ -0,0 +1,295 @@
+package mocksmpp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/target/goalert/notification/smpp"
+)
+
+// Config is used to configure the mock SMSC.
+type Config struct {
+	// SystemID and Password are required to bind, if set.
+	SystemID string
+	Password string
+
+	// ReceiptDelay is how long to wait before automatically sending a DELIVRD receipt for messages that request
+	// one. If zero, receipts must be sent with Message.Deliver.
+	ReceiptDelay time.Duration
+}
+
+// Server implements the SMSC side of SMPP 3.4 for testing.
+type Server struct {
+	cfg Config
+	l   net.Listener
+
+	mx       sync.Mutex
+	sessions map[*session]struct{}
+	nextID   int
+
+	msgCh chan *Message
+}
+
+// Message is a message submitted to the mock SMSC.
+type Message struct {
+	ID   string
+	From string
+	To   string
+	Text string
+
+	// Receipt is true if a delivery receipt was requested.
+	Receipt bool
+
+	s   *session
+	raw *smpp.ShortMessage
+}
+
+// NewServer creates a new mock SMSC, listening on addr.
+func NewServer(addr string, cfg Config) (*Server, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		cfg:      cfg,
+		l:        l,
+		sessions: make(map[*session]struct{}),
+		msgCh:    make(chan *Message, 100),
+	}
+	go s.serve()
+
+	return s, nil
+}
+
+// Addr returns the address the server is listening on.
+func (s *Server) Addr() string { return s.l.Addr().String() }
+
+// Messages returns a channel of submitted messages.
+func (s *Server) Messages() <-chan *Message { return s.msgCh }
+
+// Close shuts down the server and all sessions.
+func (s *Server) Close() error {
+	err := s.l.Close()
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	for sess := range s.sessions {
+		_ = sess.nc.Close()
+	}
+
+	return err
+}
+
+func (s *Server) serve() {
+	for {
+		nc, err := s.l.Accept()
+		if err != nil {
+			return
+		}
+
+		sess := &session{srv: s, nc: nc}
+		s.mx.Lock()
+		s.sessions[sess] = struct{}{}
+		s.mx.Unlock()
+
+		go sess.serve()
+	}
+}
+
+// SendMessage sends a mobile-originated message to the first bound session.
+func (s *Server) SendMessage(from, to, text string) error {
+	s.mx.Lock()
+	var bound *session
+	for sess := range s.sessions {
+		if sess.isBound() {
+			bound = sess
+			break
+		}
+	}
+	s.mx.Unlock()
+	if bound == nil {
+		return errors.New("no bound sessions")
+	}
+
+	coding, data := smpp.EncodeText(text)
+	return bound.deliver(&smpp.ShortMessage{
+		Source:     address(from),
+		Dest:       address(to),
+		DataCoding: coding,
+		Message:    data,
+	})
+}
+
+// Deliver sends a delivery receipt for the message with the given final state (e.g., DELIVRD, UNDELIV).
+func (m *Message) Deliver(state string) error {
+	r := smpp.Receipt{MessageID: m.ID, State: state}
+	return m.s.deliver(r.ShortMessage(m.raw.Dest, m.raw.Source))
+}
+
+func address(num string) smpp.Address {
+	if strings.HasPrefix(num, "+") {
+		return smpp.Address{TON: smpp.TONInternational, NPI: smpp.NPIISDN, Addr: strings.TrimPrefix(num, "+")}
+	}
+
+	return smpp.Address{Addr: num}
+}
+
+func number(a smpp.Address) string {
+	if a.TON == smpp.TONInternational {
+		return "+" + a.Addr
+	}
+
+	return a.Addr
+}
+
+type session struct {
+	srv *Server
+	nc  net.Conn
+
+	mx    sync.Mutex
+	bound bool
+	seq   uint32
+}
+
+func (sess *session) isBound() bool {
+	sess.mx.Lock()
+	defer sess.mx.Unlock()
+	return sess.bound
+}
+
+func (sess *session) write(p *smpp.PDU) error {
+	data, err := p.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	sess.mx.Lock()
+	defer sess.mx.Unlock()
+	_, err = sess.nc.Write(data)
+	return err
+}
+
+func (sess *session) deliver(m *smpp.ShortMessage) error {
+	body, err := m.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	sess.mx.Lock()
+	sess.seq++
+	seq := sess.seq
+	sess.mx.Unlock()
+
+	return sess.write(&smpp.PDU{Command: smpp.CmdDeliverSM, Sequence: seq, Body: body})
+}
+
+func (sess *session) resp(req *smpp.PDU, status uint32, body []byte) {
+	_ = sess.write(&smpp.PDU{Command: req.Command.Resp(), Status: status, Sequence: req.Sequence, Body: body})
+}
+
+func (sess *session) serve() {
+	defer func() {
+		_ = sess.nc.Close()
+		sess.srv.mx.Lock()
+		delete(sess.srv.sessions, sess)
+		sess.srv.mx.Unlock()
+	}()
+
+	for {
+		p, err := smpp.ReadPDU(sess.nc)
+		if err != nil {
+			return
+		}
+
+		if p.Command.IsResp() {
+			// responses to deliver_sm are not tracked
+			continue
+		}
+
+		if p.Command != smpp.CmdBindTransceiver && p.Command != smpp.CmdEnquireLink && !sess.isBound() {
+			sess.resp(p, smpp.StatusInvalidBind, nil)
+			continue
+		}
+
+		switch p.Command {
+		case smpp.CmdBindTransceiver:
+			sess.resp(p, sess.bind(p), smpp.CString("mocksmpp"))
+		case smpp.CmdEnquireLink:
+			sess.resp(p, smpp.StatusOK, nil)
+		case smpp.CmdUnbind:
+			sess.resp(p, smpp.StatusOK, nil)
+			return
+		case smpp.CmdSubmitSM:
+			id, status := sess.submit(p)
+			sess.resp(p, status, smpp.CString(id))
+		default:
+			_ = sess.write(&smpp.PDU{Command: smpp.CmdGenericNack, Status: smpp.StatusInvalidCmdID, Sequence: p.Sequence})
+		}
+	}
+}
+
+func (sess *session) bind(p *smpp.PDU) uint32 {
+	var b smpp.Bind
+	if b.UnmarshalBinary(p.Body) != nil {
+		return smpp.StatusInvalidCmdLen
+	}
+	if sess.isBound() {
+		return smpp.StatusAlreadyBound
+	}
+
+	cfg := sess.srv.cfg
+	if cfg.SystemID != "" && b.SystemID != cfg.SystemID {
+		return smpp.StatusInvalidSysID
+	}
+	if cfg.Password != "" && b.Password != cfg.Password {
+		return smpp.StatusInvalidPasswd
+	}
+
+	sess.mx.Lock()
+	sess.bound = true
+	sess.mx.Unlock()
+	return smpp.StatusOK
+}
+
+func (sess *session) submit(p *smpp.PDU) (string, uint32) {
+	var sm smpp.ShortMessage
+	if sm.UnmarshalBinary(p.Body) != nil {
+		return "", smpp.StatusInvalidCmdLen
+	}
+	if sm.Dest.Addr == "" {
+		return "", smpp.StatusInvalidDest
+	}
+
+	srv := sess.srv
+	srv.mx.Lock()
+	srv.nextID++
+	id := fmt.Sprintf("%08x", srv.nextID)
+	srv.mx.Unlock()
+
+	msg := &Message{
+		ID:      id,
+		From:    number(sm.Source),
+		To:      number(sm.Dest),
+		Text:    sm.Text(),
+		Receipt: sm.RegisteredDelivery&0x03 != 0,
+		s:       sess,
+		raw:     &sm,
+	}
+
+	select {
+	case srv.msgCh <- msg:
+	default:
+		return "", smpp.StatusMsgQueueFull
+	}
+
+	if msg.Receipt && srv.cfg.ReceiptDelay > 0 {
+		time.AfterFunc(srv.cfg.ReceiptDelay, func() { _ = msg.Deliver("DELIVRD") })
+	}
+
+	return id, smpp.StatusOK
+}
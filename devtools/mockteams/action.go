@@ -0,0 +1,107 @@
+package mockteams
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// User is a Teams user.
+type User struct {
+	ID          string
+	AADObjectID string
+	Name        string
+}
+
+// NewUser returns a new user with random IDs.
+func NewUser(name string) User {
+	return User{
+		ID:          "29:" + randID(),
+		AADObjectID: uuid.NewString(),
+		Name:        name,
+	}
+}
+
+// PerformAction will simulate a user clicking an Action.Execute button of a message, and return the
+// message displayed to them.
+func (s *Server) PerformAction(msg Message, verb string, user User) (string, error) {
+	var action *Action
+	for _, a := range msg.Card.Actions {
+		if a.Type == "Action.Execute" && a.Verb == verb {
+			action = &a
+			break
+		}
+	}
+	if action == nil {
+		return "", fmt.Errorf("message %s has no action with verb '%s'", msg.ID, verb)
+	}
+
+	tok, err := s.signRequest()
+	if err != nil {
+		return "", err
+	}
+
+	app := s.App()
+	data, err := json.Marshal(map[string]any{
+		"type":       "invoke",
+		"name":       "adaptiveCard/action",
+		"id":         randID(),
+		"serviceUrl": s.ServiceURL(),
+		"replyToId":  msg.ID,
+		"from":       map[string]string{"id": user.ID, "name": user.Name, "aadObjectId": user.AADObjectID},
+		"recipient":  map[string]string{"id": "28:" + app.AppID, "name": "GoAlert"},
+		"conversation": map[string]string{
+			"id":       msg.ConversationID + ";messageid=" + msg.ID,
+			"tenantId": app.TenantID,
+		},
+		"channelData": map[string]any{"tenant": map[string]string{"id": app.TenantID}},
+		"value": map[string]any{
+			"action": map[string]any{
+				"type": "Action.Execute",
+				"verb": verb,
+				"data": action.Data,
+			},
+			"trigger": "manual",
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, app.MessagingEndpoint, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+tok)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("invoke: %s: %s", resp.Status, body)
+	}
+
+	var res struct {
+		StatusCode int
+		Value      any
+	}
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	if err != nil {
+		return "", err
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("invoke: status code %d", res.StatusCode)
+	}
+
+	text, _ := res.Value.(string)
+	return text, nil
+}
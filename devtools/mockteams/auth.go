@@ -0,0 +1,101 @@
+package mockteams
+
+import (
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	keyID  = "mockteams"
+	issuer = "https://api.botframework.com"
+)
+
+// ServeToken implements the client credentials grant of the Microsoft identity platform.
+func (s *Server) ServeToken(w http.ResponseWriter, req *http.Request) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	switch {
+	case req.PathValue("tenantID") != s.app.TenantID:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request", "error_description": "unknown tenant"})
+		return
+	case req.FormValue("grant_type") != "client_credentials":
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	case req.FormValue("client_id") != s.app.AppID || req.FormValue("client_secret") != s.app.AppSecret:
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	tok := randID()
+	s.tokens[tok] = true
+	writeJSON(w, http.StatusOK, map[string]any{
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"access_token": tok,
+	})
+}
+
+// requireToken will reject requests without a valid access token.
+func (s *Server) requireToken(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		tok, _ := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		s.mx.Lock()
+		ok := s.tokens[tok]
+		s.mx.Unlock()
+		if !ok {
+			writeJSON(w, http.StatusUnauthorized, map[string]any{"error": map[string]string{"code": "Unauthorized"}})
+			return
+		}
+
+		next(w, req)
+	}
+}
+
+// ServeOpenIDConfig serves the Bot Framework OpenID metadata.
+func (s *Server) ServeOpenIDConfig(w http.ResponseWriter, req *http.Request) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":   issuer,
+		"jwks_uri": s.urlPrefix + "/v1/.well-known/keys",
+	})
+}
+
+// ServeKeys serves the public key used to sign requests to the messaging endpoint.
+func (s *Server) ServeKeys(w http.ResponseWriter, req *http.Request) {
+	pub := s.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"kid": keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+// signRequest will return a signed token for a request to the messaging endpoint.
+func (s *Server) signRequest() (string, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	now := time.Now()
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":        issuer,
+		"aud":        s.app.AppID,
+		"iat":        now.Unix(),
+		"nbf":        now.Add(-time.Minute).Unix(),
+		"exp":        now.Add(time.Hour).Unix(),
+		"serviceurl": s.urlPrefix + "/",
+	})
+	tok.Header["kid"] = keyID
+
+	return tok.SignedString(s.key)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/target/goalert/devtools/mockteams"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8086", "Address to listen on.")
+	prefix := flag.String("prefix", "", "API URL prefix.")
+	appID := flag.String("app-id", "", "Default app ID.")
+	appSecret := flag.String("app-secret", "", "Default app secret.")
+	tenantID := flag.String("tenant-id", "", "Default tenant ID.")
+	endpoint := flag.String("messaging-endpoint", "http://localhost:3030/api/v2/msteams/messages", "Bot messaging endpoint to send card actions to.")
+	flag.Parse()
+
+	log.SetFlags(log.Lshortfile)
+
+	srv := mockteams.NewServer()
+	srv.SetURLPrefix("http://" + *addr + *prefix)
+	srv.SetApp(mockteams.AppInfo{
+		AppID:             *appID,
+		AppSecret:         *appSecret,
+		TenantID:          *tenantID,
+		MessagingEndpoint: *endpoint,
+	})
+
+	app := srv.App()
+	log.Printf("AppID      = %s", app.AppID)
+	log.Printf("AppSecret  = %s", app.AppSecret)
+	log.Printf("TenantID   = %s", app.TenantID)
+	log.Printf("ServiceURL = %s", srv.ServiceURL())
+	log.Printf("ChannelID  = %s", mockteams.NewChannelID())
+	log.Printf("WebhookURL = %s", srv.WebhookURL("default"))
+
+	h := http.Handler(srv)
+	if *prefix != "" {
+		h = http.StripPrefix(strings.TrimSuffix(*prefix, "/"), h)
+	}
+
+	log.Println("Listening:", *addr)
+	err := http.ListenAndServe(*addr, h)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,205 @@
+package mockteams
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Message is a message sent by the bot, or to an incoming webhook.
+type Message struct {
+	// ConversationID is the channel or chat ID the message was sent to, or `webhook:<id>` for incoming webhooks.
+	ConversationID string
+
+	// ThreadID is the ID of the message this is a reply to, if any.
+	ThreadID string
+
+	ID   string
+	Text string
+	Card Card
+
+	// Updates is the number of times the message has been updated.
+	Updates int
+}
+
+// Card contains the content of an Adaptive Card.
+type Card struct {
+	// Text contains the text of all TextBlock elements and FactSet facts, one per line.
+	Text    string
+	Actions []Action
+}
+
+// Action is an action of an Adaptive Card.
+type Action struct {
+	Type  string
+	Title string
+	URL   string
+	Verb  string
+	Data  json.RawMessage
+}
+
+type cardElement struct {
+	Type  string
+	Text  string
+	Facts []struct {
+		Title string
+		Value string
+	}
+}
+
+// parseActivity will parse the text and first Adaptive Card of an activity.
+func parseActivity(req *http.Request) (text string, card Card, err error) {
+	var act struct {
+		Text        string
+		Attachments []struct {
+			ContentType string
+			Content     struct {
+				Body    []cardElement
+				Actions []Action
+			}
+		}
+	}
+	err = json.NewDecoder(req.Body).Decode(&act)
+	if err != nil {
+		return "", card, err
+	}
+
+	for _, a := range act.Attachments {
+		if a.ContentType != "application/vnd.microsoft.card.adaptive" {
+			continue
+		}
+
+		var lines []string
+		for _, e := range a.Content.Body {
+			switch e.Type {
+			case "TextBlock":
+				lines = append(lines, e.Text)
+			case "FactSet":
+				for _, f := range e.Facts {
+					lines = append(lines, fmt.Sprintf("%s: %s", f.Title, f.Value))
+				}
+			}
+		}
+		card.Text = strings.Join(lines, "\n")
+		card.Actions = a.Content.Actions
+		break
+	}
+
+	return act.Text, card, nil
+}
+
+func badRequest(w http.ResponseWriter, err error) {
+	writeJSON(w, http.StatusBadRequest, map[string]any{"error": map[string]string{"code": "BadArgument", "message": err.Error()}})
+}
+
+// ServeCreateConversation creates a new 1:1 chat.
+func (s *Server) ServeCreateConversation(w http.ResponseWriter, req *http.Request) {
+	var body struct {
+		Members []struct {
+			ID string
+		}
+	}
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		badRequest(w, err)
+		return
+	}
+	if len(body.Members) != 1 || body.Members[0].ID == "" {
+		badRequest(w, fmt.Errorf("exactly one member is required"))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]string{"id": ChatID(body.Members[0].ID)})
+}
+
+// ChatID returns the conversation ID of the 1:1 chat between the bot and a user.
+func ChatID(userID string) string { return "a:" + userID }
+
+// ServePostActivity sends a new message to a conversation.
+func (s *Server) ServePostActivity(w http.ResponseWriter, req *http.Request) {
+	text, card, err := parseActivity(req)
+	if err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	convID, threadID, _ := strings.Cut(req.PathValue("conversationID"), ";messageid=")
+	msg := &Message{
+		ConversationID: convID,
+		ThreadID:       threadID,
+		ID:             randID(),
+		Text:           text,
+		Card:           card,
+	}
+
+	s.mx.Lock()
+	s.messages = append(s.messages, msg)
+	s.mx.Unlock()
+
+	writeJSON(w, http.StatusCreated, map[string]string{"id": msg.ID})
+}
+
+// ServeUpdateActivity replaces a previously sent message.
+func (s *Server) ServeUpdateActivity(w http.ResponseWriter, req *http.Request) {
+	text, card, err := parseActivity(req)
+	if err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	convID, _, _ := strings.Cut(req.PathValue("conversationID"), ";messageid=")
+	id := req.PathValue("activityID")
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	for _, msg := range s.messages {
+		if msg.ConversationID != convID || msg.ID != id {
+			continue
+		}
+
+		msg.Text = text
+		msg.Card = card
+		msg.Updates++
+		writeJSON(w, http.StatusOK, map[string]string{"id": msg.ID})
+		return
+	}
+
+	writeJSON(w, http.StatusNotFound, map[string]any{"error": map[string]string{"code": "ActivityNotFoundInConversation"}})
+}
+
+// ServeWebhook accepts a message sent to an incoming webhook.
+func (s *Server) ServeWebhook(w http.ResponseWriter, req *http.Request) {
+	text, card, err := parseActivity(req)
+	if err != nil {
+		badRequest(w, err)
+		return
+	}
+
+	s.mx.Lock()
+	s.messages = append(s.messages, &Message{
+		ConversationID: "webhook:" + req.PathValue("webhookID"),
+		ID:             randID(),
+		Text:           text,
+		Card:           card,
+	})
+	s.mx.Unlock()
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Messages returns all messages sent to a conversation, in order.
+func (s *Server) Messages(conversationID string) []Message {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	var result []Message
+	for _, msg := range s.messages {
+		if msg.ConversationID != conversationID {
+			continue
+		}
+		result = append(result, *msg)
+	}
+
+	return result
+}
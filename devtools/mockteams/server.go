@@ -0,0 +1,135 @@
+package mockteams
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Server implements a mock Microsoft Teams API, including the Bot Framework connector, the token
+// and signing key endpoints, and incoming webhooks.
+type Server struct {
+	mux *http.ServeMux
+	key *rsa.PrivateKey
+
+	urlPrefix string
+
+	mx       sync.Mutex
+	app      AppInfo
+	tokens   map[string]bool
+	messages []*Message
+}
+
+// AppInfo contains information about the bot app registration.
+type AppInfo struct {
+	AppID     string
+	AppSecret string
+	TenantID  string
+
+	// MessagingEndpoint is where activities (e.g., card actions) are sent.
+	MessagingEndpoint string
+}
+
+// NewServer creates a new Server with a random app registration.
+func NewServer() *Server {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	srv := &Server{
+		mux: http.NewServeMux(),
+		key: key,
+		app: AppInfo{
+			AppID:     uuid.NewString(),
+			AppSecret: randID(),
+			TenantID:  uuid.NewString(),
+		},
+		tokens: make(map[string]bool),
+	}
+
+	srv.mux.HandleFunc("POST /{tenantID}/oauth2/v2.0/token", srv.ServeToken)
+	srv.mux.HandleFunc("GET /v1/.well-known/openidconfiguration", srv.ServeOpenIDConfig)
+	srv.mux.HandleFunc("GET /v1/.well-known/keys", srv.ServeKeys)
+	srv.mux.HandleFunc("POST /v3/conversations", srv.requireToken(srv.ServeCreateConversation))
+	srv.mux.HandleFunc("POST /v3/conversations/{conversationID}/activities", srv.requireToken(srv.ServePostActivity))
+	srv.mux.HandleFunc("PUT /v3/conversations/{conversationID}/activities/{activityID}", srv.requireToken(srv.ServeUpdateActivity))
+	srv.mux.HandleFunc("POST /webhook/{webhookID}", srv.ServeWebhook)
+
+	return srv
+}
+
+// SetURLPrefix will update the URL prefix for this server, it is used as the service URL.
+func (s *Server) SetURLPrefix(prefix string) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.urlPrefix = prefix
+}
+
+// ServiceURL returns the Bot Framework service URL of this server.
+func (s *Server) ServiceURL() string {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.urlPrefix + "/"
+}
+
+// SetApp will replace the app registration, empty values are left unchanged.
+func (s *Server) SetApp(app AppInfo) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if app.AppID != "" {
+		s.app.AppID = app.AppID
+	}
+	if app.AppSecret != "" {
+		s.app.AppSecret = app.AppSecret
+	}
+	if app.TenantID != "" {
+		s.app.TenantID = app.TenantID
+	}
+	if app.MessagingEndpoint != "" {
+		s.app.MessagingEndpoint = app.MessagingEndpoint
+	}
+}
+
+// App returns the current app registration.
+func (s *Server) App() AppInfo {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.app
+}
+
+// WebhookURL returns the URL of an incoming webhook with the given ID.
+func (s *Server) WebhookURL(id string) string {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.urlPrefix + "/webhook/" + id
+}
+
+// NewChannelID returns a new, random, channel ID.
+func NewChannelID() string { return "19:" + randID() + "@thread.tacv2" }
+
+func randID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		log.Println("ERROR:", err)
+	}
+}
+
+// ServeHTTP serves the Teams API.
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mux.ServeHTTP(w, req)
+}
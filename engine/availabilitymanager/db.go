@@ -0,0 +1,35 @@
+package availabilitymanager
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/util/icalutil"
+)
+
+// DB handles importing out-of-office periods from user availability feeds.
+type DB struct {
+	lock   *processinglock.Lock
+	client *http.Client
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.AvailabilityManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Version: 1,
+		Type:    processinglock.TypeAvailability,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DB{
+		lock:   lock,
+		client: icalutil.NewPublicClient(),
+	}, nil
+}
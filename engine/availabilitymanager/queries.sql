@@ -0,0 +1,100 @@
+-- name: AvailMgrFeedsDue :many
+SELECT
+    id
+FROM
+    user_availability_feeds
+WHERE
+    last_sync_at ISNULL
+    OR last_sync_at < now() - '15 minutes'::interval
+ORDER BY
+    last_sync_at NULLS FIRST
+LIMIT 100;
+
+-- name: AvailMgrFeed :one
+SELECT
+    *
+FROM
+    user_availability_feeds
+WHERE
+    id = $1;
+
+-- name: AvailMgrFeedForUpdate :one
+SELECT
+    *
+FROM
+    user_availability_feeds
+WHERE
+    id = $1
+FOR UPDATE;
+
+-- name: AvailMgrSetSynced :exec
+UPDATE
+    user_availability_feeds
+SET
+    last_sync_at = now(),
+    last_error = $2
+WHERE
+    id = $1;
+
+-- name: AvailMgrPeriods :many
+SELECT
+    id,
+    substitute_user_id,
+    summary,
+    start_time,
+    end_time
+FROM
+    user_unavailability
+WHERE
+    feed_id = $1;
+
+-- name: AvailMgrDeletePeriods :exec
+DELETE FROM user_unavailability
+WHERE id = ANY (@ids::uuid[]);
+
+-- name: AvailMgrInsertPeriod :exec
+INSERT INTO user_unavailability(id, feed_id, user_id, substitute_user_id, summary, start_time, end_time)
+    VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: AvailMgrMissingOverrides :many
+-- Returns the schedules each out-of-office period with a substitute still needs an override for. Users
+-- are on a schedule if they are the target of a rule, or a (non-shadow) participant of a targeted rotation.
+SELECT
+    u.id AS unavailability_id,
+    s.schedule_id,
+    u.user_id,
+    u.substitute_user_id::uuid AS substitute_user_id,
+    greatest(u.start_time, now())::timestamptz AS start_time,
+    u.end_time
+FROM
+    user_unavailability u
+    JOIN (
+        SELECT DISTINCT
+            r.schedule_id,
+            coalesce(r.tgt_user_id, p.user_id) AS user_id
+        FROM
+            schedule_rules r
+        LEFT JOIN rotation_participants p ON p.rotation_id = r.tgt_rotation_id
+            AND NOT p.shadow) s ON s.user_id = u.user_id
+WHERE
+    u.feed_id = $1
+    AND u.substitute_user_id NOTNULL
+    AND u.end_time > now() + '1 minute'::interval
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            user_unavailability_overrides o
+        WHERE
+            o.unavailability_id = u.id
+            AND o.schedule_id = s.schedule_id);
+
+-- name: AvailMgrCreateOverride :exec
+INSERT INTO user_overrides(id, add_user_id, remove_user_id, start_time, end_time, tgt_schedule_id)
+    VALUES (@id, @add_user_id, @remove_user_id, @start_time, @end_time, @schedule_id);
+
+-- name: AvailMgrSetOverride :exec
+INSERT INTO user_unavailability_overrides(unavailability_id, schedule_id, override_id)
+    VALUES ($1, $2, $3)
+ON CONFLICT (unavailability_id, schedule_id)
+    DO NOTHING;
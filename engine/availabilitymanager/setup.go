@@ -0,0 +1,42 @@
+package availabilitymanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/target/goalert/engine/processinglock"
+)
+
+const (
+	QueueName    = "availability-manager"
+	PrioritySync = 4
+)
+
+var _ processinglock.Setupable = &DB{}
+
+// Setup implements processinglock.Setupable.
+func (db *DB) Setup(ctx context.Context, args processinglock.SetupArgs) error {
+	river.AddWorker(args.Workers, river.WorkFunc(db.syncFeeds))
+
+	err := args.River.Queues().Add(QueueName, river.QueueConfig{MaxWorkers: 1})
+	if err != nil {
+		return fmt.Errorf("add queue: %w", err)
+	}
+
+	args.River.PeriodicJobs().AddMany([]*river.PeriodicJob{
+		river.NewPeriodicJob(
+			river.PeriodicInterval(5*time.Minute),
+			func() (river.JobArgs, *river.InsertOpts) {
+				return SyncArgs{}, &river.InsertOpts{
+					Queue:    QueueName,
+					Priority: PrioritySync,
+				}
+			},
+			&river.PeriodicJobOpts{RunOnStart: true},
+		),
+	})
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package availabilitymanager
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+	"github.com/target/goalert/availability"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/icalutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+type SyncArgs struct{}
+
+func (SyncArgs) Kind() string { return "availability-manager-sync" }
+
+// syncFeeds will import out-of-office periods for each feed that is due, and create overrides for
+// feeds with a substitute.
+func (db *DB) syncFeeds(ctx context.Context, j *river.Job[SyncArgs]) error {
+	ctx = permission.SystemContext(ctx, "AvailabilityManager")
+
+	var ids []uuid.UUID
+	err := db.lock.WithTxShared(ctx, func(ctx context.Context, tx *sql.Tx) (err error) {
+		ids, err = gadb.New(tx).AvailMgrFeedsDue(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("get feeds: %w", err)
+	}
+
+	for _, id := range ids {
+		ctx := log.WithField(ctx, "FeedID", id)
+		err = db.syncFeed(ctx, id)
+		if err != nil {
+			// record the error, but keep going so one feed can't block the others
+			log.Log(ctx, fmt.Errorf("sync availability feed: %w", err))
+			db.setSyncError(ctx, id, "internal error")
+			continue
+		}
+		db.createOverrides(ctx, id)
+	}
+
+	return nil
+}
+
+// syncFeed will replace the out-of-office periods of a feed with those currently in its calendar. If the
+// calendar cannot be fetched or parsed, the error is recorded and existing periods are kept.
+func (db *DB) syncFeed(ctx context.Context, id uuid.UUID) error {
+	var feed gadb.UserAvailabilityFeed
+	err := db.lock.WithTxShared(ctx, func(ctx context.Context, tx *sql.Tx) (err error) {
+		feed, err = gadb.New(tx).AvailMgrFeed(ctx, id)
+		return err
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get feed: %w", err)
+	}
+
+	periods, fetchErr := db.fetchPeriods(ctx, feed)
+	if fetchErr != nil {
+		log.Debugf(ctx, "fetch availability feed: %v", fetchErr)
+	}
+
+	return db.lock.WithTxShared(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		q := gadb.New(tx)
+		feed, err := q.AvailMgrFeedForUpdate(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("lock feed: %w", err)
+		}
+		if fetchErr != nil {
+			return q.AvailMgrSetSynced(ctx, gadb.AvailMgrSetSyncedParams{ID: id, LastError: feedErrorMessage(fetchErr)})
+		}
+
+		existing, err := q.AvailMgrPeriods(ctx, id)
+		if err != nil {
+			return fmt.Errorf("get periods: %w", err)
+		}
+
+		del, add := diffPeriods(existing, periods, feed.SubstituteUserID)
+		err = availability.EndOverrides(ctx, q, del)
+		if err != nil {
+			return err
+		}
+		if len(del) > 0 {
+			err = q.AvailMgrDeletePeriods(ctx, del)
+			if err != nil {
+				return fmt.Errorf("delete periods: %w", err)
+			}
+		}
+		for _, p := range add {
+			err = q.AvailMgrInsertPeriod(ctx, gadb.AvailMgrInsertPeriodParams{
+				ID:               uuid.New(),
+				FeedID:           id,
+				UserID:           feed.UserID,
+				SubstituteUserID: feed.SubstituteUserID,
+				Summary:          p.Summary,
+				StartTime:        p.Start,
+				EndTime:          p.End,
+			})
+			if err != nil {
+				return fmt.Errorf("insert period: %w", err)
+			}
+		}
+
+		return q.AvailMgrSetSynced(ctx, gadb.AvailMgrSetSyncedParams{ID: id})
+	})
+}
+
+// setSyncError will record an error for a feed that could not be synced, so it is not retried until
+// it is due again.
+func (db *DB) setSyncError(ctx context.Context, id uuid.UUID, msg string) {
+	err := db.lock.WithTxShared(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return gadb.New(tx).AvailMgrSetSynced(ctx, gadb.AvailMgrSetSyncedParams{ID: id, LastError: msg})
+	})
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("record availability feed error: %w", err))
+	}
+}
+
+// feedErrorMessage returns a description of err that is safe to show to the owner of a feed. Network
+// errors are replaced with a generic message, so details of the network (e.g., addresses) are not exposed.
+func feedErrorMessage(err error) string {
+	var netErr net.Error
+	if errors.Is(err, icalutil.ErrAddressNotAllowed) || errors.As(err, &netErr) {
+		return "fetch calendar: " + icalutil.FetchErrorMessage(err)
+	}
+
+	return err.Error()
+}
+
+func (db *DB) fetchPeriods(ctx context.Context, feed gadb.UserAvailabilityFeed) ([]availability.Period, error) {
+	loc, err := util.LoadLocation(feed.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("load time zone: %w", err)
+	}
+
+	data, err := icalutil.Fetch(ctx, db.client, feed.Url)
+	if err != nil {
+		return nil, fmt.Errorf("fetch calendar: %w", err)
+	}
+
+	periods, err := availability.ParseFeed(bytes.NewReader(data), feed.Keyword, loc, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("parse calendar: %w", err)
+	}
+
+	return periods, nil
+}
+
+// diffPeriods returns the IDs of existing periods that are no longer wanted, and the wanted periods that
+// do not exist yet. Periods with a different substitute are replaced so their overrides are recreated.
+func diffPeriods(existing []gadb.AvailMgrPeriodsRow, want []availability.Period, sub uuid.NullUUID) (del []uuid.UUID, add []availability.Period) {
+	type key struct {
+		start, end int64
+		summary    string
+		sub        uuid.NullUUID
+	}
+
+	wantKeys := make(map[key]bool, len(want))
+	for _, p := range want {
+		wantKeys[key{start: p.Start.Unix(), end: p.End.Unix(), summary: p.Summary, sub: sub}] = true
+	}
+
+	have := make(map[key]bool, len(existing))
+	for _, row := range existing {
+		k := key{start: row.StartTime.Unix(), end: row.EndTime.Unix(), summary: row.Summary, sub: row.SubstituteUserID}
+		if !wantKeys[k] || have[k] {
+			del = append(del, row.ID)
+			continue
+		}
+		have[k] = true
+	}
+
+	for _, p := range want {
+		if have[key{start: p.Start.Unix(), end: p.End.Unix(), summary: p.Summary, sub: sub}] {
+			continue
+		}
+		add = append(add, p)
+	}
+
+	return del, add
+}
+
+// createOverrides will create an override, replacing the user with the substitute, on each schedule the
+// user is on for every out-of-office period of the feed. Overrides that cannot be created (e.g., because
+// they conflict with an existing override) are skipped and not retried; the schedule will show a notice
+// instead.
+func (db *DB) createOverrides(ctx context.Context, feedID uuid.UUID) {
+	var rows []gadb.AvailMgrMissingOverridesRow
+	err := db.lock.WithTxShared(ctx, func(ctx context.Context, tx *sql.Tx) (err error) {
+		rows, err = gadb.New(tx).AvailMgrMissingOverrides(ctx, feedID)
+		return err
+	})
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("get missing overrides: %w", err))
+		return
+	}
+
+	for _, row := range rows {
+		ctx := log.WithField(ctx, "ScheduleID", row.ScheduleID)
+		err := db.lock.WithTxShared(ctx, func(ctx context.Context, tx *sql.Tx) error {
+			q := gadb.New(tx)
+			id := uuid.New()
+			err := q.AvailMgrCreateOverride(ctx, gadb.AvailMgrCreateOverrideParams{
+				ID:           id,
+				AddUserID:    uuid.NullUUID{UUID: row.SubstituteUserID, Valid: true},
+				RemoveUserID: uuid.NullUUID{UUID: row.UserID, Valid: true},
+				StartTime:    row.StartTime,
+				EndTime:      row.EndTime,
+				ScheduleID:   row.ScheduleID,
+			})
+			if err != nil {
+				return err
+			}
+
+			return q.AvailMgrSetOverride(ctx, gadb.AvailMgrSetOverrideParams{
+				UnavailabilityID: row.UnavailabilityID,
+				ScheduleID:       row.ScheduleID,
+				OverrideID:       uuid.NullUUID{UUID: id, Valid: true},
+			})
+		})
+		if isCheckViolation(err) {
+			log.Debugf(ctx, "skipping availability override: %v", err)
+			err = db.lock.WithTxShared(ctx, func(ctx context.Context, tx *sql.Tx) error {
+				return gadb.New(tx).AvailMgrSetOverride(ctx, gadb.AvailMgrSetOverrideParams{
+					UnavailabilityID: row.UnavailabilityID,
+					ScheduleID:       row.ScheduleID,
+				})
+			})
+		}
+		if isForeignKeyViolation(err) {
+			// period or schedule was deleted
+			continue
+		}
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("create availability override: %w", err))
+		}
+	}
+}
+
+func isCheckViolation(err error) bool {
+	dbErr := sqlutil.MapError(err)
+	return dbErr != nil && dbErr.Code == "23514"
+}
+
+func isForeignKeyViolation(err error) bool {
+	dbErr := sqlutil.MapError(err)
+	return dbErr != nil && dbErr.Code == "23503"
+}
@@ -0,0 +1,51 @@
+package availabilitymanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/availability"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/util/icalutil"
+)
+
+func TestDiffPeriods(t *testing.T) {
+	start := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	sub := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	keep := gadb.AvailMgrPeriodsRow{ID: uuid.New(), SubstituteUserID: sub, Summary: "PTO", StartTime: start, EndTime: start.Add(24 * time.Hour)}
+	dup := keep
+	dup.ID = uuid.New()
+	moved := gadb.AvailMgrPeriodsRow{ID: uuid.New(), SubstituteUserID: sub, Summary: "Trip", StartTime: start, EndTime: start.Add(48 * time.Hour)}
+
+	want := []availability.Period{
+		{Summary: "PTO", Start: start, End: start.Add(24 * time.Hour)},
+		{Summary: "Trip", Start: start.Add(24 * time.Hour), End: start.Add(48 * time.Hour)},
+	}
+
+	del, add := diffPeriods([]gadb.AvailMgrPeriodsRow{keep, dup, moved}, want, sub)
+	assert.Equal(t, []uuid.UUID{dup.ID, moved.ID}, del)
+	assert.Equal(t, want[1:], add)
+
+	// changing the substitute replaces all periods
+	del, add = diffPeriods([]gadb.AvailMgrPeriodsRow{keep}, want[:1], uuid.NullUUID{})
+	assert.Equal(t, []uuid.UUID{keep.ID}, del)
+	assert.Equal(t, want[:1], add)
+}
+
+func TestFeedErrorMessage(t *testing.T) {
+	dialErr := fmt.Errorf("fetch calendar: %w", &url.Error{Op: "Get", URL: "http://cal.example.com", Err: &net.OpError{Op: "dial", Net: "tcp", Addr: &net.TCPAddr{IP: net.IPv4(10, 1, 2, 3), Port: 80}, Err: errors.New("connection refused")}})
+	assert.Equal(t, "fetch calendar: request failed", feedErrorMessage(dialErr))
+
+	blocked := fmt.Errorf("fetch calendar: %w", &url.Error{Op: "Get", URL: "http://localhost", Err: &net.OpError{Op: "dial", Net: "tcp", Err: icalutil.ErrAddressNotAllowed}})
+	assert.Equal(t, "fetch calendar: address is not allowed", feedErrorMessage(blocked))
+
+	assert.Equal(t, "fetch calendar: request timed out", feedErrorMessage(fmt.Errorf("fetch calendar: %w", context.DeadlineExceeded)))
+	assert.Equal(t, "fetch calendar: unexpected status 404 Not Found", feedErrorMessage(errors.New("fetch calendar: unexpected status 404 Not Found")))
+}
@@ -33,6 +33,7 @@ func newBackend(db *sql.DB) (*backend, error) {
 				alert_id,
 				service_id,
 				contact_method_id,
+				channel_id,
 				created_at
 			FROM outgoing_messages
 			WHERE id = $1
@@ -54,13 +55,14 @@ func (b *backend) FindOne(ctx context.Context, id string) (*callback, error) {
 	var c callback
 	var alertID sql.NullInt64
 	var serviceID sql.NullString
-	var cmID uuid.NullUUID
-	err = b.findOne.QueryRowContext(ctx, id).Scan(&c.ID, &alertID, &serviceID, &cmID, &c.CreatedAt)
+	var cmID, chanID uuid.NullUUID
+	err = b.findOne.QueryRowContext(ctx, id).Scan(&c.ID, &alertID, &serviceID, &cmID, &chanID, &c.CreatedAt)
 	if err != nil {
 		return nil, err
 	}
 	c.AlertID = int(alertID.Int64)
 	c.ServiceID = serviceID.String
 	c.ContactMethodID = cmID.UUID
+	c.ChannelID = chanID.UUID
 	return &c, nil
 }
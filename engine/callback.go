@@ -13,6 +13,7 @@ type callback struct {
 	AlertID         int
 	ServiceID       string
 	ContactMethodID uuid.UUID
+	ChannelID       uuid.UUID
 	CreatedAt       time.Time
 }
 
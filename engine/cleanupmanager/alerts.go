@@ -34,7 +34,7 @@ func (db *DB) CleanupAlerts(ctx context.Context, j *river.Job[AlertArgs]) error
 				idsInt = append(idsInt, int(id))
 			}
 
-			_, err = db.alertStore.UpdateManyAlertStatus(ctx, alert.StatusClosed, idsInt, alertlog.AutoClose{AlertAutoCloseDays: cfg.Maintenance.AlertAutoCloseDays})
+			_, _, err = db.alertStore.UpdateManyAlertStatus(ctx, alert.StatusClosed, idsInt, alertlog.AutoClose{AlertAutoCloseDays: cfg.Maintenance.AlertAutoCloseDays})
 			if err != nil {
 				return false, fmt.Errorf("update alerts: %w", err)
 			}
@@ -59,5 +59,16 @@ func (db *DB) CleanupAlerts(ctx context.Context, j *river.Job[AlertArgs]) error
 		}
 	}
 
+	err := db.whileWork(ctx, func(ctx context.Context, tx *sql.Tx) (done bool, err error) {
+		count, err := gadb.New(tx).CleanupMgrDeleteOldDedupHistory(ctx)
+		if err != nil {
+			return false, fmt.Errorf("delete old dedup history: %w", err)
+		}
+		return count < 100, nil
+	})
+	if err != nil {
+		return fmt.Errorf("cleanup dedup history: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,25 @@
+package cleanupmanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/riverqueue/river"
+	"github.com/target/goalert/gadb"
+)
+
+type OverrideRequestArgs struct{}
+
+func (OverrideRequestArgs) Kind() string { return "cleanup-manager-override-requests" }
+
+// CleanupOverrideRequests will mark pending override requests as expired once their end time has passed.
+func (db *DB) CleanupOverrideRequests(ctx context.Context, j *river.Job[OverrideRequestArgs]) error {
+	return db.whileWork(ctx, func(ctx context.Context, tx *sql.Tx) (done bool, err error) {
+		count, err := gadb.New(tx).CleanupMgrExpireOverrideRequests(ctx)
+		if err != nil {
+			return false, fmt.Errorf("expire override requests: %w", err)
+		}
+		return count < 100, nil
+	})
+}
@@ -15,6 +15,21 @@ WHERE id = ANY (
         FOR UPDATE
             SKIP LOCKED);
 
+-- name: CleanupMgrDeleteOldDedupHistory :execrows
+-- CleanupMgrDeleteOldDedupHistory will delete dedup history entries that are older than the longest possible reopen or flap window.
+DELETE FROM alert_dedup_history
+WHERE (service_id, dedup_key) IN (
+        SELECT
+            service_id,
+            dedup_key
+        FROM
+            alert_dedup_history
+        WHERE
+            closed_at < now() - '1 day'::interval
+        LIMIT 100
+        FOR UPDATE
+            SKIP LOCKED);
+
 -- name: CleanupMgrFindStaleAlerts :many
 -- CleanupMgrFindStaleAlerts will find alerts that are triggered or active and have no activity in specified number of days.
 SELECT
@@ -224,3 +239,21 @@ WHERE
         FOR UPDATE
             SKIP LOCKED);
 
+-- name: CleanupMgrExpireOverrideRequests :execrows
+-- CleanupMgrExpireOverrideRequests will mark pending override requests as expired once their end time has passed.
+UPDATE
+    override_requests
+SET
+    status = 'expired'
+WHERE
+    id = ANY (
+        SELECT
+            id
+        FROM
+            override_requests
+        WHERE
+            status = 'pending'
+            AND end_time <= now()
+        LIMIT 100
+        FOR UPDATE
+            SKIP LOCKED);
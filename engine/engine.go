@@ -8,10 +8,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/app/lifecycle"
 	"github.com/target/goalert/auth/authlink"
+	"github.com/target/goalert/engine/availabilitymanager"
 	"github.com/target/goalert/engine/cleanupmanager"
 	"github.com/target/goalert/engine/compatmanager"
 	"github.com/target/goalert/engine/escalationmanager"
@@ -23,6 +25,7 @@ import (
 	"github.com/target/goalert/engine/rotationmanager"
 	"github.com/target/goalert/engine/schedulemanager"
 	"github.com/target/goalert/engine/signalmgr"
+	"github.com/target/goalert/engine/snoozemanager"
 	"github.com/target/goalert/engine/statusmgr"
 	"github.com/target/goalert/engine/verifymanager"
 	"github.com/target/goalert/expflag"
@@ -32,6 +35,7 @@ import (
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
 )
 
 // Engine handles automatic escalation of unacknowledged(triggered) alerts, as well as
@@ -99,11 +103,11 @@ func NewEngine(ctx context.Context, db *sql.DB, c *Config) (*Engine, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "rotation management backend")
 	}
-	schedMgr, err := schedulemanager.NewDB(ctx, db)
+	schedMgr, err := schedulemanager.NewDB(ctx, db, c.OnCallStore)
 	if err != nil {
 		return nil, errors.Wrap(err, "schedule management backend")
 	}
-	epMgr, err := escalationmanager.NewDB(ctx, db, c.AlertLogStore)
+	epMgr, err := escalationmanager.NewDB(ctx, db, c.AlertStore, c.AlertLogStore)
 	if err != nil {
 		return nil, errors.Wrap(err, "alert escalation backend")
 	}
@@ -115,6 +119,10 @@ func NewEngine(ctx context.Context, db *sql.DB, c *Config) (*Engine, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "status update backend")
 	}
+	snoozeMgr, err := snoozemanager.NewDB(ctx, db, c.AlertLogStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "alert snooze backend")
+	}
 	verifyMgr, err := verifymanager.NewDB(ctx, db)
 	if err != nil {
 		return nil, errors.Wrap(err, "verification backend")
@@ -135,11 +143,16 @@ func NewEngine(ctx context.Context, db *sql.DB, c *Config) (*Engine, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "compatibility backend")
 	}
+	availMgr, err := availabilitymanager.NewDB(ctx, db)
+	if err != nil {
+		return nil, errors.Wrap(err, "availability backend")
+	}
 
 	p.modules = []processinglock.Module{
 		compatMgr,
 		rotMgr,
 		schedMgr,
+		snoozeMgr,
 		epMgr,
 		ncMgr,
 		statMgr,
@@ -147,6 +160,7 @@ func NewEngine(ctx context.Context, db *sql.DB, c *Config) (*Engine, error) {
 		hbMgr,
 		cleanMgr,
 		metricsMgr,
+		availMgr,
 	}
 
 	if expflag.ContextHas(ctx, expflag.UnivKeys) {
@@ -329,10 +343,50 @@ func (p *Engine) SetSendResult(ctx context.Context, res *notification.SendResult
 
 // ReceiveSubject will process a notification result.
 func (p *Engine) ReceiveSubject(ctx context.Context, providerID, subjectID, callbackID string, result notification.Result) error {
-	cb, err := p.b.FindOne(ctx, callbackID)
+	cb, ctx, err := p.subjectCallbackContext(ctx, providerID, subjectID, callbackID)
 	if err != nil {
 		return err
 	}
+
+	return p.receiveResult(ctx, cb, result)
+}
+
+// ReceiveSubjectSnooze will process a snooze response for the requested duration.
+func (p *Engine) ReceiveSubjectSnooze(ctx context.Context, providerID, subjectID, callbackID string, dur time.Duration) error {
+	cb, ctx, err := p.subjectCallbackContext(ctx, providerID, subjectID, callbackID)
+	if err != nil {
+		return err
+	}
+
+	return p.receiveSnooze(ctx, cb, dur)
+}
+
+// Receive will process a notification result.
+func (p *Engine) Receive(ctx context.Context, callbackID string, result notification.Result) error {
+	cb, ctx, err := p.callbackContext(ctx, callbackID)
+	if err != nil {
+		return err
+	}
+
+	return p.receiveResult(ctx, cb, result)
+}
+
+// ReceiveSnooze will process a snooze response for the requested duration.
+func (p *Engine) ReceiveSnooze(ctx context.Context, callbackID string, dur time.Duration) error {
+	cb, ctx, err := p.callbackContext(ctx, callbackID)
+	if err != nil {
+		return err
+	}
+
+	return p.receiveSnooze(ctx, cb, dur)
+}
+
+// subjectCallbackContext will lookup the callback and return a context for the user linked to the provider/subject.
+func (p *Engine) subjectCallbackContext(ctx context.Context, providerID, subjectID, callbackID string) (*callback, context.Context, error) {
+	cb, err := p.b.FindOne(ctx, callbackID)
+	if err != nil {
+		return nil, nil, err
+	}
 	if cb.ServiceID != "" {
 		ctx = log.WithField(ctx, "ServiceID", cb.ServiceID)
 	}
@@ -345,10 +399,10 @@ func (p *Engine) ReceiveSubject(ctx context.Context, providerID, subjectID, call
 		usr, err = p.cfg.UserStore.FindOneBySubject(ctx, providerID, subjectID)
 	})
 	if err != nil {
-		return fmt.Errorf("failed to find user: %w", err)
+		return nil, nil, fmt.Errorf("failed to find user: %w", err)
 	}
 	if usr == nil {
-		return &notification.UnknownSubjectError{
+		return nil, nil, &notification.UnknownSubjectError{
 			AlertID: cb.AlertID,
 		}
 	}
@@ -358,37 +412,15 @@ func (p *Engine) ReceiveSubject(ctx context.Context, providerID, subjectID, call
 		ID:   callbackID,
 	})
 
-	var newStatus alert.Status
-	switch result {
-	case notification.ResultAcknowledge:
-		newStatus = alert.StatusActive
-	case notification.ResultResolve:
-		newStatus = alert.StatusClosed
-	case notification.ResultEscalate:
-		err = p.a.EscalateAsOf(ctx, cb.AlertID, cb.CreatedAt)
-		if err != nil {
-			return fmt.Errorf("escalate alert: %w", err)
-		}
-		return nil
-	default:
-		return errors.New("unknown result type")
-	}
-
-	if cb.AlertID != 0 {
-		return errors.Wrap(p.a.UpdateStatus(ctx, cb.AlertID, newStatus), "update alert")
-	}
-	if cb.ServiceID != "" {
-		return errors.Wrap(p.a.UpdateStatusByService(ctx, cb.ServiceID, newStatus), "update all alerts")
-	}
-
-	return errors.New("unknown callback type")
+	return cb, ctx, nil
 }
 
-// Receive will process a notification result.
-func (p *Engine) Receive(ctx context.Context, callbackID string, result notification.Result) error {
+// callbackContext will lookup the callback and return a context for the user that owns the contact method, or
+// for the notification channel the message was sent to.
+func (p *Engine) callbackContext(ctx context.Context, callbackID string) (*callback, context.Context, error) {
 	cb, err := p.b.FindOne(ctx, callbackID)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	if cb.ServiceID != "" {
 		ctx = log.WithField(ctx, "ServiceID", cb.ServiceID)
@@ -396,6 +428,14 @@ func (p *Engine) Receive(ctx context.Context, callbackID string, result notifica
 	if cb.AlertID != 0 {
 		ctx = log.WithField(ctx, "AlertID", cb.AlertID)
 	}
+	if cb.ContactMethodID == uuid.Nil && cb.ChannelID != uuid.Nil {
+		ctx = permission.SystemContext(ctx, "NotificationCallback")
+		ctx = permission.SourceContext(ctx, &permission.SourceInfo{
+			Type: permission.SourceTypeNotificationChannel,
+			ID:   cb.ChannelID.String(),
+		})
+		return cb, ctx, nil
+	}
 
 	var usr *user.User
 	permission.SudoContext(ctx, func(ctx context.Context) {
@@ -410,13 +450,17 @@ func (p *Engine) Receive(ctx context.Context, callbackID string, result notifica
 		}
 	})
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	ctx = permission.UserSourceContext(ctx, usr.ID, usr.Role, &permission.SourceInfo{
 		Type: permission.SourceTypeNotificationCallback,
 		ID:   callbackID,
 	})
 
+	return cb, ctx, nil
+}
+
+func (p *Engine) receiveResult(ctx context.Context, cb *callback, result notification.Result) error {
 	var newStatus alert.Status
 	switch result {
 	case notification.ResultAcknowledge:
@@ -424,7 +468,7 @@ func (p *Engine) Receive(ctx context.Context, callbackID string, result notifica
 	case notification.ResultResolve:
 		newStatus = alert.StatusClosed
 	case notification.ResultEscalate:
-		err = p.a.EscalateAsOf(ctx, cb.AlertID, cb.CreatedAt)
+		err := p.a.EscalateAsOf(ctx, cb.AlertID, cb.CreatedAt)
 		if err != nil {
 			return fmt.Errorf("escalate alert: %w", err)
 		}
@@ -443,6 +487,14 @@ func (p *Engine) Receive(ctx context.Context, callbackID string, result notifica
 	return errors.New("unknown callback type")
 }
 
+func (p *Engine) receiveSnooze(ctx context.Context, cb *callback, dur time.Duration) error {
+	if cb.AlertID == 0 {
+		return validation.NewGenericError("snooze is only supported for individual alerts")
+	}
+
+	return errors.Wrap(p.a.SnoozeAlert(ctx, cb.AlertID, dur), "snooze alert")
+}
+
 // Start will enable all associated contact methods of `value` with type `t`. This should
 // be invoked if a user, for example, responds with `START` via sms.
 func (p *Engine) Start(ctx context.Context, d gadb.DestV1) error {
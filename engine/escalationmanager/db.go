@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/engine/processinglock"
 	"github.com/target/goalert/util"
@@ -19,20 +20,29 @@ type DB struct {
 	lockStmt     *sql.Stmt
 	updateOnCall *sql.Stmt
 
+	findActiveHours *sql.Stmt
+	setStepsActive  *sql.Stmt
+
+	setMaintActive   *sql.Stmt
+	findMaintAlerts  *sql.Stmt
+	releaseMaintHeld *sql.Stmt
+
 	newPolicies      *sql.Stmt
 	deletedSteps     *sql.Stmt
 	normalEscalation *sql.Stmt
+	roundRobin       *sql.Stmt
 
 	log *alertlog.Store
+	a   *alert.Store
 }
 
 // Name returns the name of the module.
 func (db *DB) Name() string { return "Engine.EscalationManager" }
 
 // NewDB creates a new DB.
-func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
+func NewDB(ctx context.Context, db *sql.DB, a *alert.Store, log *alertlog.Store) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
-		Version: 4,
+		Version: 10,
 		Type:    processinglock.TypeEscalation,
 	})
 	if err != nil {
@@ -43,21 +53,109 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 
 	return &DB{
 		log:  log,
+		a:    a,
 		lock: lock,
 
 		lockStmt: p.P(`lock escalation_policy_steps in share mode`),
 
+		findActiveHours: p.P(`
+			select
+				id,
+				coalesce(active_weekdays, '{}'),
+				coalesce(active_start, '00:00'),
+				coalesce(active_end, '00:00'),
+				active_time_zone,
+				is_active,
+				now()
+			from escalation_policy_steps
+			where active_time_zone notnull or not is_active
+		`),
+		setStepsActive: p.P(`update escalation_policy_steps set is_active = $2 where id = any($1)`),
+
+		setMaintActive: p.P(`update service_maintenance_windows set active_since = $2 where id = $1`),
+
+		// find any open alerts created during the occurrence of a maintenance window, for all services it applies to
+		findMaintAlerts: p.P(`
+			select a.id
+			from alerts a
+			join service_maintenance_windows w on w.id = $1
+			where
+				a.status != 'closed' and
+				a.created_at >= $2 and
+				(
+					a.service_id = w.service_id or
+					exists (
+						select null
+						from labels l
+						where
+							l.tgt_service_id = a.service_id and
+							l.key = w.label_key and
+							(w.label_value = '' or l.value = w.label_value)
+					)
+				)
+		`),
+
+		// release notifications held for a maintenance window, for services no longer in maintenance
+		releaseMaintHeld: p.P(`
+			update outgoing_messages msg
+			set quiet_until = null
+			from service_maintenance_windows w
+			where
+				w.id = $1 and
+				msg.message_type = 'alert_notification' and
+				msg.last_status = 'pending' and
+				msg.quiet_until > now() and
+				(
+					msg.service_id = w.service_id or
+					exists (
+						select null
+						from labels l
+						where
+							l.tgt_service_id = msg.service_id and
+							l.key = w.label_key and
+							(w.label_value = '' or l.value = w.label_value)
+					)
+				) and
+				not svc_maint_window_active(msg.service_id)
+		`),
+
 		updateOnCall: p.P(`
-			with on_call as (
+			with active_parts as (
+				select rState.rotation_id, part.user_id, part.shadow
+				from rotation_state rState
+				join rotation_participants part on part.id = rState.rotation_participant_id
+				union
+				select ap.rotation_id, part.user_id, part.shadow
+				from rotation_active_participants ap
+				join rotation_participants part on part.id = ap.rotation_participant_id
+			), sources as (
+				select step.id step_id, act.user_id, false is_shadow
+				from escalation_policy_steps step
+				join escalation_policy_actions act on act.escalation_policy_step_id = step.id
+				where act.user_id notnull
+				union all
+				select step.id step_id, part.user_id, part.shadow
+				from escalation_policy_steps step
+				join escalation_policy_actions act on act.escalation_policy_step_id = step.id
+				join active_parts part on part.rotation_id = act.rotation_id
+				union all
 				select
 					step.id step_id,
-					coalesce(act.user_id, part.user_id, sched.user_id) user_id
+					sched.user_id,
+					-- on call for the schedule only as a shadow participant of its rotations
+					coalesce((
+						select bool_and(part.shadow)
+						from schedule_rules rule
+						join active_parts part on part.rotation_id = rule.tgt_rotation_id and part.user_id = sched.user_id
+						where rule.schedule_id = act.schedule_id
+					), false)
 				from escalation_policy_steps step
 				join escalation_policy_actions act on act.escalation_policy_step_id = step.id
-				left join rotation_state rState on rState.rotation_id = act.rotation_id
-				left join rotation_participants part on part.id = rState.rotation_participant_id
-				left join schedule_on_call_users sched on sched.schedule_id = act.schedule_id and sched.end_time isnull
-				where coalesce(act.user_id, part.user_id, sched.user_id) notnull
+				join schedule_on_call_users sched on sched.schedule_id = act.schedule_id and sched.end_time isnull
+			), on_call as (
+				select step_id, user_id, bool_and(is_shadow) is_shadow
+				from sources
+				group by step_id, user_id
 			), ended as (
 				select
 				ep_step_id step_id,
@@ -75,9 +173,18 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					ep.ep_step_id = ended.step_id and
 					ep.user_id = ended.user_id and
 					ep.end_time isnull
-			) 
-			insert into ep_step_on_call_users (ep_step_id, user_id)
-			select step_id, user_id
+			), _shadow as (
+				update ep_step_on_call_users ep
+				set is_shadow = on_call.is_shadow
+				from on_call
+				where
+					ep.ep_step_id = on_call.step_id and
+					ep.user_id = on_call.user_id and
+					ep.end_time isnull and
+					ep.is_shadow != on_call.is_shadow
+			)
+			insert into ep_step_on_call_users (ep_step_id, user_id, is_shadow)
+			select step_id, user_id, is_shadow
 			from on_call
 			on conflict do nothing
 			returning ep_step_id, user_id
@@ -100,22 +207,33 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 
 		newPolicies: p.P(`
 			with to_escalate as (
-				select alert_id, step.id ep_step_id, step.delay, step.escalation_policy_id, a.service_id
+				select alert_id, step.id ep_step_id, step.delay, step.mode, step.round_robin_minutes, step.escalation_policy_id, a.service_id, step.step_number skipped, not ep_has_active_step(state.escalation_policy_id) no_active_step
 				from escalation_policy_state state
 				join escalation_policy_steps step on
 					step.escalation_policy_id = state.escalation_policy_id and
-					step.step_number = 0
-				join alerts a on a.id = state.alert_id and (a.status = 'triggered' or state.force_escalation)
-				join services s on a.service_id = s.id and s.maintenance_expires_at isnull
+					step.step_number = (
+						select min(s.step_number)
+						from escalation_policy_steps s
+						where s.escalation_policy_id = state.escalation_policy_id and (s.is_active or not ep_has_active_step(state.escalation_policy_id))
+					)
+				join alerts a on a.id = state.alert_id and ((a.status = 'triggered' and (a.flapping_until isnull or a.flapping_until <= now())) or state.force_escalation)
+				join services s on a.service_id = s.id and s.maintenance_expires_at isnull and not svc_maint_window_active(s.id)
 				where state.last_escalation isnull
 				for update skip locked
 				limit 1000
 			), _step_cycles as (
 				select esc.alert_id, on_call.user_id, esc.ep_step_id
 				from to_escalate esc
-				join ep_step_on_call_users on_call on
-					on_call.end_time isnull and
-					on_call.ep_step_id = esc.ep_step_id
+				join lateral (
+					select oc.user_id
+					from ep_step_on_call_users oc
+					where
+						oc.end_time isnull and
+						oc.ep_step_id = esc.ep_step_id and
+						not exists (select null from alert_acks ack where ack.alert_id = esc.alert_id and ack.user_id = oc.user_id)
+					order by oc.start_time, oc.user_id
+					limit case when esc.mode = 'round_robin' then 1 end
+				) on_call on true
 			), _cycles as (
 				insert into notification_policy_cycles (alert_id, user_id)
 				select alert_id, user_id from _step_cycles
@@ -146,13 +264,15 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					last_escalation = now(),
 					next_escalation = now() + (cast(esc.delay as text)||' minutes')::interval,
 					escalation_policy_step_id = esc.ep_step_id,
-					force_escalation = false
+					force_escalation = false,
+					round_robin_count = 1,
+					round_robin_next = CASE WHEN esc.mode = 'round_robin' THEN now() + make_interval(mins => esc.round_robin_minutes) END
 				from
 					to_escalate esc
 				where
 					state.alert_id = esc.alert_id
 			)
-			select distinct esc.alert_id, step isnull and chan isnull
+			select distinct esc.alert_id, esc.skipped, esc.no_active_step, step isnull and chan isnull
 			from to_escalate esc
 			left join _step_cycles step on step.alert_id = esc.alert_id
 			left join _step_channels chan on chan.alert_id = esc.alert_id
@@ -165,19 +285,38 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					step.id ep_step_id,
 					step.step_number,
 					step.delay,
-					state.escalation_policy_step_number >= ep.step_count repeated,
+					step.mode,
+					step.round_robin_minutes,
+					step.step_number < state.escalation_policy_step_number repeated,
+					CASE
+						WHEN step.step_number < state.escalation_policy_step_number THEN
+							greatest(ep.step_count - state.escalation_policy_step_number, 0) + step.step_number
+						ELSE step.step_number - state.escalation_policy_step_number
+					END skipped,
 					a.service_id,
-					step.escalation_policy_id
+					step.escalation_policy_id,
+					not ep_has_active_step(state.escalation_policy_id) no_active_step
 				from escalation_policy_state state
-				join alerts a on a.id = state.alert_id and (a.status = 'triggered' or state.force_escalation)
+				join alerts a on a.id = state.alert_id and ((a.status = 'triggered' and (a.flapping_until isnull or a.flapping_until <= now())) or state.force_escalation)
 				join escalation_policies ep on ep.id = state.escalation_policy_id
 				join escalation_policy_steps step on
 					step.escalation_policy_id = state.escalation_policy_id and
-					step.step_number = CASE
-						WHEN state.escalation_policy_step_number >= ep.step_count THEN 0
-						ELSE state.escalation_policy_step_number
-						END
-				join services s on a.service_id = s.id and s.maintenance_expires_at isnull
+					step.step_number = coalesce(
+						(
+							select min(s.step_number)
+							from escalation_policy_steps s
+							where
+								s.escalation_policy_id = state.escalation_policy_id and
+								(s.is_active or not ep_has_active_step(state.escalation_policy_id)) and
+								s.step_number >= state.escalation_policy_step_number
+						),
+						(
+							select min(s.step_number)
+							from escalation_policy_steps s
+							where s.escalation_policy_id = state.escalation_policy_id and (s.is_active or not ep_has_active_step(state.escalation_policy_id))
+						)
+					)
+				join services s on a.service_id = s.id and s.maintenance_expires_at isnull and not svc_maint_window_active(s.id)
 				where
 					state.last_escalation notnull and
 					escalation_policy_step_id isnull
@@ -186,9 +325,16 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 			), _step_cycles as (
 				select esc.alert_id, on_call.user_id, esc.ep_step_id
 				from to_escalate esc
-				join ep_step_on_call_users on_call on
-					on_call.end_time isnull and
-					on_call.ep_step_id = esc.ep_step_id
+				join lateral (
+					select oc.user_id
+					from ep_step_on_call_users oc
+					where
+						oc.end_time isnull and
+						oc.ep_step_id = esc.ep_step_id and
+						not exists (select null from alert_acks ack where ack.alert_id = esc.alert_id and ack.user_id = oc.user_id)
+					order by oc.start_time, oc.user_id
+					limit case when esc.mode = 'round_robin' then 1 end
+				) on_call on true
 			), _cycles as (
 				insert into notification_policy_cycles (alert_id, user_id)
 				select alert_id, user_id
@@ -221,13 +367,15 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					next_escalation = now() + (cast(esc.delay as text)||' minutes')::interval,
 					escalation_policy_step_number = esc.step_number,
 					escalation_policy_step_id = esc.ep_step_id,
-					force_escalation = false
+					force_escalation = false,
+					round_robin_count = 1,
+					round_robin_next = CASE WHEN esc.mode = 'round_robin' THEN now() + make_interval(mins => esc.round_robin_minutes) END
 				from
 					to_escalate esc
 				where
 					state.alert_id = esc.alert_id
 			)
-			select distinct esc.alert_id, esc.repeated, esc.step_number, step isnull and chan isnull
+			select distinct esc.alert_id, esc.repeated, esc.step_number, esc.skipped, esc.no_active_step, step isnull and chan isnull
 			from to_escalate esc
 			left join _step_cycles step on step.alert_id = esc.alert_id
 			left join _step_channels chan on chan.alert_id = esc.alert_id
@@ -238,26 +386,45 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					alert_id,
 					nextStep.id ep_step_id,
 					nextStep.delay,
+					nextStep.mode,
+					nextStep.round_robin_minutes,
 					nextStep.step_number,
 					force_escalation forced,
 					oldStep.delay old_delay,
-					oldStep.step_number + 1 >= ep.step_count repeated,
+					nextStep.step_number <= oldStep.step_number repeated,
+					CASE
+						WHEN nextStep.step_number <= oldStep.step_number THEN
+							ep.step_count - oldStep.step_number - 1 + nextStep.step_number
+						ELSE nextStep.step_number - oldStep.step_number - 1
+					END skipped,
 					nextStep.escalation_policy_id,
-					a.service_id
+					a.service_id,
+					not ep_has_active_step(state.escalation_policy_id) no_active_step
 				from escalation_policy_state state
-				join alerts a on a.id = state.alert_id and (a.status = 'triggered' or state.force_escalation)
+				join alerts a on a.id = state.alert_id and ((a.status = 'triggered' and (a.flapping_until isnull or a.flapping_until <= now())) or state.force_escalation)
 				join escalation_policies ep on ep.id = state.escalation_policy_id
 				join escalation_policy_steps oldStep on oldStep.id = escalation_policy_step_id
 				join escalation_policy_steps nextStep on
 					nextStep.escalation_policy_id = state.escalation_policy_id and
-					nextStep.step_number = CASE
-						WHEN oldStep.step_number + 1 < ep.step_count THEN
-							oldStep.step_number + 1
-						WHEN force_escalation OR ep.repeat = -1 THEN 0
-						WHEN state.loop_count < ep.repeat THEN 0
-						ELSE -1
-					END
-				join services s on a.service_id = s.id and s.maintenance_expires_at isnull
+					nextStep.step_number = coalesce(
+						(
+							select min(s.step_number)
+							from escalation_policy_steps s
+							where
+								s.escalation_policy_id = state.escalation_policy_id and
+								(s.is_active or not ep_has_active_step(state.escalation_policy_id)) and
+								s.step_number > oldStep.step_number
+						),
+						CASE
+							WHEN force_escalation OR ep.repeat = -1 OR state.loop_count < ep.repeat THEN (
+								select min(s.step_number)
+								from escalation_policy_steps s
+								where s.escalation_policy_id = state.escalation_policy_id and (s.is_active or not ep_has_active_step(state.escalation_policy_id))
+							)
+						END,
+						-1
+					)
+				join services s on a.service_id = s.id and s.maintenance_expires_at isnull and not svc_maint_window_active(s.id)
 				where
 					state.last_escalation notnull and
 					escalation_policy_step_id notnull and
@@ -268,9 +435,16 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 			), _step_cycles as (
 				select esc.alert_id, on_call.user_id, esc.ep_step_id
 				from to_escalate esc
-				join ep_step_on_call_users on_call on
-					on_call.end_time isnull and
-					on_call.ep_step_id = esc.ep_step_id
+				join lateral (
+					select oc.user_id
+					from ep_step_on_call_users oc
+					where
+						oc.end_time isnull and
+						oc.ep_step_id = esc.ep_step_id and
+						not exists (select null from alert_acks ack where ack.alert_id = esc.alert_id and ack.user_id = oc.user_id)
+					order by oc.start_time, oc.user_id
+					limit case when esc.mode = 'round_robin' then 1 end
+				) on_call on true
 			), _cycles as (
 				insert into notification_policy_cycles (alert_id, user_id)
 				select alert_id, user_id
@@ -304,16 +478,62 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					escalation_policy_step_number = esc.step_number,
 					escalation_policy_step_id = esc.ep_step_id,
 					loop_count = CASE WHEN esc.repeated THEN loop_count + 1 ELSE loop_count END,
-					force_escalation = false
+					force_escalation = false,
+					round_robin_count = 1,
+					round_robin_next = CASE WHEN esc.mode = 'round_robin' THEN now() + make_interval(mins => esc.round_robin_minutes) END
 				from
 					to_escalate esc
 				where
 					state.alert_id = esc.alert_id
 			)
-			select distinct esc.alert_id, esc.repeated, esc.step_number, esc.old_delay, esc.forced, step isnull and chan isnull
+			select distinct esc.alert_id, esc.repeated, esc.step_number, esc.skipped, esc.old_delay, esc.forced, esc.no_active_step, step isnull and chan isnull
 			from to_escalate esc
 			left join _step_cycles step on step.alert_id = esc.alert_id
 			left join _step_channels chan on chan.alert_id = esc.alert_id
 		`),
+
+		// notify the next on-call user of round-robin steps, in the same order as the initial notification,
+		// starting over after the last user; users that have acknowledged the alert are skipped
+		roundRobin: p.P(`
+			with to_advance as (
+				select state.alert_id, state.escalation_policy_step_id ep_step_id, state.round_robin_count, step.round_robin_minutes
+				from escalation_policy_state state
+				join escalation_policy_steps step on step.id = state.escalation_policy_step_id and step.mode = 'round_robin'
+				join alerts a on a.id = state.alert_id and a.status = 'triggered' and (a.flapping_until isnull or a.flapping_until <= now())
+				join services s on a.service_id = s.id and s.maintenance_expires_at isnull and not svc_maint_window_active(s.id)
+				where state.round_robin_next < now()
+				for update skip locked
+				limit 500
+			), _next as (
+				select esc.alert_id, on_call.user_id
+				from to_advance esc
+				join lateral (
+					select u.user_id
+					from (
+						select
+							oc.user_id,
+							row_number() over (order by oc.start_time, oc.user_id) - 1 idx,
+							count(*) over () user_count
+						from ep_step_on_call_users oc
+						where
+							oc.end_time isnull and
+							oc.ep_step_id = esc.ep_step_id and
+							not exists (select null from alert_acks ack where ack.alert_id = esc.alert_id and ack.user_id = oc.user_id)
+					) u
+					where u.idx = esc.round_robin_count % u.user_count
+				) on_call on true
+			), _cycles as (
+				insert into notification_policy_cycles (alert_id, user_id)
+				select alert_id, user_id
+				from _next
+			)
+			update escalation_policy_state state
+			set
+				round_robin_count = esc.round_robin_count + 1,
+				round_robin_next = CASE WHEN nxt.user_id notnull THEN now() + make_interval(mins => esc.round_robin_minutes) END
+			from to_advance esc
+			left join _next nxt on nxt.alert_id = esc.alert_id
+			where state.alert_id = esc.alert_id
+		`),
 	}, p.Err
 }
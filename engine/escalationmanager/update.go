@@ -3,15 +3,24 @@ package escalationmanager
 import (
 	"context"
 	"database/sql"
+	"time"
 
+	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/escalation"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/maintenance"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
 
 	"github.com/pkg/errors"
 )
 
+// maxAlertBatch is the maximum number of alerts closed at once when a maintenance window ends.
+const maxAlertBatch = 500
+
 // UpdateAll will update the state of all active escalation policies.
 func (db *DB) UpdateAll(ctx context.Context) error {
 	err := db.update(ctx, true, nil)
@@ -35,6 +44,14 @@ func (db *DB) update(ctx context.Context, all bool, alertID *int) error {
 	if err != nil {
 		return errors.Wrap(err, "lock ep step table")
 	}
+	err = db.updateActiveSteps(ctx, tx)
+	if err != nil {
+		return errors.Wrap(err, "update ep step active hours")
+	}
+	err = db.updateMaintWindows(ctx, tx)
+	if err != nil {
+		return errors.Wrap(err, "update maintenance windows")
+	}
 	_, err = tx.StmtContext(ctx, db.updateOnCall).ExecContext(ctx)
 	if err != nil {
 		return errors.Wrap(err, "update ep step on-call")
@@ -57,7 +74,7 @@ func (db *DB) update(ctx context.Context, all bool, alertID *int) error {
 	err = db.processEscalations(ctx, db.newPolicies, func(rows *sql.Rows) (int, *alertlog.EscalationMetaData, error) {
 		var id int
 		var meta alertlog.EscalationMetaData
-		err := rows.Scan(&id, &meta.NoOneOnCall)
+		err := rows.Scan(&id, &meta.SkippedSteps, &meta.NoActiveStep, &meta.NoOneOnCall)
 		return id, &meta, err
 	})
 	if err != nil {
@@ -67,7 +84,7 @@ func (db *DB) update(ctx context.Context, all bool, alertID *int) error {
 	err = db.processEscalations(ctx, db.deletedSteps, func(rows *sql.Rows) (int, *alertlog.EscalationMetaData, error) {
 		var id int
 		var meta alertlog.EscalationMetaData
-		err := rows.Scan(&id, &meta.Repeat, &meta.NewStepIndex, &meta.NoOneOnCall)
+		err := rows.Scan(&id, &meta.Repeat, &meta.NewStepIndex, &meta.SkippedSteps, &meta.NoActiveStep, &meta.NoOneOnCall)
 		return id, &meta, err
 	})
 	if err != nil {
@@ -77,13 +94,18 @@ func (db *DB) update(ctx context.Context, all bool, alertID *int) error {
 	err = db.processEscalations(ctx, db.normalEscalation, func(rows *sql.Rows) (int, *alertlog.EscalationMetaData, error) {
 		var id int
 		var meta alertlog.EscalationMetaData
-		err := rows.Scan(&id, &meta.Repeat, &meta.NewStepIndex, &meta.OldDelayMinutes, &meta.Forced, &meta.NoOneOnCall)
+		err := rows.Scan(&id, &meta.Repeat, &meta.NewStepIndex, &meta.SkippedSteps, &meta.OldDelayMinutes, &meta.Forced, &meta.NoActiveStep, &meta.NoOneOnCall)
 		return id, &meta, err
 	})
 	if err != nil {
 		return errors.Wrap(err, "escalate forced or expired")
 	}
 
+	_, err = db.lock.Exec(ctx, db.roundRobin)
+	if err != nil {
+		return errors.Wrap(err, "advance round-robin steps")
+	}
+
 	return nil
 }
 
@@ -119,3 +141,159 @@ func (db *DB) processEscalations(ctx context.Context, stmt *sql.Stmt, scan func(
 
 	return tx.Commit()
 }
+
+// updateActiveSteps will update the is_active flag for all steps with active hours configured,
+// as well as re-activating any steps that no longer have active hours.
+func (db *DB) updateActiveSteps(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.StmtContext(ctx, db.findActiveHours).QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var activate, deactivate []string
+	for rows.Next() {
+		var id string
+		var h escalation.ActiveHours
+		var tz sql.NullString
+		var isActive bool
+		var now time.Time
+		err = rows.Scan(&id, &h.WeekdayFilter, &h.Start, &h.End, &tz, &isActive, &now)
+		if err != nil {
+			return err
+		}
+
+		s := escalation.Step{}
+		if tz.Valid {
+			h.TimeZone, err = util.LoadLocation(tz.String)
+			if err != nil {
+				log.Log(log.WithField(ctx, "StepID", id), errors.Wrap(err, "load active hours time zone"))
+				continue
+			}
+			s.ActiveHours = &h
+		}
+
+		active := s.IsActive(now)
+		switch {
+		case active == isActive:
+		case active:
+			activate = append(activate, id)
+		default:
+			deactivate = append(deactivate, id)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	stmt := tx.StmtContext(ctx, db.setStepsActive)
+	if len(activate) > 0 {
+		_, err = stmt.ExecContext(ctx, sqlutil.UUIDArray(activate), true)
+		if err != nil {
+			return err
+		}
+	}
+	if len(deactivate) > 0 {
+		_, err = stmt.ExecContext(ctx, sqlutil.UUIDArray(deactivate), false)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateMaintWindows will mark maintenance windows active or inactive based on the current time.
+//
+// When an occurrence of a window ends, alerts created during it are either left to escalate normally
+// or closed, depending on the window's end action.
+func (db *DB) updateMaintWindows(ctx context.Context, tx *sql.Tx) error {
+	q := gadb.New(tx)
+	now, err := q.Now(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := q.MaintWindowFindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		w, err := maintenance.FromDB(row)
+		if err != nil {
+			log.Log(log.WithField(ctx, "MaintenanceWindowID", row.ID.String()), errors.Wrap(err, "load maintenance window"))
+			continue
+		}
+
+		start, _, active := w.ActiveAt(now)
+		wasActive := !w.ActiveSince.IsZero()
+		if wasActive && active && start.Equal(w.ActiveSince) {
+			continue
+		}
+		if !wasActive && !active {
+			continue
+		}
+
+		if wasActive && w.EndAction == maintenance.EndActionDrop {
+			err = db.dropMaintAlertsTx(ctx, tx, w)
+			if err != nil {
+				return errors.Wrap(err, "close alerts for ended maintenance window")
+			}
+		}
+
+		activeSince := sql.NullTime{Time: start, Valid: active}
+		_, err = tx.StmtContext(ctx, db.setMaintActive).ExecContext(ctx, row.ID, activeSince)
+		if err != nil {
+			return err
+		}
+
+		if wasActive && w.EndAction == maintenance.EndActionEscalate {
+			// send notifications that were held during the window now, rather than waiting for the end of the
+			// occurrence they were held for (e.g., if the window was shortened)
+			_, err = tx.StmtContext(ctx, db.releaseMaintHeld).ExecContext(ctx, row.ID)
+			if err != nil {
+				return errors.Wrap(err, "release notifications held for maintenance window")
+			}
+		}
+	}
+
+	return nil
+}
+
+// dropMaintAlertsTx closes any open alerts created during the current occurrence of a maintenance window.
+func (db *DB) dropMaintAlertsTx(ctx context.Context, tx *sql.Tx, w *maintenance.Window) error {
+	rows, err := tx.StmtContext(ctx, db.findMaintAlerts).QueryContext(ctx, w.ID, w.ActiveSince)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	meta := alertlog.MaintenanceDropMetaData{
+		MaintenanceWindowID: w.ID,
+		Reason:              w.Reason,
+	}
+	for len(ids) > 0 {
+		n := min(len(ids), maxAlertBatch)
+		_, _, err = db.a.UpdateManyAlertStatusTx(ctx, tx, alert.StatusClosed, ids[:n], &meta)
+		if err != nil {
+			return err
+		}
+		ids = ids[n:]
+	}
+
+	return nil
+}
@@ -49,6 +49,8 @@ type DB struct {
 	sentByCMType *sql.Stmt
 
 	cleanupStatusUpdateOptOut *sql.Stmt
+	skipIncidentAcked         *sql.Stmt
+	holdFlapping              *sql.Stmt
 
 	tempFail     *sql.Stmt
 	permFail     *sql.Stmt
@@ -70,7 +72,7 @@ type DB struct {
 func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycle.Pausable) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeMessage,
-		Version: 11,
+		Version: 15,
 	})
 	if err != nil {
 		return nil, err
@@ -184,6 +186,39 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 				) and
 				not cm.enable_status_updates and cm.id = msg.contact_method_id
 		`),
+
+		// Notifications for alerts attached to an acknowledged incident are skipped, with the reason recorded
+		// so it shows up in the alert log.
+		skipIncidentAcked: p.P(`
+			update outgoing_messages msg
+			set
+				last_status = 'failed',
+				last_status_at = now(),
+				status_details = 'incident acknowledged',
+				cycle_id = null,
+				next_retry_at = null
+			from incident_alerts ia, incidents inc
+			where
+				msg.message_type = 'alert_notification' and
+				msg.last_status = 'pending' and
+				ia.alert_id = msg.alert_id and
+				inc.id = ia.incident_id and
+				inc.status = 'active'
+			returning msg.id, msg.alert_id, msg.user_id, msg.contact_method_id, msg.channel_id
+		`),
+
+		// Notifications for flapping alerts are held until the alert stops flapping.
+		holdFlapping: p.P(`
+			update outgoing_messages msg
+			set quiet_until = a.flapping_until
+			from alerts a
+			where
+				msg.message_type = 'alert_notification' and
+				msg.last_status = 'pending' and
+				a.id = msg.alert_id and
+				a.flapping_until > now() and
+				(msg.quiet_until isnull or msg.quiet_until < a.flapping_until)
+		`),
 		setSending: p.P(`
 			update outgoing_messages
 			set
@@ -341,6 +376,10 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 		if row.ScheduleID.Valid {
 			msg.ScheduleID = row.ScheduleID.UUID.String()
 		}
+		if row.OverrideRequestID.Valid {
+			msg.OverrideRequestID = row.OverrideRequestID.UUID.String()
+		}
+		msg.QuietUntil = row.QuietUntil.Time
 		if msg.Dest.Type == "" {
 			log.Debugf(ctx, "unknown message type for message %s", msg.ID)
 			continue
@@ -364,6 +403,42 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 	}
 	db.lastSent = now
 
+	quiet, err := loadQuietHours(ctx, gadb.New(tx), result)
+	if err != nil {
+		return nil, err
+	}
+	result, err = holdQuietMessages(result, now, quiet, func(id string, until time.Time) error {
+		err := gadb.New(tx).MessageMgrHoldQuiet(ctx, gadb.MessageMgrHoldQuietParams{
+			ID:         uuid.MustParse(id),
+			QuietUntil: sql.NullTime{Time: until, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("hold message '%s' for quiet hours: %w", id, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	maintEnds, err := loadMaintEnds(ctx, gadb.New(tx), now)
+	if err != nil {
+		return nil, err
+	}
+	result, err = holdMaintMessages(result, maintEnds, func(id string, until time.Time) error {
+		err := gadb.New(tx).MessageMgrHoldQuiet(ctx, gadb.MessageMgrHoldQuietParams{
+			ID:         uuid.MustParse(id),
+			QuietUntil: sql.NullTime{Time: until, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("hold message '%s' for maintenance: %w", id, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	result, toDelete := dedupOnCallNotifications(result)
 	if len(toDelete) > 0 {
 		_, err = tx.StmtContext(ctx, db.deleteAny).ExecContext(ctx, sqlutil.UUIDArray(toDelete))
@@ -381,6 +456,35 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 		}
 	}
 
+	result, err = digestQuietMessages(result, now, func(msg Message) (string, error) {
+		var userID uuid.NullUUID
+		if msg.UserID != "" {
+			userID = uuid.NullUUID{UUID: uuid.MustParse(msg.UserID), Valid: true}
+		}
+
+		id := uuid.New()
+		err := gadb.New(tx).MessageMgrCreateDigest(ctx, gadb.MessageMgrCreateDigestParams{
+			ID:              id,
+			CreatedAt:       msg.CreatedAt,
+			ContactMethodID: msg.DestID.CMID,
+			ChannelID:       msg.DestID.NCID,
+			UserID:          userID,
+		})
+		if err != nil {
+			return "", fmt.Errorf("create quiet hours digest: %w", err)
+		}
+		return id.String(), nil
+	}, func(parentID string, ids []string) error {
+		_, err = tx.StmtContext(ctx, db.bundleMessages).ExecContext(ctx, parentID, sqlutil.UUIDArray(ids))
+		if err != nil {
+			return fmt.Errorf("digest '%v' by pointing to '%s': %w", ids, parentID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("digest quiet hours messages: %w", err)
+	}
+
 	result, err = dedupAlerts(result, func(parentID string, duplicateIDs []string) error {
 		_, err = tx.StmtContext(ctx, db.bundleMessages).ExecContext(ctx, parentID, sqlutil.UUIDArray(duplicateIDs))
 		if err != nil {
@@ -566,15 +670,38 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 		return errors.Wrap(err, "clear disabled status updates")
 	}
 
+	_, err = tx.Stmt(db.holdFlapping).ExecContext(execCtx)
+	if err != nil {
+		return errors.Wrap(err, "hold notifications for flapping alerts")
+	}
+
 	type msgMeta struct {
 		MessageID string
 		AlertID   int
 		UserID    string
 		CMID      string
+		ChannelID string
 	}
 
 	var msgs []msgMeta
 
+	rows, err := tx.Stmt(db.skipIncidentAcked).QueryContext(execCtx)
+	if err != nil {
+		return errors.Wrap(err, "skip notifications for acknowledged incidents")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var msg msgMeta
+		var userID, cmID, chanID sql.NullString
+		err = rows.Scan(&msg.MessageID, &msg.AlertID, &userID, &cmID, &chanID)
+		if err != nil {
+			return errors.Wrap(err, "scan skipped incident messages")
+		}
+		msg.UserID, msg.CMID, msg.ChannelID = userID.String, cmID.String, chanID.String
+		msgs = append(msgs, msg)
+	}
+
 	// if twilio is disable, create an entry to notify the user
 	cfg := config.FromContext(ctx)
 	if !cfg.Twilio.Enable {
@@ -600,7 +727,7 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 	}
 
 	// processes disabled CMs and writes to alert log if disabled
-	rows, err := tx.Stmt(db.failDisabledCM).QueryContext(execCtx)
+	rows, err = tx.Stmt(db.failDisabledCM).QueryContext(execCtx)
 	if err != nil {
 		return errors.Wrap(err, "check for disabled CMs")
 	}
@@ -621,10 +748,17 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 		}
 
 		// log failures
-		db.alertlogstore.MustLogTx(permission.UserSourceContext(ctx, m.UserID, permission.RoleUser, &permission.SourceInfo{
+		logCtx := permission.UserSourceContext(ctx, m.UserID, permission.RoleUser, &permission.SourceInfo{
 			Type: permission.SourceTypeContactMethod,
 			ID:   m.CMID,
-		}), tx, m.AlertID, alertlog.TypeNotificationSent, meta)
+		})
+		if m.CMID == "" {
+			logCtx = permission.SourceContext(permission.SystemContext(ctx, "MessageManager"), &permission.SourceInfo{
+				Type: permission.SourceTypeNotificationChannel,
+				ID:   m.ChannelID,
+			})
+		}
+		db.alertlogstore.MustLogTx(logCtx, tx, m.AlertID, alertlog.TypeNotificationSent, meta)
 	}
 
 	_, err = tx.Stmt(db.sendDeadlineExpired).ExecContext(ctx)
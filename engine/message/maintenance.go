@@ -0,0 +1,60 @@
+package message
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/maintenance"
+	"github.com/target/goalert/notification"
+)
+
+// loadMaintEnds returns the end of the current maintenance window occurrence for each service that is
+// in maintenance.
+func loadMaintEnds(ctx context.Context, q *gadb.Queries, now time.Time) (map[string]time.Time, error) {
+	rows, err := q.MessageMgrActiveMaintWindows(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch active maintenance windows: %w", err)
+	}
+
+	ends := make(map[string]time.Time)
+	for _, row := range rows {
+		w, err := maintenance.FromDB(row.ServiceMaintenanceWindow)
+		if err != nil {
+			return nil, fmt.Errorf("maintenance window %s: %w", row.ServiceMaintenanceWindow.ID, err)
+		}
+		_, end, ok := w.ActiveAt(now)
+		if !ok {
+			// ended, but not yet marked inactive
+			continue
+		}
+
+		id := row.ServiceID.String()
+		if end.After(ends[id]) {
+			ends[id] = end
+		}
+	}
+
+	return ends, nil
+}
+
+// holdMaintMessages will remove alert notifications for services in maintenance, calling holdFunc for each
+// with the end of the maintenance window.
+func holdMaintMessages(messages []Message, ends map[string]time.Time, holdFunc func(id string, until time.Time) error) ([]Message, error) {
+	result := messages[:0]
+	for _, msg := range messages {
+		end, ok := ends[msg.ServiceID]
+		if !msg.SentAt.IsZero() || msg.Type != notification.MessageTypeAlert || !ok {
+			result = append(result, msg)
+			continue
+		}
+
+		err := holdFunc(msg.ID, end)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
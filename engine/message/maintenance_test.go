@@ -0,0 +1,43 @@
+package message
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/notification"
+)
+
+func TestHoldMaintMessages(t *testing.T) {
+	n := time.Date(2006, 1, 1, 23, 0, 0, 0, time.UTC)
+	end := n.Add(time.Hour)
+	ends := map[string]time.Time{"maint-svc": end}
+
+	msgs := []Message{
+		{ID: "maint-alert", Type: notification.MessageTypeAlert, ServiceID: "maint-svc"},
+		{ID: "other-alert", Type: notification.MessageTypeAlert, ServiceID: "other-svc"},
+		{ID: "maint-status", Type: notification.MessageTypeAlertStatus, ServiceID: "maint-svc"},
+		{ID: "maint-sent", Type: notification.MessageTypeAlert, ServiceID: "maint-svc", SentAt: n.Add(-time.Minute)},
+		{ID: "maint-released", Type: notification.MessageTypeAlert, ServiceID: "maint-svc", QuietUntil: n.Add(-time.Minute)},
+	}
+
+	held := make(map[string]time.Time)
+	out, err := holdMaintMessages(msgs, ends, func(id string, until time.Time) error {
+		held[id] = until
+		return nil
+	})
+	require.NoError(t, err)
+
+	// a message whose hold expired is held again if the service is still in maintenance (e.g., the next occurrence)
+	assert.Equal(t, map[string]time.Time{
+		"maint-alert":    end,
+		"maint-released": end,
+	}, held)
+
+	var ids []string
+	for _, msg := range out {
+		ids = append(ids, msg.ID)
+	}
+	assert.ElementsMatch(t, []string{"other-alert", "maint-status", "maint-sent"}, ids)
+}
@@ -24,7 +24,12 @@ type Message struct {
 	CreatedAt  time.Time
 	SentAt     time.Time
 
+	OverrideRequestID string
+
 	StatusAlertIDs []int64
+
+	// QuietUntil is set if the message was held for quiet hours, and is the time it was released.
+	QuietUntil time.Time
 }
 
 func (m Message) Base() nfymsg.Base {
@@ -13,7 +13,9 @@ SELECT
     msg.created_at,
     msg.sent_at,
     msg.status_alert_ids,
-    msg.schedule_id
+    msg.schedule_id,
+    msg.override_request_id,
+    msg.quiet_until
 FROM
     outgoing_messages msg
     LEFT JOIN user_contact_methods cm ON cm.id = msg.contact_method_id
@@ -23,5 +25,58 @@ WHERE
     OR last_status = 'pending'
     AND (msg.contact_method_id ISNULL
         OR msg.message_type = 'verification_message'
-        OR NOT cm.disabled);
+        OR NOT cm.disabled)
+    AND (msg.quiet_until ISNULL
+        OR msg.quiet_until <= now());
 
+-- name: MessageMgrQuietHours :many
+SELECT
+    *
+FROM
+    quiet_hours;
+
+-- name: MessageMgrScheduleServices :many
+-- Returns the services that escalate directly to each schedule.
+SELECT DISTINCT
+    act.schedule_id,
+    svc.id AS service_id
+FROM
+    escalation_policy_actions act
+    JOIN escalation_policy_steps step ON step.id = act.escalation_policy_step_id
+    JOIN services svc ON svc.escalation_policy_id = step.escalation_policy_id
+WHERE
+    act.schedule_id = ANY (@schedule_ids::uuid[]);
+
+-- name: MessageMgrHoldQuiet :exec
+UPDATE
+    outgoing_messages
+SET
+    quiet_until = @quiet_until
+WHERE
+    id = @id;
+
+-- name: MessageMgrCreateDigest :exec
+INSERT INTO outgoing_messages(id, created_at, message_type, contact_method_id, channel_id, user_id)
+    VALUES (@id, @created_at, 'quiet_hours_digest', @contact_method_id, @channel_id, @user_id);
+
+
+-- name: MessageMgrActiveMaintWindows :many
+-- Returns the active maintenance windows, and each service they apply to.
+SELECT
+    sqlc.embed(w),
+    svc.id AS service_id
+FROM
+    service_maintenance_windows w
+    JOIN services svc ON svc.id = w.service_id
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                labels l
+            WHERE
+                l.tgt_service_id = svc.id
+                AND l.key = w.label_key
+                AND (w.label_value = ''
+                    OR l.value = w.label_value))
+WHERE
+    w.active_since NOTNULL;
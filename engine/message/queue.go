@@ -15,13 +15,16 @@ var typePriority = map[gadb.EnumOutgoingMessagesType]int{
 	notification.MessageTypeTest:         2,
 
 	notification.MessageTypeScheduleOnCallUsers: 3,
+	notification.MessageTypeOverrideRequest:     3,
+	notification.MessageTypeScheduleCoverageGap: 3,
 
 	// First alert will jump the list with priority 0, so this only
 	// represents additional alerts to the service after the first.
 	notification.MessageTypeAlert:       4,
 	notification.MessageTypeAlertBundle: 4,
 
-	notification.MessageTypeAlertStatus: 5,
+	notification.MessageTypeAlertStatus:      5,
+	notification.MessageTypeQuietHoursDigest: 5,
 
 	notification.MessageTypeSignalMessage: 99, // lowest priority
 }
@@ -0,0 +1,212 @@
+package message
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/slack"
+	"github.com/target/goalert/quiethours"
+)
+
+// quietHoursSet contains the quiet hours of users and services, and the services that escalate to
+// each schedule.
+type quietHoursSet struct {
+	users    map[string]quiethours.QuietHours
+	services map[string]quiethours.QuietHours
+
+	scheduleServices map[string][]string
+}
+
+// isQuietType returns true if the message type is held during quiet hours.
+func isQuietType(msg Message) bool {
+	switch msg.Type {
+	case notification.MessageTypeAlertStatus:
+		return true
+	case notification.MessageTypeScheduleOnCallUsers:
+		// user group membership must be updated right away
+		return msg.Dest.Type != slack.DestTypeSlackUsergroup
+	}
+
+	return false
+}
+
+// until returns the time a message should be held until, or false if it can be sent now.
+//
+// A message is held if the user or service it is for is in quiet hours. On-call notifications are held if
+// every service that escalates to the schedule is in quiet hours.
+func (s quietHoursSet) until(msg Message, now time.Time) (time.Time, bool) {
+	if !isQuietType(msg) || !msg.QuietUntil.IsZero() {
+		return time.Time{}, false
+	}
+
+	var end time.Time
+	extend := func(t time.Time) {
+		if t.After(end) {
+			end = t
+		}
+	}
+	if q, ok := s.users[msg.UserID]; ok {
+		t, _ := q.Until(now)
+		extend(t)
+	}
+	if q, ok := s.services[msg.ServiceID]; ok {
+		t, _ := q.Until(now)
+		extend(t)
+	}
+	extend(s.scheduleUntil(msg.ScheduleID, now))
+
+	return end, !end.IsZero()
+}
+
+// scheduleUntil returns the earliest end of quiet hours for the services that escalate to a schedule,
+// or the zero time if any of them are not in quiet hours.
+func (s quietHoursSet) scheduleUntil(scheduleID string, now time.Time) time.Time {
+	var end time.Time
+	for _, id := range s.scheduleServices[scheduleID] {
+		q, ok := s.services[id]
+		if !ok {
+			return time.Time{}
+		}
+		t, ok := q.Until(now)
+		if !ok {
+			return time.Time{}
+		}
+		if end.IsZero() || t.Before(end) {
+			end = t
+		}
+	}
+
+	return end
+}
+
+// holdQuietMessages will remove messages that should be held for quiet hours, calling holdFunc for each.
+func holdQuietMessages(messages []Message, now time.Time, set quietHoursSet, holdFunc func(id string, until time.Time) error) ([]Message, error) {
+	result := messages[:0]
+	for _, msg := range messages {
+		until, ok := set.until(msg, now)
+		if !msg.SentAt.IsZero() || !ok {
+			result = append(result, msg)
+			continue
+		}
+
+		err := holdFunc(msg.ID, until)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// digestQuietMessages will replace messages that were held for quiet hours with a single digest message
+// for each destination. Alert notifications held for other reasons (e.g., maintenance) are sent as-is.
+//
+// It also handles updating the outgoing_messages table by marking held messages with the `bundled`
+// status and creating a new digest message placeholder.
+func digestQuietMessages(messages []Message, now time.Time, newDigestFunc func(Message) (string, error), bundleFunc func(string, []string) error) ([]Message, error) {
+	var toProcess, result []Message
+	for _, msg := range messages {
+		if msg.SentAt.IsZero() && !msg.QuietUntil.IsZero() && isQuietType(msg) {
+			toProcess = append(toProcess, msg)
+			continue
+		}
+		result = append(result, msg)
+	}
+	sort.Slice(toProcess, func(i, j int) bool { return toProcess[i].CreatedAt.Before(toProcess[j].CreatedAt) })
+
+	groups := make(map[notification.DestID][]Message)
+	var order []notification.DestID
+	for _, msg := range toProcess {
+		if _, ok := groups[msg.DestID]; !ok {
+			order = append(order, msg.DestID)
+		}
+		groups[msg.DestID] = append(groups[msg.DestID], msg)
+	}
+
+	for _, destID := range order {
+		msgs := groups[destID]
+		digest := Message{
+			Type:      notification.MessageTypeQuietHoursDigest,
+			DestID:    destID,
+			Dest:      msgs[0].Dest,
+			UserID:    msgs[0].UserID,
+			CreatedAt: now,
+		}
+
+		var err error
+		digest.ID, err = newDigestFunc(digest)
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make([]string, len(msgs))
+		for i, msg := range msgs {
+			ids[i] = msg.ID
+		}
+		err = bundleFunc(digest.ID, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, digest)
+	}
+
+	return result, nil
+}
+
+// loadQuietHours will return the quiet hours that apply to the given messages.
+func loadQuietHours(ctx context.Context, q *gadb.Queries, messages []Message) (quietHoursSet, error) {
+	set := quietHoursSet{
+		users:            make(map[string]quiethours.QuietHours),
+		services:         make(map[string]quiethours.QuietHours),
+		scheduleServices: make(map[string][]string),
+	}
+
+	rows, err := q.MessageMgrQuietHours(ctx)
+	if err != nil {
+		return set, fmt.Errorf("fetch quiet hours: %w", err)
+	}
+	if len(rows) == 0 {
+		return set, nil
+	}
+
+	for _, row := range rows {
+		qh, err := quiethours.FromDB(row)
+		if err != nil {
+			return set, fmt.Errorf("quiet hours %s: %w", row.ID, err)
+		}
+		if qh.UserID != "" {
+			set.users[qh.UserID] = *qh
+		}
+		if qh.ServiceID != "" {
+			set.services[qh.ServiceID] = *qh
+		}
+	}
+
+	var schedIDs []uuid.UUID
+	for _, msg := range messages {
+		if msg.Type != notification.MessageTypeScheduleOnCallUsers || msg.ScheduleID == "" {
+			continue
+		}
+		schedIDs = append(schedIDs, uuid.MustParse(msg.ScheduleID))
+	}
+	if len(schedIDs) == 0 {
+		return set, nil
+	}
+
+	svcs, err := q.MessageMgrScheduleServices(ctx, schedIDs)
+	if err != nil {
+		return set, fmt.Errorf("fetch schedule services: %w", err)
+	}
+	for _, row := range svcs {
+		id := row.ScheduleID.UUID.String()
+		set.scheduleServices[id] = append(set.scheduleServices[id], row.ServiceID.String())
+	}
+
+	return set, nil
+}
@@ -0,0 +1,98 @@
+package message
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/slack"
+	"github.com/target/goalert/quiethours"
+	"github.com/target/goalert/util/timeutil"
+)
+
+func TestHoldQuietMessages(t *testing.T) {
+	n := time.Date(2006, 1, 1, 23, 0, 0, 0, time.UTC)
+	night := quiethours.QuietHours{TimeZone: time.UTC, Start: timeutil.NewClock(22, 0), End: timeutil.NewClock(7, 0)}
+	day := quiethours.QuietHours{TimeZone: time.UTC, Start: timeutil.NewClock(9, 0), End: timeutil.NewClock(17, 0)}
+	set := quietHoursSet{
+		users:    map[string]quiethours.QuietHours{"user": night},
+		services: map[string]quiethours.QuietHours{"quiet-svc": night, "day-svc": day},
+		scheduleServices: map[string][]string{
+			"quiet-sched": {"quiet-svc"},
+			"mixed-sched": {"quiet-svc", "day-svc"},
+		},
+	}
+
+	msgs := []Message{
+		{ID: "user-status", Type: notification.MessageTypeAlertStatus, UserID: "user"},
+		{ID: "user-alert", Type: notification.MessageTypeAlert, UserID: "user"},
+		{ID: "svc-status", Type: notification.MessageTypeAlertStatus, ServiceID: "quiet-svc"},
+		{ID: "day-status", Type: notification.MessageTypeAlertStatus, ServiceID: "day-svc"},
+		{ID: "quiet-oncall", Type: notification.MessageTypeScheduleOnCallUsers, ScheduleID: "quiet-sched"},
+		{ID: "mixed-oncall", Type: notification.MessageTypeScheduleOnCallUsers, ScheduleID: "mixed-sched"},
+		{ID: "ug-oncall", Type: notification.MessageTypeScheduleOnCallUsers, ScheduleID: "quiet-sched", Dest: gadb.DestV1{Type: slack.DestTypeSlackUsergroup}},
+		{ID: "released", Type: notification.MessageTypeAlertStatus, UserID: "user", QuietUntil: n.Add(-time.Hour)},
+	}
+
+	held := make(map[string]time.Time)
+	out, err := holdQuietMessages(msgs, n, set, func(id string, until time.Time) error {
+		held[id] = until
+		return nil
+	})
+	require.NoError(t, err)
+
+	end := time.Date(2006, 1, 2, 7, 0, 0, 0, time.UTC)
+	assert.Equal(t, map[string]time.Time{
+		"user-status":  end,
+		"svc-status":   end,
+		"quiet-oncall": end,
+	}, held)
+
+	var ids []string
+	for _, msg := range out {
+		ids = append(ids, msg.ID)
+	}
+	assert.ElementsMatch(t, []string{"user-alert", "day-status", "mixed-oncall", "ug-oncall", "released"}, ids)
+}
+
+func TestDigestQuietMessages(t *testing.T) {
+	n := time.Date(2006, 1, 2, 7, 0, 0, 0, time.UTC)
+	destA := notification.DestID{CMID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+	destB := notification.DestID{NCID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+
+	msgs := []Message{
+		{ID: "a1", Type: notification.MessageTypeAlertStatus, DestID: destA, UserID: "user", QuietUntil: n, CreatedAt: n.Add(-time.Hour)},
+		{ID: "a2", Type: notification.MessageTypeScheduleOnCallUsers, DestID: destA, UserID: "user", QuietUntil: n, CreatedAt: n.Add(-2 * time.Hour)},
+		{ID: "b1", Type: notification.MessageTypeAlertStatus, DestID: destB, QuietUntil: n, CreatedAt: n.Add(-time.Hour)},
+		{ID: "c", Type: notification.MessageTypeAlertStatus, DestID: destB, CreatedAt: n},
+	}
+
+	var created []Message
+	bundled := make(map[string][]string)
+	out, err := digestQuietMessages(msgs, n, func(msg Message) (string, error) {
+		created = append(created, msg)
+		return "digest-" + msg.DestID.String(), nil
+	}, func(parentID string, ids []string) error {
+		bundled[parentID] = ids
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, created, 2)
+	assert.Equal(t, destA, created[0].DestID, "digests should be ordered by oldest held message")
+	assert.Equal(t, "user", created[0].UserID)
+	assert.Equal(t, notification.MessageTypeQuietHoursDigest, created[0].Type)
+	assert.Equal(t, map[string][]string{
+		"digest-" + destA.String(): {"a2", "a1"},
+		"digest-" + destB.String(): {"b1"},
+	}, bundled)
+
+	require.Len(t, out, 3)
+	assert.Equal(t, "c", out[0].ID)
+	assert.Equal(t, "digest-"+destA.String(), out[1].ID)
+	assert.Equal(t, "digest-"+destB.String(), out[2].ID)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notification/email"
+	"github.com/target/goalert/notification/smpp"
 	"github.com/target/goalert/notification/twilio"
 )
 
@@ -19,7 +20,7 @@ func init() {
 
 	// Rate limit sms, voice and email types
 	perCM.
-		WithDestTypes(twilio.DestTypeTwilioVoice, twilio.DestTypeTwilioSMS, email.DestTypeEmail).
+		WithDestTypes(twilio.DestTypeTwilioVoice, twilio.DestTypeTwilioSMS, smpp.DestTypeSMPPSMS, email.DestTypeEmail).
 		AddRules([]ThrottleRule{{Count: 1, Per: time.Minute}})
 
 	// On-Call Status Notifications
@@ -33,7 +34,7 @@ func init() {
 	// status notifications
 	perCM.
 		WithMsgTypes(notification.MessageTypeAlertStatus).
-		WithDestTypes(twilio.DestTypeTwilioVoice, twilio.DestTypeTwilioSMS, email.DestTypeEmail).
+		WithDestTypes(twilio.DestTypeTwilioVoice, twilio.DestTypeTwilioSMS, smpp.DestTypeSMPPSMS, email.DestTypeEmail).
 		AddRules([]ThrottleRule{
 			{Count: 1, Per: 3 * time.Minute},
 			{Count: 3, Per: 20 * time.Minute},
@@ -52,7 +53,7 @@ func init() {
 		})
 
 	alertMessages.
-		WithDestTypes(twilio.DestTypeTwilioSMS).
+		WithDestTypes(twilio.DestTypeTwilioSMS, smpp.DestTypeSMPPSMS).
 		AddRules([]ThrottleRule{
 			{Count: 5, Per: 15 * time.Minute},
 			{Count: 11, Per: time.Hour, Smooth: true},
@@ -26,7 +26,7 @@ func (db *DB) Name() string { return "Engine.NotificationCycleManager" }
 func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeNPCycle,
-		Version: 2,
+		Version: 3,
 	})
 	if err != nil {
 		return nil, err
@@ -44,6 +44,8 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 		// - notifications were sent for 0-minute at 1:00:15 (last tick = 1:00:15)
 		// - at 1:01:15 only notification rules with delays between 15 and 75 seconds would be processed/sent
 		// Note: since delays are in minutes, the above example would just send the 1 minute rules (60 seconds)
+		//
+		// Rules scoped to a set of alert priorities or a service label are skipped for alerts that don't match.
 		queueMessages: p.P(`
 			with lock_cycles as (
 				select
@@ -103,7 +105,19 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 						cycle.last_tick isnull or
 						concat(rule.delay_minutes,' minutes')::interval > (cycle.last_tick - cycle.started_at)
 					) and
-					concat(rule.delay_minutes,' minutes')::interval <= (now() - cycle.started_at)
+					concat(rule.delay_minutes,' minutes')::interval <= (now() - cycle.started_at) and
+					(rule.priorities isnull or a.priority = any(rule.priorities)) and
+					(
+						rule.service_label_key isnull or
+						exists (
+							select null
+							from labels l
+							where
+								l.tgt_service_id = a.service_id and
+								l.key = rule.service_label_key and
+								(rule.service_label_value isnull or l.value = rule.service_label_value)
+						)
+					)
 				returning cycle_id
 			), no_first_notif_sent as (
 				select user_id, alert_id
@@ -17,4 +17,6 @@ const (
 	TypeMetrics      Type = "metrics"
 	TypeCompat       Type = "compat"
 	TypeSignals      Type = "signals"
+	TypeSnooze       Type = "snooze"
+	TypeAvailability Type = "availability"
 )
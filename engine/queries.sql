@@ -24,3 +24,54 @@ SELECT
         WHERE
             nc.dest = $1);
 
+-- name: EngineGetOverrideRequest :one
+-- Get the details of an override request for rendering a notification.
+SELECT
+    req.add_user_id,
+    req.remove_user_id,
+    req.start_time,
+    req.end_time,
+    req.schedule_id,
+    sched.name AS schedule_name,
+    sched.time_zone AS schedule_time_zone,
+    requester.name AS requester_name,
+    coalesce(add_user.name, '') AS add_user_name,
+    coalesce(remove_user.name, '') AS remove_user_name,
+    req.requester_user_id,
+    req.target_user_id
+FROM
+    outgoing_messages msg
+    JOIN override_requests req ON req.id = msg.override_request_id
+    JOIN schedules sched ON sched.id = req.schedule_id
+    JOIN users requester ON requester.id = req.requester_user_id
+    LEFT JOIN users add_user ON add_user.id = req.add_user_id
+    LEFT JOIN users remove_user ON remove_user.id = req.remove_user_id
+WHERE
+    msg.id = $1;
+
+-- name: EngineGetCoverageGapNotice :one
+-- Get the coverage gap a schedule was last notified about.
+SELECT
+    gap_start,
+    gap_end
+FROM
+    schedule_coverage_notices
+WHERE
+    schedule_id = $1;
+
+-- name: EngineGetDigestMessages :many
+-- Get the messages held for quiet hours that were bundled into a digest.
+SELECT
+    message_type,
+    alert_id,
+    alert_log_id,
+    schedule_id,
+    created_at
+FROM
+    outgoing_messages
+WHERE
+    last_status = 'bundled'
+    AND quiet_until NOTNULL
+    AND status_details = @digest_id::text
+ORDER BY
+    created_at;
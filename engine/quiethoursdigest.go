@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/engine/message"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/notification"
+)
+
+// digestItems will return an item for each message that was held for quiet hours and bundled into the
+// digest message.
+func (p *Engine) digestItems(ctx context.Context, msg *message.Message) ([]notification.DigestItem, error) {
+	rows, err := gadb.New(p.b.db).EngineGetDigestMessages(ctx, msg.ID)
+	if err != nil {
+		return nil, fmt.Errorf("lookup digest messages: %w", err)
+	}
+
+	cfg := p.cfg.ConfigSource.Config()
+	var items []notification.DigestItem
+	for _, row := range rows {
+		item := notification.DigestItem{Time: row.CreatedAt}
+		switch row.MessageType {
+		case notification.MessageTypeAlertStatus:
+			e, err := p.cfg.AlertLogStore.FindOne(ctx, int(row.AlertLogID.Int64))
+			if err != nil {
+				return nil, fmt.Errorf("lookup alert log entry: %w", err)
+			}
+			a, err := p.cfg.AlertStore.FindOne(ctx, int(row.AlertID.Int64))
+			if err != nil {
+				return nil, fmt.Errorf("lookup alert: %w", err)
+			}
+			item.Summary = fmt.Sprintf("Alert #%d: %s (%s)", a.ID, a.Summary, e.String(ctx))
+			item.URL = cfg.CallbackURL(fmt.Sprintf("/alerts/%d", a.ID))
+		case notification.MessageTypeScheduleOnCallUsers:
+			item.Summary, err = p.onCallSummary(ctx, row.ScheduleID.UUID)
+			if err != nil {
+				return nil, err
+			}
+			item.URL = cfg.CallbackURL("/schedules/" + row.ScheduleID.UUID.String())
+		default:
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// onCallSummary returns a description of the users currently on call for a schedule.
+func (p *Engine) onCallSummary(ctx context.Context, scheduleID uuid.UUID) (string, error) {
+	sched, err := p.cfg.ScheduleStore.FindOne(ctx, scheduleID.String())
+	if err != nil {
+		return "", fmt.Errorf("lookup schedule by id: %w", err)
+	}
+	users, err := p.cfg.OnCallStore.OnCallUsersBySchedule(ctx, scheduleID.String())
+	if err != nil {
+		return "", fmt.Errorf("lookup on call users by schedule: %w", err)
+	}
+	if len(users) == 0 {
+		return fmt.Sprintf("No one is on call for %s.", sched.Name), nil
+	}
+
+	names := make([]string, len(users))
+	for i, u := range users {
+		names[i] = u.Name
+	}
+
+	return fmt.Sprintf("On call for %s: %s", sched.Name, strings.Join(names, ", ")), nil
+}
@@ -21,13 +21,22 @@ type rotState struct {
 }
 
 // calcAdvance will calculate rotation advancement if it is required. If not, nil is returned
-func calcAdvance(ctx context.Context, t time.Time, rot *rotation.Rotation, state rotState, partCount int) (*advance, error) {
+// Shadow participants never start a shift, and are skipped when advancing.
+func calcAdvance(ctx context.Context, t time.Time, rot *rotation.Rotation, state rotState, shadows []bool) (*advance, error) {
 	var mustUpdate bool
+	partCount := len(shadows)
 
 	if state.Position >= partCount {
 		mustUpdate = true
 		state.Position = 0
 	}
+	if shadows[state.Position] {
+		if next := rotation.NextPosition(state.Position, shadows); !shadows[next] {
+			// shadow participants can't be the active participant, so move to the next one
+			mustUpdate = true
+			state.Position = next
+		}
+	}
 
 	endTimeFunc := rot.EndTime
 
@@ -73,7 +82,7 @@ func calcAdvance(ctx context.Context, t time.Time, rot *rotation.Rotation, state
 			panic("too many rotation advances")
 		}
 
-		state.Position = (state.Position + 1) % partCount
+		state.Position = rotation.NextPosition(state.Position, shadows)
 		end := endTimeFunc(state.ShiftStart)
 		if end.After(t) {
 			break
@@ -22,7 +22,7 @@ func (db *DB) Name() string { return "Engine.RotationManager" }
 func NewDB(ctx context.Context, db *sql.DB, riverDBSQL *river.Client[*sql.Tx]) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeRotation,
-		Version: 2,
+		Version: 4,
 	})
 	if err != nil {
 		return nil, err
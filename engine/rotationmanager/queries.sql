@@ -14,7 +14,16 @@ SELECT
         WHERE
             p.rotation_id = rot.id
         ORDER BY
-            position)::uuid[] AS participants
+            position)::uuid[] AS participants,
+    ARRAY (
+        SELECT
+            p.shadow
+        FROM
+            rotation_participants p
+        WHERE
+            p.rotation_id = rot.id
+        ORDER BY
+            position)::bool[] AS shadows
     FROM
         rotations rot
     LEFT JOIN rotation_state state ON rot.id = state.rotation_id
@@ -30,14 +39,17 @@ INSERT INTO rotation_state(
     rotation_participant_id)
 SELECT
     p.rotation_id,
-    0,
+    p.position,
     now(),
     id
 FROM
     rotation_participants p
 WHERE
     p.rotation_id = @rotation_id
-    AND position = 0;
+ORDER BY
+    p.shadow,
+    p.position
+LIMIT 1;
 
 -- name: RotMgrEnd :exec
 -- End a rotation.
@@ -55,3 +67,31 @@ SET
     version = 2
 WHERE
     rotation_id = @rotation_id;
+
+-- name: RotMgrParticipantWindows :many
+-- Get the follow-the-sun windows of participants for a given rotation ID.
+SELECT
+    position,
+    window_start::text AS window_start,
+    window_end::text AS window_end,
+    window_time_zone::text AS window_time_zone
+FROM
+    rotation_participants
+WHERE
+    rotation_id = @rotation_id
+    AND window_time_zone NOTNULL
+ORDER BY
+    position;
+
+-- name: RotMgrSetActiveParticipants :exec
+-- Set the participants that are on call in addition to the one in rotation_state.
+WITH removed AS (
+    DELETE FROM rotation_active_participants
+    WHERE rotation_id = @rotation_id
+        AND rotation_participant_id <> ALL (@participant_ids::uuid[]))
+INSERT INTO rotation_active_participants(rotation_id, rotation_participant_id)
+SELECT
+    @rotation_id,
+    unnest(@participant_ids::uuid[])
+ON CONFLICT
+    DO NOTHING;
@@ -12,6 +12,7 @@ import (
 	"github.com/target/goalert/gadb"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/timeutil"
 )
 
 type UpdateArgs struct {
@@ -56,6 +57,13 @@ func (db *DB) updateRotation(ctx context.Context, j *river.Job[UpdateArgs]) erro
 			Type:        rotation.Type(row.Rotation.Type),
 			Start:       row.Rotation.StartTime.In(loc),
 			ShiftLength: int(row.Rotation.ShiftLength),
+			ActiveCount: int(row.Rotation.ActiveCount),
+		}
+		if r.Type == rotation.TypeFollowTheSun {
+			r.Windows, err = participantWindows(ctx, g, j.Args.RotationID, len(row.Participants))
+			if err != nil {
+				return fmt.Errorf("load participant windows: %w", err)
+			}
 		}
 
 		// schedule next run
@@ -72,27 +80,53 @@ func (db *DB) updateRotation(ctx context.Context, j *river.Job[UpdateArgs]) erro
 			return fmt.Errorf("schedule next run: %w", err)
 		}
 
+		pos := int(row.StatePosition)
 		if row.StateVersion == 0 {
 			// no state, but we have participants, so start at the beginning
 			err = g.RotMgrStart(ctx, j.Args.RotationID)
 			if err != nil {
 				return fmt.Errorf("start rotation: %w", err)
 			}
-			return nil
+
+			// first non-shadow participant
+			pos = rotation.NextPosition(len(row.Participants)-1, row.Shadows)
+			if r.Type != rotation.TypeFollowTheSun {
+				return setActiveParticipants(ctx, g, &r, row, pos)
+			}
+			row.StateVersion = 2
+		}
+
+		if r.Type == rotation.TypeFollowTheSun {
+			// the active participant is determined by the windows, rather than advancing in order
+			idx := r.ActiveIndex(row.Now)
+			if idx == -1 || (idx == pos && row.StateVersion == 2) {
+				return setActiveParticipants(ctx, g, &r, row, pos)
+			}
+
+			err = g.RotMgrUpdate(ctx, gadb.RotMgrUpdateParams{
+				RotationID:            j.Args.RotationID,
+				Position:              int32(idx),
+				RotationParticipantID: row.Participants[idx],
+			})
+			if err != nil {
+				return fmt.Errorf("update rotation state (follow-the-sun): %w", err)
+			}
+
+			return setActiveParticipants(ctx, g, &r, row, idx)
 		}
 
 		s := rotState{
 			ShiftStart: row.StateShiftStart.Time.In(loc),
-			Position:   int(row.StatePosition),
+			Position:   pos,
 			Version:    int(row.StateVersion),
 		}
-		adv, err := calcAdvance(ctx, row.Now, &r, s, len(row.Participants))
+		adv, err := calcAdvance(ctx, row.Now, &r, s, row.Shadows)
 		if err != nil {
 			return fmt.Errorf("calc advance: %w", err)
 		}
 		if adv == nil {
 			// no advancement needed
-			return nil
+			return setActiveParticipants(ctx, g, &r, row, pos)
 		}
 
 		err = g.RotMgrUpdate(ctx, gadb.RotMgrUpdateParams{
@@ -104,6 +138,60 @@ func (db *DB) updateRotation(ctx context.Context, j *river.Job[UpdateArgs]) erro
 			return fmt.Errorf("update rotation state (advance): %w", err)
 		}
 
-		return nil
+		return setActiveParticipants(ctx, g, &r, row, adv.newPosition)
+	})
+}
+
+// setActiveParticipants records the participants that are on call alongside the one at pos, for rotations
+// with multiple active participants or shadows.
+func setActiveParticipants(ctx context.Context, g *gadb.Queries, r *rotation.Rotation, row gadb.RotMgrRotationDataRow, pos int) error {
+	ids := []uuid.UUID{}
+	for _, p := range rotation.ActivePositions(pos, r.ActiveCount, row.Shadows) {
+		if p == pos {
+			continue
+		}
+		ids = append(ids, row.Participants[p])
+	}
+
+	err := g.RotMgrSetActiveParticipants(ctx, gadb.RotMgrSetActiveParticipantsParams{
+		RotationID:     row.Rotation.ID,
+		ParticipantIds: ids,
 	})
+	if err != nil {
+		return fmt.Errorf("set active participants: %w", err)
+	}
+
+	return nil
+}
+
+// participantWindows returns the follow-the-sun window of each participant, by position.
+func participantWindows(ctx context.Context, g *gadb.Queries, rotID uuid.UUID, count int) ([]*rotation.ParticipantWindow, error) {
+	rows, err := g.RotMgrParticipantWindows(ctx, rotID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*rotation.ParticipantWindow, count)
+	for _, row := range rows {
+		if int(row.Position) >= count {
+			continue
+		}
+
+		var w rotation.ParticipantWindow
+		w.Start, err = timeutil.ParseClock(row.WindowStart)
+		if err != nil {
+			return nil, fmt.Errorf("parse window start: %w", err)
+		}
+		w.End, err = timeutil.ParseClock(row.WindowEnd)
+		if err != nil {
+			return nil, fmt.Errorf("parse window end: %w", err)
+		}
+		w.TimeZone, err = util.LoadLocation(row.WindowTimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("load window time zone: %w", err)
+		}
+		result[row.Position] = &w
+	}
+
+	return result, nil
 }
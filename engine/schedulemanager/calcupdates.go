@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/target/goalert/gadb"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/rule"
 	"github.com/target/goalert/util/jsonutil"
 )
 
@@ -20,6 +21,7 @@ type updateInfo struct {
 	CurrentOnCall   mapset.Set[uuid.UUID]
 	Rules           []gadb.SchedMgrRulesRow
 	ActiveOverrides []gadb.SchedMgrOverridesRow
+	Holidays        rule.HolidaySet
 }
 
 type updateResult struct {
@@ -39,7 +41,7 @@ func (info updateInfo) calcLatestOnCall(now time.Time) mapset.Set[uuid.UUID] {
 	now = now.In(info.TimeZone)
 	newOnCall := mapset.NewThreadUnsafeSet[uuid.UUID]()
 	for _, r := range info.Rules {
-		if ruleRowIsActive(r, now) {
+		if ruleRowIsActive(r, now, info.Holidays) {
 			newOnCall.Add(r.ResolvedUserID)
 		}
 	}
@@ -76,6 +78,9 @@ func (info updateInfo) calcUpdates(now time.Time) (*updateResult, error) {
 	}
 	now = now.In(info.TimeZone)
 
+	// apply upcoming recurring templates first, so they take effect immediately if already started
+	dataNeedsUpdate := info.ScheduleData.MaterializeTemplates(now, info.TimeZone)
+
 	newOnCall := info.calcLatestOnCall(now)
 	onCallChanged := !newOnCall.Equal(info.CurrentOnCall)
 	if onCallChanged {
@@ -83,7 +88,6 @@ func (info updateInfo) calcUpdates(now time.Time) (*updateResult, error) {
 		result.UsersToStart = newOnCall.Difference(info.CurrentOnCall) // not currently on-call, but should be
 	}
 
-	var dataNeedsUpdate bool
 	newRules := make([]schedule.OnCallNotificationRule, len(info.ScheduleData.V1.OnCallNotificationRules))
 	// we copy the rules to avoid modifying the original slice
 	copy(newRules, info.ScheduleData.V1.OnCallNotificationRules)
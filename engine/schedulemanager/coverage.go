@@ -0,0 +1,133 @@
+package schedulemanager
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/util/log"
+)
+
+type CoverageArgs struct{}
+
+func (CoverageArgs) Kind() string { return "schedule-manager-coverage" }
+
+// checkCoverage will send a notice to a schedule's on-call notification channels when
+// a gap in coverage appears within the configured horizon.
+func (db *DB) checkCoverage(ctx context.Context, j *river.Job[CoverageArgs]) error {
+	cfg := config.FromContext(ctx)
+	if cfg.Schedules.CoverageGapNoticeHours <= 0 {
+		return nil
+	}
+	ctx = permission.SystemContext(ctx, "ScheduleCoverage")
+
+	var rows []gadb.SchedMgrCoverageSchedulesRow
+	err := db.lock.WithTxShared(ctx, func(ctx context.Context, tx *sql.Tx) (err error) {
+		rows, err = gadb.New(tx).SchedMgrCoverageSchedules(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("get schedules: %w", err)
+	}
+
+	start := time.Now()
+	end := start.Add(time.Duration(cfg.Schedules.CoverageGapNoticeHours) * time.Hour)
+	for _, row := range rows {
+		ctx := log.WithField(ctx, "ScheduleID", row.ScheduleID)
+		gaps, err := db.onCall.CoverageGapsBySchedule(ctx, row.ScheduleID.String(), start, end)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("calculate coverage gaps: %w", err))
+			continue
+		}
+
+		var last *oncall.CoverageGap
+		if row.GapStart.Valid && row.GapEnd.Valid {
+			last = &oncall.CoverageGap{Start: row.GapStart.Time, End: row.GapEnd.Time}
+		}
+		gap, clear := nextCoverageNotice(gaps, last)
+		if gap == nil && !clear {
+			continue
+		}
+
+		var data schedule.Data
+		err = json.Unmarshal(row.Data, &data)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("unmarshal schedule data: %w", err))
+			continue
+		}
+
+		err = db.lock.WithTxShared(ctx, func(ctx context.Context, tx *sql.Tx) error {
+			return sendCoverageNotice(ctx, gadb.New(tx), row.ScheduleID, data, gap)
+		})
+		if isScheduleDeleted(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("send coverage notice for schedule %s: %w", row.ScheduleID, err)
+		}
+	}
+
+	return nil
+}
+
+// sendCoverageNotice will record the gap and send a notice to each on-call notification channel.
+// If gap is nil, the last notice is cleared.
+func sendCoverageNotice(ctx context.Context, q *gadb.Queries, schedID uuid.UUID, data schedule.Data, gap *oncall.CoverageGap) error {
+	if gap == nil {
+		return q.SchedMgrDeleteCoverageNotice(ctx, schedID)
+	}
+
+	err := q.SchedMgrSetCoverageNotice(ctx, gadb.SchedMgrSetCoverageNoticeParams{
+		ScheduleID: schedID,
+		GapStart:   gap.Start,
+		GapEnd:     gap.End,
+	})
+	if err != nil {
+		return fmt.Errorf("record notice: %w", err)
+	}
+
+	sent := make(map[uuid.UUID]bool)
+	for _, r := range data.V1.OnCallNotificationRules {
+		if sent[r.ChannelID] {
+			continue
+		}
+		sent[r.ChannelID] = true
+
+		err = q.SchedMgrInsertCoverageGapMessage(ctx, gadb.SchedMgrInsertCoverageGapMessageParams{
+			ID:         uuid.New(),
+			ChannelID:  uuid.NullUUID{UUID: r.ChannelID, Valid: true},
+			ScheduleID: uuid.NullUUID{UUID: schedID, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("insert message for channel %s: %w", r.ChannelID, err)
+		}
+	}
+
+	return nil
+}
+
+// nextCoverageNotice returns the gap that a notice should be sent for, if any. The
+// earliest gap is only sent if it does not overlap the last one sent. If there are no
+// gaps, clear will be true if the last notice should be removed.
+func nextCoverageNotice(gaps []oncall.CoverageGap, last *oncall.CoverageGap) (gap *oncall.CoverageGap, clear bool) {
+	if len(gaps) == 0 {
+		return nil, last != nil
+	}
+
+	next := gaps[0]
+	if last != nil && next.Start.Before(last.End) && last.Start.Before(next.End) {
+		// already notified
+		return nil, false
+	}
+
+	return &next, false
+}
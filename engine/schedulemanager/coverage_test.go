@@ -0,0 +1,35 @@
+package schedulemanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/oncall"
+)
+
+func TestNextCoverageNotice(t *testing.T) {
+	now := time.Date(2021, 7, 7, 11, 0, 0, 0, time.UTC)
+	gap := func(startH, endH int) oncall.CoverageGap {
+		return oncall.CoverageGap{Start: now.Add(time.Duration(startH) * time.Hour), End: now.Add(time.Duration(endH) * time.Hour)}
+	}
+
+	res, clear := nextCoverageNotice(nil, nil)
+	assert.Nil(t, res)
+	assert.False(t, clear, "nothing to clear")
+
+	last := gap(2, 4)
+	res, clear = nextCoverageNotice(nil, &last)
+	assert.Nil(t, res)
+	assert.True(t, clear, "covered again")
+
+	res, _ = nextCoverageNotice([]oncall.CoverageGap{gap(2, 4), gap(8, 9)}, nil)
+	assert.Equal(t, gap(2, 4), *res, "new gap")
+
+	res, clear = nextCoverageNotice([]oncall.CoverageGap{gap(0, 4)}, &last)
+	assert.Nil(t, res, "already notified, now in progress")
+	assert.False(t, clear)
+
+	res, _ = nextCoverageNotice([]oncall.CoverageGap{gap(5, 6)}, &last)
+	assert.Equal(t, gap(5, 6), *res, "different gap")
+}
@@ -37,8 +37,21 @@ SELECT
     coalesce(rule.tgt_user_id, part.user_id) AS resolved_user_id
 FROM
     schedule_rules rule
-    LEFT JOIN rotation_state rState ON rState.rotation_id = rule.tgt_rotation_id
-    LEFT JOIN rotation_participants part ON part.id = rState.rotation_participant_id
+    LEFT JOIN LATERAL (
+        SELECT
+            rState.rotation_participant_id AS participant_id
+        FROM
+            rotation_state rState
+        WHERE
+            rState.rotation_id = rule.tgt_rotation_id
+        UNION
+        SELECT
+            ap.rotation_participant_id
+        FROM
+            rotation_active_participants ap
+        WHERE
+            ap.rotation_id = rule.tgt_rotation_id) active ON TRUE
+    LEFT JOIN rotation_participants part ON part.id = active.participant_id
 WHERE
     coalesce(rule.tgt_user_id, part.user_id)
     NOTNULL;
@@ -78,6 +91,17 @@ SELECT
 FROM
     schedules;
 
+-- name: SchedMgrHolidays :many
+-- Returns the holidays of each schedule around the current date. Dates are widened by a day to cover all time zones.
+SELECT DISTINCT
+    sched.schedule_id,
+    d.date
+FROM
+    schedule_holiday_calendars sched
+    JOIN holiday_calendar_dates d ON d.calendar_id = sched.calendar_id
+WHERE
+    d.date BETWEEN now()::date - 1 AND now()::date + 1;
+
 -- name: SchedMgrOnCall :many
 SELECT
     schedule_id,
@@ -112,3 +136,33 @@ WHERE
 INSERT INTO outgoing_messages(id, message_type, channel_id, schedule_id)
     VALUES ($1, 'schedule_on_call_notification', $2, $3);
 
+
+-- name: SchedMgrCoverageSchedules :many
+-- Returns schedules that have on-call notification rules, along with the last coverage gap notice, if any.
+SELECT
+    data.schedule_id,
+    data.data,
+    notice.gap_start,
+    notice.gap_end
+FROM
+    schedule_data data
+    LEFT JOIN schedule_coverage_notices notice ON notice.schedule_id = data.schedule_id
+WHERE
+    data.data @> '{"V1":{"OnCallNotificationRules":[{}]}}';
+
+-- name: SchedMgrSetCoverageNotice :exec
+-- Records the coverage gap that notifications were last sent for.
+INSERT INTO schedule_coverage_notices(schedule_id, gap_start, gap_end)
+    VALUES ($1, $2, $3)
+ON CONFLICT (schedule_id)
+    DO UPDATE SET
+        gap_start = $2, gap_end = $3, notified_at = now();
+
+-- name: SchedMgrDeleteCoverageNotice :exec
+-- Clears the last coverage gap notice once the schedule is covered again.
+DELETE FROM schedule_coverage_notices
+WHERE schedule_id = $1;
+
+-- name: SchedMgrInsertCoverageGapMessage :exec
+INSERT INTO outgoing_messages(id, message_type, channel_id, schedule_id)
+    VALUES ($1, 'schedule_coverage_gap_notification', $2, $3);
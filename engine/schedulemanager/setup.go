@@ -0,0 +1,42 @@
+package schedulemanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/target/goalert/engine/processinglock"
+)
+
+const (
+	QueueName        = "schedule-manager"
+	PriorityCoverage = 3
+)
+
+var _ processinglock.Setupable = &DB{}
+
+// Setup implements processinglock.Setupable.
+func (db *DB) Setup(ctx context.Context, args processinglock.SetupArgs) error {
+	river.AddWorker(args.Workers, river.WorkFunc(db.checkCoverage))
+
+	err := args.River.Queues().Add(QueueName, river.QueueConfig{MaxWorkers: 2})
+	if err != nil {
+		return fmt.Errorf("add queue: %w", err)
+	}
+
+	args.River.PeriodicJobs().AddMany([]*river.PeriodicJob{
+		river.NewPeriodicJob(
+			river.PeriodicInterval(15*time.Minute),
+			func() (river.JobArgs, *river.InsertOpts) {
+				return CoverageArgs{}, &river.InsertOpts{
+					Queue:    QueueName,
+					Priority: PriorityCoverage,
+				}
+			},
+			&river.PeriodicJobOpts{RunOnStart: true},
+		),
+	})
+
+	return nil
+}
@@ -30,7 +30,7 @@ func (db *DB) UpdateAll(ctx context.Context) error {
 	return err
 }
 
-func ruleRowIsActive(row gadb.SchedMgrRulesRow, t time.Time) bool {
+func ruleRowIsActive(row gadb.SchedMgrRulesRow, t time.Time, holidays rule.HolidaySet) bool {
 	var wf timeutil.WeekdayFilter
 	if row.Sunday {
 		wf[0] = 1
@@ -57,7 +57,8 @@ func ruleRowIsActive(row gadb.SchedMgrRulesRow, t time.Time) bool {
 		Start:         row.StartTime,
 		End:           row.EndTime,
 		WeekdayFilter: wf,
-	}.IsActive(t)
+		HolidayFilter: rule.HolidayFilter(row.HolidayFilter),
+	}.IsActiveOn(t, holidays)
 }
 
 func (db *DB) update(ctx context.Context) error {
@@ -158,6 +159,18 @@ func (db *DB) update(ctx context.Context) error {
 		}
 	}
 
+	holidayRows, err := q.SchedMgrHolidays(ctx)
+	if err != nil {
+		return errors.Wrap(err, "get holidays")
+	}
+	for _, row := range holidayRows {
+		info := getInfo(row.ScheduleID)
+		if info.Holidays == nil {
+			info.Holidays = make(rule.HolidaySet)
+		}
+		info.Holidays.Add(row.Date)
+	}
+
 	onCallRows, err := q.SchedMgrOnCall(ctx)
 	if err != nil {
 		return errors.Wrap(err, "get on call")
@@ -288,7 +301,8 @@ func isScheduleDeleted(err error) bool {
 	switch dbErr.ConstraintName {
 	case "schedule_on_call_users_schedule_id_fkey",
 		"schedule_data_schedule_id_fkey",
-		"outgoing_messages_schedule_id_fkey":
+		"outgoing_messages_schedule_id_fkey",
+		"schedule_coverage_notices_schedule_id_fkey":
 		return true
 	default:
 		return false
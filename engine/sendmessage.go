@@ -12,6 +12,7 @@ import (
 	"github.com/target/goalert/gadb"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/log"
 )
 
@@ -84,6 +85,7 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 			ServiceID:   a.ServiceID,
 			ServiceName: name,
 			Meta:        meta,
+			Priority:    int(a.Priority),
 
 			OriginalStatus: stat,
 		}
@@ -107,9 +109,9 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 
 		var status notification.AlertState
 		switch e.Type() {
-		case alertlog.TypeAcknowledged:
+		case alertlog.TypeAcknowledged, alertlog.TypeSnoozed:
 			status = notification.AlertStateAcknowledged
-		case alertlog.TypeEscalated:
+		case alertlog.TypeEscalated, alertlog.TypeUnsnoozed, alertlog.TypeReopened:
 			status = notification.AlertStateUnacknowledged
 		case alertlog.TypeClosed:
 			status = notification.AlertStateClosed
@@ -164,6 +166,24 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 			ScheduleID:   msg.ScheduleID,
 			Users:        onCallUsers,
 		}
+	case notification.MessageTypeScheduleCoverageGap:
+		sched, err := p.cfg.ScheduleStore.FindOne(ctx, msg.ScheduleID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup schedule by id")
+		}
+		gap, err := gadb.New(p.b.db).EngineGetCoverageGapNotice(ctx, uuid.MustParse(msg.ScheduleID))
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup coverage gap notice")
+		}
+
+		notifMsg = notification.ScheduleCoverageGap{
+			Base:         msg.Base(),
+			ScheduleID:   msg.ScheduleID,
+			ScheduleName: sched.Name,
+			ScheduleURL:  p.cfg.ConfigSource.Config().CallbackURL("/schedules/" + msg.ScheduleID),
+			Start:        gap.GapStart.In(sched.TimeZone),
+			End:          gap.GapEnd.In(sched.TimeZone),
+		}
 	case notification.MessageTypeSignalMessage:
 		id, err := uuid.Parse(msg.ID)
 		if err != nil {
@@ -184,6 +204,59 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 			Base:   msg.Base(),
 			Params: params,
 		}
+	case notification.MessageTypeOverrideRequest:
+		id, err := uuid.Parse(msg.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse override request message id")
+		}
+		req, err := gadb.New(p.b.db).EngineGetOverrideRequest(ctx, id)
+		if err != nil {
+			return nil, errors.Wrap(err, "get override request")
+		}
+		tz, err := util.LoadLocation(req.ScheduleTimeZone)
+		if err != nil {
+			return nil, errors.Wrap(err, "load schedule time zone")
+		}
+
+		// the requester is the "other" user if they are swapping their own shift
+		other := req.RemoveUserID
+		isAdded := req.AddUserID.Valid && req.AddUserID.UUID == req.TargetUserID
+		if !isAdded {
+			other = req.AddUserID
+		}
+
+		notifMsg = notification.OverrideRequest{
+			Base:             msg.Base(),
+			RequestID:        msg.OverrideRequestID,
+			ScheduleID:       req.ScheduleID.String(),
+			ScheduleName:     req.ScheduleName,
+			RequesterName:    req.RequesterName,
+			AddUserName:      req.AddUserName,
+			RemoveUserName:   req.RemoveUserName,
+			RecipientIsAdded: isAdded,
+			RequesterIsOther: other.Valid && other.UUID == req.RequesterUserID,
+			Start:            req.StartTime.In(tz),
+			End:              req.EndTime.In(tz),
+		}
+	case notification.MessageTypeQuietHoursDigest:
+		items, err := p.digestItems(ctx, msg)
+		if err != nil {
+			return nil, errors.Wrap(err, "build quiet hours digest")
+		}
+		if len(items) == 0 {
+			return &notification.SendResult{
+				ID: msg.ID,
+				Status: notification.Status{
+					Details: "no held notifications remain",
+					State:   notification.StateFailedPerm,
+				},
+			}, nil
+		}
+
+		notifMsg = notification.QuietHoursDigest{
+			Base:  msg.Base(),
+			Items: items,
+		}
 	default:
 		log.Log(ctx, errors.New("SEND NOT IMPLEMENTED FOR MESSAGE TYPE "+string(msg.Type)))
 		return &notification.SendResult{ID: msg.ID, Status: notification.Status{State: notification.StateFailedPerm}}, nil
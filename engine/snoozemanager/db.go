@@ -0,0 +1,75 @@
+package snoozemanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/util"
+)
+
+// DB re-triggers snoozed alerts once their snooze has expired.
+type DB struct {
+	lock *processinglock.Lock
+
+	wake     *sql.Stmt
+	resetEsc *sql.Stmt
+
+	log *alertlog.Store
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.SnoozeManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeSnooze,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &DB{
+		lock: lock,
+		log:  log,
+
+		wake: p.P(`
+			with to_wake as (
+				select id
+				from alerts
+				where
+					status = 'active' and
+					snoozed_until <= now()
+				order by snoozed_until
+				limit 500
+				for update skip locked
+			)
+			update alerts a
+			set
+				status = 'triggered',
+				snoozed_until = null
+			from to_wake
+			where a.id = to_wake.id
+			returning a.id
+		`),
+
+		// Clearing last_escalation will cause the escalation manager to
+		// treat the alert as new, starting again from the first step.
+		resetEsc: p.P(`
+			update escalation_policy_state
+			set
+				last_escalation = null,
+				next_escalation = null,
+				escalation_policy_step_id = null,
+				escalation_policy_step_number = 0,
+				loop_count = 0,
+				force_escalation = false
+			where alert_id = any($1)
+		`),
+	}, p.Err
+}
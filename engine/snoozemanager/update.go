@@ -0,0 +1,62 @@
+package snoozemanager
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+// UpdateAll will re-trigger all alerts with an expired snooze.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+	log.Debugf(ctx, "Processing snoozed alerts.")
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "begin tx")
+	}
+	defer sqlutil.Rollback(ctx, "snooze manager", tx)
+
+	rows, err := tx.StmtContext(ctx, db.wake).QueryContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "wake snoozed alerts")
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return errors.Wrap(err, "scan alert id")
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return errors.Wrap(err, "wake snoozed alerts")
+	}
+	rows.Close()
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err = tx.StmtContext(ctx, db.resetEsc).ExecContext(ctx, sqlutil.IntArray(ids))
+	if err != nil {
+		return errors.Wrap(err, "reset escalation state")
+	}
+
+	err = db.log.LogManyTx(ctx, tx, ids, alertlog.TypeUnsnoozed, nil)
+	if err != nil {
+		return errors.Wrap(err, "log unsnooze")
+	}
+
+	return tx.Commit()
+}
@@ -1,9 +1,13 @@
 package escalation
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -22,6 +26,108 @@ type Step struct {
 	PolicyID     string    `json:"escalation_policy_id"`
 	DelayMinutes int       `json:"delay_minutes"`
 	StepNumber   int       `json:"step_number"`
+
+	// ActiveHours, if set, restricts the step to a recurring window of time.
+	// Outside of the window, the step is skipped during escalation.
+	ActiveHours *ActiveHours `json:"active_hours,omitempty"`
+
+	ModeConfig
+}
+
+// StepMode controls how a step notifies its targets.
+type StepMode string
+
+const (
+	// StepModeAll will notify all targets of the step at once.
+	StepModeAll StepMode = "all"
+
+	// StepModeRoundRobin will notify on-call users of the step one at a time, advancing
+	// to the next user every RoundRobinMinutes until the alert is acknowledged.
+	StepModeRoundRobin StepMode = "round_robin"
+
+	// StepModeAckCount will notify all targets of the step at once, but requires
+	// AckCount different users to acknowledge an alert before it is considered acknowledged.
+	StepModeAckCount StepMode = "ack_count"
+)
+
+// ModeConfig configures the notification mode of a step.
+type ModeConfig struct {
+	Mode StepMode `json:"mode,omitempty"`
+
+	// RoundRobinMinutes is the number of minutes between notifying each user, for StepModeRoundRobin.
+	RoundRobinMinutes int `json:"round_robin_minutes,omitempty"`
+
+	// AckCount is the number of users that must acknowledge an alert, for StepModeAckCount.
+	AckCount int `json:"ack_count,omitempty"`
+}
+
+// Normalize will validate the mode config, defaulting to StepModeAll.
+func (m ModeConfig) Normalize() (*ModeConfig, error) {
+	if m.Mode == "" {
+		m.Mode = StepModeAll
+	}
+	err := validate.OneOf("Mode", m.Mode, StepModeAll, StepModeRoundRobin, StepModeAckCount)
+	if err != nil {
+		return nil, err
+	}
+
+	switch m.Mode {
+	case StepModeRoundRobin:
+		err = validate.Range("RoundRobinMinutes", m.RoundRobinMinutes, 1, 60)
+	case StepModeAckCount:
+		err = validate.Range("AckCount", m.AckCount, 2, 20)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if m.Mode != StepModeRoundRobin && m.RoundRobinMinutes != 0 {
+		return nil, validation.NewFieldError("RoundRobinMinutes", "only valid for round-robin mode")
+	}
+	if m.Mode != StepModeAckCount && m.AckCount != 0 {
+		return nil, validation.NewFieldError("AckCount", "only valid for ack-count mode")
+	}
+
+	return &m, nil
+}
+
+// ActiveHours defines a recurring window of time, in a specific time zone, that
+// an escalation step is active for.
+//
+// If Start and End are equal, the step is active for the entirety of each
+// enabled day. If End is before Start, the window spans midnight and belongs to
+// the day it started on.
+type ActiveHours struct {
+	timeutil.WeekdayFilter
+	Start    timeutil.Clock `json:"start"`
+	End      timeutil.Clock `json:"end"`
+	TimeZone *time.Location `json:"time_zone"`
+}
+
+// IsActive will return true if the window is active at the given time.
+func (h ActiveHours) IsActive(t time.Time) bool {
+	if h.TimeZone != nil {
+		t = t.In(h.TimeZone)
+	}
+	c := timeutil.NewClockFromTime(t)
+	wd := t.Weekday()
+
+	switch {
+	case h.Start == h.End:
+		return h.Day(wd)
+	case h.Start < h.End:
+		return h.Day(wd) && c >= h.Start && c < h.End
+	}
+
+	return (h.Day(wd) && c >= h.Start) || (h.Day(wd-1) && c < h.End)
+}
+
+// IsActive will return true if the step is active at the given time.
+func (s Step) IsActive(t time.Time) bool {
+	if s.ActiveHours == nil {
+		return true
+	}
+
+	return s.ActiveHours.IsActive(t)
 }
 
 func (s Step) Delay() time.Duration {
@@ -37,5 +143,79 @@ func (s Step) Normalize() (*Step, error) {
 		return nil, err
 	}
 
+	if s.ActiveHours != nil {
+		h, err := s.ActiveHours.Normalize()
+		if err != nil {
+			return nil, err
+		}
+		s.ActiveHours = h
+	}
+
+	m, err := s.ModeConfig.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	s.ModeConfig = *m
+
 	return &s, nil
 }
+
+// Normalize will validate and normalize the active hours, truncating the start and end to the minute.
+func (h ActiveHours) Normalize() (*ActiveHours, error) {
+	if h.TimeZone == nil {
+		return nil, validation.NewFieldError("ActiveHours.TimeZone", "must be specified")
+	}
+	if h.IsNever() {
+		return nil, validation.NewFieldError("ActiveHours.WeekdayFilter", "must include at least one day")
+	}
+
+	h.Start = timeutil.Clock(time.Duration(h.Start).Truncate(time.Minute))
+	h.End = timeutil.Clock(time.Duration(h.End).Truncate(time.Minute))
+
+	return &h, nil
+}
+
+// scanFrom will scan a step from the given row. Columns are expected to be
+// id, escalation_policy_id, delay, step_number, coalesce(active_weekdays, '{}'),
+// coalesce(active_start, '00:00'), coalesce(active_end, '00:00'), active_time_zone,
+// mode, coalesce(round_robin_minutes, 0), coalesce(ack_count, 0).
+func (s *Step) scanFrom(scan func(...interface{}) error) error {
+	var h ActiveHours
+	var tz sql.NullString
+	err := scan(&s.ID, &s.PolicyID, &s.DelayMinutes, &s.StepNumber, &h.WeekdayFilter, &h.Start, &h.End, &tz, &s.Mode, &s.RoundRobinMinutes, &s.AckCount)
+	if err != nil {
+		return err
+	}
+	if !tz.Valid {
+		s.ActiveHours = nil
+		return nil
+	}
+
+	h.TimeZone, err = util.LoadLocation(tz.String)
+	if err != nil {
+		return err
+	}
+	s.ActiveHours = &h
+	return nil
+}
+
+// activeHoursArgs returns the DB arguments for the active_weekdays, active_start, active_end, and active_time_zone columns.
+func (s Step) activeHoursArgs() []interface{} {
+	if s.ActiveHours == nil {
+		return []interface{}{nil, nil, nil, nil}
+	}
+
+	return []interface{}{s.ActiveHours.WeekdayFilter, s.ActiveHours.Start, s.ActiveHours.End, s.ActiveHours.TimeZone.String()}
+}
+
+// modeArgs returns the DB arguments for the mode, round_robin_minutes, and ack_count columns.
+func (m ModeConfig) modeArgs() []interface{} {
+	args := []interface{}{m.Mode, nil, nil}
+	if m.Mode == StepModeRoundRobin {
+		args[1] = m.RoundRobinMinutes
+	}
+	if m.Mode == StepModeAckCount {
+		args[2] = m.AckCount
+	}
+	return args
+}
@@ -2,6 +2,11 @@ package escalation
 
 import (
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/util/timeutil"
 )
 
 func TestStep_Normalize(t *testing.T) {
@@ -23,10 +28,19 @@ func TestStep_Normalize(t *testing.T) {
 
 	valid := []Step{
 		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 1},
+		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 1, ActiveHours: &ActiveHours{WeekdayFilter: timeutil.EveryDay(), TimeZone: time.UTC}},
+		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 10, ModeConfig: ModeConfig{Mode: StepModeRoundRobin, RoundRobinMinutes: 2}},
+		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 10, ModeConfig: ModeConfig{Mode: StepModeAckCount, AckCount: 2}},
 	}
 
 	invalid := []Step{
 		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 9001},
+		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 1, ActiveHours: &ActiveHours{WeekdayFilter: timeutil.EveryDay()}},
+		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 1, ActiveHours: &ActiveHours{TimeZone: time.UTC}},
+		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 10, ModeConfig: ModeConfig{Mode: StepModeRoundRobin}},
+		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 10, ModeConfig: ModeConfig{Mode: StepModeAckCount, AckCount: 1}},
+		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 10, ModeConfig: ModeConfig{Mode: StepModeAll, AckCount: 2}},
+		{PolicyID: "a81facc0-4764-012d-7bfb-002500d5d678", DelayMinutes: 10, ModeConfig: ModeConfig{Mode: "random"}},
 	}
 	for _, s := range valid {
 		test(true, s)
@@ -35,3 +49,46 @@ func TestStep_Normalize(t *testing.T) {
 		test(false, s)
 	}
 }
+
+func TestModeConfig_Normalize(t *testing.T) {
+	m, err := ModeConfig{}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, StepModeAll, m.Mode, "default mode")
+}
+
+func TestActiveHours_IsActive(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	weekdays := timeutil.WeekdayFilter{0, 1, 1, 1, 1, 1, 0}
+
+	check := func(desc string, h ActiveHours, ts string, expected bool) {
+		t.Helper()
+		tm, err := time.ParseInLocation(time.DateTime, ts, loc)
+		require.NoError(t, err)
+		assert.Equal(t, expected, h.IsActive(tm), desc+" @ "+ts)
+	}
+
+	business := ActiveHours{WeekdayFilter: weekdays, Start: timeutil.NewClock(9, 0), End: timeutil.NewClock(17, 0), TimeZone: loc}
+	check("business", business, "2026-10-19 09:00:00", true) // Monday
+	check("business", business, "2026-10-19 16:59:00", true)
+	check("business", business, "2026-10-19 17:00:00", false)
+	check("business", business, "2026-10-19 08:59:00", false)
+	check("business", business, "2026-10-18 12:00:00", false) // Sunday
+
+	overnight := ActiveHours{WeekdayFilter: weekdays, Start: timeutil.NewClock(22, 0), End: timeutil.NewClock(6, 0), TimeZone: loc}
+	check("overnight", overnight, "2026-10-19 23:00:00", true)
+	check("overnight", overnight, "2026-10-20 05:00:00", true)
+	check("overnight", overnight, "2026-10-20 06:00:00", false)
+	check("overnight", overnight, "2026-10-19 05:00:00", false) // started Sunday
+	check("overnight", overnight, "2026-10-24 05:00:00", true)  // started Friday
+
+	allDay := ActiveHours{WeekdayFilter: weekdays, TimeZone: loc}
+	check("all day", allDay, "2026-10-19 00:00:00", true)
+	check("all day", allDay, "2026-10-18 23:59:00", false)
+
+	// evaluated in the configured time zone
+	tm := time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC) // 10:00 in Chicago
+	assert.True(t, business.IsActive(tm))
+	assert.True(t, Step{}.IsActive(tm), "no active hours")
+}
@@ -42,13 +42,15 @@ type Store struct {
 	updatePolicy              *sql.Stmt
 	deletePolicy              *sql.Stmt
 
-	findOneStepForUpdate *sql.Stmt
-	findAllSteps         *sql.Stmt
-	findAllOnCallSteps   *sql.Stmt
-	createStep           *sql.Stmt
-	updateStepDelay      *sql.Stmt
-	updateStepNumber     *sql.Stmt
-	deleteStep           *sql.Stmt
+	findOneStepForUpdate  *sql.Stmt
+	findAllSteps          *sql.Stmt
+	findAllOnCallSteps    *sql.Stmt
+	createStep            *sql.Stmt
+	updateStepDelay       *sql.Stmt
+	updateStepNumber      *sql.Stmt
+	updateStepActiveHours *sql.Stmt
+	updateStepMode        *sql.Stmt
+	deleteStep            *sql.Stmt
 }
 
 func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
@@ -107,10 +109,29 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 		updatePolicy: p.P(`UPDATE escalation_policies SET name = $2, description = $3, repeat = $4 WHERE id = $1`),
 		deletePolicy: p.P(`DELETE FROM escalation_policies WHERE id = any($1)`),
 
-		findOneStepForUpdate: p.P(`SELECT id, escalation_policy_id, delay, step_number FROM escalation_policy_steps WHERE id = $1 FOR UPDATE`),
-		findAllSteps:         p.P(`SELECT id, escalation_policy_id, delay, step_number FROM escalation_policy_steps WHERE escalation_policy_id = $1 ORDER BY step_number`),
+		findOneStepForUpdate: p.P(`
+			SELECT
+				id, escalation_policy_id, delay, step_number,
+				coalesce(active_weekdays, '{}'), coalesce(active_start, '00:00'), coalesce(active_end, '00:00'), active_time_zone,
+				mode, coalesce(round_robin_minutes, 0), coalesce(ack_count, 0)
+			FROM escalation_policy_steps
+			WHERE id = $1
+			FOR UPDATE
+		`),
+		findAllSteps: p.P(`
+			SELECT
+				id, escalation_policy_id, delay, step_number,
+				coalesce(active_weekdays, '{}'), coalesce(active_start, '00:00'), coalesce(active_end, '00:00'), active_time_zone,
+				mode, coalesce(round_robin_minutes, 0), coalesce(ack_count, 0)
+			FROM escalation_policy_steps
+			WHERE escalation_policy_id = $1
+			ORDER BY step_number
+		`),
 		findAllOnCallSteps: p.P(`
-			SELECT step.id, step.escalation_policy_id, step.delay, step.step_number
+			SELECT
+				step.id, step.escalation_policy_id, step.delay, step.step_number,
+				coalesce(step.active_weekdays, '{}'), coalesce(step.active_start, '00:00'), coalesce(step.active_end, '00:00'), step.active_time_zone,
+				step.mode, coalesce(step.round_robin_minutes, 0), coalesce(step.ack_count, 0)
 			FROM ep_step_on_call_users oc
 			JOIN escalation_policy_steps step ON step.id = oc.ep_step_id
 			WHERE oc.user_id = $1 AND oc.end_time isnull
@@ -119,13 +140,23 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 
 		createStep: p.P(`
 			INSERT INTO escalation_policy_steps
-				(id, escalation_policy_id, delay, step_number)
-			VALUES ($1, $2, $3, DEFAULT)
+				(id, escalation_policy_id, delay, step_number, active_weekdays, active_start, active_end, active_time_zone, mode, round_robin_minutes, ack_count)
+			VALUES ($1, $2, $3, DEFAULT, $4, $5, $6, $7, $8, $9, $10)
 			RETURNING step_number
 		`),
 		updateStepDelay:  p.P(`UPDATE escalation_policy_steps SET delay = $2 WHERE id = $1`),
 		updateStepNumber: p.P(`UPDATE escalation_policy_steps SET step_number = $2 WHERE id = $1`),
-		deleteStep:       p.P(`DELETE FROM escalation_policy_steps WHERE id = $1 RETURNING escalation_policy_id`),
+		updateStepActiveHours: p.P(`
+			UPDATE escalation_policy_steps
+			SET active_weekdays = $2, active_start = $3, active_end = $4, active_time_zone = $5
+			WHERE id = $1
+		`),
+		updateStepMode: p.P(`
+			UPDATE escalation_policy_steps
+			SET mode = $2, round_robin_minutes = $3, ack_count = $4
+			WHERE id = $1
+		`),
+		deleteStep: p.P(`DELETE FROM escalation_policy_steps WHERE id = $1 RETURNING escalation_policy_id`),
 	}, p.Err
 }
 
@@ -340,7 +371,7 @@ func (s *Store) FindOneStepForUpdateTx(ctx context.Context, tx *sql.Tx, id strin
 
 	row := stmt.QueryRowContext(ctx, id)
 	var st Step
-	err = row.Scan(&st.ID, &st.PolicyID, &st.DelayMinutes, &st.StepNumber)
+	err = st.scanFrom(row.Scan)
 	if err != nil {
 		return nil, err
 	}
@@ -377,7 +408,7 @@ func (s *Store) FindAllOnCallStepsForUserTx(ctx context.Context, tx *sql.Tx, use
 	var result []Step
 	for rows.Next() {
 		var s Step
-		err = rows.Scan(&s.ID, &s.PolicyID, &s.DelayMinutes, &s.StepNumber)
+		err = s.scanFrom(rows.Scan)
 		if err != nil {
 			return nil, err
 		}
@@ -412,7 +443,7 @@ func (s *Store) FindAllStepsTx(ctx context.Context, tx *sql.Tx, policyID string)
 	var result []Step
 	for rows.Next() {
 		var s Step
-		err = rows.Scan(&s.ID, &s.PolicyID, &s.DelayMinutes, &s.StepNumber)
+		err = s.scanFrom(rows.Scan)
 		if err != nil {
 			return nil, err
 		}
@@ -440,7 +471,7 @@ func (s *Store) CreateStepTx(ctx context.Context, tx *sql.Tx, st *Step) (*Step,
 
 	n.ID = uuid.New()
 
-	err = stmt.QueryRowContext(ctx, n.ID, n.PolicyID, n.DelayMinutes).Scan(&n.StepNumber)
+	err = stmt.QueryRowContext(ctx, append(append([]interface{}{n.ID, n.PolicyID, n.DelayMinutes}, n.activeHoursArgs()...), n.modeArgs()...)...).Scan(&n.StepNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -494,6 +525,59 @@ func (s *Store) UpdateStepDelayTx(ctx context.Context, tx *sql.Tx, stepID uuid.U
 	return nil
 }
 
+// UpdateStepActiveHoursTx updates the active hours for a step. A nil value will
+// clear the active hours, making the step always active.
+func (s *Store) UpdateStepActiveHoursTx(ctx context.Context, tx *sql.Tx, stepID uuid.UUID, h *ActiveHours) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	if h != nil {
+		h, err = h.Normalize()
+		if err != nil {
+			return err
+		}
+	}
+
+	stmt := s.updateStepActiveHours
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	_, err = stmt.ExecContext(ctx, append([]interface{}{stepID}, Step{ActiveHours: h}.activeHoursArgs()...)...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateStepModeTx updates the notification mode for a step.
+func (s *Store) UpdateStepModeTx(ctx context.Context, tx *sql.Tx, stepID uuid.UUID, m ModeConfig) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	n, err := m.Normalize()
+	if err != nil {
+		return err
+	}
+
+	stmt := s.updateStepMode
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	_, err = stmt.ExecContext(ctx, append([]interface{}{stepID}, n.modeArgs()...)...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // DeleteStepTx deletes a step from an escalation policy.
 func (s *Store) DeleteStepTx(ctx context.Context, tx *sql.Tx, id uuid.UUID) (string, error) {
 	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
@@ -20,6 +20,7 @@ import (
 type EngineProcessingType string
 
 const (
+	EngineProcessingTypeAvailability EngineProcessingType = "availability"
 	EngineProcessingTypeCleanup      EngineProcessingType = "cleanup"
 	EngineProcessingTypeCompat       EngineProcessingType = "compat"
 	EngineProcessingTypeEscalation   EngineProcessingType = "escalation"
@@ -30,6 +31,7 @@ const (
 	EngineProcessingTypeRotation     EngineProcessingType = "rotation"
 	EngineProcessingTypeSchedule     EngineProcessingType = "schedule"
 	EngineProcessingTypeSignals      EngineProcessingType = "signals"
+	EngineProcessingTypeSnooze       EngineProcessingType = "snooze"
 	EngineProcessingTypeStatusUpdate EngineProcessingType = "status_update"
 	EngineProcessingTypeVerify       EngineProcessingType = "verify"
 )
@@ -72,6 +74,7 @@ func (ns NullEngineProcessingType) Value() (driver.Value, error) {
 type EnumAlertLogEvent string
 
 const (
+	EnumAlertLogEventAckRecorded         EnumAlertLogEvent = "ack_recorded"
 	EnumAlertLogEventAcknowledged        EnumAlertLogEvent = "acknowledged"
 	EnumAlertLogEventAssignmentChanged   EnumAlertLogEvent = "assignment_changed"
 	EnumAlertLogEventClosed              EnumAlertLogEvent = "closed"
@@ -79,12 +82,15 @@ const (
 	EnumAlertLogEventDuplicateSuppressed EnumAlertLogEvent = "duplicate_suppressed"
 	EnumAlertLogEventEscalated           EnumAlertLogEvent = "escalated"
 	EnumAlertLogEventEscalationRequest   EnumAlertLogEvent = "escalation_request"
+	EnumAlertLogEventFlapping            EnumAlertLogEvent = "flapping"
 	EnumAlertLogEventNoNotificationSent  EnumAlertLogEvent = "no_notification_sent"
 	EnumAlertLogEventNotificationSent    EnumAlertLogEvent = "notification_sent"
 	EnumAlertLogEventPolicyUpdated       EnumAlertLogEvent = "policy_updated"
 	EnumAlertLogEventReopened            EnumAlertLogEvent = "reopened"
 	EnumAlertLogEventResponseReceived    EnumAlertLogEvent = "response_received"
+	EnumAlertLogEventSnoozed             EnumAlertLogEvent = "snoozed"
 	EnumAlertLogEventStatusChanged       EnumAlertLogEvent = "status_changed"
+	EnumAlertLogEventUnsnoozed           EnumAlertLogEvent = "unsnoozed"
 )
 
 func (e *EnumAlertLogEvent) Scan(src interface{}) error {
@@ -256,6 +262,49 @@ func (ns NullEnumAlertStatus) Value() (driver.Value, error) {
 	return string(ns.EnumAlertStatus), nil
 }
 
+type EnumEpStepMode string
+
+const (
+	EnumEpStepModeAckCount   EnumEpStepMode = "ack_count"
+	EnumEpStepModeAll        EnumEpStepMode = "all"
+	EnumEpStepModeRoundRobin EnumEpStepMode = "round_robin"
+)
+
+func (e *EnumEpStepMode) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnumEpStepMode(s)
+	case string:
+		*e = EnumEpStepMode(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnumEpStepMode: %T", src)
+	}
+	return nil
+}
+
+type NullEnumEpStepMode struct {
+	EnumEpStepMode EnumEpStepMode
+	Valid          bool // Valid is true if EnumEpStepMode is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEnumEpStepMode) Scan(value interface{}) error {
+	if value == nil {
+		ns.EnumEpStepMode, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EnumEpStepMode.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEnumEpStepMode) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EnumEpStepMode), nil
+}
+
 type EnumHeartbeatState string
 
 const (
@@ -299,6 +348,49 @@ func (ns NullEnumHeartbeatState) Value() (driver.Value, error) {
 	return string(ns.EnumHeartbeatState), nil
 }
 
+type EnumHolidayFilter string
+
+const (
+	EnumHolidayFilterAny     EnumHolidayFilter = "any"
+	EnumHolidayFilterExclude EnumHolidayFilter = "exclude"
+	EnumHolidayFilterOnly    EnumHolidayFilter = "only"
+)
+
+func (e *EnumHolidayFilter) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnumHolidayFilter(s)
+	case string:
+		*e = EnumHolidayFilter(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnumHolidayFilter: %T", src)
+	}
+	return nil
+}
+
+type NullEnumHolidayFilter struct {
+	EnumHolidayFilter EnumHolidayFilter
+	Valid             bool // Valid is true if EnumHolidayFilter is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEnumHolidayFilter) Scan(value interface{}) error {
+	if value == nil {
+		ns.EnumHolidayFilter, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EnumHolidayFilter.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEnumHolidayFilter) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EnumHolidayFilter), nil
+}
+
 type EnumIntegrationKeysType string
 
 const (
@@ -399,6 +491,91 @@ func (ns NullEnumLimitType) Value() (driver.Value, error) {
 	return string(ns.EnumLimitType), nil
 }
 
+type EnumMaintWindowEndAction string
+
+const (
+	EnumMaintWindowEndActionDrop     EnumMaintWindowEndAction = "drop"
+	EnumMaintWindowEndActionEscalate EnumMaintWindowEndAction = "escalate"
+)
+
+func (e *EnumMaintWindowEndAction) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnumMaintWindowEndAction(s)
+	case string:
+		*e = EnumMaintWindowEndAction(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnumMaintWindowEndAction: %T", src)
+	}
+	return nil
+}
+
+type NullEnumMaintWindowEndAction struct {
+	EnumMaintWindowEndAction EnumMaintWindowEndAction
+	Valid                    bool // Valid is true if EnumMaintWindowEndAction is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEnumMaintWindowEndAction) Scan(value interface{}) error {
+	if value == nil {
+		ns.EnumMaintWindowEndAction, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EnumMaintWindowEndAction.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEnumMaintWindowEndAction) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EnumMaintWindowEndAction), nil
+}
+
+type EnumMaintWindowRepeat string
+
+const (
+	EnumMaintWindowRepeatDaily  EnumMaintWindowRepeat = "daily"
+	EnumMaintWindowRepeatNone   EnumMaintWindowRepeat = "none"
+	EnumMaintWindowRepeatWeekly EnumMaintWindowRepeat = "weekly"
+)
+
+func (e *EnumMaintWindowRepeat) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnumMaintWindowRepeat(s)
+	case string:
+		*e = EnumMaintWindowRepeat(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnumMaintWindowRepeat: %T", src)
+	}
+	return nil
+}
+
+type NullEnumMaintWindowRepeat struct {
+	EnumMaintWindowRepeat EnumMaintWindowRepeat
+	Valid                 bool // Valid is true if EnumMaintWindowRepeat is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEnumMaintWindowRepeat) Scan(value interface{}) error {
+	if value == nil {
+		ns.EnumMaintWindowRepeat, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EnumMaintWindowRepeat.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEnumMaintWindowRepeat) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EnumMaintWindowRepeat), nil
+}
+
 type EnumNotifChannelType string
 
 const (
@@ -494,14 +671,17 @@ func (ns NullEnumOutgoingMessagesStatus) Value() (driver.Value, error) {
 type EnumOutgoingMessagesType string
 
 const (
-	EnumOutgoingMessagesTypeAlertNotification          EnumOutgoingMessagesType = "alert_notification"
-	EnumOutgoingMessagesTypeAlertNotificationBundle    EnumOutgoingMessagesType = "alert_notification_bundle"
-	EnumOutgoingMessagesTypeAlertStatusUpdate          EnumOutgoingMessagesType = "alert_status_update"
-	EnumOutgoingMessagesTypeAlertStatusUpdateBundle    EnumOutgoingMessagesType = "alert_status_update_bundle"
-	EnumOutgoingMessagesTypeScheduleOnCallNotification EnumOutgoingMessagesType = "schedule_on_call_notification"
-	EnumOutgoingMessagesTypeSignalMessage              EnumOutgoingMessagesType = "signal_message"
-	EnumOutgoingMessagesTypeTestNotification           EnumOutgoingMessagesType = "test_notification"
-	EnumOutgoingMessagesTypeVerificationMessage        EnumOutgoingMessagesType = "verification_message"
+	EnumOutgoingMessagesTypeAlertNotification               EnumOutgoingMessagesType = "alert_notification"
+	EnumOutgoingMessagesTypeAlertNotificationBundle         EnumOutgoingMessagesType = "alert_notification_bundle"
+	EnumOutgoingMessagesTypeAlertStatusUpdate               EnumOutgoingMessagesType = "alert_status_update"
+	EnumOutgoingMessagesTypeAlertStatusUpdateBundle         EnumOutgoingMessagesType = "alert_status_update_bundle"
+	EnumOutgoingMessagesTypeOverrideRequestNotification     EnumOutgoingMessagesType = "override_request_notification"
+	EnumOutgoingMessagesTypeQuietHoursDigest                EnumOutgoingMessagesType = "quiet_hours_digest"
+	EnumOutgoingMessagesTypeScheduleCoverageGapNotification EnumOutgoingMessagesType = "schedule_coverage_gap_notification"
+	EnumOutgoingMessagesTypeScheduleOnCallNotification      EnumOutgoingMessagesType = "schedule_on_call_notification"
+	EnumOutgoingMessagesTypeSignalMessage                   EnumOutgoingMessagesType = "signal_message"
+	EnumOutgoingMessagesTypeTestNotification                EnumOutgoingMessagesType = "test_notification"
+	EnumOutgoingMessagesTypeVerificationMessage             EnumOutgoingMessagesType = "verification_message"
 )
 
 func (e *EnumOutgoingMessagesType) Scan(src interface{}) error {
@@ -539,13 +719,58 @@ func (ns NullEnumOutgoingMessagesType) Value() (driver.Value, error) {
 	return string(ns.EnumOutgoingMessagesType), nil
 }
 
+type EnumOverrideRequestStatus string
+
+const (
+	EnumOverrideRequestStatusAccepted EnumOverrideRequestStatus = "accepted"
+	EnumOverrideRequestStatusDeclined EnumOverrideRequestStatus = "declined"
+	EnumOverrideRequestStatusExpired  EnumOverrideRequestStatus = "expired"
+	EnumOverrideRequestStatusPending  EnumOverrideRequestStatus = "pending"
+)
+
+func (e *EnumOverrideRequestStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EnumOverrideRequestStatus(s)
+	case string:
+		*e = EnumOverrideRequestStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EnumOverrideRequestStatus: %T", src)
+	}
+	return nil
+}
+
+type NullEnumOverrideRequestStatus struct {
+	EnumOverrideRequestStatus EnumOverrideRequestStatus
+	Valid                     bool // Valid is true if EnumOverrideRequestStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEnumOverrideRequestStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.EnumOverrideRequestStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EnumOverrideRequestStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEnumOverrideRequestStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EnumOverrideRequestStatus), nil
+}
+
 type EnumRotationType string
 
 const (
-	EnumRotationTypeDaily   EnumRotationType = "daily"
-	EnumRotationTypeHourly  EnumRotationType = "hourly"
-	EnumRotationTypeMonthly EnumRotationType = "monthly"
-	EnumRotationTypeWeekly  EnumRotationType = "weekly"
+	EnumRotationTypeDaily        EnumRotationType = "daily"
+	EnumRotationTypeFollowTheSun EnumRotationType = "follow_the_sun"
+	EnumRotationTypeHourly       EnumRotationType = "hourly"
+	EnumRotationTypeMonthly      EnumRotationType = "monthly"
+	EnumRotationTypeWeekly       EnumRotationType = "weekly"
 )
 
 func (e *EnumRotationType) Scan(src interface{}) error {
@@ -811,21 +1036,39 @@ type Alert struct {
 	DedupKey        sql.NullString
 	Details         string
 	EscalationLevel int32
+	FlappingUntil   sql.NullTime
 	ID              int64
 	LastEscalation  sql.NullTime
 	LastProcessed   sql.NullTime
+	Priority        int32
+	ReopenedAt      sql.NullTime
 	ServiceID       uuid.NullUUID
+	SnoozedUntil    sql.NullTime
 	Source          EnumAlertSource
 	Status          EnumAlertStatus
 	Summary         string
 }
 
+type AlertAck struct {
+	AlertID   int64
+	CreatedAt time.Time
+	UserID    uuid.UUID
+}
+
 type AlertDatum struct {
 	AlertID  int64
 	ID       int64
 	Metadata pqtype.NullRawMessage
 }
 
+type AlertDedupHistory struct {
+	AlertID    int64
+	CloseTimes []time.Time
+	ClosedAt   time.Time
+	DedupKey   string
+	ServiceID  uuid.UUID
+}
+
 type AlertFeedback struct {
 	AlertID     int64
 	ID          int64
@@ -938,6 +1181,7 @@ type EpStepOnCallUser struct {
 	EndTime   sql.NullTime
 	EpStepID  uuid.UUID
 	ID        int64
+	IsShadow  bool
 	StartTime time.Time
 	UserID    uuid.UUID
 }
@@ -969,13 +1213,23 @@ type EscalationPolicyState struct {
 	LastEscalation             sql.NullTime
 	LoopCount                  int32
 	NextEscalation             sql.NullTime
+	RoundRobinCount            int32
+	RoundRobinNext             sql.NullTime
 	ServiceID                  uuid.UUID
 }
 
 type EscalationPolicyStep struct {
+	AckCount           sql.NullInt32
+	ActiveEnd          sql.NullTime
+	ActiveStart        sql.NullTime
+	ActiveTimeZone     sql.NullString
+	ActiveWeekdays     []bool
 	Delay              int32
 	EscalationPolicyID uuid.UUID
 	ID                 uuid.UUID
+	IsActive           bool
+	Mode               EnumEpStepMode
+	RoundRobinMinutes  sql.NullInt32
 	StepNumber         int32
 }
 
@@ -1017,6 +1271,34 @@ type HeartbeatMonitor struct {
 	ServiceID         uuid.UUID
 }
 
+type HolidayCalendar struct {
+	CreatedAt  time.Time
+	ID         uuid.UUID
+	ImportedAt time.Time
+	Name       string
+	SourceUrl  sql.NullString
+}
+
+type HolidayCalendarDate struct {
+	CalendarID uuid.UUID
+	Date       time.Time
+	Name       string
+}
+
+type Incident struct {
+	CreatedAt time.Time
+	Details   string
+	ID        int64
+	Status    EnumAlertStatus
+	Summary   string
+}
+
+type IncidentAlert struct {
+	AlertID    int64
+	CreatedAt  time.Time
+	IncidentID int64
+}
+
 type IntegrationKey struct {
 	ExternalSystemName sql.NullString
 	ID                 uuid.UUID
@@ -1090,8 +1372,10 @@ type OutgoingMessage struct {
 	LastStatusAt           sql.NullTime
 	MessageType            EnumOutgoingMessagesType
 	NextRetryAt            sql.NullTime
+	OverrideRequestID      uuid.NullUUID
 	ProviderMsgID          ProviderMessageID
 	ProviderSeq            int32
+	QuietUntil             sql.NullTime
 	RetryCount             int32
 	ScheduleID             uuid.NullUUID
 	SendingDeadline        sql.NullTime
@@ -1104,6 +1388,21 @@ type OutgoingMessage struct {
 	UserVerificationCodeID uuid.NullUUID
 }
 
+type OverrideRequest struct {
+	AddUserID       uuid.NullUUID
+	CreatedAt       time.Time
+	EndTime         time.Time
+	ID              uuid.UUID
+	OverrideID      uuid.NullUUID
+	RemoveUserID    uuid.NullUUID
+	RequesterUserID uuid.UUID
+	RespondedAt     sql.NullTime
+	ScheduleID      uuid.UUID
+	StartTime       time.Time
+	Status          EnumOverrideRequestStatus
+	TargetUserID    uuid.UUID
+}
+
 type PendingSignal struct {
 	CreatedAt time.Time
 	DestID    uuid.UUID
@@ -1119,6 +1418,15 @@ type PgStatActivity struct {
 	ApplicationName sql.NullString
 }
 
+type QuietHour struct {
+	EndTime   timeutil.Clock
+	ID        uuid.UUID
+	ServiceID uuid.NullUUID
+	StartTime timeutil.Clock
+	TimeZone  string
+	UserID    uuid.NullUUID
+}
+
 type RegionID struct {
 	ID   int32
 	Name string
@@ -1183,6 +1491,7 @@ type RiverQueue struct {
 }
 
 type Rotation struct {
+	ActiveCount      int32
 	Description      string
 	ID               uuid.UUID
 	LastProcessed    sql.NullTime
@@ -1194,11 +1503,20 @@ type Rotation struct {
 	Type             EnumRotationType
 }
 
+type RotationActiveParticipant struct {
+	RotationID            uuid.UUID
+	RotationParticipantID uuid.UUID
+}
+
 type RotationParticipant struct {
-	ID         uuid.UUID
-	Position   int32
-	RotationID uuid.UUID
-	UserID     uuid.UUID
+	ID             uuid.UUID
+	Position       int32
+	RotationID     uuid.UUID
+	Shadow         bool
+	UserID         uuid.UUID
+	WindowEnd      sql.NullTime
+	WindowStart    sql.NullTime
+	WindowTimeZone sql.NullString
 }
 
 type RotationState struct {
@@ -1218,6 +1536,13 @@ type Schedule struct {
 	TimeZone      string
 }
 
+type ScheduleCoverageNotice struct {
+	GapEnd     time.Time
+	GapStart   time.Time
+	NotifiedAt time.Time
+	ScheduleID uuid.UUID
+}
+
 type ScheduleDatum struct {
 	Data          json.RawMessage
 	ID            int64
@@ -1225,6 +1550,11 @@ type ScheduleDatum struct {
 	ScheduleID    uuid.UUID
 }
 
+type ScheduleHolidayCalendar struct {
+	CalendarID uuid.UUID
+	ScheduleID uuid.UUID
+}
+
 type ScheduleOnCallUser struct {
 	EndTime    sql.NullTime
 	ID         int64
@@ -1237,6 +1567,7 @@ type ScheduleRule struct {
 	CreatedAt     time.Time
 	EndTime       timeutil.Clock
 	Friday        bool
+	HolidayFilter EnumHolidayFilter
 	ID            uuid.UUID
 	IsActive      bool
 	Monday        bool
@@ -1254,9 +1585,33 @@ type ScheduleRule struct {
 type Service struct {
 	Description          string
 	EscalationPolicyID   uuid.UUID
+	FlapThreshold        int32
+	FlapWindowMinutes    int32
 	ID                   uuid.UUID
 	MaintenanceExpiresAt sql.NullTime
 	Name                 string
+	ReopenWindowMinutes  int32
+}
+
+type ServiceAlertRule struct {
+	Config    SvcAlertRules
+	ServiceID uuid.UUID
+}
+
+type ServiceMaintenanceWindow struct {
+	ActiveSince sql.NullTime
+	CreatedAt   time.Time
+	CreatedBy   uuid.NullUUID
+	EndAction   EnumMaintWindowEndAction
+	EndTime     time.Time
+	ID          uuid.UUID
+	LabelKey    sql.NullString
+	LabelValue  string
+	Reason      string
+	Repeat      EnumMaintWindowRepeat
+	ServiceID   uuid.NullUUID
+	StartTime   time.Time
+	TimeZone    string
 }
 
 type SwitchoverLog struct {
@@ -1316,6 +1671,19 @@ type User struct {
 	Role                          EnumUserRole
 }
 
+type UserAvailabilityFeed struct {
+	CreatedAt        time.Time
+	ID               uuid.UUID
+	Keyword          string
+	LastError        string
+	LastSyncAt       sql.NullTime
+	Name             string
+	SubstituteUserID uuid.NullUUID
+	TimeZone         string
+	Url              string
+	UserID           uuid.UUID
+}
+
 type UserCalendarSubscription struct {
 	Config     json.RawMessage
 	CreatedAt  time.Time
@@ -1324,7 +1692,9 @@ type UserCalendarSubscription struct {
 	LastAccess sql.NullTime
 	LastUpdate time.Time
 	Name       string
-	ScheduleID uuid.UUID
+	RotationID uuid.NullUUID
+	ScheduleID uuid.NullUUID
+	ServiceID  uuid.NullUUID
 	UserID     uuid.UUID
 }
 
@@ -1353,11 +1723,14 @@ type UserFavorite struct {
 }
 
 type UserNotificationRule struct {
-	ContactMethodID uuid.UUID
-	CreatedAt       sql.NullTime
-	DelayMinutes    int32
-	ID              uuid.UUID
-	UserID          uuid.UUID
+	ContactMethodID   uuid.UUID
+	CreatedAt         sql.NullTime
+	DelayMinutes      int32
+	ID                uuid.UUID
+	Priorities        []int32
+	ServiceLabelKey   sql.NullString
+	ServiceLabelValue sql.NullString
+	UserID            uuid.UUID
 }
 
 type UserOverride struct {
@@ -1374,6 +1747,22 @@ type UserSlackDatum struct {
 	ID          uuid.UUID
 }
 
+type UserUnavailability struct {
+	EndTime          time.Time
+	FeedID           uuid.UUID
+	ID               uuid.UUID
+	StartTime        time.Time
+	SubstituteUserID uuid.NullUUID
+	Summary          string
+	UserID           uuid.UUID
+}
+
+type UserUnavailabilityOverride struct {
+	OverrideID       uuid.NullUUID
+	ScheduleID       uuid.UUID
+	UnavailabilityID uuid.UUID
+}
+
 type UserVerificationCode struct {
 	Code            int32
 	ContactMethodID uuid.UUID
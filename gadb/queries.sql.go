@@ -498,6 +498,50 @@ func (q *Queries) Alert_AlertHasEPState(ctx context.Context, alertID int64) (boo
 	return has_ep_state, err
 }
 
+const alert_FlapState = `-- name: Alert_FlapState :one
+SELECT
+    svc.flap_window_minutes,
+    (
+        SELECT
+            count(*)
+        FROM
+            unnest(h.close_times) t
+        WHERE
+            t > now() - '1 minute'::interval * svc.flap_window_minutes)::int AS cycles,
+    coalesce(a.flapping_until > now(), FALSE)::bool AS is_flapping
+FROM
+    alerts a
+    JOIN services svc ON svc.id = a.service_id
+    JOIN alert_dedup_history h ON h.service_id = a.service_id
+        AND h.dedup_key = a.dedup_key
+WHERE
+    a.id = $1::bigint
+    AND svc.flap_threshold > 0
+    AND svc.flap_window_minutes > 0
+    AND (
+        SELECT
+            count(*)
+        FROM
+            unnest(h.close_times) t
+        WHERE
+            t > now() - '1 minute'::interval * svc.flap_window_minutes) >= svc.flap_threshold
+`
+
+type Alert_FlapStateRow struct {
+	FlapWindowMinutes int32
+	Cycles            int32
+	IsFlapping        bool
+}
+
+// Returns the flap window of the alert's service, the number of times the alert's dedup key was closed within it, and if the alert is already flapping.
+// No rows are returned unless the number of cycles has reached the flap threshold of the service.
+func (q *Queries) Alert_FlapState(ctx context.Context, id int64) (Alert_FlapStateRow, error) {
+	row := q.db.QueryRowContext(ctx, alert_FlapState, id)
+	var i Alert_FlapStateRow
+	err := row.Scan(&i.FlapWindowMinutes, &i.Cycles, &i.IsFlapping)
+	return i, err
+}
+
 const alert_GetAlertFeedback = `-- name: Alert_GetAlertFeedback :many
 SELECT
     alert_id,
@@ -649,7 +693,8 @@ func (q *Queries) Alert_LockManyAlertServices(ctx context.Context, alertIds []in
 
 const alert_LockOneAlertService = `-- name: Alert_LockOneAlertService :one
 SELECT
-    maintenance_expires_at NOTNULL::bool AS is_maint_mode,
+    (maintenance_expires_at NOTNULL
+        OR svc_maint_window_active(svc.id))::bool AS is_maint_mode,
     alerts.status
 FROM
     services svc
@@ -688,6 +733,150 @@ func (q *Queries) Alert_LockService(ctx context.Context, serviceID uuid.UUID) er
 	return err
 }
 
+const alert_RecordAcks = `-- name: Alert_RecordAcks :many
+WITH steps AS (
+    SELECT
+        state.alert_id,
+        step.ack_count
+    FROM
+        escalation_policy_state state
+        JOIN escalation_policy_steps step ON step.id = state.escalation_policy_step_id
+            AND step.mode = 'ack_count'
+        JOIN alerts a ON a.id = state.alert_id
+            AND a.status = 'triggered'
+    WHERE
+        state.alert_id = ANY ($1::bigint[])
+),
+inserted AS (
+INSERT INTO alert_acks(alert_id, user_id)
+    SELECT
+        alert_id,
+        $2::uuid
+    FROM
+        steps
+    ON CONFLICT
+        DO NOTHING
+    RETURNING
+        alert_id
+)
+SELECT
+    steps.alert_id,
+    steps.ack_count::int AS required,
+((
+        SELECT
+            count(*)
+        FROM alert_acks ack
+        WHERE
+            ack.alert_id = steps.alert_id) +(
+        SELECT
+            count(*)
+        FROM inserted i
+        WHERE
+            i.alert_id = steps.alert_id))::int AS acks
+FROM
+    steps
+`
+
+type Alert_RecordAcksParams struct {
+	AlertIds []int64
+	UserID   uuid.UUID
+}
+
+type Alert_RecordAcksRow struct {
+	AlertID  int64
+	Required int32
+	Acks     int32
+}
+
+// Records an acknowledgement from a user for alerts whose current escalation step requires multiple,
+// returning the number of acknowledgements (including this one) and the number required.
+func (q *Queries) Alert_RecordAcks(ctx context.Context, arg Alert_RecordAcksParams) ([]Alert_RecordAcksRow, error) {
+	rows, err := q.db.QueryContext(ctx, alert_RecordAcks, pq.Array(arg.AlertIds), arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Alert_RecordAcksRow
+	for rows.Next() {
+		var i Alert_RecordAcksRow
+		if err := rows.Scan(&i.AlertID, &i.Required, &i.Acks); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const alert_ReopenRecent = `-- name: Alert_ReopenRecent :one
+UPDATE
+    alerts a
+SET
+    status = 'triggered',
+    dedup_key = h.dedup_key,
+    reopened_at = now(),
+    escalation_level = 0,
+    last_escalation = now()
+FROM
+    alert_dedup_history h
+    JOIN services svc ON svc.id = h.service_id
+WHERE
+    h.service_id = $1
+    AND h.dedup_key = $2::text
+    AND a.id = h.alert_id
+    AND a.status = 'closed'
+    AND h.closed_at > now() - '1 minute'::interval * svc.reopen_window_minutes
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            alerts o
+        WHERE
+            o.service_id = $1
+            AND o.dedup_key = $2::text)
+RETURNING
+    a.id,
+    a.summary,
+    a.details,
+    a.source,
+    a.created_at,
+    a.priority
+`
+
+type Alert_ReopenRecentParams struct {
+	ServiceID uuid.UUID
+	DedupKey  string
+}
+
+type Alert_ReopenRecentRow struct {
+	ID        int64
+	Summary   string
+	Details   string
+	Source    EnumAlertSource
+	CreatedAt time.Time
+	Priority  int32
+}
+
+// Re-opens the most recently closed alert with the given dedup key, if it was closed within the reopen window of the service and no open alert with the same key exists.
+func (q *Queries) Alert_ReopenRecent(ctx context.Context, arg Alert_ReopenRecentParams) (Alert_ReopenRecentRow, error) {
+	row := q.db.QueryRowContext(ctx, alert_ReopenRecent, arg.ServiceID, arg.DedupKey)
+	var i Alert_ReopenRecentRow
+	err := row.Scan(
+		&i.ID,
+		&i.Summary,
+		&i.Details,
+		&i.Source,
+		&i.CreatedAt,
+		&i.Priority,
+	)
+	return i, err
+}
+
 const alert_RequestAlertEscalationByTime = `-- name: Alert_RequestAlertEscalationByTime :one
 UPDATE
     escalation_policy_state
@@ -734,6 +923,37 @@ func (q *Queries) Alert_ServiceEPHasSteps(ctx context.Context, serviceID uuid.UU
 	return exists, err
 }
 
+const alert_ServiceMaintWindowReason = `-- name: Alert_ServiceMaintWindowReason :one
+SELECT
+    w.reason
+FROM
+    service_maintenance_windows w
+WHERE
+    w.active_since NOTNULL
+    AND (w.service_id = $1
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                labels l
+            WHERE
+                l.tgt_service_id = $1
+                AND l.key = w.label_key
+                AND (w.label_value = ''
+                    OR l.value = w.label_value)))
+ORDER BY
+    w.active_since
+LIMIT 1
+`
+
+// Returns the reason of an active maintenance window that applies to the service, if any.
+func (q *Queries) Alert_ServiceMaintWindowReason(ctx context.Context, serviceID uuid.NullUUID) (string, error) {
+	row := q.db.QueryRowContext(ctx, alert_ServiceMaintWindowReason, serviceID)
+	var reason string
+	err := row.Scan(&reason)
+	return reason, err
+}
+
 const alert_SetAlertFeedback = `-- name: Alert_SetAlertFeedback :exec
 INSERT INTO alert_feedback(alert_id, noise_reason)
     VALUES ($1, $2)
@@ -789,6 +1009,26 @@ func (q *Queries) Alert_SetAlertMetadata(ctx context.Context, arg Alert_SetAlert
 	return result.RowsAffected()
 }
 
+const alert_SetFlapping = `-- name: Alert_SetFlapping :exec
+UPDATE
+    alerts
+SET
+    flapping_until = now() + '1 minute'::interval * $1::int
+WHERE
+    id = $2::bigint
+`
+
+type Alert_SetFlappingParams struct {
+	WindowMinutes int32
+	ID            int64
+}
+
+// Marks an alert as flapping until the flap window has passed without another open/close cycle.
+func (q *Queries) Alert_SetFlapping(ctx context.Context, arg Alert_SetFlappingParams) error {
+	_, err := q.db.ExecContext(ctx, alert_SetFlapping, arg.WindowMinutes, arg.ID)
+	return err
+}
+
 const alert_SetManyAlertFeedback = `-- name: Alert_SetManyAlertFeedback :many
 INSERT INTO alert_feedback(alert_id, noise_reason)
     VALUES (unnest($1::bigint[]), $2)
@@ -830,6 +1070,67 @@ func (q *Queries) Alert_SetManyAlertFeedback(ctx context.Context, arg Alert_SetM
 	return items, nil
 }
 
+const alert_ShadowAckAlerts = `-- name: Alert_ShadowAckAlerts :many
+SELECT
+    state.alert_id
+FROM
+    escalation_policy_state state
+    JOIN ep_step_on_call_users oc ON oc.ep_step_id = state.escalation_policy_step_id
+        AND oc.user_id = $1::uuid
+        AND oc.end_time ISNULL
+        AND oc.is_shadow
+    JOIN alerts a ON a.id = state.alert_id
+        AND a.status = 'triggered'
+WHERE
+    state.alert_id = ANY ($2::bigint[])
+`
+
+type Alert_ShadowAckAlertsParams struct {
+	UserID   uuid.UUID
+	AlertIds []int64
+}
+
+// Returns the alerts whose current escalation step has the user on call only as a shadow participant.
+func (q *Queries) Alert_ShadowAckAlerts(ctx context.Context, arg Alert_ShadowAckAlertsParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, alert_ShadowAckAlerts, arg.UserID, pq.Array(arg.AlertIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var alert_id int64
+		if err := rows.Scan(&alert_id); err != nil {
+			return nil, err
+		}
+		items = append(items, alert_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const alert_StopUserCycles = `-- name: Alert_StopUserCycles :exec
+DELETE FROM notification_policy_cycles
+WHERE alert_id = ANY ($1::bigint[])
+    AND user_id = $2::uuid
+`
+
+type Alert_StopUserCyclesParams struct {
+	AlertIds []int64
+	UserID   uuid.UUID
+}
+
+// Stops notifying a user about the given alerts.
+func (q *Queries) Alert_StopUserCycles(ctx context.Context, arg Alert_StopUserCyclesParams) error {
+	_, err := q.db.ExecContext(ctx, alert_StopUserCycles, pq.Array(arg.AlertIds), arg.UserID)
+	return err
+}
+
 const allPendingMsgDests = `-- name: AllPendingMsgDests :many
 SELECT DISTINCT
   usr.name AS user_name,
@@ -967,91 +1268,133 @@ func (q *Queries) AuthLinkUseReq(ctx context.Context, id uuid.UUID) (AuthLinkUse
 	return i, err
 }
 
-const calSubAuthUser = `-- name: CalSubAuthUser :one
+const availDeleteFutureOverrides = `-- name: AvailDeleteFutureOverrides :exec
+DELETE FROM user_overrides
+WHERE id IN (
+        SELECT
+            override_id
+        FROM
+            user_unavailability_overrides
+        WHERE
+            unavailability_id = ANY ($1::uuid[]))
+    AND start_time > now()
+`
+
+// Deletes overrides created for the given out-of-office periods that have not started.
+func (q *Queries) AvailDeleteFutureOverrides(ctx context.Context, unavailabilityIds []uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, availDeleteFutureOverrides, pq.Array(unavailabilityIds))
+	return err
+}
+
+const availEndActiveOverrides = `-- name: AvailEndActiveOverrides :exec
 UPDATE
-    user_calendar_subscriptions
+    user_overrides
 SET
-    last_access = now()
+    end_time = now()
 WHERE
-    NOT disabled
-    AND id = $1
-    AND date_trunc('second', created_at) = $2
-RETURNING
-    user_id
+    id IN (
+        SELECT
+            override_id
+        FROM
+            user_unavailability_overrides
+        WHERE
+            unavailability_id = ANY ($1::uuid[]))
+    AND start_time < now()
+    AND end_time > now()
 `
 
-type CalSubAuthUserParams struct {
-	ID        uuid.UUID
-	CreatedAt time.Time
-}
-
-func (q *Queries) CalSubAuthUser(ctx context.Context, arg CalSubAuthUserParams) (uuid.UUID, error) {
-	row := q.db.QueryRowContext(ctx, calSubAuthUser, arg.ID, arg.CreatedAt)
-	var user_id uuid.UUID
-	err := row.Scan(&user_id)
-	return user_id, err
+// Ends overrides created for the given out-of-office periods that are in progress.
+func (q *Queries) AvailEndActiveOverrides(ctx context.Context, unavailabilityIds []uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, availEndActiveOverrides, pq.Array(unavailabilityIds))
+	return err
 }
 
-const calSubRenderInfo = `-- name: CalSubRenderInfo :one
+const availFeedCountByUser = `-- name: AvailFeedCountByUser :one
 SELECT
-    now()::timestamptz AS now,
-    sub.schedule_id,
-    sched.name AS schedule_name,
-    sub.config,
-    sub.user_id
+    count(*)
 FROM
-    user_calendar_subscriptions sub
-    JOIN schedules sched ON sched.id = schedule_id
+    user_availability_feeds
 WHERE
-    sub.id = $1
+    user_id = $1
 `
 
-type CalSubRenderInfoRow struct {
-	Now          time.Time
-	ScheduleID   uuid.UUID
-	ScheduleName string
-	Config       json.RawMessage
-	UserID       uuid.UUID
+func (q *Queries) AvailFeedCountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, availFeedCountByUser, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-func (q *Queries) CalSubRenderInfo(ctx context.Context, id uuid.UUID) (CalSubRenderInfoRow, error) {
-	row := q.db.QueryRowContext(ctx, calSubRenderInfo, id)
-	var i CalSubRenderInfoRow
-	err := row.Scan(
-		&i.Now,
-		&i.ScheduleID,
-		&i.ScheduleName,
-		&i.Config,
-		&i.UserID,
+const availFeedCreate = `-- name: AvailFeedCreate :exec
+INSERT INTO user_availability_feeds(id, user_id, name, url, keyword, time_zone, substitute_user_id)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type AvailFeedCreateParams struct {
+	ID               uuid.UUID
+	UserID           uuid.UUID
+	Name             string
+	Url              string
+	Keyword          string
+	TimeZone         string
+	SubstituteUserID uuid.NullUUID
+}
+
+func (q *Queries) AvailFeedCreate(ctx context.Context, arg AvailFeedCreateParams) error {
+	_, err := q.db.ExecContext(ctx, availFeedCreate,
+		arg.ID,
+		arg.UserID,
+		arg.Name,
+		arg.Url,
+		arg.Keyword,
+		arg.TimeZone,
+		arg.SubstituteUserID,
 	)
-	return i, err
+	return err
 }
 
-const calSubUserNames = `-- name: CalSubUserNames :many
+const availFeedDelete = `-- name: AvailFeedDelete :exec
+DELETE FROM user_availability_feeds
+WHERE id = $1
+`
+
+func (q *Queries) AvailFeedDelete(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, availFeedDelete, id)
+	return err
+}
+
+const availFeedFindByUser = `-- name: AvailFeedFindByUser :many
 SELECT
-    id,
-    name
+    created_at, id, keyword, last_error, last_sync_at, name, substitute_user_id, time_zone, url, user_id
 FROM
-    users
+    user_availability_feeds
 WHERE
-    id = ANY ($1::uuid[])
+    user_id = $1
+ORDER BY
+    lower(name)
 `
 
-type CalSubUserNamesRow struct {
-	ID   uuid.UUID
-	Name string
-}
-
-func (q *Queries) CalSubUserNames(ctx context.Context, dollar_1 []uuid.UUID) ([]CalSubUserNamesRow, error) {
-	rows, err := q.db.QueryContext(ctx, calSubUserNames, pq.Array(dollar_1))
+func (q *Queries) AvailFeedFindByUser(ctx context.Context, userID uuid.UUID) ([]UserAvailabilityFeed, error) {
+	rows, err := q.db.QueryContext(ctx, availFeedFindByUser, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []CalSubUserNamesRow
+	var items []UserAvailabilityFeed
 	for rows.Next() {
-		var i CalSubUserNamesRow
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		var i UserAvailabilityFeed
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.ID,
+			&i.Keyword,
+			&i.LastError,
+			&i.LastSyncAt,
+			&i.Name,
+			&i.SubstituteUserID,
+			&i.TimeZone,
+			&i.Url,
+			&i.UserID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -1065,285 +1408,1832 @@ func (q *Queries) CalSubUserNames(ctx context.Context, dollar_1 []uuid.UUID) ([]
 	return items, nil
 }
 
-const cleanupAlertLogs = `-- name: CleanupAlertLogs :one
-WITH scope AS (
-    SELECT
-        id
-    FROM
-        alert_logs l
-    WHERE
-        l.id BETWEEN $2 AND $3- 1
-    ORDER BY
-        l.id
+const availFeedFindOne = `-- name: AvailFeedFindOne :one
+SELECT
+    created_at, id, keyword, last_error, last_sync_at, name, substitute_user_id, time_zone, url, user_id
+FROM
+    user_availability_feeds
+WHERE
+    id = $1
+`
+
+func (q *Queries) AvailFeedFindOne(ctx context.Context, id uuid.UUID) (UserAvailabilityFeed, error) {
+	row := q.db.QueryRowContext(ctx, availFeedFindOne, id)
+	var i UserAvailabilityFeed
+	err := row.Scan(
+		&i.CreatedAt,
+		&i.ID,
+		&i.Keyword,
+		&i.LastError,
+		&i.LastSyncAt,
+		&i.Name,
+		&i.SubstituteUserID,
+		&i.TimeZone,
+		&i.Url,
+		&i.UserID,
+	)
+	return i, err
+}
+
+const availFeedFindOneForUpdate = `-- name: AvailFeedFindOneForUpdate :one
+SELECT
+    created_at, id, keyword, last_error, last_sync_at, name, substitute_user_id, time_zone, url, user_id
+FROM
+    user_availability_feeds
+WHERE
+    id = $1
+FOR UPDATE
+`
+
+func (q *Queries) AvailFeedFindOneForUpdate(ctx context.Context, id uuid.UUID) (UserAvailabilityFeed, error) {
+	row := q.db.QueryRowContext(ctx, availFeedFindOneForUpdate, id)
+	var i UserAvailabilityFeed
+	err := row.Scan(
+		&i.CreatedAt,
+		&i.ID,
+		&i.Keyword,
+		&i.LastError,
+		&i.LastSyncAt,
+		&i.Name,
+		&i.SubstituteUserID,
+		&i.TimeZone,
+		&i.Url,
+		&i.UserID,
+	)
+	return i, err
+}
+
+const availFeedPeriods = `-- name: AvailFeedPeriods :many
+SELECT
+    id,
+    summary,
+    start_time,
+    end_time
+FROM
+    user_unavailability
+WHERE
+    feed_id = $1
+ORDER BY
+    start_time
+`
+
+type AvailFeedPeriodsRow struct {
+	ID        uuid.UUID
+	Summary   string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+func (q *Queries) AvailFeedPeriods(ctx context.Context, feedID uuid.UUID) ([]AvailFeedPeriodsRow, error) {
+	rows, err := q.db.QueryContext(ctx, availFeedPeriods, feedID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AvailFeedPeriodsRow
+	for rows.Next() {
+		var i AvailFeedPeriodsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Summary,
+			&i.StartTime,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const availFeedUpdate = `-- name: AvailFeedUpdate :exec
+UPDATE
+    user_availability_feeds
+SET
+    name = $2,
+    url = $3,
+    keyword = $4,
+    time_zone = $5,
+    substitute_user_id = $6,
+    last_sync_at = NULL
+WHERE
+    id = $1
+`
+
+type AvailFeedUpdateParams struct {
+	ID               uuid.UUID
+	Name             string
+	Url              string
+	Keyword          string
+	TimeZone         string
+	SubstituteUserID uuid.NullUUID
+}
+
+func (q *Queries) AvailFeedUpdate(ctx context.Context, arg AvailFeedUpdateParams) error {
+	_, err := q.db.ExecContext(ctx, availFeedUpdate,
+		arg.ID,
+		arg.Name,
+		arg.Url,
+		arg.Keyword,
+		arg.TimeZone,
+		arg.SubstituteUserID,
+	)
+	return err
+}
+
+const availMgrCreateOverride = `-- name: AvailMgrCreateOverride :exec
+INSERT INTO user_overrides(id, add_user_id, remove_user_id, start_time, end_time, tgt_schedule_id)
+    VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type AvailMgrCreateOverrideParams struct {
+	ID           uuid.UUID
+	AddUserID    uuid.NullUUID
+	RemoveUserID uuid.NullUUID
+	StartTime    time.Time
+	EndTime      time.Time
+	ScheduleID   uuid.UUID
+}
+
+func (q *Queries) AvailMgrCreateOverride(ctx context.Context, arg AvailMgrCreateOverrideParams) error {
+	_, err := q.db.ExecContext(ctx, availMgrCreateOverride,
+		arg.ID,
+		arg.AddUserID,
+		arg.RemoveUserID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.ScheduleID,
+	)
+	return err
+}
+
+const availMgrDeletePeriods = `-- name: AvailMgrDeletePeriods :exec
+DELETE FROM user_unavailability
+WHERE id = ANY ($1::uuid[])
+`
+
+func (q *Queries) AvailMgrDeletePeriods(ctx context.Context, ids []uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, availMgrDeletePeriods, pq.Array(ids))
+	return err
+}
+
+const availMgrFeed = `-- name: AvailMgrFeed :one
+SELECT
+    created_at, id, keyword, last_error, last_sync_at, name, substitute_user_id, time_zone, url, user_id
+FROM
+    user_availability_feeds
+WHERE
+    id = $1
+`
+
+func (q *Queries) AvailMgrFeed(ctx context.Context, id uuid.UUID) (UserAvailabilityFeed, error) {
+	row := q.db.QueryRowContext(ctx, availMgrFeed, id)
+	var i UserAvailabilityFeed
+	err := row.Scan(
+		&i.CreatedAt,
+		&i.ID,
+		&i.Keyword,
+		&i.LastError,
+		&i.LastSyncAt,
+		&i.Name,
+		&i.SubstituteUserID,
+		&i.TimeZone,
+		&i.Url,
+		&i.UserID,
+	)
+	return i, err
+}
+
+const availMgrFeedForUpdate = `-- name: AvailMgrFeedForUpdate :one
+SELECT
+    created_at, id, keyword, last_error, last_sync_at, name, substitute_user_id, time_zone, url, user_id
+FROM
+    user_availability_feeds
+WHERE
+    id = $1
+FOR UPDATE
+`
+
+func (q *Queries) AvailMgrFeedForUpdate(ctx context.Context, id uuid.UUID) (UserAvailabilityFeed, error) {
+	row := q.db.QueryRowContext(ctx, availMgrFeedForUpdate, id)
+	var i UserAvailabilityFeed
+	err := row.Scan(
+		&i.CreatedAt,
+		&i.ID,
+		&i.Keyword,
+		&i.LastError,
+		&i.LastSyncAt,
+		&i.Name,
+		&i.SubstituteUserID,
+		&i.TimeZone,
+		&i.Url,
+		&i.UserID,
+	)
+	return i, err
+}
+
+const availMgrFeedsDue = `-- name: AvailMgrFeedsDue :many
+SELECT
+    id
+FROM
+    user_availability_feeds
+WHERE
+    last_sync_at ISNULL
+    OR last_sync_at < now() - '15 minutes'::interval
+ORDER BY
+    last_sync_at NULLS FIRST
+LIMIT 100
+`
+
+func (q *Queries) AvailMgrFeedsDue(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, availMgrFeedsDue)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const availMgrInsertPeriod = `-- name: AvailMgrInsertPeriod :exec
+INSERT INTO user_unavailability(id, feed_id, user_id, substitute_user_id, summary, start_time, end_time)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type AvailMgrInsertPeriodParams struct {
+	ID               uuid.UUID
+	FeedID           uuid.UUID
+	UserID           uuid.UUID
+	SubstituteUserID uuid.NullUUID
+	Summary          string
+	StartTime        time.Time
+	EndTime          time.Time
+}
+
+func (q *Queries) AvailMgrInsertPeriod(ctx context.Context, arg AvailMgrInsertPeriodParams) error {
+	_, err := q.db.ExecContext(ctx, availMgrInsertPeriod,
+		arg.ID,
+		arg.FeedID,
+		arg.UserID,
+		arg.SubstituteUserID,
+		arg.Summary,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	return err
+}
+
+const availMgrMissingOverrides = `-- name: AvailMgrMissingOverrides :many
+SELECT
+    u.id AS unavailability_id,
+    s.schedule_id,
+    u.user_id,
+    u.substitute_user_id::uuid AS substitute_user_id,
+    greatest(u.start_time, now())::timestamptz AS start_time,
+    u.end_time
+FROM
+    user_unavailability u
+    JOIN (
+        SELECT DISTINCT
+            r.schedule_id,
+            coalesce(r.tgt_user_id, p.user_id) AS user_id
+        FROM
+            schedule_rules r
+        LEFT JOIN rotation_participants p ON p.rotation_id = r.tgt_rotation_id
+            AND NOT p.shadow) s ON s.user_id = u.user_id
+WHERE
+    u.feed_id = $1
+    AND u.substitute_user_id NOTNULL
+    AND u.end_time > now() + '1 minute'::interval
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            user_unavailability_overrides o
+        WHERE
+            o.unavailability_id = u.id
+            AND o.schedule_id = s.schedule_id)
+`
+
+type AvailMgrMissingOverridesRow struct {
+	UnavailabilityID uuid.UUID
+	ScheduleID       uuid.UUID
+	UserID           uuid.UUID
+	SubstituteUserID uuid.UUID
+	StartTime        time.Time
+	EndTime          time.Time
+}
+
+// Returns the schedules each out-of-office period with a substitute still needs an override for. Users
+// are on a schedule if they are the target of a rule, or a (non-shadow) participant of a targeted rotation.
+func (q *Queries) AvailMgrMissingOverrides(ctx context.Context, feedID uuid.UUID) ([]AvailMgrMissingOverridesRow, error) {
+	rows, err := q.db.QueryContext(ctx, availMgrMissingOverrides, feedID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AvailMgrMissingOverridesRow
+	for rows.Next() {
+		var i AvailMgrMissingOverridesRow
+		if err := rows.Scan(
+			&i.UnavailabilityID,
+			&i.ScheduleID,
+			&i.UserID,
+			&i.SubstituteUserID,
+			&i.StartTime,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const availMgrPeriods = `-- name: AvailMgrPeriods :many
+SELECT
+    id,
+    substitute_user_id,
+    summary,
+    start_time,
+    end_time
+FROM
+    user_unavailability
+WHERE
+    feed_id = $1
+`
+
+type AvailMgrPeriodsRow struct {
+	ID               uuid.UUID
+	SubstituteUserID uuid.NullUUID
+	Summary          string
+	StartTime        time.Time
+	EndTime          time.Time
+}
+
+func (q *Queries) AvailMgrPeriods(ctx context.Context, feedID uuid.UUID) ([]AvailMgrPeriodsRow, error) {
+	rows, err := q.db.QueryContext(ctx, availMgrPeriods, feedID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AvailMgrPeriodsRow
+	for rows.Next() {
+		var i AvailMgrPeriodsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubstituteUserID,
+			&i.Summary,
+			&i.StartTime,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const availMgrSetOverride = `-- name: AvailMgrSetOverride :exec
+INSERT INTO user_unavailability_overrides(unavailability_id, schedule_id, override_id)
+    VALUES ($1, $2, $3)
+ON CONFLICT (unavailability_id, schedule_id)
+    DO NOTHING
+`
+
+type AvailMgrSetOverrideParams struct {
+	UnavailabilityID uuid.UUID
+	ScheduleID       uuid.UUID
+	OverrideID       uuid.NullUUID
+}
+
+func (q *Queries) AvailMgrSetOverride(ctx context.Context, arg AvailMgrSetOverrideParams) error {
+	_, err := q.db.ExecContext(ctx, availMgrSetOverride, arg.UnavailabilityID, arg.ScheduleID, arg.OverrideID)
+	return err
+}
+
+const availMgrSetSynced = `-- name: AvailMgrSetSynced :exec
+UPDATE
+    user_availability_feeds
+SET
+    last_sync_at = now(),
+    last_error = $2
+WHERE
+    id = $1
+`
+
+type AvailMgrSetSyncedParams struct {
+	ID        uuid.UUID
+	LastError string
+}
+
+func (q *Queries) AvailMgrSetSynced(ctx context.Context, arg AvailMgrSetSyncedParams) error {
+	_, err := q.db.ExecContext(ctx, availMgrSetSynced, arg.ID, arg.LastError)
+	return err
+}
+
+const calSubAuthUser = `-- name: CalSubAuthUser :one
+UPDATE
+    user_calendar_subscriptions
+SET
+    last_access = now()
+WHERE
+    NOT disabled
+    AND id = $1
+    AND date_trunc('second', created_at) = $2
+RETURNING
+    user_id
+`
+
+type CalSubAuthUserParams struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+}
+
+func (q *Queries) CalSubAuthUser(ctx context.Context, arg CalSubAuthUserParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, calSubAuthUser, arg.ID, arg.CreatedAt)
+	var user_id uuid.UUID
+	err := row.Scan(&user_id)
+	return user_id, err
+}
+
+const calSubOverrides = `-- name: CalSubOverrides :many
+SELECT
+    add_user_id,
+    start_time,
+    end_time
+FROM
+    user_overrides
+WHERE
+    tgt_schedule_id = $1
+    AND add_user_id NOTNULL
+    AND end_time > $2::timestamptz
+    AND start_time < $3::timestamptz
+`
+
+type CalSubOverridesParams struct {
+	TgtScheduleID uuid.UUID
+	StartTime     time.Time
+	EndTime       time.Time
+}
+
+type CalSubOverridesRow struct {
+	AddUserID uuid.NullUUID
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+func (q *Queries) CalSubOverrides(ctx context.Context, arg CalSubOverridesParams) ([]CalSubOverridesRow, error) {
+	rows, err := q.db.QueryContext(ctx, calSubOverrides, arg.TgtScheduleID, arg.StartTime, arg.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CalSubOverridesRow
+	for rows.Next() {
+		var i CalSubOverridesRow
+		if err := rows.Scan(&i.AddUserID, &i.StartTime, &i.EndTime); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const calSubRenderInfo = `-- name: CalSubRenderInfo :one
+SELECT
+    now()::timestamptz AS now,
+    sub.schedule_id,
+    sub.rotation_id,
+    sub.service_id,
+    coalesce(sched.name, rot.name, svc.name, '')::text AS target_name,
+    sub.config,
+    sub.user_id
+FROM
+    user_calendar_subscriptions sub
+    LEFT JOIN schedules sched ON sched.id = sub.schedule_id
+    LEFT JOIN rotations rot ON rot.id = sub.rotation_id
+    LEFT JOIN services svc ON svc.id = sub.service_id
+WHERE
+    sub.id = $1
+`
+
+type CalSubRenderInfoRow struct {
+	Now        time.Time
+	ScheduleID uuid.NullUUID
+	RotationID uuid.NullUUID
+	ServiceID  uuid.NullUUID
+	TargetName string
+	Config     json.RawMessage
+	UserID     uuid.UUID
+}
+
+func (q *Queries) CalSubRenderInfo(ctx context.Context, id uuid.UUID) (CalSubRenderInfoRow, error) {
+	row := q.db.QueryRowContext(ctx, calSubRenderInfo, id)
+	var i CalSubRenderInfoRow
+	err := row.Scan(
+		&i.Now,
+		&i.ScheduleID,
+		&i.RotationID,
+		&i.ServiceID,
+		&i.TargetName,
+		&i.Config,
+		&i.UserID,
+	)
+	return i, err
+}
+
+const calSubServiceAlerts = `-- name: CalSubServiceAlerts :many
+SELECT
+    a.id,
+    a.summary,
+    a.status,
+    a.created_at,
+    coalesce(m.closed_at, (
+            SELECT
+                max(l.timestamp)
+            FROM alert_logs l
+            WHERE
+                l.alert_id = a.id
+                AND l.event = 'closed'), now())::timestamptz AS end_time
+FROM
+    alerts a
+    LEFT JOIN alert_metrics m ON m.alert_id = a.id
+WHERE
+    a.service_id = $1
+    AND a.created_at > $2::timestamptz
+ORDER BY
+    a.created_at DESC
+LIMIT 500
+`
+
+type CalSubServiceAlertsParams struct {
+	ServiceID uuid.NullUUID
+	Since     time.Time
+}
+
+type CalSubServiceAlertsRow struct {
+	ID        int64
+	Summary   string
+	Status    EnumAlertStatus
+	CreatedAt time.Time
+	EndTime   time.Time
+}
+
+func (q *Queries) CalSubServiceAlerts(ctx context.Context, arg CalSubServiceAlertsParams) ([]CalSubServiceAlertsRow, error) {
+	rows, err := q.db.QueryContext(ctx, calSubServiceAlerts, arg.ServiceID, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CalSubServiceAlertsRow
+	for rows.Next() {
+		var i CalSubServiceAlertsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Summary,
+			&i.Status,
+			&i.CreatedAt,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const calSubUserNames = `-- name: CalSubUserNames :many
+SELECT
+    id,
+    name,
+    email
+FROM
+    users
+WHERE
+    id = ANY ($1::uuid[])
+`
+
+type CalSubUserNamesRow struct {
+	ID    uuid.UUID
+	Name  string
+	Email string
+}
+
+func (q *Queries) CalSubUserNames(ctx context.Context, dollar_1 []uuid.UUID) ([]CalSubUserNamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, calSubUserNames, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CalSubUserNamesRow
+	for rows.Next() {
+		var i CalSubUserNamesRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.Email); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const cleanupAlertLogs = `-- name: CleanupAlertLogs :one
+WITH scope AS (
+    SELECT
+        id
+    FROM
+        alert_logs l
+    WHERE
+        l.id BETWEEN $2 AND $3- 1
+    ORDER BY
+        l.id
     LIMIT $4
 ),
 id_range AS (
     SELECT
-        min(id),
-        max(id)
+        min(id),
+        max(id)
+    FROM
+        scope
+),
+_delete AS (
+    DELETE FROM alert_logs
+    WHERE id = ANY (
+            SELECT
+                id
+            FROM
+                alert_logs
+            WHERE
+                id BETWEEN (
+                    SELECT
+                        min
+                    FROM
+                        id_range)
+                    AND (
+                        SELECT
+                            max
+                        FROM
+                            id_range)
+                        AND NOT EXISTS (
+                            SELECT
+                                1
+                            FROM
+                                alerts
+                            WHERE
+                                alert_id = id)
+                            FOR UPDATE
+                                SKIP LOCKED))
+                SELECT
+                    id
+                FROM
+                    scope OFFSET $1- 1
+                LIMIT 1
+`
+
+type CleanupAlertLogsParams struct {
+	BatchSize int32
+	StartID   int64
+	EndID     int64
+}
+
+func (q *Queries) CleanupAlertLogs(ctx context.Context, arg CleanupAlertLogsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, cleanupAlertLogs,
+		arg.BatchSize,
+		arg.StartID,
+		arg.EndID,
+		arg.BatchSize,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const cleanupMgrAlertLogsMinMax = `-- name: CleanupMgrAlertLogsMinMax :one
+SELECT
+    coalesce(min(id), 0)::bigint AS min_id,
+    coalesce(max(id), 0)::bigint AS max_id
+FROM
+    alert_logs
+`
+
+type CleanupMgrAlertLogsMinMaxRow struct {
+	MinID int64
+	MaxID int64
+}
+
+// CleanupMgrAlertLogsMinMax will find the minimum and maximum id of the alert_logs table.
+func (q *Queries) CleanupMgrAlertLogsMinMax(ctx context.Context) (CleanupMgrAlertLogsMinMaxRow, error) {
+	row := q.db.QueryRowContext(ctx, cleanupMgrAlertLogsMinMax)
+	var i CleanupMgrAlertLogsMinMaxRow
+	err := row.Scan(&i.MinID, &i.MaxID)
+	return i, err
+}
+
+const cleanupMgrDeleteOldAlerts = `-- name: CleanupMgrDeleteOldAlerts :execrows
+DELETE FROM alerts
+WHERE id = ANY (
+        SELECT
+            id
+        FROM
+            alerts a
+        WHERE
+            status = 'closed'
+            AND a.created_at < now() -($1::bigint * '1 day'::interval)
+        ORDER BY
+            id
+        LIMIT 100
+        FOR UPDATE
+            SKIP LOCKED)
+`
+
+// CleanupMgrDeleteOldAlerts will delete old alerts from the alerts table that are closed and older than the given number of days before now.
+func (q *Queries) CleanupMgrDeleteOldAlerts(ctx context.Context, staleThresholdDays int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cleanupMgrDeleteOldAlerts, staleThresholdDays)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const cleanupMgrDeleteOldDedupHistory = `-- name: CleanupMgrDeleteOldDedupHistory :execrows
+DELETE FROM alert_dedup_history
+WHERE (service_id, dedup_key) IN (
+        SELECT
+            service_id,
+            dedup_key
+        FROM
+            alert_dedup_history
+        WHERE
+            closed_at < now() - '1 day'::interval
+        LIMIT 100
+        FOR UPDATE
+            SKIP LOCKED)
+`
+
+// CleanupMgrDeleteOldDedupHistory will delete dedup history entries that are older than the longest possible reopen or flap window.
+func (q *Queries) CleanupMgrDeleteOldDedupHistory(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cleanupMgrDeleteOldDedupHistory)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const cleanupMgrDeleteOldOverrides = `-- name: CleanupMgrDeleteOldOverrides :execrows
+DELETE FROM user_overrides
+WHERE id = ANY (
+        SELECT
+            id
+        FROM
+            user_overrides
+        WHERE
+            end_time <(now() - '1 day'::interval * $1)
+        LIMIT 100
+        FOR UPDATE
+            SKIP LOCKED)
+`
+
+// CleanupMgrDeleteOldOverrides will delete old overrides from the user_overrides table that are older than the given number of days before now.
+func (q *Queries) CleanupMgrDeleteOldOverrides(ctx context.Context, historyThresholdDays interface{}) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cleanupMgrDeleteOldOverrides, historyThresholdDays)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const cleanupMgrDeleteOldScheduleShifts = `-- name: CleanupMgrDeleteOldScheduleShifts :execrows
+DELETE FROM schedule_on_call_users
+WHERE id = ANY (
+        SELECT
+            id
+        FROM
+            schedule_on_call_users
+        WHERE
+            end_time <(now() - '1 day'::interval * $1)
+        LIMIT 100
+        FOR UPDATE
+            SKIP LOCKED)
+`
+
+// CleanupMgrDeleteOldScheduleShifts will delete old schedule shifts from the schedule_on_call_users table that are older than the given number of days before now.
+func (q *Queries) CleanupMgrDeleteOldScheduleShifts(ctx context.Context, historyThresholdDays interface{}) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cleanupMgrDeleteOldScheduleShifts, historyThresholdDays)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const cleanupMgrDeleteOldSessions = `-- name: CleanupMgrDeleteOldSessions :execrows
+DELETE FROM auth_user_sessions
+WHERE id = ANY (
+        SELECT
+            id
+        FROM
+            auth_user_sessions
+        WHERE
+            last_access_at <(now() - '1 day'::interval * $1::int)
+        LIMIT 100
+        FOR UPDATE
+            SKIP LOCKED)
+`
+
+// CleanupMgrDeleteOldSessions will delete old sessions from the auth_user_sessions table that are older than the given number of days before now.
+func (q *Queries) CleanupMgrDeleteOldSessions(ctx context.Context, maxSessionAgeDays int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cleanupMgrDeleteOldSessions, maxSessionAgeDays)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const cleanupMgrDeleteOldStepShifts = `-- name: CleanupMgrDeleteOldStepShifts :execrows
+DELETE FROM ep_step_on_call_users
+WHERE id = ANY (
+        SELECT
+            id
+        FROM
+            ep_step_on_call_users
+        WHERE
+            end_time <(now() - '1 day'::interval * $1)
+        LIMIT 100
+        FOR UPDATE
+            SKIP LOCKED)
+`
+
+// CleanupMgrDeleteOldStepShifts will delete old EP step shifts from the ep_step_on_call_users table that are older than the given number of days before now.
+func (q *Queries) CleanupMgrDeleteOldStepShifts(ctx context.Context, historyThresholdDays interface{}) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cleanupMgrDeleteOldStepShifts, historyThresholdDays)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const cleanupMgrDisableOldCalSub = `-- name: CleanupMgrDisableOldCalSub :execrows
+UPDATE
+    user_calendar_subscriptions
+SET
+    disabled = TRUE
+WHERE
+    id = ANY (
+        SELECT
+            id
+        FROM
+            user_calendar_subscriptions
+        WHERE
+            greatest(last_access, last_update) <(now() - '1 day'::interval * $1::int)
+            AND NOT disabled
+        ORDER BY
+            id
+        LIMIT 100
+        FOR UPDATE
+            SKIP LOCKED)
+`
+
+// CleanupMgrDeleteOldCalSub will disable old calendar subscriptions from the user_calendar_subscriptions table that are unused for at least the given number of days.
+func (q *Queries) CleanupMgrDisableOldCalSub(ctx context.Context, inactivityThresholdDays int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cleanupMgrDisableOldCalSub, inactivityThresholdDays)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const cleanupMgrExpireOverrideRequests = `-- name: CleanupMgrExpireOverrideRequests :execrows
+UPDATE
+    override_requests
+SET
+    status = 'expired'
+WHERE
+    id = ANY (
+        SELECT
+            id
+        FROM
+            override_requests
+        WHERE
+            status = 'pending'
+            AND end_time <= now()
+        LIMIT 100
+        FOR UPDATE
+            SKIP LOCKED)
+`
+
+// CleanupMgrExpireOverrideRequests will mark pending override requests as expired once their end time has passed.
+func (q *Queries) CleanupMgrExpireOverrideRequests(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cleanupMgrExpireOverrideRequests)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const cleanupMgrFindStaleAlerts = `-- name: CleanupMgrFindStaleAlerts :many
+SELECT
+    id
+FROM
+    alerts a
+WHERE (a.status = 'triggered'
+    OR ($1
+        AND a.status = 'active'))
+AND created_at <= now() - '1 day'::interval * $2
+AND NOT EXISTS (
+    SELECT
+        1
     FROM
-        scope
-),
-_delete AS (
-    DELETE FROM alert_logs
-    WHERE id = ANY (
-            SELECT
-                id
-            FROM
-                alert_logs
-            WHERE
-                id BETWEEN (
-                    SELECT
-                        min
-                    FROM
-                        id_range)
-                    AND (
-                        SELECT
-                            max
-                        FROM
-                            id_range)
-                        AND NOT EXISTS (
-                            SELECT
-                                1
-                            FROM
-                                alerts
-                            WHERE
-                                alert_id = id)
-                            FOR UPDATE
-                                SKIP LOCKED))
-                SELECT
-                    id
-                FROM
-                    scope OFFSET $1- 1
-                LIMIT 1
+        alert_logs log
+    WHERE
+        timestamp > now() - '1 day'::interval * $2
+        AND log.alert_id = a.id)
+LIMIT 100
+`
+
+type CleanupMgrFindStaleAlertsParams struct {
+	IncludeAcked           interface{}
+	AutoCloseThresholdDays interface{}
+}
+
+// CleanupMgrFindStaleAlerts will find alerts that are triggered or active and have no activity in specified number of days.
+func (q *Queries) CleanupMgrFindStaleAlerts(ctx context.Context, arg CleanupMgrFindStaleAlertsParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, cleanupMgrFindStaleAlerts, arg.IncludeAcked, arg.AutoCloseThresholdDays)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const cleanupMgrScheduleData = `-- name: CleanupMgrScheduleData :one
+SELECT
+    data
+FROM
+    schedule_data
+WHERE
+    schedule_id = $1
+FOR UPDATE
+LIMIT 1
+`
+
+// CleanupMgrScheduleData will select the schedule data for the given schedule id.
+func (q *Queries) CleanupMgrScheduleData(ctx context.Context, scheduleID uuid.UUID) (json.RawMessage, error) {
+	row := q.db.QueryRowContext(ctx, cleanupMgrScheduleData, scheduleID)
+	var data json.RawMessage
+	err := row.Scan(&data)
+	return data, err
+}
+
+const cleanupMgrScheduleDataSkip = `-- name: CleanupMgrScheduleDataSkip :exec
+UPDATE
+    schedule_data
+SET
+    last_cleanup_at = now()
+WHERE
+    schedule_id = $1
+`
+
+// CleanupMgrScheduleDataSkip will update the last_cleanup_at field in the schedule_data table.
+func (q *Queries) CleanupMgrScheduleDataSkip(ctx context.Context, scheduleID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, cleanupMgrScheduleDataSkip, scheduleID)
+	return err
+}
+
+const cleanupMgrScheduleNeedsCleanup = `-- name: CleanupMgrScheduleNeedsCleanup :many
+SELECT
+    schedule_id
+FROM
+    schedule_data
+WHERE
+    data NOTNULL
+    AND (last_cleanup_at ISNULL
+        OR last_cleanup_at <= now() - '1 day'::interval * $1::int)
+ORDER BY
+    last_cleanup_at ASC nulls FIRST
+`
+
+// CleanupMgrScheduleNeedsCleanup will find schedules that need to be cleaned up. The last_cleanup_at field is used to ensure we clean up each schedule data at most once per interval.
+func (q *Queries) CleanupMgrScheduleNeedsCleanup(ctx context.Context, cleanupIntervalDays int32) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, cleanupMgrScheduleNeedsCleanup, cleanupIntervalDays)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var schedule_id uuid.UUID
+		if err := rows.Scan(&schedule_id); err != nil {
+			return nil, err
+		}
+		items = append(items, schedule_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const cleanupMgrUpdateScheduleData = `-- name: CleanupMgrUpdateScheduleData :exec
+UPDATE
+    schedule_data
+SET
+    last_cleanup_at = now(),
+    data = $2
+WHERE
+    schedule_id = $1
+`
+
+type CleanupMgrUpdateScheduleDataParams struct {
+	ScheduleID uuid.UUID
+	Data       json.RawMessage
+}
+
+// CleanupMgrUpdateScheduleData will update the last_cleanup_at and data fields in the schedule_data table.
+func (q *Queries) CleanupMgrUpdateScheduleData(ctx context.Context, arg CleanupMgrUpdateScheduleDataParams) error {
+	_, err := q.db.ExecContext(ctx, cleanupMgrUpdateScheduleData, arg.ScheduleID, arg.Data)
+	return err
+}
+
+const cleanupMgrVerifyUsers = `-- name: CleanupMgrVerifyUsers :many
+SELECT
+    id
+FROM
+    users
+WHERE
+    id = ANY ($1::uuid[])
+`
+
+// CleanupMgrVerifyUsers will verify that the given user ids exist in the users table.
+func (q *Queries) CleanupMgrVerifyUsers(ctx context.Context, userIds []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, cleanupMgrVerifyUsers, pq.Array(userIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const compatAuthSubSetCMID = `-- name: CompatAuthSubSetCMID :exec
+UPDATE
+    auth_subjects
+SET
+    cm_id =(
+        SELECT
+            id
+        FROM
+            user_contact_methods
+        WHERE
+            type = 'SLACK_DM'
+            AND value = $2)
+WHERE
+    auth_subjects.id = $1
+`
+
+type CompatAuthSubSetCMIDParams struct {
+	ID    int64
+	Value string
+}
+
+// Updates the contact method id for an auth_subject with the given destination.
+func (q *Queries) CompatAuthSubSetCMID(ctx context.Context, arg CompatAuthSubSetCMIDParams) error {
+	_, err := q.db.ExecContext(ctx, compatAuthSubSetCMID, arg.ID, arg.Value)
+	return err
+}
+
+const compatAuthSubSlackMissingCM = `-- name: CompatAuthSubSlackMissingCM :many
+SELECT
+    cm_id, id, provider_id, subject_id, user_id
+FROM
+    auth_subjects
+WHERE
+    provider_id LIKE 'slack:%'
+    AND cm_id IS NULL
+FOR UPDATE
+    SKIP LOCKED
+LIMIT 10
+`
+
+// Get up to 10 auth_subjects (slack only) missing a contact method.
+func (q *Queries) CompatAuthSubSlackMissingCM(ctx context.Context) ([]AuthSubject, error) {
+	rows, err := q.db.QueryContext(ctx, compatAuthSubSlackMissingCM)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuthSubject
+	for rows.Next() {
+		var i AuthSubject
+		if err := rows.Scan(
+			&i.CmID,
+			&i.ID,
+			&i.ProviderID,
+			&i.SubjectID,
+			&i.UserID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const compatCMMissingSub = `-- name: CompatCMMissingSub :many
+SELECT
+    id,
+    user_id,
+    value
+FROM
+    user_contact_methods
+WHERE
+    type = 'SLACK_DM'
+    AND NOT disabled
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            auth_subjects
+        WHERE
+            cm_id = user_contact_methods.id)
+FOR UPDATE
+    SKIP LOCKED
+LIMIT 10
+`
+
+type CompatCMMissingSubRow struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	Value  string
+}
+
+// Get up to 10 contact methods missing an auth_subjects link.
+func (q *Queries) CompatCMMissingSub(ctx context.Context) ([]CompatCMMissingSubRow, error) {
+	rows, err := q.db.QueryContext(ctx, compatCMMissingSub)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CompatCMMissingSubRow
+	for rows.Next() {
+		var i CompatCMMissingSubRow
+		if err := rows.Scan(&i.ID, &i.UserID, &i.Value); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const compatInsertUserCM = `-- name: CompatInsertUserCM :exec
+INSERT INTO user_contact_methods(id, name, type, value, user_id, pending)
+    VALUES ($1, $2, $3, $4, $5, FALSE)
+ON CONFLICT (type, value)
+    DO NOTHING
+`
+
+type CompatInsertUserCMParams struct {
+	ID     uuid.UUID
+	Name   string
+	Type   EnumUserContactMethodType
+	Value  string
+	UserID uuid.UUID
+}
+
+// Inserts a new contact method for a user.
+func (q *Queries) CompatInsertUserCM(ctx context.Context, arg CompatInsertUserCMParams) error {
+	_, err := q.db.ExecContext(ctx, compatInsertUserCM,
+		arg.ID,
+		arg.Name,
+		arg.Type,
+		arg.Value,
+		arg.UserID,
+	)
+	return err
+}
+
+const compatUpsertAuthSubject = `-- name: CompatUpsertAuthSubject :exec
+INSERT INTO auth_subjects(user_id, subject_id, provider_id, cm_id)
+    VALUES ($1, $2, $3, $4)
+ON CONFLICT (subject_id, provider_id)
+    DO UPDATE SET
+        user_id = $1, cm_id = $4
+`
+
+type CompatUpsertAuthSubjectParams struct {
+	UserID     uuid.UUID
+	SubjectID  string
+	ProviderID string
+	CmID       uuid.NullUUID
+}
+
+// Inserts a new auth_subject for a user.
+func (q *Queries) CompatUpsertAuthSubject(ctx context.Context, arg CompatUpsertAuthSubjectParams) error {
+	_, err := q.db.ExecContext(ctx, compatUpsertAuthSubject,
+		arg.UserID,
+		arg.SubjectID,
+		arg.ProviderID,
+		arg.CmID,
+	)
+	return err
+}
+
+const connectionInfo = `-- name: ConnectionInfo :many
+SELECT application_name AS NAME,
+    COUNT(*)
+FROM pg_stat_activity
+WHERE datname = current_database()
+GROUP BY NAME
+`
+
+type ConnectionInfoRow struct {
+	Name  sql.NullString
+	Count int64
+}
+
+func (q *Queries) ConnectionInfo(ctx context.Context) ([]ConnectionInfoRow, error) {
+	rows, err := q.db.QueryContext(ctx, connectionInfo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ConnectionInfoRow
+	for rows.Next() {
+		var i ConnectionInfoRow
+		if err := rows.Scan(&i.Name, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const contactMethodAdd = `-- name: ContactMethodAdd :exec
+INSERT INTO user_contact_methods(id, name, dest, disabled, user_id, enable_status_updates)
+    VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type ContactMethodAddParams struct {
+	ID                  uuid.UUID
+	Name                string
+	Dest                NullDestV1
+	Disabled            bool
+	UserID              uuid.UUID
+	EnableStatusUpdates bool
+}
+
+func (q *Queries) ContactMethodAdd(ctx context.Context, arg ContactMethodAddParams) error {
+	_, err := q.db.ExecContext(ctx, contactMethodAdd,
+		arg.ID,
+		arg.Name,
+		arg.Dest,
+		arg.Disabled,
+		arg.UserID,
+		arg.EnableStatusUpdates,
+	)
+	return err
+}
+
+const contactMethodEnableDisable = `-- name: ContactMethodEnableDisable :one
+UPDATE
+    user_contact_methods
+SET
+    disabled = $2
+WHERE
+    dest = $1
+RETURNING
+    id
+`
+
+type ContactMethodEnableDisableParams struct {
+	Dest     NullDestV1
+	Disabled bool
+}
+
+func (q *Queries) ContactMethodEnableDisable(ctx context.Context, arg ContactMethodEnableDisableParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, contactMethodEnableDisable, arg.Dest, arg.Disabled)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const contactMethodFindAll = `-- name: ContactMethodFindAll :many
+SELECT
+    dest, disabled, enable_status_updates, id, last_test_verify_at, metadata, name, pending, type, user_id, value
+FROM
+    user_contact_methods
+WHERE
+    user_id = $1
+`
+
+func (q *Queries) ContactMethodFindAll(ctx context.Context, userID uuid.UUID) ([]UserContactMethod, error) {
+	rows, err := q.db.QueryContext(ctx, contactMethodFindAll, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UserContactMethod
+	for rows.Next() {
+		var i UserContactMethod
+		if err := rows.Scan(
+			&i.Dest,
+			&i.Disabled,
+			&i.EnableStatusUpdates,
+			&i.ID,
+			&i.LastTestVerifyAt,
+			&i.Metadata,
+			&i.Name,
+			&i.Pending,
+			&i.Type,
+			&i.UserID,
+			&i.Value,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const contactMethodFindMany = `-- name: ContactMethodFindMany :many
+SELECT
+    dest, disabled, enable_status_updates, id, last_test_verify_at, metadata, name, pending, type, user_id, value
+FROM
+    user_contact_methods
+WHERE
+    id = ANY ($1::uuid[])
+`
+
+func (q *Queries) ContactMethodFindMany(ctx context.Context, dollar_1 []uuid.UUID) ([]UserContactMethod, error) {
+	rows, err := q.db.QueryContext(ctx, contactMethodFindMany, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UserContactMethod
+	for rows.Next() {
+		var i UserContactMethod
+		if err := rows.Scan(
+			&i.Dest,
+			&i.Disabled,
+			&i.EnableStatusUpdates,
+			&i.ID,
+			&i.LastTestVerifyAt,
+			&i.Metadata,
+			&i.Name,
+			&i.Pending,
+			&i.Type,
+			&i.UserID,
+			&i.Value,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const contactMethodFindOneUpdate = `-- name: ContactMethodFindOneUpdate :one
+SELECT
+    dest, disabled, enable_status_updates, id, last_test_verify_at, metadata, name, pending, type, user_id, value
+FROM
+    user_contact_methods
+WHERE
+    id = $1
+FOR UPDATE
+`
+
+func (q *Queries) ContactMethodFindOneUpdate(ctx context.Context, id uuid.UUID) (UserContactMethod, error) {
+	row := q.db.QueryRowContext(ctx, contactMethodFindOneUpdate, id)
+	var i UserContactMethod
+	err := row.Scan(
+		&i.Dest,
+		&i.Disabled,
+		&i.EnableStatusUpdates,
+		&i.ID,
+		&i.LastTestVerifyAt,
+		&i.Metadata,
+		&i.Name,
+		&i.Pending,
+		&i.Type,
+		&i.UserID,
+		&i.Value,
+	)
+	return i, err
+}
+
+const contactMethodFineOne = `-- name: ContactMethodFineOne :one
+SELECT
+    dest, disabled, enable_status_updates, id, last_test_verify_at, metadata, name, pending, type, user_id, value
+FROM
+    user_contact_methods
+WHERE
+    id = $1
+`
+
+func (q *Queries) ContactMethodFineOne(ctx context.Context, id uuid.UUID) (UserContactMethod, error) {
+	row := q.db.QueryRowContext(ctx, contactMethodFineOne, id)
+	var i UserContactMethod
+	err := row.Scan(
+		&i.Dest,
+		&i.Disabled,
+		&i.EnableStatusUpdates,
+		&i.ID,
+		&i.LastTestVerifyAt,
+		&i.Metadata,
+		&i.Name,
+		&i.Pending,
+		&i.Type,
+		&i.UserID,
+		&i.Value,
+	)
+	return i, err
+}
+
+const contactMethodLookupUserID = `-- name: ContactMethodLookupUserID :many
+SELECT DISTINCT
+    user_id
+FROM
+    user_contact_methods
+WHERE
+    id = ANY ($1::uuid[])
+`
+
+func (q *Queries) ContactMethodLookupUserID(ctx context.Context, dollar_1 []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, contactMethodLookupUserID, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var user_id uuid.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const contactMethodMetaDest = `-- name: ContactMethodMetaDest :one
+SELECT
+    coalesce(metadata, '{}'),
+    now()::timestamptz AS now
+FROM
+    user_contact_methods
+WHERE
+    dest = $1
+`
+
+type ContactMethodMetaDestRow struct {
+	Metadata json.RawMessage
+	Now      time.Time
+}
+
+func (q *Queries) ContactMethodMetaDest(ctx context.Context, dest NullDestV1) (ContactMethodMetaDestRow, error) {
+	row := q.db.QueryRowContext(ctx, contactMethodMetaDest, dest)
+	var i ContactMethodMetaDestRow
+	err := row.Scan(&i.Metadata, &i.Now)
+	return i, err
+}
+
+const contactMethodReplaceDest = `-- name: ContactMethodReplaceDest :one
+UPDATE
+    user_contact_methods
+SET
+    dest = $1
+WHERE
+    user_id = $2
+    AND dest = $3
+RETURNING
+    id
+`
+
+type ContactMethodReplaceDestParams struct {
+	NewDest NullDestV1
+	UserID  uuid.UUID
+	OldDest NullDestV1
+}
+
+func (q *Queries) ContactMethodReplaceDest(ctx context.Context, arg ContactMethodReplaceDestParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, contactMethodReplaceDest, arg.NewDest, arg.UserID, arg.OldDest)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const contactMethodUpdate = `-- name: ContactMethodUpdate :exec
+UPDATE
+    user_contact_methods
+SET
+    name = $2,
+    disabled = $3,
+    enable_status_updates = $4
+WHERE
+    id = $1
 `
 
-type CleanupAlertLogsParams struct {
-	BatchSize int32
-	StartID   int64
-	EndID     int64
+type ContactMethodUpdateParams struct {
+	ID                  uuid.UUID
+	Name                string
+	Disabled            bool
+	EnableStatusUpdates bool
 }
 
-func (q *Queries) CleanupAlertLogs(ctx context.Context, arg CleanupAlertLogsParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, cleanupAlertLogs,
-		arg.BatchSize,
-		arg.StartID,
-		arg.EndID,
-		arg.BatchSize,
+func (q *Queries) ContactMethodUpdate(ctx context.Context, arg ContactMethodUpdateParams) error {
+	_, err := q.db.ExecContext(ctx, contactMethodUpdate,
+		arg.ID,
+		arg.Name,
+		arg.Disabled,
+		arg.EnableStatusUpdates,
 	)
-	var id int64
-	err := row.Scan(&id)
-	return id, err
+	return err
 }
 
-const cleanupMgrAlertLogsMinMax = `-- name: CleanupMgrAlertLogsMinMax :one
-SELECT
-    coalesce(min(id), 0)::bigint AS min_id,
-    coalesce(max(id), 0)::bigint AS max_id
-FROM
-    alert_logs
+const contactMethodUpdateMetaDest = `-- name: ContactMethodUpdateMetaDest :exec
+UPDATE
+    user_contact_methods
+SET
+    metadata = jsonb_set(jsonb_set(metadata, '{CarrierV1}', $2::jsonb), '{CarrierV1,UpdatedAt}',('"' || NOW()::timestamptz AT TIME ZONE 'UTC' || '"')::jsonb)
+WHERE
+    dest = $1
 `
 
-type CleanupMgrAlertLogsMinMaxRow struct {
-	MinID int64
-	MaxID int64
+type ContactMethodUpdateMetaDestParams struct {
+	Dest      NullDestV1
+	CarrierV1 json.RawMessage
 }
 
-// CleanupMgrAlertLogsMinMax will find the minimum and maximum id of the alert_logs table.
-func (q *Queries) CleanupMgrAlertLogsMinMax(ctx context.Context) (CleanupMgrAlertLogsMinMaxRow, error) {
-	row := q.db.QueryRowContext(ctx, cleanupMgrAlertLogsMinMax)
-	var i CleanupMgrAlertLogsMinMaxRow
-	err := row.Scan(&i.MinID, &i.MaxID)
-	return i, err
+func (q *Queries) ContactMethodUpdateMetaDest(ctx context.Context, arg ContactMethodUpdateMetaDestParams) error {
+	_, err := q.db.ExecContext(ctx, contactMethodUpdateMetaDest, arg.Dest, arg.CarrierV1)
+	return err
 }
 
-const cleanupMgrDeleteOldAlerts = `-- name: CleanupMgrDeleteOldAlerts :execrows
-DELETE FROM alerts
-WHERE id = ANY (
-        SELECT
-            id
-        FROM
-            alerts a
-        WHERE
-            status = 'closed'
-            AND a.created_at < now() -($1::bigint * '1 day'::interval)
-        ORDER BY
-            id
-        LIMIT 100
-        FOR UPDATE
-            SKIP LOCKED)
+const createCalSub = `-- name: CreateCalSub :one
+INSERT INTO user_calendar_subscriptions(id, NAME, user_id, disabled, schedule_id, rotation_id, service_id, config)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING
+    created_at
 `
 
-// CleanupMgrDeleteOldAlerts will delete old alerts from the alerts table that are closed and older than the given number of days before now.
-func (q *Queries) CleanupMgrDeleteOldAlerts(ctx context.Context, staleThresholdDays int64) (int64, error) {
-	result, err := q.db.ExecContext(ctx, cleanupMgrDeleteOldAlerts, staleThresholdDays)
-	if err != nil {
-		return 0, err
-	}
-	return result.RowsAffected()
+type CreateCalSubParams struct {
+	ID         uuid.UUID
+	Name       string
+	UserID     uuid.UUID
+	Disabled   bool
+	ScheduleID uuid.NullUUID
+	RotationID uuid.NullUUID
+	ServiceID  uuid.NullUUID
+	Config     json.RawMessage
 }
 
-const cleanupMgrDeleteOldOverrides = `-- name: CleanupMgrDeleteOldOverrides :execrows
-DELETE FROM user_overrides
-WHERE id = ANY (
-        SELECT
-            id
-        FROM
-            user_overrides
-        WHERE
-            end_time <(now() - '1 day'::interval * $1)
-        LIMIT 100
-        FOR UPDATE
-            SKIP LOCKED)
+func (q *Queries) CreateCalSub(ctx context.Context, arg CreateCalSubParams) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, createCalSub,
+		arg.ID,
+		arg.Name,
+		arg.UserID,
+		arg.Disabled,
+		arg.ScheduleID,
+		arg.RotationID,
+		arg.ServiceID,
+		arg.Config,
+	)
+	var created_at time.Time
+	err := row.Scan(&created_at)
+	return created_at, err
+}
+
+const databaseInfo = `-- name: DatabaseInfo :one
+SELECT db_id AS id,
+    version()
+FROM switchover_state
 `
 
-// CleanupMgrDeleteOldOverrides will delete old overrides from the user_overrides table that are older than the given number of days before now.
-func (q *Queries) CleanupMgrDeleteOldOverrides(ctx context.Context, historyThresholdDays interface{}) (int64, error) {
-	result, err := q.db.ExecContext(ctx, cleanupMgrDeleteOldOverrides, historyThresholdDays)
-	if err != nil {
-		return 0, err
-	}
-	return result.RowsAffected()
+type DatabaseInfoRow struct {
+	ID      uuid.UUID
+	Version string
 }
 
-const cleanupMgrDeleteOldScheduleShifts = `-- name: CleanupMgrDeleteOldScheduleShifts :execrows
-DELETE FROM schedule_on_call_users
-WHERE id = ANY (
-        SELECT
-            id
-        FROM
-            schedule_on_call_users
-        WHERE
-            end_time <(now() - '1 day'::interval * $1)
-        LIMIT 100
-        FOR UPDATE
-            SKIP LOCKED)
+func (q *Queries) DatabaseInfo(ctx context.Context) (DatabaseInfoRow, error) {
+	row := q.db.QueryRowContext(ctx, databaseInfo)
+	var i DatabaseInfoRow
+	err := row.Scan(&i.ID, &i.Version)
+	return i, err
+}
+
+const deleteContactMethod = `-- name: DeleteContactMethod :exec
+DELETE FROM user_contact_methods
+WHERE id = ANY ($1::uuid[])
 `
 
-// CleanupMgrDeleteOldScheduleShifts will delete old schedule shifts from the schedule_on_call_users table that are older than the given number of days before now.
-func (q *Queries) CleanupMgrDeleteOldScheduleShifts(ctx context.Context, historyThresholdDays interface{}) (int64, error) {
-	result, err := q.db.ExecContext(ctx, cleanupMgrDeleteOldScheduleShifts, historyThresholdDays)
-	if err != nil {
-		return 0, err
-	}
-	return result.RowsAffected()
+func (q *Queries) DeleteContactMethod(ctx context.Context, dollar_1 []uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteContactMethod, pq.Array(dollar_1))
+	return err
 }
 
-const cleanupMgrDeleteOldSessions = `-- name: CleanupMgrDeleteOldSessions :execrows
-DELETE FROM auth_user_sessions
-WHERE id = ANY (
-        SELECT
-            id
-        FROM
-            auth_user_sessions
-        WHERE
-            last_access_at <(now() - '1 day'::interval * $1::int)
-        LIMIT 100
-        FOR UPDATE
-            SKIP LOCKED)
+const deleteManyCalSub = `-- name: DeleteManyCalSub :exec
+DELETE FROM user_calendar_subscriptions
+WHERE id = ANY ($1::uuid[])
+    AND user_id = $2
 `
 
-// CleanupMgrDeleteOldSessions will delete old sessions from the auth_user_sessions table that are older than the given number of days before now.
-func (q *Queries) CleanupMgrDeleteOldSessions(ctx context.Context, maxSessionAgeDays int32) (int64, error) {
-	result, err := q.db.ExecContext(ctx, cleanupMgrDeleteOldSessions, maxSessionAgeDays)
-	if err != nil {
-		return 0, err
-	}
-	return result.RowsAffected()
+type DeleteManyCalSubParams struct {
+	Column1 []uuid.UUID
+	UserID  uuid.UUID
 }
 
-const cleanupMgrDeleteOldStepShifts = `-- name: CleanupMgrDeleteOldStepShifts :execrows
-DELETE FROM ep_step_on_call_users
-WHERE id = ANY (
-        SELECT
-            id
-        FROM
-            ep_step_on_call_users
-        WHERE
-            end_time <(now() - '1 day'::interval * $1)
-        LIMIT 100
-        FOR UPDATE
-            SKIP LOCKED)
+func (q *Queries) DeleteManyCalSub(ctx context.Context, arg DeleteManyCalSubParams) error {
+	_, err := q.db.ExecContext(ctx, deleteManyCalSub, pq.Array(arg.Column1), arg.UserID)
+	return err
+}
+
+const disableChangeLogTriggers = `-- name: DisableChangeLogTriggers :exec
+UPDATE switchover_state
+SET current_state = 'idle'
+WHERE current_state = 'in_progress'
 `
 
-// CleanupMgrDeleteOldStepShifts will delete old EP step shifts from the ep_step_on_call_users table that are older than the given number of days before now.
-func (q *Queries) CleanupMgrDeleteOldStepShifts(ctx context.Context, historyThresholdDays interface{}) (int64, error) {
-	result, err := q.db.ExecContext(ctx, cleanupMgrDeleteOldStepShifts, historyThresholdDays)
-	if err != nil {
-		return 0, err
-	}
-	return result.RowsAffected()
+func (q *Queries) DisableChangeLogTriggers(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, disableChangeLogTriggers)
+	return err
 }
 
-const cleanupMgrDisableOldCalSub = `-- name: CleanupMgrDisableOldCalSub :execrows
-UPDATE
-    user_calendar_subscriptions
-SET
-    disabled = TRUE
-WHERE
-    id = ANY (
-        SELECT
-            id
-        FROM
-            user_calendar_subscriptions
-        WHERE
-            greatest(last_access, last_update) <(now() - '1 day'::interval * $1::int)
-            AND NOT disabled
-        ORDER BY
-            id
-        LIMIT 100
-        FOR UPDATE
-            SKIP LOCKED)
+const ePStepActionsAddAction = `-- name: EPStepActionsAddAction :exec
+INSERT INTO escalation_policy_actions(escalation_policy_step_id, user_id, schedule_id, rotation_id, channel_id)
+    VALUES ($1, $2, $3, $4, $5)
 `
 
-// CleanupMgrDeleteOldCalSub will disable old calendar subscriptions from the user_calendar_subscriptions table that are unused for at least the given number of days.
-func (q *Queries) CleanupMgrDisableOldCalSub(ctx context.Context, inactivityThresholdDays int32) (int64, error) {
-	result, err := q.db.ExecContext(ctx, cleanupMgrDisableOldCalSub, inactivityThresholdDays)
-	if err != nil {
-		return 0, err
-	}
-	return result.RowsAffected()
+type EPStepActionsAddActionParams struct {
+	EscalationPolicyStepID uuid.UUID
+	UserID                 uuid.NullUUID
+	ScheduleID             uuid.NullUUID
+	RotationID             uuid.NullUUID
+	ChannelID              uuid.NullUUID
+}
+
+func (q *Queries) EPStepActionsAddAction(ctx context.Context, arg EPStepActionsAddActionParams) error {
+	_, err := q.db.ExecContext(ctx, ePStepActionsAddAction,
+		arg.EscalationPolicyStepID,
+		arg.UserID,
+		arg.ScheduleID,
+		arg.RotationID,
+		arg.ChannelID,
+	)
+	return err
 }
 
-const cleanupMgrFindStaleAlerts = `-- name: CleanupMgrFindStaleAlerts :many
+const ePStepActionsByStepId = `-- name: EPStepActionsByStepId :many
 SELECT
-    id
+    a.user_id,
+    a.schedule_id,
+    a.rotation_id,
+    ch.dest
 FROM
-    alerts a
-WHERE (a.status = 'triggered'
-    OR ($1
-        AND a.status = 'active'))
-AND created_at <= now() - '1 day'::interval * $2
-AND NOT EXISTS (
-    SELECT
-        1
-    FROM
-        alert_logs log
-    WHERE
-        timestamp > now() - '1 day'::interval * $2
-        AND log.alert_id = a.id)
-LIMIT 100
+    escalation_policy_actions a
+    LEFT JOIN notification_channels ch ON a.channel_id = ch.id
+WHERE
+    a.escalation_policy_step_id = $1
 `
 
-type CleanupMgrFindStaleAlertsParams struct {
-	IncludeAcked           interface{}
-	AutoCloseThresholdDays interface{}
+type EPStepActionsByStepIdRow struct {
+	UserID     uuid.NullUUID
+	ScheduleID uuid.NullUUID
+	RotationID uuid.NullUUID
+	Dest       NullDestV1
 }
 
-// CleanupMgrFindStaleAlerts will find alerts that are triggered or active and have no activity in specified number of days.
-func (q *Queries) CleanupMgrFindStaleAlerts(ctx context.Context, arg CleanupMgrFindStaleAlertsParams) ([]int64, error) {
-	rows, err := q.db.QueryContext(ctx, cleanupMgrFindStaleAlerts, arg.IncludeAcked, arg.AutoCloseThresholdDays)
+func (q *Queries) EPStepActionsByStepId(ctx context.Context, escalationPolicyStepID uuid.UUID) ([]EPStepActionsByStepIdRow, error) {
+	rows, err := q.db.QueryContext(ctx, ePStepActionsByStepId, escalationPolicyStepID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []int64
+	var items []EPStepActionsByStepIdRow
 	for rows.Next() {
-		var id int64
-		if err := rows.Scan(&id); err != nil {
+		var i EPStepActionsByStepIdRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.ScheduleID,
+			&i.RotationID,
+			&i.Dest,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -1354,67 +3244,113 @@ func (q *Queries) CleanupMgrFindStaleAlerts(ctx context.Context, arg CleanupMgrF
 	return items, nil
 }
 
-const cleanupMgrScheduleData = `-- name: CleanupMgrScheduleData :one
-SELECT
-    data
-FROM
-    schedule_data
-WHERE
-    schedule_id = $1
-FOR UPDATE
-LIMIT 1
+const ePStepActionsDeleteAction = `-- name: EPStepActionsDeleteAction :exec
+DELETE FROM escalation_policy_actions
+WHERE escalation_policy_step_id = $1
+    AND (user_id = $2
+        OR schedule_id = $3
+        OR rotation_id = $4
+        OR channel_id = $5)
 `
 
-// CleanupMgrScheduleData will select the schedule data for the given schedule id.
-func (q *Queries) CleanupMgrScheduleData(ctx context.Context, scheduleID uuid.UUID) (json.RawMessage, error) {
-	row := q.db.QueryRowContext(ctx, cleanupMgrScheduleData, scheduleID)
-	var data json.RawMessage
-	err := row.Scan(&data)
-	return data, err
+type EPStepActionsDeleteActionParams struct {
+	EscalationPolicyStepID uuid.UUID
+	UserID                 uuid.NullUUID
+	ScheduleID             uuid.NullUUID
+	RotationID             uuid.NullUUID
+	ChannelID              uuid.NullUUID
 }
 
-const cleanupMgrScheduleDataSkip = `-- name: CleanupMgrScheduleDataSkip :exec
-UPDATE
-    schedule_data
-SET
-    last_cleanup_at = now()
+func (q *Queries) EPStepActionsDeleteAction(ctx context.Context, arg EPStepActionsDeleteActionParams) error {
+	_, err := q.db.ExecContext(ctx, ePStepActionsDeleteAction,
+		arg.EscalationPolicyStepID,
+		arg.UserID,
+		arg.ScheduleID,
+		arg.RotationID,
+		arg.ChannelID,
+	)
+	return err
+}
+
+const enableChangeLogTriggers = `-- name: EnableChangeLogTriggers :exec
+UPDATE switchover_state
+SET current_state = 'in_progress'
+WHERE current_state = 'idle'
+`
+
+func (q *Queries) EnableChangeLogTriggers(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, enableChangeLogTriggers)
+	return err
+}
+
+const engineGetCoverageGapNotice = `-- name: EngineGetCoverageGapNotice :one
+SELECT
+    gap_start,
+    gap_end
+FROM
+    schedule_coverage_notices
 WHERE
     schedule_id = $1
 `
 
-// CleanupMgrScheduleDataSkip will update the last_cleanup_at field in the schedule_data table.
-func (q *Queries) CleanupMgrScheduleDataSkip(ctx context.Context, scheduleID uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, cleanupMgrScheduleDataSkip, scheduleID)
-	return err
+type EngineGetCoverageGapNoticeRow struct {
+	GapStart time.Time
+	GapEnd   time.Time
 }
 
-const cleanupMgrScheduleNeedsCleanup = `-- name: CleanupMgrScheduleNeedsCleanup :many
+// Get the coverage gap a schedule was last notified about.
+func (q *Queries) EngineGetCoverageGapNotice(ctx context.Context, scheduleID uuid.UUID) (EngineGetCoverageGapNoticeRow, error) {
+	row := q.db.QueryRowContext(ctx, engineGetCoverageGapNotice, scheduleID)
+	var i EngineGetCoverageGapNoticeRow
+	err := row.Scan(&i.GapStart, &i.GapEnd)
+	return i, err
+}
+
+const engineGetDigestMessages = `-- name: EngineGetDigestMessages :many
 SELECT
-    schedule_id
+    message_type,
+    alert_id,
+    alert_log_id,
+    schedule_id,
+    created_at
 FROM
-    schedule_data
+    outgoing_messages
 WHERE
-    data NOTNULL
-    AND (last_cleanup_at ISNULL
-        OR last_cleanup_at <= now() - '1 day'::interval * $1::int)
+    last_status = 'bundled'
+    AND quiet_until NOTNULL
+    AND status_details = $1::text
 ORDER BY
-    last_cleanup_at ASC nulls FIRST
+    created_at
 `
 
-// CleanupMgrScheduleNeedsCleanup will find schedules that need to be cleaned up. The last_cleanup_at field is used to ensure we clean up each schedule data at most once per interval.
-func (q *Queries) CleanupMgrScheduleNeedsCleanup(ctx context.Context, cleanupIntervalDays int32) ([]uuid.UUID, error) {
-	rows, err := q.db.QueryContext(ctx, cleanupMgrScheduleNeedsCleanup, cleanupIntervalDays)
+type EngineGetDigestMessagesRow struct {
+	MessageType EnumOutgoingMessagesType
+	AlertID     sql.NullInt64
+	AlertLogID  sql.NullInt64
+	ScheduleID  uuid.NullUUID
+	CreatedAt   time.Time
+}
+
+// Get the messages held for quiet hours that were bundled into a digest.
+func (q *Queries) EngineGetDigestMessages(ctx context.Context, digestID string) ([]EngineGetDigestMessagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, engineGetDigestMessages, digestID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []uuid.UUID
+	var items []EngineGetDigestMessagesRow
 	for rows.Next() {
-		var schedule_id uuid.UUID
-		if err := rows.Scan(&schedule_id); err != nil {
+		var i EngineGetDigestMessagesRow
+		if err := rows.Scan(
+			&i.MessageType,
+			&i.AlertID,
+			&i.AlertLogID,
+			&i.ScheduleID,
+			&i.CreatedAt,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, schedule_id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -1425,116 +3361,157 @@ func (q *Queries) CleanupMgrScheduleNeedsCleanup(ctx context.Context, cleanupInt
 	return items, nil
 }
 
-const cleanupMgrUpdateScheduleData = `-- name: CleanupMgrUpdateScheduleData :exec
-UPDATE
-    schedule_data
-SET
-    last_cleanup_at = now(),
-    data = $2
+const engineGetOverrideRequest = `-- name: EngineGetOverrideRequest :one
+SELECT
+    req.add_user_id,
+    req.remove_user_id,
+    req.start_time,
+    req.end_time,
+    req.schedule_id,
+    sched.name AS schedule_name,
+    sched.time_zone AS schedule_time_zone,
+    requester.name AS requester_name,
+    coalesce(add_user.name, '') AS add_user_name,
+    coalesce(remove_user.name, '') AS remove_user_name,
+    req.requester_user_id,
+    req.target_user_id
+FROM
+    outgoing_messages msg
+    JOIN override_requests req ON req.id = msg.override_request_id
+    JOIN schedules sched ON sched.id = req.schedule_id
+    JOIN users requester ON requester.id = req.requester_user_id
+    LEFT JOIN users add_user ON add_user.id = req.add_user_id
+    LEFT JOIN users remove_user ON remove_user.id = req.remove_user_id
 WHERE
-    schedule_id = $1
-`
-
-type CleanupMgrUpdateScheduleDataParams struct {
-	ScheduleID uuid.UUID
-	Data       json.RawMessage
-}
-
-// CleanupMgrUpdateScheduleData will update the last_cleanup_at and data fields in the schedule_data table.
-func (q *Queries) CleanupMgrUpdateScheduleData(ctx context.Context, arg CleanupMgrUpdateScheduleDataParams) error {
-	_, err := q.db.ExecContext(ctx, cleanupMgrUpdateScheduleData, arg.ScheduleID, arg.Data)
-	return err
+    msg.id = $1
+`
+
+type EngineGetOverrideRequestRow struct {
+	AddUserID        uuid.NullUUID
+	RemoveUserID     uuid.NullUUID
+	StartTime        time.Time
+	EndTime          time.Time
+	ScheduleID       uuid.UUID
+	ScheduleName     string
+	ScheduleTimeZone string
+	RequesterName    string
+	AddUserName      string
+	RemoveUserName   string
+	RequesterUserID  uuid.UUID
+	TargetUserID     uuid.UUID
+}
+
+// Get the details of an override request for rendering a notification.
+func (q *Queries) EngineGetOverrideRequest(ctx context.Context, id uuid.UUID) (EngineGetOverrideRequestRow, error) {
+	row := q.db.QueryRowContext(ctx, engineGetOverrideRequest, id)
+	var i EngineGetOverrideRequestRow
+	err := row.Scan(
+		&i.AddUserID,
+		&i.RemoveUserID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.ScheduleID,
+		&i.ScheduleName,
+		&i.ScheduleTimeZone,
+		&i.RequesterName,
+		&i.AddUserName,
+		&i.RemoveUserName,
+		&i.RequesterUserID,
+		&i.TargetUserID,
+	)
+	return i, err
 }
 
-const cleanupMgrVerifyUsers = `-- name: CleanupMgrVerifyUsers :many
+const engineGetSignalParams = `-- name: EngineGetSignalParams :one
 SELECT
-    id
+    params
 FROM
-    users
+    pending_signals
 WHERE
-    id = ANY ($1::uuid[])
+    message_id = $1
 `
 
-// CleanupMgrVerifyUsers will verify that the given user ids exist in the users table.
-func (q *Queries) CleanupMgrVerifyUsers(ctx context.Context, userIds []uuid.UUID) ([]uuid.UUID, error) {
-	rows, err := q.db.QueryContext(ctx, cleanupMgrVerifyUsers, pq.Array(userIds))
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []uuid.UUID
-	for rows.Next() {
-		var id uuid.UUID
-		if err := rows.Scan(&id); err != nil {
-			return nil, err
-		}
-		items = append(items, id)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+// Get a pending signal's rendered params.
+func (q *Queries) EngineGetSignalParams(ctx context.Context, messageID uuid.NullUUID) (json.RawMessage, error) {
+	row := q.db.QueryRowContext(ctx, engineGetSignalParams, messageID)
+	var params json.RawMessage
+	err := row.Scan(&params)
+	return params, err
 }
 
-const compatAuthSubSetCMID = `-- name: CompatAuthSubSetCMID :exec
-UPDATE
-    auth_subjects
-SET
-    cm_id =(
+const engineIsKnownDest = `-- name: EngineIsKnownDest :one
+SELECT
+    EXISTS (
         SELECT
-            id
         FROM
-            user_contact_methods
+            user_contact_methods uc
         WHERE
-            type = 'SLACK_DM'
-            AND value = $2)
-WHERE
-    auth_subjects.id = $1
+            uc.dest = $1
+            AND uc.disabled = FALSE)
+    OR EXISTS (
+        SELECT
+        FROM
+            notification_channels nc
+        WHERE
+            nc.dest = $1)
 `
 
-type CompatAuthSubSetCMIDParams struct {
-	ID    int64
-	Value string
-}
-
-// Updates the contact method id for an auth_subject with the given destination.
-func (q *Queries) CompatAuthSubSetCMID(ctx context.Context, arg CompatAuthSubSetCMIDParams) error {
-	_, err := q.db.ExecContext(ctx, compatAuthSubSetCMID, arg.ID, arg.Value)
-	return err
+// Check if a destination is known in user_contact_methods or notification_channels table.
+func (q *Queries) EngineIsKnownDest(ctx context.Context, dest NullDestV1) (sql.NullBool, error) {
+	row := q.db.QueryRowContext(ctx, engineIsKnownDest, dest)
+	var column_1 sql.NullBool
+	err := row.Scan(&column_1)
+	return column_1, err
 }
 
-const compatAuthSubSlackMissingCM = `-- name: CompatAuthSubSlackMissingCM :many
+const findManyCalSubByUser = `-- name: FindManyCalSubByUser :many
 SELECT
-    cm_id, id, provider_id, subject_id, user_id
+    id,
+    NAME,
+    user_id,
+    disabled,
+    schedule_id,
+    rotation_id,
+    service_id,
+    config,
+    last_access
 FROM
-    auth_subjects
+    user_calendar_subscriptions
 WHERE
-    provider_id LIKE 'slack:%'
-    AND cm_id IS NULL
-FOR UPDATE
-    SKIP LOCKED
-LIMIT 10
+    user_id = $1
 `
 
-// Get up to 10 auth_subjects (slack only) missing a contact method.
-func (q *Queries) CompatAuthSubSlackMissingCM(ctx context.Context) ([]AuthSubject, error) {
-	rows, err := q.db.QueryContext(ctx, compatAuthSubSlackMissingCM)
+type FindManyCalSubByUserRow struct {
+	ID         uuid.UUID
+	Name       string
+	UserID     uuid.UUID
+	Disabled   bool
+	ScheduleID uuid.NullUUID
+	RotationID uuid.NullUUID
+	ServiceID  uuid.NullUUID
+	Config     json.RawMessage
+	LastAccess sql.NullTime
+}
+
+func (q *Queries) FindManyCalSubByUser(ctx context.Context, userID uuid.UUID) ([]FindManyCalSubByUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, findManyCalSubByUser, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []AuthSubject
+	var items []FindManyCalSubByUserRow
 	for rows.Next() {
-		var i AuthSubject
+		var i FindManyCalSubByUserRow
 		if err := rows.Scan(
-			&i.CmID,
 			&i.ID,
-			&i.ProviderID,
-			&i.SubjectID,
+			&i.Name,
 			&i.UserID,
+			&i.Disabled,
+			&i.ScheduleID,
+			&i.RotationID,
+			&i.ServiceID,
+			&i.Config,
+			&i.LastAccess,
 		); err != nil {
 			return nil, err
 		}
@@ -1549,45 +3526,126 @@ func (q *Queries) CompatAuthSubSlackMissingCM(ctx context.Context) ([]AuthSubjec
 	return items, nil
 }
 
-const compatCMMissingSub = `-- name: CompatCMMissingSub :many
+const findOneCalSub = `-- name: FindOneCalSub :one
 SELECT
     id,
+    NAME,
     user_id,
-    value
+    disabled,
+    schedule_id,
+    rotation_id,
+    service_id,
+    config,
+    last_access
 FROM
-    user_contact_methods
+    user_calendar_subscriptions
 WHERE
-    type = 'SLACK_DM'
-    AND NOT disabled
-    AND NOT EXISTS (
-        SELECT
-            1
-        FROM
-            auth_subjects
-        WHERE
-            cm_id = user_contact_methods.id)
+    id = $1
+`
+
+type FindOneCalSubRow struct {
+	ID         uuid.UUID
+	Name       string
+	UserID     uuid.UUID
+	Disabled   bool
+	ScheduleID uuid.NullUUID
+	RotationID uuid.NullUUID
+	ServiceID  uuid.NullUUID
+	Config     json.RawMessage
+	LastAccess sql.NullTime
+}
+
+func (q *Queries) FindOneCalSub(ctx context.Context, id uuid.UUID) (FindOneCalSubRow, error) {
+	row := q.db.QueryRowContext(ctx, findOneCalSub, id)
+	var i FindOneCalSubRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.Disabled,
+		&i.ScheduleID,
+		&i.RotationID,
+		&i.ServiceID,
+		&i.Config,
+		&i.LastAccess,
+	)
+	return i, err
+}
+
+const findOneCalSubForUpdate = `-- name: FindOneCalSubForUpdate :one
+SELECT
+    id,
+    NAME,
+    user_id,
+    disabled,
+    schedule_id,
+    rotation_id,
+    service_id,
+    config,
+    last_access
+FROM
+    user_calendar_subscriptions
+WHERE
+    id = $1
 FOR UPDATE
-    SKIP LOCKED
-LIMIT 10
 `
 
-type CompatCMMissingSubRow struct {
-	ID     uuid.UUID
-	UserID uuid.UUID
-	Value  string
+type FindOneCalSubForUpdateRow struct {
+	ID         uuid.UUID
+	Name       string
+	UserID     uuid.UUID
+	Disabled   bool
+	ScheduleID uuid.NullUUID
+	RotationID uuid.NullUUID
+	ServiceID  uuid.NullUUID
+	Config     json.RawMessage
+	LastAccess sql.NullTime
 }
 
-// Get up to 10 contact methods missing an auth_subjects link.
-func (q *Queries) CompatCMMissingSub(ctx context.Context) ([]CompatCMMissingSubRow, error) {
-	rows, err := q.db.QueryContext(ctx, compatCMMissingSub)
+func (q *Queries) FindOneCalSubForUpdate(ctx context.Context, id uuid.UUID) (FindOneCalSubForUpdateRow, error) {
+	row := q.db.QueryRowContext(ctx, findOneCalSubForUpdate, id)
+	var i FindOneCalSubForUpdateRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.Disabled,
+		&i.ScheduleID,
+		&i.RotationID,
+		&i.ServiceID,
+		&i.Config,
+		&i.LastAccess,
+	)
+	return i, err
+}
+
+const foreignKeyRefs = `-- name: ForeignKeyRefs :many
+SELECT src.relname::text,
+    dst.relname::text
+FROM pg_catalog.pg_constraint con
+    JOIN pg_catalog.pg_namespace ns ON ns.nspname = 'public'
+    AND ns.oid = con.connamespace
+    JOIN pg_catalog.pg_class src ON src.oid = con.conrelid
+    JOIN pg_catalog.pg_class dst ON dst.oid = con.confrelid
+WHERE con.contype = 'f'
+    AND NOT con.condeferrable
+`
+
+type ForeignKeyRefsRow struct {
+	SrcRelname string
+	DstRelname string
+}
+
+func (q *Queries) ForeignKeyRefs(ctx context.Context) ([]ForeignKeyRefsRow, error) {
+	rows, err := q.db.QueryContext(ctx, foreignKeyRefs)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []CompatCMMissingSubRow
+	var items []ForeignKeyRefsRow
 	for rows.Next() {
-		var i CompatCMMissingSubRow
-		if err := rows.Scan(&i.ID, &i.UserID, &i.Value); err != nil {
+		var i ForeignKeyRefsRow
+		if err := rows.Scan(&i.SrcRelname, &i.DstRelname); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -1601,82 +3659,47 @@ func (q *Queries) CompatCMMissingSub(ctx context.Context) ([]CompatCMMissingSubR
 	return items, nil
 }
 
-const compatInsertUserCM = `-- name: CompatInsertUserCM :exec
-INSERT INTO user_contact_methods(id, name, type, value, user_id, pending)
-    VALUES ($1, $2, $3, $4, $5, FALSE)
-ON CONFLICT (type, value)
-    DO NOTHING
-`
-
-type CompatInsertUserCMParams struct {
-	ID     uuid.UUID
-	Name   string
-	Type   EnumUserContactMethodType
-	Value  string
-	UserID uuid.UUID
-}
-
-// Inserts a new contact method for a user.
-func (q *Queries) CompatInsertUserCM(ctx context.Context, arg CompatInsertUserCMParams) error {
-	_, err := q.db.ExecContext(ctx, compatInsertUserCM,
-		arg.ID,
-		arg.Name,
-		arg.Type,
-		arg.Value,
-		arg.UserID,
-	)
-	return err
-}
-
-const compatUpsertAuthSubject = `-- name: CompatUpsertAuthSubject :exec
-INSERT INTO auth_subjects(user_id, subject_id, provider_id, cm_id)
-    VALUES ($1, $2, $3, $4)
-ON CONFLICT (subject_id, provider_id)
-    DO UPDATE SET
-        user_id = $1, cm_id = $4
-`
-
-type CompatUpsertAuthSubjectParams struct {
-	UserID     uuid.UUID
-	SubjectID  string
-	ProviderID string
-	CmID       uuid.NullUUID
-}
-
-// Inserts a new auth_subject for a user.
-func (q *Queries) CompatUpsertAuthSubject(ctx context.Context, arg CompatUpsertAuthSubjectParams) error {
-	_, err := q.db.ExecContext(ctx, compatUpsertAuthSubject,
-		arg.UserID,
-		arg.SubjectID,
-		arg.ProviderID,
-		arg.CmID,
-	)
-	return err
-}
-
-const connectionInfo = `-- name: ConnectionInfo :many
-SELECT application_name AS NAME,
-    COUNT(*)
-FROM pg_stat_activity
-WHERE datname = current_database()
-GROUP BY NAME
+const gQLUserOnCallOverview = `-- name: GQLUserOnCallOverview :many
+SELECT
+  svc.id AS service_id,
+  svc.name AS service_name,
+  ep.id AS policy_id,
+  ep.name AS policy_name,
+  step.step_number
+FROM
+  ep_step_on_call_users oc
+  JOIN escalation_policy_steps step ON step.id = oc.ep_step_id
+  JOIN escalation_policies ep ON ep.id = step.escalation_policy_id
+  JOIN services svc ON svc.escalation_policy_id = ep.id
+WHERE
+  oc.user_id = $1
+  AND oc.end_time IS NULL
 `
 
-type ConnectionInfoRow struct {
-	Name  sql.NullString
-	Count int64
+type GQLUserOnCallOverviewRow struct {
+	ServiceID   uuid.UUID
+	ServiceName string
+	PolicyID    uuid.UUID
+	PolicyName  string
+	StepNumber  int32
 }
 
-func (q *Queries) ConnectionInfo(ctx context.Context) ([]ConnectionInfoRow, error) {
-	rows, err := q.db.QueryContext(ctx, connectionInfo)
+func (q *Queries) GQLUserOnCallOverview(ctx context.Context, userID uuid.UUID) ([]GQLUserOnCallOverviewRow, error) {
+	rows, err := q.db.QueryContext(ctx, gQLUserOnCallOverview, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ConnectionInfoRow
+	var items []GQLUserOnCallOverviewRow
 	for rows.Next() {
-		var i ConnectionInfoRow
-		if err := rows.Scan(&i.Name, &i.Count); err != nil {
+		var i GQLUserOnCallOverviewRow
+		if err := rows.Scan(
+			&i.ServiceID,
+			&i.ServiceName,
+			&i.PolicyID,
+			&i.PolicyName,
+			&i.StepNumber,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -1690,85 +3713,32 @@ func (q *Queries) ConnectionInfo(ctx context.Context) ([]ConnectionInfoRow, erro
 	return items, nil
 }
 
-const contactMethodAdd = `-- name: ContactMethodAdd :exec
-INSERT INTO user_contact_methods(id, name, dest, disabled, user_id, enable_status_updates)
-    VALUES ($1, $2, $3, $4, $5, $6)
-`
-
-type ContactMethodAddParams struct {
-	ID                  uuid.UUID
-	Name                string
-	Dest                NullDestV1
-	Disabled            bool
-	UserID              uuid.UUID
-	EnableStatusUpdates bool
-}
-
-func (q *Queries) ContactMethodAdd(ctx context.Context, arg ContactMethodAddParams) error {
-	_, err := q.db.ExecContext(ctx, contactMethodAdd,
-		arg.ID,
-		arg.Name,
-		arg.Dest,
-		arg.Disabled,
-		arg.UserID,
-		arg.EnableStatusUpdates,
-	)
-	return err
-}
-
-const contactMethodEnableDisable = `-- name: ContactMethodEnableDisable :one
-UPDATE
-    user_contact_methods
-SET
-    disabled = $2
-WHERE
-    dest = $1
-RETURNING
-    id
-`
-
-type ContactMethodEnableDisableParams struct {
-	Dest     NullDestV1
-	Disabled bool
-}
-
-func (q *Queries) ContactMethodEnableDisable(ctx context.Context, arg ContactMethodEnableDisableParams) (uuid.UUID, error) {
-	row := q.db.QueryRowContext(ctx, contactMethodEnableDisable, arg.Dest, arg.Disabled)
-	var id uuid.UUID
-	err := row.Scan(&id)
-	return id, err
-}
-
-const contactMethodFindAll = `-- name: ContactMethodFindAll :many
+const graphQL_MessageStatusHistory = `-- name: GraphQL_MessageStatusHistory :many
 SELECT
-    dest, disabled, enable_status_updates, id, last_test_verify_at, metadata, name, pending, type, user_id, value
+  id, message_id, status, status_details, timestamp
 FROM
-    user_contact_methods
+  message_status_history
 WHERE
-    user_id = $1
+  message_id = $1
+ORDER BY
+  timestamp DESC
 `
 
-func (q *Queries) ContactMethodFindAll(ctx context.Context, userID uuid.UUID) ([]UserContactMethod, error) {
-	rows, err := q.db.QueryContext(ctx, contactMethodFindAll, userID)
+func (q *Queries) GraphQL_MessageStatusHistory(ctx context.Context, messageID uuid.UUID) ([]MessageStatusHistory, error) {
+	rows, err := q.db.QueryContext(ctx, graphQL_MessageStatusHistory, messageID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []UserContactMethod
+	var items []MessageStatusHistory
 	for rows.Next() {
-		var i UserContactMethod
+		var i MessageStatusHistory
 		if err := rows.Scan(
-			&i.Dest,
-			&i.Disabled,
-			&i.EnableStatusUpdates,
 			&i.ID,
-			&i.LastTestVerifyAt,
-			&i.Metadata,
-			&i.Name,
-			&i.Pending,
-			&i.Type,
-			&i.UserID,
-			&i.Value,
+			&i.MessageID,
+			&i.Status,
+			&i.StatusDetails,
+			&i.Timestamp,
 		); err != nil {
 			return nil, err
 		}
@@ -1783,36 +3753,61 @@ func (q *Queries) ContactMethodFindAll(ctx context.Context, userID uuid.UUID) ([
 	return items, nil
 }
 
-const contactMethodFindMany = `-- name: ContactMethodFindMany :many
+const hBByIDForUpdate = `-- name: HBByIDForUpdate :one
 SELECT
-    dest, disabled, enable_status_updates, id, last_test_verify_at, metadata, name, pending, type, user_id, value
+    additional_details, heartbeat_interval, id, last_heartbeat, last_state, muted, name, service_id
 FROM
-    user_contact_methods
+    heartbeat_monitors
 WHERE
-    id = ANY ($1::uuid[])
+    id = $1
+FOR UPDATE
 `
 
-func (q *Queries) ContactMethodFindMany(ctx context.Context, dollar_1 []uuid.UUID) ([]UserContactMethod, error) {
-	rows, err := q.db.QueryContext(ctx, contactMethodFindMany, pq.Array(dollar_1))
+// HBByIDForUpdate returns a single heartbeat record by ID for update.
+func (q *Queries) HBByIDForUpdate(ctx context.Context, id uuid.UUID) (HeartbeatMonitor, error) {
+	row := q.db.QueryRowContext(ctx, hBByIDForUpdate, id)
+	var i HeartbeatMonitor
+	err := row.Scan(
+		&i.AdditionalDetails,
+		&i.HeartbeatInterval,
+		&i.ID,
+		&i.LastHeartbeat,
+		&i.LastState,
+		&i.Muted,
+		&i.Name,
+		&i.ServiceID,
+	)
+	return i, err
+}
+
+const hBByService = `-- name: HBByService :many
+SELECT
+    additional_details, heartbeat_interval, id, last_heartbeat, last_state, muted, name, service_id
+FROM
+    heartbeat_monitors
+WHERE
+    service_id = $1
+`
+
+// HBByService returns all heartbeat records for a service.
+func (q *Queries) HBByService(ctx context.Context, serviceID uuid.UUID) ([]HeartbeatMonitor, error) {
+	rows, err := q.db.QueryContext(ctx, hBByService, serviceID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []UserContactMethod
+	var items []HeartbeatMonitor
 	for rows.Next() {
-		var i UserContactMethod
+		var i HeartbeatMonitor
 		if err := rows.Scan(
-			&i.Dest,
-			&i.Disabled,
-			&i.EnableStatusUpdates,
+			&i.AdditionalDetails,
+			&i.HeartbeatInterval,
 			&i.ID,
-			&i.LastTestVerifyAt,
-			&i.Metadata,
+			&i.LastHeartbeat,
+			&i.LastState,
+			&i.Muted,
 			&i.Name,
-			&i.Pending,
-			&i.Type,
-			&i.UserID,
-			&i.Value,
+			&i.ServiceID,
 		); err != nil {
 			return nil, err
 		}
@@ -1827,85 +3822,76 @@ func (q *Queries) ContactMethodFindMany(ctx context.Context, dollar_1 []uuid.UUI
 	return items, nil
 }
 
-const contactMethodFindOneUpdate = `-- name: ContactMethodFindOneUpdate :one
-SELECT
-    dest, disabled, enable_status_updates, id, last_test_verify_at, metadata, name, pending, type, user_id, value
-FROM
-    user_contact_methods
-WHERE
-    id = $1
-FOR UPDATE
+const hBDelete = `-- name: HBDelete :exec
+DELETE FROM heartbeat_monitors
+WHERE id = ANY ($1::uuid[])
 `
 
-func (q *Queries) ContactMethodFindOneUpdate(ctx context.Context, id uuid.UUID) (UserContactMethod, error) {
-	row := q.db.QueryRowContext(ctx, contactMethodFindOneUpdate, id)
-	var i UserContactMethod
-	err := row.Scan(
-		&i.Dest,
-		&i.Disabled,
-		&i.EnableStatusUpdates,
-		&i.ID,
-		&i.LastTestVerifyAt,
-		&i.Metadata,
-		&i.Name,
-		&i.Pending,
-		&i.Type,
-		&i.UserID,
-		&i.Value,
-	)
-	return i, err
+// HBDelete will delete a heartbeat record.
+func (q *Queries) HBDelete(ctx context.Context, id []uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, hBDelete, pq.Array(id))
+	return err
 }
 
-const contactMethodFineOne = `-- name: ContactMethodFineOne :one
-SELECT
-    dest, disabled, enable_status_updates, id, last_test_verify_at, metadata, name, pending, type, user_id, value
-FROM
-    user_contact_methods
-WHERE
-    id = $1
+const hBInsert = `-- name: HBInsert :exec
+INSERT INTO heartbeat_monitors(id, name, service_id, heartbeat_interval, additional_details, muted)
+    VALUES ($1, $2, $3, $4, $5, $6)
 `
 
-func (q *Queries) ContactMethodFineOne(ctx context.Context, id uuid.UUID) (UserContactMethod, error) {
-	row := q.db.QueryRowContext(ctx, contactMethodFineOne, id)
-	var i UserContactMethod
-	err := row.Scan(
-		&i.Dest,
-		&i.Disabled,
-		&i.EnableStatusUpdates,
-		&i.ID,
-		&i.LastTestVerifyAt,
-		&i.Metadata,
-		&i.Name,
-		&i.Pending,
-		&i.Type,
-		&i.UserID,
-		&i.Value,
+type HBInsertParams struct {
+	ID                uuid.UUID
+	Name              string
+	ServiceID         uuid.UUID
+	HeartbeatInterval sqlutil.Interval
+	AdditionalDetails sql.NullString
+	Muted             sql.NullString
+}
+
+// HBInsert will insert a new heartbeat record.
+func (q *Queries) HBInsert(ctx context.Context, arg HBInsertParams) error {
+	_, err := q.db.ExecContext(ctx, hBInsert,
+		arg.ID,
+		arg.Name,
+		arg.ServiceID,
+		arg.HeartbeatInterval,
+		arg.AdditionalDetails,
+		arg.Muted,
 	)
-	return i, err
+	return err
 }
 
-const contactMethodLookupUserID = `-- name: ContactMethodLookupUserID :many
-SELECT DISTINCT
-    user_id
+const hBManyByID = `-- name: HBManyByID :many
+SELECT
+    additional_details, heartbeat_interval, id, last_heartbeat, last_state, muted, name, service_id
 FROM
-    user_contact_methods
+    heartbeat_monitors
 WHERE
     id = ANY ($1::uuid[])
 `
 
-func (q *Queries) ContactMethodLookupUserID(ctx context.Context, dollar_1 []uuid.UUID) ([]uuid.UUID, error) {
-	rows, err := q.db.QueryContext(ctx, contactMethodLookupUserID, pq.Array(dollar_1))
+// HBManyByID returns multiple heartbeat records by their IDs.
+func (q *Queries) HBManyByID(ctx context.Context, ids []uuid.UUID) ([]HeartbeatMonitor, error) {
+	rows, err := q.db.QueryContext(ctx, hBManyByID, pq.Array(ids))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []uuid.UUID
+	var items []HeartbeatMonitor
 	for rows.Next() {
-		var user_id uuid.UUID
-		if err := rows.Scan(&user_id); err != nil {
+		var i HeartbeatMonitor
+		if err := rows.Scan(
+			&i.AdditionalDetails,
+			&i.HeartbeatInterval,
+			&i.ID,
+			&i.LastHeartbeat,
+			&i.LastState,
+			&i.Muted,
+			&i.Name,
+			&i.ServiceID,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, user_id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -1916,219 +3902,138 @@ func (q *Queries) ContactMethodLookupUserID(ctx context.Context, dollar_1 []uuid
 	return items, nil
 }
 
-const contactMethodMetaDest = `-- name: ContactMethodMetaDest :one
-SELECT
-    coalesce(metadata, '{}'),
-    now()::timestamptz AS now
-FROM
-    user_contact_methods
-WHERE
-    dest = $1
-`
-
-type ContactMethodMetaDestRow struct {
-	Metadata json.RawMessage
-	Now      time.Time
-}
-
-func (q *Queries) ContactMethodMetaDest(ctx context.Context, dest NullDestV1) (ContactMethodMetaDestRow, error) {
-	row := q.db.QueryRowContext(ctx, contactMethodMetaDest, dest)
-	var i ContactMethodMetaDestRow
-	err := row.Scan(&i.Metadata, &i.Now)
-	return i, err
-}
-
-const contactMethodUpdate = `-- name: ContactMethodUpdate :exec
+const hBRecordHeartbeat = `-- name: HBRecordHeartbeat :exec
 UPDATE
-    user_contact_methods
+    heartbeat_monitors
 SET
-    name = $2,
-    disabled = $3,
-    enable_status_updates = $4
+    last_heartbeat = now()
 WHERE
     id = $1
 `
 
-type ContactMethodUpdateParams struct {
-	ID                  uuid.UUID
-	Name                string
-	Disabled            bool
-	EnableStatusUpdates bool
-}
-
-func (q *Queries) ContactMethodUpdate(ctx context.Context, arg ContactMethodUpdateParams) error {
-	_, err := q.db.ExecContext(ctx, contactMethodUpdate,
-		arg.ID,
-		arg.Name,
-		arg.Disabled,
-		arg.EnableStatusUpdates,
-	)
+// HBRecordHeartbeat updates the last heartbeat time for a monitor.
+func (q *Queries) HBRecordHeartbeat(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, hBRecordHeartbeat, id)
 	return err
 }
 
-const contactMethodUpdateMetaDest = `-- name: ContactMethodUpdateMetaDest :exec
+const hBUpdate = `-- name: HBUpdate :exec
 UPDATE
-    user_contact_methods
+    heartbeat_monitors
 SET
-    metadata = jsonb_set(jsonb_set(metadata, '{CarrierV1}', $2::jsonb), '{CarrierV1,UpdatedAt}',('"' || NOW()::timestamptz AT TIME ZONE 'UTC' || '"')::jsonb)
+    name = $1,
+    heartbeat_interval = $2,
+    additional_details = $3,
+    muted = $4
 WHERE
-    dest = $1
-`
-
-type ContactMethodUpdateMetaDestParams struct {
-	Dest      NullDestV1
-	CarrierV1 json.RawMessage
-}
-
-func (q *Queries) ContactMethodUpdateMetaDest(ctx context.Context, arg ContactMethodUpdateMetaDestParams) error {
-	_, err := q.db.ExecContext(ctx, contactMethodUpdateMetaDest, arg.Dest, arg.CarrierV1)
-	return err
-}
-
-const createCalSub = `-- name: CreateCalSub :one
-INSERT INTO user_calendar_subscriptions(id, NAME, user_id, disabled, schedule_id, config)
-    VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING
-    created_at
+    id = $5
 `
 
-type CreateCalSubParams struct {
-	ID         uuid.UUID
-	Name       string
-	UserID     uuid.UUID
-	Disabled   bool
-	ScheduleID uuid.UUID
-	Config     json.RawMessage
+type HBUpdateParams struct {
+	Name              string
+	HeartbeatInterval sqlutil.Interval
+	AdditionalDetails sql.NullString
+	Muted             sql.NullString
+	ID                uuid.UUID
 }
 
-func (q *Queries) CreateCalSub(ctx context.Context, arg CreateCalSubParams) (time.Time, error) {
-	row := q.db.QueryRowContext(ctx, createCalSub,
-		arg.ID,
+// HBUpdate will update a heartbeat record.
+func (q *Queries) HBUpdate(ctx context.Context, arg HBUpdateParams) error {
+	_, err := q.db.ExecContext(ctx, hBUpdate,
 		arg.Name,
-		arg.UserID,
-		arg.Disabled,
-		arg.ScheduleID,
-		arg.Config,
+		arg.HeartbeatInterval,
+		arg.AdditionalDetails,
+		arg.Muted,
+		arg.ID,
 	)
-	var created_at time.Time
-	err := row.Scan(&created_at)
-	return created_at, err
+	return err
 }
 
-const databaseInfo = `-- name: DatabaseInfo :one
-SELECT db_id AS id,
-    version()
-FROM switchover_state
+const holidayCalAddScheduleCalendars = `-- name: HolidayCalAddScheduleCalendars :exec
+INSERT INTO schedule_holiday_calendars(schedule_id, calendar_id)
+SELECT
+    $1,
+    unnest($2::uuid[])
+ON CONFLICT
+    DO NOTHING
 `
 
-type DatabaseInfoRow struct {
-	ID      uuid.UUID
-	Version string
-}
-
-func (q *Queries) DatabaseInfo(ctx context.Context) (DatabaseInfoRow, error) {
-	row := q.db.QueryRowContext(ctx, databaseInfo)
-	var i DatabaseInfoRow
-	err := row.Scan(&i.ID, &i.Version)
-	return i, err
+type HolidayCalAddScheduleCalendarsParams struct {
+	ScheduleID  uuid.UUID
+	CalendarIds []uuid.UUID
 }
 
-const deleteContactMethod = `-- name: DeleteContactMethod :exec
-DELETE FROM user_contact_methods
-WHERE id = ANY ($1::uuid[])
-`
-
-func (q *Queries) DeleteContactMethod(ctx context.Context, dollar_1 []uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteContactMethod, pq.Array(dollar_1))
+func (q *Queries) HolidayCalAddScheduleCalendars(ctx context.Context, arg HolidayCalAddScheduleCalendarsParams) error {
+	_, err := q.db.ExecContext(ctx, holidayCalAddScheduleCalendars, arg.ScheduleID, pq.Array(arg.CalendarIds))
 	return err
 }
 
-const deleteManyCalSub = `-- name: DeleteManyCalSub :exec
-DELETE FROM user_calendar_subscriptions
-WHERE id = ANY ($1::uuid[])
-    AND user_id = $2
+const holidayCalClearScheduleCalendars = `-- name: HolidayCalClearScheduleCalendars :exec
+DELETE FROM schedule_holiday_calendars
+WHERE schedule_id = $1
+    AND calendar_id <> ALL ($2::uuid[])
 `
 
-type DeleteManyCalSubParams struct {
-	Column1 []uuid.UUID
-	UserID  uuid.UUID
-}
-
-func (q *Queries) DeleteManyCalSub(ctx context.Context, arg DeleteManyCalSubParams) error {
-	_, err := q.db.ExecContext(ctx, deleteManyCalSub, pq.Array(arg.Column1), arg.UserID)
-	return err
+type HolidayCalClearScheduleCalendarsParams struct {
+	ScheduleID  uuid.UUID
+	CalendarIds []uuid.UUID
 }
 
-const disableChangeLogTriggers = `-- name: DisableChangeLogTriggers :exec
-UPDATE switchover_state
-SET current_state = 'idle'
-WHERE current_state = 'in_progress'
-`
-
-func (q *Queries) DisableChangeLogTriggers(ctx context.Context) error {
-	_, err := q.db.ExecContext(ctx, disableChangeLogTriggers)
+func (q *Queries) HolidayCalClearScheduleCalendars(ctx context.Context, arg HolidayCalClearScheduleCalendarsParams) error {
+	_, err := q.db.ExecContext(ctx, holidayCalClearScheduleCalendars, arg.ScheduleID, pq.Array(arg.CalendarIds))
 	return err
 }
 
-const ePStepActionsAddAction = `-- name: EPStepActionsAddAction :exec
-INSERT INTO escalation_policy_actions(escalation_policy_step_id, user_id, schedule_id, rotation_id, channel_id)
-    VALUES ($1, $2, $3, $4, $5)
+const holidayCalCreate = `-- name: HolidayCalCreate :exec
+INSERT INTO holiday_calendars(id, name, source_url)
+    VALUES ($1, $2, $3)
 `
 
-type EPStepActionsAddActionParams struct {
-	EscalationPolicyStepID uuid.UUID
-	UserID                 uuid.NullUUID
-	ScheduleID             uuid.NullUUID
-	RotationID             uuid.NullUUID
-	ChannelID              uuid.NullUUID
+type HolidayCalCreateParams struct {
+	ID        uuid.UUID
+	Name      string
+	SourceUrl sql.NullString
 }
 
-func (q *Queries) EPStepActionsAddAction(ctx context.Context, arg EPStepActionsAddActionParams) error {
-	_, err := q.db.ExecContext(ctx, ePStepActionsAddAction,
-		arg.EscalationPolicyStepID,
-		arg.UserID,
-		arg.ScheduleID,
-		arg.RotationID,
-		arg.ChannelID,
-	)
+func (q *Queries) HolidayCalCreate(ctx context.Context, arg HolidayCalCreateParams) error {
+	_, err := q.db.ExecContext(ctx, holidayCalCreate, arg.ID, arg.Name, arg.SourceUrl)
 	return err
 }
 
-const ePStepActionsByStepId = `-- name: EPStepActionsByStepId :many
+const holidayCalDates = `-- name: HolidayCalDates :many
 SELECT
-    a.user_id,
-    a.schedule_id,
-    a.rotation_id,
-    ch.dest
+    date,
+    name
 FROM
-    escalation_policy_actions a
-    LEFT JOIN notification_channels ch ON a.channel_id = ch.id
+    holiday_calendar_dates
 WHERE
-    a.escalation_policy_step_id = $1
+    calendar_id = $1
+    AND date BETWEEN $2::date AND $3::date
+ORDER BY
+    date
 `
 
-type EPStepActionsByStepIdRow struct {
-	UserID     uuid.NullUUID
-	ScheduleID uuid.NullUUID
-	RotationID uuid.NullUUID
-	Dest       NullDestV1
+type HolidayCalDatesParams struct {
+	CalendarID uuid.UUID
+	StartDate  time.Time
+	EndDate    time.Time
 }
 
-func (q *Queries) EPStepActionsByStepId(ctx context.Context, escalationPolicyStepID uuid.UUID) ([]EPStepActionsByStepIdRow, error) {
-	rows, err := q.db.QueryContext(ctx, ePStepActionsByStepId, escalationPolicyStepID)
+type HolidayCalDatesRow struct {
+	Date time.Time
+	Name string
+}
+
+func (q *Queries) HolidayCalDates(ctx context.Context, arg HolidayCalDatesParams) ([]HolidayCalDatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, holidayCalDates, arg.CalendarID, arg.StartDate, arg.EndDate)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []EPStepActionsByStepIdRow
+	var items []HolidayCalDatesRow
 	for rows.Next() {
-		var i EPStepActionsByStepIdRow
-		if err := rows.Scan(
-			&i.UserID,
-			&i.ScheduleID,
-			&i.RotationID,
-			&i.Dest,
-		); err != nil {
+		var i HolidayCalDatesRow
+		if err := rows.Scan(&i.Date, &i.Name); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -2142,129 +4047,88 @@ func (q *Queries) EPStepActionsByStepId(ctx context.Context, escalationPolicySte
 	return items, nil
 }
 
-const ePStepActionsDeleteAction = `-- name: EPStepActionsDeleteAction :exec
-DELETE FROM escalation_policy_actions
-WHERE escalation_policy_step_id = $1
-    AND (user_id = $2
-        OR schedule_id = $3
-        OR rotation_id = $4
-        OR channel_id = $5)
-`
-
-type EPStepActionsDeleteActionParams struct {
-	EscalationPolicyStepID uuid.UUID
-	UserID                 uuid.NullUUID
-	ScheduleID             uuid.NullUUID
-	RotationID             uuid.NullUUID
-	ChannelID              uuid.NullUUID
-}
-
-func (q *Queries) EPStepActionsDeleteAction(ctx context.Context, arg EPStepActionsDeleteActionParams) error {
-	_, err := q.db.ExecContext(ctx, ePStepActionsDeleteAction,
-		arg.EscalationPolicyStepID,
-		arg.UserID,
-		arg.ScheduleID,
-		arg.RotationID,
-		arg.ChannelID,
-	)
-	return err
-}
-
-const enableChangeLogTriggers = `-- name: EnableChangeLogTriggers :exec
-UPDATE switchover_state
-SET current_state = 'in_progress'
-WHERE current_state = 'idle'
+const holidayCalDelete = `-- name: HolidayCalDelete :exec
+DELETE FROM holiday_calendars
+WHERE id = ANY ($1::uuid[])
 `
 
-func (q *Queries) EnableChangeLogTriggers(ctx context.Context) error {
-	_, err := q.db.ExecContext(ctx, enableChangeLogTriggers)
+func (q *Queries) HolidayCalDelete(ctx context.Context, ids []uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, holidayCalDelete, pq.Array(ids))
 	return err
 }
 
-const engineGetSignalParams = `-- name: EngineGetSignalParams :one
-SELECT
-    params
-FROM
-    pending_signals
-WHERE
-    message_id = $1
+const holidayCalDeleteDates = `-- name: HolidayCalDeleteDates :exec
+DELETE FROM holiday_calendar_dates
+WHERE calendar_id = $1
 `
-
-// Get a pending signal's rendered params.
-func (q *Queries) EngineGetSignalParams(ctx context.Context, messageID uuid.NullUUID) (json.RawMessage, error) {
-	row := q.db.QueryRowContext(ctx, engineGetSignalParams, messageID)
-	var params json.RawMessage
-	err := row.Scan(&params)
-	return params, err
+
+func (q *Queries) HolidayCalDeleteDates(ctx context.Context, calendarID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, holidayCalDeleteDates, calendarID)
+	return err
 }
 
-const engineIsKnownDest = `-- name: EngineIsKnownDest :one
+const holidayCalFindAll = `-- name: HolidayCalFindAll :many
 SELECT
-    EXISTS (
-        SELECT
-        FROM
-            user_contact_methods uc
-        WHERE
-            uc.dest = $1
-            AND uc.disabled = FALSE)
-    OR EXISTS (
-        SELECT
-        FROM
-            notification_channels nc
-        WHERE
-            nc.dest = $1)
+    created_at, id, imported_at, name, source_url
+FROM
+    holiday_calendars
+ORDER BY
+    lower(name)
 `
 
-// Check if a destination is known in user_contact_methods or notification_channels table.
-func (q *Queries) EngineIsKnownDest(ctx context.Context, dest NullDestV1) (sql.NullBool, error) {
-	row := q.db.QueryRowContext(ctx, engineIsKnownDest, dest)
-	var column_1 sql.NullBool
-	err := row.Scan(&column_1)
-	return column_1, err
+func (q *Queries) HolidayCalFindAll(ctx context.Context) ([]HolidayCalendar, error) {
+	rows, err := q.db.QueryContext(ctx, holidayCalFindAll)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []HolidayCalendar
+	for rows.Next() {
+		var i HolidayCalendar
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.ID,
+			&i.ImportedAt,
+			&i.Name,
+			&i.SourceUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const findManyCalSubByUser = `-- name: FindManyCalSubByUser :many
+const holidayCalFindMany = `-- name: HolidayCalFindMany :many
 SELECT
-    id,
-    NAME,
-    user_id,
-    disabled,
-    schedule_id,
-    config,
-    last_access
+    created_at, id, imported_at, name, source_url
 FROM
-    user_calendar_subscriptions
+    holiday_calendars
 WHERE
-    user_id = $1
+    id = ANY ($1::uuid[])
 `
 
-type FindManyCalSubByUserRow struct {
-	ID         uuid.UUID
-	Name       string
-	UserID     uuid.UUID
-	Disabled   bool
-	ScheduleID uuid.UUID
-	Config     json.RawMessage
-	LastAccess sql.NullTime
-}
-
-func (q *Queries) FindManyCalSubByUser(ctx context.Context, userID uuid.UUID) ([]FindManyCalSubByUserRow, error) {
-	rows, err := q.db.QueryContext(ctx, findManyCalSubByUser, userID)
+func (q *Queries) HolidayCalFindMany(ctx context.Context, ids []uuid.UUID) ([]HolidayCalendar, error) {
+	rows, err := q.db.QueryContext(ctx, holidayCalFindMany, pq.Array(ids))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []FindManyCalSubByUserRow
+	var items []HolidayCalendar
 	for rows.Next() {
-		var i FindManyCalSubByUserRow
+		var i HolidayCalendar
 		if err := rows.Scan(
+			&i.CreatedAt,
 			&i.ID,
+			&i.ImportedAt,
 			&i.Name,
-			&i.UserID,
-			&i.Disabled,
-			&i.ScheduleID,
-			&i.Config,
-			&i.LastAccess,
+			&i.SourceUrl,
 		); err != nil {
 			return nil, err
 		}
@@ -2279,114 +4143,102 @@ func (q *Queries) FindManyCalSubByUser(ctx context.Context, userID uuid.UUID) ([
 	return items, nil
 }
 
-const findOneCalSub = `-- name: FindOneCalSub :one
+const holidayCalFindOne = `-- name: HolidayCalFindOne :one
 SELECT
-    id,
-    NAME,
-    user_id,
-    disabled,
-    schedule_id,
-    config,
-    last_access
+    created_at, id, imported_at, name, source_url
 FROM
-    user_calendar_subscriptions
+    holiday_calendars
 WHERE
     id = $1
 `
 
-type FindOneCalSubRow struct {
-	ID         uuid.UUID
-	Name       string
-	UserID     uuid.UUID
-	Disabled   bool
-	ScheduleID uuid.UUID
-	Config     json.RawMessage
-	LastAccess sql.NullTime
-}
-
-func (q *Queries) FindOneCalSub(ctx context.Context, id uuid.UUID) (FindOneCalSubRow, error) {
-	row := q.db.QueryRowContext(ctx, findOneCalSub, id)
-	var i FindOneCalSubRow
+func (q *Queries) HolidayCalFindOne(ctx context.Context, id uuid.UUID) (HolidayCalendar, error) {
+	row := q.db.QueryRowContext(ctx, holidayCalFindOne, id)
+	var i HolidayCalendar
 	err := row.Scan(
+		&i.CreatedAt,
 		&i.ID,
+		&i.ImportedAt,
 		&i.Name,
-		&i.UserID,
-		&i.Disabled,
-		&i.ScheduleID,
-		&i.Config,
-		&i.LastAccess,
+		&i.SourceUrl,
 	)
 	return i, err
 }
 
-const findOneCalSubForUpdate = `-- name: FindOneCalSubForUpdate :one
+const holidayCalFindOneForUpdate = `-- name: HolidayCalFindOneForUpdate :one
 SELECT
-    id,
-    NAME,
-    user_id,
-    disabled,
-    schedule_id,
-    config,
-    last_access
+    created_at, id, imported_at, name, source_url
 FROM
-    user_calendar_subscriptions
+    holiday_calendars
 WHERE
     id = $1
 FOR UPDATE
 `
 
-type FindOneCalSubForUpdateRow struct {
-	ID         uuid.UUID
-	Name       string
-	UserID     uuid.UUID
-	Disabled   bool
-	ScheduleID uuid.UUID
-	Config     json.RawMessage
-	LastAccess sql.NullTime
-}
-
-func (q *Queries) FindOneCalSubForUpdate(ctx context.Context, id uuid.UUID) (FindOneCalSubForUpdateRow, error) {
-	row := q.db.QueryRowContext(ctx, findOneCalSubForUpdate, id)
-	var i FindOneCalSubForUpdateRow
+func (q *Queries) HolidayCalFindOneForUpdate(ctx context.Context, id uuid.UUID) (HolidayCalendar, error) {
+	row := q.db.QueryRowContext(ctx, holidayCalFindOneForUpdate, id)
+	var i HolidayCalendar
 	err := row.Scan(
+		&i.CreatedAt,
 		&i.ID,
+		&i.ImportedAt,
 		&i.Name,
-		&i.UserID,
-		&i.Disabled,
-		&i.ScheduleID,
-		&i.Config,
-		&i.LastAccess,
+		&i.SourceUrl,
 	)
 	return i, err
 }
 
-const foreignKeyRefs = `-- name: ForeignKeyRefs :many
-SELECT src.relname::text,
-    dst.relname::text
-FROM pg_catalog.pg_constraint con
-    JOIN pg_catalog.pg_namespace ns ON ns.nspname = 'public'
-    AND ns.oid = con.connamespace
-    JOIN pg_catalog.pg_class src ON src.oid = con.conrelid
-    JOIN pg_catalog.pg_class dst ON dst.oid = con.confrelid
-WHERE con.contype = 'f'
-    AND NOT con.condeferrable
+const holidayCalInsertDates = `-- name: HolidayCalInsertDates :exec
+INSERT INTO holiday_calendar_dates(calendar_id, date, name)
+SELECT
+    $1,
+    unnest($2::date[]),
+    unnest($3::text[])
 `
 
-type ForeignKeyRefsRow struct {
-	SrcRelname string
-	DstRelname string
+type HolidayCalInsertDatesParams struct {
+	CalendarID uuid.UUID
+	Dates      []time.Time
+	Names      []string
 }
 
-func (q *Queries) ForeignKeyRefs(ctx context.Context) ([]ForeignKeyRefsRow, error) {
-	rows, err := q.db.QueryContext(ctx, foreignKeyRefs)
+func (q *Queries) HolidayCalInsertDates(ctx context.Context, arg HolidayCalInsertDatesParams) error {
+	_, err := q.db.ExecContext(ctx, holidayCalInsertDates, arg.CalendarID, pq.Array(arg.Dates), pq.Array(arg.Names))
+	return err
+}
+
+const holidayCalScheduleCalendars = `-- name: HolidayCalScheduleCalendars :many
+SELECT
+    cal.created_at, cal.id, cal.imported_at, cal.name, cal.source_url
+FROM
+    schedule_holiday_calendars sched
+    JOIN holiday_calendars cal ON cal.id = sched.calendar_id
+WHERE
+    sched.schedule_id = $1
+ORDER BY
+    lower(cal.name)
+`
+
+type HolidayCalScheduleCalendarsRow struct {
+	HolidayCalendar HolidayCalendar
+}
+
+func (q *Queries) HolidayCalScheduleCalendars(ctx context.Context, scheduleID uuid.UUID) ([]HolidayCalScheduleCalendarsRow, error) {
+	rows, err := q.db.QueryContext(ctx, holidayCalScheduleCalendars, scheduleID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ForeignKeyRefsRow
+	var items []HolidayCalScheduleCalendarsRow
 	for rows.Next() {
-		var i ForeignKeyRefsRow
-		if err := rows.Scan(&i.SrcRelname, &i.DstRelname); err != nil {
+		var i HolidayCalScheduleCalendarsRow
+		if err := rows.Scan(
+			&i.HolidayCalendar.CreatedAt,
+			&i.HolidayCalendar.ID,
+			&i.HolidayCalendar.ImportedAt,
+			&i.HolidayCalendar.Name,
+			&i.HolidayCalendar.SourceUrl,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -2400,50 +4252,123 @@ func (q *Queries) ForeignKeyRefs(ctx context.Context) ([]ForeignKeyRefsRow, erro
 	return items, nil
 }
 
-const gQLUserOnCallOverview = `-- name: GQLUserOnCallOverview :many
-SELECT
-  svc.id AS service_id,
-  svc.name AS service_name,
-  ep.id AS policy_id,
-  ep.name AS policy_name,
-  step.step_number
-FROM
-  ep_step_on_call_users oc
-  JOIN escalation_policy_steps step ON step.id = oc.ep_step_id
-  JOIN escalation_policies ep ON ep.id = step.escalation_policy_id
-  JOIN services svc ON svc.escalation_policy_id = ep.id
+const holidayCalSetImported = `-- name: HolidayCalSetImported :exec
+UPDATE
+    holiday_calendars
+SET
+    imported_at = now()
 WHERE
-  oc.user_id = $1
-  AND oc.end_time IS NULL
+    id = $1
 `
 
-type GQLUserOnCallOverviewRow struct {
-	ServiceID   uuid.UUID
-	ServiceName string
-	PolicyID    uuid.UUID
-	PolicyName  string
-	StepNumber  int32
+func (q *Queries) HolidayCalSetImported(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, holidayCalSetImported, id)
+	return err
 }
 
-func (q *Queries) GQLUserOnCallOverview(ctx context.Context, userID uuid.UUID) ([]GQLUserOnCallOverviewRow, error) {
-	rows, err := q.db.QueryContext(ctx, gQLUserOnCallOverview, userID)
+const holidayCalUpdate = `-- name: HolidayCalUpdate :exec
+UPDATE
+    holiday_calendars
+SET
+    name = $2,
+    source_url = $3
+WHERE
+    id = $1
+`
+
+type HolidayCalUpdateParams struct {
+	ID        uuid.UUID
+	Name      string
+	SourceUrl sql.NullString
+}
+
+func (q *Queries) HolidayCalUpdate(ctx context.Context, arg HolidayCalUpdateParams) error {
+	_, err := q.db.ExecContext(ctx, holidayCalUpdate, arg.ID, arg.Name, arg.SourceUrl)
+	return err
+}
+
+const incidentAddAlerts = `-- name: IncidentAddAlerts :exec
+INSERT INTO incident_alerts(incident_id, alert_id)
+SELECT
+    $1,
+    unnest($2::bigint[])
+ON CONFLICT (alert_id)
+    DO UPDATE SET
+        incident_id = excluded.incident_id, created_at = now()
+`
+
+type IncidentAddAlertsParams struct {
+	IncidentID int64
+	AlertIds   []int64
+}
+
+// IncidentAddAlerts attaches alerts to an incident, moving them from any incident they were previously attached to.
+func (q *Queries) IncidentAddAlerts(ctx context.Context, arg IncidentAddAlertsParams) error {
+	_, err := q.db.ExecContext(ctx, incidentAddAlerts, arg.IncidentID, pq.Array(arg.AlertIds))
+	return err
+}
+
+const incidentAlertIDs = `-- name: IncidentAlertIDs :many
+SELECT
+    alert_id
+FROM
+    incident_alerts
+WHERE
+    incident_id = $1
+ORDER BY
+    alert_id
+`
+
+func (q *Queries) IncidentAlertIDs(ctx context.Context, incidentID int64) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, incidentAlertIDs, incidentID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GQLUserOnCallOverviewRow
+	var items []int64
 	for rows.Next() {
-		var i GQLUserOnCallOverviewRow
-		if err := rows.Scan(
-			&i.ServiceID,
-			&i.ServiceName,
-			&i.PolicyID,
-			&i.PolicyName,
-			&i.StepNumber,
-		); err != nil {
+		var alert_id int64
+		if err := rows.Scan(&alert_id); err != nil {
+			return nil, err
+		}
+		items = append(items, alert_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const incidentAlertIDsForStatus = `-- name: IncidentAlertIDsForStatus :many
+SELECT
+    ia.alert_id
+FROM
+    incident_alerts ia
+    JOIN alerts a ON a.id = ia.alert_id
+WHERE
+    ia.incident_id = $1
+    AND a.status != 'closed'
+ORDER BY
+    ia.alert_id
+`
+
+// IncidentAlertIDsForStatus returns the IDs of member alerts that are not closed.
+func (q *Queries) IncidentAlertIDsForStatus(ctx context.Context, incidentID int64) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, incidentAlertIDsForStatus, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var alert_id int64
+		if err := rows.Scan(&alert_id); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, alert_id)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -2454,33 +4379,31 @@ func (q *Queries) GQLUserOnCallOverview(ctx context.Context, userID uuid.UUID) (
 	return items, nil
 }
 
-const graphQL_MessageStatusHistory = `-- name: GraphQL_MessageStatusHistory :many
+const incidentByAlertIDs = `-- name: IncidentByAlertIDs :many
 SELECT
-  id, message_id, status, status_details, timestamp
+    alert_id,
+    incident_id
 FROM
-  message_status_history
+    incident_alerts
 WHERE
-  message_id = $1
-ORDER BY
-  timestamp DESC
+    alert_id = ANY ($1::bigint[])
 `
 
-func (q *Queries) GraphQL_MessageStatusHistory(ctx context.Context, messageID uuid.UUID) ([]MessageStatusHistory, error) {
-	rows, err := q.db.QueryContext(ctx, graphQL_MessageStatusHistory, messageID)
+type IncidentByAlertIDsRow struct {
+	AlertID    int64
+	IncidentID int64
+}
+
+func (q *Queries) IncidentByAlertIDs(ctx context.Context, alertIds []int64) ([]IncidentByAlertIDsRow, error) {
+	rows, err := q.db.QueryContext(ctx, incidentByAlertIDs, pq.Array(alertIds))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []MessageStatusHistory
+	var items []IncidentByAlertIDsRow
 	for rows.Next() {
-		var i MessageStatusHistory
-		if err := rows.Scan(
-			&i.ID,
-			&i.MessageID,
-			&i.Status,
-			&i.StatusDetails,
-			&i.Timestamp,
-		); err != nil {
+		var i IncidentByAlertIDsRow
+		if err := rows.Scan(&i.AlertID, &i.IncidentID); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -2494,61 +4417,54 @@ func (q *Queries) GraphQL_MessageStatusHistory(ctx context.Context, messageID uu
 	return items, nil
 }
 
-const hBByIDForUpdate = `-- name: HBByIDForUpdate :one
-SELECT
-    additional_details, heartbeat_interval, id, last_heartbeat, last_state, muted, name, service_id
-FROM
-    heartbeat_monitors
-WHERE
-    id = $1
-FOR UPDATE
+const incidentCreate = `-- name: IncidentCreate :one
+INSERT INTO incidents(summary, details)
+    VALUES ($1, $2)
+RETURNING
+    id, created_at
 `
 
-// HBByIDForUpdate returns a single heartbeat record by ID for update.
-func (q *Queries) HBByIDForUpdate(ctx context.Context, id uuid.UUID) (HeartbeatMonitor, error) {
-	row := q.db.QueryRowContext(ctx, hBByIDForUpdate, id)
-	var i HeartbeatMonitor
-	err := row.Scan(
-		&i.AdditionalDetails,
-		&i.HeartbeatInterval,
-		&i.ID,
-		&i.LastHeartbeat,
-		&i.LastState,
-		&i.Muted,
-		&i.Name,
-		&i.ServiceID,
-	)
+type IncidentCreateParams struct {
+	Summary string
+	Details string
+}
+
+type IncidentCreateRow struct {
+	ID        int64
+	CreatedAt time.Time
+}
+
+func (q *Queries) IncidentCreate(ctx context.Context, arg IncidentCreateParams) (IncidentCreateRow, error) {
+	row := q.db.QueryRowContext(ctx, incidentCreate, arg.Summary, arg.Details)
+	var i IncidentCreateRow
+	err := row.Scan(&i.ID, &i.CreatedAt)
 	return i, err
 }
 
-const hBByService = `-- name: HBByService :many
+const incidentFindMany = `-- name: IncidentFindMany :many
 SELECT
-    additional_details, heartbeat_interval, id, last_heartbeat, last_state, muted, name, service_id
+    created_at, details, id, status, summary
 FROM
-    heartbeat_monitors
+    incidents
 WHERE
-    service_id = $1
+    id = ANY ($1::bigint[])
 `
 
-// HBByService returns all heartbeat records for a service.
-func (q *Queries) HBByService(ctx context.Context, serviceID uuid.UUID) ([]HeartbeatMonitor, error) {
-	rows, err := q.db.QueryContext(ctx, hBByService, serviceID)
+func (q *Queries) IncidentFindMany(ctx context.Context, ids []int64) ([]Incident, error) {
+	rows, err := q.db.QueryContext(ctx, incidentFindMany, pq.Array(ids))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []HeartbeatMonitor
+	var items []Incident
 	for rows.Next() {
-		var i HeartbeatMonitor
+		var i Incident
 		if err := rows.Scan(
-			&i.AdditionalDetails,
-			&i.HeartbeatInterval,
+			&i.CreatedAt,
+			&i.Details,
 			&i.ID,
-			&i.LastHeartbeat,
-			&i.LastState,
-			&i.Muted,
-			&i.Name,
-			&i.ServiceID,
+			&i.Status,
+			&i.Summary,
 		); err != nil {
 			return nil, err
 		}
@@ -2563,72 +4479,88 @@ func (q *Queries) HBByService(ctx context.Context, serviceID uuid.UUID) ([]Heart
 	return items, nil
 }
 
-const hBDelete = `-- name: HBDelete :exec
-DELETE FROM heartbeat_monitors
-WHERE id = ANY ($1::uuid[])
+const incidentFindOneForUpdate = `-- name: IncidentFindOneForUpdate :one
+SELECT
+    created_at, details, id, status, summary
+FROM
+    incidents
+WHERE
+    id = $1
+FOR UPDATE
 `
 
-// HBDelete will delete a heartbeat record.
-func (q *Queries) HBDelete(ctx context.Context, id []uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, hBDelete, pq.Array(id))
-	return err
+func (q *Queries) IncidentFindOneForUpdate(ctx context.Context, id int64) (Incident, error) {
+	row := q.db.QueryRowContext(ctx, incidentFindOneForUpdate, id)
+	var i Incident
+	err := row.Scan(
+		&i.CreatedAt,
+		&i.Details,
+		&i.ID,
+		&i.Status,
+		&i.Summary,
+	)
+	return i, err
 }
 
-const hBInsert = `-- name: HBInsert :exec
-INSERT INTO heartbeat_monitors(id, name, service_id, heartbeat_interval, additional_details, muted)
-    VALUES ($1, $2, $3, $4, $5, $6)
+const incidentRemoveAlerts = `-- name: IncidentRemoveAlerts :exec
+DELETE FROM incident_alerts
+WHERE incident_id = $1
+    AND alert_id = ANY ($2::bigint[])
 `
 
-type HBInsertParams struct {
-	ID                uuid.UUID
-	Name              string
-	ServiceID         uuid.UUID
-	HeartbeatInterval sqlutil.Interval
-	AdditionalDetails sql.NullString
-	Muted             sql.NullString
+type IncidentRemoveAlertsParams struct {
+	IncidentID int64
+	AlertIds   []int64
 }
 
-// HBInsert will insert a new heartbeat record.
-func (q *Queries) HBInsert(ctx context.Context, arg HBInsertParams) error {
-	_, err := q.db.ExecContext(ctx, hBInsert,
-		arg.ID,
-		arg.Name,
-		arg.ServiceID,
-		arg.HeartbeatInterval,
-		arg.AdditionalDetails,
-		arg.Muted,
-	)
+func (q *Queries) IncidentRemoveAlerts(ctx context.Context, arg IncidentRemoveAlertsParams) error {
+	_, err := q.db.ExecContext(ctx, incidentRemoveAlerts, arg.IncidentID, pq.Array(arg.AlertIds))
 	return err
 }
 
-const hBManyByID = `-- name: HBManyByID :many
+const incidentSearch = `-- name: IncidentSearch :many
 SELECT
-    additional_details, heartbeat_interval, id, last_heartbeat, last_state, muted, name, service_id
+    created_at, details, id, status, summary
 FROM
-    heartbeat_monitors
-WHERE
-    id = ANY ($1::uuid[])
+    incidents
+WHERE (NOT $1::bool
+    OR status != 'closed')
+AND ($2::bigint = 0
+    OR id < $2)
+AND ($3::text = ''
+    OR summary ILIKE '%' || $3 || '%')
+ORDER BY
+    id DESC
+LIMIT $4
 `
 
-// HBManyByID returns multiple heartbeat records by their IDs.
-func (q *Queries) HBManyByID(ctx context.Context, ids []uuid.UUID) ([]HeartbeatMonitor, error) {
-	rows, err := q.db.QueryContext(ctx, hBManyByID, pq.Array(ids))
+type IncidentSearchParams struct {
+	OmitClosed bool
+	AfterID    int64
+	Search     string
+	MaxResults int32
+}
+
+func (q *Queries) IncidentSearch(ctx context.Context, arg IncidentSearchParams) ([]Incident, error) {
+	rows, err := q.db.QueryContext(ctx, incidentSearch,
+		arg.OmitClosed,
+		arg.AfterID,
+		arg.Search,
+		arg.MaxResults,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []HeartbeatMonitor
+	var items []Incident
 	for rows.Next() {
-		var i HeartbeatMonitor
+		var i Incident
 		if err := rows.Scan(
-			&i.AdditionalDetails,
-			&i.HeartbeatInterval,
+			&i.CreatedAt,
+			&i.Details,
 			&i.ID,
-			&i.LastHeartbeat,
-			&i.LastState,
-			&i.Muted,
-			&i.Name,
-			&i.ServiceID,
+			&i.Status,
+			&i.Summary,
 		); err != nil {
 			return nil, err
 		}
@@ -2643,50 +4575,45 @@ func (q *Queries) HBManyByID(ctx context.Context, ids []uuid.UUID) ([]HeartbeatM
 	return items, nil
 }
 
-const hBRecordHeartbeat = `-- name: HBRecordHeartbeat :exec
+const incidentSetStatus = `-- name: IncidentSetStatus :exec
 UPDATE
-    heartbeat_monitors
+    incidents
 SET
-    last_heartbeat = now()
+    status = $1
 WHERE
-    id = $1
+    id = $2
+    AND status != 'closed'
 `
 
-// HBRecordHeartbeat updates the last heartbeat time for a monitor.
-func (q *Queries) HBRecordHeartbeat(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, hBRecordHeartbeat, id)
+type IncidentSetStatusParams struct {
+	Status EnumAlertStatus
+	ID     int64
+}
+
+// IncidentSetStatus updates the status of an incident. Closed incidents can't be reopened.
+func (q *Queries) IncidentSetStatus(ctx context.Context, arg IncidentSetStatusParams) error {
+	_, err := q.db.ExecContext(ctx, incidentSetStatus, arg.Status, arg.ID)
 	return err
 }
 
-const hBUpdate = `-- name: HBUpdate :exec
+const incidentUpdate = `-- name: IncidentUpdate :exec
 UPDATE
-    heartbeat_monitors
+    incidents
 SET
-    name = $1,
-    heartbeat_interval = $2,
-    additional_details = $3,
-    muted = $4
+    summary = $1,
+    details = $2
 WHERE
-    id = $5
+    id = $3
 `
 
-type HBUpdateParams struct {
-	Name              string
-	HeartbeatInterval sqlutil.Interval
-	AdditionalDetails sql.NullString
-	Muted             sql.NullString
-	ID                uuid.UUID
+type IncidentUpdateParams struct {
+	Summary string
+	Details string
+	ID      int64
 }
 
-// HBUpdate will update a heartbeat record.
-func (q *Queries) HBUpdate(ctx context.Context, arg HBUpdateParams) error {
-	_, err := q.db.ExecContext(ctx, hBUpdate,
-		arg.Name,
-		arg.HeartbeatInterval,
-		arg.AdditionalDetails,
-		arg.Muted,
-		arg.ID,
-	)
+func (q *Queries) IncidentUpdate(ctx context.Context, arg IncidentUpdateParams) error {
+	_, err := q.db.ExecContext(ctx, incidentUpdate, arg.Summary, arg.Details, arg.ID)
 	return err
 }
 
@@ -3036,36 +4963,150 @@ type IntKeyUIKValidateServiceParams struct {
 	TokenID uuid.NullUUID
 }
 
-func (q *Queries) IntKeyUIKValidateService(ctx context.Context, arg IntKeyUIKValidateServiceParams) (uuid.UUID, error) {
-	row := q.db.QueryRowContext(ctx, intKeyUIKValidateService, arg.KeyID, arg.TokenID)
-	var service_id uuid.UUID
-	err := row.Scan(&service_id)
-	return service_id, err
+func (q *Queries) IntKeyUIKValidateService(ctx context.Context, arg IntKeyUIKValidateServiceParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, intKeyUIKValidateService, arg.KeyID, arg.TokenID)
+	var service_id uuid.UUID
+	err := row.Scan(&service_id)
+	return service_id, err
+}
+
+const keyring_GetConfigPayloads = `-- name: Keyring_GetConfigPayloads :many
+SELECT
+    id,
+    data
+FROM
+    config
+`
+
+type Keyring_GetConfigPayloadsRow struct {
+	ID   int32
+	Data []byte
+}
+
+func (q *Queries) Keyring_GetConfigPayloads(ctx context.Context) ([]Keyring_GetConfigPayloadsRow, error) {
+	rows, err := q.db.QueryContext(ctx, keyring_GetConfigPayloads)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Keyring_GetConfigPayloadsRow
+	for rows.Next() {
+		var i Keyring_GetConfigPayloadsRow
+		if err := rows.Scan(&i.ID, &i.Data); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const keyring_GetEncryptedCMDests = `-- name: Keyring_GetEncryptedCMDests :many
+SELECT
+    id,
+    dest
+FROM
+    user_contact_methods
+WHERE
+    dest::text LIKE '%-----BEGIN %'
+`
+
+type Keyring_GetEncryptedCMDestsRow struct {
+	ID   uuid.UUID
+	Dest NullDestV1
+}
+
+func (q *Queries) Keyring_GetEncryptedCMDests(ctx context.Context) ([]Keyring_GetEncryptedCMDestsRow, error) {
+	rows, err := q.db.QueryContext(ctx, keyring_GetEncryptedCMDests)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Keyring_GetEncryptedCMDestsRow
+	for rows.Next() {
+		var i Keyring_GetEncryptedCMDestsRow
+		if err := rows.Scan(&i.ID, &i.Dest); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const keyring_GetEncryptedNCDests = `-- name: Keyring_GetEncryptedNCDests :many
+SELECT
+    id,
+    dest
+FROM
+    notification_channels
+WHERE
+    dest::text LIKE '%-----BEGIN %'
+`
+
+type Keyring_GetEncryptedNCDestsRow struct {
+	ID   uuid.UUID
+	Dest NullDestV1
+}
+
+func (q *Queries) Keyring_GetEncryptedNCDests(ctx context.Context) ([]Keyring_GetEncryptedNCDestsRow, error) {
+	rows, err := q.db.QueryContext(ctx, keyring_GetEncryptedNCDests)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Keyring_GetEncryptedNCDestsRow
+	for rows.Next() {
+		var i Keyring_GetEncryptedNCDestsRow
+		if err := rows.Scan(&i.ID, &i.Dest); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const keyring_GetConfigPayloads = `-- name: Keyring_GetConfigPayloads :many
+const keyring_GetEncryptedUIKConfigs = `-- name: Keyring_GetEncryptedUIKConfigs :many
 SELECT
     id,
-    data
-FROM
     config
+FROM
+    uik_config
+WHERE
+    config::text LIKE '%-----BEGIN %'
 `
 
-type Keyring_GetConfigPayloadsRow struct {
-	ID   int32
-	Data []byte
+type Keyring_GetEncryptedUIKConfigsRow struct {
+	ID     uuid.UUID
+	Config UIKConfig
 }
 
-func (q *Queries) Keyring_GetConfigPayloads(ctx context.Context) ([]Keyring_GetConfigPayloadsRow, error) {
-	rows, err := q.db.QueryContext(ctx, keyring_GetConfigPayloads)
+func (q *Queries) Keyring_GetEncryptedUIKConfigs(ctx context.Context) ([]Keyring_GetEncryptedUIKConfigsRow, error) {
+	rows, err := q.db.QueryContext(ctx, keyring_GetEncryptedUIKConfigs)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Keyring_GetConfigPayloadsRow
+	var items []Keyring_GetEncryptedUIKConfigsRow
 	for rows.Next() {
-		var i Keyring_GetConfigPayloadsRow
-		if err := rows.Scan(&i.ID, &i.Data); err != nil {
+		var i Keyring_GetEncryptedUIKConfigsRow
+		if err := rows.Scan(&i.ID, &i.Config); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -3127,6 +5168,16 @@ func (q *Queries) Keyring_LockConfig(ctx context.Context) error {
 	return err
 }
 
+const keyring_LockDests = `-- name: Keyring_LockDests :exec
+LOCK TABLE user_contact_methods, notification_channels, uik_config IN EXCLUSIVE MODE
+`
+
+// Locks tables with destinations so no new encrypted args can be created.
+func (q *Queries) Keyring_LockDests(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, keyring_LockDests)
+	return err
+}
+
 const keyring_LockKeyrings = `-- name: Keyring_LockKeyrings :exec
 LOCK TABLE keyring IN ACCESS EXCLUSIVE MODE
 `
@@ -3137,6 +5188,25 @@ func (q *Queries) Keyring_LockKeyrings(ctx context.Context) error {
 	return err
 }
 
+const keyring_UpdateCMDest = `-- name: Keyring_UpdateCMDest :exec
+UPDATE
+    user_contact_methods
+SET
+    dest = $1
+WHERE
+    id = $2
+`
+
+type Keyring_UpdateCMDestParams struct {
+	Dest NullDestV1
+	ID   uuid.UUID
+}
+
+func (q *Queries) Keyring_UpdateCMDest(ctx context.Context, arg Keyring_UpdateCMDestParams) error {
+	_, err := q.db.ExecContext(ctx, keyring_UpdateCMDest, arg.Dest, arg.ID)
+	return err
+}
+
 const keyring_UpdateConfigPayload = `-- name: Keyring_UpdateConfigPayload :exec
 UPDATE
     config
@@ -3177,6 +5247,44 @@ func (q *Queries) Keyring_UpdateKeyringSecrets(ctx context.Context, arg Keyring_
 	return err
 }
 
+const keyring_UpdateNCDest = `-- name: Keyring_UpdateNCDest :exec
+UPDATE
+    notification_channels
+SET
+    dest = $1
+WHERE
+    id = $2
+`
+
+type Keyring_UpdateNCDestParams struct {
+	Dest NullDestV1
+	ID   uuid.UUID
+}
+
+func (q *Queries) Keyring_UpdateNCDest(ctx context.Context, arg Keyring_UpdateNCDestParams) error {
+	_, err := q.db.ExecContext(ctx, keyring_UpdateNCDest, arg.Dest, arg.ID)
+	return err
+}
+
+const keyring_UpdateUIKConfig = `-- name: Keyring_UpdateUIKConfig :exec
+UPDATE
+    uik_config
+SET
+    config = $1
+WHERE
+    id = $2
+`
+
+type Keyring_UpdateUIKConfigParams struct {
+	Config UIKConfig
+	ID     uuid.UUID
+}
+
+func (q *Queries) Keyring_UpdateUIKConfig(ctx context.Context, arg Keyring_UpdateUIKConfigParams) error {
+	_, err := q.db.ExecContext(ctx, keyring_UpdateUIKConfig, arg.Config, arg.ID)
+	return err
+}
+
 const labelDeleteKeyByTarget = `-- name: LabelDeleteKeyByTarget :exec
 DELETE FROM labels
 WHERE key = $1
@@ -3720,26 +5828,236 @@ type ListSequencesRow struct {
 	ColumnName   string
 }
 
-func (q *Queries) ListSequences(ctx context.Context) ([]ListSequencesRow, error) {
-	rows, err := q.db.QueryContext(ctx, listSequences)
+func (q *Queries) ListSequences(ctx context.Context) ([]ListSequencesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSequences)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSequencesRow
+	for rows.Next() {
+		var i ListSequencesRow
+		if err := rows.Scan(
+			&i.SchemaName,
+			&i.SequenceName,
+			&i.StartValue,
+			&i.Increment,
+			&i.MinValue,
+			&i.MaxValue,
+			&i.Cache,
+			&i.TableSchema,
+			&i.TableName,
+			&i.ColumnName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTriggers = `-- name: ListTriggers :many
+SELECT
+    n.nspname::text AS schema_name,
+    t.relname::text AS table_name,
+    trg.tgname::text AS trigger_name,
+    pg_catalog.pg_get_triggerdef(trg.oid) AS trigger_definition
+FROM
+    pg_catalog.pg_trigger trg
+    JOIN pg_catalog.pg_class t ON t.oid = trg.tgrelid
+    JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+WHERE
+    NOT trg.tgisinternal
+    AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+ORDER BY
+    n.nspname,
+    t.relname,
+    trg.tgname
+`
+
+type ListTriggersRow struct {
+	SchemaName        string
+	TableName         string
+	TriggerName       string
+	TriggerDefinition string
+}
+
+func (q *Queries) ListTriggers(ctx context.Context) ([]ListTriggersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTriggers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTriggersRow
+	for rows.Next() {
+		var i ListTriggersRow
+		if err := rows.Scan(
+			&i.SchemaName,
+			&i.TableName,
+			&i.TriggerName,
+			&i.TriggerDefinition,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const logEvents = `-- name: LogEvents :many
+SELECT id,
+    TIMESTAMP,
+    DATA
+FROM switchover_log
+WHERE id > $1
+ORDER BY id ASC
+LIMIT 100
+`
+
+type LogEventsRow struct {
+	ID        int64
+	Timestamp time.Time
+	Data      json.RawMessage
+}
+
+func (q *Queries) LogEvents(ctx context.Context, id int64) ([]LogEventsRow, error) {
+	rows, err := q.db.QueryContext(ctx, logEvents, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LogEventsRow
+	for rows.Next() {
+		var i LogEventsRow
+		if err := rows.Scan(&i.ID, &i.Timestamp, &i.Data); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const maintWindowDelete = `-- name: MaintWindowDelete :exec
+DELETE FROM service_maintenance_windows
+WHERE id = ANY ($1::uuid[])
+`
+
+func (q *Queries) MaintWindowDelete(ctx context.Context, ids []uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, maintWindowDelete, pq.Array(ids))
+	return err
+}
+
+const maintWindowFindAll = `-- name: MaintWindowFindAll :many
+SELECT
+    active_since, created_at, created_by, end_action, end_time, id, label_key, label_value, reason, repeat, service_id, start_time, time_zone
+FROM
+    service_maintenance_windows
+ORDER BY
+    start_time,
+    id
+`
+
+func (q *Queries) MaintWindowFindAll(ctx context.Context) ([]ServiceMaintenanceWindow, error) {
+	rows, err := q.db.QueryContext(ctx, maintWindowFindAll)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ServiceMaintenanceWindow
+	for rows.Next() {
+		var i ServiceMaintenanceWindow
+		if err := rows.Scan(
+			&i.ActiveSince,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.EndAction,
+			&i.EndTime,
+			&i.ID,
+			&i.LabelKey,
+			&i.LabelValue,
+			&i.Reason,
+			&i.Repeat,
+			&i.ServiceID,
+			&i.StartTime,
+			&i.TimeZone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const maintWindowFindAllByService = `-- name: MaintWindowFindAllByService :many
+SELECT
+    w.active_since, w.created_at, w.created_by, w.end_action, w.end_time, w.id, w.label_key, w.label_value, w.reason, w.repeat, w.service_id, w.start_time, w.time_zone
+FROM
+    service_maintenance_windows w
+WHERE
+    w.service_id = $1
+    OR EXISTS (
+        SELECT
+            1
+        FROM
+            labels l
+        WHERE
+            l.tgt_service_id = $1
+            AND l.key = w.label_key
+            AND (w.label_value = ''
+                OR l.value = w.label_value))
+ORDER BY
+    w.start_time,
+    w.id
+`
+
+// MaintWindowFindAllByService returns all maintenance windows that apply to a service, either directly or by label.
+func (q *Queries) MaintWindowFindAllByService(ctx context.Context, serviceID uuid.NullUUID) ([]ServiceMaintenanceWindow, error) {
+	rows, err := q.db.QueryContext(ctx, maintWindowFindAllByService, serviceID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListSequencesRow
+	var items []ServiceMaintenanceWindow
 	for rows.Next() {
-		var i ListSequencesRow
+		var i ServiceMaintenanceWindow
 		if err := rows.Scan(
-			&i.SchemaName,
-			&i.SequenceName,
-			&i.StartValue,
-			&i.Increment,
-			&i.MinValue,
-			&i.MaxValue,
-			&i.Cache,
-			&i.TableSchema,
-			&i.TableName,
-			&i.ColumnName,
+			&i.ActiveSince,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.EndAction,
+			&i.EndTime,
+			&i.ID,
+			&i.LabelKey,
+			&i.LabelValue,
+			&i.Reason,
+			&i.Repeat,
+			&i.ServiceID,
+			&i.StartTime,
+			&i.TimeZone,
 		); err != nil {
 			return nil, err
 		}
@@ -3754,46 +6072,38 @@ func (q *Queries) ListSequences(ctx context.Context) ([]ListSequencesRow, error)
 	return items, nil
 }
 
-const listTriggers = `-- name: ListTriggers :many
+const maintWindowFindMany = `-- name: MaintWindowFindMany :many
 SELECT
-    n.nspname::text AS schema_name,
-    t.relname::text AS table_name,
-    trg.tgname::text AS trigger_name,
-    pg_catalog.pg_get_triggerdef(trg.oid) AS trigger_definition
+    active_since, created_at, created_by, end_action, end_time, id, label_key, label_value, reason, repeat, service_id, start_time, time_zone
 FROM
-    pg_catalog.pg_trigger trg
-    JOIN pg_catalog.pg_class t ON t.oid = trg.tgrelid
-    JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+    service_maintenance_windows
 WHERE
-    NOT trg.tgisinternal
-    AND n.nspname NOT IN ('pg_catalog', 'information_schema')
-ORDER BY
-    n.nspname,
-    t.relname,
-    trg.tgname
+    id = ANY ($1::uuid[])
 `
 
-type ListTriggersRow struct {
-	SchemaName        string
-	TableName         string
-	TriggerName       string
-	TriggerDefinition string
-}
-
-func (q *Queries) ListTriggers(ctx context.Context) ([]ListTriggersRow, error) {
-	rows, err := q.db.QueryContext(ctx, listTriggers)
+func (q *Queries) MaintWindowFindMany(ctx context.Context, ids []uuid.UUID) ([]ServiceMaintenanceWindow, error) {
+	rows, err := q.db.QueryContext(ctx, maintWindowFindMany, pq.Array(ids))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListTriggersRow
+	var items []ServiceMaintenanceWindow
 	for rows.Next() {
-		var i ListTriggersRow
+		var i ServiceMaintenanceWindow
 		if err := rows.Scan(
-			&i.SchemaName,
-			&i.TableName,
-			&i.TriggerName,
-			&i.TriggerDefinition,
+			&i.ActiveSince,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.EndAction,
+			&i.EndTime,
+			&i.ID,
+			&i.LabelKey,
+			&i.LabelValue,
+			&i.Reason,
+			&i.Repeat,
+			&i.ServiceID,
+			&i.StartTime,
+			&i.TimeZone,
 		); err != nil {
 			return nil, err
 		}
@@ -3808,32 +6118,162 @@ func (q *Queries) ListTriggers(ctx context.Context) ([]ListTriggersRow, error) {
 	return items, nil
 }
 
-const logEvents = `-- name: LogEvents :many
-SELECT id,
-    TIMESTAMP,
-    DATA
-FROM switchover_log
-WHERE id > $1
-ORDER BY id ASC
-LIMIT 100
+const maintWindowFindOneForUpdate = `-- name: MaintWindowFindOneForUpdate :one
+SELECT
+    active_since, created_at, created_by, end_action, end_time, id, label_key, label_value, reason, repeat, service_id, start_time, time_zone
+FROM
+    service_maintenance_windows
+WHERE
+    id = $1
+FOR UPDATE
 `
 
-type LogEventsRow struct {
-	ID        int64
-	Timestamp time.Time
-	Data      json.RawMessage
+func (q *Queries) MaintWindowFindOneForUpdate(ctx context.Context, id uuid.UUID) (ServiceMaintenanceWindow, error) {
+	row := q.db.QueryRowContext(ctx, maintWindowFindOneForUpdate, id)
+	var i ServiceMaintenanceWindow
+	err := row.Scan(
+		&i.ActiveSince,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.EndAction,
+		&i.EndTime,
+		&i.ID,
+		&i.LabelKey,
+		&i.LabelValue,
+		&i.Reason,
+		&i.Repeat,
+		&i.ServiceID,
+		&i.StartTime,
+		&i.TimeZone,
+	)
+	return i, err
 }
 
-func (q *Queries) LogEvents(ctx context.Context, id int64) ([]LogEventsRow, error) {
-	rows, err := q.db.QueryContext(ctx, logEvents, id)
+const maintWindowInsert = `-- name: MaintWindowInsert :exec
+INSERT INTO service_maintenance_windows(id, service_id, label_key, label_value, start_time, end_time, repeat, time_zone, reason, end_action, created_by)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+`
+
+type MaintWindowInsertParams struct {
+	ID         uuid.UUID
+	ServiceID  uuid.NullUUID
+	LabelKey   sql.NullString
+	LabelValue string
+	StartTime  time.Time
+	EndTime    time.Time
+	Repeat     EnumMaintWindowRepeat
+	TimeZone   string
+	Reason     string
+	EndAction  EnumMaintWindowEndAction
+	CreatedBy  uuid.NullUUID
+}
+
+func (q *Queries) MaintWindowInsert(ctx context.Context, arg MaintWindowInsertParams) error {
+	_, err := q.db.ExecContext(ctx, maintWindowInsert,
+		arg.ID,
+		arg.ServiceID,
+		arg.LabelKey,
+		arg.LabelValue,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Repeat,
+		arg.TimeZone,
+		arg.Reason,
+		arg.EndAction,
+		arg.CreatedBy,
+	)
+	return err
+}
+
+const maintWindowUpdate = `-- name: MaintWindowUpdate :exec
+UPDATE
+    service_maintenance_windows
+SET
+    start_time = $1,
+    end_time = $2,
+    repeat = $3,
+    time_zone = $4,
+    reason = $5,
+    end_action = $6
+WHERE
+    id = $7
+`
+
+type MaintWindowUpdateParams struct {
+	StartTime time.Time
+	EndTime   time.Time
+	Repeat    EnumMaintWindowRepeat
+	TimeZone  string
+	Reason    string
+	EndAction EnumMaintWindowEndAction
+	ID        uuid.UUID
+}
+
+func (q *Queries) MaintWindowUpdate(ctx context.Context, arg MaintWindowUpdateParams) error {
+	_, err := q.db.ExecContext(ctx, maintWindowUpdate,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Repeat,
+		arg.TimeZone,
+		arg.Reason,
+		arg.EndAction,
+		arg.ID,
+	)
+	return err
+}
+
+const messageMgrActiveMaintWindows = `-- name: MessageMgrActiveMaintWindows :many
+SELECT
+    w.active_since, w.created_at, w.created_by, w.end_action, w.end_time, w.id, w.label_key, w.label_value, w.reason, w.repeat, w.service_id, w.start_time, w.time_zone,
+    svc.id AS service_id
+FROM
+    service_maintenance_windows w
+    JOIN services svc ON svc.id = w.service_id
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                labels l
+            WHERE
+                l.tgt_service_id = svc.id
+                AND l.key = w.label_key
+                AND (w.label_value = ''
+                    OR l.value = w.label_value))
+WHERE
+    w.active_since NOTNULL
+`
+
+type MessageMgrActiveMaintWindowsRow struct {
+	ServiceMaintenanceWindow ServiceMaintenanceWindow
+	ServiceID                uuid.UUID
+}
+
+// Returns the active maintenance windows, and each service they apply to.
+func (q *Queries) MessageMgrActiveMaintWindows(ctx context.Context) ([]MessageMgrActiveMaintWindowsRow, error) {
+	rows, err := q.db.QueryContext(ctx, messageMgrActiveMaintWindows)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []LogEventsRow
+	var items []MessageMgrActiveMaintWindowsRow
 	for rows.Next() {
-		var i LogEventsRow
-		if err := rows.Scan(&i.ID, &i.Timestamp, &i.Data); err != nil {
+		var i MessageMgrActiveMaintWindowsRow
+		if err := rows.Scan(
+			&i.ServiceMaintenanceWindow.ActiveSince,
+			&i.ServiceMaintenanceWindow.CreatedAt,
+			&i.ServiceMaintenanceWindow.CreatedBy,
+			&i.ServiceMaintenanceWindow.EndAction,
+			&i.ServiceMaintenanceWindow.EndTime,
+			&i.ServiceMaintenanceWindow.ID,
+			&i.ServiceMaintenanceWindow.LabelKey,
+			&i.ServiceMaintenanceWindow.LabelValue,
+			&i.ServiceMaintenanceWindow.Reason,
+			&i.ServiceMaintenanceWindow.Repeat,
+			&i.ServiceMaintenanceWindow.ServiceID,
+			&i.ServiceMaintenanceWindow.StartTime,
+			&i.ServiceMaintenanceWindow.TimeZone,
+			&i.ServiceID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -3847,6 +6287,30 @@ func (q *Queries) LogEvents(ctx context.Context, id int64) ([]LogEventsRow, erro
 	return items, nil
 }
 
+const messageMgrCreateDigest = `-- name: MessageMgrCreateDigest :exec
+INSERT INTO outgoing_messages(id, created_at, message_type, contact_method_id, channel_id, user_id)
+    VALUES ($1, $2, 'quiet_hours_digest', $3, $4, $5)
+`
+
+type MessageMgrCreateDigestParams struct {
+	ID              uuid.UUID
+	CreatedAt       time.Time
+	ContactMethodID uuid.NullUUID
+	ChannelID       uuid.NullUUID
+	UserID          uuid.NullUUID
+}
+
+func (q *Queries) MessageMgrCreateDigest(ctx context.Context, arg MessageMgrCreateDigestParams) error {
+	_, err := q.db.ExecContext(ctx, messageMgrCreateDigest,
+		arg.ID,
+		arg.CreatedAt,
+		arg.ContactMethodID,
+		arg.ChannelID,
+		arg.UserID,
+	)
+	return err
+}
+
 const messageMgrGetPending = `-- name: MessageMgrGetPending :many
 SELECT
     msg.id,
@@ -3862,7 +6326,9 @@ SELECT
     msg.created_at,
     msg.sent_at,
     msg.status_alert_ids,
-    msg.schedule_id
+    msg.schedule_id,
+    msg.override_request_id,
+    msg.quiet_until
 FROM
     outgoing_messages msg
     LEFT JOIN user_contact_methods cm ON cm.id = msg.contact_method_id
@@ -3873,6 +6339,8 @@ WHERE
     AND (msg.contact_method_id ISNULL
         OR msg.message_type = 'verification_message'
         OR NOT cm.disabled)
+    AND (msg.quiet_until ISNULL
+        OR msg.quiet_until <= now())
 `
 
 type MessageMgrGetPendingRow struct {
@@ -3890,6 +6358,8 @@ type MessageMgrGetPendingRow struct {
 	SentAt                 sql.NullTime
 	StatusAlertIds         []int64
 	ScheduleID             uuid.NullUUID
+	OverrideRequestID      uuid.NullUUID
+	QuietUntil             sql.NullTime
 }
 
 func (q *Queries) MessageMgrGetPending(ctx context.Context, sentAt sql.NullTime) ([]MessageMgrGetPendingRow, error) {
@@ -3916,6 +6386,64 @@ func (q *Queries) MessageMgrGetPending(ctx context.Context, sentAt sql.NullTime)
 			&i.SentAt,
 			pq.Array(&i.StatusAlertIds),
 			&i.ScheduleID,
+			&i.OverrideRequestID,
+			&i.QuietUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const messageMgrHoldQuiet = `-- name: MessageMgrHoldQuiet :exec
+UPDATE
+    outgoing_messages
+SET
+    quiet_until = $1
+WHERE
+    id = $2
+`
+
+type MessageMgrHoldQuietParams struct {
+	QuietUntil sql.NullTime
+	ID         uuid.UUID
+}
+
+func (q *Queries) MessageMgrHoldQuiet(ctx context.Context, arg MessageMgrHoldQuietParams) error {
+	_, err := q.db.ExecContext(ctx, messageMgrHoldQuiet, arg.QuietUntil, arg.ID)
+	return err
+}
+
+const messageMgrQuietHours = `-- name: MessageMgrQuietHours :many
+SELECT
+    end_time, id, service_id, start_time, time_zone, user_id
+FROM
+    quiet_hours
+`
+
+func (q *Queries) MessageMgrQuietHours(ctx context.Context) ([]QuietHour, error) {
+	rows, err := q.db.QueryContext(ctx, messageMgrQuietHours)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []QuietHour
+	for rows.Next() {
+		var i QuietHour
+		if err := rows.Scan(
+			&i.EndTime,
+			&i.ID,
+			&i.ServiceID,
+			&i.StartTime,
+			&i.TimeZone,
+			&i.UserID,
 		); err != nil {
 			return nil, err
 		}
@@ -3930,9 +6458,50 @@ func (q *Queries) MessageMgrGetPending(ctx context.Context, sentAt sql.NullTime)
 	return items, nil
 }
 
+const messageMgrScheduleServices = `-- name: MessageMgrScheduleServices :many
+SELECT DISTINCT
+    act.schedule_id,
+    svc.id AS service_id
+FROM
+    escalation_policy_actions act
+    JOIN escalation_policy_steps step ON step.id = act.escalation_policy_step_id
+    JOIN services svc ON svc.escalation_policy_id = step.escalation_policy_id
+WHERE
+    act.schedule_id = ANY ($1::uuid[])
+`
+
+type MessageMgrScheduleServicesRow struct {
+	ScheduleID uuid.NullUUID
+	ServiceID  uuid.UUID
+}
+
+// Returns the services that escalate directly to each schedule.
+func (q *Queries) MessageMgrScheduleServices(ctx context.Context, scheduleIds []uuid.UUID) ([]MessageMgrScheduleServicesRow, error) {
+	rows, err := q.db.QueryContext(ctx, messageMgrScheduleServices, pq.Array(scheduleIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MessageMgrScheduleServicesRow
+	for rows.Next() {
+		var i MessageMgrScheduleServicesRow
+		if err := rows.Scan(&i.ScheduleID, &i.ServiceID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const nfyLastMessageStatus = `-- name: NfyLastMessageStatus :one
 SELECT
-    om.alert_id, om.alert_log_id, om.channel_id, om.contact_method_id, om.created_at, om.cycle_id, om.escalation_policy_id, om.fired_at, om.id, om.last_status, om.last_status_at, om.message_type, om.next_retry_at, om.provider_msg_id, om.provider_seq, om.retry_count, om.schedule_id, om.sending_deadline, om.sent_at, om.service_id, om.src_value, om.status_alert_ids, om.status_details, om.user_id, om.user_verification_code_id,
+    om.alert_id, om.alert_log_id, om.channel_id, om.contact_method_id, om.created_at, om.cycle_id, om.escalation_policy_id, om.fired_at, om.id, om.last_status, om.last_status_at, om.message_type, om.next_retry_at, om.override_request_id, om.provider_msg_id, om.provider_seq, om.quiet_until, om.retry_count, om.schedule_id, om.sending_deadline, om.sent_at, om.service_id, om.src_value, om.status_alert_ids, om.status_details, om.user_id, om.user_verification_code_id,
     cm.dest AS cm_dest,
     ch.dest AS ch_dest
 FROM
@@ -3974,8 +6543,10 @@ func (q *Queries) NfyLastMessageStatus(ctx context.Context, arg NfyLastMessageSt
 		&i.OutgoingMessage.LastStatusAt,
 		&i.OutgoingMessage.MessageType,
 		&i.OutgoingMessage.NextRetryAt,
+		&i.OutgoingMessage.OverrideRequestID,
 		&i.OutgoingMessage.ProviderMsgID,
 		&i.OutgoingMessage.ProviderSeq,
+		&i.OutgoingMessage.QuietUntil,
 		&i.OutgoingMessage.RetryCount,
 		&i.OutgoingMessage.ScheduleID,
 		&i.OutgoingMessage.SendingDeadline,
@@ -3994,7 +6565,7 @@ func (q *Queries) NfyLastMessageStatus(ctx context.Context, arg NfyLastMessageSt
 
 const nfyManyMessageStatus = `-- name: NfyManyMessageStatus :many
 SELECT
-    om.alert_id, om.alert_log_id, om.channel_id, om.contact_method_id, om.created_at, om.cycle_id, om.escalation_policy_id, om.fired_at, om.id, om.last_status, om.last_status_at, om.message_type, om.next_retry_at, om.provider_msg_id, om.provider_seq, om.retry_count, om.schedule_id, om.sending_deadline, om.sent_at, om.service_id, om.src_value, om.status_alert_ids, om.status_details, om.user_id, om.user_verification_code_id,
+    om.alert_id, om.alert_log_id, om.channel_id, om.contact_method_id, om.created_at, om.cycle_id, om.escalation_policy_id, om.fired_at, om.id, om.last_status, om.last_status_at, om.message_type, om.next_retry_at, om.override_request_id, om.provider_msg_id, om.provider_seq, om.quiet_until, om.retry_count, om.schedule_id, om.sending_deadline, om.sent_at, om.service_id, om.src_value, om.status_alert_ids, om.status_details, om.user_id, om.user_verification_code_id,
     cm.dest AS cm_dest,
     ch.dest AS ch_dest
 FROM
@@ -4034,8 +6605,10 @@ func (q *Queries) NfyManyMessageStatus(ctx context.Context, dollar_1 []uuid.UUID
 			&i.OutgoingMessage.LastStatusAt,
 			&i.OutgoingMessage.MessageType,
 			&i.OutgoingMessage.NextRetryAt,
+			&i.OutgoingMessage.OverrideRequestID,
 			&i.OutgoingMessage.ProviderMsgID,
 			&i.OutgoingMessage.ProviderSeq,
+			&i.OutgoingMessage.QuietUntil,
 			&i.OutgoingMessage.RetryCount,
 			&i.OutgoingMessage.ScheduleID,
 			&i.OutgoingMessage.SendingDeadline,
@@ -4064,7 +6637,7 @@ func (q *Queries) NfyManyMessageStatus(ctx context.Context, dollar_1 []uuid.UUID
 
 const nfyOriginalMessageStatus = `-- name: NfyOriginalMessageStatus :one
 SELECT
-    om.alert_id, om.alert_log_id, om.channel_id, om.contact_method_id, om.created_at, om.cycle_id, om.escalation_policy_id, om.fired_at, om.id, om.last_status, om.last_status_at, om.message_type, om.next_retry_at, om.provider_msg_id, om.provider_seq, om.retry_count, om.schedule_id, om.sending_deadline, om.sent_at, om.service_id, om.src_value, om.status_alert_ids, om.status_details, om.user_id, om.user_verification_code_id,
+    om.alert_id, om.alert_log_id, om.channel_id, om.contact_method_id, om.created_at, om.cycle_id, om.escalation_policy_id, om.fired_at, om.id, om.last_status, om.last_status_at, om.message_type, om.next_retry_at, om.override_request_id, om.provider_msg_id, om.provider_seq, om.quiet_until, om.retry_count, om.schedule_id, om.sending_deadline, om.sent_at, om.service_id, om.src_value, om.status_alert_ids, om.status_details, om.user_id, om.user_verification_code_id,
     cm.dest AS cm_dest,
     ch.dest AS ch_dest
 FROM
@@ -4110,8 +6683,10 @@ func (q *Queries) NfyOriginalMessageStatus(ctx context.Context, arg NfyOriginalM
 		&i.OutgoingMessage.LastStatusAt,
 		&i.OutgoingMessage.MessageType,
 		&i.OutgoingMessage.NextRetryAt,
+		&i.OutgoingMessage.OverrideRequestID,
 		&i.OutgoingMessage.ProviderMsgID,
 		&i.OutgoingMessage.ProviderSeq,
+		&i.OutgoingMessage.QuietUntil,
 		&i.OutgoingMessage.RetryCount,
 		&i.OutgoingMessage.ScheduleID,
 		&i.OutgoingMessage.SendingDeadline,
@@ -4128,6 +6703,91 @@ func (q *Queries) NfyOriginalMessageStatus(ctx context.Context, arg NfyOriginalM
 	return i, err
 }
 
+const noticeScheduleUnavailability = `-- name: NoticeScheduleUnavailability :many
+SELECT
+    u.user_id,
+    usr.name AS user_name,
+    u.summary,
+    u.start_time,
+    u.end_time,
+    sched.time_zone
+FROM
+    user_unavailability u
+    JOIN users usr ON usr.id = u.user_id
+    JOIN schedules sched ON sched.id = $1::uuid
+WHERE
+    u.end_time > now()
+    AND u.start_time < $2::timestamptz
+    AND u.user_id IN (
+        SELECT
+            coalesce(r.tgt_user_id, p.user_id)
+        FROM
+            schedule_rules r
+        LEFT JOIN rotation_participants p ON p.rotation_id = r.tgt_rotation_id
+            AND NOT p.shadow
+    WHERE
+        r.schedule_id = $1::uuid)
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            user_overrides o
+        WHERE
+            o.tgt_schedule_id = $1::uuid
+            AND o.remove_user_id = u.user_id
+            AND o.start_time <= greatest(u.start_time, now())
+            AND o.end_time >= u.end_time)
+ORDER BY
+    u.start_time,
+    usr.name
+`
+
+type NoticeScheduleUnavailabilityParams struct {
+	ScheduleID uuid.UUID
+	Before     time.Time
+}
+
+type NoticeScheduleUnavailabilityRow struct {
+	UserID    uuid.UUID
+	UserName  string
+	Summary   string
+	StartTime time.Time
+	EndTime   time.Time
+	TimeZone  string
+}
+
+// Returns upcoming out-of-office periods of users on a schedule that are not covered by an override
+// removing them from it.
+func (q *Queries) NoticeScheduleUnavailability(ctx context.Context, arg NoticeScheduleUnavailabilityParams) ([]NoticeScheduleUnavailabilityRow, error) {
+	rows, err := q.db.QueryContext(ctx, noticeScheduleUnavailability, arg.ScheduleID, arg.Before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NoticeScheduleUnavailabilityRow
+	for rows.Next() {
+		var i NoticeScheduleUnavailabilityRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.UserName,
+			&i.Summary,
+			&i.StartTime,
+			&i.EndTime,
+			&i.TimeZone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const noticeUnackedAlertsByService = `-- name: NoticeUnackedAlertsByService :one
 SELECT
     count(*),
@@ -4292,6 +6952,292 @@ func (q *Queries) Now(ctx context.Context) (time.Time, error) {
 	return column_1, err
 }
 
+const overrideRequestCreate = `-- name: OverrideRequestCreate :exec
+INSERT INTO override_requests(id, schedule_id, requester_user_id, target_user_id, add_user_id, remove_user_id, start_time, end_time)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type OverrideRequestCreateParams struct {
+	ID              uuid.UUID
+	ScheduleID      uuid.UUID
+	RequesterUserID uuid.UUID
+	TargetUserID    uuid.UUID
+	AddUserID       uuid.NullUUID
+	RemoveUserID    uuid.NullUUID
+	StartTime       time.Time
+	EndTime         time.Time
+}
+
+func (q *Queries) OverrideRequestCreate(ctx context.Context, arg OverrideRequestCreateParams) error {
+	_, err := q.db.ExecContext(ctx, overrideRequestCreate,
+		arg.ID,
+		arg.ScheduleID,
+		arg.RequesterUserID,
+		arg.TargetUserID,
+		arg.AddUserID,
+		arg.RemoveUserID,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	return err
+}
+
+const overrideRequestDelete = `-- name: OverrideRequestDelete :exec
+DELETE FROM override_requests
+WHERE id = $1
+`
+
+func (q *Queries) OverrideRequestDelete(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, overrideRequestDelete, id)
+	return err
+}
+
+const overrideRequestFindOne = `-- name: OverrideRequestFindOne :one
+SELECT
+    id,
+    schedule_id,
+    requester_user_id,
+    target_user_id,
+    add_user_id,
+    remove_user_id,
+    start_time,
+    end_time,
+    status,
+    created_at,
+    override_id
+FROM
+    override_requests
+WHERE
+    id = $1
+`
+
+type OverrideRequestFindOneRow struct {
+	ID              uuid.UUID
+	ScheduleID      uuid.UUID
+	RequesterUserID uuid.UUID
+	TargetUserID    uuid.UUID
+	AddUserID       uuid.NullUUID
+	RemoveUserID    uuid.NullUUID
+	StartTime       time.Time
+	EndTime         time.Time
+	Status          EnumOverrideRequestStatus
+	CreatedAt       time.Time
+	OverrideID      uuid.NullUUID
+}
+
+func (q *Queries) OverrideRequestFindOne(ctx context.Context, id uuid.UUID) (OverrideRequestFindOneRow, error) {
+	row := q.db.QueryRowContext(ctx, overrideRequestFindOne, id)
+	var i OverrideRequestFindOneRow
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduleID,
+		&i.RequesterUserID,
+		&i.TargetUserID,
+		&i.AddUserID,
+		&i.RemoveUserID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Status,
+		&i.CreatedAt,
+		&i.OverrideID,
+	)
+	return i, err
+}
+
+const overrideRequestFindOneForUpdate = `-- name: OverrideRequestFindOneForUpdate :one
+SELECT
+    id,
+    schedule_id,
+    requester_user_id,
+    target_user_id,
+    add_user_id,
+    remove_user_id,
+    start_time,
+    end_time,
+    status,
+    created_at,
+    override_id
+FROM
+    override_requests
+WHERE
+    id = $1
+FOR UPDATE
+`
+
+type OverrideRequestFindOneForUpdateRow struct {
+	ID              uuid.UUID
+	ScheduleID      uuid.UUID
+	RequesterUserID uuid.UUID
+	TargetUserID    uuid.UUID
+	AddUserID       uuid.NullUUID
+	RemoveUserID    uuid.NullUUID
+	StartTime       time.Time
+	EndTime         time.Time
+	Status          EnumOverrideRequestStatus
+	CreatedAt       time.Time
+	OverrideID      uuid.NullUUID
+}
+
+func (q *Queries) OverrideRequestFindOneForUpdate(ctx context.Context, id uuid.UUID) (OverrideRequestFindOneForUpdateRow, error) {
+	row := q.db.QueryRowContext(ctx, overrideRequestFindOneForUpdate, id)
+	var i OverrideRequestFindOneForUpdateRow
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduleID,
+		&i.RequesterUserID,
+		&i.TargetUserID,
+		&i.AddUserID,
+		&i.RemoveUserID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Status,
+		&i.CreatedAt,
+		&i.OverrideID,
+	)
+	return i, err
+}
+
+const overrideRequestNotify = `-- name: OverrideRequestNotify :exec
+INSERT INTO outgoing_messages(message_type, contact_method_id, user_id, override_request_id)
+SELECT
+    'override_request_notification',
+    cm.id,
+    cm.user_id,
+    req.id
+FROM
+    override_requests req
+    JOIN user_contact_methods cm ON cm.user_id = req.target_user_id
+        AND NOT cm.disabled
+WHERE
+    req.id = $1
+    AND EXISTS (
+        SELECT
+        FROM
+            user_notification_rules nr
+        WHERE
+            nr.contact_method_id = cm.id
+            AND nr.delay_minutes = 0)
+`
+
+// OverrideRequestNotify queues a notification of the request to the target user, for each contact method with an immediate notification rule.
+func (q *Queries) OverrideRequestNotify(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, overrideRequestNotify, id)
+	return err
+}
+
+const overrideRequestSearch = `-- name: OverrideRequestSearch :many
+SELECT
+    id,
+    schedule_id,
+    requester_user_id,
+    target_user_id,
+    add_user_id,
+    remove_user_id,
+    start_time,
+    end_time,
+    status,
+    created_at,
+    override_id
+FROM
+    override_requests
+WHERE ($1::uuid ISNULL
+    OR target_user_id = $1)
+AND ($2::uuid ISNULL
+    OR requester_user_id = $2)
+AND ($3::uuid ISNULL
+    OR schedule_id = $3)
+AND (NOT $4::bool
+    OR (status = 'pending'
+        AND end_time > now()))
+ORDER BY
+    start_time,
+    id
+LIMIT 150
+`
+
+type OverrideRequestSearchParams struct {
+	TargetUserID    uuid.NullUUID
+	RequesterUserID uuid.NullUUID
+	ScheduleID      uuid.NullUUID
+	PendingOnly     bool
+}
+
+type OverrideRequestSearchRow struct {
+	ID              uuid.UUID
+	ScheduleID      uuid.UUID
+	RequesterUserID uuid.UUID
+	TargetUserID    uuid.UUID
+	AddUserID       uuid.NullUUID
+	RemoveUserID    uuid.NullUUID
+	StartTime       time.Time
+	EndTime         time.Time
+	Status          EnumOverrideRequestStatus
+	CreatedAt       time.Time
+	OverrideID      uuid.NullUUID
+}
+
+func (q *Queries) OverrideRequestSearch(ctx context.Context, arg OverrideRequestSearchParams) ([]OverrideRequestSearchRow, error) {
+	rows, err := q.db.QueryContext(ctx, overrideRequestSearch,
+		arg.TargetUserID,
+		arg.RequesterUserID,
+		arg.ScheduleID,
+		arg.PendingOnly,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OverrideRequestSearchRow
+	for rows.Next() {
+		var i OverrideRequestSearchRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScheduleID,
+			&i.RequesterUserID,
+			&i.TargetUserID,
+			&i.AddUserID,
+			&i.RemoveUserID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Status,
+			&i.CreatedAt,
+			&i.OverrideID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const overrideRequestSetStatus = `-- name: OverrideRequestSetStatus :exec
+UPDATE
+    override_requests
+SET
+    status = $2,
+    override_id = $3,
+    responded_at = now()
+WHERE
+    id = $1
+`
+
+type OverrideRequestSetStatusParams struct {
+	ID         uuid.UUID
+	Status     EnumOverrideRequestStatus
+	OverrideID uuid.NullUUID
+}
+
+func (q *Queries) OverrideRequestSetStatus(ctx context.Context, arg OverrideRequestSetStatusParams) error {
+	_, err := q.db.ExecContext(ctx, overrideRequestSetStatus, arg.ID, arg.Status, arg.OverrideID)
+	return err
+}
+
 const overrideSearch = `-- name: OverrideSearch :many
 WITH AFTER AS (
     SELECT
@@ -4516,6 +7462,126 @@ func (q *Queries) ProcSharedAdvisoryLock(ctx context.Context, pgTryAdvisoryXactL
 	return lock_acquired, err
 }
 
+const quietHoursDeleteService = `-- name: QuietHoursDeleteService :exec
+DELETE FROM quiet_hours
+WHERE service_id = $1
+`
+
+func (q *Queries) QuietHoursDeleteService(ctx context.Context, serviceID uuid.NullUUID) error {
+	_, err := q.db.ExecContext(ctx, quietHoursDeleteService, serviceID)
+	return err
+}
+
+const quietHoursDeleteUser = `-- name: QuietHoursDeleteUser :exec
+DELETE FROM quiet_hours
+WHERE user_id = $1
+`
+
+func (q *Queries) QuietHoursDeleteUser(ctx context.Context, userID uuid.NullUUID) error {
+	_, err := q.db.ExecContext(ctx, quietHoursDeleteUser, userID)
+	return err
+}
+
+const quietHoursFindOneByService = `-- name: QuietHoursFindOneByService :one
+SELECT
+    end_time, id, service_id, start_time, time_zone, user_id
+FROM
+    quiet_hours
+WHERE
+    service_id = $1
+`
+
+func (q *Queries) QuietHoursFindOneByService(ctx context.Context, serviceID uuid.NullUUID) (QuietHour, error) {
+	row := q.db.QueryRowContext(ctx, quietHoursFindOneByService, serviceID)
+	var i QuietHour
+	err := row.Scan(
+		&i.EndTime,
+		&i.ID,
+		&i.ServiceID,
+		&i.StartTime,
+		&i.TimeZone,
+		&i.UserID,
+	)
+	return i, err
+}
+
+const quietHoursFindOneByUser = `-- name: QuietHoursFindOneByUser :one
+SELECT
+    end_time, id, service_id, start_time, time_zone, user_id
+FROM
+    quiet_hours
+WHERE
+    user_id = $1
+`
+
+func (q *Queries) QuietHoursFindOneByUser(ctx context.Context, userID uuid.NullUUID) (QuietHour, error) {
+	row := q.db.QueryRowContext(ctx, quietHoursFindOneByUser, userID)
+	var i QuietHour
+	err := row.Scan(
+		&i.EndTime,
+		&i.ID,
+		&i.ServiceID,
+		&i.StartTime,
+		&i.TimeZone,
+		&i.UserID,
+	)
+	return i, err
+}
+
+const quietHoursSetService = `-- name: QuietHoursSetService :exec
+INSERT INTO quiet_hours(id, service_id, time_zone, start_time, end_time)
+    VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (service_id)
+    DO UPDATE SET
+        time_zone = excluded.time_zone, start_time = excluded.start_time, end_time = excluded.end_time
+`
+
+type QuietHoursSetServiceParams struct {
+	ID        uuid.UUID
+	ServiceID uuid.NullUUID
+	TimeZone  string
+	StartTime timeutil.Clock
+	EndTime   timeutil.Clock
+}
+
+func (q *Queries) QuietHoursSetService(ctx context.Context, arg QuietHoursSetServiceParams) error {
+	_, err := q.db.ExecContext(ctx, quietHoursSetService,
+		arg.ID,
+		arg.ServiceID,
+		arg.TimeZone,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	return err
+}
+
+const quietHoursSetUser = `-- name: QuietHoursSetUser :exec
+INSERT INTO quiet_hours(id, user_id, time_zone, start_time, end_time)
+    VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id)
+    DO UPDATE SET
+        time_zone = excluded.time_zone, start_time = excluded.start_time, end_time = excluded.end_time
+`
+
+type QuietHoursSetUserParams struct {
+	ID        uuid.UUID
+	UserID    uuid.NullUUID
+	TimeZone  string
+	StartTime timeutil.Clock
+	EndTime   timeutil.Clock
+}
+
+func (q *Queries) QuietHoursSetUser(ctx context.Context, arg QuietHoursSetUserParams) error {
+	_, err := q.db.ExecContext(ctx, quietHoursSetUser,
+		arg.ID,
+		arg.UserID,
+		arg.TimeZone,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	return err
+}
+
 const rotMgrEnd = `-- name: RotMgrEnd :exec
 DELETE FROM rotation_state
 WHERE rotation_id = $1
@@ -4527,22 +7593,82 @@ func (q *Queries) RotMgrEnd(ctx context.Context, rotationID uuid.UUID) error {
 	return err
 }
 
+const rotMgrParticipantWindows = `-- name: RotMgrParticipantWindows :many
+SELECT
+    position,
+    window_start::text AS window_start,
+    window_end::text AS window_end,
+    window_time_zone::text AS window_time_zone
+FROM
+    rotation_participants
+WHERE
+    rotation_id = $1
+    AND window_time_zone NOTNULL
+ORDER BY
+    position
+`
+
+type RotMgrParticipantWindowsRow struct {
+	Position       int32
+	WindowStart    string
+	WindowEnd      string
+	WindowTimeZone string
+}
+
+// Get the follow-the-sun windows of participants for a given rotation ID.
+func (q *Queries) RotMgrParticipantWindows(ctx context.Context, rotationID uuid.UUID) ([]RotMgrParticipantWindowsRow, error) {
+	rows, err := q.db.QueryContext(ctx, rotMgrParticipantWindows, rotationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RotMgrParticipantWindowsRow
+	for rows.Next() {
+		var i RotMgrParticipantWindowsRow
+		if err := rows.Scan(
+			&i.Position,
+			&i.WindowStart,
+			&i.WindowEnd,
+			&i.WindowTimeZone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const rotMgrRotationData = `-- name: RotMgrRotationData :one
 SELECT
     now()::timestamptz AS now,
-    rot.description, rot.id, rot.last_processed, rot.name, rot.participant_count, rot.shift_length, rot.start_time, rot.time_zone, rot.type,
+    rot.active_count, rot.description, rot.id, rot.last_processed, rot.name, rot.participant_count, rot.shift_length, rot.start_time, rot.time_zone, rot.type,
     coalesce(state.version, 0) AS state_version,
     coalesce(state.position, 0) AS state_position,
     state.shift_start AS state_shift_start,
     ARRAY (
         SELECT
-            p.id
+            p.id
+        FROM
+            rotation_participants p
+        WHERE
+            p.rotation_id = rot.id
+        ORDER BY
+            position)::uuid[] AS participants,
+    ARRAY (
+        SELECT
+            p.shadow
         FROM
             rotation_participants p
         WHERE
             p.rotation_id = rot.id
         ORDER BY
-            position)::uuid[] AS participants
+            position)::bool[] AS shadows
     FROM
         rotations rot
     LEFT JOIN rotation_state state ON rot.id = state.rotation_id
@@ -4557,6 +7683,7 @@ type RotMgrRotationDataRow struct {
 	StatePosition   int32
 	StateShiftStart sql.NullTime
 	Participants    []uuid.UUID
+	Shadows         []bool
 }
 
 // Get rotation data for a given rotation ID
@@ -4565,6 +7692,7 @@ func (q *Queries) RotMgrRotationData(ctx context.Context, rotationID uuid.UUID)
 	var i RotMgrRotationDataRow
 	err := row.Scan(
 		&i.Now,
+		&i.Rotation.ActiveCount,
 		&i.Rotation.Description,
 		&i.Rotation.ID,
 		&i.Rotation.LastProcessed,
@@ -4578,10 +7706,35 @@ func (q *Queries) RotMgrRotationData(ctx context.Context, rotationID uuid.UUID)
 		&i.StatePosition,
 		&i.StateShiftStart,
 		pq.Array(&i.Participants),
+		pq.Array(&i.Shadows),
 	)
 	return i, err
 }
 
+const rotMgrSetActiveParticipants = `-- name: RotMgrSetActiveParticipants :exec
+WITH removed AS (
+    DELETE FROM rotation_active_participants
+    WHERE rotation_id = $1
+        AND rotation_participant_id <> ALL ($2::uuid[]))
+INSERT INTO rotation_active_participants(rotation_id, rotation_participant_id)
+SELECT
+    $1,
+    unnest($2::uuid[])
+ON CONFLICT
+    DO NOTHING
+`
+
+type RotMgrSetActiveParticipantsParams struct {
+	RotationID     uuid.UUID
+	ParticipantIds []uuid.UUID
+}
+
+// Set the participants that are on call in addition to the one in rotation_state.
+func (q *Queries) RotMgrSetActiveParticipants(ctx context.Context, arg RotMgrSetActiveParticipantsParams) error {
+	_, err := q.db.ExecContext(ctx, rotMgrSetActiveParticipants, arg.RotationID, pq.Array(arg.ParticipantIds))
+	return err
+}
+
 const rotMgrStart = `-- name: RotMgrStart :exec
 INSERT INTO rotation_state(
     rotation_id,
@@ -4590,14 +7743,17 @@ INSERT INTO rotation_state(
     rotation_participant_id)
 SELECT
     p.rotation_id,
-    0,
+    p.position,
     now(),
     id
 FROM
     rotation_participants p
 WHERE
     p.rotation_id = $1
-    AND position = 0
+ORDER BY
+    p.shadow,
+    p.position
+LIMIT 1
 `
 
 // Start a rotation.
@@ -4899,6 +8055,55 @@ func (q *Queries) SchedInsertData(ctx context.Context, scheduleID uuid.UUID) err
 	return err
 }
 
+const schedMgrCoverageSchedules = `-- name: SchedMgrCoverageSchedules :many
+SELECT
+    data.schedule_id,
+    data.data,
+    notice.gap_start,
+    notice.gap_end
+FROM
+    schedule_data data
+    LEFT JOIN schedule_coverage_notices notice ON notice.schedule_id = data.schedule_id
+WHERE
+    data.data @> '{"V1":{"OnCallNotificationRules":[{}]}}'
+`
+
+type SchedMgrCoverageSchedulesRow struct {
+	ScheduleID uuid.UUID
+	Data       json.RawMessage
+	GapStart   sql.NullTime
+	GapEnd     sql.NullTime
+}
+
+// Returns schedules that have on-call notification rules, along with the last coverage gap notice, if any.
+func (q *Queries) SchedMgrCoverageSchedules(ctx context.Context) ([]SchedMgrCoverageSchedulesRow, error) {
+	rows, err := q.db.QueryContext(ctx, schedMgrCoverageSchedules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SchedMgrCoverageSchedulesRow
+	for rows.Next() {
+		var i SchedMgrCoverageSchedulesRow
+		if err := rows.Scan(
+			&i.ScheduleID,
+			&i.Data,
+			&i.GapStart,
+			&i.GapEnd,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const schedMgrDataForUpdate = `-- name: SchedMgrDataForUpdate :many
 SELECT
     schedule_id,
@@ -4969,6 +8174,17 @@ func (q *Queries) SchedMgrDataIDs(ctx context.Context) ([]uuid.UUID, error) {
 	return items, nil
 }
 
+const schedMgrDeleteCoverageNotice = `-- name: SchedMgrDeleteCoverageNotice :exec
+DELETE FROM schedule_coverage_notices
+WHERE schedule_id = $1
+`
+
+// Clears the last coverage gap notice once the schedule is covered again.
+func (q *Queries) SchedMgrDeleteCoverageNotice(ctx context.Context, scheduleID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, schedMgrDeleteCoverageNotice, scheduleID)
+	return err
+}
+
 const schedMgrEndOnCall = `-- name: SchedMgrEndOnCall :exec
 UPDATE
     schedule_on_call_users
@@ -5007,6 +8223,62 @@ func (q *Queries) SchedMgrGetData(ctx context.Context, scheduleID uuid.UUID) (js
 	return data, err
 }
 
+const schedMgrHolidays = `-- name: SchedMgrHolidays :many
+SELECT DISTINCT
+    sched.schedule_id,
+    d.date
+FROM
+    schedule_holiday_calendars sched
+    JOIN holiday_calendar_dates d ON d.calendar_id = sched.calendar_id
+WHERE
+    d.date BETWEEN now()::date - 1 AND now()::date + 1
+`
+
+type SchedMgrHolidaysRow struct {
+	ScheduleID uuid.UUID
+	Date       time.Time
+}
+
+// Returns the holidays of each schedule around the current date. Dates are widened by a day to cover all time zones.
+func (q *Queries) SchedMgrHolidays(ctx context.Context) ([]SchedMgrHolidaysRow, error) {
+	rows, err := q.db.QueryContext(ctx, schedMgrHolidays)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SchedMgrHolidaysRow
+	for rows.Next() {
+		var i SchedMgrHolidaysRow
+		if err := rows.Scan(&i.ScheduleID, &i.Date); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const schedMgrInsertCoverageGapMessage = `-- name: SchedMgrInsertCoverageGapMessage :exec
+INSERT INTO outgoing_messages(id, message_type, channel_id, schedule_id)
+    VALUES ($1, 'schedule_coverage_gap_notification', $2, $3)
+`
+
+type SchedMgrInsertCoverageGapMessageParams struct {
+	ID         uuid.UUID
+	ChannelID  uuid.NullUUID
+	ScheduleID uuid.NullUUID
+}
+
+func (q *Queries) SchedMgrInsertCoverageGapMessage(ctx context.Context, arg SchedMgrInsertCoverageGapMessageParams) error {
+	_, err := q.db.ExecContext(ctx, schedMgrInsertCoverageGapMessage, arg.ID, arg.ChannelID, arg.ScheduleID)
+	return err
+}
+
 const schedMgrInsertMessage = `-- name: SchedMgrInsertMessage :exec
 INSERT INTO outgoing_messages(id, message_type, channel_id, schedule_id)
     VALUES ($1, 'schedule_on_call_notification', $2, $3)
@@ -5140,12 +8412,25 @@ func (q *Queries) SchedMgrOverrides(ctx context.Context) ([]SchedMgrOverridesRow
 
 const schedMgrRules = `-- name: SchedMgrRules :many
 SELECT
-    rule.created_at, rule.end_time, rule.friday, rule.id, rule.is_active, rule.monday, rule.saturday, rule.schedule_id, rule.start_time, rule.sunday, rule.tgt_rotation_id, rule.tgt_user_id, rule.thursday, rule.tuesday, rule.wednesday,
+    rule.created_at, rule.end_time, rule.friday, rule.holiday_filter, rule.id, rule.is_active, rule.monday, rule.saturday, rule.schedule_id, rule.start_time, rule.sunday, rule.tgt_rotation_id, rule.tgt_user_id, rule.thursday, rule.tuesday, rule.wednesday,
     coalesce(rule.tgt_user_id, part.user_id) AS resolved_user_id
 FROM
     schedule_rules rule
-    LEFT JOIN rotation_state rState ON rState.rotation_id = rule.tgt_rotation_id
-    LEFT JOIN rotation_participants part ON part.id = rState.rotation_participant_id
+    LEFT JOIN LATERAL (
+        SELECT
+            rState.rotation_participant_id AS participant_id
+        FROM
+            rotation_state rState
+        WHERE
+            rState.rotation_id = rule.tgt_rotation_id
+        UNION
+        SELECT
+            ap.rotation_participant_id
+        FROM
+            rotation_active_participants ap
+        WHERE
+            ap.rotation_id = rule.tgt_rotation_id) active ON TRUE
+    LEFT JOIN rotation_participants part ON part.id = active.participant_id
 WHERE
     coalesce(rule.tgt_user_id, part.user_id)
     NOTNULL
@@ -5155,6 +8440,7 @@ type SchedMgrRulesRow struct {
 	CreatedAt      time.Time
 	EndTime        timeutil.Clock
 	Friday         bool
+	HolidayFilter  EnumHolidayFilter
 	ID             uuid.UUID
 	IsActive       bool
 	Monday         bool
@@ -5183,6 +8469,7 @@ func (q *Queries) SchedMgrRules(ctx context.Context) ([]SchedMgrRulesRow, error)
 			&i.CreatedAt,
 			&i.EndTime,
 			&i.Friday,
+			&i.HolidayFilter,
 			&i.ID,
 			&i.IsActive,
 			&i.Monday,
@@ -5210,6 +8497,26 @@ func (q *Queries) SchedMgrRules(ctx context.Context) ([]SchedMgrRulesRow, error)
 	return items, nil
 }
 
+const schedMgrSetCoverageNotice = `-- name: SchedMgrSetCoverageNotice :exec
+INSERT INTO schedule_coverage_notices(schedule_id, gap_start, gap_end)
+    VALUES ($1, $2, $3)
+ON CONFLICT (schedule_id)
+    DO UPDATE SET
+        gap_start = $2, gap_end = $3, notified_at = now()
+`
+
+type SchedMgrSetCoverageNoticeParams struct {
+	ScheduleID uuid.UUID
+	GapStart   time.Time
+	GapEnd     time.Time
+}
+
+// Records the coverage gap that notifications were last sent for.
+func (q *Queries) SchedMgrSetCoverageNotice(ctx context.Context, arg SchedMgrSetCoverageNoticeParams) error {
+	_, err := q.db.ExecContext(ctx, schedMgrSetCoverageNotice, arg.ScheduleID, arg.GapStart, arg.GapEnd)
+	return err
+}
+
 const schedMgrSetData = `-- name: SchedMgrSetData :exec
 UPDATE
     schedule_data
@@ -5760,15 +9067,15 @@ FROM
     alert_logs
 WHERE
     alert_id = $1::bigint
-    AND event = $2::enum_alert_log_event
+    AND event = ANY ($2::enum_alert_log_event[])
 ORDER BY
     id DESC
 LIMIT 1
 `
 
 type StatusMgrLogEntryParams struct {
-	AlertID   int64
-	EventType EnumAlertLogEvent
+	AlertID    int64
+	EventTypes []EnumAlertLogEvent
 }
 
 type StatusMgrLogEntryRow struct {
@@ -5777,7 +9084,7 @@ type StatusMgrLogEntryRow struct {
 }
 
 func (q *Queries) StatusMgrLogEntry(ctx context.Context, arg StatusMgrLogEntryParams) (StatusMgrLogEntryRow, error) {
-	row := q.db.QueryRowContext(ctx, statusMgrLogEntry, arg.AlertID, arg.EventType)
+	row := q.db.QueryRowContext(ctx, statusMgrLogEntry, arg.AlertID, pq.Array(arg.EventTypes))
 	var i StatusMgrLogEntryRow
 	err := row.Scan(&i.ID, &i.UserID)
 	return i, err
@@ -5885,6 +9192,50 @@ func (q *Queries) StatusMgrUpdateSub(ctx context.Context, arg StatusMgrUpdateSub
 	return err
 }
 
+const svcAlertRulesDeleteConfig = `-- name: SvcAlertRulesDeleteConfig :exec
+DELETE FROM service_alert_rules
+WHERE service_id = $1
+`
+
+func (q *Queries) SvcAlertRulesDeleteConfig(ctx context.Context, serviceID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, svcAlertRulesDeleteConfig, serviceID)
+	return err
+}
+
+const svcAlertRulesGetConfig = `-- name: SvcAlertRulesGetConfig :one
+SELECT
+    config
+FROM
+    service_alert_rules
+WHERE
+    service_id = $1
+`
+
+func (q *Queries) SvcAlertRulesGetConfig(ctx context.Context, serviceID uuid.UUID) (SvcAlertRules, error) {
+	row := q.db.QueryRowContext(ctx, svcAlertRulesGetConfig, serviceID)
+	var config SvcAlertRules
+	err := row.Scan(&config)
+	return config, err
+}
+
+const svcAlertRulesSetConfig = `-- name: SvcAlertRulesSetConfig :exec
+INSERT INTO service_alert_rules(service_id, config)
+    VALUES ($1, $2)
+ON CONFLICT (service_id)
+    DO UPDATE SET
+        config = $2
+`
+
+type SvcAlertRulesSetConfigParams struct {
+	ServiceID uuid.UUID
+	Config    SvcAlertRules
+}
+
+func (q *Queries) SvcAlertRulesSetConfig(ctx context.Context, arg SvcAlertRulesSetConfigParams) error {
+	_, err := q.db.ExecContext(ctx, svcAlertRulesSetConfig, arg.ServiceID, arg.Config)
+	return err
+}
+
 const tableColumns = `-- name: TableColumns :many
 SELECT col.table_name::text,
     col.column_name::text,
@@ -6100,3 +9451,194 @@ func (q *Queries) UserFavUnset(ctx context.Context, arg UserFavUnsetParams) erro
 	)
 	return err
 }
+
+const workload_Acks = `-- name: Workload_Acks :many
+SELECT
+    sub_user_id::uuid AS user_id,
+    timestamp::timestamptz AS acked_at
+FROM
+    alert_logs
+WHERE
+    event = 'acknowledged'
+    AND sub_user_id = ANY ($1::uuid[])
+    AND timestamp >= $2::timestamptz
+    AND timestamp < $3::timestamptz
+`
+
+type Workload_AcksParams struct {
+	UserIds   []uuid.UUID
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+type Workload_AcksRow struct {
+	UserID  uuid.UUID
+	AckedAt time.Time
+}
+
+// Returns alert acknowledgements made by the given users within the given time range.
+func (q *Queries) Workload_Acks(ctx context.Context, arg Workload_AcksParams) ([]Workload_AcksRow, error) {
+	rows, err := q.db.QueryContext(ctx, workload_Acks, pq.Array(arg.UserIds), arg.StartTime, arg.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Workload_AcksRow
+	for rows.Next() {
+		var i Workload_AcksRow
+		if err := rows.Scan(&i.UserID, &i.AckedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const workload_Pages = `-- name: Workload_Pages :many
+SELECT
+    user_id::uuid AS user_id,
+    alert_id::bigint AS alert_id,
+    min(coalesce(sent_at, last_status_at))::timestamptz AS notified_at
+FROM
+    outgoing_messages
+WHERE
+    message_type = 'alert_notification'
+    AND user_id = ANY ($1::uuid[])
+    AND last_status NOT IN ('pending', 'sending', 'failed')
+    AND coalesce(sent_at, last_status_at) >= $2::timestamptz
+    AND coalesce(sent_at, last_status_at) < $3::timestamptz
+GROUP BY
+    user_id,
+    alert_id
+`
+
+type Workload_PagesParams struct {
+	UserIds   []uuid.UUID
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+type Workload_PagesRow struct {
+	UserID     uuid.UUID
+	AlertID    int64
+	NotifiedAt time.Time
+}
+
+// Returns the time each user was first notified of each alert within the given time range.
+func (q *Queries) Workload_Pages(ctx context.Context, arg Workload_PagesParams) ([]Workload_PagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, workload_Pages, pq.Array(arg.UserIds), arg.StartTime, arg.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Workload_PagesRow
+	for rows.Next() {
+		var i Workload_PagesRow
+		if err := rows.Scan(&i.UserID, &i.AlertID, &i.NotifiedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const workload_UserNames = `-- name: Workload_UserNames :many
+SELECT
+    id,
+    name
+FROM
+    users
+WHERE
+    id = ANY ($1::uuid[])
+`
+
+type Workload_UserNamesRow struct {
+	ID   uuid.UUID
+	Name string
+}
+
+// Returns the names of the given users.
+func (q *Queries) Workload_UserNames(ctx context.Context, userIds []uuid.UUID) ([]Workload_UserNamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, workload_UserNames, pq.Array(userIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Workload_UserNamesRow
+	for rows.Next() {
+		var i Workload_UserNamesRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const workload_UserShifts = `-- name: Workload_UserShifts :many
+SELECT
+    user_id,
+    start_time,
+    end_time
+FROM
+    schedule_on_call_users
+WHERE
+    user_id = $1
+    AND start_time < $2::timestamptz
+    AND (end_time ISNULL
+        OR end_time > $3::timestamptz)
+`
+
+type Workload_UserShiftsParams struct {
+	UserID    uuid.UUID
+	EndTime   time.Time
+	StartTime time.Time
+}
+
+type Workload_UserShiftsRow struct {
+	UserID    uuid.UUID
+	StartTime time.Time
+	EndTime   sql.NullTime
+}
+
+// Returns the schedule shifts of a user that overlap the given time range.
+func (q *Queries) Workload_UserShifts(ctx context.Context, arg Workload_UserShiftsParams) ([]Workload_UserShiftsRow, error) {
+	rows, err := q.db.QueryContext(ctx, workload_UserShifts, arg.UserID, arg.EndTime, arg.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Workload_UserShiftsRow
+	for rows.Next() {
+		var i Workload_UserShiftsRow
+		if err := rows.Scan(&i.UserID, &i.StartTime, &i.EndTime); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
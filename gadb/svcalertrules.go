@@ -0,0 +1,60 @@
+package gadb
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// SvcAlertRules stores the expression rules applied to every alert entering a service.
+type SvcAlertRules struct {
+	Version int
+	V1      SvcAlertRulesV1
+}
+
+// Scan implements the Scanner interface.
+func (cfg *SvcAlertRules) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case json.RawMessage:
+		return json.Unmarshal(v, cfg)
+	case []byte:
+		return json.Unmarshal(v, cfg)
+	case string:
+		return json.Unmarshal([]byte(v), cfg)
+	default:
+		return fmt.Errorf("unsupported scan for SvcAlertRules type: %T", value)
+	}
+}
+
+// Value implements the driver Valuer interface.
+func (cfg SvcAlertRules) Value() (interface{}, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.RawMessage(data), nil
+}
+
+// SvcAlertRulesV1 stores the expression rules applied to every alert entering a service, regardless of source.
+type SvcAlertRulesV1 struct {
+	Rules []SvcAlertRuleV1
+}
+
+// SvcAlertRuleV1 is a condition and the changes to make to an alert if the condition is met.
+type SvcAlertRuleV1 struct {
+	ID            uuid.UUID
+	Name          string
+	Description   string
+	ConditionExpr string
+
+	// Drop indicates the alert should be discarded if the rule matches.
+	Drop bool
+
+	// Set contains the alert fields to change if the rule matches.
+	// The keys are field names (e.g., `summary` or `meta.<key>`), and the values are the Expr expression strings.
+	Set map[string]string
+
+	ContinueAfterMatch bool
+}
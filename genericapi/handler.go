@@ -79,6 +79,7 @@ func (h *Handler) ServeCreateAlert(w http.ResponseWriter, r *http.Request) {
 	details := r.FormValue("details")
 	action := r.FormValue("action")
 	dedup := r.FormValue("dedup")
+	priority := r.FormValue("priority")
 
 	meta := make(map[string]string)
 	for _, v := range r.Form["meta"] {
@@ -97,8 +98,8 @@ func (h *Handler) ServeCreateAlert(w http.ResponseWriter, r *http.Request) {
 		}
 
 		var b struct {
-			Summary, Details, Action, Dedup *string
-			Meta                            map[string]string
+			Summary, Details, Action, Dedup, Priority *string
+			Meta                                      map[string]string
 		}
 		err = json.Unmarshal(data, &b)
 		if errutil.HTTPError(ctx, w, validation.WrapError(err)) {
@@ -117,6 +118,9 @@ func (h *Handler) ServeCreateAlert(w http.ResponseWriter, r *http.Request) {
 		if b.Action != nil {
 			action = *b.Action
 		}
+		if b.Priority != nil {
+			priority = *b.Priority
+		}
 		if b.Meta != nil {
 			meta = b.Meta
 		}
@@ -127,6 +131,11 @@ func (h *Handler) ServeCreateAlert(w http.ResponseWriter, r *http.Request) {
 		status = alert.StatusClosed
 	}
 
+	prio, err := alert.ParsePriority(priority)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
 	summary = validate.SanitizeText(summary, alert.MaxSummaryLength)
 	details = validate.SanitizeText(details, alert.MaxDetailsLength)
 
@@ -137,6 +146,7 @@ func (h *Handler) ServeCreateAlert(w http.ResponseWriter, r *http.Request) {
 		ServiceID: serviceID,
 		Dedup:     alert.NewUserDedup(dedup),
 		Status:    status,
+		Priority:  prio,
 	}
 
 	var resp struct {
@@ -109,6 +109,12 @@ func alertsFromLegacy(ctx context.Context, req *http.Request, serviceID string,
 		body += "\n\n![Panel Snapshot](" + g.ImageURL + ")"
 	}
 
+	// legacy payloads have no severity, so only the query param is considered
+	prio, err := alert.ParsePriority(req.FormValue("priority"))
+	if err != nil {
+		return nil, err
+	}
+
 	// dedupe is description, source, and serviceID
 	return []alert.Alert{{
 		Summary:   validate.SanitizeText(g.RuleName, alert.MaxSummaryLength),
@@ -117,10 +123,11 @@ func alertsFromLegacy(ctx context.Context, req *http.Request, serviceID string,
 		ServiceID: serviceID,
 		Source:    alert.SourceGrafana,
 		Dedup:     alert.NewUserDedup(req.FormValue("dedup")),
+		Priority:  prio,
 	}}, nil
 }
 
-func alertsFromV1(ctx context.Context, serviceID string, data []byte) ([]alert.Alert, error) {
+func alertsFromV1(ctx context.Context, req *http.Request, serviceID string, data []byte) ([]alert.Alert, error) {
 	var g struct {
 		Alerts []struct {
 			Status              string
@@ -137,6 +144,12 @@ func alertsFromV1(ctx context.Context, serviceID string, data []byte) ([]alert.A
 		return nil, err
 	}
 
+	// an explicit priority param takes precedence over alert labels
+	prioOverride, err := alert.ParsePriority(req.FormValue("priority"))
+	if err != nil {
+		return nil, err
+	}
+
 	var alerts []alert.Alert
 	for _, a := range g.Alerts {
 		var alertStatus alert.Status
@@ -159,6 +172,11 @@ func alertsFromV1(ctx context.Context, serviceID string, data []byte) ([]alert.A
 			summary = a.Labels["alertname"]
 		}
 
+		prio := prioOverride
+		if !prio.IsSet() {
+			prio = alert.PriorityFromLabels(a.Labels)
+		}
+
 		alerts = append(alerts, alert.Alert{
 			Summary:   validate.SanitizeText(summary, alert.MaxSummaryLength),
 			Details:   validate.SanitizeText(buf.String(), alert.MaxDetailsLength),
@@ -166,6 +184,7 @@ func alertsFromV1(ctx context.Context, serviceID string, data []byte) ([]alert.A
 			ServiceID: serviceID,
 			Source:    alert.SourceGrafana,
 			Dedup:     alert.NewUserDedup(a.Fingerprint),
+			Priority:  prio,
 		})
 	}
 
@@ -196,7 +215,7 @@ func GrafanaToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.Hand
 		var alerts []alert.Alert
 		switch versionInfo.Version {
 		case "1":
-			alerts, err = alertsFromV1(ctx, serviceID, data)
+			alerts, err = alertsFromV1(ctx, r, serviceID, data)
 		case "":
 			alerts, err = alertsFromLegacy(ctx, r, serviceID, data)
 		default:
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/target/goalert/alert"
@@ -290,6 +291,9 @@ func (q *Query) Alerts(ctx context.Context, opts *graphql2.AlertSearchOptions) (
 				s.Status = append(s.Status, alert.StatusClosed)
 			}
 		}
+		for _, p := range opts.FilterByPriority {
+			s.Priority = append(s.Priority, alert.Priority(p))
+		}
 		if opts.Sort != nil {
 			switch *opts.Sort {
 			case graphql2.AlertSearchSortStatusID:
@@ -347,7 +351,11 @@ func (a *Alert) ID(ctx context.Context, raw *alert.Alert) (string, error) {
 }
 
 func (a *Alert) Status(ctx context.Context, raw *alert.Alert) (graphql2.AlertStatus, error) {
-	switch raw.Status {
+	return gqlAlertStatus(raw.Status)
+}
+
+func gqlAlertStatus(s alert.Status) (graphql2.AlertStatus, error) {
+	switch s {
 	case alert.StatusTriggered:
 		return graphql2.AlertStatusStatusUnacknowledged, nil
 	case alert.StatusClosed:
@@ -355,7 +363,25 @@ func (a *Alert) Status(ctx context.Context, raw *alert.Alert) (graphql2.AlertSta
 	case alert.StatusActive:
 		return graphql2.AlertStatusStatusAcknowledged, nil
 	}
-	return "", errors.New("unknown alert status " + string(raw.Status))
+	return "", errors.New("unknown alert status " + string(s))
+}
+
+func (a *Alert) Priority(ctx context.Context, raw *alert.Alert) (int, error) {
+	return int(raw.Priority), nil
+}
+
+func (a *Alert) SnoozedUntil(ctx context.Context, raw *alert.Alert) (*time.Time, error) {
+	if raw.SnoozedUntil.IsZero() {
+		return nil, nil
+	}
+	return &raw.SnoozedUntil, nil
+}
+
+func (a *Alert) FlappingUntil(ctx context.Context, raw *alert.Alert) (*time.Time, error) {
+	if !raw.FlappingUntil.After(time.Now()) {
+		return nil, nil
+	}
+	return &raw.FlappingUntil, nil
 }
 
 func (a *Alert) AlertID(ctx context.Context, raw *alert.Alert) (int, error) {
@@ -415,6 +441,10 @@ func (m *Mutation) CreateAlert(ctx context.Context, input graphql2.CreateAlertIn
 		a.Dedup = alert.NewUserDedup(*input.Dedup)
 	}
 
+	if input.Priority != nil {
+		a.Priority = alert.Priority(*input.Priority)
+	}
+
 	var meta map[string]string
 	if input.Meta != nil {
 		meta = make(map[string]string, len(input.Meta))
@@ -432,19 +462,8 @@ func (m *Mutation) CreateAlert(ctx context.Context, input graphql2.CreateAlertIn
 	var newAlert *alert.Alert
 	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
-		newAlert, err = m.AlertStore.CreateTx(ctx, tx, a)
-		if err != nil {
-			return err
-		}
-
-		if meta != nil {
-			err = m.AlertStore.SetMetadataTx(ctx, tx, newAlert.ID, meta)
-			if err != nil {
-				return err
-			}
-		}
-
-		return nil
+		newAlert, err = m.AlertStore.CreateWithMetaTx(ctx, tx, a, meta)
+		return err
 	})
 	if err != nil {
 		return nil, err
@@ -603,6 +622,9 @@ func (m *Mutation) UpdateAlerts(ctx context.Context, args graphql2.UpdateAlertsI
 	if args.NewStatus != nil && args.NoiseReason != nil {
 		return nil, validation.NewGenericError("cannot set both 'newStatus' and 'noiseReason'")
 	}
+	if args.SnoozeMinutes != nil && (args.NewStatus != nil || args.NoiseReason != nil) {
+		return nil, validation.NewGenericError("cannot set 'snoozeMinutes' with 'newStatus' or 'noiseReason'")
+	}
 
 	var updatedIDs []int
 	if args.NewStatus != nil {
@@ -619,10 +641,14 @@ func (m *Mutation) UpdateAlerts(ctx context.Context, args graphql2.UpdateAlertsI
 			status = alert.StatusClosed
 		}
 
-		updatedIDs, err = m.AlertStore.UpdateManyAlertStatus(ctx, status, args.AlertIDs, nil)
+		var heldIDs []int
+		updatedIDs, heldIDs, err = m.AlertStore.UpdateManyAlertStatus(ctx, status, args.AlertIDs, nil)
 		if err != nil {
 			return nil, err
 		}
+		for _, id := range heldIDs {
+			graphql.AddErrorf(ctx, "alert #%d: acknowledgement recorded, alert requires more acknowledgements", id)
+		}
 	}
 
 	if args.NoiseReason != nil {
@@ -633,6 +659,14 @@ func (m *Mutation) UpdateAlerts(ctx context.Context, args graphql2.UpdateAlertsI
 		}
 	}
 
+	if args.SnoozeMinutes != nil {
+		var err error
+		updatedIDs, err = m.AlertStore.SnoozeManyAlerts(ctx, args.AlertIDs, time.Duration(*args.SnoozeMinutes)*time.Minute)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return m.AlertStore.FindMany(ctx, updatedIDs)
 }
 
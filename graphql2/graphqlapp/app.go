@@ -20,19 +20,24 @@ import (
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/alert/alertmetrics"
+	"github.com/target/goalert/alert/alertrule"
 	"github.com/target/goalert/apikey"
 	"github.com/target/goalert/auth"
 	"github.com/target/goalert/auth/authlink"
 	"github.com/target/goalert/auth/basic"
+	"github.com/target/goalert/availability"
 	"github.com/target/goalert/calsub"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/heartbeat"
+	"github.com/target/goalert/holiday"
+	"github.com/target/goalert/incident"
 	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
+	"github.com/target/goalert/maintenance"
 	"github.com/target/goalert/notice"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notification/nfydest"
@@ -42,6 +47,7 @@ import (
 	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/quiethours"
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
@@ -56,6 +62,7 @@ import (
 	"github.com/target/goalert/util/errutil"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/validation"
+	"github.com/target/goalert/workload"
 	"github.com/vektah/gqlparser/v2/ast"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 )
@@ -77,6 +84,10 @@ type App struct {
 	CalSubStore       *calsub.Store
 	RotationStore     *rotation.Store
 	OnCallStore       *oncall.Store
+	WorkloadStore     *workload.Store
+	HolidayStore      *holiday.Store
+	AvailStore        *availability.Store
+	QuietHoursStore   *quiethours.Store
 	IntKeyStore       *integrationkey.Store
 	LabelStore        *label.Store
 	RuleStore         *rule.Store
@@ -85,6 +96,9 @@ type App struct {
 	LimitStore        *limit.Store
 	SlackStore        *slack.ChannelSender
 	HeartbeatStore    *heartbeat.Store
+	IncidentStore     *incident.Store
+	MaintStore        *maintenance.Store
+	AlertRuleStore    *alertrule.Store
 	NoticeStore       *notice.Store
 	APIKeyStore       *apikey.Store
 
@@ -0,0 +1,123 @@
+package graphqlapp
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/target/goalert/availability"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/notice"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+)
+
+type UserAvailabilityFeed App
+
+func (a *App) UserAvailabilityFeed() graphql2.UserAvailabilityFeedResolver {
+	return (*UserAvailabilityFeed)(a)
+}
+
+func (f *UserAvailabilityFeed) TimeZone(ctx context.Context, raw *availability.Feed) (string, error) {
+	return raw.TimeZone.String(), nil
+}
+
+func (f *UserAvailabilityFeed) SubstituteUser(ctx context.Context, raw *availability.Feed) (*user.User, error) {
+	if raw.SubstituteUserID == "" {
+		return nil, nil
+	}
+	return (*App)(f).FindOneUser(ctx, raw.SubstituteUserID)
+}
+
+func (f *UserAvailabilityFeed) LastSyncAt(ctx context.Context, raw *availability.Feed) (*time.Time, error) {
+	if raw.LastSyncAt.IsZero() {
+		return nil, nil
+	}
+	return &raw.LastSyncAt, nil
+}
+
+func (f *UserAvailabilityFeed) Periods(ctx context.Context, raw *availability.Feed) ([]availability.Period, error) {
+	return f.AvailStore.Periods(ctx, raw.ID)
+}
+
+func (u *User) AvailabilityFeeds(ctx context.Context, raw *user.User) ([]availability.Feed, error) {
+	return u.AvailStore.FindAllByUser(ctx, raw.ID)
+}
+
+func (s *Schedule) Notices(ctx context.Context, raw *schedule.Schedule) ([]notice.Notice, error) {
+	return s.NoticeStore.FindAllScheduleNotices(ctx, raw.ID)
+}
+
+func (m *Mutation) CreateUserAvailabilityFeed(ctx context.Context, input graphql2.CreateUserAvailabilityFeedInput) (feed *availability.Feed, err error) {
+	loc, err := util.LoadLocation(input.TimeZone)
+	if err != nil {
+		return nil, validation.NewFieldError("timeZone", err.Error())
+	}
+	f := &availability.Feed{
+		UserID:   input.UserID,
+		Name:     input.Name,
+		URL:      input.URL,
+		TimeZone: loc,
+	}
+	if input.Keyword != nil {
+		f.Keyword = *input.Keyword
+	}
+	if input.SubstituteUserID != nil {
+		f.SubstituteUserID = *input.SubstituteUserID
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		feed, err = m.AvailStore.CreateTx(ctx, tx, f)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return feed, nil
+}
+
+func (m *Mutation) UpdateUserAvailabilityFeed(ctx context.Context, input graphql2.UpdateUserAvailabilityFeedInput) (bool, error) {
+	f, err := m.AvailStore.FindOne(ctx, input.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, validation.NewFieldError("id", "not found")
+	}
+	if err != nil {
+		return false, err
+	}
+	if input.Name != nil {
+		f.Name = *input.Name
+	}
+	if input.URL != nil {
+		f.URL = *input.URL
+	}
+	if input.Keyword != nil {
+		f.Keyword = *input.Keyword
+	}
+	if input.TimeZone != nil {
+		f.TimeZone, err = util.LoadLocation(*input.TimeZone)
+		if err != nil {
+			return false, validation.NewFieldError("timeZone", err.Error())
+		}
+	}
+	if input.SubstituteUserID != nil {
+		f.SubstituteUserID = *input.SubstituteUserID
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.AvailStore.UpdateTx(ctx, tx, f)
+	})
+
+	return err == nil, err
+}
+
+func (m *Mutation) DeleteUserAvailabilityFeed(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.AvailStore.DeleteTx(ctx, tx, id)
+	})
+
+	return err == nil, err
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/service"
 )
 
 type UserCalendarSubscription App
@@ -25,10 +27,48 @@ func (a *UserCalendarSubscription) FullSchedule(ctx context.Context, obj *calsub
 	return obj.Config.FullSchedule, nil
 }
 
+func (a *UserCalendarSubscription) ScheduleID(ctx context.Context, obj *calsub.Subscription) (*string, error) {
+	if obj.ScheduleID == "" {
+		return nil, nil
+	}
+	return &obj.ScheduleID, nil
+}
+
 func (a *UserCalendarSubscription) Schedule(ctx context.Context, obj *calsub.Subscription) (*schedule.Schedule, error) {
+	if obj.ScheduleID == "" {
+		return nil, nil
+	}
 	return a.ScheduleStore.FindOne(ctx, obj.ScheduleID)
 }
 
+func (a *UserCalendarSubscription) RotationID(ctx context.Context, obj *calsub.Subscription) (*string, error) {
+	if obj.RotationID == "" {
+		return nil, nil
+	}
+	return &obj.RotationID, nil
+}
+
+func (a *UserCalendarSubscription) Rotation(ctx context.Context, obj *calsub.Subscription) (*rotation.Rotation, error) {
+	if obj.RotationID == "" {
+		return nil, nil
+	}
+	return (*App)(a).FindOneRotation(ctx, obj.RotationID)
+}
+
+func (a *UserCalendarSubscription) ServiceID(ctx context.Context, obj *calsub.Subscription) (*string, error) {
+	if obj.ServiceID == "" {
+		return nil, nil
+	}
+	return &obj.ServiceID, nil
+}
+
+func (a *UserCalendarSubscription) Service(ctx context.Context, obj *calsub.Subscription) (*service.Service, error) {
+	if obj.ServiceID == "" {
+		return nil, nil
+	}
+	return (*App)(a).FindOneService(ctx, obj.ServiceID)
+}
+
 func (a *UserCalendarSubscription) URL(ctx context.Context, obj *calsub.Subscription) (*string, error) {
 	tok := obj.Token()
 	if tok == "" {
@@ -50,9 +90,17 @@ func (q *Query) UserCalendarSubscription(ctx context.Context, id string) (*calsu
 // todo: return UserCalendarSubscription with generated url once endpoint has been created
 func (m *Mutation) CreateUserCalendarSubscription(ctx context.Context, input graphql2.CreateUserCalendarSubscriptionInput) (cs *calsub.Subscription, err error) {
 	cs = &calsub.Subscription{
-		Name:       input.Name,
-		ScheduleID: input.ScheduleID,
-		UserID:     permission.UserID(ctx),
+		Name:   input.Name,
+		UserID: permission.UserID(ctx),
+	}
+	if input.ScheduleID != nil {
+		cs.ScheduleID = *input.ScheduleID
+	}
+	if input.RotationID != nil {
+		cs.RotationID = *input.RotationID
+	}
+	if input.ServiceID != nil {
+		cs.ServiceID = *input.ServiceID
 	}
 	if input.Disabled != nil {
 		cs.Disabled = *input.Disabled
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"slices"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/target/goalert/assignment"
@@ -19,6 +20,7 @@ import (
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/search"
 	"github.com/target/goalert/user"
+	"github.com/target/goalert/util"
 	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
@@ -26,6 +28,7 @@ import (
 type (
 	EscalationPolicy                App
 	EscalationPolicyStep            App
+	StepActiveHours                 App
 	CreateEscalationPolicyStepInput App
 	UpdateEscalationPolicyStepInput App
 )
@@ -35,6 +38,10 @@ func (a *App) EscalationPolicyStep() graphql2.EscalationPolicyStepResolver {
 	return (*EscalationPolicyStep)(a)
 }
 
+func (a *App) StepActiveHours() graphql2.StepActiveHoursResolver {
+	return (*StepActiveHours)(a)
+}
+
 func (a *App) CreateEscalationPolicyStepInput() graphql2.CreateEscalationPolicyStepInputResolver {
 	return (*CreateEscalationPolicyStepInput)(a)
 }
@@ -115,6 +122,19 @@ func (m *Mutation) CreateEscalationPolicyStep(ctx context.Context, input graphql
 		if input.EscalationPolicyID != nil {
 			s.PolicyID = *input.EscalationPolicyID
 		}
+		s.ActiveHours, err = activeHoursFromInput(input.ActiveHours)
+		if err != nil {
+			return err
+		}
+		if input.Mode != nil {
+			s.Mode = *input.Mode
+		}
+		if input.RoundRobinMinutes != nil {
+			s.RoundRobinMinutes = *input.RoundRobinMinutes
+		}
+		if input.AckCount != nil {
+			s.AckCount = *input.AckCount
+		}
 
 		step, err = m.PolicyStore.CreateStepTx(ctx, tx, s)
 		if err != nil {
@@ -288,6 +308,38 @@ func (m *Mutation) UpdateEscalationPolicyStep(ctx context.Context, input graphql
 			}
 		}
 
+		// update active hours if provided
+		if input.ActiveHours.IsSet() {
+			h, err := activeHoursFromInput(input.ActiveHours.Value())
+			if err != nil {
+				return err
+			}
+
+			err = m.PolicyStore.UpdateStepActiveHoursTx(ctx, tx, step.ID, h)
+			if err != nil {
+				return validation.AddPrefix("activeHours.", err)
+			}
+		}
+
+		// update mode if provided
+		if input.Mode != nil || input.RoundRobinMinutes != nil || input.AckCount != nil {
+			mode := step.ModeConfig
+			if input.Mode != nil && *input.Mode != mode.Mode {
+				mode = escalation.ModeConfig{Mode: *input.Mode}
+			}
+			if input.RoundRobinMinutes != nil {
+				mode.RoundRobinMinutes = *input.RoundRobinMinutes
+			}
+			if input.AckCount != nil {
+				mode.AckCount = *input.AckCount
+			}
+
+			err = m.PolicyStore.UpdateStepModeTx(ctx, tx, step.ID, mode)
+			if err != nil {
+				return err
+			}
+		}
+
 		// update targets if provided
 		if input.Actions != nil {
 			// get current actions
@@ -358,6 +410,32 @@ func (step *EscalationPolicyStep) Targets(ctx context.Context, raw *escalation.S
 	return targets, nil
 }
 
+func activeHoursFromInput(input *graphql2.StepActiveHoursInput) (*escalation.ActiveHours, error) {
+	if input == nil {
+		return nil, nil
+	}
+
+	loc, err := util.LoadLocation(input.TimeZone)
+	if err != nil {
+		return nil, validation.NewFieldError("activeHours.timeZone", err.Error())
+	}
+
+	return &escalation.ActiveHours{
+		WeekdayFilter: input.WeekdayFilter,
+		Start:         input.Start,
+		End:           input.End,
+		TimeZone:      loc,
+	}, nil
+}
+
+func (h *StepActiveHours) TimeZone(ctx context.Context, raw *escalation.ActiveHours) (string, error) {
+	return raw.TimeZone.String(), nil
+}
+
+func (h *StepActiveHours) IsActive(ctx context.Context, raw *escalation.ActiveHours) (bool, error) {
+	return raw.IsActive(time.Now()), nil
+}
+
 func (step *EscalationPolicyStep) EscalationPolicy(ctx context.Context, raw *escalation.Step) (*escalation.Policy, error) {
 	return (*App)(step).FindOnePolicy(ctx, raw.PolicyID)
 }
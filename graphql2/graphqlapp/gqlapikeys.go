@@ -51,6 +51,7 @@ func (q *Query) GqlAPIKeys(ctx context.Context) ([]graphql2.GQLAPIKey, error) {
 			ExpiresAt:   k.ExpiresAt,
 			Query:       k.Query,
 			Role:        graphql2.UserRole(k.Role),
+			Rest:        k.REST,
 		}
 
 		if k.CreatedBy != nil {
@@ -99,6 +100,7 @@ func (a *Mutation) CreateGQLAPIKey(ctx context.Context, input graphql2.CreateGQL
 		Expires: input.ExpiresAt,
 		Query:   input.Query,
 		Role:    permission.Role(input.Role),
+		REST:    input.Rest != nil && *input.Rest,
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,125 @@
+package graphqlapp
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/holiday"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/validation"
+)
+
+type (
+	Holiday         App
+	HolidayCalendar App
+)
+
+func (a *App) Holiday() graphql2.HolidayResolver                 { return (*Holiday)(a) }
+func (a *App) HolidayCalendar() graphql2.HolidayCalendarResolver { return (*HolidayCalendar)(a) }
+
+func (h *Holiday) Date(ctx context.Context, raw *holiday.Holiday) (string, error) {
+	return raw.Date.Format(time.DateOnly), nil
+}
+
+func (h *HolidayCalendar) Holidays(ctx context.Context, cal *holiday.Calendar, start, end time.Time) ([]holiday.Holiday, error) {
+	if end.Before(start) {
+		return nil, validation.NewFieldError("end", "must not be before start")
+	}
+	if end.Sub(start) > 2*366*24*time.Hour {
+		return nil, validation.NewFieldError("end", "must be within 2 years of start")
+	}
+
+	return h.HolidayStore.Holidays(ctx, cal.ID, start, end)
+}
+
+func (q *Query) HolidayCalendar(ctx context.Context, id string) (*holiday.Calendar, error) {
+	cal, err := q.HolidayStore.FindOne(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return cal, err
+}
+
+func (q *Query) HolidayCalendars(ctx context.Context) ([]holiday.Calendar, error) {
+	return q.HolidayStore.FindAll(ctx)
+}
+
+func (s *Schedule) HolidayCalendars(ctx context.Context, raw *schedule.Schedule) ([]holiday.Calendar, error) {
+	return s.HolidayStore.ScheduleCalendars(ctx, raw.ID)
+}
+
+func (m *Mutation) CreateHolidayCalendar(ctx context.Context, input graphql2.CreateHolidayCalendarInput) (cal *holiday.Calendar, err error) {
+	c := &holiday.Calendar{Name: input.Name}
+	if input.SourceURL != nil {
+		c.SourceURL = *input.SourceURL
+	}
+	var ics []byte
+	if input.Ics != nil {
+		ics = []byte(*input.Ics)
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		cal, err = m.HolidayStore.CreateTx(ctx, tx, c, ics)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cal, nil
+}
+
+func (m *Mutation) UpdateHolidayCalendar(ctx context.Context, input graphql2.UpdateHolidayCalendarInput) (bool, error) {
+	cal, err := m.HolidayStore.FindOne(ctx, input.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, validation.NewFieldError("id", "not found")
+	}
+	if err != nil {
+		return false, err
+	}
+	if input.Name != nil {
+		cal.Name = *input.Name
+	}
+	if input.SourceURL != nil {
+		cal.SourceURL = *input.SourceURL
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.HolidayStore.UpdateTx(ctx, tx, cal)
+	})
+
+	return err == nil, err
+}
+
+func (m *Mutation) ImportHolidayCalendar(ctx context.Context, input graphql2.ImportHolidayCalendarInput) (bool, error) {
+	var ics []byte
+	if input.Ics != nil {
+		ics = []byte(*input.Ics)
+	}
+
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.HolidayStore.ImportTx(ctx, tx, input.ID, ics)
+	})
+
+	return err == nil, err
+}
+
+func (m *Mutation) DeleteHolidayCalendar(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.HolidayStore.DeleteManyTx(ctx, tx, []string{id})
+	})
+
+	return err == nil, err
+}
+
+func (m *Mutation) SetScheduleHolidayCalendars(ctx context.Context, input graphql2.SetScheduleHolidayCalendarsInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.HolidayStore.SetScheduleCalendarsTx(ctx, tx, input.ScheduleID, input.CalendarIDs)
+	})
+
+	return err == nil, err
+}
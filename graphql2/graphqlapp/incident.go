@@ -0,0 +1,191 @@
+package graphqlapp
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/incident"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/validation/validate"
+)
+
+type Incident App
+
+func (a *App) Incident() graphql2.IncidentResolver { return (*Incident)(a) }
+
+func (a *Incident) Status(ctx context.Context, inc *incident.Incident) (graphql2.AlertStatus, error) {
+	return gqlAlertStatus(inc.Status)
+}
+
+func (a *Incident) Alerts(ctx context.Context, inc *incident.Incident) ([]alert.Alert, error) {
+	ids, err := a.IncidentStore.AlertIDs(ctx, inc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.AlertStore.FindMany(ctx, ids)
+}
+
+func (a *Incident) Timeline(ctx context.Context, inc *incident.Incident, opts *graphql2.AlertRecentEventsOptions) (*graphql2.AlertLogEntryConnection, error) {
+	return (*App)(a).RecentAlertEvents(ctx, opts, alertlog.SearchOptions{FilterIncidentID: inc.ID})
+}
+
+func (a *Alert) Incident(ctx context.Context, raw *alert.Alert) (*incident.Incident, error) {
+	ids, err := a.IncidentStore.IncidentIDsByAlert(ctx, []int{raw.ID})
+	if err != nil {
+		return nil, err
+	}
+	id, ok := ids[raw.ID]
+	if !ok {
+		return nil, nil
+	}
+
+	return (*App)(a).findOneIncident(ctx, id)
+}
+
+func (a *App) findOneIncident(ctx context.Context, id int) (*incident.Incident, error) {
+	inc, err := a.IncidentStore.FindOne(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return inc, err
+}
+
+func (q *Query) Incident(ctx context.Context, id int) (*incident.Incident, error) {
+	return (*App)(q).findOneIncident(ctx, id)
+}
+
+func (q *Query) Incidents(ctx context.Context, opts *graphql2.IncidentSearchOptions) (*graphql2.IncidentConnection, error) {
+	if opts == nil {
+		opts = new(graphql2.IncidentSearchOptions)
+	}
+
+	var s incident.SearchOptions
+	if opts.After != nil && *opts.After != "" {
+		err := search.ParseCursor(*opts.After, &s)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if opts.Search != nil {
+		s.Search = *opts.Search
+	}
+	if opts.OmitClosed != nil {
+		s.OmitClosed = *opts.OmitClosed
+	}
+	if opts.First != nil {
+		s.Limit = *opts.First
+	}
+	if s.Limit == 0 {
+		s.Limit = search.DefaultMaxResults
+	}
+
+	err := validate.Range("First", s.Limit, 1, search.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Limit++
+	incs, err := q.IncidentStore.Search(ctx, &s)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := new(graphql2.IncidentConnection)
+	conn.PageInfo = &graphql2.PageInfo{}
+	if len(incs) == s.Limit {
+		incs = incs[:len(incs)-1]
+		conn.PageInfo.HasNextPage = true
+	}
+	if len(incs) > 0 {
+		s.After.ID = incs[len(incs)-1].ID
+		cur, err := search.Cursor(s)
+		if err != nil {
+			return nil, err
+		}
+		conn.PageInfo.EndCursor = &cur
+	}
+	conn.Nodes = incs
+
+	return conn, nil
+}
+
+func (m *Mutation) CreateIncident(ctx context.Context, input graphql2.CreateIncidentInput) (inc *incident.Incident, err error) {
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		inc = &incident.Incident{Summary: input.Summary}
+		if input.Details != nil {
+			inc.Details = *input.Details
+		}
+
+		inc, err = m.IncidentStore.CreateTx(ctx, tx, inc)
+		if err != nil {
+			return err
+		}
+		if len(input.AlertIDs) == 0 {
+			return nil
+		}
+
+		return m.IncidentStore.AddAlertsTx(ctx, tx, inc.ID, input.AlertIDs)
+	})
+
+	return inc, err
+}
+
+func (m *Mutation) UpdateIncident(ctx context.Context, input graphql2.UpdateIncidentInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		inc, err := m.IncidentStore.FindOneTx(ctx, tx, input.ID)
+		if err != nil {
+			return err
+		}
+		if input.Summary != nil {
+			inc.Summary = *input.Summary
+		}
+		if input.Details != nil {
+			inc.Details = *input.Details
+		}
+
+		return m.IncidentStore.UpdateTx(ctx, tx, inc)
+	})
+
+	return err == nil, err
+}
+
+func (m *Mutation) UpdateIncidentStatus(ctx context.Context, input graphql2.UpdateIncidentStatusInput) (*incident.Incident, error) {
+	err := validate.OneOf("NewStatus", input.NewStatus, graphql2.AlertStatusStatusAcknowledged, graphql2.AlertStatusStatusClosed)
+	if err != nil {
+		return nil, err
+	}
+
+	status := alert.StatusActive
+	if input.NewStatus == graphql2.AlertStatusStatusClosed {
+		status = alert.StatusClosed
+	}
+
+	err = m.IncidentStore.UpdateStatus(ctx, input.ID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.IncidentStore.FindOne(ctx, input.ID)
+}
+
+func (m *Mutation) AddIncidentAlerts(ctx context.Context, input graphql2.IncidentAlertsInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.IncidentStore.AddAlertsTx(ctx, tx, input.IncidentID, input.AlertIDs)
+	})
+
+	return err == nil, err
+}
+
+func (m *Mutation) RemoveIncidentAlerts(ctx context.Context, input graphql2.IncidentAlertsInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.IncidentStore.RemoveAlertsTx(ctx, tx, input.IncidentID, input.AlertIDs)
+	})
+
+	return err == nil, err
+}
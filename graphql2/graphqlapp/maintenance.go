@@ -0,0 +1,152 @@
+package graphqlapp
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/maintenance"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/util"
+)
+
+type MaintenanceWindow App
+
+func (a *App) MaintenanceWindow() graphql2.MaintenanceWindowResolver {
+	return (*MaintenanceWindow)(a)
+}
+
+func (a *MaintenanceWindow) ServiceID(ctx context.Context, w *maintenance.Window) (*string, error) {
+	if w.ServiceID == "" {
+		return nil, nil
+	}
+	return &w.ServiceID, nil
+}
+
+func (a *MaintenanceWindow) LabelKey(ctx context.Context, w *maintenance.Window) (*string, error) {
+	if w.LabelKey == "" {
+		return nil, nil
+	}
+	return &w.LabelKey, nil
+}
+
+func (a *MaintenanceWindow) TimeZone(ctx context.Context, w *maintenance.Window) (string, error) {
+	return w.TimeZone.String(), nil
+}
+
+func (a *MaintenanceWindow) CreatedBy(ctx context.Context, w *maintenance.Window) (*user.User, error) {
+	if w.CreatedBy == "" {
+		return nil, nil
+	}
+	return (*App)(a).FindOneUser(ctx, w.CreatedBy)
+}
+
+func (a *MaintenanceWindow) IsActive(ctx context.Context, w *maintenance.Window) (bool, error) {
+	return !w.ActiveSince.IsZero(), nil
+}
+
+func (a *MaintenanceWindow) NextStart(ctx context.Context, w *maintenance.Window) (*time.Time, error) {
+	start, _, ok := w.Next(time.Now())
+	if !ok {
+		return nil, nil
+	}
+	return &start, nil
+}
+
+func (a *MaintenanceWindow) NextEnd(ctx context.Context, w *maintenance.Window) (*time.Time, error) {
+	_, end, ok := w.Next(time.Now())
+	if !ok {
+		return nil, nil
+	}
+	return &end, nil
+}
+
+func (s *Service) MaintenanceWindows(ctx context.Context, raw *service.Service) ([]maintenance.Window, error) {
+	return s.MaintStore.FindAllByService(ctx, raw.ID)
+}
+
+func (q *Query) MaintenanceWindows(ctx context.Context) ([]maintenance.Window, error) {
+	return q.MaintStore.FindAll(ctx)
+}
+
+func (m *Mutation) CreateMaintenanceWindow(ctx context.Context, input graphql2.CreateMaintenanceWindowInput) (w *maintenance.Window, err error) {
+	w = &maintenance.Window{
+		Start: input.Start,
+		End:   input.End,
+	}
+	if input.ServiceID != nil {
+		w.ServiceID = *input.ServiceID
+	}
+	if input.LabelKey != nil {
+		w.LabelKey = *input.LabelKey
+	}
+	if input.LabelValue != nil {
+		w.LabelValue = *input.LabelValue
+	}
+	if input.Repeat != nil {
+		w.Repeat = *input.Repeat
+	}
+	if input.TimeZone != nil {
+		w.TimeZone, err = util.LoadLocation(*input.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if input.Reason != nil {
+		w.Reason = *input.Reason
+	}
+	if input.EndAction != nil {
+		w.EndAction = *input.EndAction
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		w, err = m.MaintStore.CreateTx(ctx, tx, w)
+		return err
+	})
+
+	return w, err
+}
+
+func (m *Mutation) UpdateMaintenanceWindow(ctx context.Context, input graphql2.UpdateMaintenanceWindowInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		w, err := m.MaintStore.FindOneTx(ctx, tx, input.ID)
+		if err != nil {
+			return err
+		}
+		if input.Start != nil {
+			w.Start = *input.Start
+		}
+		if input.End != nil {
+			w.End = *input.End
+		}
+		if input.Repeat != nil {
+			w.Repeat = *input.Repeat
+		}
+		if input.TimeZone != nil {
+			w.TimeZone, err = util.LoadLocation(*input.TimeZone)
+			if err != nil {
+				return err
+			}
+		}
+		if input.Reason != nil {
+			w.Reason = *input.Reason
+		}
+		if input.EndAction != nil {
+			w.EndAction = *input.EndAction
+		}
+
+		return m.MaintStore.UpdateTx(ctx, tx, w)
+	})
+
+	return err == nil, err
+}
+
+func (m *Mutation) DeleteMaintenanceWindow(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.MaintStore.DeleteTx(ctx, tx, id)
+	})
+
+	return err == nil, err
+}
@@ -108,6 +108,12 @@ func msgTypeFriendlyName(msgType gadb.EnumOutgoingMessagesType) string {
 		return "Status Update"
 	case gadb.EnumOutgoingMessagesTypeScheduleOnCallNotification:
 		return "On-Call Notification"
+	case gadb.EnumOutgoingMessagesTypeOverrideRequestNotification:
+		return "Override Request"
+	case gadb.EnumOutgoingMessagesTypeScheduleCoverageGapNotification:
+		return "Coverage Gap Notice"
+	case gadb.EnumOutgoingMessagesTypeQuietHoursDigest:
+		return "Quiet Hours Digest"
 	case gadb.EnumOutgoingMessagesTypeSignalMessage:
 		return "Signal Message"
 	case gadb.EnumOutgoingMessagesTypeAlertStatusUpdateBundle:
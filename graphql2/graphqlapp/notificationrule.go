@@ -4,6 +4,7 @@ import (
 	context "context"
 	"database/sql"
 
+	"github.com/target/goalert/alert"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/user/contactmethod"
 	"github.com/target/goalert/user/notificationrule"
@@ -33,6 +34,16 @@ func (m *Mutation) CreateUserNotificationRule(ctx context.Context, input graphql
 		nr.ContactMethodID = id
 	}
 
+	for _, p := range input.Priorities {
+		nr.Priorities = append(nr.Priorities, alert.Priority(p))
+	}
+	if input.ServiceLabelKey != nil {
+		nr.ServiceLabelKey = *input.ServiceLabelKey
+	}
+	if input.ServiceLabelValue != nil {
+		nr.ServiceLabelValue = *input.ServiceLabelValue
+	}
+
 	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		nr, err = m.NRStore.CreateTx(ctx, tx, nr)
@@ -45,6 +56,14 @@ func (m *Mutation) CreateUserNotificationRule(ctx context.Context, input graphql
 	return nr, nil
 }
 
+func (nr *UserNotificationRule) Priorities(ctx context.Context, raw *notificationrule.NotificationRule) ([]int, error) {
+	res := make([]int, len(raw.Priorities))
+	for i, p := range raw.Priorities {
+		res[i] = int(p)
+	}
+	return res, nil
+}
+
 func (nr *UserNotificationRule) ContactMethod(ctx context.Context, raw *notificationrule.NotificationRule) (*contactmethod.ContactMethod, error) {
 	return (*App)(nr).FindOneCM(ctx, raw.ContactMethodID)
 }
@@ -0,0 +1,140 @@
+package graphqlapp
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/override"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/user"
+)
+
+type OverrideRequest App
+
+func (a *App) OverrideRequest() graphql2.OverrideRequestResolver { return (*OverrideRequest)(a) }
+
+func (q *Query) OverrideRequest(ctx context.Context, id string) (*override.Request, error) {
+	r, err := q.OverrideStore.FindOneRequest(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return r, err
+}
+
+func (q *Query) OverrideRequests(ctx context.Context, input *graphql2.OverrideRequestSearchOptions) ([]override.Request, error) {
+	if input == nil {
+		input = &graphql2.OverrideRequestSearchOptions{}
+	}
+
+	opts := override.RequestSearchOptions{PendingOnly: true}
+	if input.TargetUserID != nil {
+		opts.TargetUserID = *input.TargetUserID
+	}
+	if input.RequesterUserID != nil {
+		opts.RequesterUserID = *input.RequesterUserID
+	}
+	if input.ScheduleID != nil {
+		opts.ScheduleID = *input.ScheduleID
+	}
+	if input.PendingOnly != nil {
+		opts.PendingOnly = *input.PendingOnly
+	}
+	if opts.TargetUserID == "" && opts.RequesterUserID == "" && opts.ScheduleID == "" {
+		// default to requests waiting on the current user
+		opts.TargetUserID = permission.UserID(ctx)
+	}
+
+	return q.OverrideStore.SearchRequests(ctx, opts)
+}
+
+func (m *Mutation) CreateOverrideRequest(ctx context.Context, input graphql2.CreateOverrideRequestInput) (r *override.Request, err error) {
+	req := &override.Request{
+		ScheduleID:   input.ScheduleID,
+		TargetUserID: input.TargetUserID,
+		Start:        input.Start,
+		End:          input.End,
+	}
+	if input.AddUserID != nil {
+		req.AddUserID = *input.AddUserID
+	}
+	if input.RemoveUserID != nil {
+		req.RemoveUserID = *input.RemoveUserID
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		r, err = m.OverrideStore.CreateRequestTx(ctx, tx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (m *Mutation) AcceptOverrideRequest(ctx context.Context, id string) (r *override.Request, err error) {
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		r, err = m.OverrideStore.AcceptRequestTx(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (m *Mutation) DeclineOverrideRequest(ctx context.Context, id string) (r *override.Request, err error) {
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		r, err = m.OverrideStore.DeclineRequestTx(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (m *Mutation) DeleteOverrideRequest(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.OverrideStore.DeleteRequestTx(ctx, tx, id)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *OverrideRequest) Schedule(ctx context.Context, raw *override.Request) (*schedule.Schedule, error) {
+	return (*App)(r).FindOneSchedule(ctx, raw.ScheduleID)
+}
+
+func (r *OverrideRequest) Requester(ctx context.Context, raw *override.Request) (*user.User, error) {
+	return (*App)(r).FindOneUser(ctx, raw.RequesterID)
+}
+
+func (r *OverrideRequest) TargetUser(ctx context.Context, raw *override.Request) (*user.User, error) {
+	return (*App)(r).FindOneUser(ctx, raw.TargetUserID)
+}
+
+func (r *OverrideRequest) AddUser(ctx context.Context, raw *override.Request) (*user.User, error) {
+	if raw.AddUserID == "" {
+		return nil, nil
+	}
+	return (*App)(r).FindOneUser(ctx, raw.AddUserID)
+}
+
+func (r *OverrideRequest) RemoveUser(ctx context.Context, raw *override.Request) (*user.User, error) {
+	if raw.RemoveUserID == "" {
+		return nil, nil
+	}
+	return (*App)(r).FindOneUser(ctx, raw.RemoveUserID)
+}
+
+func (r *OverrideRequest) Override(ctx context.Context, raw *override.Request) (*override.UserOverride, error) {
+	if raw.OverrideID == "" {
+		return nil, nil
+	}
+	return r.OverrideStore.FindOneUserOverrideTx(ctx, nil, raw.OverrideID, false)
+}
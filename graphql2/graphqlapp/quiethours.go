@@ -0,0 +1,58 @@
+package graphqlapp
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/quiethours"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+)
+
+type QuietHours App
+
+func (a *App) QuietHours() graphql2.QuietHoursResolver { return (*QuietHours)(a) }
+
+func (q *QuietHours) TimeZone(ctx context.Context, raw *quiethours.QuietHours) (string, error) {
+	return raw.TimeZone.String(), nil
+}
+
+func (u *User) QuietHours(ctx context.Context, raw *user.User) (*quiethours.QuietHours, error) {
+	return u.QuietHoursStore.FindOneByUser(ctx, raw.ID)
+}
+
+func (s *Service) QuietHours(ctx context.Context, raw *service.Service) (*quiethours.QuietHours, error) {
+	return s.QuietHoursStore.FindOneByService(ctx, raw.ID)
+}
+
+func (m *Mutation) SetQuietHours(ctx context.Context, input graphql2.SetQuietHoursInput) (bool, error) {
+	if (input.UserID == nil) == (input.ServiceID == nil) {
+		return false, validation.NewGenericError("exactly one of userID or serviceID must be set")
+	}
+
+	var q *quiethours.QuietHours
+	if input.QuietHours != nil {
+		loc, err := util.LoadLocation(input.QuietHours.TimeZone)
+		if err != nil {
+			return false, validation.NewFieldError("timeZone", err.Error())
+		}
+		q = &quiethours.QuietHours{
+			TimeZone: loc,
+			Start:    input.QuietHours.Start,
+			End:      input.QuietHours.End,
+		}
+	}
+
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		if input.UserID != nil {
+			return m.QuietHoursStore.SetUserTx(ctx, tx, *input.UserID, q)
+		}
+
+		return m.QuietHoursStore.SetServiceTx(ctx, tx, *input.ServiceID, q)
+	})
+
+	return err == nil, err
+}
@@ -3,6 +3,7 @@ package graphqlapp
 import (
 	context "context"
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/target/goalert/assignment"
@@ -44,6 +45,9 @@ func (m *Mutation) CreateRotation(ctx context.Context, input graphql2.CreateRota
 		if input.ShiftLength != nil {
 			rot.ShiftLength = *input.ShiftLength
 		}
+		if input.ActiveCount != nil {
+			rot.ActiveCount = *input.ActiveCount
+		}
 
 		result, err = m.RotationStore.CreateRotationTx(ctx, tx, rot)
 		if err != nil {
@@ -63,6 +67,20 @@ func (m *Mutation) CreateRotation(ctx context.Context, input graphql2.CreateRota
 				return err
 			}
 		}
+
+		if input.ParticipantWindows != nil {
+			err = setParticipantWindows(ctx, tx, m.RotationStore, result.ID, input.ParticipantWindows)
+			if err != nil {
+				return err
+			}
+		}
+
+		if input.Shadows != nil {
+			err = m.RotationStore.SetParticipantShadowsTx(ctx, tx, result.ID, input.Shadows)
+			if err != nil {
+				return err
+			}
+		}
 		return err
 	})
 
@@ -107,6 +125,16 @@ func (r *Rotation) NextHandoffTimes(ctx context.Context, rot *rotation.Rotation,
 		return nil, err
 	}
 
+	if rot.Type == rotation.TypeFollowTheSun {
+		parts, err := r.RotationStore.FindAllParticipants(ctx, rot.ID)
+		if err != nil {
+			return nil, err
+		}
+		cpy := *rot
+		cpy.Windows = rotation.ParticipantWindows(parts)
+		rot = &cpy
+	}
+
 	result := make([]time.Time, n)
 	t := s.ShiftStart
 	for i := range result {
@@ -131,6 +159,36 @@ func (r *Rotation) UserIDs(ctx context.Context, rot *rotation.Rotation) ([]strin
 	return ids, nil
 }
 
+func (r *Rotation) Shadows(ctx context.Context, rot *rotation.Rotation) ([]bool, error) {
+	parts, err := r.RotationStore.FindAllParticipants(ctx, rot.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rotation.ParticipantShadows(parts), nil
+}
+
+func (r *Rotation) ParticipantWindows(ctx context.Context, rot *rotation.Rotation) ([]*graphql2.RotationParticipantWindow, error) {
+	parts, err := r.RotationStore.FindAllParticipants(ctx, rot.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*graphql2.RotationParticipantWindow, len(parts))
+	for i, w := range rotation.ParticipantWindows(parts) {
+		if w == nil {
+			continue
+		}
+		result[i] = &graphql2.RotationParticipantWindow{
+			Start:    w.Start,
+			End:      w.End,
+			TimeZone: w.TimeZone.String(),
+		}
+	}
+
+	return result, nil
+}
+
 func (r *Rotation) Users(ctx context.Context, rot *rotation.Rotation) ([]user.User, error) {
 	userIDs, err := r.UserIDs(ctx, rot)
 	if err != nil {
@@ -177,6 +235,28 @@ func (r *Rotation) ActiveUserIndex(ctx context.Context, obj *rotation.Rotation)
 	return s.Position, err
 }
 
+func (r *Rotation) ActiveUserIndexes(ctx context.Context, obj *rotation.Rotation) ([]int, error) {
+	s, err := r.RotationStore.State(ctx, obj.ID)
+	if errors.Is(err, rotation.ErrNoState) {
+		return []int{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := r.RotationStore.FindAllParticipants(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := rotation.ActivePositions(s.Position, obj.ActiveCount, rotation.ParticipantShadows(parts))
+	if result == nil {
+		result = []int{}
+	}
+
+	return result, nil
+}
+
 func (q *Query) Rotations(ctx context.Context, opts *graphql2.RotationSearchOptions) (conn *graphql2.RotationConnection, err error) {
 	if opts == nil {
 		opts = &graphql2.RotationSearchOptions{}
@@ -234,78 +314,6 @@ func (q *Query) Rotations(ctx context.Context, opts *graphql2.RotationSearchOpti
 	return conn, err
 }
 
-func (m *Mutation) updateRotationParticipants(ctx context.Context, tx *sql.Tx, rotationID string, userIDs []string, updateActive bool) (err error) {
-	// Get current participants
-	currentParticipants, err := m.RotationStore.FindAllParticipantsTx(ctx, tx, rotationID)
-	if err != nil {
-		return err
-	}
-
-	var participantIDsToRemove []string
-
-	for i, c := range currentParticipants {
-		if i >= len(userIDs) {
-			participantIDsToRemove = append(participantIDsToRemove, c.ID)
-			continue
-		}
-
-		if c.Target.TargetID() == userIDs[i] {
-			// nothing to update
-			continue
-		}
-
-		// Update
-		err = m.RotationStore.UpdateParticipantUserIDTx(ctx, tx, c.ID, userIDs[i])
-		if err != nil {
-			return err
-		}
-	}
-
-	if len(userIDs) > len(currentParticipants) {
-		// Add users
-		err = m.RotationStore.AddRotationUsersTx(ctx, tx, rotationID, userIDs[len(currentParticipants):])
-		if err != nil {
-			return err
-		}
-	}
-
-	if len(participantIDsToRemove) == 0 {
-		return nil
-	}
-
-	if len(userIDs) == 0 {
-		// Delete rotation state if all users are going to be deleted as per new input
-		err = m.RotationStore.DeleteStateTx(ctx, tx, rotationID)
-		if err != nil {
-			return err
-		}
-	} else if updateActive {
-		// get current active participant
-		s, err := m.RotationStore.StateTx(ctx, tx, rotationID)
-		if errors.Is(err, rotation.ErrNoState) {
-			return nil
-		}
-		if err != nil {
-			return err
-		}
-
-		// if currently active user is going to be deleted
-		// then set to first user before we actually delete any users
-		if s.Position >= len(userIDs) {
-			err = m.RotationStore.SetActiveIndexTx(ctx, tx, rotationID, 0)
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	err = m.RotationStore.DeleteRotationParticipantsTx(ctx, tx, participantIDsToRemove)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 func (m *Mutation) UpdateRotation(ctx context.Context, input graphql2.UpdateRotationInput) (res bool, err error) {
 	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		result, err := m.RotationStore.FindRotationForUpdateTx(ctx, tx, input.ID)
@@ -336,6 +344,10 @@ func (m *Mutation) UpdateRotation(ctx context.Context, input graphql2.UpdateRota
 			update = true
 			result.ShiftLength = *input.ShiftLength
 		}
+		if input.ActiveCount != nil {
+			update = true
+			result.ActiveCount = *input.ActiveCount
+		}
 
 		if input.TimeZone != nil {
 			update = true
@@ -354,7 +366,21 @@ func (m *Mutation) UpdateRotation(ctx context.Context, input graphql2.UpdateRota
 		}
 
 		if input.UserIDs != nil {
-			err = m.updateRotationParticipants(ctx, tx, input.ID, input.UserIDs, input.ActiveUserIndex == nil)
+			err = m.RotationStore.SetParticipantsTx(ctx, tx, input.ID, input.UserIDs, input.ActiveUserIndex == nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		if input.ParticipantWindows != nil {
+			err = setParticipantWindows(ctx, tx, m.RotationStore, input.ID, input.ParticipantWindows)
+			if err != nil {
+				return err
+			}
+		}
+
+		if input.Shadows != nil {
+			err = m.RotationStore.SetParticipantShadowsTx(ctx, tx, input.ID, input.Shadows)
 			if err != nil {
 				return err
 			}
@@ -377,6 +403,23 @@ func (m *Mutation) UpdateRotation(ctx context.Context, input graphql2.UpdateRota
 	return true, nil
 }
 
+// setParticipantWindows converts and applies the participant windows from the given input.
+func setParticipantWindows(ctx context.Context, tx *sql.Tx, store *rotation.Store, rotID string, input []*graphql2.RotationParticipantWindowInput) error {
+	windows := make([]*rotation.ParticipantWindow, len(input))
+	for i, w := range input {
+		if w == nil {
+			continue
+		}
+		loc, err := util.LoadLocation(w.TimeZone)
+		if err != nil {
+			return validation.NewFieldError(fmt.Sprintf("participantWindows[%d].timeZone", i), err.Error())
+		}
+		windows[i] = &rotation.ParticipantWindow{Start: w.Start, End: w.End, TimeZone: loc}
+	}
+
+	return store.SetParticipantWindowsTx(ctx, tx, rotID, windows)
+}
+
 func (a *Query) CalcRotationHandoffTimes(ctx context.Context, input *graphql2.CalcRotationHandoffTimesInput) ([]time.Time, error) {
 	err := validate.Range("count", input.Count, 0, 20)
 	if err != nil {
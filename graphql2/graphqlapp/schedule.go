@@ -85,6 +85,20 @@ func (q *Query) Schedule(ctx context.Context, id string) (*schedule.Schedule, er
 	return (*App)(q).FindOneSchedule(ctx, id)
 }
 
+func (s *Schedule) CoverageGaps(ctx context.Context, raw *schedule.Schedule, days *int) ([]oncall.CoverageGap, error) {
+	n := 14
+	if days != nil {
+		n = *days
+	}
+	err := validate.Range("Days", n, 1, 50)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return s.OnCallStore.CoverageGapsBySchedule(ctx, raw.ID, now, now.AddDate(0, 0, n))
+}
+
 func (s *Schedule) Shifts(ctx context.Context, raw *schedule.Schedule, start, end time.Time, userIDs []string) ([]oncall.Shift, error) {
 	if end.Before(start) {
 		return nil, validation.NewFieldError("EndTime", "must be after StartTime")
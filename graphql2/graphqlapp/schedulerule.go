@@ -66,6 +66,9 @@ func (m *Mutation) UpdateScheduleTarget(ctx context.Context, input graphql2.Sche
 			if inputRule.WeekdayFilter != nil {
 				r.WeekdayFilter = *inputRule.WeekdayFilter
 			}
+			if inputRule.HolidayFilter != nil {
+				r.HolidayFilter = *inputRule.HolidayFilter
+			}
 			if ruleIndex < len(rules) {
 				r.ID = rules[ruleIndex].ID
 				err = errors.Wrap(m.RuleStore.UpdateTx(ctx, tx, r), "update rule")
@@ -33,6 +33,14 @@ type Service App
 
 func (a *App) Service() graphql2.ServiceResolver { return (*Service)(a) }
 
+func (s *Service) ReopenWindowMinutes(ctx context.Context, raw *service.Service) (int, error) {
+	return int(raw.ReopenWindow / time.Minute), nil
+}
+
+func (s *Service) FlapWindowMinutes(ctx context.Context, raw *service.Service) (int, error) {
+	return int(raw.FlapWindow / time.Minute), nil
+}
+
 func (q *Query) Service(ctx context.Context, id string) (*service.Service, error) {
 	return (*App)(q).FindOneService(ctx, id)
 }
@@ -216,6 +224,15 @@ func (m *Mutation) CreateService(ctx context.Context, input graphql2.CreateServi
 		if input.Description != nil {
 			svc.Description = *input.Description
 		}
+		if input.ReopenWindowMinutes != nil {
+			svc.ReopenWindow = time.Duration(*input.ReopenWindowMinutes) * time.Minute
+		}
+		if input.FlapThreshold != nil {
+			svc.FlapThreshold = *input.FlapThreshold
+		}
+		if input.FlapWindowMinutes != nil {
+			svc.FlapWindow = time.Duration(*input.FlapWindowMinutes) * time.Minute
+		}
 		if input.NewEscalationPolicy != nil {
 			// Set tempUUID so that Normalize won't fail on the yet-to-be-created
 			// escalation policy.
@@ -312,6 +329,15 @@ func (a *Mutation) UpdateService(ctx context.Context, input graphql2.UpdateServi
 	if input.MaintenanceExpiresAt != nil {
 		svc.MaintenanceExpiresAt = *input.MaintenanceExpiresAt
 	}
+	if input.ReopenWindowMinutes != nil {
+		svc.ReopenWindow = time.Duration(*input.ReopenWindowMinutes) * time.Minute
+	}
+	if input.FlapThreshold != nil {
+		svc.FlapThreshold = *input.FlapThreshold
+	}
+	if input.FlapWindowMinutes != nil {
+		svc.FlapWindow = time.Duration(*input.FlapWindowMinutes) * time.Minute
+	}
 
 	err = a.ServiceStore.UpdateTx(ctx, tx, svc)
 	if err != nil {
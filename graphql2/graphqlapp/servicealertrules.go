@@ -0,0 +1,30 @@
+package graphqlapp
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/service"
+)
+
+func (s *Service) AlertRules(ctx context.Context, raw *service.Service) ([]gadb.SvcAlertRuleV1, error) {
+	cfg, err := s.AlertRuleStore.Config(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Rules == nil {
+		return []gadb.SvcAlertRuleV1{}, nil
+	}
+
+	return cfg.Rules, nil
+}
+
+func (m *Mutation) SetServiceAlertRules(ctx context.Context, input graphql2.SetServiceAlertRulesInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.AlertRuleStore.SetConfigTx(ctx, tx, input.ServiceID, gadb.SvcAlertRulesV1{Rules: input.Rules})
+	})
+
+	return err == nil, err
+}
@@ -0,0 +1,137 @@
+package graphqlapp
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/user"
+)
+
+type (
+	TemporaryScheduleTemplate           App
+	TemporaryScheduleTemplateShift      App
+	TemporaryScheduleTemplateRecurrence App
+)
+
+func (a *App) TemporaryScheduleTemplate() graphql2.TemporaryScheduleTemplateResolver {
+	return (*TemporaryScheduleTemplate)(a)
+}
+
+func (a *App) TemporaryScheduleTemplateShift() graphql2.TemporaryScheduleTemplateShiftResolver {
+	return (*TemporaryScheduleTemplateShift)(a)
+}
+
+func (a *App) TemporaryScheduleTemplateRecurrence() graphql2.TemporaryScheduleTemplateRecurrenceResolver {
+	return (*TemporaryScheduleTemplateRecurrence)(a)
+}
+
+func (s *Schedule) TemporaryScheduleTemplates(ctx context.Context, raw *schedule.Schedule) ([]schedule.TemporaryScheduleTemplate, error) {
+	id, err := parseUUID("ScheduleID", raw.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ScheduleStore.TemporaryScheduleTemplates(ctx, nil, id)
+}
+
+func (a *TemporaryScheduleTemplate) LastApplied(ctx context.Context, tmpl *schedule.TemporaryScheduleTemplate) (*time.Time, error) {
+	if tmpl.LastApplied.IsZero() {
+		return nil, nil
+	}
+
+	return &tmpl.LastApplied, nil
+}
+
+func (a *TemporaryScheduleTemplateShift) User(ctx context.Context, s *schedule.TemplateShift) (*user.User, error) {
+	return (*App)(a).FindOneUser(ctx, s.UserID)
+}
+
+func (a *TemporaryScheduleTemplateRecurrence) Month(ctx context.Context, r *schedule.TemplateRecurrence) (int, error) {
+	return int(r.Month), nil
+}
+
+func (a *Mutation) SetTemporaryScheduleTemplate(ctx context.Context, input graphql2.SetTemporaryScheduleTemplateInput) (*schedule.TemporaryScheduleTemplate, error) {
+	schedID, err := parseUUID("ScheduleID", input.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := schedule.TemporaryScheduleTemplate{
+		Name:   input.Name,
+		Days:   input.Days,
+		Shifts: make([]schedule.TemplateShift, 0, len(input.Shifts)),
+	}
+	if input.ID != nil {
+		tmpl.ID, err = parseUUID("ID", *input.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range input.Shifts {
+		tmpl.Shifts = append(tmpl.Shifts, schedule.TemplateShift{
+			UserID: s.UserID,
+			Start:  schedule.ShiftOffset{Day: s.Start.Day, Time: s.Start.Time},
+			End:    schedule.ShiftOffset{Day: s.End.Day, Time: s.End.Time},
+		})
+	}
+	if input.Recurrence != nil {
+		tmpl.Recurrence = &schedule.TemplateRecurrence{
+			Month: time.Month(input.Recurrence.Month),
+			Day:   input.Recurrence.Day,
+		}
+	}
+
+	var result *schedule.TemporaryScheduleTemplate
+	err = withContextTx(ctx, a.DB, func(ctx context.Context, tx *sql.Tx) error {
+		result, err = a.ScheduleStore.SetTemporaryScheduleTemplate(ctx, tx, schedID, tmpl)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (a *Mutation) DeleteTemporaryScheduleTemplate(ctx context.Context, input graphql2.DeleteTemporaryScheduleTemplateInput) (bool, error) {
+	schedID, tmplID, err := parseTemplateIDs(input.ScheduleID, input.ID)
+	if err != nil {
+		return false, err
+	}
+
+	err = withContextTx(ctx, a.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return a.ScheduleStore.DeleteTemporaryScheduleTemplate(ctx, tx, schedID, tmplID)
+	})
+
+	return err == nil, err
+}
+
+func (a *Mutation) ApplyTemporaryScheduleTemplate(ctx context.Context, input graphql2.ApplyTemporaryScheduleTemplateInput) (bool, error) {
+	schedID, tmplID, err := parseTemplateIDs(input.ScheduleID, input.ID)
+	if err != nil {
+		return false, err
+	}
+
+	err = withContextTx(ctx, a.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return a.ScheduleStore.ApplyTemporaryScheduleTemplate(ctx, tx, schedID, tmplID, input.Start)
+	})
+
+	return err == nil, err
+}
+
+func parseTemplateIDs(schedID, tmplID string) (uuid.UUID, uuid.UUID, error) {
+	s, err := parseUUID("ScheduleID", schedID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	t, err := parseUUID("ID", tmplID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return s, t, nil
+}
@@ -0,0 +1,52 @@
+package graphqlapp
+
+import (
+	"context"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/workload"
+)
+
+type (
+	WorkloadReport App
+	UserWorkload   App
+)
+
+func (a *App) WorkloadReport() graphql2.WorkloadReportResolver { return (*WorkloadReport)(a) }
+func (a *App) UserWorkload() graphql2.UserWorkloadResolver     { return (*UserWorkload)(a) }
+
+func (q *Query) WorkloadReport(ctx context.Context, input graphql2.WorkloadReportInput) (*workload.Report, error) {
+	opts := workload.Options{
+		Start: input.Start,
+		End:   input.End,
+	}
+	if input.ScheduleID != nil {
+		opts.ScheduleID = *input.ScheduleID
+	}
+	if input.RotationID != nil {
+		opts.RotationID = *input.RotationID
+	}
+	if input.UserID != nil {
+		opts.UserID = *input.UserID
+	}
+	if input.TimeZone != nil {
+		loc, err := util.LoadLocation(*input.TimeZone)
+		if err != nil {
+			return nil, validation.NewFieldError("timeZone", err.Error())
+		}
+		opts.TimeZone = loc
+	}
+
+	return q.WorkloadStore.Report(ctx, opts)
+}
+
+func (r *WorkloadReport) TimeZone(ctx context.Context, rep *workload.Report) (string, error) {
+	return rep.TimeZone.String(), nil
+}
+
+func (r *UserWorkload) User(ctx context.Context, w *workload.UserWorkload) (*user.User, error) {
+	return (*App)(r).FindOneUser(ctx, w.UserID)
+}
@@ -19,6 +19,7 @@ func MapConfigHints(cfg config.Hints) []ConfigHint {
 		{ID: "Twilio.MessageWebhookURL", Value: cfg.Twilio.MessageWebhookURL},
 		{ID: "Twilio.VoiceWebhookURL", Value: cfg.Twilio.VoiceWebhookURL},
 		{ID: "Slack.InteractivityResponseURL", Value: cfg.Slack.InteractivityResponseURL},
+		{ID: "MSTeams.MessagingEndpoint", Value: cfg.MSTeams.MessagingEndpoint},
 	}
 }
 
@@ -35,6 +36,7 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "General.DisableLabelCreation", Type: ConfigTypeBoolean, Description: "Disables the ability to create new labels for services.", Value: fmt.Sprintf("%t", cfg.General.DisableLabelCreation)},
 		{ID: "General.DisableCalendarSubscriptions", Type: ConfigTypeBoolean, Description: "If set, disables all active calendar subscriptions as well as the ability to create new calendar subscriptions.", Value: fmt.Sprintf("%t", cfg.General.DisableCalendarSubscriptions)},
 		{ID: "Services.RequiredLabels", Type: ConfigTypeStringList, Description: "List of label names to require new services to define.", Value: strings.Join(cfg.Services.RequiredLabels, "\n")},
+		{ID: "Schedules.CoverageGapNoticeHours", Type: ConfigTypeInteger, Description: "Send a notice to a schedule's on-call notification channels when no one will be on call within this many hours (0 means disable).", Value: fmt.Sprintf("%d", cfg.Schedules.CoverageGapNoticeHours)},
 		{ID: "Maintenance.AlertCleanupDays", Type: ConfigTypeInteger, Description: "Closed alerts will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertCleanupDays)},
 		{ID: "Maintenance.AlertAutoCloseDays", Type: ConfigTypeInteger, Description: "Unacknowledged alerts will automatically be closed after this many days of inactivity. (0 means disable auto-close).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertAutoCloseDays)},
 		{ID: "Maintenance.AutoCloseAckedAlerts", Type: ConfigTypeBoolean, Description: "If set, alerts that are acknowledged will also be automatically closed after the configured number of days of inactivity.", Value: fmt.Sprintf("%t", cfg.Maintenance.AutoCloseAckedAlerts)},
@@ -68,6 +70,12 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "Slack.AccessToken", Type: ConfigTypeString, Description: "Slack app bot user OAuth access token (should start with xoxb-).", Value: cfg.Slack.AccessToken, Password: true},
 		{ID: "Slack.SigningSecret", Type: ConfigTypeString, Description: "Signing secret to verify requests from slack.", Value: cfg.Slack.SigningSecret, Password: true},
 		{ID: "Slack.InteractiveMessages", Type: ConfigTypeBoolean, Description: "Enable interactive messages (e.g. buttons).", Value: fmt.Sprintf("%t", cfg.Slack.InteractiveMessages)},
+		{ID: "MSTeams.Enable", Type: ConfigTypeBoolean, Description: "Enables Microsoft Teams destinations. Incoming webhooks (Workflows) only require this, channels require a bot (App ID).", Value: fmt.Sprintf("%t", cfg.MSTeams.Enable)},
+		{ID: "MSTeams.AppID", Type: ConfigTypeString, Description: "Microsoft App ID of the Teams bot. Required for Teams channel destinations.", Value: cfg.MSTeams.AppID},
+		{ID: "MSTeams.AppSecret", Type: ConfigTypeString, Description: "Client secret of the bot's app registration.", Value: cfg.MSTeams.AppSecret, Password: true},
+		{ID: "MSTeams.TenantID", Type: ConfigTypeString, Description: "Microsoft Entra tenant ID the bot is registered in.", Value: cfg.MSTeams.TenantID},
+		{ID: "MSTeams.ServiceURL", Type: ConfigTypeString, Description: "Bot Framework service URL for your region (e.g., https://smba.trafficmanager.net/amer/).", Value: cfg.MSTeams.ServiceURL},
+		{ID: "MSTeams.InteractiveMessages", Type: ConfigTypeBoolean, Description: "Enable interactive messages (e.g. Acknowledge and Close buttons). Requires the bot messaging endpoint to be set.", Value: fmt.Sprintf("%t", cfg.MSTeams.InteractiveMessages)},
 		{ID: "Twilio.Enable", Type: ConfigTypeBoolean, Description: "Enables sending and processing of Voice and SMS messages through the Twilio notification provider.", Value: fmt.Sprintf("%t", cfg.Twilio.Enable)},
 		{ID: "Twilio.VoiceName", Type: ConfigTypeString, Description: "The Twilio voice to use for Text To Speech for phone calls. See https://www.twilio.com/docs/voice/twiml/say/text-speech#polly-standard-and-neural-voices", Value: cfg.Twilio.VoiceName},
 		{ID: "Twilio.VoiceLanguage", Type: ConfigTypeString, Description: "The Twilio voice language to use for Text To Speech for phone calls. See https://www.twilio.com/docs/voice/twiml/say/text-speech#polly-standard-and-neural-voices", Value: cfg.Twilio.VoiceLanguage},
@@ -80,6 +88,15 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "Twilio.DisableTwoWaySMS", Type: ConfigTypeBoolean, Description: "Disables SMS reply codes for alert messages.", Value: fmt.Sprintf("%t", cfg.Twilio.DisableTwoWaySMS)},
 		{ID: "Twilio.SMSCarrierLookup", Type: ConfigTypeBoolean, Description: "Perform carrier lookup of SMS contact methods (required for SMSFromNumberOverride). Extra charges may apply.", Value: fmt.Sprintf("%t", cfg.Twilio.SMSCarrierLookup)},
 		{ID: "Twilio.SMSFromNumberOverride", Type: ConfigTypeStringList, Description: "List of 'carrier=number' pairs, SMS messages to numbers of the provided carrier string (exact match) will use the alternate From Number.", Value: strings.Join(cfg.Twilio.SMSFromNumberOverride, "\n")},
+		{ID: "SMPP.Enable", Type: ConfigTypeBoolean, Description: "Enables sending and processing of SMS messages through an SMPP 3.4 gateway (SMSC).", Value: fmt.Sprintf("%t", cfg.SMPP.Enable)},
+		{ID: "SMPP.Address", Type: ConfigTypeString, Description: "The host:port of the SMSC.", Value: cfg.SMPP.Address},
+		{ID: "SMPP.EnableTLS", Type: ConfigTypeBoolean, Description: "Connect to the SMSC using TLS.", Value: fmt.Sprintf("%t", cfg.SMPP.EnableTLS)},
+		{ID: "SMPP.SkipVerify", Type: ConfigTypeBoolean, Description: "Disables certificate validation for TLS (insecure).", Value: fmt.Sprintf("%t", cfg.SMPP.SkipVerify)},
+		{ID: "SMPP.SystemID", Type: ConfigTypeString, Description: "The system_id used to bind to the SMSC.", Value: cfg.SMPP.SystemID},
+		{ID: "SMPP.Password", Type: ConfigTypeString, Description: "The password used to bind to the SMSC.", Value: cfg.SMPP.Password, Password: true},
+		{ID: "SMPP.SystemType", Type: ConfigTypeString, Description: "The system_type used to bind to the SMSC, if required by the carrier.", Value: cfg.SMPP.SystemType},
+		{ID: "SMPP.SourceAddr", Type: ConfigTypeString, Description: "The number (E.164 format) or alphanumeric sender ID to use for outgoing messages.", Value: cfg.SMPP.SourceAddr},
+		{ID: "SMPP.DisableTwoWaySMS", Type: ConfigTypeBoolean, Description: "Disables SMS reply codes for alert messages.", Value: fmt.Sprintf("%t", cfg.SMPP.DisableTwoWaySMS)},
 		{ID: "SMTP.Enable", Type: ConfigTypeBoolean, Description: "Enables email as a contact method.", Value: fmt.Sprintf("%t", cfg.SMTP.Enable)},
 		{ID: "SMTP.From", Type: ConfigTypeString, Description: "The email address messages should be sent from.", Value: cfg.SMTP.From},
 		{ID: "SMTP.Address", Type: ConfigTypeString, Description: "The server address to use for sending email. Port is optional and defaults to 465, or 25 if Disable TLS is set. Common ports are: 25 or 587 for STARTTLS (or unencrypted) and 465 for TLS.", Value: cfg.SMTP.Address},
@@ -89,6 +106,9 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "SMTP.Password", Type: ConfigTypeString, Description: "Password for authentication.", Value: cfg.SMTP.Password, Password: true},
 		{ID: "Webhook.Enable", Type: ConfigTypeBoolean, Description: "Enables webhook as a contact method.", Value: fmt.Sprintf("%t", cfg.Webhook.Enable)},
 		{ID: "Webhook.AllowedURLs", Type: ConfigTypeStringList, Description: "If set, allows webhooks for these domains only.", Value: strings.Join(cfg.Webhook.AllowedURLs, "\n")},
+		{ID: "Webhook.ActionURLs", Type: ConfigTypeBoolean, Description: "Include signed URLs in alert notifications that can be used to acknowledge, close, or escalate the alert.", Value: fmt.Sprintf("%t", cfg.Webhook.ActionURLs)},
+		{ID: "WebPush.Enable", Type: ConfigTypeBoolean, Description: "Enables browser push notifications (Web Push) as a contact method.", Value: fmt.Sprintf("%t", cfg.WebPush.Enable)},
+		{ID: "WebPush.Subject", Type: ConfigTypeString, Description: "Contact URI (mailto: or https:) push services can use to reach the operator. Defaults to the public URL.", Value: cfg.WebPush.Subject},
 		{ID: "Feedback.Enable", Type: ConfigTypeBoolean, Description: "Enables Feedback link in nav bar.", Value: fmt.Sprintf("%t", cfg.Feedback.Enable)},
 		{ID: "Feedback.OverrideURL", Type: ConfigTypeString, Description: "Use a custom URL for Feedback link in nav bar.", Value: cfg.Feedback.OverrideURL},
 	}
@@ -107,6 +127,7 @@ func MapPublicConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "General.DisableLabelCreation", Type: ConfigTypeBoolean, Description: "Disables the ability to create new labels for services.", Value: fmt.Sprintf("%t", cfg.General.DisableLabelCreation)},
 		{ID: "General.DisableCalendarSubscriptions", Type: ConfigTypeBoolean, Description: "If set, disables all active calendar subscriptions as well as the ability to create new calendar subscriptions.", Value: fmt.Sprintf("%t", cfg.General.DisableCalendarSubscriptions)},
 		{ID: "Services.RequiredLabels", Type: ConfigTypeStringList, Description: "List of label names to require new services to define.", Value: strings.Join(cfg.Services.RequiredLabels, "\n")},
+		{ID: "Schedules.CoverageGapNoticeHours", Type: ConfigTypeInteger, Description: "Send a notice to a schedule's on-call notification channels when no one will be on call within this many hours (0 means disable).", Value: fmt.Sprintf("%d", cfg.Schedules.CoverageGapNoticeHours)},
 		{ID: "Maintenance.AlertCleanupDays", Type: ConfigTypeInteger, Description: "Closed alerts will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertCleanupDays)},
 		{ID: "Maintenance.AlertAutoCloseDays", Type: ConfigTypeInteger, Description: "Unacknowledged alerts will automatically be closed after this many days of inactivity. (0 means disable auto-close).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertAutoCloseDays)},
 		{ID: "Maintenance.AutoCloseAckedAlerts", Type: ConfigTypeBoolean, Description: "If set, alerts that are acknowledged will also be automatically closed after the configured number of days of inactivity.", Value: fmt.Sprintf("%t", cfg.Maintenance.AutoCloseAckedAlerts)},
@@ -117,13 +138,18 @@ func MapPublicConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "OIDC.Enable", Type: ConfigTypeBoolean, Description: "Enable OpenID Connect authentication.", Value: fmt.Sprintf("%t", cfg.OIDC.Enable)},
 		{ID: "Mailgun.Enable", Type: ConfigTypeBoolean, Description: "", Value: fmt.Sprintf("%t", cfg.Mailgun.Enable)},
 		{ID: "Slack.Enable", Type: ConfigTypeBoolean, Description: "", Value: fmt.Sprintf("%t", cfg.Slack.Enable)},
+		{ID: "MSTeams.Enable", Type: ConfigTypeBoolean, Description: "Enables Microsoft Teams destinations. Incoming webhooks (Workflows) only require this, channels require a bot (App ID).", Value: fmt.Sprintf("%t", cfg.MSTeams.Enable)},
 		{ID: "Twilio.Enable", Type: ConfigTypeBoolean, Description: "Enables sending and processing of Voice and SMS messages through the Twilio notification provider.", Value: fmt.Sprintf("%t", cfg.Twilio.Enable)},
 		{ID: "Twilio.FromNumber", Type: ConfigTypeString, Description: "The Twilio number to use for outgoing notifications.", Value: cfg.Twilio.FromNumber},
 		{ID: "Twilio.MessagingServiceSID", Type: ConfigTypeString, Description: "If set, replaces the use of From Number for SMS notifications.", Value: cfg.Twilio.MessagingServiceSID},
+		{ID: "SMPP.Enable", Type: ConfigTypeBoolean, Description: "Enables sending and processing of SMS messages through an SMPP 3.4 gateway (SMSC).", Value: fmt.Sprintf("%t", cfg.SMPP.Enable)},
+		{ID: "SMPP.SourceAddr", Type: ConfigTypeString, Description: "The number (E.164 format) or alphanumeric sender ID to use for outgoing messages.", Value: cfg.SMPP.SourceAddr},
 		{ID: "SMTP.Enable", Type: ConfigTypeBoolean, Description: "Enables email as a contact method.", Value: fmt.Sprintf("%t", cfg.SMTP.Enable)},
 		{ID: "SMTP.From", Type: ConfigTypeString, Description: "The email address messages should be sent from.", Value: cfg.SMTP.From},
 		{ID: "Webhook.Enable", Type: ConfigTypeBoolean, Description: "Enables webhook as a contact method.", Value: fmt.Sprintf("%t", cfg.Webhook.Enable)},
 		{ID: "Webhook.AllowedURLs", Type: ConfigTypeStringList, Description: "If set, allows webhooks for these domains only.", Value: strings.Join(cfg.Webhook.AllowedURLs, "\n")},
+		{ID: "Webhook.ActionURLs", Type: ConfigTypeBoolean, Description: "Include signed URLs in alert notifications that can be used to acknowledge, close, or escalate the alert.", Value: fmt.Sprintf("%t", cfg.Webhook.ActionURLs)},
+		{ID: "WebPush.Enable", Type: ConfigTypeBoolean, Description: "Enables browser push notifications (Web Push) as a contact method.", Value: fmt.Sprintf("%t", cfg.WebPush.Enable)},
 		{ID: "Feedback.Enable", Type: ConfigTypeBoolean, Description: "Enables Feedback link in nav bar.", Value: fmt.Sprintf("%t", cfg.Feedback.Enable)},
 		{ID: "Feedback.OverrideURL", Type: ConfigTypeString, Description: "Use a custom URL for Feedback link in nav bar.", Value: cfg.Feedback.OverrideURL},
 	}
@@ -195,6 +221,12 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 			cfg.General.DisableCalendarSubscriptions = val
 		case "Services.RequiredLabels":
 			cfg.Services.RequiredLabels = parseStringList(v.Value)
+		case "Schedules.CoverageGapNoticeHours":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Schedules.CoverageGapNoticeHours = val
 		case "Maintenance.AlertCleanupDays":
 			val, err := parseInt(v.ID, v.Value)
 			if err != nil {
@@ -313,6 +345,26 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 				return cfg, err
 			}
 			cfg.Slack.InteractiveMessages = val
+		case "MSTeams.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.MSTeams.Enable = val
+		case "MSTeams.AppID":
+			cfg.MSTeams.AppID = v.Value
+		case "MSTeams.AppSecret":
+			cfg.MSTeams.AppSecret = v.Value
+		case "MSTeams.TenantID":
+			cfg.MSTeams.TenantID = v.Value
+		case "MSTeams.ServiceURL":
+			cfg.MSTeams.ServiceURL = v.Value
+		case "MSTeams.InteractiveMessages":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.MSTeams.InteractiveMessages = val
 		case "Twilio.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
@@ -349,6 +401,40 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 			cfg.Twilio.SMSCarrierLookup = val
 		case "Twilio.SMSFromNumberOverride":
 			cfg.Twilio.SMSFromNumberOverride = parseStringList(v.Value)
+		case "SMPP.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.SMPP.Enable = val
+		case "SMPP.Address":
+			cfg.SMPP.Address = v.Value
+		case "SMPP.EnableTLS":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.SMPP.EnableTLS = val
+		case "SMPP.SkipVerify":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.SMPP.SkipVerify = val
+		case "SMPP.SystemID":
+			cfg.SMPP.SystemID = v.Value
+		case "SMPP.Password":
+			cfg.SMPP.Password = v.Value
+		case "SMPP.SystemType":
+			cfg.SMPP.SystemType = v.Value
+		case "SMPP.SourceAddr":
+			cfg.SMPP.SourceAddr = v.Value
+		case "SMPP.DisableTwoWaySMS":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.SMPP.DisableTwoWaySMS = val
 		case "SMTP.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
@@ -383,6 +469,20 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 			cfg.Webhook.Enable = val
 		case "Webhook.AllowedURLs":
 			cfg.Webhook.AllowedURLs = parseStringList(v.Value)
+		case "Webhook.ActionURLs":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Webhook.ActionURLs = val
+		case "WebPush.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.WebPush.Enable = val
+		case "WebPush.Subject":
+			cfg.WebPush.Subject = v.Value
 		case "Feedback.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
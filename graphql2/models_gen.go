@@ -9,15 +9,18 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	ast1 "github.com/expr-lang/expr/ast"
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/incident"
 	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
+	"github.com/target/goalert/maintenance"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notification/slack"
 	"github.com/target/goalert/override"
@@ -74,19 +77,21 @@ type AlertRecentEventsOptions struct {
 }
 
 type AlertSearchOptions struct {
-	FilterByStatus    []AlertStatus    `json:"filterByStatus,omitempty"`
-	FilterByServiceID []string         `json:"filterByServiceID,omitempty"`
-	Search            *string          `json:"search,omitempty"`
-	First             *int             `json:"first,omitempty"`
-	After             *string          `json:"after,omitempty"`
-	FavoritesOnly     *bool            `json:"favoritesOnly,omitempty"`
-	IncludeNotified   *bool            `json:"includeNotified,omitempty"`
-	Omit              []int            `json:"omit,omitempty"`
-	Sort              *AlertSearchSort `json:"sort,omitempty"`
-	CreatedBefore     *time.Time       `json:"createdBefore,omitempty"`
-	NotCreatedBefore  *time.Time       `json:"notCreatedBefore,omitempty"`
-	ClosedBefore      *time.Time       `json:"closedBefore,omitempty"`
-	NotClosedBefore   *time.Time       `json:"notClosedBefore,omitempty"`
+	FilterByStatus    []AlertStatus `json:"filterByStatus,omitempty"`
+	FilterByServiceID []string      `json:"filterByServiceID,omitempty"`
+	// Only include alerts with one of the provided priorities (0 matches alerts without a priority).
+	FilterByPriority []int            `json:"filterByPriority,omitempty"`
+	Search           *string          `json:"search,omitempty"`
+	First            *int             `json:"first,omitempty"`
+	After            *string          `json:"after,omitempty"`
+	FavoritesOnly    *bool            `json:"favoritesOnly,omitempty"`
+	IncludeNotified  *bool            `json:"includeNotified,omitempty"`
+	Omit             []int            `json:"omit,omitempty"`
+	Sort             *AlertSearchSort `json:"sort,omitempty"`
+	CreatedBefore    *time.Time       `json:"createdBefore,omitempty"`
+	NotCreatedBefore *time.Time       `json:"notCreatedBefore,omitempty"`
+	ClosedBefore     *time.Time       `json:"closedBefore,omitempty"`
+	NotClosedBefore  *time.Time       `json:"notClosedBefore,omitempty"`
 }
 
 // AlertStats returns aggregated statistics about alerts.
@@ -103,6 +108,13 @@ type AlertsByStatus struct {
 	Closed  int `json:"closed"`
 }
 
+type ApplyTemporaryScheduleTemplateInput struct {
+	ScheduleID string `json:"scheduleID"`
+	ID         string `json:"id"`
+	// The template is applied starting on the day containing this time, in the schedule's time zone.
+	Start time.Time `json:"start"`
+}
+
 type AuthSubjectConnection struct {
 	Nodes    []user.AuthSubject `json:"nodes"`
 	PageInfo *PageInfo          `json:"pageInfo"`
@@ -195,6 +207,8 @@ type CreateAlertInput struct {
 	// It can also be used to close an alert using closeMatchingAlert mutation.
 	Dedup *string              `json:"dedup,omitempty"`
 	Meta  []AlertMetadataInput `json:"meta,omitempty"`
+	// Priority of the alert, 1 (most urgent) through 5. Omit or set to 0 for none.
+	Priority *int `json:"priority,omitempty"`
 }
 
 type CreateBasicAuthInput struct {
@@ -218,6 +232,14 @@ type CreateEscalationPolicyStepInput struct {
 	NewRotation        *CreateRotationInput   `json:"newRotation,omitempty"`
 	NewSchedule        *CreateScheduleInput   `json:"newSchedule,omitempty"`
 	Actions            []gadb.DestV1          `json:"actions,omitempty"`
+	// Restricts the step to a recurring window of time. If omitted, the step is always active.
+	ActiveHours *StepActiveHoursInput `json:"activeHours,omitempty"`
+	// Controls how the step notifies its targets. Defaults to `all`.
+	Mode *escalation.StepMode `json:"mode,omitempty"`
+	// Minutes between notifying each on-call user. Required for `round_robin` mode.
+	RoundRobinMinutes *int `json:"roundRobinMinutes,omitempty"`
+	// Number of different users that must acknowledge an alert. Required for `ack_count` mode.
+	AckCount *int `json:"ackCount,omitempty"`
 }
 
 type CreateGQLAPIKeyInput struct {
@@ -226,6 +248,8 @@ type CreateGQLAPIKeyInput struct {
 	ExpiresAt   time.Time `json:"expiresAt"`
 	Role        UserRole  `json:"role"`
 	Query       string    `json:"query"`
+	// Allows the key to be used with the REST API (at /api/rest/v1) in addition to the provided query. The query may be empty for REST-only keys.
+	Rest *bool `json:"rest,omitempty"`
 }
 
 type CreateHeartbeatMonitorInput struct {
@@ -240,6 +264,20 @@ type CreateHeartbeatMonitorInput struct {
 	Muted *string `json:"muted,omitempty"`
 }
 
+type CreateHolidayCalendarInput struct {
+	Name      string  `json:"name"`
+	SourceURL *string `json:"sourceURL,omitempty"`
+	// The contents of an ICS file. If not set, the calendar is downloaded from sourceURL.
+	Ics *string `json:"ics,omitempty"`
+}
+
+type CreateIncidentInput struct {
+	Summary string  `json:"summary"`
+	Details *string `json:"details,omitempty"`
+	// Alerts to attach to the new incident.
+	AlertIDs []int `json:"alertIDs,omitempty"`
+}
+
 type CreateIntegrationKeyInput struct {
 	ServiceID *string            `json:"serviceID,omitempty"`
 	Type      IntegrationKeyType `json:"type"`
@@ -248,6 +286,30 @@ type CreateIntegrationKeyInput struct {
 	ExternalSystemName *string `json:"externalSystemName,omitempty"`
 }
 
+type CreateMaintenanceWindowInput struct {
+	// Exactly one of serviceID or labelKey must be set.
+	ServiceID  *string                `json:"serviceID,omitempty"`
+	LabelKey   *string                `json:"labelKey,omitempty"`
+	LabelValue *string                `json:"labelValue,omitempty"`
+	Start      time.Time              `json:"start"`
+	End        time.Time              `json:"end"`
+	Repeat     *maintenance.Repeat    `json:"repeat,omitempty"`
+	TimeZone   *string                `json:"timeZone,omitempty"`
+	Reason     *string                `json:"reason,omitempty"`
+	EndAction  *maintenance.EndAction `json:"endAction,omitempty"`
+}
+
+type CreateOverrideRequestInput struct {
+	ScheduleID string `json:"scheduleID"`
+	// The user that must accept the request. It must be the added or removed user.
+	TargetUserID string `json:"targetUserID"`
+	// At least one of addUserID or removeUserID must be set.
+	AddUserID    *string   `json:"addUserID,omitempty"`
+	RemoveUserID *string   `json:"removeUserID,omitempty"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+}
+
 type CreateRotationInput struct {
 	Name        string        `json:"name"`
 	Description *string       `json:"description,omitempty"`
@@ -256,7 +318,13 @@ type CreateRotationInput struct {
 	Favorite    *bool         `json:"favorite,omitempty"`
 	Type        rotation.Type `json:"type"`
 	ShiftLength *int          `json:"shiftLength,omitempty"`
-	UserIDs     []string      `json:"userIDs,omitempty"`
+	// The number of participants on call at the same time. Defaults to 1.
+	ActiveCount *int     `json:"activeCount,omitempty"`
+	UserIDs     []string `json:"userIDs,omitempty"`
+	// The shadow flag of each participant, by position. Shadow participants are on call alongside the participant before them, but do not count toward acknowledgement.
+	Shadows []bool `json:"shadows,omitempty"`
+	// The time-of-day window covered by each participant, by position. Only used by follow_the_sun rotations.
+	ParticipantWindows []*RotationParticipantWindowInput `json:"participantWindows,omitempty"`
 }
 
 type CreateScheduleInput struct {
@@ -271,6 +339,9 @@ type CreateScheduleInput struct {
 type CreateServiceInput struct {
 	Name                 string                        `json:"name"`
 	Description          *string                       `json:"description,omitempty"`
+	ReopenWindowMinutes  *int                          `json:"reopenWindowMinutes,omitempty"`
+	FlapThreshold        *int                          `json:"flapThreshold,omitempty"`
+	FlapWindowMinutes    *int                          `json:"flapWindowMinutes,omitempty"`
 	Favorite             *bool                         `json:"favorite,omitempty"`
 	EscalationPolicyID   *string                       `json:"escalationPolicyID,omitempty"`
 	NewEscalationPolicy  *CreateEscalationPolicyInput  `json:"newEscalationPolicy,omitempty"`
@@ -279,12 +350,25 @@ type CreateServiceInput struct {
 	NewHeartbeatMonitors []CreateHeartbeatMonitorInput `json:"newHeartbeatMonitors,omitempty"`
 }
 
+type CreateUserAvailabilityFeedInput struct {
+	UserID           string  `json:"userID"`
+	Name             string  `json:"name"`
+	URL              string  `json:"url"`
+	Keyword          *string `json:"keyword,omitempty"`
+	TimeZone         string  `json:"timeZone"`
+	SubstituteUserID *string `json:"substituteUserID,omitempty"`
+}
+
 type CreateUserCalendarSubscriptionInput struct {
 	Name            string `json:"name"`
 	ReminderMinutes []int  `json:"reminderMinutes,omitempty"`
-	ScheduleID      string `json:"scheduleID"`
-	Disabled        *bool  `json:"disabled,omitempty"`
-	FullSchedule    *bool  `json:"fullSchedule,omitempty"`
+	// At most one of scheduleID, rotationID, or serviceID may be set. If none are set, the subscription
+	// will provide the user's shifts on every schedule they are on.
+	ScheduleID   *string `json:"scheduleID,omitempty"`
+	RotationID   *string `json:"rotationID,omitempty"`
+	ServiceID    *string `json:"serviceID,omitempty"`
+	Disabled     *bool   `json:"disabled,omitempty"`
+	FullSchedule *bool   `json:"fullSchedule,omitempty"`
 }
 
 type CreateUserContactMethodInput struct {
@@ -314,6 +398,11 @@ type CreateUserNotificationRuleInput struct {
 	UserID          *string `json:"userID,omitempty"`
 	ContactMethodID *string `json:"contactMethodID,omitempty"`
 	DelayMinutes    int     `json:"delayMinutes"`
+	// Restrict the rule to alerts with one of these priorities (0 matches alerts without a priority).
+	Priorities []int `json:"priorities,omitempty"`
+	// Restrict the rule to alerts from services with this label key (and value, if provided).
+	ServiceLabelKey   *string `json:"serviceLabelKey,omitempty"`
+	ServiceLabelValue *string `json:"serviceLabelValue,omitempty"`
 }
 
 type CreateUserOverrideInput struct {
@@ -377,6 +466,11 @@ type DebugSendSMSInput struct {
 	Body string `json:"body"`
 }
 
+type DeleteTemporaryScheduleTemplateInput struct {
+	ScheduleID string `json:"scheduleID"`
+	ID         string `json:"id"`
+}
+
 type DestinationDisplayInfoError struct {
 	// error message to display when the display info cannot be retrieved
 	Error string `json:"error"`
@@ -478,6 +572,7 @@ type GQLAPIKey struct {
 	ExpiresAt   time.Time       `json:"expiresAt"`
 	Query       string          `json:"query"`
 	Role        UserRole        `json:"role"`
+	Rest        bool            `json:"rest"`
 }
 
 type GQLAPIKeyUsage struct {
@@ -486,6 +581,29 @@ type GQLAPIKeyUsage struct {
 	IP   string    `json:"ip"`
 }
 
+type ImportHolidayCalendarInput struct {
+	ID string `json:"id"`
+	// The contents of an ICS file. If not set, the calendar is downloaded from its source URL.
+	Ics *string `json:"ics,omitempty"`
+}
+
+type IncidentAlertsInput struct {
+	IncidentID int   `json:"incidentID"`
+	AlertIDs   []int `json:"alertIDs"`
+}
+
+type IncidentConnection struct {
+	Nodes    []incident.Incident `json:"nodes"`
+	PageInfo *PageInfo           `json:"pageInfo"`
+}
+
+type IncidentSearchOptions struct {
+	Search     *string `json:"search,omitempty"`
+	OmitClosed *bool   `json:"omitClosed,omitempty"`
+	First      *int    `json:"first,omitempty"`
+	After      *string `json:"after,omitempty"`
+}
+
 type IntegrationKeyConnection struct {
 	Nodes    []integrationkey.IntegrationKey `json:"nodes"`
 	PageInfo *PageInfo                       `json:"pageInfo"`
@@ -586,6 +704,15 @@ type OnCallServiceAssignment struct {
 	ServiceName          string `json:"serviceName"`
 }
 
+type OverrideRequestSearchOptions struct {
+	// If no user or schedule filter is set, targetUserID defaults to the current user.
+	TargetUserID    *string `json:"targetUserID,omitempty"`
+	RequesterUserID *string `json:"requesterUserID,omitempty"`
+	ScheduleID      *string `json:"scheduleID,omitempty"`
+	// Only return requests that are pending and have not yet expired.
+	PendingOnly *bool `json:"pendingOnly,omitempty"`
+}
+
 type PageInfo struct {
 	EndCursor   *string `json:"endCursor,omitempty"`
 	HasNextPage bool    `json:"hasNextPage"`
@@ -603,11 +730,29 @@ type PhoneNumberInfo struct {
 type Query struct {
 }
 
+type QuietHoursInput struct {
+	TimeZone string         `json:"timeZone"`
+	Start    timeutil.Clock `json:"start"`
+	End      timeutil.Clock `json:"end"`
+}
+
 type RotationConnection struct {
 	Nodes    []rotation.Rotation `json:"nodes"`
 	PageInfo *PageInfo           `json:"pageInfo"`
 }
 
+type RotationParticipantWindow struct {
+	Start    timeutil.Clock `json:"start"`
+	End      timeutil.Clock `json:"end"`
+	TimeZone string         `json:"timeZone"`
+}
+
+type RotationParticipantWindowInput struct {
+	Start    timeutil.Clock `json:"start"`
+	End      timeutil.Clock `json:"end"`
+	TimeZone string         `json:"timeZone"`
+}
+
 type RotationSearchOptions struct {
 	First  *int     `json:"first,omitempty"`
 	After  *string  `json:"after,omitempty"`
@@ -657,6 +802,8 @@ type ScheduleRuleInput struct {
 	End   *timeutil.Clock `json:"end,omitempty"`
 	// Weekday filter is a 7-item array that indicates if the rule is active on each weekday, starting with Sunday.
 	WeekdayFilter *timeutil.WeekdayFilter `json:"weekdayFilter,omitempty"`
+	// Determines if the rule is active on holidays from the schedule's holiday calendars. Defaults to any.
+	HolidayFilter *rule.HolidayFilter `json:"holidayFilter,omitempty"`
 }
 
 type ScheduleSearchOptions struct {
@@ -727,11 +874,29 @@ type SetLabelInput struct {
 	Value string `json:"value"`
 }
 
+type SetQuietHoursInput struct {
+	// Exactly one of userID or serviceID must be set.
+	UserID    *string `json:"userID,omitempty"`
+	ServiceID *string `json:"serviceID,omitempty"`
+	// The new quiet hours; null removes them.
+	QuietHours *QuietHoursInput `json:"quietHours,omitempty"`
+}
+
+type SetScheduleHolidayCalendarsInput struct {
+	ScheduleID  string   `json:"scheduleID"`
+	CalendarIDs []string `json:"calendarIDs"`
+}
+
 type SetScheduleOnCallNotificationRulesInput struct {
 	ScheduleID string                        `json:"scheduleID"`
 	Rules      []OnCallNotificationRuleInput `json:"rules"`
 }
 
+type SetServiceAlertRulesInput struct {
+	ServiceID string                `json:"serviceID"`
+	Rules     []gadb.SvcAlertRuleV1 `json:"rules"`
+}
+
 type SetTemporaryScheduleInput struct {
 	ScheduleID string                `json:"scheduleID"`
 	ClearStart *time.Time            `json:"clearStart,omitempty"`
@@ -741,6 +906,15 @@ type SetTemporaryScheduleInput struct {
 	Shifts     []schedule.FixedShift `json:"shifts"`
 }
 
+type SetTemporaryScheduleTemplateInput struct {
+	ScheduleID string                                    `json:"scheduleID"`
+	ID         *string                                   `json:"id,omitempty"`
+	Name       string                                    `json:"name"`
+	Days       int                                       `json:"days"`
+	Shifts     []TemporaryScheduleTemplateShiftInput     `json:"shifts"`
+	Recurrence *TemporaryScheduleTemplateRecurrenceInput `json:"recurrence,omitempty"`
+}
+
 type SlackChannelConnection struct {
 	Nodes    []slack.Channel `json:"nodes"`
 	PageInfo *PageInfo       `json:"pageInfo"`
@@ -765,6 +939,14 @@ type SlackUserGroupSearchOptions struct {
 	Omit   []string `json:"omit,omitempty"`
 }
 
+type StepActiveHoursInput struct {
+	// Weekday filter is a 7-item array that indicates if the window is active on each weekday, starting with Sunday.
+	WeekdayFilter timeutil.WeekdayFilter `json:"weekdayFilter"`
+	Start         timeutil.Clock         `json:"start"`
+	End           timeutil.Clock         `json:"end"`
+	TimeZone      string                 `json:"timeZone"`
+}
+
 type StringConnection struct {
 	Nodes    []string  `json:"nodes"`
 	PageInfo *PageInfo `json:"pageInfo"`
@@ -781,6 +963,22 @@ type SystemLimitInput struct {
 	Value int      `json:"value"`
 }
 
+type TemporaryScheduleTemplateOffsetInput struct {
+	Day  int            `json:"day"`
+	Time timeutil.Clock `json:"time"`
+}
+
+type TemporaryScheduleTemplateRecurrenceInput struct {
+	Month int `json:"month"`
+	Day   int `json:"day"`
+}
+
+type TemporaryScheduleTemplateShiftInput struct {
+	UserID string                                `json:"userID"`
+	Start  *TemporaryScheduleTemplateOffsetInput `json:"start"`
+	End    *TemporaryScheduleTemplateOffsetInput `json:"end"`
+}
+
 type TimeSeriesBucket struct {
 	Start time.Time `json:"start"`
 	End   time.Time `json:"end"`
@@ -826,6 +1024,10 @@ type UpdateAlertsInput struct {
 	AlertIDs    []int        `json:"alertIDs"`
 	NewStatus   *AlertStatus `json:"newStatus,omitempty"`
 	NoiseReason *string      `json:"noiseReason,omitempty"`
+	// Snooze the alerts for the given number of minutes. While snoozed, alerts are
+	// treated as acknowledged and will re-trigger (restarting escalation) when the
+	// snooze expires. Cannot be combined with newStatus or noiseReason.
+	SnoozeMinutes *int `json:"snoozeMinutes,omitempty"`
 }
 
 type UpdateBasicAuthInput struct {
@@ -847,6 +1049,12 @@ type UpdateEscalationPolicyStepInput struct {
 	DelayMinutes *int                   `json:"delayMinutes,omitempty"`
 	Targets      []assignment.RawTarget `json:"targets,omitempty"`
 	Actions      []gadb.DestV1          `json:"actions,omitempty"`
+	// Restricts the step to a recurring window of time. Setting to null will clear the restriction.
+	ActiveHours graphql.Omittable[*StepActiveHoursInput] `json:"activeHours,omitempty"`
+	// Controls how the step notifies its targets. Changing the mode clears settings of the previous mode.
+	Mode              *escalation.StepMode `json:"mode,omitempty"`
+	RoundRobinMinutes *int                 `json:"roundRobinMinutes,omitempty"`
+	AckCount          *int                 `json:"ackCount,omitempty"`
 }
 
 type UpdateGQLAPIKeyInput struct {
@@ -867,6 +1075,24 @@ type UpdateHeartbeatMonitorInput struct {
 	Muted *string `json:"muted,omitempty"`
 }
 
+type UpdateHolidayCalendarInput struct {
+	ID        string  `json:"id"`
+	Name      *string `json:"name,omitempty"`
+	SourceURL *string `json:"sourceURL,omitempty"`
+}
+
+type UpdateIncidentInput struct {
+	ID      int     `json:"id"`
+	Summary *string `json:"summary,omitempty"`
+	Details *string `json:"details,omitempty"`
+}
+
+type UpdateIncidentStatusInput struct {
+	ID int `json:"id"`
+	// Must be StatusAcknowledged or StatusClosed.
+	NewStatus AlertStatus `json:"newStatus"`
+}
+
 type UpdateKeyConfigInput struct {
 	KeyID string           `json:"keyID"`
 	Rules []gadb.UIKRuleV1 `json:"rules,omitempty"`
@@ -882,6 +1108,16 @@ type UpdateKeyConfigInput struct {
 	DefaultActions []gadb.UIKActionV1 `json:"defaultActions,omitempty"`
 }
 
+type UpdateMaintenanceWindowInput struct {
+	ID        string                 `json:"id"`
+	Start     *time.Time             `json:"start,omitempty"`
+	End       *time.Time             `json:"end,omitempty"`
+	Repeat    *maintenance.Repeat    `json:"repeat,omitempty"`
+	TimeZone  *string                `json:"timeZone,omitempty"`
+	Reason    *string                `json:"reason,omitempty"`
+	EndAction *maintenance.EndAction `json:"endAction,omitempty"`
+}
+
 type UpdateRotationInput struct {
 	ID          string         `json:"id"`
 	Name        *string        `json:"name,omitempty"`
@@ -890,9 +1126,14 @@ type UpdateRotationInput struct {
 	Start       *time.Time     `json:"start,omitempty"`
 	Type        *rotation.Type `json:"type,omitempty"`
 	ShiftLength *int           `json:"shiftLength,omitempty"`
+	ActiveCount *int           `json:"activeCount,omitempty"`
 	UserIDs     []string       `json:"userIDs,omitempty"`
+	// The shadow flag of each participant, by position.
+	Shadows []bool `json:"shadows,omitempty"`
 	// The index of the user in `userIDs` to set as the active user. If not provided, the existing active user index will be used.
 	ActiveUserIndex *int `json:"activeUserIndex,omitempty"`
+	// The time-of-day window covered by each participant, by position. Only used by follow_the_sun rotations.
+	ParticipantWindows []*RotationParticipantWindowInput `json:"participantWindows,omitempty"`
 }
 
 type UpdateScheduleInput struct {
@@ -908,6 +1149,19 @@ type UpdateServiceInput struct {
 	Description          *string    `json:"description,omitempty"`
 	EscalationPolicyID   *string    `json:"escalationPolicyID,omitempty"`
 	MaintenanceExpiresAt *time.Time `json:"maintenanceExpiresAt,omitempty"`
+	ReopenWindowMinutes  *int       `json:"reopenWindowMinutes,omitempty"`
+	FlapThreshold        *int       `json:"flapThreshold,omitempty"`
+	FlapWindowMinutes    *int       `json:"flapWindowMinutes,omitempty"`
+}
+
+type UpdateUserAvailabilityFeedInput struct {
+	ID       string  `json:"id"`
+	Name     *string `json:"name,omitempty"`
+	URL      *string `json:"url,omitempty"`
+	Keyword  *string `json:"keyword,omitempty"`
+	TimeZone *string `json:"timeZone,omitempty"`
+	// The new substitute; an empty string removes it.
+	SubstituteUserID *string `json:"substituteUserID,omitempty"`
 }
 
 type UpdateUserCalendarSubscriptionInput struct {
@@ -999,6 +1253,17 @@ type VerifyContactMethodInput struct {
 	Code            int    `json:"code"`
 }
 
+type WorkloadReportInput struct {
+	// Exactly one of scheduleID, rotationID, or userID must be specified.
+	ScheduleID *string   `json:"scheduleID,omitempty"`
+	RotationID *string   `json:"rotationID,omitempty"`
+	UserID     *string   `json:"userID,omitempty"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	// Time zone used for off-hours, weekend, and night calculations. Defaults to UTC.
+	TimeZone *string `json:"timeZone,omitempty"`
+}
+
 type AlertSearchSort string
 
 const (
@@ -0,0 +1,42 @@
+package holiday
+
+import (
+	"time"
+
+	"github.com/target/goalert/validation/validate"
+)
+
+// A Calendar is a named set of holidays that can be attached to schedules.
+type Calendar struct {
+	ID   string
+	Name string
+
+	// SourceURL, if set, is the location of the ICS file the calendar was imported from.
+	SourceURL string
+
+	// ImportedAt is the last time holidays were imported.
+	ImportedAt time.Time
+}
+
+// A Holiday is a single date of a holiday calendar.
+type Holiday struct {
+	// Date is the calendar date of the holiday, at midnight UTC.
+	Date time.Time
+	Name string
+}
+
+// Normalize will validate and return a normalized Calendar.
+func (c Calendar) Normalize() (*Calendar, error) {
+	err := validate.IDName("Name", c.Name)
+	if err != nil {
+		return nil, err
+	}
+	if c.SourceURL != "" {
+		err = validate.AbsoluteURL("SourceURL", c.SourceURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,96 @@
+package holiday
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/util/icalutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+)
+
+const (
+	// MaxHolidays is the maximum number of dates a single calendar can contain.
+	MaxHolidays = 5000
+
+	// MaxFileSize is the maximum size of an imported ICS file.
+	MaxFileSize = icalutil.MaxFileSize
+
+	// RecurrenceYears is how many years ahead recurring holidays are expanded on import.
+	RecurrenceYears = 5
+
+	// maxEventDays is the maximum number of days a single event can cover.
+	maxEventDays = 31
+)
+
+// ParseICS will read holidays from an ICS file. Every date an event covers, in the time zone of the event,
+// is a holiday. Recurring events are expanded RecurrenceYears ahead of now; if the recurrence rule is not
+// supported, only the first occurrence is used.
+func ParseICS(r io.Reader, now time.Time) ([]Holiday, error) {
+	events, err := icalutil.Parse(io.LimitReader(r, MaxFileSize))
+	if err != nil {
+		return nil, validation.NewFieldError("ICS", err.Error())
+	}
+
+	names := make(map[time.Time][]string)
+	until := now.AddDate(RecurrenceYears, 0, 0)
+	for _, e := range events {
+		occurrences, err := e.Expand(until)
+		if errors.Is(err, icalutil.ErrUnsupportedRRule) {
+			occurrences = []icalutil.Event{e}
+			err = nil
+		}
+		if err != nil {
+			return nil, validation.NewFieldError("ICS", fmt.Sprintf("event '%s': %s", e.UID, err.Error()))
+		}
+
+		for _, occ := range occurrences {
+			y, m, d := occ.Start.Date()
+			for i := 0; i < maxEventDays; i++ {
+				date := time.Date(y, m, d+i, 0, 0, 0, 0, time.UTC)
+				if i > 0 && !time.Date(y, m, d+i, 0, 0, 0, 0, occ.Start.Location()).Before(occ.End) {
+					break
+				}
+				if !containsString(names[date], occ.Summary) {
+					names[date] = append(names[date], occ.Summary)
+				}
+			}
+		}
+		if len(names) > MaxHolidays {
+			return nil, validation.NewFieldError("ICS", fmt.Sprintf("too many holidays (max %d)", MaxHolidays))
+		}
+	}
+
+	result := make([]Holiday, 0, len(names))
+	for date, n := range names {
+		result = append(result, Holiday{Date: date, Name: strings.Join(n, ", ")})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date.Before(result[j].Date) })
+
+	return result, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchICS will download an ICS file from the given URL. Only public addresses are allowed.
+func (s *Store) fetchICS(ctx context.Context, url string) ([]byte, error) {
+	data, err := icalutil.Fetch(ctx, s.client, url)
+	if err != nil {
+		log.Debugf(ctx, "fetch holiday calendar: %v", err)
+		return nil, validation.NewFieldError("SourceURL", "fetch calendar: "+icalutil.FetchErrorMessage(err))
+	}
+
+	return data, nil
+}
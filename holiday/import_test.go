@@ -0,0 +1,72 @@
+package holiday
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/validation"
+)
+
+func TestParseICS(t *testing.T) {
+	const ics = `BEGIN:VCALENDAR
+BEGIN:VEVENT
+UID:1
+DTSTART;VALUE=DATE:20241224
+DTEND;VALUE=DATE:20241226
+SUMMARY:Winter Break
+END:VEVENT
+BEGIN:VEVENT
+UID:2
+DTSTART;VALUE=DATE:20241225
+SUMMARY:Christmas
+END:VEVENT
+BEGIN:VEVENT
+UID:3
+DTSTART;VALUE=DATE:20240101
+SUMMARY:New Year
+RRULE:FREQ=YEARLY
+END:VEVENT
+END:VCALENDAR
+`
+	holidays, err := ParseICS(strings.NewReader(ics), time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	assert.Equal(t, []Holiday{
+		{Date: date(2024, 1, 1), Name: "New Year"},
+		{Date: date(2024, 12, 24), Name: "Winter Break"},
+		{Date: date(2024, 12, 25), Name: "Winter Break, Christmas"},
+		{Date: date(2025, 1, 1), Name: "New Year"},
+		{Date: date(2026, 1, 1), Name: "New Year"},
+		{Date: date(2027, 1, 1), Name: "New Year"},
+		{Date: date(2028, 1, 1), Name: "New Year"},
+		{Date: date(2029, 1, 1), Name: "New Year"},
+	}, holidays)
+
+	_, err = ParseICS(strings.NewReader("not a calendar"), time.Now())
+	assert.Error(t, err)
+}
+
+func TestStore_FetchICS(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte("BEGIN:VCALENDAR\nEND:VCALENDAR\n"))
+	}))
+	defer srv.Close()
+
+	s, err := NewStore(context.Background(), nil)
+	require.NoError(t, err)
+
+	// the test server is on a loopback address, and the error must not include details of the connection
+	_, err = s.fetchICS(context.Background(), srv.URL)
+	require.Error(t, err)
+	var fErr validation.FieldError
+	require.ErrorAs(t, err, &fErr)
+	assert.Equal(t, "SourceURL", fErr.Field())
+	assert.Equal(t, "fetch calendar: address is not allowed", fErr.Reason())
+}
@@ -0,0 +1,104 @@
+-- name: HolidayCalCreate :exec
+INSERT INTO holiday_calendars(id, name, source_url)
+    VALUES ($1, $2, $3);
+
+-- name: HolidayCalUpdate :exec
+UPDATE
+    holiday_calendars
+SET
+    name = $2,
+    source_url = $3
+WHERE
+    id = $1;
+
+-- name: HolidayCalDelete :exec
+DELETE FROM holiday_calendars
+WHERE id = ANY (@ids::uuid[]);
+
+-- name: HolidayCalFindOne :one
+SELECT
+    *
+FROM
+    holiday_calendars
+WHERE
+    id = $1;
+
+-- name: HolidayCalFindOneForUpdate :one
+SELECT
+    *
+FROM
+    holiday_calendars
+WHERE
+    id = $1
+FOR UPDATE;
+
+-- name: HolidayCalFindAll :many
+SELECT
+    *
+FROM
+    holiday_calendars
+ORDER BY
+    lower(name);
+
+-- name: HolidayCalFindMany :many
+SELECT
+    *
+FROM
+    holiday_calendars
+WHERE
+    id = ANY (@ids::uuid[]);
+
+-- name: HolidayCalDeleteDates :exec
+DELETE FROM holiday_calendar_dates
+WHERE calendar_id = $1;
+
+-- name: HolidayCalInsertDates :exec
+INSERT INTO holiday_calendar_dates(calendar_id, date, name)
+SELECT
+    @calendar_id,
+    unnest(@dates::date[]),
+    unnest(@names::text[]);
+
+-- name: HolidayCalSetImported :exec
+UPDATE
+    holiday_calendars
+SET
+    imported_at = now()
+WHERE
+    id = $1;
+
+-- name: HolidayCalDates :many
+SELECT
+    date,
+    name
+FROM
+    holiday_calendar_dates
+WHERE
+    calendar_id = $1
+    AND date BETWEEN @start_date::date AND @end_date::date
+ORDER BY
+    date;
+
+-- name: HolidayCalScheduleCalendars :many
+SELECT
+    sqlc.embed(cal)
+FROM
+    schedule_holiday_calendars sched
+    JOIN holiday_calendars cal ON cal.id = sched.calendar_id
+WHERE
+    sched.schedule_id = $1
+ORDER BY
+    lower(cal.name);
+
+-- name: HolidayCalClearScheduleCalendars :exec
+DELETE FROM schedule_holiday_calendars
+WHERE schedule_id = $1
+    AND calendar_id <> ALL (@calendar_ids::uuid[]);
+
+-- name: HolidayCalAddScheduleCalendars :exec
+INSERT INTO schedule_holiday_calendars(schedule_id, calendar_id)
+SELECT
+    @schedule_id,
+    unnest(@calendar_ids::uuid[])
+ON CONFLICT
+    DO NOTHING;
@@ -0,0 +1,312 @@
+package holiday
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/icalutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store manages holiday calendars and the calendars attached to schedules.
+type Store struct {
+	db     *sql.DB
+	client *http.Client
+}
+
+// NewStore will create a new Store.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	return &Store{db: db, client: icalutil.NewPublicClient()}, nil
+}
+
+func calFromDB(c gadb.HolidayCalendar) Calendar {
+	return Calendar{
+		ID:         c.ID.String(),
+		Name:       c.Name,
+		SourceURL:  c.SourceUrl.String,
+		ImportedAt: c.ImportedAt,
+	}
+}
+
+func nullString(s string) sql.NullString { return sql.NullString{String: s, Valid: s != ""} }
+
+// CreateTx will create a new calendar and import its holidays. If ics is empty, the holidays are
+// downloaded from the SourceURL of the calendar.
+func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, c *Calendar, ics []byte) (*Calendar, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := c.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := s.loadHolidays(ctx, n.SourceURL, ics)
+	if err != nil {
+		return nil, err
+	}
+
+	n.ID = uuid.NewString()
+	db := gadb.New(tx)
+	err = db.HolidayCalCreate(ctx, gadb.HolidayCalCreateParams{
+		ID:        uuid.MustParse(n.ID),
+		Name:      n.Name,
+		SourceUrl: nullString(n.SourceURL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = setDates(ctx, db, uuid.MustParse(n.ID), holidays)
+	if err != nil {
+		return nil, err
+	}
+	n.ImportedAt = time.Now()
+
+	return n, nil
+}
+
+// UpdateTx will update the name and source URL of a calendar. Holidays are not re-imported.
+func (s *Store) UpdateTx(ctx context.Context, tx *sql.Tx, c *Calendar) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("CalendarID", c.ID)
+	if err != nil {
+		return err
+	}
+	n, err := c.Normalize()
+	if err != nil {
+		return err
+	}
+
+	return gadb.New(tx).HolidayCalUpdate(ctx, gadb.HolidayCalUpdateParams{
+		ID:        uuid.MustParse(n.ID),
+		Name:      n.Name,
+		SourceUrl: nullString(n.SourceURL),
+	})
+}
+
+// ImportTx will replace all holidays of a calendar. If ics is empty, the holidays are downloaded from
+// the SourceURL of the calendar.
+func (s *Store) ImportTx(ctx context.Context, tx *sql.Tx, id string, ics []byte) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("CalendarID", id)
+	if err != nil {
+		return err
+	}
+
+	db := gadb.New(tx)
+	cal, err := db.HolidayCalFindOneForUpdate(ctx, uuid.MustParse(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewFieldError("CalendarID", "not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	holidays, err := s.loadHolidays(ctx, cal.SourceUrl.String, ics)
+	if err != nil {
+		return err
+	}
+
+	err = db.HolidayCalDeleteDates(ctx, cal.ID)
+	if err != nil {
+		return err
+	}
+	err = setDates(ctx, db, cal.ID, holidays)
+	if err != nil {
+		return err
+	}
+
+	return db.HolidayCalSetImported(ctx, cal.ID)
+}
+
+func (s *Store) loadHolidays(ctx context.Context, url string, ics []byte) ([]Holiday, error) {
+	if len(ics) == 0 {
+		if url == "" {
+			return nil, validation.NewFieldError("ICS", "must be provided if SourceURL is not set")
+		}
+
+		var err error
+		ics, err = s.fetchICS(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ParseICS(bytes.NewReader(ics), time.Now())
+}
+
+func setDates(ctx context.Context, db *gadb.Queries, id uuid.UUID, holidays []Holiday) error {
+	dates := make([]time.Time, len(holidays))
+	names := make([]string, len(holidays))
+	for i, h := range holidays {
+		dates[i] = h.Date
+		names[i] = h.Name
+	}
+
+	return db.HolidayCalInsertDates(ctx, gadb.HolidayCalInsertDatesParams{
+		CalendarID: id,
+		Dates:      dates,
+		Names:      names,
+	})
+}
+
+// DeleteManyTx will delete the calendars with the given IDs, detaching them from any schedules.
+func (s *Store) DeleteManyTx(ctx context.Context, tx *sql.Tx, ids []string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+	err = validate.ManyUUID("CalendarID", ids, 50)
+	if err != nil {
+		return err
+	}
+
+	uuids := make([]uuid.UUID, len(ids))
+	for i, id := range ids {
+		uuids[i] = uuid.MustParse(id)
+	}
+
+	return gadb.New(tx).HolidayCalDelete(ctx, uuids)
+}
+
+// FindOne will return the calendar with the given ID.
+func (s *Store) FindOne(ctx context.Context, id string) (*Calendar, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("CalendarID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := gadb.New(s.db).HolidayCalFindOne(ctx, uuid.MustParse(id))
+	if err != nil {
+		return nil, err
+	}
+
+	c := calFromDB(row)
+	return &c, nil
+}
+
+// FindAll will return all calendars, sorted by name.
+func (s *Store) FindAll(ctx context.Context) ([]Calendar, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).HolidayCalFindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Calendar, len(rows))
+	for i, row := range rows {
+		result[i] = calFromDB(row)
+	}
+
+	return result, nil
+}
+
+// Holidays will return the holidays of a calendar between the start and end dates (inclusive).
+func (s *Store) Holidays(ctx context.Context, id string, start, end time.Time) ([]Holiday, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("CalendarID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).HolidayCalDates(ctx, gadb.HolidayCalDatesParams{
+		CalendarID: uuid.MustParse(id),
+		StartDate:  start,
+		EndDate:    end,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Holiday, len(rows))
+	for i, row := range rows {
+		result[i] = Holiday{Date: row.Date, Name: row.Name}
+	}
+
+	return result, nil
+}
+
+// ScheduleCalendars will return the calendars attached to a schedule.
+func (s *Store) ScheduleCalendars(ctx context.Context, scheduleID string) ([]Calendar, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ScheduleID", scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).HolidayCalScheduleCalendars(ctx, uuid.MustParse(scheduleID))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Calendar, len(rows))
+	for i, row := range rows {
+		result[i] = calFromDB(row.HolidayCalendar)
+	}
+
+	return result, nil
+}
+
+// SetScheduleCalendarsTx will set the calendars attached to a schedule.
+func (s *Store) SetScheduleCalendarsTx(ctx context.Context, tx *sql.Tx, scheduleID string, calendarIDs []string) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.Many(
+		validate.UUID("ScheduleID", scheduleID),
+		validate.ManyUUID("CalendarIDs", calendarIDs, 10),
+	)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uuid.UUID, len(calendarIDs))
+	for i, id := range calendarIDs {
+		ids[i] = uuid.MustParse(id)
+	}
+
+	db := gadb.New(tx)
+	err = db.HolidayCalClearScheduleCalendars(ctx, gadb.HolidayCalClearScheduleCalendarsParams{
+		ScheduleID:  uuid.MustParse(scheduleID),
+		CalendarIds: ids,
+	})
+	if err != nil {
+		return err
+	}
+
+	return db.HolidayCalAddScheduleCalendars(ctx, gadb.HolidayCalAddScheduleCalendarsParams{
+		ScheduleID:  uuid.MustParse(scheduleID),
+		CalendarIds: ids,
+	})
+}
@@ -0,0 +1,50 @@
+package incident
+
+import (
+	"strings"
+	"time"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/validation/validate"
+)
+
+// An Incident groups related alerts, potentially from multiple services, so they
+// can be tracked and responded to together.
+type Incident struct {
+	ID        int
+	Summary   string
+	Details   string
+	Status    alert.Status
+	CreatedAt time.Time
+}
+
+// Normalize will validate and normalize the Incident, returning a copy.
+func (inc Incident) Normalize() (*Incident, error) {
+	inc.Summary = strings.ReplaceAll(inc.Summary, "\n", " ")
+	inc.Summary = strings.ReplaceAll(inc.Summary, "  ", " ")
+	if inc.Status == "" {
+		inc.Status = alert.StatusTriggered
+	}
+
+	err := validate.Many(
+		validate.RequiredText("Summary", inc.Summary, 1, alert.MaxSummaryLength),
+		validate.Text("Details", inc.Details, 0, alert.MaxDetailsLength),
+		validate.OneOf("Status", inc.Status, alert.StatusTriggered, alert.StatusActive, alert.StatusClosed),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inc, nil
+}
+
+func fromDB(row gadb.Incident) Incident {
+	return Incident{
+		ID:        int(row.ID),
+		Summary:   row.Summary,
+		Details:   row.Details,
+		Status:    alert.Status(row.Status),
+		CreatedAt: row.CreatedAt,
+	}
+}
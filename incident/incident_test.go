@@ -0,0 +1,60 @@
+package incident
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+)
+
+func TestIncident_Normalize(t *testing.T) {
+	inc, err := Incident{Summary: "Database\noutage"}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, "Database outage", inc.Summary)
+	assert.Equal(t, alert.StatusTriggered, inc.Status)
+
+	_, err = Incident{}.Normalize()
+	assert.Error(t, err, "summary is required")
+
+	_, err = Incident{Summary: "foo", Details: strings.Repeat("a", alert.MaxDetailsLength+1)}.Normalize()
+	assert.Error(t, err, "details too long")
+
+	_, err = Incident{Summary: "foo", Status: "bar"}.Normalize()
+	assert.Error(t, err, "invalid status")
+}
+
+func TestInBatches(t *testing.T) {
+	ids := make([]int, 1201)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	var sizes []int
+	var seen []int
+	err := inBatches(ids, maxAlertBatch, func(batch []int) error {
+		sizes = append(sizes, len(batch))
+		seen = append(seen, batch...)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{500, 500, 201}, sizes)
+	assert.Equal(t, ids, seen, "every alert should be updated exactly once, in order")
+
+	// an error should stop propagation to any remaining alerts
+	var calls int
+	err = inBatches(ids, maxAlertBatch, func(batch []int) error {
+		calls++
+		return errors.New("update failed")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	err = inBatches(nil, maxAlertBatch, func(batch []int) error {
+		t.Fatal("should not be called for an incident with no open alerts")
+		return nil
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,103 @@
+-- name: IncidentCreate :one
+INSERT INTO incidents(summary, details)
+    VALUES (@summary, @details)
+RETURNING
+    id, created_at;
+
+-- name: IncidentUpdate :exec
+UPDATE
+    incidents
+SET
+    summary = @summary,
+    details = @details
+WHERE
+    id = @id;
+
+-- name: IncidentFindMany :many
+SELECT
+    *
+FROM
+    incidents
+WHERE
+    id = ANY (@ids::bigint[]);
+
+-- name: IncidentFindOneForUpdate :one
+SELECT
+    *
+FROM
+    incidents
+WHERE
+    id = @id
+FOR UPDATE;
+
+-- name: IncidentSearch :many
+SELECT
+    *
+FROM
+    incidents
+WHERE (NOT @omit_closed::bool
+    OR status != 'closed')
+AND (@after_id::bigint = 0
+    OR id < @after_id)
+AND (@search::text = ''
+    OR summary ILIKE '%' || @search || '%')
+ORDER BY
+    id DESC
+LIMIT @max_results;
+
+-- name: IncidentSetStatus :exec
+-- IncidentSetStatus updates the status of an incident. Closed incidents can't be reopened.
+UPDATE
+    incidents
+SET
+    status = @status
+WHERE
+    id = @id
+    AND status != 'closed';
+
+-- name: IncidentAlertIDs :many
+SELECT
+    alert_id
+FROM
+    incident_alerts
+WHERE
+    incident_id = @incident_id
+ORDER BY
+    alert_id;
+
+-- name: IncidentAlertIDsForStatus :many
+-- IncidentAlertIDsForStatus returns the IDs of member alerts that are not closed.
+SELECT
+    ia.alert_id
+FROM
+    incident_alerts ia
+    JOIN alerts a ON a.id = ia.alert_id
+WHERE
+    ia.incident_id = @incident_id
+    AND a.status != 'closed'
+ORDER BY
+    ia.alert_id;
+
+-- name: IncidentByAlertIDs :many
+SELECT
+    alert_id,
+    incident_id
+FROM
+    incident_alerts
+WHERE
+    alert_id = ANY (@alert_ids::bigint[]);
+
+-- name: IncidentAddAlerts :exec
+-- IncidentAddAlerts attaches alerts to an incident, moving them from any incident they were previously attached to.
+INSERT INTO incident_alerts(incident_id, alert_id)
+SELECT
+    @incident_id,
+    unnest(@alert_ids::bigint[])
+ON CONFLICT (alert_id)
+    DO UPDATE SET
+        incident_id = excluded.incident_id, created_at = now();
+
+-- name: IncidentRemoveAlerts :exec
+DELETE FROM incident_alerts
+WHERE incident_id = @incident_id
+    AND alert_id = ANY (@alert_ids::bigint[]);
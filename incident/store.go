@@ -0,0 +1,372 @@
+package incident
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// maxAlertBatch is the maximum number of alerts that can be attached or detached at once,
+// and the batch size used when propagating status changes to member alerts.
+const maxAlertBatch = 500
+
+// Store manages incidents and their member alerts.
+type Store struct {
+	db *sql.DB
+	a  *alert.Store
+}
+
+// NewStore creates a new Store.
+func NewStore(ctx context.Context, db *sql.DB, a *alert.Store) (*Store, error) {
+	return &Store{db: db, a: a}, nil
+}
+
+func (s *Store) dbtx(tx *sql.Tx) *gadb.Queries {
+	db := gadb.New(s.db)
+	if tx == nil {
+		return db
+	}
+
+	return db.WithTx(tx)
+}
+
+// CreateTx creates a new incident. The incident is always created in the triggered state.
+func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, inc *Incident) (*Incident, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := inc.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.dbtx(tx).IncidentCreate(ctx, gadb.IncidentCreateParams{
+		Summary: n.Summary,
+		Details: n.Details,
+	})
+	if err != nil {
+		return nil, err
+	}
+	n.ID = int(row.ID)
+	n.CreatedAt = row.CreatedAt
+	n.Status = alert.StatusTriggered
+
+	return n, nil
+}
+
+// UpdateTx updates the summary and details of an incident.
+func (s *Store) UpdateTx(ctx context.Context, tx *sql.Tx, inc *Incident) error {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	n, err := inc.Normalize()
+	if err != nil {
+		return err
+	}
+
+	return s.dbtx(tx).IncidentUpdate(ctx, gadb.IncidentUpdateParams{
+		ID:      int64(n.ID),
+		Summary: n.Summary,
+		Details: n.Details,
+	})
+}
+
+// FindOneTx returns a single incident, locking it for update.
+func (s *Store) FindOneTx(ctx context.Context, tx *sql.Tx, id int) (*Incident, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.dbtx(tx).IncidentFindOneForUpdate(ctx, int64(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, validation.NewFieldError("IncidentID", "incident does not exist")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	inc := fromDB(row)
+	return &inc, nil
+}
+
+// FindOne returns a single incident.
+func (s *Store) FindOne(ctx context.Context, id int) (*Incident, error) {
+	incs, err := s.FindMany(ctx, []int{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(incs) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return &incs[0], nil
+}
+
+// FindMany returns the incidents with the given IDs.
+//
+// The order and number of returned incidents is not guaranteed.
+func (s *Store) FindMany(ctx context.Context, ids []int) ([]Incident, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	err = validate.Range("IncidentIDs", len(ids), 1, search.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.dbtx(nil).IncidentFindMany(ctx, int64s(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Incident, len(rows))
+	for i, r := range rows {
+		result[i] = fromDB(r)
+	}
+
+	return result, nil
+}
+
+// IncidentIDsByAlert returns a map of alert ID to incident ID for the given alerts
+// that are attached to an incident.
+func (s *Store) IncidentIDsByAlert(ctx context.Context, alertIDs []int) (map[int]int, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	if len(alertIDs) == 0 {
+		return nil, nil
+	}
+
+	err = validate.Range("AlertIDs", len(alertIDs), 1, maxAlertBatch)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.dbtx(nil).IncidentByAlertIDs(ctx, int64s(alertIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int]int, len(rows))
+	for _, r := range rows {
+		result[int(r.AlertID)] = int(r.IncidentID)
+	}
+
+	return result, nil
+}
+
+// AlertIDs returns the IDs of all alerts attached to the incident.
+func (s *Store) AlertIDs(ctx context.Context, id int) ([]int, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := s.dbtx(nil).IncidentAlertIDs(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return ints(ids), nil
+}
+
+// AddAlertsTx attaches alerts to an incident. Alerts already attached to a different
+// incident are moved.
+func (s *Store) AddAlertsTx(ctx context.Context, tx *sql.Tx, id int, alertIDs []int) error {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	err = validate.Range("AlertIDs", len(alertIDs), 1, maxAlertBatch)
+	if err != nil {
+		return err
+	}
+
+	inc, err := s.FindOneTx(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if inc.Status == alert.StatusClosed {
+		return validation.NewFieldError("IncidentID", "cannot add alerts to a closed incident")
+	}
+
+	err = s.dbtx(tx).IncidentAddAlerts(ctx, gadb.IncidentAddAlertsParams{
+		IncidentID: int64(id),
+		AlertIds:   int64s(alertIDs),
+	})
+	return errutil.MapDBError(err)
+}
+
+// RemoveAlertsTx detaches alerts from an incident.
+func (s *Store) RemoveAlertsTx(ctx context.Context, tx *sql.Tx, id int, alertIDs []int) error {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	err = validate.Range("AlertIDs", len(alertIDs), 1, maxAlertBatch)
+	if err != nil {
+		return err
+	}
+
+	return s.dbtx(tx).IncidentRemoveAlerts(ctx, gadb.IncidentRemoveAlertsParams{
+		IncidentID: int64(id),
+		AlertIds:   int64s(alertIDs),
+	})
+}
+
+// UpdateStatus will acknowledge or close an incident. The new status is applied
+// to all open member alerts, and the incident itself, in a single transaction.
+func (s *Store) UpdateStatus(ctx context.Context, id int, status alert.Status) error {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	err = validate.OneOf("Status", status, alert.StatusActive, alert.StatusClosed)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer sqlutil.Rollback(ctx, "incident: update status", tx)
+
+	inc, err := s.FindOneTx(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if inc.Status == alert.StatusClosed {
+		return validation.NewFieldError("IncidentID", "incident is already closed")
+	}
+
+	alertIDs, err := s.dbtx(tx).IncidentAlertIDsForStatus(ctx, int64(id))
+	if err != nil {
+		return err
+	}
+
+	err = inBatches(ints(alertIDs), maxAlertBatch, func(ids []int) error {
+		_, _, err := s.a.UpdateManyAlertStatusTx(ctx, tx, status, ids, nil)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.dbtx(tx).IncidentSetStatus(ctx, gadb.IncidentSetStatusParams{
+		ID:     int64(id),
+		Status: gadb.EnumAlertStatus(status),
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// inBatches calls fn for consecutive slices of ids, each no longer than size.
+func inBatches(ids []int, size int, fn func([]int) error) error {
+	for len(ids) > 0 {
+		n := min(len(ids), size)
+		err := fn(ids[:n])
+		if err != nil {
+			return err
+		}
+		ids = ids[n:]
+	}
+
+	return nil
+}
+
+// SearchOptions contains criteria for filtering and paginating incidents.
+type SearchOptions struct {
+	Search     string       `json:"s,omitempty"`
+	OmitClosed bool         `json:"o,omitempty"`
+	After      SearchCursor `json:"a,omitempty"`
+
+	// Limit restricts the maximum number of rows returned. Default is search.DefaultMaxResults.
+	Limit int `json:"-"`
+}
+
+// SearchCursor is used to indicate a position in a paginated list.
+type SearchCursor struct {
+	ID int `json:"i,omitempty"`
+}
+
+// Search returns incidents matching the given options, newest first.
+func (s *Store) Search(ctx context.Context, opts *SearchOptions) ([]Incident, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &SearchOptions{}
+	}
+	if opts.Limit == 0 {
+		opts.Limit = search.DefaultMaxResults
+	}
+
+	err = validate.Many(
+		validate.Search("Search", opts.Search),
+		validate.Range("Limit", opts.Limit, 0, search.MaxResults),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.dbtx(nil).IncidentSearch(ctx, gadb.IncidentSearchParams{
+		OmitClosed: opts.OmitClosed,
+		AfterID:    int64(opts.After.ID),
+		Search:     search.Escape(opts.Search),
+		MaxResults: int32(opts.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Incident, len(rows))
+	for i, r := range rows {
+		result[i] = fromDB(r)
+	}
+
+	return result, nil
+}
+
+func int64s(ids []int) []int64 {
+	result := make([]int64, len(ids))
+	for i, id := range ids {
+		result[i] = int64(id)
+	}
+	return result
+}
+
+func ints(ids []int64) []int {
+	result := make([]int, len(ids))
+	for i, id := range ids {
+		result[i] = int(id)
+	}
+	return result
+}
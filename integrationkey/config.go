@@ -180,7 +180,13 @@ func (s *Store) setActionChannels(ctx context.Context, tx gadb.DBTX, actions []g
 			return validation.NewFieldError(fmt.Sprintf("Actions[%d]", i), "invalid destination type")
 		}
 
-		actions[i].ChannelID, err = s.ncStore.MapDestToID(ctx, tx, act.Dest)
+		// normalize before mapping, so the stored action matches the channel
+		actions[i].Dest, err = s.reg.NormalizeDest(ctx, act.Dest)
+		if err != nil {
+			return err
+		}
+
+		actions[i].ChannelID, err = s.ncStore.MapDestToID(ctx, tx, actions[i].Dest)
 		if err != nil {
 			return err
 		}
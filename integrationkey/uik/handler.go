@@ -56,13 +56,18 @@ func (h *Handler) handleAction(ctx context.Context, act gadb.UIKActionV1) (inser
 		if act.Param("close") == "true" {
 			status = alert.StatusClosed
 		}
+		prio, err := alert.ParsePriority(act.Param("priority"))
+		if err != nil {
+			return false, err
+		}
 
-		_, _, err := h.alertStore.CreateOrUpdate(ctx, &alert.Alert{
+		_, _, err = h.alertStore.CreateOrUpdate(ctx, &alert.Alert{
 			ServiceID: permission.ServiceID(ctx),
 			Summary:   act.Param("summary"),
 			Details:   act.Param("details"),
 			Source:    alert.SourceUniversal,
 			Status:    status,
+			Priority:  prio,
 		})
 		if err != nil {
 			return false, err
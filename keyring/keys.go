@@ -32,6 +32,9 @@ func (k Keys) Decrypt(pemData []byte) (data []byte, label string, err error) {
 		k = Keys{[]byte{}}
 	}
 	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, "", errors.New("invalid PEM data")
+	}
 
 	for _, key := range k {
 		//nolint:all SA1019 TODO migrate off deprecated method; usage is secure for at-rest data
@@ -38,3 +38,58 @@ SET
 WHERE
     id = @id;
 
+
+-- name: Keyring_LockDests :exec
+-- Locks tables with destinations so no new encrypted args can be created.
+LOCK TABLE user_contact_methods, notification_channels, uik_config IN EXCLUSIVE MODE;
+
+-- name: Keyring_GetEncryptedCMDests :many
+SELECT
+    id,
+    dest
+FROM
+    user_contact_methods
+WHERE
+    dest::text LIKE '%-----BEGIN %';
+
+-- name: Keyring_UpdateCMDest :exec
+UPDATE
+    user_contact_methods
+SET
+    dest = @dest
+WHERE
+    id = @id;
+
+-- name: Keyring_GetEncryptedNCDests :many
+SELECT
+    id,
+    dest
+FROM
+    notification_channels
+WHERE
+    dest::text LIKE '%-----BEGIN %';
+
+-- name: Keyring_UpdateNCDest :exec
+UPDATE
+    notification_channels
+SET
+    dest = @dest
+WHERE
+    id = @id;
+
+-- name: Keyring_GetEncryptedUIKConfigs :many
+SELECT
+    id,
+    config
+FROM
+    uik_config
+WHERE
+    config::text LIKE '%-----BEGIN %';
+
+-- name: Keyring_UpdateUIKConfig :exec
+UPDATE
+    uik_config
+SET
+    config = @config
+WHERE
+    id = @id;
@@ -94,6 +94,11 @@ func ReEncryptAll(ctx context.Context, db *sql.DB, keys Keys) error {
 		}
 	}
 
+	err = reEncryptDests(ctx, gdb, keys)
+	if err != nil {
+		return err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return fmt.Errorf("commit transaction: %w", err)
@@ -0,0 +1,137 @@
+package keyring
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/target/goalert/gadb"
+)
+
+// destReEncrypter will re-encrypt encrypted destination args (e.g., webhook signing secrets).
+//
+// The same encrypted value always results in the same new value, so destinations that were
+// equal before (like a UIK action and its notification channel) remain equal.
+type destReEncrypter struct {
+	keys  Keys
+	cache map[string]string
+}
+
+func (r *destReEncrypter) value(v string) (string, error) {
+	if res, ok := r.cache[v]; ok {
+		return res, nil
+	}
+
+	dec, label, err := r.keys.Decrypt([]byte(v))
+	if err != nil {
+		return "", err
+	}
+	enc, err := r.keys.Encrypt(label, dec)
+	if err != nil {
+		return "", err
+	}
+	r.cache[v] = string(enc)
+
+	return string(enc), nil
+}
+
+// dest returns a copy of the destination with all encrypted args re-encrypted.
+func (r *destReEncrypter) dest(d gadb.DestV1) (gadb.DestV1, error) {
+	args := make(map[string]string, len(d.Args))
+	for name, v := range d.Args {
+		args[name] = v
+		if !strings.HasPrefix(v, "-----BEGIN ") {
+			continue
+		}
+
+		var err error
+		args[name], err = r.value(v)
+		if err != nil {
+			return d, fmt.Errorf("arg %s: %w", name, err)
+		}
+	}
+	d.Args = args
+
+	return d, nil
+}
+
+func (r *destReEncrypter) actions(actions []gadb.UIKActionV1) error {
+	for i, a := range actions {
+		var err error
+		actions[i].Dest, err = r.dest(a.Dest)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *destReEncrypter) config(cfg gadb.UIKConfigV1) error {
+	for _, rule := range cfg.Rules {
+		err := r.actions(rule.Actions)
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.actions(cfg.DefaultActions)
+}
+
+// reEncryptDests will re-encrypt destination args of contact methods, notification channels, and
+// universal integration key actions.
+func reEncryptDests(ctx context.Context, gdb *gadb.Queries, keys Keys) error {
+	err := gdb.Keyring_LockDests(ctx)
+	if err != nil {
+		return fmt.Errorf("lock destinations: %w", err)
+	}
+
+	r := &destReEncrypter{keys: keys, cache: make(map[string]string)}
+
+	cms, err := gdb.Keyring_GetEncryptedCMDests(ctx)
+	if err != nil {
+		return fmt.Errorf("get contact method destinations: %w", err)
+	}
+	for _, cm := range cms {
+		d, err := r.dest(cm.Dest.DestV1)
+		if err != nil {
+			return fmt.Errorf("re-encrypt contact method '%s': %w", cm.ID, err)
+		}
+		err = gdb.Keyring_UpdateCMDest(ctx, gadb.Keyring_UpdateCMDestParams{ID: cm.ID, Dest: gadb.NullDestV1{Valid: true, DestV1: d}})
+		if err != nil {
+			return fmt.Errorf("update contact method '%s': %w", cm.ID, err)
+		}
+	}
+
+	ncs, err := gdb.Keyring_GetEncryptedNCDests(ctx)
+	if err != nil {
+		return fmt.Errorf("get notification channel destinations: %w", err)
+	}
+	for _, nc := range ncs {
+		d, err := r.dest(nc.Dest.DestV1)
+		if err != nil {
+			return fmt.Errorf("re-encrypt notification channel '%s': %w", nc.ID, err)
+		}
+		err = gdb.Keyring_UpdateNCDest(ctx, gadb.Keyring_UpdateNCDestParams{ID: nc.ID, Dest: gadb.NullDestV1{Valid: true, DestV1: d}})
+		if err != nil {
+			return fmt.Errorf("update notification channel '%s': %w", nc.ID, err)
+		}
+	}
+
+	cfgs, err := gdb.Keyring_GetEncryptedUIKConfigs(ctx)
+	if err != nil {
+		return fmt.Errorf("get integration key configs: %w", err)
+	}
+	for _, cfg := range cfgs {
+		err = r.config(cfg.Config.V1)
+		if err != nil {
+			return fmt.Errorf("re-encrypt integration key config '%s': %w", cfg.ID, err)
+		}
+		err = gdb.Keyring_UpdateUIKConfig(ctx, gadb.Keyring_UpdateUIKConfigParams{ID: cfg.ID, Config: cfg.Config})
+		if err != nil {
+			return fmt.Errorf("update integration key config '%s': %w", cfg.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -76,6 +77,11 @@ type Config struct {
 
 	// Keys specifies a set of keys to use for encrypting and decrypting the private key.
 	Keys Keys
+
+	// Curve is the elliptic curve used for new keys. If nil, P-224 is used.
+	//
+	// Only P-224 keys support Sign and Verify, P-256 keys may be used for JWTs (ES256).
+	Curve elliptic.Curve
 }
 
 // DB implements a Keyring using postgres as the datastore.
@@ -142,6 +148,12 @@ func NewDB(ctx context.Context, logger *log.Logger, db *sql.DB, cfg *Config) (*D
 	if cfg.MaxOldKeys == 0 {
 		cfg.MaxOldKeys = 1
 	}
+	if cfg.Curve == nil {
+		cfg.Curve = elliptic.P224()
+	}
+	if cfg.Curve != elliptic.P224() && cfg.Curve != elliptic.P256() {
+		return nil, validation.NewFieldError("Curve", "must be P-224 or P-256")
+	}
 	err := validate.Many(
 		validate.IDName("Name", cfg.Name),
 
@@ -261,7 +273,7 @@ mainLoop:
 }
 
 func (db *DB) newKey() (*ecdsa.PrivateKey, []byte, error) {
-	key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	key, err := ecdsa.GenerateKey(db.cfg.Curve, rand.Reader)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -468,6 +480,15 @@ func (db *DB) refreshAndRotateKeys(ctx context.Context, forceRotation bool) erro
 	return nil
 }
 
+// jwtMethod returns the JWT signing method for the curve of the given key.
+func jwtMethod(key *ecdsa.PrivateKey) jwt.SigningMethod {
+	if key.Curve == elliptic.P256() {
+		return jwt.SigningMethodES256
+	}
+
+	return jwt.GetSigningMethod("ES224")
+}
+
 func (db *DB) SignJWT(c jwt.Claims) (string, error) {
 	db.mx.RLock()
 	defer db.mx.RUnlock()
@@ -476,12 +497,24 @@ func (db *DB) SignJWT(c jwt.Claims) (string, error) {
 		return "", errors.New("signing key unavailable")
 	}
 
-	tok := jwt.NewWithClaims(jwt.GetSigningMethod("ES224"), c)
+	tok := jwt.NewWithClaims(jwtMethod(db.signingKey), c)
 	tok.Header["key"] = byte(db.rotationCount % 256)
 
 	return tok.SignedString(db.signingKey)
 }
 
+// PublicKey returns the public key of the current signing key.
+func (db *DB) PublicKey() (*ecdsa.PublicKey, error) {
+	db.mx.RLock()
+	defer db.mx.RUnlock()
+
+	if db.signingKey == nil {
+		return nil, errors.New("signing key unavailable")
+	}
+
+	return &db.signingKey.PublicKey, nil
+}
+
 // Sign will sign a message and return the signature.
 func (db *DB) Sign(p []byte) ([]byte, error) {
 	db.mx.RLock()
@@ -490,6 +523,9 @@ func (db *DB) Sign(p []byte) ([]byte, error) {
 	if db.signingKey == nil {
 		return nil, errors.New("signing key unavailable")
 	}
+	if db.signingKey.Curve != elliptic.P224() {
+		return nil, errors.New("signing messages requires a P-224 key")
+	}
 
 	hdr := header{
 		Version:  1, // v1 is latest
@@ -538,7 +574,7 @@ func (db *DB) VerifyJWT(s string, c jwt.Claims, iss, aud string) (bool, error) {
 		currentKey = byte(keyIndex) == byte(db.rotationCount) || byte(keyIndex) == byte(db.rotationCount+1)
 		return &key, nil
 	},
-		jwt.WithValidMethods([]string{"ES224"}),
+		jwt.WithValidMethods([]string{"ES224", "ES256"}),
 		jwt.WithIssuer(iss),
 		jwt.WithAudience(aud),
 	)
@@ -7,6 +7,7 @@ import (
 	"crypto/rand"
 	"testing"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
@@ -49,3 +50,34 @@ func TestSignVerify(t *testing.T) {
 		t.Run("", try)
 	}
 }
+
+func TestSignVerifyJWT_P256(t *testing.T) {
+	signKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := &DB{
+		verificationKeys: map[byte]ecdsa.PublicKey{0: signKey.PublicKey},
+		signingKey:       signKey,
+	}
+
+	tok, err := db.SignJWT(jwt.RegisteredClaims{Issuer: "goalert", Audience: jwt.ClaimStrings{"test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var c jwt.RegisteredClaims
+	current, err := db.VerifyJWT(tok, &c, "goalert", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !current {
+		t.Fatal("old key used")
+	}
+
+	_, err = db.Sign([]byte("test"))
+	if err == nil {
+		t.Fatal("expected error signing message with P-256 key")
+	}
+}
@@ -0,0 +1,68 @@
+-- name: MaintWindowInsert :exec
+INSERT INTO service_maintenance_windows(id, service_id, label_key, label_value, start_time, end_time, repeat, time_zone, reason, end_action, created_by)
+    VALUES (@id, @service_id, @label_key, @label_value, @start_time, @end_time, @repeat, @time_zone, @reason, @end_action, @created_by);
+
+-- name: MaintWindowUpdate :exec
+UPDATE
+    service_maintenance_windows
+SET
+    start_time = @start_time,
+    end_time = @end_time,
+    repeat = @repeat,
+    time_zone = @time_zone,
+    reason = @reason,
+    end_action = @end_action
+WHERE
+    id = @id;
+
+-- name: MaintWindowDelete :exec
+DELETE FROM service_maintenance_windows
+WHERE id = ANY (@ids::uuid[]);
+
+-- name: MaintWindowFindOneForUpdate :one
+SELECT
+    *
+FROM
+    service_maintenance_windows
+WHERE
+    id = @id
+FOR UPDATE;
+
+-- name: MaintWindowFindMany :many
+SELECT
+    *
+FROM
+    service_maintenance_windows
+WHERE
+    id = ANY (@ids::uuid[]);
+
+-- name: MaintWindowFindAll :many
+SELECT
+    *
+FROM
+    service_maintenance_windows
+ORDER BY
+    start_time,
+    id;
+
+-- name: MaintWindowFindAllByService :many
+-- MaintWindowFindAllByService returns all maintenance windows that apply to a service, either directly or by label.
+SELECT
+    w.*
+FROM
+    service_maintenance_windows w
+WHERE
+    w.service_id = @service_id
+    OR EXISTS (
+        SELECT
+            1
+        FROM
+            labels l
+        WHERE
+            l.tgt_service_id = @service_id
+            AND l.key = w.label_key
+            AND (w.label_value = ''
+                OR l.value = w.label_value))
+ORDER BY
+    w.start_time,
+    w.id;
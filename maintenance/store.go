@@ -0,0 +1,245 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store manages scheduled maintenance windows.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore creates a new Store.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	return &Store{db: db}, nil
+}
+
+func (s *Store) dbtx(tx *sql.Tx) *gadb.Queries {
+	db := gadb.New(s.db)
+	if tx == nil {
+		return db
+	}
+
+	return db.WithTx(tx)
+}
+
+// CreateTx creates a new maintenance window, recording the current user as the creator.
+func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, w *Window) (*Window, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := w.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	id := uuid.New()
+	n.ID = id.String()
+	n.CreatedBy = permission.UserID(ctx)
+
+	var svcID uuid.NullUUID
+	if n.ServiceID != "" {
+		svcID = uuid.NullUUID{UUID: uuid.MustParse(n.ServiceID), Valid: true} // already validated in Normalize
+	}
+
+	err = s.dbtx(tx).MaintWindowInsert(ctx, gadb.MaintWindowInsertParams{
+		ID:         id,
+		ServiceID:  svcID,
+		LabelKey:   sql.NullString{String: n.LabelKey, Valid: n.LabelKey != ""},
+		LabelValue: n.LabelValue,
+		StartTime:  n.Start,
+		EndTime:    n.End,
+		Repeat:     gadb.EnumMaintWindowRepeat(n.Repeat),
+		TimeZone:   n.TimeZone.String(),
+		Reason:     n.Reason,
+		EndAction:  gadb.EnumMaintWindowEndAction(n.EndAction),
+		CreatedBy:  permission.UserNullUUID(ctx),
+	})
+	if err != nil {
+		return nil, errutil.MapDBError(err)
+	}
+
+	return n, nil
+}
+
+// UpdateTx updates the schedule, reason, and end action of a maintenance window.
+// The target of a window can not be changed.
+func (s *Store) UpdateTx(ctx context.Context, tx *sql.Tx, w *Window) error {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	n, err := w.Normalize()
+	if err != nil {
+		return err
+	}
+	id, err := validate.ParseUUID("ID", n.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.dbtx(tx).MaintWindowUpdate(ctx, gadb.MaintWindowUpdateParams{
+		ID:        id,
+		StartTime: n.Start,
+		EndTime:   n.End,
+		Repeat:    gadb.EnumMaintWindowRepeat(n.Repeat),
+		TimeZone:  n.TimeZone.String(),
+		Reason:    n.Reason,
+		EndAction: gadb.EnumMaintWindowEndAction(n.EndAction),
+	})
+}
+
+// DeleteTx deletes the maintenance windows with the given ID(s).
+func (s *Store) DeleteTx(ctx context.Context, tx *sql.Tx, idStrs ...string) error {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	ids, err := validate.ParseManyUUID("ID", idStrs, search.MaxResults)
+	if err != nil {
+		return err
+	}
+
+	return s.dbtx(tx).MaintWindowDelete(ctx, ids)
+}
+
+// FindOneTx returns a maintenance window, locking it for update.
+func (s *Store) FindOneTx(ctx context.Context, tx *sql.Tx, idStr string) (*Window, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := validate.ParseUUID("ID", idStr)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.dbtx(tx).MaintWindowFindOneForUpdate(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, validation.NewFieldError("ID", "maintenance window not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return FromDB(row)
+}
+
+// FindMany returns the maintenance windows with the given IDs.
+//
+// The order and number of returned windows is not guaranteed.
+func (s *Store) FindMany(ctx context.Context, idStrs []string) ([]Window, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	if len(idStrs) == 0 {
+		return nil, nil
+	}
+
+	ids, err := validate.ParseManyUUID("IDs", idStrs, search.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.dbtx(nil).MaintWindowFindMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromDBMany(rows)
+}
+
+// FindAll returns all maintenance windows.
+func (s *Store) FindAll(ctx context.Context) ([]Window, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.dbtx(nil).MaintWindowFindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromDBMany(rows)
+}
+
+// FindAllByService returns all maintenance windows that apply to the given service, either
+// directly or through a matching label.
+func (s *Store) FindAllByService(ctx context.Context, serviceID string) ([]Window, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := validate.ParseUUID("ServiceID", serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.dbtx(nil).MaintWindowFindAllByService(ctx, uuid.NullUUID{UUID: id, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return fromDBMany(rows)
+}
+
+// FromDB converts a database row into a Window.
+func FromDB(row gadb.ServiceMaintenanceWindow) (*Window, error) {
+	tz, err := util.LoadLocation(row.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	w := Window{
+		ID:          row.ID.String(),
+		LabelKey:    row.LabelKey.String,
+		LabelValue:  row.LabelValue,
+		Start:       row.StartTime,
+		End:         row.EndTime,
+		Repeat:      Repeat(row.Repeat),
+		TimeZone:    tz,
+		Reason:      row.Reason,
+		EndAction:   EndAction(row.EndAction),
+		CreatedAt:   row.CreatedAt,
+		ActiveSince: row.ActiveSince.Time,
+	}
+	if row.ServiceID.Valid {
+		w.ServiceID = row.ServiceID.UUID.String()
+	}
+	if row.CreatedBy.Valid {
+		w.CreatedBy = row.CreatedBy.UUID.String()
+	}
+
+	return &w, nil
+}
+
+func fromDBMany(rows []gadb.ServiceMaintenanceWindow) ([]Window, error) {
+	result := make([]Window, 0, len(rows))
+	for _, r := range rows {
+		w, err := FromDB(r)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *w)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,198 @@
+package maintenance
+
+import (
+	"time"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Repeat indicates how often a maintenance Window recurs.
+type Repeat string
+
+// Supported repeat values.
+const (
+	RepeatNone   Repeat = "none"
+	RepeatDaily  Repeat = "daily"
+	RepeatWeekly Repeat = "weekly"
+)
+
+// period returns the number of days between occurrences, or 0 if the window does not repeat.
+func (r Repeat) period() int {
+	switch r {
+	case RepeatDaily:
+		return 1
+	case RepeatWeekly:
+		return 7
+	}
+	return 0
+}
+
+// EndAction determines what happens to alerts still open when a maintenance Window ends.
+type EndAction string
+
+// Supported end actions.
+const (
+	// EndActionEscalate will begin escalation of any open alerts once the window ends.
+	EndActionEscalate EndAction = "escalate"
+
+	// EndActionDrop will close any alerts created during the window that are still open.
+	EndActionDrop EndAction = "drop"
+)
+
+// MaxReasonLength is the maximum length of a maintenance window reason.
+const MaxReasonLength = 255
+
+// A Window is a scheduled, optionally recurring, period of maintenance for a service or
+// all services matching a label. While a window is active, alerts are still created and
+// logged, but escalation and notifications are held.
+type Window struct {
+	ID string
+
+	// ServiceID is set if the window applies to a single service.
+	ServiceID string
+
+	// LabelKey is set if the window applies to all services with a matching label.
+	// If LabelValue is empty, any value matches.
+	LabelKey   string
+	LabelValue string
+
+	// Start and End are the bounds of the first occurrence.
+	Start time.Time
+	End   time.Time
+
+	Repeat   Repeat
+	TimeZone *time.Location
+
+	Reason    string
+	EndAction EndAction
+
+	CreatedBy string
+	CreatedAt time.Time
+
+	// ActiveSince is the start of the current occurrence, set by the engine while the window is active.
+	ActiveSince time.Time
+}
+
+// Normalize will validate and normalize the Window, returning a copy.
+func (w Window) Normalize() (*Window, error) {
+	if w.Repeat == "" {
+		w.Repeat = RepeatNone
+	}
+	if w.EndAction == "" {
+		w.EndAction = EndActionEscalate
+	}
+	if w.TimeZone == nil {
+		w.TimeZone = time.UTC
+	}
+	w.Start = w.Start.Truncate(time.Minute)
+	w.End = w.End.Truncate(time.Minute)
+
+	err := validate.Many(
+		validate.OneOf("Repeat", w.Repeat, RepeatNone, RepeatDaily, RepeatWeekly),
+		validate.OneOf("EndAction", w.EndAction, EndActionEscalate, EndActionDrop),
+		validate.Text("Reason", w.Reason, 0, MaxReasonLength),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case w.ServiceID != "" && w.LabelKey != "":
+		return nil, validation.NewGenericError("cannot set both service ID and label key")
+	case w.ServiceID != "":
+		err = validate.UUID("ServiceID", w.ServiceID)
+	case w.LabelKey != "":
+		err = validate.Many(
+			validate.LabelKey("LabelKey", w.LabelKey),
+			validate.LabelValue("LabelValue", w.LabelValue),
+		)
+	default:
+		return nil, validation.NewGenericError("must set either service ID or label key")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if w.Start.IsZero() {
+		return nil, validation.NewFieldError("Start", "must be set")
+	}
+	if !w.End.After(w.Start) {
+		return nil, validation.NewFieldError("End", "must be after start")
+	}
+	if p := w.Repeat.period(); p > 0 {
+		// occurrences must not overlap
+		err = validate.Duration("End", w.End.Sub(w.Start), time.Minute, time.Duration(p)*24*time.Hour)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &w, nil
+}
+
+// occurrence returns the bounds of the n-th occurrence of the window.
+//
+// Days are added in the window's time zone so recurring windows keep the same wall-clock start time
+// across DST changes. The duration is kept as-is, since the end time may otherwise fall in a DST gap.
+func (w Window) occurrence(n int) (start, end time.Time) {
+	days := n * w.Repeat.period()
+	tz := w.TimeZone
+	if tz == nil {
+		tz = time.UTC
+	}
+
+	start = w.Start.In(tz).AddDate(0, 0, days)
+	return start, start.Add(w.End.Sub(w.Start))
+}
+
+// ActiveAt returns the start and end of the occurrence active at t, if any.
+func (w Window) ActiveAt(t time.Time) (start, end time.Time, ok bool) {
+	if t.Before(w.Start) {
+		return time.Time{}, time.Time{}, false
+	}
+
+	p := w.Repeat.period()
+	if p == 0 {
+		if t.Before(w.End) {
+			return w.Start, w.End, true
+		}
+		return time.Time{}, time.Time{}, false
+	}
+
+	// estimate the occurrence and check its neighbors to account for DST shifts
+	n := int(t.Sub(w.Start) / (time.Duration(p) * 24 * time.Hour))
+	for i := n + 1; i >= n-1 && i >= 0; i-- {
+		start, end = w.occurrence(i)
+		if !t.Before(start) && t.Before(end) {
+			return start, end, true
+		}
+	}
+
+	return time.Time{}, time.Time{}, false
+}
+
+// Next returns the start and end of the current or next occurrence after t, if any.
+func (w Window) Next(t time.Time) (start, end time.Time, ok bool) {
+	if start, end, ok = w.ActiveAt(t); ok {
+		return start, end, true
+	}
+	if t.Before(w.Start) {
+		return w.Start, w.End, true
+	}
+
+	p := w.Repeat.period()
+	if p == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	n := int(t.Sub(w.Start) / (time.Duration(p) * 24 * time.Hour))
+	for i := max(n-1, 0); i <= n+2; i++ {
+		start, end = w.occurrence(i)
+		if start.After(t) {
+			return start, end, true
+		}
+	}
+
+	return time.Time{}, time.Time{}, false
+}
@@ -0,0 +1,98 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindow_Normalize(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	valid := Window{
+		ServiceID: "fc0a6a1e-76a7-4bd5-a1e3-c6d8e5b0e3d1",
+		Start:     start,
+		End:       start.Add(2 * time.Hour),
+	}
+
+	n, err := valid.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, RepeatNone, n.Repeat)
+	assert.Equal(t, EndActionEscalate, n.EndAction)
+	assert.Equal(t, time.UTC, n.TimeZone)
+
+	check := func(desc string, fn func(w *Window)) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			w := valid
+			fn(&w)
+			_, err := w.Normalize()
+			assert.Error(t, err)
+		})
+	}
+
+	check("no target", func(w *Window) { w.ServiceID = "" })
+	check("both targets", func(w *Window) { w.LabelKey = "foo/bar" })
+	check("end before start", func(w *Window) { w.End = w.Start.Add(-time.Hour) })
+	check("daily overlap", func(w *Window) { w.Repeat = RepeatDaily; w.End = w.Start.Add(25 * time.Hour) })
+	check("bad repeat", func(w *Window) { w.Repeat = "monthly" })
+}
+
+func TestWindow_ActiveAt(t *testing.T) {
+	start := time.Date(2026, 1, 5, 22, 0, 0, 0, time.UTC)
+	w := Window{Start: start, End: start.Add(4 * time.Hour), Repeat: RepeatWeekly, TimeZone: time.UTC}
+
+	_, _, ok := w.ActiveAt(start.Add(-time.Minute))
+	assert.False(t, ok, "before first occurrence")
+
+	s, e, ok := w.ActiveAt(start.Add(time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, start, s)
+	assert.Equal(t, start.Add(4*time.Hour), e)
+
+	_, _, ok = w.ActiveAt(start.Add(4 * time.Hour))
+	assert.False(t, ok, "end is exclusive")
+
+	s, _, ok = w.ActiveAt(start.AddDate(0, 0, 14).Add(time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, start.AddDate(0, 0, 14), s)
+
+	w.Repeat = RepeatNone
+	_, _, ok = w.ActiveAt(start.AddDate(0, 0, 7).Add(time.Hour))
+	assert.False(t, ok, "non-repeating")
+}
+
+func TestWindow_DST(t *testing.T) {
+	tz, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	// weekly at 01:30 local, DST begins 2026-03-08
+	start := time.Date(2026, 3, 1, 1, 30, 0, 0, tz)
+	w := Window{Start: start, End: start.Add(time.Hour), Repeat: RepeatWeekly, TimeZone: tz}
+
+	s, _, ok := w.Next(start.Add(2 * time.Hour))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, 3, 8, 1, 30, 0, 0, tz), s)
+
+	_, _, ok = w.ActiveAt(time.Date(2026, 3, 8, 1, 45, 0, 0, tz))
+	assert.True(t, ok, "same wall-clock time after DST change")
+}
+
+func TestWindow_Next(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	w := Window{Start: start, End: start.Add(time.Hour), Repeat: RepeatDaily, TimeZone: time.UTC}
+
+	s, e, ok := w.Next(start.Add(-time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, start, s)
+	assert.Equal(t, start.Add(time.Hour), e)
+
+	s, _, ok = w.Next(start.Add(2 * time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, start.AddDate(0, 0, 1), s)
+
+	w.Repeat = RepeatNone
+	_, _, ok = w.Next(start.Add(2 * time.Hour))
+	assert.False(t, ok)
+}
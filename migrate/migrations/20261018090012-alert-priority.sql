@@ -0,0 +1,12 @@
+-- +migrate Up
+ALTER TABLE alerts
+    ADD COLUMN priority integer NOT NULL DEFAULT 0,
+    ADD CONSTRAINT alerts_priority_check CHECK (priority >= 0 AND priority <= 5);
+
+CREATE INDEX idx_alert_priority ON alerts(priority)
+WHERE
+    priority > 0;
+
+-- +migrate Down
+ALTER TABLE alerts
+    DROP COLUMN priority;
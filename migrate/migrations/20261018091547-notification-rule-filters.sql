@@ -0,0 +1,39 @@
+-- +migrate Up
+ALTER TABLE user_notification_rules
+    ADD COLUMN priorities integer[],
+    ADD COLUMN service_label_key text,
+    ADD COLUMN service_label_value text,
+    ADD CONSTRAINT user_notification_rules_label_value_needs_key CHECK (service_label_value IS NULL OR service_label_key IS NOT NULL);
+
+-- allow multiple rules for the same contact method and delay as long as the filters differ
+ALTER TABLE user_notification_rules
+    DROP CONSTRAINT user_notification_rules_contact_method_id_delay_minutes_key;
+
+CREATE UNIQUE INDEX user_notification_rules_contact_method_id_delay_minutes_key ON user_notification_rules(contact_method_id, delay_minutes, coalesce(priorities, '{}'), coalesce(service_label_key, ''), coalesce(service_label_value, ''));
+
+UPDATE
+    engine_processing_versions
+SET
+    "version" = 3
+WHERE
+    type_id = 'np_cycle';
+
+-- +migrate Down
+UPDATE
+    engine_processing_versions
+SET
+    "version" = 2
+WHERE
+    type_id = 'np_cycle';
+
+DELETE FROM user_notification_rules
+WHERE priorities NOTNULL
+    OR service_label_key NOTNULL;
+
+DROP INDEX user_notification_rules_contact_method_id_delay_minutes_key;
+
+ALTER TABLE user_notification_rules
+    DROP COLUMN priorities,
+    DROP COLUMN service_label_key,
+    DROP COLUMN service_label_value,
+    ADD CONSTRAINT user_notification_rules_contact_method_id_delay_minutes_key UNIQUE (contact_method_id, delay_minutes);
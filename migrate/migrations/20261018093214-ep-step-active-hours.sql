@@ -0,0 +1,30 @@
+-- +migrate Up
+ALTER TABLE escalation_policy_steps
+    ADD COLUMN active_weekdays boolean[],
+    ADD COLUMN active_start time without time zone,
+    ADD COLUMN active_end time without time zone,
+    ADD COLUMN active_time_zone text,
+    ADD COLUMN is_active boolean NOT NULL DEFAULT TRUE,
+    ADD CONSTRAINT escalation_policy_steps_active_hours_check CHECK ((active_time_zone IS NULL AND active_weekdays IS NULL AND active_start IS NULL AND active_end IS NULL) OR (active_time_zone IS NOT NULL AND active_weekdays IS NOT NULL AND active_start IS NOT NULL AND active_end IS NOT NULL));
+
+UPDATE
+    engine_processing_versions
+SET
+    "version" = 5
+WHERE
+    type_id = 'escalation';
+
+-- +migrate Down
+UPDATE
+    engine_processing_versions
+SET
+    "version" = 4
+WHERE
+    type_id = 'escalation';
+
+ALTER TABLE escalation_policy_steps
+    DROP COLUMN active_weekdays,
+    DROP COLUMN active_start,
+    DROP COLUMN active_end,
+    DROP COLUMN active_time_zone,
+    DROP COLUMN is_active;
@@ -0,0 +1,18 @@
+-- +migrate Up notransaction
+ALTER TYPE engine_processing_type
+    ADD VALUE IF NOT EXISTS 'snooze';
+
+ALTER TYPE enum_alert_log_event
+    ADD VALUE IF NOT EXISTS 'snoozed';
+
+ALTER TYPE enum_alert_log_event
+    ADD VALUE IF NOT EXISTS 'unsnoozed';
+
+INSERT INTO engine_processing_versions(type_id, version)
+    VALUES ('snooze', 1)
+ON CONFLICT
+    DO NOTHING;
+
+-- +migrate Down
+DELETE FROM engine_processing_versions
+WHERE type_id = 'snooze';
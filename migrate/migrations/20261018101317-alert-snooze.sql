@@ -0,0 +1,33 @@
+-- +migrate Up
+ALTER TABLE alerts
+    ADD COLUMN snoozed_until timestamp with time zone;
+
+CREATE INDEX idx_alert_snoozed_until ON alerts (snoozed_until)
+WHERE
+    snoozed_until NOTNULL;
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_clear_snooze_on_status_change()
+    RETURNS TRIGGER
+    AS $$
+BEGIN
+    NEW.snoozed_until = NULL;
+    RETURN NEW;
+END;
+$$
+LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_clear_snooze_on_status_change
+    BEFORE UPDATE ON alerts
+    FOR EACH ROW
+    WHEN (NEW.status <> OLD.status AND NEW.snoozed_until IS NOT DISTINCT FROM OLD.snoozed_until AND NEW.snoozed_until NOTNULL)
+    EXECUTE FUNCTION fn_clear_snooze_on_status_change();
+
+-- +migrate Down
+DROP TRIGGER trg_clear_snooze_on_status_change ON alerts;
+
+DROP FUNCTION fn_clear_snooze_on_status_change();
+
+ALTER TABLE alerts
+    DROP COLUMN snoozed_until;
@@ -0,0 +1,37 @@
+-- +migrate Up
+CREATE TABLE incidents (
+    id bigserial PRIMARY KEY,
+    summary text NOT NULL,
+    details text NOT NULL DEFAULT '',
+    status enum_alert_status NOT NULL DEFAULT 'triggered',
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_incident_status ON incidents (status);
+
+CREATE TABLE incident_alerts (
+    alert_id bigint PRIMARY KEY REFERENCES alerts (id) ON DELETE CASCADE,
+    incident_id bigint NOT NULL REFERENCES incidents (id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_incident_alerts_incident_id ON incident_alerts (incident_id);
+
+UPDATE
+    engine_processing_versions
+SET
+    version = 12
+WHERE
+    type_id = 'message';
+
+-- +migrate Down
+UPDATE
+    engine_processing_versions
+SET
+    version = 11
+WHERE
+    type_id = 'message';
+
+DROP TABLE incident_alerts;
+
+DROP TABLE incidents;
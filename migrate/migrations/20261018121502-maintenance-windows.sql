@@ -0,0 +1,100 @@
+-- +migrate Up
+CREATE TYPE enum_maint_window_repeat AS ENUM (
+    'none',
+    'daily',
+    'weekly'
+);
+
+CREATE TYPE enum_maint_window_end_action AS ENUM (
+    'escalate',
+    'drop'
+);
+
+CREATE TABLE service_maintenance_windows (
+    id uuid PRIMARY KEY,
+    service_id uuid REFERENCES services (id) ON DELETE CASCADE,
+    label_key text,
+    label_value text NOT NULL DEFAULT '',
+    start_time timestamptz NOT NULL,
+    end_time timestamptz NOT NULL,
+    repeat enum_maint_window_repeat NOT NULL DEFAULT 'none',
+    time_zone text NOT NULL DEFAULT 'UTC',
+    reason text NOT NULL DEFAULT '',
+    end_action enum_maint_window_end_action NOT NULL DEFAULT 'escalate',
+    created_by uuid REFERENCES users (id) ON DELETE SET NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    active_since timestamptz,
+    CONSTRAINT service_maintenance_windows_target_check CHECK ((service_id IS NULL) != (label_key IS NULL)),
+    CONSTRAINT service_maintenance_windows_time_check CHECK (end_time > start_time)
+);
+
+CREATE INDEX idx_maint_window_service_id ON service_maintenance_windows (service_id);
+
+CREATE INDEX idx_maint_window_active ON service_maintenance_windows (active_since)
+WHERE
+    active_since NOTNULL;
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION svc_maint_window_active(_svc_id uuid)
+    RETURNS boolean
+    LANGUAGE sql
+    STABLE
+    AS $$
+    SELECT
+        EXISTS (
+            SELECT
+                1
+            FROM
+                service_maintenance_windows w
+            WHERE
+                w.active_since NOTNULL
+                AND (w.service_id = _svc_id
+                    OR EXISTS (
+                        SELECT
+                            1
+                        FROM
+                            labels l
+                        WHERE
+                            l.tgt_service_id = _svc_id
+                            AND l.key = w.label_key
+                            AND (w.label_value = ''
+                                OR l.value = w.label_value))))
+$$;
+-- +migrate StatementEnd
+
+UPDATE
+    engine_processing_versions
+SET
+    version = 6
+WHERE
+    type_id = 'escalation';
+
+UPDATE
+    engine_processing_versions
+SET
+    version = 13
+WHERE
+    type_id = 'message';
+
+-- +migrate Down
+UPDATE
+    engine_processing_versions
+SET
+    version = 12
+WHERE
+    type_id = 'message';
+
+UPDATE
+    engine_processing_versions
+SET
+    version = 5
+WHERE
+    type_id = 'escalation';
+
+DROP FUNCTION svc_maint_window_active(uuid);
+
+DROP TABLE service_maintenance_windows;
+
+DROP TYPE enum_maint_window_end_action;
+
+DROP TYPE enum_maint_window_repeat;
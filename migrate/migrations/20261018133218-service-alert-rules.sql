@@ -0,0 +1,8 @@
+-- +migrate Up
+CREATE TABLE service_alert_rules (
+    service_id uuid PRIMARY KEY REFERENCES services (id) ON DELETE CASCADE,
+    config jsonb NOT NULL
+);
+
+-- +migrate Down
+DROP TABLE service_alert_rules;
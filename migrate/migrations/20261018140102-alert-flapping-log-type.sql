@@ -0,0 +1,39 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_alert_log_event
+    ADD VALUE IF NOT EXISTS 'flapping';
+
+-- +migrate Down
+DELETE FROM alert_logs
+WHERE event = 'flapping';
+
+DROP INDEX idx_closed_events;
+
+ALTER TYPE enum_alert_log_event RENAME TO enum_alert_log_event_old;
+
+CREATE TYPE enum_alert_log_event AS ENUM (
+    'created',
+    'reopened',
+    'status_changed',
+    'assignment_changed',
+    'escalated',
+    'closed',
+    'notification_sent',
+    'response_received',
+    'acknowledged',
+    'policy_updated',
+    'duplicate_suppressed',
+    'escalation_request',
+    'no_notification_sent',
+    'snoozed',
+    'unsnoozed'
+);
+
+ALTER TABLE alert_logs
+    ALTER COLUMN event TYPE enum_alert_log_event
+    USING event::text::enum_alert_log_event;
+
+DROP TYPE enum_alert_log_event_old;
+
+CREATE INDEX idx_closed_events ON alert_logs (timestamp)
+WHERE
+    event = 'closed';
@@ -0,0 +1,138 @@
+-- +migrate Up
+ALTER TABLE services
+    ADD COLUMN reopen_window_minutes integer NOT NULL DEFAULT 0 CHECK (reopen_window_minutes BETWEEN 0 AND 1440),
+    ADD COLUMN flap_threshold integer NOT NULL DEFAULT 0 CHECK (flap_threshold >= 0),
+    ADD COLUMN flap_window_minutes integer NOT NULL DEFAULT 0 CHECK (flap_window_minutes BETWEEN 0 AND 1440);
+
+ALTER TABLE alerts
+    ADD COLUMN flapping_until timestamptz,
+    ADD COLUMN reopened_at timestamptz;
+
+CREATE TABLE alert_dedup_history (
+    service_id uuid NOT NULL REFERENCES services (id) ON DELETE CASCADE,
+    dedup_key text NOT NULL,
+    alert_id bigint NOT NULL REFERENCES alerts (id) ON DELETE CASCADE,
+    closed_at timestamptz NOT NULL DEFAULT now(),
+    close_times timestamptz[] NOT NULL DEFAULT '{}',
+    PRIMARY KEY (service_id, dedup_key)
+);
+
+CREATE INDEX idx_alert_dedup_history_closed_at ON alert_dedup_history (closed_at);
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_record_alert_dedup_history()
+    RETURNS TRIGGER
+    AS $$
+BEGIN
+    -- keep the last 100 close times for flap detection
+    INSERT INTO alert_dedup_history(service_id, dedup_key, alert_id, closed_at, close_times)
+    SELECT
+        svc.id,
+        OLD.dedup_key,
+        NEW.id,
+        now(),
+        ARRAY[now()]
+    FROM
+        services svc
+    WHERE
+        svc.id = NEW.service_id
+        AND (svc.reopen_window_minutes > 0
+            OR svc.flap_threshold > 0)
+    ON CONFLICT (service_id,
+        dedup_key)
+        DO UPDATE SET
+            alert_id = excluded.alert_id,
+            closed_at = excluded.closed_at,
+            close_times =(array_append(alert_dedup_history.close_times, excluded.closed_at))[greatest(cardinality(alert_dedup_history.close_times) - 98, 1):];
+    RETURN NEW;
+END;
+$$
+LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_record_alert_dedup_history
+    AFTER UPDATE ON alerts
+    FOR EACH ROW
+    WHEN (old.status <> new.status AND new.status = 'closed' AND old.dedup_key IS NOT NULL)
+    EXECUTE FUNCTION fn_record_alert_dedup_history();
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_prevent_reopen()
+    RETURNS TRIGGER
+    AS $$
+BEGIN
+    -- closed alerts may only be re-opened explicitly, by setting reopened_at
+    IF OLD.status = 'closed' AND (NEW.status = 'closed' OR NEW.reopened_at IS NOT DISTINCT FROM OLD.reopened_at) THEN
+        RAISE EXCEPTION 'cannot change status of closed alert';
+    END IF;
+    RETURN NEW;
+END;
+$$
+LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_10_insert_ep_state_on_alert_reopen
+    AFTER UPDATE ON alerts
+    FOR EACH ROW
+    WHEN (old.status = 'closed' AND new.status <> 'closed')
+    EXECUTE FUNCTION fn_insert_ep_state_on_alert_insert();
+
+UPDATE
+    engine_processing_versions
+SET
+    version = 7
+WHERE
+    type_id = 'escalation';
+
+UPDATE
+    engine_processing_versions
+SET
+    version = 14
+WHERE
+    type_id = 'message';
+
+-- +migrate Down
+UPDATE
+    engine_processing_versions
+SET
+    version = 13
+WHERE
+    type_id = 'message';
+
+UPDATE
+    engine_processing_versions
+SET
+    version = 6
+WHERE
+    type_id = 'escalation';
+
+DROP TRIGGER trg_10_insert_ep_state_on_alert_reopen ON alerts;
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_prevent_reopen()
+    RETURNS TRIGGER
+    AS $$
+BEGIN
+    IF OLD.status = 'closed' THEN
+        RAISE EXCEPTION 'cannot change status of closed alert';
+    END IF;
+    RETURN NEW;
+END;
+$$
+LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+DROP TRIGGER trg_record_alert_dedup_history ON alerts;
+
+DROP FUNCTION fn_record_alert_dedup_history();
+
+DROP TABLE alert_dedup_history;
+
+ALTER TABLE alerts
+    DROP COLUMN flapping_until,
+    DROP COLUMN reopened_at;
+
+ALTER TABLE services
+    DROP COLUMN reopen_window_minutes,
+    DROP COLUMN flap_threshold,
+    DROP COLUMN flap_window_minutes;
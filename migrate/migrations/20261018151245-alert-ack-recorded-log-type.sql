@@ -0,0 +1,5 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_alert_log_event
+    ADD VALUE IF NOT EXISTS 'ack_recorded';
+
+-- +migrate Down
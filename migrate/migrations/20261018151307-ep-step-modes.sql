@@ -0,0 +1,76 @@
+-- +migrate Up
+CREATE TYPE enum_ep_step_mode AS ENUM (
+    'all',
+    'round_robin',
+    'ack_count'
+);
+
+ALTER TABLE escalation_policy_steps
+    ADD COLUMN mode enum_ep_step_mode NOT NULL DEFAULT 'all',
+    ADD COLUMN round_robin_minutes integer,
+    ADD COLUMN ack_count integer,
+    ADD CONSTRAINT escalation_policy_steps_mode_check CHECK ((mode = 'round_robin') = (round_robin_minutes IS NOT NULL) AND (mode = 'ack_count') = (ack_count IS NOT NULL)),
+    ADD CONSTRAINT escalation_policy_steps_round_robin_minutes_check CHECK (round_robin_minutes BETWEEN 1 AND 60),
+    ADD CONSTRAINT escalation_policy_steps_ack_count_check CHECK (ack_count BETWEEN 2 AND 20);
+
+ALTER TABLE escalation_policy_state
+    ADD COLUMN round_robin_count integer NOT NULL DEFAULT 0,
+    ADD COLUMN round_robin_next timestamptz;
+
+CREATE TABLE alert_acks (
+    alert_id bigint NOT NULL REFERENCES alerts (id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (alert_id, user_id)
+);
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_clear_alert_acks_on_status_change()
+    RETURNS TRIGGER
+    AS $$
+BEGIN
+    DELETE FROM alert_acks
+    WHERE alert_id = NEW.id;
+    RETURN NEW;
+END;
+$$
+LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_clear_alert_acks_on_status_change
+    AFTER UPDATE ON alerts
+    FOR EACH ROW
+    WHEN (old.status <> new.status AND new.status <> 'triggered')
+    EXECUTE FUNCTION fn_clear_alert_acks_on_status_change();
+
+UPDATE
+    engine_processing_versions
+SET
+    version = 8
+WHERE
+    type_id = 'escalation';
+
+-- +migrate Down
+UPDATE
+    engine_processing_versions
+SET
+    version = 7
+WHERE
+    type_id = 'escalation';
+
+DROP TRIGGER trg_clear_alert_acks_on_status_change ON alerts;
+
+DROP FUNCTION fn_clear_alert_acks_on_status_change();
+
+DROP TABLE alert_acks;
+
+ALTER TABLE escalation_policy_state
+    DROP COLUMN round_robin_count,
+    DROP COLUMN round_robin_next;
+
+ALTER TABLE escalation_policy_steps
+    DROP COLUMN mode,
+    DROP COLUMN round_robin_minutes,
+    DROP COLUMN ack_count;
+
+DROP TYPE enum_ep_step_mode;
@@ -0,0 +1,46 @@
+-- +migrate Up
+CREATE TYPE enum_override_request_status AS ENUM (
+    'pending',
+    'accepted',
+    'declined',
+    'expired'
+);
+
+CREATE TABLE override_requests (
+    id uuid PRIMARY KEY,
+    schedule_id uuid NOT NULL REFERENCES schedules (id) ON DELETE CASCADE,
+    requester_user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    target_user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    add_user_id uuid REFERENCES users (id) ON DELETE CASCADE,
+    remove_user_id uuid REFERENCES users (id) ON DELETE CASCADE,
+    start_time timestamptz NOT NULL,
+    end_time timestamptz NOT NULL,
+    status enum_override_request_status NOT NULL DEFAULT 'pending',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    responded_at timestamptz,
+    override_id uuid REFERENCES user_overrides (id) ON DELETE SET NULL,
+    CONSTRAINT override_requests_check_users CHECK (add_user_id IS NOT NULL OR remove_user_id IS NOT NULL),
+    CONSTRAINT override_requests_check_add_remove CHECK (add_user_id <> remove_user_id),
+    CONSTRAINT override_requests_check_target CHECK (target_user_id = add_user_id OR target_user_id = remove_user_id),
+    CONSTRAINT override_requests_check_time CHECK (end_time > start_time)
+);
+
+CREATE INDEX idx_override_requests_target_pending ON override_requests (target_user_id)
+WHERE
+    status = 'pending';
+
+CREATE INDEX idx_override_requests_requester ON override_requests (requester_user_id);
+
+CREATE INDEX idx_override_requests_schedule ON override_requests (schedule_id);
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN override_request_id uuid REFERENCES override_requests (id) ON DELETE CASCADE,
+    ADD CONSTRAINT om_override_request_id CHECK (message_type <> 'override_request_notification' OR override_request_id IS NOT NULL);
+
+-- +migrate Down
+ALTER TABLE outgoing_messages
+    DROP COLUMN override_request_id;
+
+DROP TABLE override_requests;
+
+DROP TYPE enum_override_request_status;
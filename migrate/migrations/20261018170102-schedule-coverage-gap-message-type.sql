@@ -0,0 +1,5 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_outgoing_messages_type
+    ADD VALUE IF NOT EXISTS 'schedule_coverage_gap_notification';
+
+-- +migrate Down
@@ -0,0 +1,10 @@
+-- +migrate Up
+CREATE TABLE schedule_coverage_notices (
+    schedule_id uuid PRIMARY KEY REFERENCES schedules (id) ON DELETE CASCADE,
+    gap_start timestamptz NOT NULL,
+    gap_end timestamptz NOT NULL,
+    notified_at timestamptz NOT NULL DEFAULT now()
+);
+
+-- +migrate Down
+DROP TABLE schedule_coverage_notices;
@@ -0,0 +1,12 @@
+-- +migrate Up
+ALTER TABLE rotation_participants
+    ADD COLUMN window_start time without time zone,
+    ADD COLUMN window_end time without time zone,
+    ADD COLUMN window_time_zone text,
+    ADD CONSTRAINT rotation_participants_window_check CHECK ((window_start IS NULL) = (window_end IS NULL) AND (window_start IS NULL) = (window_time_zone IS NULL));
+
+-- +migrate Down
+ALTER TABLE rotation_participants
+    DROP COLUMN window_start,
+    DROP COLUMN window_end,
+    DROP COLUMN window_time_zone;
@@ -0,0 +1,29 @@
+-- +migrate Up
+ALTER TABLE rotations
+    ADD COLUMN active_count integer NOT NULL DEFAULT 1,
+    ADD CONSTRAINT rotations_active_count_check CHECK (active_count BETWEEN 1 AND 10);
+
+ALTER TABLE rotation_participants
+    ADD COLUMN shadow boolean NOT NULL DEFAULT FALSE;
+
+ALTER TABLE ep_step_on_call_users
+    ADD COLUMN is_shadow boolean NOT NULL DEFAULT FALSE;
+
+-- participants that are on call in addition to the one in rotation_state
+CREATE TABLE rotation_active_participants (
+    rotation_id uuid NOT NULL REFERENCES rotations (id) ON DELETE CASCADE,
+    rotation_participant_id uuid NOT NULL REFERENCES rotation_participants (id) ON DELETE CASCADE,
+    PRIMARY KEY (rotation_id, rotation_participant_id)
+);
+
+-- +migrate Down
+DROP TABLE rotation_active_participants;
+
+ALTER TABLE ep_step_on_call_users
+    DROP COLUMN is_shadow;
+
+ALTER TABLE rotation_participants
+    DROP COLUMN shadow;
+
+ALTER TABLE rotations
+    DROP COLUMN active_count;
@@ -0,0 +1,44 @@
+-- +migrate Up
+CREATE TYPE enum_holiday_filter AS ENUM (
+    'any',
+    'exclude',
+    'only'
+);
+
+CREATE TABLE holiday_calendars (
+    id uuid PRIMARY KEY,
+    name text NOT NULL UNIQUE,
+    source_url text,
+    imported_at timestamp with time zone NOT NULL DEFAULT now(),
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE holiday_calendar_dates (
+    calendar_id uuid NOT NULL REFERENCES holiday_calendars (id) ON DELETE CASCADE,
+    date date NOT NULL,
+    name text NOT NULL,
+    PRIMARY KEY (calendar_id, date)
+);
+
+CREATE TABLE schedule_holiday_calendars (
+    schedule_id uuid NOT NULL REFERENCES schedules (id) ON DELETE CASCADE,
+    calendar_id uuid NOT NULL REFERENCES holiday_calendars (id) ON DELETE CASCADE,
+    PRIMARY KEY (schedule_id, calendar_id)
+);
+
+CREATE INDEX idx_schedule_holiday_calendars_calendar ON schedule_holiday_calendars (calendar_id);
+
+ALTER TABLE schedule_rules
+    ADD COLUMN holiday_filter enum_holiday_filter NOT NULL DEFAULT 'any';
+
+-- +migrate Down
+ALTER TABLE schedule_rules
+    DROP COLUMN holiday_filter;
+
+DROP TABLE schedule_holiday_calendars;
+
+DROP TABLE holiday_calendar_dates;
+
+DROP TABLE holiday_calendars;
+
+DROP TYPE enum_holiday_filter;
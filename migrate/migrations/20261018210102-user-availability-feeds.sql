@@ -0,0 +1,50 @@
+-- +migrate Up
+CREATE TABLE user_availability_feeds (
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    url text NOT NULL,
+    keyword text NOT NULL DEFAULT '',
+    time_zone text NOT NULL,
+    substitute_user_id uuid REFERENCES users (id) ON DELETE SET NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_sync_at timestamp with time zone,
+    last_error text NOT NULL DEFAULT '',
+    UNIQUE (user_id, name),
+    CHECK (substitute_user_id <> user_id)
+);
+
+CREATE INDEX idx_user_availability_feeds_substitute ON user_availability_feeds (substitute_user_id);
+
+CREATE TABLE user_unavailability (
+    id uuid PRIMARY KEY,
+    feed_id uuid NOT NULL REFERENCES user_availability_feeds (id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    substitute_user_id uuid REFERENCES users (id) ON DELETE CASCADE,
+    summary text NOT NULL,
+    start_time timestamp with time zone NOT NULL,
+    end_time timestamp with time zone NOT NULL,
+    CHECK (end_time > start_time)
+);
+
+CREATE INDEX idx_user_unavailability_feed ON user_unavailability (feed_id);
+
+CREATE INDEX idx_user_unavailability_user ON user_unavailability (user_id, end_time);
+
+CREATE TABLE user_unavailability_overrides (
+    unavailability_id uuid NOT NULL REFERENCES user_unavailability (id) ON DELETE CASCADE,
+    schedule_id uuid NOT NULL REFERENCES schedules (id) ON DELETE CASCADE,
+    override_id uuid REFERENCES user_overrides (id) ON DELETE SET NULL,
+    PRIMARY KEY (unavailability_id, schedule_id)
+);
+
+CREATE INDEX idx_user_unavailability_overrides_override ON user_unavailability_overrides (override_id);
+
+CREATE INDEX idx_user_unavailability_overrides_schedule ON user_unavailability_overrides (schedule_id);
+
+-- +migrate Down
+DROP TABLE user_unavailability_overrides;
+
+DROP TABLE user_unavailability;
+
+DROP TABLE user_availability_feeds;
@@ -0,0 +1,20 @@
+-- +migrate Up
+ALTER TABLE user_calendar_subscriptions
+    ALTER COLUMN schedule_id DROP NOT NULL,
+    ADD COLUMN rotation_id uuid REFERENCES rotations (id) ON DELETE CASCADE,
+    ADD COLUMN service_id uuid REFERENCES services (id) ON DELETE CASCADE,
+    ADD CONSTRAINT user_calendar_subscriptions_one_target CHECK (num_nonnulls(schedule_id, rotation_id, service_id) <= 1);
+
+CREATE INDEX idx_user_calendar_subscriptions_rotation ON user_calendar_subscriptions (rotation_id);
+
+CREATE INDEX idx_user_calendar_subscriptions_service ON user_calendar_subscriptions (service_id);
+
+-- +migrate Down
+DELETE FROM user_calendar_subscriptions
+WHERE schedule_id ISNULL;
+
+ALTER TABLE user_calendar_subscriptions
+    DROP CONSTRAINT user_calendar_subscriptions_one_target,
+    DROP COLUMN rotation_id,
+    DROP COLUMN service_id,
+    ALTER COLUMN schedule_id SET NOT NULL;
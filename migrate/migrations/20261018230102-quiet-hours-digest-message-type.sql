@@ -0,0 +1,5 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_outgoing_messages_type
+    ADD VALUE IF NOT EXISTS 'quiet_hours_digest';
+
+-- +migrate Down
@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE quiet_hours (
+    id uuid PRIMARY KEY,
+    user_id uuid UNIQUE REFERENCES users (id) ON DELETE CASCADE,
+    service_id uuid UNIQUE REFERENCES services (id) ON DELETE CASCADE,
+    time_zone text NOT NULL,
+    start_time time without time zone NOT NULL,
+    end_time time without time zone NOT NULL,
+    CONSTRAINT quiet_hours_one_target CHECK (num_nonnulls(user_id, service_id) = 1),
+    CHECK (start_time <> end_time)
+);
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN quiet_until timestamp with time zone;
+
+-- +migrate Down
+ALTER TABLE outgoing_messages
+    DROP COLUMN quiet_until;
+
+DROP TABLE quiet_hours;
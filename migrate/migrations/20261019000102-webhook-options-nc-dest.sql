@@ -0,0 +1,52 @@
+-- +migrate Up
+-- Webhooks with options (e.g., signing secret or headers) are stored as generic destinations, so that
+-- multiple channels can share the same URL.
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_nc_compat_set_type_val_on_insert()
+    RETURNS TRIGGER
+    AS $$
+BEGIN
+    IF NEW.dest ->> 'Type' = 'builtin-slack-channel' THEN
+        NEW.type = 'SLACK';
+        NEW.value = NEW.dest -> 'Args' ->> 'slack_channel_id';
+    ELSIF NEW.dest ->> 'Type' = 'builtin-slack-usergroup' THEN
+        NEW.type = 'SLACK_USER_GROUP';
+        NEW.value =(NEW.dest -> 'Args' ->> 'slack_usergroup_id') || ':' ||(NEW.dest -> 'Args' ->> 'slack_channel_id');
+    ELSIF NEW.dest ->> 'Type' = 'builtin-webhook' AND NEW.dest -> 'Args' - 'webhook_url' = '{}'::jsonb THEN
+        NEW.type = 'WEBHOOK';
+        NEW.value = NEW.dest -> 'Args' ->> 'webhook_url';
+    ELSE
+        NEW.type = 'DEST';
+        NEW.value = gen_random_uuid()::text;
+    END IF;
+    RETURN new;
+END;
+$$
+LANGUAGE plpgsql;
+
+-- +migrate StatementEnd
+-- +migrate Down
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_nc_compat_set_type_val_on_insert()
+    RETURNS TRIGGER
+    AS $$
+BEGIN
+    IF NEW.dest ->> 'Type' = 'builtin-slack-channel' THEN
+        NEW.type = 'SLACK';
+        NEW.value = NEW.dest -> 'Args' ->> 'slack_channel_id';
+    ELSIF NEW.dest ->> 'Type' = 'builtin-slack-usergroup' THEN
+        NEW.type = 'SLACK_USER_GROUP';
+        NEW.value =(NEW.dest -> 'Args' ->> 'slack_usergroup_id') || ':' ||(NEW.dest -> 'Args' ->> 'slack_channel_id');
+    ELSIF NEW.dest ->> 'Type' = 'builtin-webhook' THEN
+        NEW.type = 'WEBHOOK';
+        NEW.value = NEW.dest -> 'Args' ->> 'webhook_url';
+    ELSE
+        NEW.type = 'DEST';
+        NEW.value = gen_random_uuid()::text;
+    END IF;
+    RETURN new;
+END;
+$$
+LANGUAGE plpgsql;
+
+-- +migrate StatementEnd
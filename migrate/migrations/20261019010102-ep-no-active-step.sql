@@ -0,0 +1,35 @@
+-- +migrate Up
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION ep_has_active_step(_ep_id uuid)
+    RETURNS boolean
+    LANGUAGE sql
+    STABLE
+    AS $$
+    SELECT
+        EXISTS (
+            SELECT
+                1
+            FROM
+                escalation_policy_steps s
+            WHERE
+                s.escalation_policy_id = _ep_id
+                AND s.is_active)
+$$;
+-- +migrate StatementEnd
+
+UPDATE
+    engine_processing_versions
+SET
+    version = 9
+WHERE
+    type_id = 'escalation';
+
+-- +migrate Down
+UPDATE
+    engine_processing_versions
+SET
+    version = 8
+WHERE
+    type_id = 'escalation';
+
+DROP FUNCTION ep_has_active_step(uuid);
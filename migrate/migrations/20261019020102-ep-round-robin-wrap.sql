@@ -0,0 +1,15 @@
+-- +migrate Up
+UPDATE
+    engine_processing_versions
+SET
+    version = 10
+WHERE
+    type_id = 'escalation';
+
+-- +migrate Down
+UPDATE
+    engine_processing_versions
+SET
+    version = 9
+WHERE
+    type_id = 'escalation';
@@ -0,0 +1,15 @@
+-- +migrate Up
+UPDATE
+    engine_processing_versions
+SET
+    version = 4
+WHERE
+    type_id = 'schedule';
+
+-- +migrate Down
+UPDATE
+    engine_processing_versions
+SET
+    version = 3
+WHERE
+    type_id = 'schedule';
@@ -0,0 +1,29 @@
+-- +migrate Up
+UPDATE
+    engine_processing_versions
+SET
+    version = 4
+WHERE
+    type_id = 'rotation';
+
+UPDATE
+    engine_processing_versions
+SET
+    version = 5
+WHERE
+    type_id = 'schedule';
+
+-- +migrate Down
+UPDATE
+    engine_processing_versions
+SET
+    version = 3
+WHERE
+    type_id = 'rotation';
+
+UPDATE
+    engine_processing_versions
+SET
+    version = 4
+WHERE
+    type_id = 'schedule';
@@ -1,7 +1,7 @@
 -- This file is auto-generated by "make db-schema"; DO NOT EDIT
--- DATA=0007376a4111aa0c4c5c00e98ae7319f888fd2e27ab4973fd432e6a3ff1f189c  -
--- DISK=430f816575f801e95a72868f6f7afaae61f26eda5c389a65a0ec0a3feebb95b4  -
--- PSQL=430f816575f801e95a72868f6f7afaae61f26eda5c389a65a0ec0a3feebb95b4  -
+-- DATA=916165840a889e3814ae106d99e7ead4f0c736598a85cd74176096c05cc881c3  -
+-- DISK=9f73c06c322c9a32dbb82cc8a15aa9263a128c7f406a8656b4fc14833ce9bf78  -
+-- PSQL=9f73c06c322c9a32dbb82cc8a15aa9263a128c7f406a8656b4fc14833ce9bf78  -
 --
 -- pgdump-lite database dump
 --
@@ -13,6 +13,7 @@ CREATE EXTENSION IF NOT EXISTS pgcrypto;
 -- Enums
 
 CREATE TYPE engine_processing_type AS ENUM (
+	'availability',
 	'cleanup',
 	'compat',
 	'escalation',
@@ -23,11 +24,13 @@ CREATE TYPE engine_processing_type AS ENUM (
 	'rotation',
 	'schedule',
 	'signals',
+	'snooze',
 	'status_update',
 	'verify'
 );
 
 CREATE TYPE enum_alert_log_event AS ENUM (
+	'ack_recorded',
 	'acknowledged',
 	'assignment_changed',
 	'closed',
@@ -35,12 +38,15 @@ CREATE TYPE enum_alert_log_event AS ENUM (
 	'duplicate_suppressed',
 	'escalated',
 	'escalation_request',
+	'flapping',
 	'no_notification_sent',
 	'notification_sent',
 	'policy_updated',
 	'reopened',
 	'response_received',
-	'status_changed'
+	'snoozed',
+	'status_changed',
+	'unsnoozed'
 );
 
 CREATE TYPE enum_alert_log_subject_type AS ENUM (
@@ -66,12 +72,24 @@ CREATE TYPE enum_alert_status AS ENUM (
 	'triggered'
 );
 
+CREATE TYPE enum_ep_step_mode AS ENUM (
+	'ack_count',
+	'all',
+	'round_robin'
+);
+
 CREATE TYPE enum_heartbeat_state AS ENUM (
 	'healthy',
 	'inactive',
 	'unhealthy'
 );
 
+CREATE TYPE enum_holiday_filter AS ENUM (
+	'any',
+	'exclude',
+	'only'
+);
+
 CREATE TYPE enum_integration_keys_type AS ENUM (
 	'email',
 	'generic',
@@ -98,6 +116,17 @@ CREATE TYPE enum_limit_type AS ENUM (
 	'user_overrides_per_schedule'
 );
 
+CREATE TYPE enum_maint_window_end_action AS ENUM (
+	'drop',
+	'escalate'
+);
+
+CREATE TYPE enum_maint_window_repeat AS ENUM (
+	'daily',
+	'none',
+	'weekly'
+);
+
 CREATE TYPE enum_notif_channel_type AS ENUM (
 	'DEST',
 	'SLACK',
@@ -121,14 +150,25 @@ CREATE TYPE enum_outgoing_messages_type AS ENUM (
 	'alert_notification_bundle',
 	'alert_status_update',
 	'alert_status_update_bundle',
+	'override_request_notification',
+	'quiet_hours_digest',
+	'schedule_coverage_gap_notification',
 	'schedule_on_call_notification',
 	'signal_message',
 	'test_notification',
 	'verification_message'
 );
 
+CREATE TYPE enum_override_request_status AS ENUM (
+	'accepted',
+	'declined',
+	'expired',
+	'pending'
+);
+
 CREATE TYPE enum_rotation_type AS ENUM (
 	'daily',
+	'follow_the_sun',
 	'hourly',
 	'monthly',
 	'weekly'
@@ -223,6 +263,23 @@ END;
 $function$
 ;
 
+CREATE OR REPLACE FUNCTION public.ep_has_active_step(_ep_id uuid)
+ RETURNS boolean
+ LANGUAGE sql
+ STABLE
+AS $function$
+    SELECT
+        EXISTS (
+            SELECT
+                1
+            FROM
+                escalation_policy_steps s
+            WHERE
+                s.escalation_policy_id = _ep_id
+                AND s.is_active)
+$function$
+;
+
 CREATE OR REPLACE FUNCTION public.escalate_alerts()
  RETURNS void
  LANGUAGE plpgsql
@@ -289,6 +346,18 @@ END;
 $function$
 ;
 
+CREATE OR REPLACE FUNCTION public.fn_clear_alert_acks_on_status_change()
+ RETURNS trigger
+ LANGUAGE plpgsql
+AS $function$
+BEGIN
+    DELETE FROM alert_acks
+    WHERE alert_id = NEW.id;
+    RETURN NEW;
+END;
+$function$
+;
+
 CREATE OR REPLACE FUNCTION public.fn_clear_dedup_on_close()
  RETURNS trigger
  LANGUAGE plpgsql
@@ -350,6 +419,17 @@ END;
 $function$
 ;
 
+CREATE OR REPLACE FUNCTION public.fn_clear_snooze_on_status_change()
+ RETURNS trigger
+ LANGUAGE plpgsql
+AS $function$
+BEGIN
+    NEW.snoozed_until = NULL;
+    RETURN NEW;
+END;
+$function$
+;
+
 CREATE OR REPLACE FUNCTION public.fn_cm_compat_set_type_val_on_insert()
  RETURNS trigger
  LANGUAGE plpgsql
@@ -1193,7 +1273,7 @@ BEGIN
     ELSIF NEW.dest ->> 'Type' = 'builtin-slack-usergroup' THEN
         NEW.type = 'SLACK_USER_GROUP';
         NEW.value =(NEW.dest -> 'Args' ->> 'slack_usergroup_id') || ':' ||(NEW.dest -> 'Args' ->> 'slack_channel_id');
-    ELSIF NEW.dest ->> 'Type' = 'builtin-webhook' THEN
+    ELSIF NEW.dest ->> 'Type' = 'builtin-webhook' AND NEW.dest -> 'Args' - 'webhook_url' = '{}'::jsonb THEN
         NEW.type = 'WEBHOOK';
         NEW.value = NEW.dest -> 'Args' ->> 'webhook_url';
     ELSE
@@ -1251,12 +1331,43 @@ CREATE OR REPLACE FUNCTION public.fn_prevent_reopen()
  RETURNS trigger
  LANGUAGE plpgsql
 AS $function$
-    BEGIN
-        IF OLD.status = 'closed' THEN
-            RAISE EXCEPTION 'cannot change status of closed alert';
-        END IF;
-        RETURN NEW;
-    END;
+BEGIN
+    -- closed alerts may only be re-opened explicitly, by setting reopened_at
+    IF OLD.status = 'closed' AND (NEW.status = 'closed' OR NEW.reopened_at IS NOT DISTINCT FROM OLD.reopened_at) THEN
+        RAISE EXCEPTION 'cannot change status of closed alert';
+    END IF;
+    RETURN NEW;
+END;
+$function$
+;
+
+CREATE OR REPLACE FUNCTION public.fn_record_alert_dedup_history()
+ RETURNS trigger
+ LANGUAGE plpgsql
+AS $function$
+BEGIN
+    -- keep the last 100 close times for flap detection
+    INSERT INTO alert_dedup_history(service_id, dedup_key, alert_id, closed_at, close_times)
+    SELECT
+        svc.id,
+        OLD.dedup_key,
+        NEW.id,
+        now(),
+        ARRAY[now()]
+    FROM
+        services svc
+    WHERE
+        svc.id = NEW.service_id
+        AND (svc.reopen_window_minutes > 0
+            OR svc.flap_threshold > 0)
+    ON CONFLICT (service_id,
+        dedup_key)
+        DO UPDATE SET
+            alert_id = excluded.alert_id,
+            closed_at = excluded.closed_at,
+            close_times =(array_append(alert_dedup_history.close_times, excluded.closed_at))[greatest(cardinality(alert_dedup_history.close_times) - 98, 1):];
+    RETURN NEW;
+END;
 $function$
 ;
 
@@ -1529,6 +1640,33 @@ AS $function$
 $function$
 ;
 
+CREATE OR REPLACE FUNCTION public.svc_maint_window_active(_svc_id uuid)
+ RETURNS boolean
+ LANGUAGE sql
+ STABLE
+AS $function$
+    SELECT
+        EXISTS (
+            SELECT
+                1
+            FROM
+                service_maintenance_windows w
+            WHERE
+                w.active_since NOTNULL
+                AND (w.service_id = _svc_id
+                    OR EXISTS (
+                        SELECT
+                            1
+                        FROM
+                            labels l
+                        WHERE
+                            l.tgt_service_id = _svc_id
+                            AND l.key = w.label_key
+                            AND (w.label_value = ''
+                                OR l.value = w.label_value))))
+$function$
+;
+
 CREATE OR REPLACE FUNCTION public.update_notification_cycles()
  RETURNS void
  LANGUAGE plpgsql
@@ -1565,6 +1703,18 @@ AS $function$
 
 -- Tables
 
+CREATE TABLE alert_acks (
+	alert_id bigint NOT NULL,
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	user_id uuid NOT NULL,
+	CONSTRAINT alert_acks_alert_id_fkey FOREIGN KEY (alert_id) REFERENCES alerts(id) ON DELETE CASCADE,
+	CONSTRAINT alert_acks_pkey PRIMARY KEY (alert_id, user_id),
+	CONSTRAINT alert_acks_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX alert_acks_pkey ON public.alert_acks USING btree (alert_id, user_id);
+
+
 CREATE TABLE alert_data (
 	alert_id bigint NOT NULL,
 	id bigint DEFAULT nextval('alert_data_id_seq'::regclass) NOT NULL,
@@ -1578,6 +1728,21 @@ CREATE UNIQUE INDEX alert_data_id_key ON public.alert_data USING btree (id);
 CREATE UNIQUE INDEX alert_data_pkey ON public.alert_data USING btree (alert_id);
 
 
+CREATE TABLE alert_dedup_history (
+	alert_id bigint NOT NULL,
+	close_times timestamp with time zone[] DEFAULT '{}'::timestamp with time zone[] NOT NULL,
+	closed_at timestamp with time zone DEFAULT now() NOT NULL,
+	dedup_key text NOT NULL,
+	service_id uuid NOT NULL,
+	CONSTRAINT alert_dedup_history_alert_id_fkey FOREIGN KEY (alert_id) REFERENCES alerts(id) ON DELETE CASCADE,
+	CONSTRAINT alert_dedup_history_pkey PRIMARY KEY (service_id, dedup_key),
+	CONSTRAINT alert_dedup_history_service_id_fkey FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX alert_dedup_history_pkey ON public.alert_dedup_history USING btree (service_id, dedup_key);
+CREATE INDEX idx_alert_dedup_history_closed_at ON public.alert_dedup_history USING btree (closed_at);
+
+
 CREATE TABLE alert_feedback (
 	alert_id bigint NOT NULL,
 	id bigint DEFAULT nextval('alert_feedback_id_seq'::regclass) NOT NULL,
@@ -1660,21 +1825,28 @@ CREATE TABLE alerts (
 	dedup_key text,
 	details text DEFAULT ''::text NOT NULL,
 	escalation_level integer DEFAULT 0 NOT NULL,
+	flapping_until timestamp with time zone,
 	id bigint DEFAULT nextval('alerts_id_seq'::regclass) NOT NULL,
 	last_escalation timestamp with time zone DEFAULT now(),
 	last_processed timestamp with time zone,
+	priority integer DEFAULT 0 NOT NULL,
+	reopened_at timestamp with time zone,
 	service_id uuid,
+	snoozed_until timestamp with time zone,
 	source enum_alert_source DEFAULT 'manual'::enum_alert_source NOT NULL,
 	status enum_alert_status DEFAULT 'triggered'::enum_alert_status NOT NULL,
 	summary text NOT NULL,
 	CONSTRAINT alerts_pkey PRIMARY KEY (id),
+	CONSTRAINT alerts_priority_check CHECK (priority >= 0 AND priority <= 5),
 	CONSTRAINT alerts_services_id_fkey FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE,
 	CONSTRAINT dedup_key_only_for_open_alerts CHECK ((status = 'closed'::enum_alert_status) = (dedup_key IS NULL))
 );
 
 CREATE UNIQUE INDEX alerts_pkey ON public.alerts USING btree (id);
 CREATE INDEX idx_alert_cleanup ON public.alerts USING btree (id, created_at) WHERE (status = 'closed'::enum_alert_status);
+CREATE INDEX idx_alert_priority ON public.alerts USING btree (priority) WHERE (priority > 0);
 CREATE INDEX idx_alert_service_id ON public.alerts USING btree (service_id);
+CREATE INDEX idx_alert_snoozed_until ON public.alerts USING btree (snoozed_until) WHERE (snoozed_until IS NOT NULL);
 CREATE INDEX idx_dedup_alerts ON public.alerts USING btree (dedup_key);
 CREATE UNIQUE INDEX idx_no_alert_duplicates ON public.alerts USING btree (service_id, dedup_key);
 CREATE INDEX idx_search_alerts_summary_eng ON public.alerts USING gin (to_tsvector('english'::regconfig, replace(lower(summary), '.'::text, ' '::text)));
@@ -1682,10 +1854,14 @@ CREATE INDEX idx_unacked_alert_service ON public.alerts USING btree (status, ser
 
 CREATE TRIGGER trg_10_clear_ep_state_on_alert_close AFTER UPDATE ON public.alerts FOR EACH ROW WHEN (((old.status <> new.status) AND (new.status = 'closed'::enum_alert_status))) EXECUTE FUNCTION fn_clear_ep_state_on_alert_close();
 CREATE TRIGGER trg_10_insert_ep_state_on_alert_insert AFTER INSERT ON public.alerts FOR EACH ROW WHEN ((new.status <> 'closed'::enum_alert_status)) EXECUTE FUNCTION fn_insert_ep_state_on_alert_insert();
+CREATE TRIGGER trg_10_insert_ep_state_on_alert_reopen AFTER UPDATE ON public.alerts FOR EACH ROW WHEN (((old.status = 'closed'::enum_alert_status) AND (new.status <> 'closed'::enum_alert_status))) EXECUTE FUNCTION fn_insert_ep_state_on_alert_insert();
 CREATE TRIGGER trg_20_clear_next_esc_on_alert_ack AFTER UPDATE ON public.alerts FOR EACH ROW WHEN (((new.status <> old.status) AND (old.status = 'active'::enum_alert_status))) EXECUTE FUNCTION fn_clear_next_esc_on_alert_ack();
+CREATE TRIGGER trg_clear_alert_acks_on_status_change AFTER UPDATE ON public.alerts FOR EACH ROW WHEN (((old.status <> new.status) AND (new.status <> 'triggered'::enum_alert_status))) EXECUTE FUNCTION fn_clear_alert_acks_on_status_change();
 CREATE TRIGGER trg_clear_dedup_on_close BEFORE UPDATE ON public.alerts FOR EACH ROW WHEN (((new.status <> old.status) AND (new.status = 'closed'::enum_alert_status))) EXECUTE FUNCTION fn_clear_dedup_on_close();
+CREATE TRIGGER trg_clear_snooze_on_status_change BEFORE UPDATE ON public.alerts FOR EACH ROW WHEN (((new.status <> old.status) AND (NOT (new.snoozed_until IS DISTINCT FROM old.snoozed_until)) AND (new.snoozed_until IS NOT NULL))) EXECUTE FUNCTION fn_clear_snooze_on_status_change();
 CREATE CONSTRAINT TRIGGER trg_enforce_alert_limit AFTER INSERT ON public.alerts NOT DEFERRABLE INITIALLY IMMEDIATE FOR EACH ROW EXECUTE FUNCTION fn_enforce_alert_limit();
 CREATE TRIGGER trg_prevent_reopen BEFORE UPDATE OF status ON public.alerts FOR EACH ROW EXECUTE FUNCTION fn_prevent_reopen();
+CREATE TRIGGER trg_record_alert_dedup_history AFTER UPDATE ON public.alerts FOR EACH ROW WHEN (((old.status <> new.status) AND (new.status = 'closed'::enum_alert_status) AND (old.dedup_key IS NOT NULL))) EXECUTE FUNCTION fn_record_alert_dedup_history();
 CREATE TRIGGER trg_track_alert_status_update AFTER UPDATE ON public.alerts FOR EACH ROW WHEN ((new.status IS DISTINCT FROM old.status)) EXECUTE FUNCTION fn_track_alert_status();
 
 
@@ -1806,6 +1982,7 @@ CREATE TABLE ep_step_on_call_users (
 	end_time timestamp with time zone,
 	ep_step_id uuid NOT NULL,
 	id bigint DEFAULT nextval('ep_step_on_call_users_id_seq'::regclass) NOT NULL,
+	is_shadow boolean DEFAULT false NOT NULL,
 	start_time timestamp with time zone DEFAULT now() NOT NULL,
 	user_id uuid NOT NULL,
 	CONSTRAINT ep_step_on_call_users_ep_step_id_fkey FOREIGN KEY (ep_step_id) REFERENCES escalation_policy_steps(id) ON DELETE CASCADE,
@@ -1890,6 +2067,8 @@ CREATE TABLE escalation_policy_state (
 	last_escalation timestamp with time zone,
 	loop_count integer DEFAULT 0 NOT NULL,
 	next_escalation timestamp with time zone,
+	round_robin_count integer DEFAULT 0 NOT NULL,
+	round_robin_next timestamp with time zone,
 	service_id uuid NOT NULL,
 	CONSTRAINT escalation_policy_state_alert_id_fkey FOREIGN KEY (alert_id) REFERENCES alerts(id) ON DELETE CASCADE,
 	CONSTRAINT escalation_policy_state_escalation_policy_id_fkey FOREIGN KEY (escalation_policy_id) REFERENCES escalation_policies(id) ON DELETE CASCADE,
@@ -1911,13 +2090,25 @@ CREATE TRIGGER trg_30_trig_alert_on_force_escalation AFTER UPDATE ON public.esca
 
 
 CREATE TABLE escalation_policy_steps (
+	ack_count integer,
+	active_end time without time zone,
+	active_start time without time zone,
+	active_time_zone text,
+	active_weekdays boolean[],
 	delay integer DEFAULT 1 NOT NULL,
 	escalation_policy_id uuid NOT NULL,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
+	is_active boolean DEFAULT true NOT NULL,
+	mode enum_ep_step_mode DEFAULT 'all'::enum_ep_step_mode NOT NULL,
+	round_robin_minutes integer,
 	step_number integer DEFAULT '-1'::integer NOT NULL,
+	CONSTRAINT escalation_policy_steps_ack_count_check CHECK (ack_count >= 2 AND ack_count <= 20),
+	CONSTRAINT escalation_policy_steps_active_hours_check CHECK (active_time_zone IS NULL AND active_weekdays IS NULL AND active_start IS NULL AND active_end IS NULL OR active_time_zone IS NOT NULL AND active_weekdays IS NOT NULL AND active_start IS NOT NULL AND active_end IS NOT NULL),
 	CONSTRAINT escalation_policy_steps_escalation_policy_id_fkey FOREIGN KEY (escalation_policy_id) REFERENCES escalation_policies(id) ON DELETE CASCADE,
 	CONSTRAINT escalation_policy_steps_escalation_policy_id_step_number_key UNIQUE (escalation_policy_id, step_number) DEFERRABLE INITIALLY DEFERRED,
-	CONSTRAINT escalation_policy_steps_pkey PRIMARY KEY (id)
+	CONSTRAINT escalation_policy_steps_mode_check CHECK ((mode = 'round_robin'::enum_ep_step_mode) = (round_robin_minutes IS NOT NULL) AND (mode = 'ack_count'::enum_ep_step_mode) = (ack_count IS NOT NULL)),
+	CONSTRAINT escalation_policy_steps_pkey PRIMARY KEY (id),
+	CONSTRAINT escalation_policy_steps_round_robin_minutes_check CHECK (round_robin_minutes >= 1 AND round_robin_minutes <= 60)
 );
 
 CREATE UNIQUE INDEX escalation_policy_steps_escalation_policy_id_step_number_key ON public.escalation_policy_steps USING btree (escalation_policy_id, step_number);
@@ -1999,6 +2190,57 @@ CREATE INDEX idx_heartbeat_monitor_service ON public.heartbeat_monitors USING bt
 CREATE CONSTRAINT TRIGGER trg_enforce_heartbeat_monitor_limit AFTER INSERT ON public.heartbeat_monitors NOT DEFERRABLE INITIALLY IMMEDIATE FOR EACH ROW EXECUTE FUNCTION fn_enforce_heartbeat_limit();
 
 
+CREATE TABLE holiday_calendar_dates (
+	calendar_id uuid NOT NULL,
+	date date NOT NULL,
+	name text NOT NULL,
+	CONSTRAINT holiday_calendar_dates_calendar_id_fkey FOREIGN KEY (calendar_id) REFERENCES holiday_calendars(id) ON DELETE CASCADE,
+	CONSTRAINT holiday_calendar_dates_pkey PRIMARY KEY (calendar_id, date)
+);
+
+CREATE UNIQUE INDEX holiday_calendar_dates_pkey ON public.holiday_calendar_dates USING btree (calendar_id, date);
+
+
+CREATE TABLE holiday_calendars (
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	id uuid NOT NULL,
+	imported_at timestamp with time zone DEFAULT now() NOT NULL,
+	name text NOT NULL,
+	source_url text,
+	CONSTRAINT holiday_calendars_name_key UNIQUE (name),
+	CONSTRAINT holiday_calendars_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX holiday_calendars_name_key ON public.holiday_calendars USING btree (name);
+CREATE UNIQUE INDEX holiday_calendars_pkey ON public.holiday_calendars USING btree (id);
+
+
+CREATE TABLE incident_alerts (
+	alert_id bigint NOT NULL,
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	incident_id bigint NOT NULL,
+	CONSTRAINT incident_alerts_alert_id_fkey FOREIGN KEY (alert_id) REFERENCES alerts(id) ON DELETE CASCADE,
+	CONSTRAINT incident_alerts_incident_id_fkey FOREIGN KEY (incident_id) REFERENCES incidents(id) ON DELETE CASCADE,
+	CONSTRAINT incident_alerts_pkey PRIMARY KEY (alert_id)
+);
+
+CREATE INDEX idx_incident_alerts_incident_id ON public.incident_alerts USING btree (incident_id);
+CREATE UNIQUE INDEX incident_alerts_pkey ON public.incident_alerts USING btree (alert_id);
+
+
+CREATE TABLE incidents (
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	details text DEFAULT ''::text NOT NULL,
+	id bigint DEFAULT nextval('incidents_id_seq'::regclass) NOT NULL,
+	status enum_alert_status DEFAULT 'triggered'::enum_alert_status NOT NULL,
+	summary text NOT NULL,
+	CONSTRAINT incidents_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_incident_status ON public.incidents USING btree (status);
+CREATE UNIQUE INDEX incidents_pkey ON public.incidents USING btree (id);
+
+
 CREATE TABLE integration_keys (
 	external_system_name text,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
@@ -2125,8 +2367,10 @@ CREATE TABLE outgoing_messages (
 	last_status_at timestamp with time zone DEFAULT now(),
 	message_type enum_outgoing_messages_type NOT NULL,
 	next_retry_at timestamp with time zone,
+	override_request_id uuid,
 	provider_msg_id text,
 	provider_seq integer DEFAULT 0 NOT NULL,
+	quiet_until timestamp with time zone,
 	retry_count integer DEFAULT 0 NOT NULL,
 	schedule_id uuid,
 	sending_deadline timestamp with time zone,
@@ -2139,6 +2383,7 @@ CREATE TABLE outgoing_messages (
 	user_verification_code_id uuid,
 	CONSTRAINT om_alert_svc_ep_ids CHECK (message_type <> 'alert_notification'::enum_outgoing_messages_type OR alert_id IS NOT NULL AND service_id IS NOT NULL AND escalation_policy_id IS NOT NULL),
 	CONSTRAINT om_no_status_bundles CHECK (message_type <> 'alert_status_update_bundle'::enum_outgoing_messages_type OR last_status <> 'pending'::enum_outgoing_messages_status),
+	CONSTRAINT om_override_request_id CHECK (message_type <> 'override_request_notification'::enum_outgoing_messages_type OR override_request_id IS NOT NULL),
 	CONSTRAINT om_pending_no_fired_no_sent CHECK (last_status <> 'pending'::enum_outgoing_messages_status OR fired_at IS NULL AND sent_at IS NULL),
 	CONSTRAINT om_processed_no_fired_sent CHECK ((last_status = ANY (ARRAY['pending'::enum_outgoing_messages_status, 'sending'::enum_outgoing_messages_status, 'failed'::enum_outgoing_messages_status, 'bundled'::enum_outgoing_messages_status])) OR fired_at IS NULL AND sent_at IS NOT NULL),
 	CONSTRAINT om_sending_deadline_reqd CHECK (last_status <> 'sending'::enum_outgoing_messages_status OR sending_deadline IS NOT NULL),
@@ -2152,6 +2397,7 @@ CREATE TABLE outgoing_messages (
 	CONSTRAINT outgoing_messages_contact_method_id_fkey FOREIGN KEY (contact_method_id) REFERENCES user_contact_methods(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_cycle_id_fkey FOREIGN KEY (cycle_id) REFERENCES notification_policy_cycles(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_escalation_policy_id_fkey FOREIGN KEY (escalation_policy_id) REFERENCES escalation_policies(id) ON DELETE CASCADE,
+	CONSTRAINT outgoing_messages_override_request_id_fkey FOREIGN KEY (override_request_id) REFERENCES override_requests(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_pkey PRIMARY KEY (id),
 	CONSTRAINT outgoing_messages_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
 	CONSTRAINT outgoing_messages_service_id_fkey FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE,
@@ -2177,6 +2423,38 @@ CREATE TRIGGER trg_insert_message_status_history AFTER INSERT ON public.outgoing
 CREATE TRIGGER trg_update_message_status_history AFTER UPDATE OF last_status ON public.outgoing_messages FOR EACH ROW EXECUTE FUNCTION fn_insert_message_status_history();
 
 
+CREATE TABLE override_requests (
+	add_user_id uuid,
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	end_time timestamp with time zone NOT NULL,
+	id uuid NOT NULL,
+	override_id uuid,
+	remove_user_id uuid,
+	requester_user_id uuid NOT NULL,
+	responded_at timestamp with time zone,
+	schedule_id uuid NOT NULL,
+	start_time timestamp with time zone NOT NULL,
+	status enum_override_request_status DEFAULT 'pending'::enum_override_request_status NOT NULL,
+	target_user_id uuid NOT NULL,
+	CONSTRAINT override_requests_add_user_id_fkey FOREIGN KEY (add_user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT override_requests_check_add_remove CHECK (add_user_id <> remove_user_id),
+	CONSTRAINT override_requests_check_target CHECK (target_user_id = add_user_id OR target_user_id = remove_user_id),
+	CONSTRAINT override_requests_check_time CHECK (end_time > start_time),
+	CONSTRAINT override_requests_check_users CHECK (add_user_id IS NOT NULL OR remove_user_id IS NOT NULL),
+	CONSTRAINT override_requests_override_id_fkey FOREIGN KEY (override_id) REFERENCES user_overrides(id) ON DELETE SET NULL,
+	CONSTRAINT override_requests_pkey PRIMARY KEY (id),
+	CONSTRAINT override_requests_remove_user_id_fkey FOREIGN KEY (remove_user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT override_requests_requester_user_id_fkey FOREIGN KEY (requester_user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT override_requests_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+	CONSTRAINT override_requests_target_user_id_fkey FOREIGN KEY (target_user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_override_requests_requester ON public.override_requests USING btree (requester_user_id);
+CREATE INDEX idx_override_requests_schedule ON public.override_requests USING btree (schedule_id);
+CREATE INDEX idx_override_requests_target_pending ON public.override_requests USING btree (target_user_id) WHERE (status = 'pending'::enum_override_request_status);
+CREATE UNIQUE INDEX override_requests_pkey ON public.override_requests USING btree (id);
+
+
 CREATE TABLE pending_signals (
 	created_at timestamp with time zone DEFAULT now() NOT NULL,
 	dest_id uuid NOT NULL,
@@ -2200,6 +2478,27 @@ CREATE CONSTRAINT TRIGGER trg_enforce_signals_per_service_limit AFTER INSERT ON
 CREATE TRIGGER trg_pending_signals_after_insert AFTER INSERT ON public.pending_signals FOR EACH ROW EXECUTE FUNCTION fn_job_signal();
 
 
+CREATE TABLE quiet_hours (
+	end_time time without time zone NOT NULL,
+	id uuid NOT NULL,
+	service_id uuid,
+	start_time time without time zone NOT NULL,
+	time_zone text NOT NULL,
+	user_id uuid,
+	CONSTRAINT quiet_hours_check CHECK (start_time <> end_time),
+	CONSTRAINT quiet_hours_one_target CHECK (num_nonnulls(user_id, service_id) = 1),
+	CONSTRAINT quiet_hours_pkey PRIMARY KEY (id),
+	CONSTRAINT quiet_hours_service_id_fkey FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE,
+	CONSTRAINT quiet_hours_service_id_key UNIQUE (service_id),
+	CONSTRAINT quiet_hours_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT quiet_hours_user_id_key UNIQUE (user_id)
+);
+
+CREATE UNIQUE INDEX quiet_hours_pkey ON public.quiet_hours USING btree (id);
+CREATE UNIQUE INDEX quiet_hours_service_id_key ON public.quiet_hours USING btree (service_id);
+CREATE UNIQUE INDEX quiet_hours_user_id_key ON public.quiet_hours USING btree (user_id);
+
+
 CREATE TABLE region_ids (
 	id integer DEFAULT nextval('region_ids_id_seq'::regclass) NOT NULL,
 	name text NOT NULL,
@@ -2313,15 +2612,31 @@ CREATE UNIQUE INDEX river_queue_id_key ON public.river_queue USING btree (id);
 CREATE UNIQUE INDEX river_queue_pkey ON public.river_queue USING btree (name);
 
 
+CREATE TABLE rotation_active_participants (
+	rotation_id uuid NOT NULL,
+	rotation_participant_id uuid NOT NULL,
+	CONSTRAINT rotation_active_participants_pkey PRIMARY KEY (rotation_id, rotation_participant_id),
+	CONSTRAINT rotation_active_participants_rotation_id_fkey FOREIGN KEY (rotation_id) REFERENCES rotations(id) ON DELETE CASCADE,
+	CONSTRAINT rotation_active_participants_rotation_participant_id_fkey FOREIGN KEY (rotation_participant_id) REFERENCES rotation_participants(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX rotation_active_participants_pkey ON public.rotation_active_participants USING btree (rotation_id, rotation_participant_id);
+
+
 CREATE TABLE rotation_participants (
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
 	position integer NOT NULL,
 	rotation_id uuid NOT NULL,
+	shadow boolean DEFAULT false NOT NULL,
 	user_id uuid NOT NULL,
+	window_end time without time zone,
+	window_start time without time zone,
+	window_time_zone text,
 	CONSTRAINT rotation_participants_pkey PRIMARY KEY (id),
 	CONSTRAINT rotation_participants_rotation_id_fkey FOREIGN KEY (rotation_id) REFERENCES rotations(id) ON DELETE CASCADE,
 	CONSTRAINT rotation_participants_rotation_id_position_key UNIQUE (rotation_id, "position") DEFERRABLE INITIALLY DEFERRED,
-	CONSTRAINT rotation_participants_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	CONSTRAINT rotation_participants_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT rotation_participants_window_check CHECK ((window_start IS NULL) = (window_end IS NULL) AND (window_start IS NULL) = (window_time_zone IS NULL))
 );
 
 CREATE INDEX idx_participant_rotation ON public.rotation_participants USING btree (rotation_id);
@@ -2361,6 +2676,7 @@ CREATE TRIGGER trg_track_rotation_state_updates AFTER UPDATE ON public.rotation_
 
 
 CREATE TABLE rotations (
+	active_count integer DEFAULT 1 NOT NULL,
 	description text DEFAULT ''::text NOT NULL,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
 	last_processed timestamp with time zone,
@@ -2370,6 +2686,7 @@ CREATE TABLE rotations (
 	start_time timestamp with time zone DEFAULT now() NOT NULL,
 	time_zone text NOT NULL,
 	type enum_rotation_type NOT NULL,
+	CONSTRAINT rotations_active_count_check CHECK (active_count >= 1 AND active_count <= 10),
 	CONSTRAINT rotations_name_unique UNIQUE (name),
 	CONSTRAINT rotations_pkey PRIMARY KEY (id),
 	CONSTRAINT rotations_shift_length_check CHECK (shift_length > 0)
@@ -2384,6 +2701,18 @@ CREATE UNIQUE INDEX rotations_pkey ON public.rotations USING btree (id);
 CREATE TRIGGER trg_track_rotation_updates AFTER INSERT OR UPDATE ON public.rotations FOR EACH ROW EXECUTE FUNCTION fn_track_rotation_updates();
 
 
+CREATE TABLE schedule_coverage_notices (
+	gap_end timestamp with time zone NOT NULL,
+	gap_start timestamp with time zone NOT NULL,
+	notified_at timestamp with time zone DEFAULT now() NOT NULL,
+	schedule_id uuid NOT NULL,
+	CONSTRAINT schedule_coverage_notices_pkey PRIMARY KEY (schedule_id),
+	CONSTRAINT schedule_coverage_notices_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX schedule_coverage_notices_pkey ON public.schedule_coverage_notices USING btree (schedule_id);
+
+
 CREATE TABLE schedule_data (
 	data jsonb NOT NULL,
 	id bigint DEFAULT nextval('schedule_data_id_seq'::regclass) NOT NULL,
@@ -2398,6 +2727,18 @@ CREATE UNIQUE INDEX schedule_data_id_key ON public.schedule_data USING btree (id
 CREATE UNIQUE INDEX schedule_data_pkey ON public.schedule_data USING btree (schedule_id);
 
 
+CREATE TABLE schedule_holiday_calendars (
+	calendar_id uuid NOT NULL,
+	schedule_id uuid NOT NULL,
+	CONSTRAINT schedule_holiday_calendars_calendar_id_fkey FOREIGN KEY (calendar_id) REFERENCES holiday_calendars(id) ON DELETE CASCADE,
+	CONSTRAINT schedule_holiday_calendars_pkey PRIMARY KEY (schedule_id, calendar_id),
+	CONSTRAINT schedule_holiday_calendars_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_schedule_holiday_calendars_calendar ON public.schedule_holiday_calendars USING btree (calendar_id);
+CREATE UNIQUE INDEX schedule_holiday_calendars_pkey ON public.schedule_holiday_calendars USING btree (schedule_id, calendar_id);
+
+
 CREATE TABLE schedule_on_call_users (
 	end_time timestamp with time zone,
 	id bigint DEFAULT nextval('schedule_on_call_users_id_seq'::regclass) NOT NULL,
@@ -2419,6 +2760,7 @@ CREATE TABLE schedule_rules (
 	created_at timestamp with time zone DEFAULT now() NOT NULL,
 	end_time time without time zone DEFAULT '23:59:59'::time without time zone NOT NULL,
 	friday boolean DEFAULT true NOT NULL,
+	holiday_filter enum_holiday_filter DEFAULT 'any'::enum_holiday_filter NOT NULL,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
 	is_active boolean DEFAULT false NOT NULL,
 	monday boolean DEFAULT true NOT NULL,
@@ -2463,15 +2805,57 @@ CREATE UNIQUE INDEX schedules_name_key ON public.schedules USING btree (name);
 CREATE UNIQUE INDEX schedules_pkey ON public.schedules USING btree (id);
 
 
+CREATE TABLE service_alert_rules (
+	config jsonb NOT NULL,
+	service_id uuid NOT NULL,
+	CONSTRAINT service_alert_rules_pkey PRIMARY KEY (service_id),
+	CONSTRAINT service_alert_rules_service_id_fkey FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX service_alert_rules_pkey ON public.service_alert_rules USING btree (service_id);
+
+
+CREATE TABLE service_maintenance_windows (
+	active_since timestamp with time zone,
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	created_by uuid,
+	end_action enum_maint_window_end_action DEFAULT 'escalate'::enum_maint_window_end_action NOT NULL,
+	end_time timestamp with time zone NOT NULL,
+	id uuid NOT NULL,
+	label_key text,
+	label_value text DEFAULT ''::text NOT NULL,
+	reason text DEFAULT ''::text NOT NULL,
+	repeat enum_maint_window_repeat DEFAULT 'none'::enum_maint_window_repeat NOT NULL,
+	service_id uuid,
+	start_time timestamp with time zone NOT NULL,
+	time_zone text DEFAULT 'UTC'::text NOT NULL,
+	CONSTRAINT service_maintenance_windows_created_by_fkey FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+	CONSTRAINT service_maintenance_windows_pkey PRIMARY KEY (id),
+	CONSTRAINT service_maintenance_windows_service_id_fkey FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE,
+	CONSTRAINT service_maintenance_windows_target_check CHECK ((service_id IS NULL) <> (label_key IS NULL)),
+	CONSTRAINT service_maintenance_windows_time_check CHECK (end_time > start_time)
+);
+
+CREATE INDEX idx_maint_window_active ON public.service_maintenance_windows USING btree (active_since) WHERE (active_since IS NOT NULL);
+CREATE INDEX idx_maint_window_service_id ON public.service_maintenance_windows USING btree (service_id);
+CREATE UNIQUE INDEX service_maintenance_windows_pkey ON public.service_maintenance_windows USING btree (id);
+
+
 CREATE TABLE services (
 	description text DEFAULT ''::text NOT NULL,
 	escalation_policy_id uuid NOT NULL,
+	flap_threshold integer DEFAULT 0 NOT NULL,
+	flap_window_minutes integer DEFAULT 0 NOT NULL,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
 	maintenance_expires_at timestamp with time zone,
 	name text NOT NULL,
+	reopen_window_minutes integer DEFAULT 0 NOT NULL,
 	CONSTRAINT services_escalation_policy_id_fkey FOREIGN KEY (escalation_policy_id) REFERENCES escalation_policies(id),
+	CONSTRAINT services_flap_threshold_check CHECK (flap_threshold >= 0),
+	CONSTRAINT services_flap_window_minutes_check CHECK (flap_window_minutes >= 0 AND flap_window_minutes <= 1440),
 	CONSTRAINT services_name_key UNIQUE (name),
 	CONSTRAINT services_pkey PRIMARY KEY (id),
+	CONSTRAINT services_reopen_window_minutes_check CHECK (reopen_window_minutes >= 0 AND reopen_window_minutes <= 1440),
 	CONSTRAINT svc_ep_uniq UNIQUE (id, escalation_policy_id)
 );
 
@@ -2569,6 +2953,29 @@ CREATE UNIQUE INDEX uik_config_primary_token_key ON public.uik_config USING btre
 CREATE UNIQUE INDEX uik_config_secondary_token_key ON public.uik_config USING btree (secondary_token);
 
 
+CREATE TABLE user_availability_feeds (
+	created_at timestamp with time zone DEFAULT now() NOT NULL,
+	id uuid NOT NULL,
+	keyword text DEFAULT ''::text NOT NULL,
+	last_error text DEFAULT ''::text NOT NULL,
+	last_sync_at timestamp with time zone,
+	name text NOT NULL,
+	substitute_user_id uuid,
+	time_zone text NOT NULL,
+	url text NOT NULL,
+	user_id uuid NOT NULL,
+	CONSTRAINT user_availability_feeds_check CHECK (substitute_user_id <> user_id),
+	CONSTRAINT user_availability_feeds_pkey PRIMARY KEY (id),
+	CONSTRAINT user_availability_feeds_substitute_user_id_fkey FOREIGN KEY (substitute_user_id) REFERENCES users(id) ON DELETE SET NULL,
+	CONSTRAINT user_availability_feeds_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT user_availability_feeds_user_id_name_key UNIQUE (user_id, name)
+);
+
+CREATE INDEX idx_user_availability_feeds_substitute ON public.user_availability_feeds USING btree (substitute_user_id);
+CREATE UNIQUE INDEX user_availability_feeds_pkey ON public.user_availability_feeds USING btree (id);
+CREATE UNIQUE INDEX user_availability_feeds_user_id_name_key ON public.user_availability_feeds USING btree (user_id, name);
+
+
 CREATE TABLE user_calendar_subscriptions (
 	config jsonb NOT NULL,
 	created_at timestamp with time zone DEFAULT now() NOT NULL,
@@ -2577,14 +2984,21 @@ CREATE TABLE user_calendar_subscriptions (
 	last_access timestamp with time zone,
 	last_update timestamp with time zone DEFAULT now() NOT NULL,
 	name text NOT NULL,
-	schedule_id uuid NOT NULL,
+	rotation_id uuid,
+	schedule_id uuid,
+	service_id uuid,
 	user_id uuid NOT NULL,
 	CONSTRAINT user_calendar_subscriptions_name_schedule_id_user_id_key UNIQUE (name, schedule_id, user_id),
+	CONSTRAINT user_calendar_subscriptions_one_target CHECK (num_nonnulls(schedule_id, rotation_id, service_id) <= 1),
 	CONSTRAINT user_calendar_subscriptions_pkey PRIMARY KEY (id),
+	CONSTRAINT user_calendar_subscriptions_rotation_id_fkey FOREIGN KEY (rotation_id) REFERENCES rotations(id) ON DELETE CASCADE,
 	CONSTRAINT user_calendar_subscriptions_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+	CONSTRAINT user_calendar_subscriptions_service_id_fkey FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE CASCADE,
 	CONSTRAINT user_calendar_subscriptions_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
+CREATE INDEX idx_user_calendar_subscriptions_rotation ON public.user_calendar_subscriptions USING btree (rotation_id);
+CREATE INDEX idx_user_calendar_subscriptions_service ON public.user_calendar_subscriptions USING btree (service_id);
 CREATE UNIQUE INDEX user_calendar_subscriptions_name_schedule_id_user_id_key ON public.user_calendar_subscriptions USING btree (name, schedule_id, user_id);
 CREATE UNIQUE INDEX user_calendar_subscriptions_pkey ON public.user_calendar_subscriptions USING btree (id);
 
@@ -2658,16 +3072,19 @@ CREATE TABLE user_notification_rules (
 	created_at timestamp with time zone DEFAULT now(),
 	delay_minutes integer DEFAULT 0 NOT NULL,
 	id uuid DEFAULT gen_random_uuid() NOT NULL,
+	priorities integer[],
+	service_label_key text,
+	service_label_value text,
 	user_id uuid NOT NULL,
-	CONSTRAINT user_notification_rules_contact_method_id_delay_minutes_key UNIQUE (contact_method_id, delay_minutes),
 	CONSTRAINT user_notification_rules_contact_method_id_fkey FOREIGN KEY (contact_method_id) REFERENCES user_contact_methods(id) ON DELETE CASCADE,
+	CONSTRAINT user_notification_rules_label_value_needs_key CHECK (service_label_value IS NULL OR service_label_key IS NOT NULL),
 	CONSTRAINT user_notification_rules_pkey PRIMARY KEY (id),
 	CONSTRAINT user_notification_rules_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
 CREATE INDEX idx_notif_rule_creation_time ON public.user_notification_rules USING btree (user_id, created_at);
 CREATE INDEX idx_notification_rule_users ON public.user_notification_rules USING btree (user_id);
-CREATE UNIQUE INDEX user_notification_rules_contact_method_id_delay_minutes_key ON public.user_notification_rules USING btree (contact_method_id, delay_minutes);
+CREATE UNIQUE INDEX user_notification_rules_contact_method_id_delay_minutes_key ON public.user_notification_rules USING btree (contact_method_id, delay_minutes, COALESCE(priorities, '{}'::integer[]), COALESCE(service_label_key, ''::text), COALESCE(service_label_value, ''::text));
 CREATE UNIQUE INDEX user_notification_rules_pkey ON public.user_notification_rules USING btree (id);
 
 CREATE CONSTRAINT TRIGGER trg_enforce_notification_rule_limit AFTER INSERT ON public.user_notification_rules NOT DEFERRABLE INITIALLY IMMEDIATE FOR EACH ROW EXECUTE FUNCTION fn_enforce_notification_rule_limit();
@@ -2707,6 +3124,41 @@ CREATE TABLE user_slack_data (
 CREATE UNIQUE INDEX user_slack_data_pkey ON public.user_slack_data USING btree (id);
 
 
+CREATE TABLE user_unavailability (
+	end_time timestamp with time zone NOT NULL,
+	feed_id uuid NOT NULL,
+	id uuid NOT NULL,
+	start_time timestamp with time zone NOT NULL,
+	substitute_user_id uuid,
+	summary text NOT NULL,
+	user_id uuid NOT NULL,
+	CONSTRAINT user_unavailability_check CHECK (end_time > start_time),
+	CONSTRAINT user_unavailability_feed_id_fkey FOREIGN KEY (feed_id) REFERENCES user_availability_feeds(id) ON DELETE CASCADE,
+	CONSTRAINT user_unavailability_pkey PRIMARY KEY (id),
+	CONSTRAINT user_unavailability_substitute_user_id_fkey FOREIGN KEY (substitute_user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT user_unavailability_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_unavailability_feed ON public.user_unavailability USING btree (feed_id);
+CREATE INDEX idx_user_unavailability_user ON public.user_unavailability USING btree (user_id, end_time);
+CREATE UNIQUE INDEX user_unavailability_pkey ON public.user_unavailability USING btree (id);
+
+
+CREATE TABLE user_unavailability_overrides (
+	override_id uuid,
+	schedule_id uuid NOT NULL,
+	unavailability_id uuid NOT NULL,
+	CONSTRAINT user_unavailability_overrides_override_id_fkey FOREIGN KEY (override_id) REFERENCES user_overrides(id) ON DELETE SET NULL,
+	CONSTRAINT user_unavailability_overrides_pkey PRIMARY KEY (unavailability_id, schedule_id),
+	CONSTRAINT user_unavailability_overrides_schedule_id_fkey FOREIGN KEY (schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+	CONSTRAINT user_unavailability_overrides_unavailability_id_fkey FOREIGN KEY (unavailability_id) REFERENCES user_unavailability(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_unavailability_overrides_override ON public.user_unavailability_overrides USING btree (override_id);
+CREATE INDEX idx_user_unavailability_overrides_schedule ON public.user_unavailability_overrides USING btree (schedule_id);
+CREATE UNIQUE INDEX user_unavailability_overrides_pkey ON public.user_unavailability_overrides USING btree (unavailability_id, schedule_id);
+
+
 CREATE TABLE user_verification_codes (
 	code integer NOT NULL,
 	contact_method_id uuid NOT NULL,
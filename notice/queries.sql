@@ -14,3 +14,43 @@ FROM
 WHERE
     service_id = $1::uuid
     AND status = 'triggered';
+
+-- name: NoticeScheduleUnavailability :many
+-- Returns upcoming out-of-office periods of users on a schedule that are not covered by an override
+-- removing them from it.
+SELECT
+    u.user_id,
+    usr.name AS user_name,
+    u.summary,
+    u.start_time,
+    u.end_time,
+    sched.time_zone
+FROM
+    user_unavailability u
+    JOIN users usr ON usr.id = u.user_id
+    JOIN schedules sched ON sched.id = @schedule_id::uuid
+WHERE
+    u.end_time > now()
+    AND u.start_time < @before::timestamptz
+    AND u.user_id IN (
+        SELECT
+            coalesce(r.tgt_user_id, p.user_id)
+        FROM
+            schedule_rules r
+        LEFT JOIN rotation_participants p ON p.rotation_id = r.tgt_rotation_id
+            AND NOT p.shadow
+    WHERE
+        r.schedule_id = @schedule_id::uuid)
+    AND NOT EXISTS (
+        SELECT
+            1
+        FROM
+            user_overrides o
+        WHERE
+            o.tgt_schedule_id = @schedule_id::uuid
+            AND o.remove_user_id = u.user_id
+            AND o.start_time <= greatest(u.start_time, now())
+            AND o.end_time >= u.end_time)
+ORDER BY
+    u.start_time,
+    usr.name;
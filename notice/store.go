@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/target/goalert/config"
@@ -132,3 +133,55 @@ func (s *Store) FindAllServiceNotices(ctx context.Context, serviceID string) ([]
 
 	return nil, nil
 }
+
+// ScheduleUnavailabilityDays is how far ahead schedules are checked for users that are out of office.
+const ScheduleUnavailabilityDays = 14
+
+// FindAllScheduleNotices returns a notice for each upcoming out-of-office period of a user on the schedule
+// that is not covered by an override removing them.
+func (s *Store) FindAllScheduleNotices(ctx context.Context, scheduleID string) ([]Notice, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validate.UUID("ScheduleID", scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).NoticeScheduleUnavailability(ctx, gadb.NoticeScheduleUnavailabilityParams{
+		ScheduleID: uuid.MustParse(scheduleID),
+		Before:     time.Now().AddDate(0, 0, ScheduleUnavailabilityDays),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var notices []Notice
+	for _, row := range rows {
+		loc, err := util.LoadLocation(row.TimeZone)
+		if err != nil {
+			loc = time.UTC
+		}
+
+		summary := row.Summary
+		if summary == "" {
+			summary = "Out of office"
+		}
+
+		const layout = "Mon Jan 2 3:04 PM"
+		notices = append(notices, Notice{
+			Type:    TypeWarning,
+			Message: row.UserName + " is out of office",
+			Details: fmt.Sprintf("%s: %s to %s (%s). Add an override to cover any of their shifts during this time.",
+				summary,
+				row.StartTime.In(loc).Format(layout),
+				row.EndTime.In(loc).Format(layout),
+				loc.String(),
+			),
+		})
+	}
+
+	return notices, nil
+}
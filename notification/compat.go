@@ -11,6 +11,10 @@ type (
 	Verification        = nfymsg.Verification
 	SignalMessage       = nfymsg.SignalMessage
 	ScheduleOnCallUsers = nfymsg.ScheduleOnCallUsers
+	OverrideRequest     = nfymsg.OverrideRequest
+	ScheduleCoverageGap = nfymsg.ScheduleCoverageGap
+	QuietHoursDigest    = nfymsg.QuietHoursDigest
+	DigestItem          = nfymsg.DigestItem
 
 	State = nfymsg.State
 	User  = nfymsg.User
@@ -10,6 +10,7 @@ import (
 	"net/mail"
 	"net/smtp"
 	"strings"
+	"time"
 
 	"github.com/matcornic/hermes"
 	"github.com/target/goalert/config"
@@ -95,6 +96,28 @@ func (s *Sender) SendMessage(ctx context.Context, msg notification.Message) (*no
 			},
 		}}
 		e.Body.Outros = []string{"You are receiving this message because you have status updates enabled. Visit your Profile page to change this."}
+	case notification.OverrideRequest:
+		subject = fmt.Sprintf("Override Request: %s", m.ScheduleName)
+		e.Body.Title = "Override Request"
+		e.Body.Intros = []string{m.Summary()}
+		e.Body.Actions = []hermes.Action{{
+			Instructions: "Accept or decline the request from the schedule's overrides page.",
+			Button: hermes.Button{
+				Text: "Open Schedule Overrides",
+				Link: cfg.CallbackURL(fmt.Sprintf("/schedules/%s/overrides", m.ScheduleID)),
+			},
+		}}
+	case notification.QuietHoursDigest:
+		subject = fmt.Sprintf("Quiet Hours Digest: %d notifications", len(m.Items))
+		e.Body.Title = "Quiet Hours Digest"
+		e.Body.Intros = []string{m.Summary()}
+		for _, item := range m.Items {
+			e.Body.Dictionary = append(e.Body.Dictionary, hermes.Entry{
+				Key:   item.Time.Format(time.RFC1123),
+				Value: fmt.Sprintf("%s %s", item.Summary, item.URL),
+			})
+		}
+		e.Body.Outros = []string{"You are receiving this message because quiet hours are configured for you or a service. Status updates and on-call notifications are held during quiet hours and delivered afterward as a digest."}
 	default:
 		return nil, errors.New("message type not supported")
 	}
@@ -21,4 +21,10 @@ const (
 	MessageTypeScheduleOnCallUsers = gadb.EnumOutgoingMessagesTypeScheduleOnCallNotification
 
 	MessageTypeSignalMessage = gadb.EnumOutgoingMessagesTypeSignalMessage
+
+	MessageTypeOverrideRequest = gadb.EnumOutgoingMessagesTypeOverrideRequestNotification
+
+	MessageTypeScheduleCoverageGap = gadb.EnumOutgoingMessagesTypeScheduleCoverageGapNotification
+
+	MessageTypeQuietHoursDigest = gadb.EnumOutgoingMessagesTypeQuietHoursDigest
 )
@@ -0,0 +1,76 @@
+package msteams
+
+import "encoding/json"
+
+// activity is a Bot Framework activity.
+//
+// https://learn.microsoft.com/en-us/azure/bot-service/rest-api/bot-framework-rest-connector-api-reference#activity-object
+type activity struct {
+	Type         string               `json:"type"`
+	ID           string               `json:"id,omitempty"`
+	Name         string               `json:"name,omitempty"`
+	ServiceURL   string               `json:"serviceUrl,omitempty"`
+	From         *channelAccount      `json:"from,omitempty"`
+	Recipient    *channelAccount      `json:"recipient,omitempty"`
+	Conversation *conversationAccount `json:"conversation,omitempty"`
+	ChannelData  *channelData         `json:"channelData,omitempty"`
+	Text         string               `json:"text,omitempty"`
+	Attachments  []attachment         `json:"attachments,omitempty"`
+	Value        json.RawMessage      `json:"value,omitempty"`
+}
+
+type channelAccount struct {
+	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	AADObjectID string `json:"aadObjectId,omitempty"`
+}
+
+type conversationAccount struct {
+	ID       string `json:"id"`
+	TenantID string `json:"tenantId,omitempty"`
+}
+
+type channelData struct {
+	Tenant tenantInfo `json:"tenant"`
+}
+
+type tenantInfo struct {
+	ID string `json:"id"`
+}
+
+type attachment struct {
+	ContentType string `json:"contentType"`
+	Content     *Card  `json:"content"`
+}
+
+// tenantID returns the tenant ID of the activity.
+func (a activity) tenantID() string {
+	if a.ChannelData != nil && a.ChannelData.Tenant.ID != "" {
+		return a.ChannelData.Tenant.ID
+	}
+	if a.Conversation != nil {
+		return a.Conversation.TenantID
+	}
+
+	return ""
+}
+
+// cardMessage returns a message activity containing the card.
+func cardMessage(c *Card) activity {
+	return activity{
+		Type:        "message",
+		Attachments: []attachment{{ContentType: cardContentType, Content: c}},
+	}
+}
+
+// invokeResponse is the response body of an invoke activity.
+type invokeResponse struct {
+	StatusCode int    `json:"statusCode"`
+	Type       string `json:"type"`
+	Value      any    `json:"value"`
+}
+
+// invokeMessage returns an invoke response that displays a message to the user that performed the action.
+func invokeMessage(msg string) invokeResponse {
+	return invokeResponse{StatusCode: 200, Type: "application/vnd.microsoft.activity.message", Value: msg}
+}
@@ -0,0 +1,157 @@
+package msteams
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/target/goalert/config"
+)
+
+const (
+	botIssuer          = "https://api.botframework.com"
+	botMetadataBaseURL = "https://login.botframework.com"
+
+	// keyRefreshInterval is how often signing keys are refreshed. Unknown key IDs will cause a refresh at most once per keyRefreshMinInterval.
+	keyRefreshInterval    = 24 * time.Hour
+	keyRefreshMinInterval = time.Minute
+)
+
+func (s *Sender) getJSON(ctx context.Context, url string, v any) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.cfg.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("get %s: %s", url, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// fetchKeys will fetch the current Bot Framework token signing keys.
+func (s *Sender) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	base := botMetadataBaseURL
+	if s.cfg.BaseURL != "" {
+		base = s.cfg.BaseURL
+	}
+
+	var meta struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	err := s.getJSON(ctx, strings.TrimSuffix(base, "/")+"/v1/.well-known/openidconfiguration", &meta)
+	if err != nil {
+		return nil, fmt.Errorf("get openid configuration: %w", err)
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	err = s.getJSON(ctx, meta.JWKSURI, &jwks)
+	if err != nil {
+		return nil, fmt.Errorf("get signing keys: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("decode key '%s' modulus: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("decode key '%s' exponent: %w", k.Kid, err)
+		}
+
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+
+	return keys, nil
+}
+
+// publicKey returns the signing key with the given ID.
+func (s *Sender) publicKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	s.keyMx.Lock()
+	defer s.keyMx.Unlock()
+
+	key, ok := s.keys[kid]
+	age := time.Since(s.keysFetch)
+	if ok && age < keyRefreshInterval {
+		return key, nil
+	}
+	if !ok && age < keyRefreshMinInterval {
+		return nil, fmt.Errorf("unknown signing key '%s'", kid)
+	}
+
+	keys, err := s.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.keys = keys
+	s.keysFetch = time.Now()
+
+	key, ok = keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key '%s'", kid)
+	}
+
+	return key, nil
+}
+
+type botClaims struct {
+	jwt.RegisteredClaims
+	ServiceURL string `json:"serviceurl"`
+}
+
+// verifyRequest will verify a request from the Bot Framework and return the service URL it was issued for.
+func (s *Sender) verifyRequest(req *http.Request) (string, error) {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+
+	tokStr, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return "", errors.New("missing bearer token")
+	}
+
+	var c botClaims
+	_, err := jwt.ParseWithClaims(tokStr, &c, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return s.publicKey(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithIssuer(botIssuer),
+		jwt.WithAudience(cfg.MSTeams.AppID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(5*time.Minute),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return c.ServiceURL, nil
+}
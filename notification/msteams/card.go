@@ -0,0 +1,236 @@
+package msteams
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+)
+
+const (
+	cardContentType = "application/vnd.microsoft.card.adaptive"
+	cardSchema      = "http://adaptivecards.io/schemas/adaptive-card.json"
+
+	// cardVersion is the highest Adaptive Card version supported by Teams that includes Action.Execute.
+	cardVersion = "1.4"
+
+	verbAck   = "ack"
+	verbClose = "close"
+)
+
+// Card is an Adaptive Card.
+//
+// https://adaptivecards.io/explorer/AdaptiveCard.html
+type Card struct {
+	Type    string        `json:"type"`
+	Schema  string        `json:"$schema"`
+	Version string        `json:"version"`
+	Body    []CardElement `json:"body"`
+	Actions []CardAction  `json:"actions,omitempty"`
+	MSTeams struct {
+		Width string `json:"width,omitempty"`
+	} `json:"msteams"`
+}
+
+// CardElement is a TextBlock or FactSet element of an Adaptive Card.
+type CardElement struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	Size     string `json:"size,omitempty"`
+	Weight   string `json:"weight,omitempty"`
+	Color    string `json:"color,omitempty"`
+	IsSubtle bool   `json:"isSubtle,omitempty"`
+	Wrap     bool   `json:"wrap,omitempty"`
+	Facts    []Fact `json:"facts,omitempty"`
+}
+
+// Fact is a single title/value pair of a FactSet.
+type Fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// CardAction is an Action.OpenUrl or Action.Execute action of an Adaptive Card.
+type CardAction struct {
+	Type  string      `json:"type"`
+	Title string      `json:"title"`
+	URL   string      `json:"url,omitempty"`
+	Verb  string      `json:"verb,omitempty"`
+	Style string      `json:"style,omitempty"`
+	Data  *ActionData `json:"data,omitempty"`
+}
+
+// ActionData is the data submitted with an Action.Execute action.
+type ActionData struct {
+	CallbackID string `json:"callbackID"`
+}
+
+func newCard(body ...CardElement) *Card {
+	c := &Card{
+		Type:    "AdaptiveCard",
+		Schema:  cardSchema,
+		Version: cardVersion,
+		Body:    body,
+	}
+	c.MSTeams.Width = "Full"
+	return c
+}
+
+func textBlock(text string) CardElement {
+	return CardElement{Type: "TextBlock", Text: text, Wrap: true}
+}
+
+func openURL(title, url string) CardAction {
+	return CardAction{Type: "Action.OpenUrl", Title: title, URL: url}
+}
+
+func execute(title, verb, callbackID string) CardAction {
+	return CardAction{Type: "Action.Execute", Title: title, Verb: verb, Data: &ActionData{CallbackID: callbackID}}
+}
+
+var mdEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `&lt;`,
+	`>`, `&gt;`,
+)
+
+// escapeMD escapes text so that it is displayed as-is by a TextBlock, which supports a subset of Markdown.
+func escapeMD(s string) string { return mdEscaper.Replace(s) }
+
+func mdLink(text, url string) string {
+	return fmt.Sprintf("[%s](%s)", escapeMD(text), strings.ReplaceAll(url, ")", "%29"))
+}
+
+// alertCard will return the card for an alert-type message (e.g., notification or status update).
+//
+// Acknowledge and Close buttons are only included if interactive is true.
+func alertCard(ctx context.Context, callbackID string, id int, summary, logEntry string, state notification.AlertState, interactive bool) *Card {
+	cfg := config.FromContext(ctx)
+	alertURL := cfg.CallbackURL(fmt.Sprintf("/alerts/%d", id))
+
+	title := textBlock(mdLink(fmt.Sprintf("Alert #%d: %s", id, summary), alertURL))
+	title.Size = "Medium"
+	title.Weight = "Bolder"
+
+	status := textBlock(escapeMD(logEntry))
+	status.IsSubtle = true
+	switch state {
+	case notification.AlertStateUnacknowledged:
+		status.Color = "Attention"
+	case notification.AlertStateAcknowledged:
+		status.Color = "Warning"
+	case notification.AlertStateClosed:
+		status.Color = "Good"
+	}
+
+	c := newCard(title, status)
+	if interactive {
+		switch state {
+		case notification.AlertStateUnacknowledged:
+			c.Actions = append(c.Actions,
+				execute("Acknowledge", verbAck, callbackID),
+				execute("Close", verbClose, callbackID),
+			)
+		case notification.AlertStateAcknowledged:
+			c.Actions = append(c.Actions, execute("Close", verbClose, callbackID))
+		}
+	}
+	c.Actions = append(c.Actions, openURL("Open in "+cfg.ApplicationName(), alertURL))
+
+	return c
+}
+
+// onCallCard will return the card for a ScheduleOnCallUsers notification.
+func onCallCard(t notification.ScheduleOnCallUsers) *Card {
+	suffix := "on-call for " + mdLink(t.ScheduleName, t.ScheduleURL)
+
+	users := make([]notification.User, len(t.Users))
+	copy(users, t.Users)
+	sort.Slice(users, func(i, j int) bool {
+		ui, uj := users[i], users[j]
+		if ui.Name == uj.Name {
+			return ui.ID < uj.ID
+		}
+
+		return ui.Name < uj.Name
+	})
+
+	links := make([]string, len(users))
+	for i, u := range users {
+		links[i] = mdLink(u.Name, u.URL)
+	}
+
+	var text string
+	switch len(links) {
+	case 0:
+		text = "No users are " + suffix
+	case 1:
+		text = fmt.Sprintf("%s is %s", links[0], suffix)
+	case 2:
+		text = fmt.Sprintf("%s and %s are %s", links[0], links[1], suffix)
+	default:
+		text = fmt.Sprintf("%s, and %s are %s", strings.Join(links[:len(links)-1], ", "), links[len(links)-1], suffix)
+	}
+
+	return newCard(textBlock(text))
+}
+
+// digestCard will return the card for a QuietHoursDigest notification.
+func digestCard(t notification.QuietHoursDigest) *Card {
+	var b strings.Builder
+	for _, item := range t.Items {
+		fmt.Fprintf(&b, "- %s\n", mdLink(item.Summary, item.URL))
+	}
+
+	return newCard(textBlock(escapeMD(t.Summary())), textBlock(b.String()))
+}
+
+// messageCard will return the card for a message, or nil if the message type is not supported.
+func (s *Sender) messageCard(ctx context.Context, msg notification.Message, interactive bool) *Card {
+	cfg := config.FromContext(ctx)
+
+	switch t := msg.(type) {
+	case notification.Test:
+		return newCard(textBlock("This is a test message."))
+	case notification.Alert:
+		if t.OriginalStatus != nil && msg.DestType() == DestTypeMSTeamsChannel {
+			// replies in the alert's thread only need a link
+			return newCard(textBlock(mdLink(fmt.Sprintf("Alert #%d: %s", t.AlertID, t.Summary), cfg.CallbackURL(fmt.Sprintf("/alerts/%d", t.AlertID)))))
+		}
+
+		c := alertCard(ctx, t.MsgID(), t.AlertID, t.Summary, "Unacknowledged", notification.AlertStateUnacknowledged, interactive)
+		if t.ServiceName != "" {
+			c.Body = append(c.Body, CardElement{Type: "FactSet", Facts: []Fact{{Title: "Service", Value: escapeMD(t.ServiceName)}}})
+		}
+		return c
+	case notification.AlertStatus:
+		return alertCard(ctx, t.OriginalStatus.ID, t.AlertID, t.Summary, t.LogEntry, t.NewAlertState, interactive)
+	case notification.AlertBundle:
+		c := newCard(textBlock(escapeMD(fmt.Sprintf("Service '%s' has %d unacknowledged alerts.", t.ServiceName, t.Count))))
+		c.Actions = append(c.Actions, openURL("View Alerts", cfg.CallbackURL("/services/"+t.ServiceID+"/alerts")))
+		return c
+	case notification.SignalMessage:
+		return newCard(textBlock(t.Param("message")))
+	case notification.ScheduleOnCallUsers:
+		return onCallCard(t)
+	case notification.ScheduleCoverageGap:
+		c := newCard(textBlock(escapeMD(t.Summary())))
+		c.Actions = append(c.Actions, openURL("View Schedule", t.ScheduleURL))
+		return c
+	case notification.QuietHoursDigest:
+		return digestCard(t)
+	case notification.OverrideRequest:
+		c := newCard(textBlock(escapeMD(t.Summary())))
+		c.Actions = append(c.Actions, openURL("Accept or decline", cfg.CallbackURL("/schedules/"+t.ScheduleID+"/overrides")))
+		return c
+	}
+
+	return nil
+}
@@ -0,0 +1,11 @@
+package msteams
+
+import "net/http"
+
+// Config contains values used for the Microsoft Teams notification sender.
+type Config struct {
+	// BaseURL, if set, is used instead of the Microsoft login and Bot Framework metadata endpoints (e.g., for mockteams).
+	BaseURL string
+
+	Client *http.Client
+}
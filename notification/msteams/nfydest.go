@@ -0,0 +1,203 @@
+package msteams
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/notification/nfydest"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+const (
+	DestTypeMSTeamsChannel = "builtin-msteams-channel"
+	DestTypeMSTeamsWebhook = "builtin-msteams-webhook"
+
+	FieldMSTeamsChannelID  = "msteams_channel_id"
+	FieldMSTeamsWebhookURL = "msteams_webhook_url"
+
+	FallbackIconURL = "builtin://msteams"
+)
+
+// channelIDRx matches a Teams channel (thread) ID, e.g., `19:abc123@thread.tacv2`.
+var channelIDRx = regexp.MustCompile(`^19:[A-Za-z0-9_.-]+@thread\.[a-z0-9]+$`)
+
+func NewChannelDest(id string) gadb.DestV1 {
+	return gadb.NewDestV1(DestTypeMSTeamsChannel, FieldMSTeamsChannelID, id)
+}
+
+func NewWebhookDest(url string) gadb.DestV1 {
+	return gadb.NewDestV1(DestTypeMSTeamsWebhook, FieldMSTeamsWebhookURL, url)
+}
+
+// parseChannelID will return the channel ID from a channel ID or a channel link
+// (e.g., `https://teams.microsoft.com/l/channel/19%3a...%40thread.tacv2/General?groupId=...`).
+func parseChannelID(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if channelIDRx.MatchString(value) {
+		return value, nil
+	}
+
+	u, err := url.Parse(value)
+	if err == nil && u.IsAbs() {
+		parts := strings.Split(u.EscapedPath(), "/")
+		for i, p := range parts {
+			if p != "channel" || i+1 >= len(parts) {
+				continue
+			}
+			id, err := url.PathUnescape(parts[i+1])
+			if err == nil && channelIDRx.MatchString(id) {
+				return id, nil
+			}
+		}
+	}
+
+	return "", validation.NewFieldError(FieldMSTeamsChannelID, "must be a channel ID (19:...@thread.tacv2) or a link to the channel (Get link to channel)")
+}
+
+var (
+	_ nfydest.Provider       = (*Sender)(nil)
+	_ nfydest.DestNormalizer = (*Sender)(nil)
+)
+
+func (s *Sender) ID() string { return DestTypeMSTeamsChannel }
+func (s *Sender) TypeInfo(ctx context.Context) (*nfydest.TypeInfo, error) {
+	cfg := config.FromContext(ctx)
+	return &nfydest.TypeInfo{
+		Type:                       DestTypeMSTeamsChannel,
+		Name:                       "Microsoft Teams Channel",
+		Enabled:                    cfg.MSTeams.Enable && cfg.MSTeams.AppID != "",
+		SupportsAlertNotifications: true,
+		SupportsStatusUpdates:      true,
+		StatusUpdatesRequired:      true,
+		SupportsOnCallNotify:       true,
+		SupportsSignals:            true,
+		RequiredFields: []nfydest.FieldConfig{{
+			FieldID:            FieldMSTeamsChannelID,
+			Label:              "Teams Channel",
+			PlaceholderText:    "https://teams.microsoft.com/l/channel/...",
+			InputType:          "text",
+			Hint:               "Paste a link to the channel (Get link to channel). The GoAlert bot must be added to the team.",
+			SupportsValidation: true,
+		}},
+		DynamicParams: []nfydest.DynamicParamConfig{{
+			ParamID: "message",
+			Label:   "Message",
+			Hint:    "The text of the message to send.",
+		}},
+	}, nil
+}
+
+func (s *Sender) ValidateField(ctx context.Context, fieldID, value string) error {
+	switch fieldID {
+	case FieldMSTeamsChannelID:
+		_, err := parseChannelID(value)
+		return err
+	}
+
+	return validation.NewGenericError("unknown field ID")
+}
+
+// NormalizeDest will replace channel links with the channel ID, so the same channel is always stored the same way.
+func (s *Sender) NormalizeDest(ctx context.Context, dest gadb.DestV1) (gadb.DestV1, error) {
+	if dest.Type != DestTypeMSTeamsChannel {
+		return dest, nil
+	}
+
+	id, err := parseChannelID(dest.Arg(FieldMSTeamsChannelID))
+	if err != nil {
+		return dest, err
+	}
+	dest.SetArg(FieldMSTeamsChannelID, id)
+
+	return dest, nil
+}
+
+func (s *Sender) DisplayInfo(ctx context.Context, args map[string]string) (*nfydest.DisplayInfo, error) {
+	if args == nil {
+		args = make(map[string]string)
+	}
+
+	return &nfydest.DisplayInfo{
+		IconURL:     FallbackIconURL,
+		IconAltText: "Microsoft Teams",
+		Text:        args[FieldMSTeamsChannelID],
+	}, nil
+}
+
+// WebhookSender wraps a Sender with incoming webhook (Workflows) functionality.
+type WebhookSender struct {
+	*Sender
+}
+
+// WebhookSender returns a new WebhookSender wrapping the given Sender.
+func (s *Sender) WebhookSender() *WebhookSender {
+	return &WebhookSender{s}
+}
+
+var _ nfydest.Provider = (*WebhookSender)(nil)
+
+func (s *WebhookSender) ID() string { return DestTypeMSTeamsWebhook }
+func (s *WebhookSender) TypeInfo(ctx context.Context) (*nfydest.TypeInfo, error) {
+	cfg := config.FromContext(ctx)
+	return &nfydest.TypeInfo{
+		Type:                       DestTypeMSTeamsWebhook,
+		Name:                       "Microsoft Teams Webhook",
+		Enabled:                    cfg.MSTeams.Enable,
+		SupportsAlertNotifications: true,
+		SupportsStatusUpdates:      true,
+		SupportsOnCallNotify:       true,
+		SupportsSignals:            true,
+		RequiredFields: []nfydest.FieldConfig{{
+			FieldID:            FieldMSTeamsWebhookURL,
+			Label:              "Webhook URL",
+			PlaceholderText:    "https://example.webhook.office.com/...",
+			InputType:          "url",
+			Hint:               "An incoming webhook URL, or the URL of a Workflows 'When a Teams webhook request is received' trigger.",
+			SupportsValidation: true,
+		}},
+		DynamicParams: []nfydest.DynamicParamConfig{{
+			ParamID: "message",
+			Label:   "Message",
+			Hint:    "The text of the message to send.",
+		}},
+	}, nil
+}
+
+func (s *WebhookSender) ValidateField(ctx context.Context, fieldID, value string) error {
+	cfg := config.FromContext(ctx)
+	switch fieldID {
+	case FieldMSTeamsWebhookURL:
+		err := validate.AbsoluteURL(FieldMSTeamsWebhookURL, value)
+		if err != nil {
+			return err
+		}
+		if !cfg.ValidWebhookURL(value) {
+			return validation.NewGenericError("url is not allowed by administator")
+		}
+
+		return nil
+	}
+
+	return validation.NewGenericError("unknown field ID")
+}
+
+func (s *WebhookSender) DisplayInfo(ctx context.Context, args map[string]string) (*nfydest.DisplayInfo, error) {
+	if args == nil {
+		args = make(map[string]string)
+	}
+
+	u, err := url.Parse(args[FieldMSTeamsWebhookURL])
+	if err != nil {
+		return nil, validation.WrapError(err)
+	}
+	return &nfydest.DisplayInfo{
+		IconURL:     FallbackIconURL,
+		IconAltText: "Microsoft Teams",
+		Text:        u.Hostname(),
+	}, nil
+}
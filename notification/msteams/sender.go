@@ -0,0 +1,260 @@
+package msteams
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/nfydest"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	loginBaseURL = "https://login.microsoftonline.com"
+	botScope     = "https://api.botframework.com/.default"
+)
+
+// Sender sends notifications to Microsoft Teams channels (via a bot) and incoming webhooks as Adaptive Cards.
+type Sender struct {
+	cfg Config
+
+	tokMx  sync.Mutex
+	tokKey string
+	tokSrc oauth2.TokenSource
+
+	keyMx     sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	keysFetch time.Time
+
+	recv notification.Receiver
+}
+
+var (
+	_ nfydest.MessageSender       = &Sender{}
+	_ notification.ReceiverSetter = &Sender{}
+)
+
+func NewSender(ctx context.Context, cfg Config) (*Sender, error) {
+	if cfg.Client == nil {
+		return nil, errors.New("http client is required")
+	}
+
+	return &Sender{cfg: cfg}, nil
+}
+
+func (s *Sender) SetReceiver(r notification.Receiver) {
+	s.recv = r
+}
+
+// botError is returned when a Bot Framework request fails.
+type botError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *botError) Error() string {
+	return fmt.Sprintf("bot framework: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+func (s *Sender) loginURL(tenantID string) string {
+	base := loginBaseURL
+	if s.cfg.BaseURL != "" {
+		base = s.cfg.BaseURL
+	}
+
+	return strings.TrimSuffix(base, "/") + "/" + url.PathEscape(tenantID) + "/oauth2/v2.0/token"
+}
+
+// token returns an access token for the Bot Framework API, tokens are cached until they expire.
+func (s *Sender) token(ctx context.Context) (*oauth2.Token, error) {
+	cfg := config.FromContext(ctx)
+	key := strings.Join([]string{cfg.MSTeams.TenantID, cfg.MSTeams.AppID, cfg.MSTeams.AppSecret}, "\n")
+
+	s.tokMx.Lock()
+	defer s.tokMx.Unlock()
+	if s.tokKey != key {
+		cc := clientcredentials.Config{
+			ClientID:     cfg.MSTeams.AppID,
+			ClientSecret: cfg.MSTeams.AppSecret,
+			TokenURL:     s.loginURL(cfg.MSTeams.TenantID),
+			Scopes:       []string{botScope},
+			AuthStyle:    oauth2.AuthStyleInParams,
+		}
+
+		// The token source outlives the request, so it can't use the request context.
+		s.tokSrc = cc.TokenSource(context.WithValue(context.Background(), oauth2.HTTPClient, s.cfg.Client))
+		s.tokKey = key
+	}
+
+	tok, err := s.tokSrc.Token()
+	if err != nil {
+		return nil, fmt.Errorf("get bot access token: %w", err)
+	}
+
+	return tok, nil
+}
+
+// botRequest will make an authenticated request to the Bot Framework API and decode the result, if not nil.
+func (s *Sender) botRequest(ctx context.Context, serviceURL, method, path string, body, result any) error {
+	tok, err := s.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(serviceURL, "/")+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	tok.SetAuthHeader(req)
+
+	resp, err := s.cfg.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &botError{StatusCode: resp.StatusCode, Body: string(msg)}
+	}
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func activitiesPath(conversationID string) string {
+	return "/v3/conversations/" + url.PathEscape(conversationID) + "/activities"
+}
+
+// postActivity will send an activity to a conversation and return the new activity ID.
+func (s *Sender) postActivity(ctx context.Context, serviceURL, conversationID string, act activity) (string, error) {
+	var res struct {
+		ID string `json:"id"`
+	}
+	err := s.botRequest(ctx, serviceURL, http.MethodPost, activitiesPath(conversationID), act, &res)
+	if err != nil {
+		return "", err
+	}
+
+	return res.ID, nil
+}
+
+// updateActivity will replace a previously sent activity.
+func (s *Sender) updateActivity(ctx context.Context, serviceURL, conversationID, activityID string, act activity) error {
+	act.ID = activityID
+	return s.botRequest(ctx, serviceURL, http.MethodPut, activitiesPath(conversationID)+"/"+url.PathEscape(activityID), act, nil)
+}
+
+// threadID returns the conversation ID of the reply thread of a channel message.
+func threadID(channelID, activityID string) string {
+	return channelID + ";messageid=" + activityID
+}
+
+func (s *Sender) SendMessage(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	switch msg.DestType() {
+	case DestTypeMSTeamsChannel:
+		return s.sendChannel(ctx, msg)
+	case DestTypeMSTeamsWebhook:
+		return s.sendWebhook(ctx, msg)
+	}
+
+	return nil, fmt.Errorf("unsupported destination type: %s", msg.DestType())
+}
+
+func (s *Sender) sendChannel(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	if cfg.MSTeams.AppID == "" {
+		return &notification.SentMessage{State: notification.StateFailedPerm, StateDetails: "Teams bot is not configured"}, nil
+	}
+
+	card := s.messageCard(ctx, msg, cfg.MSTeams.InteractiveMessages)
+	if card == nil {
+		return nil, fmt.Errorf("unsupported message type: %T", msg)
+	}
+
+	conversationID := msg.DestArg(FieldMSTeamsChannelID)
+	switch t := msg.(type) {
+	case notification.Alert:
+		if t.OriginalStatus != nil {
+			// Reply in thread if we already sent a message for this alert.
+			conversationID = threadID(conversationID, t.OriginalStatus.ProviderMessageID.ExternalID)
+		}
+	case notification.AlertStatus:
+		activityID := t.OriginalStatus.ProviderMessageID.ExternalID
+		err := s.updateActivity(ctx, cfg.MSTeams.ServiceURL, threadID(conversationID, activityID), activityID, cardMessage(card))
+		if err != nil {
+			return nil, fmt.Errorf("update activity: %w", err)
+		}
+
+		return &notification.SentMessage{State: notification.StateDelivered}, nil
+	}
+
+	id, err := s.postActivity(ctx, cfg.MSTeams.ServiceURL, conversationID, cardMessage(card))
+	if err != nil {
+		return nil, fmt.Errorf("post activity: %w", err)
+	}
+
+	return &notification.SentMessage{
+		ExternalID: id,
+		State:      notification.StateDelivered,
+	}, nil
+}
+
+func (s *Sender) sendWebhook(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	card := s.messageCard(ctx, msg, false)
+	if card == nil {
+		return nil, fmt.Errorf("unsupported message type: %T", msg)
+	}
+
+	data, err := json.Marshal(cardMessage(card))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, msg.DestArg(FieldMSTeamsWebhookURL), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.cfg.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return &notification.SentMessage{State: notification.StateFailedTemp, StateDetails: resp.Status}, nil
+	case resp.StatusCode >= 400:
+		return &notification.SentMessage{State: notification.StateFailedPerm, StateDetails: resp.Status}, nil
+	}
+
+	return &notification.SentMessage{State: notification.StateSent}, nil
+}
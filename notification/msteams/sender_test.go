@@ -0,0 +1,175 @@
+package msteams
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/auth"
+	"github.com/target/goalert/auth/authlink"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/devtools/mockteams"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/nfymsg"
+)
+
+type receiver struct {
+	notification.Receiver
+	unknown bool
+
+	providerID, subjectID, callbackID string
+	result                            notification.Result
+}
+
+func (r *receiver) ReceiveSubject(ctx context.Context, providerID, subjectID, callbackID string, result notification.Result) error {
+	if r.unknown {
+		return &notification.UnknownSubjectError{AlertID: 1}
+	}
+
+	r.providerID, r.subjectID, r.callbackID, r.result = providerID, subjectID, callbackID, result
+	return nil
+}
+
+func (r *receiver) AuthLinkURL(ctx context.Context, providerID, subjectID string, meta authlink.Metadata) (string, error) {
+	return "http://goalert.example.com/link", nil
+}
+
+// setup returns a Sender connected to a mock Teams server, with its messaging endpoint served.
+func setup(t *testing.T) (context.Context, *Sender, *receiver, *mockteams.Server) {
+	t.Helper()
+
+	mock := mockteams.NewServer()
+	mockSrv := httptest.NewServer(mock)
+	t.Cleanup(mockSrv.Close)
+	mock.SetURLPrefix(mockSrv.URL)
+	app := mock.App()
+
+	var cfg config.Config
+	cfg.General.PublicURL = "http://goalert.example.com"
+	cfg.MSTeams.Enable = true
+	cfg.MSTeams.AppID = app.AppID
+	cfg.MSTeams.AppSecret = app.AppSecret
+	cfg.MSTeams.TenantID = app.TenantID
+	cfg.MSTeams.ServiceURL = mock.ServiceURL()
+	cfg.MSTeams.InteractiveMessages = true
+	ctx := cfg.Context(context.Background())
+
+	s, err := NewSender(ctx, Config{BaseURL: mockSrv.URL, Client: http.DefaultClient})
+	require.NoError(t, err)
+	var recv receiver
+	s.SetReceiver(&recv)
+
+	// activities are sent through the auth middleware, as they are by the app
+	var authH auth.Handler
+	goalertSrv := httptest.NewServer(authH.WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.ServeMessages(w, req.WithContext(cfg.Context(req.Context())))
+	})))
+	t.Cleanup(goalertSrv.Close)
+	mock.SetApp(mockteams.AppInfo{MessagingEndpoint: goalertSrv.URL + "/api/v2/msteams/messages"})
+
+	return ctx, s, &recv, mock
+}
+
+func TestSender_Channel(t *testing.T) {
+	ctx, s, recv, mock := setup(t)
+	channelID := mockteams.NewChannelID()
+
+	dest, err := s.NormalizeDest(ctx, NewChannelDest("https://teams.microsoft.com/l/channel/"+url.PathEscape(channelID)+"/General?groupId=123"))
+	require.NoError(t, err)
+	assert.Equal(t, NewChannelDest(channelID), dest)
+
+	const callbackID = "6e5c5a29-9d4c-4b8c-8d2d-6b1b6f0a5a8e"
+	res, err := s.SendMessage(ctx, notification.Alert{Base: nfymsg.Base{ID: callbackID, Dest: dest}, AlertID: 1, Summary: "disk full", ServiceName: "db"})
+	require.NoError(t, err)
+	assert.Equal(t, notification.StateDelivered, res.State)
+
+	msgs := mock.Messages(channelID)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, res.ExternalID, msgs[0].ID)
+	assert.Contains(t, msgs[0].Card.Text, "Alert #1: disk full")
+	assert.Contains(t, msgs[0].Card.Text, "Service: db")
+
+	// unknown users are sent a link in a 1:1 chat
+	user := mockteams.NewUser("Bob")
+	recv.unknown = true
+	text, err := mock.PerformAction(msgs[0], verbAck, user)
+	require.NoError(t, err)
+	assert.Contains(t, text, "a link was sent to you")
+	chat := mock.Messages(mockteams.ChatID(user.ID))
+	require.Len(t, chat, 1)
+	require.Len(t, chat[0].Card.Actions, 1)
+	assert.Equal(t, "http://goalert.example.com/link", chat[0].Card.Actions[0].URL)
+
+	recv.unknown = false
+	text, err = mock.PerformAction(msgs[0], verbAck, user)
+	require.NoError(t, err)
+	assert.Equal(t, "Alert acknowledged.", text)
+	assert.Equal(t, ProviderID(mock.App().TenantID), recv.providerID)
+	assert.Equal(t, user.AADObjectID, recv.subjectID)
+	assert.Equal(t, callbackID, recv.callbackID)
+	assert.Equal(t, notification.ResultAcknowledge, recv.result)
+
+	orig := nfymsg.SendResult{ID: callbackID, ProviderMessageID: gadb.ProviderMessageID{ExternalID: res.ExternalID}}
+	res, err = s.SendMessage(ctx, notification.AlertStatus{
+		Base:           nfymsg.Base{Dest: dest},
+		AlertID:        1,
+		Summary:        "disk full",
+		LogEntry:       "Acknowledged by Bob",
+		OriginalStatus: orig,
+		NewAlertState:  notification.AlertStateAcknowledged,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, res.ExternalID, "updates should not have a new external ID")
+
+	msgs = mock.Messages(channelID)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, 1, msgs[0].Updates)
+	assert.Contains(t, msgs[0].Card.Text, "Acknowledged by Bob")
+	_, err = mock.PerformAction(msgs[0], verbAck, user)
+	assert.Error(t, err, "acknowledge should not be available")
+	text, err = mock.PerformAction(msgs[0], verbClose, user)
+	require.NoError(t, err)
+	assert.Equal(t, "Alert closed.", text)
+
+	// re-notifications are replies
+	_, err = s.SendMessage(ctx, notification.Alert{Base: nfymsg.Base{ID: callbackID, Dest: dest}, AlertID: 1, Summary: "disk full", OriginalStatus: &orig})
+	require.NoError(t, err)
+	msgs = mock.Messages(channelID)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, msgs[0].ID, msgs[1].ThreadID)
+}
+
+func TestSender_Webhook(t *testing.T) {
+	ctx, s, _, mock := setup(t)
+
+	res, err := s.SendMessage(ctx, notification.ScheduleOnCallUsers{
+		Base:         nfymsg.Base{Dest: NewWebhookDest(mock.WebhookURL("test"))},
+		ScheduleName: "Primary",
+		ScheduleURL:  "http://goalert.example.com/schedules/1",
+		Users: []notification.User{
+			{Name: "Joe", URL: "http://goalert.example.com/users/2"},
+			{Name: "Bob", URL: "http://goalert.example.com/users/1"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, notification.StateSent, res.State)
+
+	msgs := mock.Messages("webhook:test")
+	require.Len(t, msgs, 1)
+	assert.Equal(t, "[Bob](http://goalert.example.com/users/1) and [Joe](http://goalert.example.com/users/2) are on-call for [Primary](http://goalert.example.com/schedules/1)", msgs[0].Card.Text)
+}
+
+func TestSender_ServeMessages_Unauthorized(t *testing.T) {
+	ctx, s, _, _ := setup(t)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/v2/msteams/messages", nil).WithContext(ctx)
+	req.Header.Set("Authorization", "Bearer invalid")
+	s.ServeMessages(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,167 @@
+package msteams
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/auth/authlink"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+)
+
+// ProviderID returns the auth subject provider ID for Teams users of a tenant.
+func ProviderID(tenantID string) string { return "msteams:" + tenantID }
+
+func writeInvokeResponse(ctx context.Context, w http.ResponseWriter, res invokeResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(res)
+	if err != nil {
+		log.Log(ctx, err)
+	}
+}
+
+// ServeMessages handles activities sent to the bot's messaging endpoint. Only Adaptive Card actions
+// (i.e., the Acknowledge and Close buttons) are processed, other activities are ignored.
+func (s *Sender) ServeMessages(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+
+	if !cfg.MSTeams.Enable || cfg.MSTeams.AppID == "" {
+		http.Error(w, "not enabled", http.StatusNotFound)
+		return
+	}
+
+	serviceURL, err := s.verifyRequest(req)
+	if err != nil {
+		log.Debug(ctx, fmt.Errorf("verify teams request: %w", err))
+		errutil.HTTPError(ctx, w, permission.Unauthorized())
+		return
+	}
+
+	var act activity
+	err = json.NewDecoder(io.LimitReader(req.Body, 1<<20)).Decode(&act)
+	if errutil.HTTPError(ctx, w, validation.WrapError(err)) {
+		return
+	}
+	if act.ServiceURL != serviceURL {
+		errutil.HTTPError(ctx, w, permission.Unauthorized())
+		return
+	}
+
+	if act.Type != "invoke" || act.Name != "adaptiveCard/action" {
+		// e.g., the bot being added to a team
+		return
+	}
+
+	if !cfg.MSTeams.InteractiveMessages {
+		writeInvokeResponse(ctx, w, invokeMessage("Interactive messages are disabled."))
+		return
+	}
+
+	var value struct {
+		Action struct {
+			Verb string
+			Data ActionData
+		}
+	}
+	err = json.Unmarshal(act.Value, &value)
+	if errutil.HTTPError(ctx, w, validation.WrapError(err)) {
+		return
+	}
+
+	var res notification.Result
+	var done string
+	switch value.Action.Verb {
+	case verbAck:
+		res = notification.ResultAcknowledge
+		done = "Alert acknowledged."
+	case verbClose:
+		res = notification.ResultResolve
+		done = "Alert closed."
+	default:
+		errutil.HTTPError(ctx, w, validation.NewFieldErrorf("verb", "unknown action verb '%s'", value.Action.Verb))
+		return
+	}
+
+	tenantID := act.tenantID()
+	if act.From == nil || act.From.AADObjectID == "" || tenantID == "" {
+		log.Log(ctx, errors.New("teams activity missing required data"))
+		writeInvokeResponse(ctx, w, invokeMessage("Unable to identify your Teams account."))
+		return
+	}
+
+	var e *notification.UnknownSubjectError
+	err = s.recv.ReceiveSubject(ctx, ProviderID(tenantID), act.From.AADObjectID, value.Action.Data.CallbackID, res)
+	if errors.As(err, &e) {
+		writeInvokeResponse(ctx, w, invokeMessage(s.linkAccount(ctx, act, res, e.AlertID)))
+		return
+	}
+	if alert.IsAlreadyAcknowledged(err) || alert.IsAlreadyClosed(err) || alert.IsAckPending(err) {
+		// ignore errors from duplicate requests, or acknowledgements that are recorded on the alert
+		writeInvokeResponse(ctx, w, invokeMessage(done))
+		return
+	}
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	writeInvokeResponse(ctx, w, invokeMessage(done))
+}
+
+// linkAccount will send a 'Link Account' button to the user that performed an action in a 1:1 chat with the bot,
+// and return the message to display to them.
+func (s *Sender) linkAccount(ctx context.Context, act activity, res notification.Result, alertID int) string {
+	cfg := config.FromContext(ctx)
+	const notLinked = "Your Teams account isn't currently linked to %s, please try again later."
+
+	tenantID := act.tenantID()
+	if act.Recipient == nil {
+		// missing data, don't allow linking
+		log.Log(ctx, errors.New("teams activity missing recipient"))
+		return fmt.Sprintf(notLinked, cfg.ApplicationName())
+	}
+
+	linkURL, err := s.recv.AuthLinkURL(ctx, ProviderID(tenantID), act.From.AADObjectID, authlink.Metadata{
+		UserDetails: fmt.Sprintf("Microsoft Teams user %s", act.From.Name),
+		AlertID:     alertID,
+		AlertAction: res.String(),
+	})
+	if err != nil {
+		log.Log(ctx, err)
+		return fmt.Sprintf(notLinked, cfg.ApplicationName())
+	}
+
+	// The link must only be visible to the user, so it's sent in a 1:1 chat rather than the channel.
+	var conv struct {
+		ID string `json:"id"`
+	}
+	err = s.botRequest(ctx, act.ServiceURL, http.MethodPost, "/v3/conversations", map[string]any{
+		"bot":         act.Recipient,
+		"members":     []channelAccount{{ID: act.From.ID}},
+		"channelData": channelData{Tenant: tenantInfo{ID: tenantID}},
+		"tenantId":    tenantID,
+	}, &conv)
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("create teams conversation: %w", err))
+		return fmt.Sprintf(notLinked, cfg.ApplicationName())
+	}
+
+	card := newCard(textBlock(fmt.Sprintf("Please link your Teams account with %s.", cfg.ApplicationName())))
+	card.Actions = append(card.Actions, openURL("Link Account", linkURL))
+	_, err = s.postActivity(ctx, act.ServiceURL, conv.ID, cardMessage(card))
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("send link account message: %w", err))
+		return fmt.Sprintf(notLinked, cfg.ApplicationName())
+	}
+
+	return fmt.Sprintf("Please link your Teams account with %s, a link was sent to you in a chat.", cfg.ApplicationName())
+}
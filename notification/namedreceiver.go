@@ -2,6 +2,7 @@ package notification
 
 import (
 	"context"
+	"time"
 
 	"github.com/target/goalert/auth/authlink"
 	"github.com/target/goalert/gadb"
@@ -56,3 +57,15 @@ func (nr *namedReceiver) ReceiveSubject(ctx context.Context, providerID, subject
 	metricRecvTotal.WithLabelValues(nr.destType, result.String())
 	return nr.r.ReceiveSubject(ctx, providerID, subjectID, callbackID, result)
 }
+
+// ReceiveSnooze implements the Receiver interface by calling the underlying Receiver.ReceiveSnooze method.
+func (nr *namedReceiver) ReceiveSnooze(ctx context.Context, callbackID string, dur time.Duration) error {
+	metricRecvTotal.WithLabelValues(nr.destType, ResultSnooze.String())
+	return nr.r.ReceiveSnooze(ctx, callbackID, dur)
+}
+
+// ReceiveSubjectSnooze implements the Receiver interface by calling the underlying Receiver.ReceiveSubjectSnooze method.
+func (nr *namedReceiver) ReceiveSubjectSnooze(ctx context.Context, providerID, subjectID, callbackID string, dur time.Duration) error {
+	metricRecvTotal.WithLabelValues(nr.destType, ResultSnooze.String())
+	return nr.r.ReceiveSubjectSnooze(ctx, providerID, subjectID, callbackID, dur)
+}
@@ -48,15 +48,19 @@ func (r *Registry) SendMessage(ctx context.Context, msg nfymsg.Message) (*nfymsg
 		if !info.SupportsUserVerification {
 			return nil, ErrUnsupported
 		}
-	case nfymsg.Test:
+	case nfymsg.Test, nfymsg.OverrideRequest:
 	case nfymsg.SignalMessage:
 		if !info.SupportsSignals {
 			return nil, ErrUnsupported
 		}
-	case nfymsg.ScheduleOnCallUsers:
+	case nfymsg.ScheduleOnCallUsers, nfymsg.ScheduleCoverageGap:
 		if !info.SupportsOnCallNotify {
 			return nil, ErrUnsupported
 		}
+	case nfymsg.QuietHoursDigest:
+		if !info.SupportsStatusUpdates && !info.SupportsOnCallNotify {
+			return nil, ErrUnsupported
+		}
 	default:
 		return nil, ErrUnsupported
 	}
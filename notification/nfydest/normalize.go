@@ -0,0 +1,33 @@
+package nfydest
+
+import (
+	"context"
+	"maps"
+
+	"github.com/target/goalert/gadb"
+)
+
+// DestNormalizer is implemented by providers that need to transform destination args before they are stored
+// (e.g., to encrypt secrets).
+type DestNormalizer interface {
+	// NormalizeDest will return the destination as it should be stored. It must be idempotent, as already
+	// normalized destinations are passed to it when they are updated.
+	NormalizeDest(ctx context.Context, dest gadb.DestV1) (gadb.DestV1, error)
+}
+
+// NormalizeDest will return the destination as it should be stored. The destination should be validated first.
+func (r *Registry) NormalizeDest(ctx context.Context, dest gadb.DestV1) (gadb.DestV1, error) {
+	p := r.Provider(dest.Type)
+	if p == nil {
+		return dest, ErrUnknownType
+	}
+
+	n, ok := p.(DestNormalizer)
+	if !ok {
+		return dest, nil
+	}
+
+	// copy args so the caller's map is never modified
+	dest.Args = maps.Clone(dest.Args)
+	return n.NormalizeDest(ctx, dest)
+}
@@ -11,6 +11,9 @@ type Alert struct {
 	ServiceName string
 	Meta        map[string]string
 
+	// Priority of the alert, 1 (most urgent) through 5, or 0 if unset.
+	Priority int
+
 	// OriginalStatus is the status of the first Alert notification to this Dest for this AlertID.
 	OriginalStatus *SendResult
 }
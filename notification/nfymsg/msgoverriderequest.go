@@ -0,0 +1,52 @@
+package nfymsg
+
+import (
+	"fmt"
+	"time"
+)
+
+// OverrideRequest notifies a user that another user has asked them to accept a schedule override,
+// such as covering a shift.
+type OverrideRequest struct {
+	Base
+
+	RequestID     string
+	ScheduleID    string
+	ScheduleName  string
+	RequesterName string
+
+	// AddUserName and RemoveUserName are the names of the users that would be added or removed, if any.
+	AddUserName    string
+	RemoveUserName string
+
+	// RecipientIsAdded is true if the recipient would be added to the schedule, and false if they would be removed.
+	RecipientIsAdded bool
+
+	// RequesterIsOther is true if the requester is the other user affected by the override (e.g., asking to swap their own shift).
+	RequesterIsOther bool
+
+	// Start and End are in the time zone of the schedule.
+	Start time.Time
+	End   time.Time
+}
+
+// Summary returns a short, human-readable description of the request.
+func (m OverrideRequest) Summary() string {
+	const timeFmt = "Mon Jan 2 3:04PM MST"
+	window := fmt.Sprintf("from %s to %s", m.Start.Format(timeFmt), m.End.Format(timeFmt))
+
+	switch {
+	case m.RecipientIsAdded && m.RemoveUserName == "":
+		return fmt.Sprintf("%s asked you to be on call for %s %s.", m.RequesterName, m.ScheduleName, window)
+	case m.RecipientIsAdded && m.RequesterIsOther:
+		return fmt.Sprintf("%s asked you to cover their shift on %s %s.", m.RequesterName, m.ScheduleName, window)
+	case m.RecipientIsAdded:
+		return fmt.Sprintf("%s asked you to cover for %s on %s %s.", m.RequesterName, m.RemoveUserName, m.ScheduleName, window)
+	case m.AddUserName == "":
+		return fmt.Sprintf("%s asked to remove you from %s %s.", m.RequesterName, m.ScheduleName, window)
+	case m.RequesterIsOther:
+		return fmt.Sprintf("%s asked to cover your shift on %s %s.", m.RequesterName, m.ScheduleName, window)
+	}
+
+	return fmt.Sprintf("%s asked for %s to cover your shift on %s %s.", m.RequesterName, m.AddUserName, m.ScheduleName, window)
+}
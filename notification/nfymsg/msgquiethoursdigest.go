@@ -0,0 +1,35 @@
+package nfymsg
+
+import (
+	"fmt"
+	"time"
+)
+
+// DigestItem is a single notification that was held during quiet hours.
+type DigestItem struct {
+	// Time is when the notification was originally created.
+	Time time.Time
+
+	// Summary is a short, human-readable description of the notification.
+	Summary string
+
+	// URL links to the alert or schedule the notification is about.
+	URL string
+}
+
+// QuietHoursDigest is a Message containing the non-urgent notifications (status updates and on-call
+// notifications) that were held during quiet hours.
+type QuietHoursDigest struct {
+	Base
+
+	Items []DigestItem
+}
+
+// Summary returns a short, human-readable description of the digest.
+func (m QuietHoursDigest) Summary() string {
+	if len(m.Items) == 1 {
+		return "1 notification was held during quiet hours."
+	}
+
+	return fmt.Sprintf("%d notifications were held during quiet hours.", len(m.Items))
+}
@@ -0,0 +1,26 @@
+package nfymsg
+
+import (
+	"fmt"
+	"time"
+)
+
+// ScheduleCoverageGap is a Message that indicates no one will be on call for a
+// Schedule for a period of time.
+type ScheduleCoverageGap struct {
+	Base
+
+	ScheduleID   string
+	ScheduleName string
+	ScheduleURL  string
+
+	// Start and End are in the time zone of the schedule.
+	Start time.Time
+	End   time.Time
+}
+
+// Summary returns a short, human-readable description of the gap.
+func (m ScheduleCoverageGap) Summary() string {
+	const timeFmt = "Mon Jan 2 3:04PM MST"
+	return fmt.Sprintf("No one is scheduled to be on call for %s from %s to %s.", m.ScheduleName, m.Start.Format(timeFmt), m.End.Format(timeFmt))
+}
@@ -2,6 +2,7 @@ package notification
 
 import (
 	"context"
+	"time"
 
 	"github.com/target/goalert/auth/authlink"
 	"github.com/target/goalert/gadb"
@@ -18,6 +19,12 @@ type Receiver interface {
 	// ReceiveSubject records a response to a previously sent message from a provider/subject (e.g. Slack user).
 	ReceiveSubject(ctx context.Context, providerID, subjectID, callbackID string, result Result) error
 
+	// ReceiveSnooze records a request to snooze the alert of a previously sent message for the given duration.
+	ReceiveSnooze(ctx context.Context, callbackID string, dur time.Duration) error
+
+	// ReceiveSubjectSnooze records a request to snooze the alert of a previously sent message from a provider/subject (e.g. Slack user).
+	ReceiveSubjectSnooze(ctx context.Context, providerID, subjectID, callbackID string, dur time.Duration) error
+
 	// AuthLinkURL will generate a URL to link a provider and subject to a GoAlert user.
 	AuthLinkURL(ctx context.Context, providerID, subjectID string, meta authlink.Metadata) (string, error)
 
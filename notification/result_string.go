@@ -11,11 +11,12 @@ func _() {
 	_ = x[ResultAcknowledge-0]
 	_ = x[ResultResolve-1]
 	_ = x[ResultEscalate-2]
+	_ = x[ResultSnooze-3]
 }
 
-const _Result_name = "ResultAcknowledgeResultResolveResultEscalate"
+const _Result_name = "ResultAcknowledgeResultResolveResultEscalateResultSnooze"
 
-var _Result_index = [...]uint8{0, 17, 30, 44}
+var _Result_index = [...]uint8{0, 17, 30, 44, 56}
 
 func (i Result) String() string {
 	idx := int(i) - 0
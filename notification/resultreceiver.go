@@ -2,6 +2,7 @@ package notification
 
 import (
 	"context"
+	"time"
 
 	"github.com/target/goalert/auth/authlink"
 	"github.com/target/goalert/gadb"
@@ -13,6 +14,8 @@ type ResultReceiver interface {
 
 	Receive(ctx context.Context, callbackID string, result Result) error
 	ReceiveSubject(ctx context.Context, providerID, subjectID, callbackID string, result Result) error
+	ReceiveSnooze(ctx context.Context, callbackID string, dur time.Duration) error
+	ReceiveSubjectSnooze(ctx context.Context, providerID, subjectID, callbackID string, dur time.Duration) error
 	AuthLinkURL(ctx context.Context, providerID, subjectID string, meta authlink.Metadata) (string, error)
 	Start(context.Context, gadb.DestV1) error
 	Stop(context.Context, gadb.DestV1) error
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -368,9 +369,53 @@ const (
 	alertResponseBlockID = "block_alert_response"
 	alertCloseActionID   = "action_alert_close"
 	alertAckActionID     = "action_alert_ack"
+	alertSnoozeActionID  = "action_alert_snooze"
 	linkActActionID      = "action_link_account"
 )
 
+// snoozeOptions are the durations offered by the Slack snooze menu.
+var snoozeOptions = []struct {
+	Label string
+	Dur   time.Duration
+}{
+	{"30 minutes", 30 * time.Minute},
+	{"1 hour", time.Hour},
+	{"4 hours", 4 * time.Hour},
+	{"24 hours", 24 * time.Hour},
+}
+
+// snoozeSelectElement returns a static select element offering snooze durations.
+//
+// Option values are encoded as `<minutes>:<callbackID>`.
+func snoozeSelectElement(callbackID string) *slack.SelectBlockElement {
+	opts := make([]*slack.OptionBlockObject, 0, len(snoozeOptions))
+	for _, o := range snoozeOptions {
+		opts = append(opts, slack.NewOptionBlockObject(
+			fmt.Sprintf("%d:%s", int(o.Dur/time.Minute), callbackID),
+			slack.NewTextBlockObject("plain_text", o.Label, false, false),
+			nil,
+		))
+	}
+
+	return slack.NewOptionsSelectBlockElement(slack.OptTypeStatic,
+		slack.NewTextBlockObject("plain_text", "Snooze", false, false),
+		alertSnoozeActionID, opts...)
+}
+
+// parseSnoozeValue parses a selected snooze option value into its duration and callback ID.
+func parseSnoozeValue(value string) (time.Duration, string, error) {
+	minStr, callbackID, ok := strings.Cut(value, ":")
+	if !ok || callbackID == "" {
+		return 0, "", validation.NewFieldError("value", "invalid snooze value")
+	}
+	mins, err := strconv.Atoi(minStr)
+	if err != nil || mins < 1 {
+		return 0, "", validation.NewFieldError("value", "invalid snooze duration")
+	}
+
+	return time.Duration(mins) * time.Minute, callbackID, nil
+}
+
 // alertMsgOption will return the slack.MsgOption for an alert-type message (e.g., notification or status update).
 func alertMsgOption(ctx context.Context, callbackID string, id int, summary, logEntry string, state notification.AlertState) slack.MsgOption {
 	blocks := []slack.Block{
@@ -387,6 +432,7 @@ func alertMsgOption(ctx context.Context, callbackID string, id int, summary, log
 			slack.NewDividerBlock(),
 			slack.NewActionBlock(alertResponseBlockID,
 				slack.NewButtonBlockElement(alertCloseActionID, callbackID, slack.NewTextBlockObject("plain_text", "Close", false, false)),
+				snoozeSelectElement(callbackID),
 			),
 		}
 	case notification.AlertStateUnacknowledged:
@@ -396,6 +442,7 @@ func alertMsgOption(ctx context.Context, callbackID string, id int, summary, log
 			slack.NewActionBlock(alertResponseBlockID,
 				slack.NewButtonBlockElement(alertAckActionID, callbackID, slack.NewTextBlockObject("plain_text", "Acknowledge", false, false)),
 				slack.NewButtonBlockElement(alertCloseActionID, callbackID, slack.NewTextBlockObject("plain_text", "Close", false, false)),
+				snoozeSelectElement(callbackID),
 			),
 		}
 	case notification.AlertStateClosed:
@@ -482,6 +529,14 @@ func (s *ChannelSender) SendMessage(ctx context.Context, msg notification.Messag
 		opts = append(opts, slack.MsgOptionText(t.Param("message"), false))
 	case notification.ScheduleOnCallUsers:
 		opts = append(opts, slack.MsgOptionText(s.onCallNotificationText(ctx, t), false))
+	case notification.ScheduleCoverageGap:
+		opts = append(opts, slack.MsgOptionText(coverageGapText(t), false))
+	case notification.QuietHoursDigest:
+		opts = append(opts, slack.MsgOptionText(digestText(t), false))
+	case notification.OverrideRequest:
+		opts = append(opts, slack.MsgOptionText(
+			fmt.Sprintf("%s\n\n<%s|Accept or decline>", slackutilsx.EscapeMessage(t.Summary()), cfg.CallbackURL("/schedules/"+t.ScheduleID+"/overrides")),
+			false))
 	default:
 		return nil, errors.Errorf("unsupported message type: %T", t)
 	}
@@ -555,3 +610,19 @@ func (s *ChannelSender) BotName(ctx context.Context) (string, error) {
 
 	return info.User, nil
 }
+
+// coverageGapText will return text intended to be sent to Slack representing a ScheduleCoverageGap notification.
+func coverageGapText(t notification.ScheduleCoverageGap) string {
+	return fmt.Sprintf("%s\n\n<%s>", slackutilsx.EscapeMessage(t.Summary()), t.ScheduleURL)
+}
+
+// digestText will return text intended to be sent to Slack representing a QuietHoursDigest notification.
+func digestText(t notification.QuietHoursDigest) string {
+	var b strings.Builder
+	b.WriteString(slackutilsx.EscapeMessage(t.Summary()))
+	for _, item := range t.Items {
+		fmt.Fprintf(&b, "\n• <%s|%s>", item.URL, slackutilsx.EscapeMessage(item.Summary))
+	}
+
+	return b.String()
+}
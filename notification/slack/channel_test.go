@@ -65,3 +65,24 @@ func TestChannelSender_LoadChannels(t *testing.T) {
 		{ID: "C5", Name: "#channel5", TeamID: "team_1"},
 	}, ch)
 }
+
+func TestParseSnoozeValue(t *testing.T) {
+	sel := snoozeSelectElement("cb-id")
+	require.Len(t, sel.Options, len(snoozeOptions))
+	for i, opt := range sel.Options {
+		dur, cb, err := parseSnoozeValue(opt.Value)
+		require.NoError(t, err)
+		assert.Equal(t, snoozeOptions[i].Dur, dur)
+		assert.Equal(t, "cb-id", cb)
+	}
+
+	dur, cb, err := parseSnoozeValue("90:some-callback")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, dur)
+	assert.Equal(t, "some-callback", cb)
+
+	for _, val := range []string{"", "90", "90:", "abc:cb", "0:cb", "-5:cb"} {
+		_, _, err = parseSnoozeValue(val)
+		assert.Errorf(t, err, "value %q", val)
+	}
+}
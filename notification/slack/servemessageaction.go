@@ -94,6 +94,10 @@ func (s *ChannelSender) ServeMessageAction(w http.ResponseWriter, req *http.Requ
 			ActionID string `json:"action_id"`
 			BlockID  string `json:"block_id"`
 			Value    string `json:"value"`
+
+			SelectedOption struct {
+				Value string `json:"value"`
+			} `json:"selected_option"`
 		}
 	}
 
@@ -114,7 +118,15 @@ func (s *ChannelSender) ServeMessageAction(w http.ResponseWriter, req *http.Requ
 	}
 
 	var res notification.Result
+	var snoozeDur time.Duration
+	callbackID := act.Value
 	switch act.ActionID {
+	case alertSnoozeActionID:
+		res = notification.ResultSnooze
+		snoozeDur, callbackID, err = parseSnoozeValue(act.SelectedOption.Value)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
 	case alertAckActionID:
 		res = notification.ResultAcknowledge
 	case alertCloseActionID:
@@ -141,7 +153,11 @@ func (s *ChannelSender) ServeMessageAction(w http.ResponseWriter, req *http.Requ
 	}
 
 	var e *notification.UnknownSubjectError
-	err = s.recv.ReceiveSubject(ctx, "slack:"+payload.Team.ID, payload.User.ID, act.Value, res)
+	if res == notification.ResultSnooze {
+		err = s.recv.ReceiveSubjectSnooze(ctx, "slack:"+payload.Team.ID, payload.User.ID, callbackID, snoozeDur)
+	} else {
+		err = s.recv.ReceiveSubject(ctx, "slack:"+payload.Team.ID, payload.User.ID, callbackID, res)
+	}
 
 	if errors.As(err, &e) {
 		var linkURL string
@@ -158,11 +174,15 @@ func (s *ChannelSender) ServeMessageAction(w http.ResponseWriter, req *http.Requ
 			if name == "" {
 				name = payload.User.Name
 			}
-			linkURL, err = s.recv.AuthLinkURL(ctx, "slack:"+teamID, payload.User.ID, authlink.Metadata{
+			meta := authlink.Metadata{
 				UserDetails: fmt.Sprintf("Slack user %s from %s.slack.com", name, payload.Team.Domain),
 				AlertID:     e.AlertID,
-				AlertAction: res.String(),
-			})
+			}
+			if res != notification.ResultSnooze {
+				// snooze isn't a supported follow-up action after linking
+				meta.AlertAction = res.String()
+			}
+			linkURL, err = s.recv.AuthLinkURL(ctx, "slack:"+teamID, payload.User.ID, meta)
 			if err != nil {
 				log.Log(ctx, err)
 			}
@@ -200,8 +220,8 @@ func (s *ChannelSender) ServeMessageAction(w http.ResponseWriter, req *http.Requ
 		})
 		return
 	}
-	if alert.IsAlreadyAcknowledged(err) || alert.IsAlreadyClosed(err) {
-		// ignore errors from duplicate requests
+	if alert.IsAlreadyAcknowledged(err) || alert.IsAlreadyClosed(err) || alert.IsAckPending(err) {
+		// ignore errors from duplicate requests, or acknowledgements that are recorded on the alert
 		return
 	}
 	if errutil.HTTPError(ctx, w, err) {
@@ -40,6 +40,20 @@ func (s *UserGroupSender) SendMessage(ctx context.Context, msg notification.Mess
 		return nil, errors.Errorf("unsupported destination type: %s", msg.DestType())
 	}
 
+	if gap, ok := msg.(notification.ScheduleCoverageGap); ok {
+		// nothing to update, just let the channel know
+		var ts string
+		err = s.withClient(ctx, func(c *slack.Client) error {
+			_, ts, err = c.PostMessageContext(ctx, gap.DestArg(FieldSlackChannelID), slack.MsgOptionText(coverageGapText(gap), false))
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("post message to channel '%s': %w", gap.DestArg(FieldSlackChannelID), err)
+		}
+
+		return &notification.SentMessage{State: notification.StateDelivered, ExternalID: ts}, nil
+	}
+
 	t, ok := msg.(notification.ScheduleOnCallUsers)
 	if !ok {
 		return nil, errors.Errorf("unsupported message type: %T", msg)
@@ -0,0 +1,82 @@
+package smpp
+
+import (
+	"context"
+
+	"github.com/nyaruka/phonenumbers"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/notification/nfydest"
+	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/validation"
+)
+
+const (
+	DestTypeSMPPSMS  = "builtin-smpp-sms"
+	FieldPhoneNumber = twilio.FieldPhoneNumber
+)
+
+// NewDest returns a new SMPP SMS destination for the given number.
+func NewDest(number string) gadb.DestV1 {
+	return gadb.NewDestV1(DestTypeSMPPSMS, FieldPhoneNumber, number)
+}
+
+var _ nfydest.Provider = (*Sender)(nil)
+
+func (s *Sender) ID() string { return DestTypeSMPPSMS }
+
+func (s *Sender) TypeInfo(ctx context.Context) (*nfydest.TypeInfo, error) {
+	cfg := config.FromContext(ctx)
+	return &nfydest.TypeInfo{
+		Type:                       DestTypeSMPPSMS,
+		Name:                       "Text Message (SMS Gateway)",
+		Enabled:                    cfg.SMPP.Enable,
+		UserDisclaimer:             cfg.General.NotificationDisclaimer,
+		SupportsAlertNotifications: true,
+		SupportsUserVerification:   true,
+		SupportsStatusUpdates:      true,
+		UserVerificationRequired:   true,
+		RequiredFields: []nfydest.FieldConfig{{
+			FieldID:            FieldPhoneNumber,
+			Label:              "Phone Number",
+			Hint:               "Include country code e.g. +1 (USA), +91 (India), +44 (UK)",
+			PlaceholderText:    "11235550123",
+			Prefix:             "+",
+			InputType:          "tel",
+			SupportsValidation: true,
+		}},
+	}, nil
+}
+
+func (s *Sender) ValidateField(ctx context.Context, fieldID, value string) error {
+	switch fieldID {
+	case FieldPhoneNumber:
+		n, err := phonenumbers.Parse(value, "")
+		if err != nil {
+			return validation.WrapError(err)
+		}
+		if !phonenumbers.IsValidNumber(n) {
+			return validation.NewGenericError("invalid phone number")
+		}
+		return nil
+	}
+
+	return validation.NewGenericError("unknown field ID")
+}
+
+func (s *Sender) DisplayInfo(ctx context.Context, args map[string]string) (*nfydest.DisplayInfo, error) {
+	if args == nil {
+		args = make(map[string]string)
+	}
+
+	n, err := phonenumbers.Parse(args[FieldPhoneNumber], "")
+	if err != nil {
+		return nil, validation.WrapError(err)
+	}
+
+	return &nfydest.DisplayInfo{
+		IconURL:     twilio.FallbackIconURLSMS,
+		IconAltText: "Text Message",
+		Text:        phonenumbers.Format(n, phonenumbers.INTERNATIONAL),
+	}, nil
+}
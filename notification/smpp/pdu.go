@@ -0,0 +1,430 @@
+package smpp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// CommandID identifies the operation of a PDU.
+type CommandID uint32
+
+// SMPP 3.4 command IDs used by GoAlert.
+const (
+	CmdGenericNack         CommandID = 0x80000000
+	CmdBindTransceiver     CommandID = 0x00000009
+	CmdBindTransceiverResp CommandID = 0x80000009
+	CmdSubmitSM            CommandID = 0x00000004
+	CmdSubmitSMResp        CommandID = 0x80000004
+	CmdDeliverSM           CommandID = 0x00000005
+	CmdDeliverSMResp       CommandID = 0x80000005
+	CmdUnbind              CommandID = 0x00000006
+	CmdUnbindResp          CommandID = 0x80000006
+	CmdEnquireLink         CommandID = 0x00000015
+	CmdEnquireLinkResp     CommandID = 0x80000015
+)
+
+// Resp returns the response command ID for a request.
+func (id CommandID) Resp() CommandID { return id | 0x80000000 }
+
+// IsResp returns true if the command ID is a response.
+func (id CommandID) IsResp() bool { return id&0x80000000 != 0 }
+
+func (id CommandID) String() string {
+	switch id {
+	case CmdGenericNack:
+		return "generic_nack"
+	case CmdBindTransceiver:
+		return "bind_transceiver"
+	case CmdBindTransceiverResp:
+		return "bind_transceiver_resp"
+	case CmdSubmitSM:
+		return "submit_sm"
+	case CmdSubmitSMResp:
+		return "submit_sm_resp"
+	case CmdDeliverSM:
+		return "deliver_sm"
+	case CmdDeliverSMResp:
+		return "deliver_sm_resp"
+	case CmdUnbind:
+		return "unbind"
+	case CmdUnbindResp:
+		return "unbind_resp"
+	case CmdEnquireLink:
+		return "enquire_link"
+	case CmdEnquireLinkResp:
+		return "enquire_link_resp"
+	}
+
+	return fmt.Sprintf("command_0x%08x", uint32(id))
+}
+
+// SMPP 3.4 command status codes used by GoAlert.
+const (
+	StatusOK            uint32 = 0x00
+	StatusInvalidMsgLen uint32 = 0x01
+	StatusInvalidCmdLen uint32 = 0x02
+	StatusInvalidCmdID  uint32 = 0x03
+	StatusInvalidBind   uint32 = 0x04
+	StatusAlreadyBound  uint32 = 0x05
+	StatusSystemError   uint32 = 0x08
+	StatusInvalidDest   uint32 = 0x0B
+	StatusBindFailed    uint32 = 0x0D
+	StatusInvalidPasswd uint32 = 0x0E
+	StatusInvalidSysID  uint32 = 0x0F
+	StatusMsgQueueFull  uint32 = 0x14
+	StatusThrottled     uint32 = 0x58
+)
+
+// StatusError is returned when a response PDU has a non-zero command status.
+type StatusError struct {
+	Command CommandID
+	Status  uint32
+}
+
+func (err StatusError) Error() string {
+	return fmt.Sprintf("smpp: %s failed with status 0x%08x", err.Command, err.Status)
+}
+
+// Temporary returns true if the request may succeed if retried.
+func (err StatusError) Temporary() bool {
+	switch err.Status {
+	case StatusSystemError, StatusMsgQueueFull, StatusThrottled:
+		return true
+	}
+	return false
+}
+
+const (
+	headerLen = 16
+
+	// maxPDULen is the largest PDU that will be read, to protect against garbage or hostile peers.
+	maxPDULen = 64 * 1024
+)
+
+// PDU is a single SMPP protocol data unit.
+type PDU struct {
+	Command  CommandID
+	Status   uint32
+	Sequence uint32
+	Body     []byte
+}
+
+// ReadPDU reads a single PDU from r.
+func ReadPDU(r io.Reader) (*PDU, error) {
+	var hdr [headerLen]byte
+	_, err := io.ReadFull(r, hdr[:])
+	if err != nil {
+		return nil, err
+	}
+
+	n := binary.BigEndian.Uint32(hdr[0:])
+	if n < headerLen || n > maxPDULen {
+		return nil, errors.Errorf("smpp: invalid PDU length %d", n)
+	}
+
+	p := &PDU{
+		Command:  CommandID(binary.BigEndian.Uint32(hdr[4:])),
+		Status:   binary.BigEndian.Uint32(hdr[8:]),
+		Sequence: binary.BigEndian.Uint32(hdr[12:]),
+		Body:     make([]byte, n-headerLen),
+	}
+	_, err = io.ReadFull(r, p.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// MarshalBinary returns the wire encoding of the PDU.
+func (p *PDU) MarshalBinary() ([]byte, error) {
+	if headerLen+len(p.Body) > maxPDULen {
+		return nil, errors.Errorf("smpp: PDU body too large (%d bytes)", len(p.Body))
+	}
+
+	data := make([]byte, headerLen, headerLen+len(p.Body))
+	binary.BigEndian.PutUint32(data[0:], uint32(headerLen+len(p.Body)))
+	binary.BigEndian.PutUint32(data[4:], uint32(p.Command))
+	binary.BigEndian.PutUint32(data[8:], p.Status)
+	binary.BigEndian.PutUint32(data[12:], p.Sequence)
+	return append(data, p.Body...), nil
+}
+
+// Err returns a StatusError if the PDU has a non-zero command status.
+func (p *PDU) Err() error {
+	if p.Command == CmdGenericNack || p.Status != StatusOK {
+		return StatusError{Command: p.Command, Status: p.Status}
+	}
+
+	return nil
+}
+
+// Optional parameter (TLV) tags used by GoAlert.
+const (
+	TagReceiptedMessageID uint16 = 0x001E
+	TagNetworkErrorCode   uint16 = 0x0423
+	TagMessageState       uint16 = 0x0427
+	TagMessagePayload     uint16 = 0x0424
+)
+
+// TLV is an optional parameter of a PDU body.
+type TLV struct {
+	Tag   uint16
+	Value []byte
+}
+
+// Address is a source or destination address.
+type Address struct {
+	TON  byte
+	NPI  byte
+	Addr string
+}
+
+// Type of number and numbering plan indicator values used by GoAlert.
+const (
+	TONUnknown       = 0x00
+	TONInternational = 0x01
+	TONAlphanumeric  = 0x05
+
+	NPIUnknown = 0x00
+	NPIISDN    = 0x01
+)
+
+// esm_class and registered_delivery values used by GoAlert.
+const (
+	ESMClassReceipt = 0x04
+
+	RegisteredDeliveryFinal = 0x01
+)
+
+// Data coding schemes used by GoAlert.
+const (
+	CodingDefault = 0x00 // GSM 03.38, unpacked
+	CodingASCII   = 0x01
+	CodingLatin1  = 0x03
+	CodingUCS2    = 0x08
+)
+
+// Bind is the body of a bind_transceiver PDU.
+type Bind struct {
+	SystemID         string
+	Password         string
+	SystemType       string
+	InterfaceVersion byte
+	AddrTON          byte
+	AddrNPI          byte
+	AddressRange     string
+}
+
+// ShortMessage is the body of a submit_sm or deliver_sm PDU.
+type ShortMessage struct {
+	ServiceType          string
+	Source               Address
+	Dest                 Address
+	ESMClass             byte
+	ProtocolID           byte
+	PriorityFlag         byte
+	ScheduleDeliveryTime string
+	ValidityPeriod       string
+	RegisteredDelivery   byte
+	ReplaceIfPresent     byte
+	DataCoding           byte
+	DefaultMsgID         byte
+	Message              []byte
+
+	TLVs []TLV
+}
+
+// Payload returns the message content, from the message_payload parameter if present.
+func (m *ShortMessage) Payload() []byte {
+	if v, ok := m.TLV(TagMessagePayload); ok {
+		return v
+	}
+	return m.Message
+}
+
+// TLV returns the value of the optional parameter with the given tag.
+func (m *ShortMessage) TLV(tag uint16) ([]byte, bool) {
+	for _, t := range m.TLVs {
+		if t.Tag == tag {
+			return t.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// Text returns the decoded message content.
+func (m *ShortMessage) Text() string { return DecodeText(m.DataCoding, m.Payload()) }
+
+type encoder struct{ bytes.Buffer }
+
+func (e *encoder) cstring(s string) {
+	e.WriteString(s)
+	e.WriteByte(0)
+}
+
+func (e *encoder) addr(a Address) {
+	e.WriteByte(a.TON)
+	e.WriteByte(a.NPI)
+	e.cstring(a.Addr)
+}
+
+func (e *encoder) tlvs(tlvs []TLV) {
+	for _, t := range tlvs {
+		_ = binary.Write(e, binary.BigEndian, t.Tag)
+		_ = binary.Write(e, binary.BigEndian, uint16(len(t.Value)))
+		e.Write(t.Value)
+	}
+}
+
+type decoder struct {
+	data []byte
+	err  error
+}
+
+func (d *decoder) byte() byte {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.data) == 0 {
+		d.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b
+}
+
+func (d *decoder) bytes(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if len(d.data) < n {
+		d.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	b := d.data[:n:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *decoder) cstring() string {
+	if d.err != nil {
+		return ""
+	}
+	n := bytes.IndexByte(d.data, 0)
+	if n == -1 {
+		d.err = errors.New("smpp: unterminated C-Octet string")
+		return ""
+	}
+	s := string(d.data[:n])
+	d.data = d.data[n+1:]
+	return s
+}
+
+func (d *decoder) addr() (a Address) {
+	a.TON = d.byte()
+	a.NPI = d.byte()
+	a.Addr = d.cstring()
+	return a
+}
+
+func (d *decoder) tlvs() (tlvs []TLV) {
+	for d.err == nil && len(d.data) > 0 {
+		hdr := d.bytes(4)
+		if d.err != nil {
+			break
+		}
+		tag := binary.BigEndian.Uint16(hdr)
+		val := d.bytes(int(binary.BigEndian.Uint16(hdr[2:])))
+		tlvs = append(tlvs, TLV{Tag: tag, Value: val})
+	}
+
+	return tlvs
+}
+
+// MarshalBinary returns the encoded PDU body.
+func (b *Bind) MarshalBinary() ([]byte, error) {
+	var e encoder
+	e.cstring(b.SystemID)
+	e.cstring(b.Password)
+	e.cstring(b.SystemType)
+	e.WriteByte(b.InterfaceVersion)
+	e.WriteByte(b.AddrTON)
+	e.WriteByte(b.AddrNPI)
+	e.cstring(b.AddressRange)
+	return e.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a PDU body.
+func (b *Bind) UnmarshalBinary(data []byte) error {
+	d := decoder{data: data}
+	b.SystemID = d.cstring()
+	b.Password = d.cstring()
+	b.SystemType = d.cstring()
+	b.InterfaceVersion = d.byte()
+	b.AddrTON = d.byte()
+	b.AddrNPI = d.byte()
+	b.AddressRange = d.cstring()
+	return d.err
+}
+
+// MarshalBinary returns the encoded PDU body.
+func (m *ShortMessage) MarshalBinary() ([]byte, error) {
+	if len(m.Message) > 254 {
+		return nil, errors.Errorf("smpp: short_message too long (%d bytes); use message_payload instead", len(m.Message))
+	}
+
+	var e encoder
+	e.cstring(m.ServiceType)
+	e.addr(m.Source)
+	e.addr(m.Dest)
+	e.WriteByte(m.ESMClass)
+	e.WriteByte(m.ProtocolID)
+	e.WriteByte(m.PriorityFlag)
+	e.cstring(m.ScheduleDeliveryTime)
+	e.cstring(m.ValidityPeriod)
+	e.WriteByte(m.RegisteredDelivery)
+	e.WriteByte(m.ReplaceIfPresent)
+	e.WriteByte(m.DataCoding)
+	e.WriteByte(m.DefaultMsgID)
+	e.WriteByte(byte(len(m.Message)))
+	e.Write(m.Message)
+	e.tlvs(m.TLVs)
+	return e.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a PDU body.
+func (m *ShortMessage) UnmarshalBinary(data []byte) error {
+	d := decoder{data: data}
+	m.ServiceType = d.cstring()
+	m.Source = d.addr()
+	m.Dest = d.addr()
+	m.ESMClass = d.byte()
+	m.ProtocolID = d.byte()
+	m.PriorityFlag = d.byte()
+	m.ScheduleDeliveryTime = d.cstring()
+	m.ValidityPeriod = d.cstring()
+	m.RegisteredDelivery = d.byte()
+	m.ReplaceIfPresent = d.byte()
+	m.DataCoding = d.byte()
+	m.DefaultMsgID = d.byte()
+	m.Message = d.bytes(int(d.byte()))
+	m.TLVs = d.tlvs()
+	return d.err
+}
+
+// CString returns the encoding of a single C-Octet string, as used by bind and submit_sm responses.
+func CString(s string) []byte { return append([]byte(s), 0) }
+
+// ParseCString decodes a body that starts with a C-Octet string (e.g., submit_sm_resp), ignoring any trailing data.
+func ParseCString(body []byte) (string, error) {
+	d := decoder{data: body}
+	s := d.cstring()
+	return s, d.err
+}
@@ -0,0 +1,73 @@
+package smpp
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPDU(t *testing.T) {
+	p := &PDU{Command: CmdSubmitSMResp, Sequence: 7, Body: CString("abc")}
+	data, err := p.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{
+		0, 0, 0, 20, // length
+		0x80, 0, 0, 4, // submit_sm_resp
+		0, 0, 0, 0, // status
+		0, 0, 0, 7, // sequence
+		'a', 'b', 'c', 0,
+	}, data)
+
+	read, err := ReadPDU(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, p, read)
+	assert.NoError(t, read.Err())
+
+	read.Status = StatusThrottled
+	var statusErr StatusError
+	require.ErrorAs(t, read.Err(), &statusErr)
+	assert.True(t, statusErr.Temporary())
+
+	_, err = ReadPDU(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 1}))
+	assert.Error(t, err, "length over limit")
+}
+
+func TestShortMessage(t *testing.T) {
+	m := ShortMessage{
+		Source:             Address{TON: TONAlphanumeric, Addr: "GoAlert"},
+		Dest:               Address{TON: TONInternational, NPI: NPIISDN, Addr: "17635550100"},
+		RegisteredDelivery: RegisteredDeliveryFinal,
+		DataCoding:         CodingUCS2,
+		Message:            []byte{0, 'h', 0, 'i'},
+		TLVs:               []TLV{{Tag: TagMessageState, Value: []byte{2}}},
+	}
+	data, err := m.MarshalBinary()
+	require.NoError(t, err)
+
+	var read ShortMessage
+	require.NoError(t, read.UnmarshalBinary(data))
+	assert.Equal(t, m, read)
+	assert.Equal(t, "hi", read.Text())
+
+	assert.Error(t, read.UnmarshalBinary(data[:20]), "truncated body")
+
+	m.Message = make([]byte, 255)
+	_, err = m.MarshalBinary()
+	assert.Error(t, err, "short_message over 254 bytes")
+
+	m.Message = nil
+	m.TLVs = []TLV{{Tag: TagMessagePayload, Value: []byte{0, 'o', 0, 'k'}}}
+	assert.Equal(t, "ok", m.Text())
+}
+
+func TestBind(t *testing.T) {
+	b := Bind{SystemID: "goalert", Password: "secret", InterfaceVersion: interfaceVersion}
+	data, err := b.MarshalBinary()
+	require.NoError(t, err)
+
+	var read Bind
+	require.NoError(t, read.UnmarshalBinary(data))
+	assert.Equal(t, b, read)
+}
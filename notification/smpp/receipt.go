@@ -0,0 +1,120 @@
+package smpp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/notification"
+)
+
+// Receipt is a delivery receipt, sent by the SMSC as a deliver_sm.
+type Receipt struct {
+	MessageID string
+
+	// State is the final message state (e.g., DELIVRD, UNDELIV).
+	State string
+
+	// Err is the network or SMSC specific error code.
+	Err string
+}
+
+// message_state values (SMPP 3.4 5.2.28) and their receipt stat text.
+var messageStates = []string{
+	1: "ENROUTE",
+	2: "DELIVRD",
+	3: "EXPIRED",
+	4: "DELETED",
+	5: "UNDELIV",
+	6: "ACCEPTD",
+	7: "UNKNOWN",
+	8: "REJECTD",
+}
+
+var (
+	receiptIDRx   = regexp.MustCompile(`(?i)\bid:(\S+)`)
+	receiptStatRx = regexp.MustCompile(`(?i)\bstat:(\S+)`)
+	receiptErrRx  = regexp.MustCompile(`(?i)\berr:(\S+)`)
+)
+
+// ParseReceipt parses a delivery receipt. The receipted_message_id and message_state parameters are preferred
+// when present, otherwise the message text is parsed using the format from SMPP 3.4 Appendix B.
+func ParseReceipt(m *ShortMessage) (*Receipt, error) {
+	if m.ESMClass&0x3c != ESMClassReceipt {
+		return nil, errors.New("smpp: not a delivery receipt")
+	}
+
+	var r Receipt
+	text := DecodeText(CodingASCII, m.Payload())
+	if v, ok := m.TLV(TagReceiptedMessageID); ok {
+		r.MessageID, _ = ParseCString(v)
+	}
+	if r.MessageID == "" {
+		if m := receiptIDRx.FindStringSubmatch(text); m != nil {
+			r.MessageID = m[1]
+		}
+	}
+	if v, ok := m.TLV(TagMessageState); ok && len(v) == 1 && int(v[0]) < len(messageStates) {
+		r.State = messageStates[v[0]]
+	}
+	if r.State == "" {
+		if m := receiptStatRx.FindStringSubmatch(text); m != nil {
+			r.State = strings.ToUpper(m[1])
+		}
+	}
+	if m := receiptErrRx.FindStringSubmatch(text); m != nil {
+		r.Err = m[1]
+	}
+
+	if r.MessageID == "" {
+		return nil, errors.New("smpp: delivery receipt missing message ID")
+	}
+
+	return &r, nil
+}
+
+// ShortMessage returns a deliver_sm body for the receipt.
+func (r Receipt) ShortMessage(src, dst Address) *ShortMessage {
+	err := r.Err
+	if err == "" {
+		err = "000"
+	}
+
+	m := &ShortMessage{
+		Source:   src,
+		Dest:     dst,
+		ESMClass: ESMClassReceipt,
+		Message:  fmt.Appendf(nil, "id:%s sub:001 dlvrd:001 stat:%s err:%s", r.MessageID, r.State, err),
+		TLVs: []TLV{
+			{Tag: TagReceiptedMessageID, Value: CString(r.MessageID)},
+		},
+	}
+	for i, s := range messageStates {
+		if s != "" && s == r.State {
+			m.TLVs = append(m.TLVs, TLV{Tag: TagMessageState, Value: []byte{byte(i)}})
+		}
+	}
+
+	return m
+}
+
+// Status returns the notification status for the receipt.
+func (r Receipt) Status() *notification.Status {
+	s := &notification.Status{Details: strings.ToLower(r.State)}
+	if r.Err != "" && strings.Trim(r.Err, "0") != "" {
+		s.Details += ": err " + r.Err
+	}
+
+	switch r.State {
+	case "DELIVRD":
+		s.State = notification.StateDelivered
+	case "EXPIRED", "DELETED", "UNDELIV", "REJECTD":
+		s.State = notification.StateFailedPerm
+	default:
+		// ENROUTE, ACCEPTD, and UNKNOWN only tell us the SMSC has the message
+		s.State = notification.StateSent
+	}
+
+	return s
+}
@@ -0,0 +1,32 @@
+package smpp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/notification"
+)
+
+func TestParseReceipt(t *testing.T) {
+	r, err := ParseReceipt(&ShortMessage{
+		ESMClass: ESMClassReceipt,
+		Message:  []byte("id:0123456789 sub:001 dlvrd:001 submit date:2410181200 done date:2410181201 stat:UNDELIV err:034 Text:Alert #1"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &Receipt{MessageID: "0123456789", State: "UNDELIV", Err: "034"}, r)
+	assert.Equal(t, &notification.Status{State: notification.StateFailedPerm, Details: "undeliv: err 034"}, r.Status())
+
+	// parameters take priority over the text
+	m := Receipt{MessageID: "abc", State: "DELIVRD"}.ShortMessage(Address{}, Address{})
+	m.Message = []byte("id:other stat:ENROUTE")
+	r, err = ParseReceipt(m)
+	require.NoError(t, err)
+	assert.Equal(t, &Receipt{MessageID: "abc", State: "DELIVRD"}, r)
+	assert.Equal(t, &notification.Status{State: notification.StateDelivered, Details: "delivrd"}, r.Status())
+
+	_, err = ParseReceipt(&ShortMessage{Message: []byte("id:abc stat:DELIVRD")})
+	assert.Error(t, err, "not a receipt")
+	_, err = ParseReceipt(&ShortMessage{ESMClass: ESMClassReceipt, Message: []byte("stat:DELIVRD")})
+	assert.Error(t, err, "missing ID")
+}
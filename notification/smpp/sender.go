@@ -0,0 +1,343 @@
+package smpp
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nyaruka/phonenumbers"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/nfydest"
+	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/util/log"
+)
+
+// reconnectInterval is how often the session is checked, and re-established if needed.
+const reconnectInterval = 5 * time.Second
+
+// Sender implements a notification.Sender for SMS through an SMPP 3.4 gateway.
+//
+// A single transceiver session is kept bound while the provider is enabled, so that delivery receipts and
+// replies can be received at any time.
+type Sender struct {
+	replies *twilio.SMSReplies
+	r       notification.Receiver
+
+	cfgSrc config.Source
+	logger *log.Logger
+
+	mx      sync.Mutex
+	c       *Session
+	lastErr string
+
+	readyOnce  sync.Once
+	readyCh    chan struct{}
+	shutdownCh chan struct{}
+	doneCh     chan struct{}
+}
+
+var (
+	_ notification.ReceiverSetter = &Sender{}
+	_ nfydest.MessageSender       = &Sender{}
+)
+
+// NewSender creates a new Sender. Once a receiver is set, the session will be (re)established in the background
+// based on the current SMPP config.
+func NewSender(ctx context.Context, db *sql.DB, cfgSrc config.Source) (*Sender, error) {
+	replies, err := twilio.NewSMSReplies(ctx, db, DestTypeSMPPSMS)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Sender{
+		replies:    replies,
+		cfgSrc:     cfgSrc,
+		logger:     log.FromContext(ctx),
+		readyCh:    make(chan struct{}),
+		shutdownCh: make(chan struct{}),
+		doneCh:     make(chan struct{}),
+	}
+	go s.loop()
+
+	return s, nil
+}
+
+// SetReceiver sets the notification.Receiver for incoming messages and status updates.
+func (s *Sender) SetReceiver(r notification.Receiver) {
+	s.r = r
+	s.replies.SetReceiver(r)
+	s.readyOnce.Do(func() { close(s.readyCh) })
+}
+
+// Shutdown will unbind the current session, if any, and stop reconnecting.
+func (s *Sender) Shutdown(ctx context.Context) error {
+	close(s.shutdownCh)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.doneCh:
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if s.c == nil {
+		return nil
+	}
+
+	err := s.c.Unbind(ctx)
+	s.c = nil
+	return err
+}
+
+func (s *Sender) context() context.Context {
+	ctx := s.cfgSrc.Config().Context(s.logger.BackgroundContext())
+	return log.WithField(ctx, "Type", "SMPPSMS")
+}
+
+func (s *Sender) loop() {
+	defer close(s.doneCh)
+
+	// receipts and replies can't be processed until there is a receiver
+	select {
+	case <-s.shutdownCh:
+		return
+	case <-s.readyCh:
+	}
+
+	t := time.NewTicker(reconnectInterval)
+	defer t.Stop()
+
+	for {
+		ctx := s.context()
+		if config.FromContext(ctx).SMPP.Enable {
+			_, err := s.conn(ctx)
+			s.logConnErr(ctx, err)
+		} else {
+			s.unbind(ctx)
+		}
+
+		select {
+		case <-s.shutdownCh:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// logConnErr will log connection errors once, rather than every reconnect attempt.
+func (s *Sender) logConnErr(ctx context.Context, err error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	var msg string
+	if err != nil {
+		msg = err.Error()
+	}
+	if msg == s.lastErr {
+		return
+	}
+	s.lastErr = msg
+
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "connect to SMSC"))
+	} else {
+		log.Logf(ctx, "SMPP session bound.")
+	}
+}
+
+func (s *Sender) unbind(ctx context.Context) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if s.c == nil {
+		return
+	}
+
+	err := s.c.Unbind(ctx)
+	if err != nil {
+		log.Debug(ctx, errors.Wrap(err, "unbind SMPP session"))
+	}
+	s.c = nil
+	s.lastErr = ""
+}
+
+// conn returns the current session, establishing a new one if it is closed or the config has changed.
+func (s *Sender) conn(ctx context.Context) (*Session, error) {
+	cfg := config.FromContext(ctx)
+	cc := DialConfig{
+		Address:    cfg.SMPP.Address,
+		TLS:        cfg.SMPP.EnableTLS,
+		SkipVerify: cfg.SMPP.SkipVerify,
+		SystemID:   cfg.SMPP.SystemID,
+		Password:   cfg.SMPP.Password,
+		SystemType: cfg.SMPP.SystemType,
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if s.c != nil && s.c.Err() == nil && s.c.cfg == cc {
+		return s.c, nil
+	}
+	if s.c != nil {
+		if s.c.Err() == nil {
+			_ = s.c.Unbind(ctx)
+		}
+		s.c = nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, responseTimeout)
+	defer cancel()
+	c, err := Dial(ctx, cc, s.handleDeliver)
+	if err != nil {
+		return nil, err
+	}
+	s.c = c
+
+	return c, nil
+}
+
+// sourceAddr returns the address to use for a configured source (number or alphanumeric sender ID).
+func sourceAddr(src string) Address {
+	if strings.HasPrefix(src, "+") {
+		return Address{TON: TONInternational, NPI: NPIISDN, Addr: strings.TrimPrefix(src, "+")}
+	}
+	if strings.Trim(src, "0123456789") == "" {
+		return Address{TON: TONUnknown, NPI: NPIISDN, Addr: src}
+	}
+
+	return Address{TON: TONAlphanumeric, NPI: NPIUnknown, Addr: src}
+}
+
+// sourceNumber returns the E.164 number of an incoming message, or an empty string if it is not a valid
+// international number.
+func sourceNumber(a Address) string {
+	num := a.Addr
+	switch {
+	case strings.HasPrefix(num, "+"):
+	case a.TON != TONInternational && strings.HasPrefix(num, "00"):
+		num = "+" + num[2:]
+	default:
+		// national formats are ambiguous, so anything else is expected to already include the country code
+		num = "+" + num
+	}
+
+	n, err := phonenumbers.Parse(num, "")
+	if err != nil || !phonenumbers.IsValidNumber(n) {
+		return ""
+	}
+
+	return phonenumbers.Format(n, phonenumbers.E164)
+}
+
+// submit sends a message to the given E.164 number, returning the SMSC message ID.
+func (s *Sender) submit(ctx context.Context, number, text string, receipt bool) (string, error) {
+	cfg := config.FromContext(ctx)
+	c, err := s.conn(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "connect to SMSC")
+	}
+
+	m := &ShortMessage{
+		Source: sourceAddr(cfg.SMPP.SourceAddr),
+		Dest:   Address{TON: TONInternational, NPI: NPIISDN, Addr: strings.TrimPrefix(number, "+")},
+	}
+	if receipt {
+		m.RegisteredDelivery = RegisteredDeliveryFinal
+	}
+
+	var data []byte
+	m.DataCoding, data = EncodeText(text)
+	if len(data) > 140 && (m.DataCoding != CodingDefault || len(data) > 160) {
+		// too long for a single SMS, let the SMSC handle segmentation
+		m.TLVs = append(m.TLVs, TLV{Tag: TagMessagePayload, Value: data})
+	} else {
+		m.Message = data
+	}
+
+	return c.SubmitSM(ctx, m)
+}
+
+// SendMessage implements the nfydest.MessageSender interface.
+func (s *Sender) SendMessage(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.SMPP.Enable {
+		return nil, errors.New("SMPP provider is disabled")
+	}
+	if msg.DestType() != DestTypeSMPPSMS {
+		return nil, errors.Errorf("unsupported destination type %s; expected SMPP SMS", msg.DestType())
+	}
+	destNumber := msg.DestArg(FieldPhoneNumber)
+	if destNumber == cfg.SMPP.SourceAddr {
+		return nil, errors.New("refusing to send outgoing SMS to SourceAddr")
+	}
+
+	ctx = log.WithFields(ctx, log.Fields{
+		"Phone": destNumber,
+		"Type":  "SMPPSMS",
+	})
+
+	message, err := s.replies.RenderMessage(ctx, destNumber, msg, !cfg.SMPP.DisableTwoWaySMS)
+	if err != nil {
+		return nil, errors.Wrap(err, "render message")
+	}
+
+	id, err := s.submit(ctx, destNumber, message, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "send message")
+	}
+
+	// If the message was sent successfully, reset reply limits.
+	s.replies.Sent(destNumber)
+
+	return &notification.SentMessage{
+		ExternalID:   id,
+		State:        notification.StateSending,
+		StateDetails: "accepted",
+		SrcValue:     cfg.SMPP.SourceAddr,
+	}, nil
+}
+
+// handleDeliver processes delivery receipts and incoming messages from the SMSC.
+func (s *Sender) handleDeliver(m *ShortMessage) uint32 {
+	ctx := s.context()
+
+	if m.ESMClass&0x3c == ESMClassReceipt {
+		r, err := ParseReceipt(m)
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "parse delivery receipt"))
+			return StatusOK
+		}
+
+		ctx = log.WithField(ctx, "MessageID", r.MessageID)
+		err = s.r.SetMessageStatus(ctx, r.MessageID, r.Status())
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "update message status"))
+		}
+		return StatusOK
+	}
+
+	cfg := config.FromContext(ctx)
+	from := sourceNumber(m.Source)
+	if from == "" || from == cfg.SMPP.SourceAddr {
+		log.Debugf(ctx, "SMPP: ignoring message from invalid source address '%s'", m.Source.Addr)
+		return StatusOK
+	}
+
+	reply := s.replies.HandleReply(ctx, from, m.Text(), !cfg.SMPP.DisableTwoWaySMS)
+	if reply == "" {
+		return StatusOK
+	}
+
+	// reply in the background, so the SMSC gets a response to the deliver_sm first
+	go func() {
+		_, err := s.submit(ctx, from, reply, false)
+		if err != nil {
+			log.Log(log.WithField(ctx, "Number", from), errors.Wrap(err, "send response"))
+		}
+	}()
+
+	return StatusOK
+}
@@ -0,0 +1,22 @@
+package smpp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceNumber(t *testing.T) {
+	assert.Equal(t, "+17635550100", sourceNumber(Address{TON: TONInternational, Addr: "17635550100"}))
+	assert.Equal(t, "+17635550100", sourceNumber(Address{Addr: "+17635550100"}))
+	assert.Equal(t, "+447911123456", sourceNumber(Address{Addr: "00447911123456"}))
+	assert.Equal(t, "+447911123456", sourceNumber(Address{Addr: "447911123456"}))
+	assert.Empty(t, sourceNumber(Address{TON: TONAlphanumeric, Addr: "CARRIER"}))
+	assert.Empty(t, sourceNumber(Address{Addr: "12345"}))
+}
+
+func TestSourceAddr(t *testing.T) {
+	assert.Equal(t, Address{TON: TONInternational, NPI: NPIISDN, Addr: "17635550100"}, sourceAddr("+17635550100"))
+	assert.Equal(t, Address{NPI: NPIISDN, Addr: "24365"}, sourceAddr("24365"))
+	assert.Equal(t, Address{TON: TONAlphanumeric, Addr: "GoAlert"}, sourceAddr("GoAlert"))
+}
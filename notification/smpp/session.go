@@ -0,0 +1,255 @@
+package smpp
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// interfaceVersion is SMPP 3.4
+	interfaceVersion = 0x34
+
+	enquireLinkInterval = 30 * time.Second
+	responseTimeout     = 10 * time.Second
+)
+
+var errClosed = errors.New("smpp: connection closed")
+
+// DialConfig contains the settings used to establish a session.
+type DialConfig struct {
+	Address    string
+	TLS        bool
+	SkipVerify bool
+	SystemID   string
+	Password   string
+	SystemType string
+}
+
+// Session is a bound transceiver session with an SMSC.
+type Session struct {
+	cfg DialConfig
+	nc  net.Conn
+
+	// handle is called for each incoming deliver_sm, it returns the command status for the response.
+	handle func(*ShortMessage) uint32
+
+	wMx sync.Mutex
+
+	mx      sync.Mutex
+	seq     uint32
+	pending map[uint32]chan *PDU
+	err     error
+
+	doneCh chan struct{}
+}
+
+// Dial connects and binds a transceiver session. The handle func is called for each incoming deliver_sm (delivery
+// receipts and mobile-originated messages) and returns the command status for the response.
+func Dial(ctx context.Context, cfg DialConfig, handle func(*ShortMessage) uint32) (*Session, error) {
+	var nc net.Conn
+	var err error
+	if cfg.TLS {
+		host, _, _ := net.SplitHostPort(cfg.Address)
+		d := tls.Dialer{Config: &tls.Config{ServerName: host, InsecureSkipVerify: cfg.SkipVerify}}
+		nc, err = d.DialContext(ctx, "tcp", cfg.Address)
+	} else {
+		var d net.Dialer
+		nc, err = d.DialContext(ctx, "tcp", cfg.Address)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "dial SMSC")
+	}
+
+	c := &Session{
+		cfg:     cfg,
+		nc:      nc,
+		handle:  handle,
+		pending: make(map[uint32]chan *PDU),
+		doneCh:  make(chan struct{}),
+	}
+	go c.readLoop()
+
+	bind := Bind{
+		SystemID:         cfg.SystemID,
+		Password:         cfg.Password,
+		SystemType:       cfg.SystemType,
+		InterfaceVersion: interfaceVersion,
+	}
+	body, _ := bind.MarshalBinary()
+	_, err = c.request(ctx, CmdBindTransceiver, body)
+	if err != nil {
+		c.close(err)
+		return nil, errors.Wrap(err, "bind transceiver")
+	}
+
+	go c.keepAlive()
+
+	return c, nil
+}
+
+// Err returns the error that closed the connection, or nil if it is still open.
+func (c *Session) Err() error {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.err
+}
+
+func (c *Session) close(err error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	_ = c.nc.Close()
+	close(c.doneCh)
+}
+
+func (c *Session) write(p *PDU) error {
+	data, err := p.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	c.wMx.Lock()
+	defer c.wMx.Unlock()
+	_ = c.nc.SetWriteDeadline(time.Now().Add(responseTimeout))
+	_, err = c.nc.Write(data)
+	if err != nil {
+		c.close(err)
+	}
+	return err
+}
+
+// request sends a request PDU and waits for the response.
+func (c *Session) request(ctx context.Context, cmd CommandID, body []byte) (*PDU, error) {
+	ch := make(chan *PDU, 1)
+	c.mx.Lock()
+	if c.err != nil {
+		c.mx.Unlock()
+		return nil, c.err
+	}
+	c.seq++
+	if c.seq > 0x7fffffff {
+		c.seq = 1
+	}
+	seq := c.seq
+	c.pending[seq] = ch
+	c.mx.Unlock()
+
+	defer func() {
+		c.mx.Lock()
+		delete(c.pending, seq)
+		c.mx.Unlock()
+	}()
+
+	err := c.write(&PDU{Command: cmd, Sequence: seq, Body: body})
+	if err != nil {
+		return nil, err
+	}
+
+	t := time.NewTimer(responseTimeout)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-t.C:
+		return nil, errors.Errorf("smpp: timeout waiting for %s", cmd.Resp())
+	case <-c.doneCh:
+		return nil, c.Err()
+	case resp := <-ch:
+		return resp, resp.Err()
+	}
+}
+
+func (c *Session) readLoop() {
+	for {
+		p, err := ReadPDU(c.nc)
+		if err != nil {
+			c.close(err)
+			return
+		}
+
+		if p.Command.IsResp() {
+			c.mx.Lock()
+			ch := c.pending[p.Sequence]
+			c.mx.Unlock()
+			if ch != nil {
+				ch <- p // buffered, and each sequence number is only answered once
+			}
+			continue
+		}
+
+		switch p.Command {
+		case CmdEnquireLink:
+			_ = c.write(&PDU{Command: CmdEnquireLinkResp, Sequence: p.Sequence})
+		case CmdUnbind:
+			_ = c.write(&PDU{Command: CmdUnbindResp, Sequence: p.Sequence})
+			c.close(errors.New("smpp: unbound by SMSC"))
+			return
+		case CmdDeliverSM:
+			go c.deliver(p)
+		default:
+			_ = c.write(&PDU{Command: CmdGenericNack, Status: StatusInvalidCmdID, Sequence: p.Sequence})
+		}
+	}
+}
+
+func (c *Session) deliver(p *PDU) {
+	var m ShortMessage
+	var status uint32
+	err := m.UnmarshalBinary(p.Body)
+	if err != nil {
+		status = StatusInvalidMsgLen
+	} else {
+		status = c.handle(&m)
+	}
+
+	_ = c.write(&PDU{Command: CmdDeliverSMResp, Status: status, Sequence: p.Sequence, Body: CString("")})
+}
+
+func (c *Session) keepAlive() {
+	t := time.NewTicker(enquireLinkInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-c.doneCh:
+			return
+		case <-t.C:
+		}
+
+		_, err := c.request(context.Background(), CmdEnquireLink, nil)
+		if err != nil {
+			c.close(errors.Wrap(err, "enquire link"))
+			return
+		}
+	}
+}
+
+// SubmitSM sends a message, returning the SMSC message ID.
+func (c *Session) SubmitSM(ctx context.Context, m *ShortMessage) (string, error) {
+	body, err := m.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.request(ctx, CmdSubmitSM, body)
+	if err != nil {
+		return "", err
+	}
+
+	return ParseCString(resp.Body)
+}
+
+// Unbind will gracefully end the session and close the connection.
+func (c *Session) Unbind(ctx context.Context) error {
+	_, err := c.request(ctx, CmdUnbind, nil)
+	c.close(errClosed)
+	return err
+}
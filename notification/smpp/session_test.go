@@ -0,0 +1,79 @@
+package smpp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/devtools/mocksmpp"
+	"github.com/target/goalert/notification/smpp"
+)
+
+func TestSession(t *testing.T) {
+	srv, err := mocksmpp.NewServer("127.0.0.1:0", mocksmpp.Config{SystemID: "goalert", Password: "secret"})
+	require.NoError(t, err)
+	defer srv.Close()
+
+	ctx := context.Background()
+	cfg := smpp.DialConfig{Address: srv.Addr(), SystemID: "goalert", Password: "wrong"}
+	_, err = smpp.Dial(ctx, cfg, nil)
+	var statusErr smpp.StatusError
+	require.ErrorAs(t, err, &statusErr, "bad password")
+	assert.Equal(t, smpp.StatusInvalidPasswd, statusErr.Status)
+
+	delivered := make(chan *smpp.ShortMessage, 1)
+	cfg.Password = "secret"
+	sess, err := smpp.Dial(ctx, cfg, func(m *smpp.ShortMessage) uint32 {
+		delivered <- m
+		return smpp.StatusOK
+	})
+	require.NoError(t, err)
+
+	coding, data := smpp.EncodeText("Alert #1: disk full")
+	id, err := sess.SubmitSM(ctx, &smpp.ShortMessage{
+		Source:             smpp.Address{TON: smpp.TONInternational, Addr: "17635550100"},
+		Dest:               smpp.Address{TON: smpp.TONInternational, Addr: "17635550123"},
+		RegisteredDelivery: smpp.RegisteredDeliveryFinal,
+		DataCoding:         coding,
+		Message:            data,
+	})
+	require.NoError(t, err)
+
+	msg := <-srv.Messages()
+	assert.Equal(t, id, msg.ID)
+	assert.Equal(t, "+17635550100", msg.From)
+	assert.Equal(t, "+17635550123", msg.To)
+	assert.Equal(t, "Alert #1: disk full", msg.Text)
+	assert.True(t, msg.Receipt)
+
+	// delivery receipt
+	require.NoError(t, msg.Deliver("DELIVRD"))
+	select {
+	case m := <-delivered:
+		r, err := smpp.ParseReceipt(m)
+		require.NoError(t, err)
+		assert.Equal(t, id, r.MessageID)
+		assert.Equal(t, "DELIVRD", r.State)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for receipt")
+	}
+
+	// mobile-originated reply
+	require.NoError(t, srv.SendMessage("+17635550123", "+17635550100", "1a"))
+	select {
+	case m := <-delivered:
+		_, err = smpp.ParseReceipt(m)
+		assert.Error(t, err, "not a receipt")
+		assert.Equal(t, "17635550123", m.Source.Addr)
+		assert.Equal(t, "1a", m.Text())
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for message")
+	}
+
+	require.NoError(t, sess.Unbind(ctx))
+	assert.Error(t, sess.Err())
+	_, err = sess.SubmitSM(ctx, &smpp.ShortMessage{Dest: smpp.Address{Addr: "17635550123"}})
+	assert.Error(t, err, "closed session")
+}
@@ -0,0 +1,112 @@
+package smpp
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+// gsmBasic is the GSM 03.38 default alphabet, indexed by septet value.
+const gsmBasic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞ\x1bÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// gsmExt is the GSM 03.38 extension table, characters are prefixed with an escape (0x1B).
+var gsmExt = map[rune]byte{
+	'\f': 0x0A,
+	'^':  0x14,
+	'{':  0x28,
+	'}':  0x29,
+	'\\': 0x2F,
+	'[':  0x3C,
+	'~':  0x3D,
+	']':  0x3E,
+	'|':  0x40,
+	'€':  0x65,
+}
+
+var (
+	gsmEncode = make(map[rune]byte, 128)
+	gsmDecode = make([]rune, 0, 128)
+	extDecode = make(map[byte]rune, len(gsmExt))
+)
+
+func init() {
+	for _, r := range gsmBasic {
+		gsmEncode[r] = byte(len(gsmDecode))
+		gsmDecode = append(gsmDecode, r)
+	}
+	delete(gsmEncode, 0x1b) // escape is not a character
+	for r, b := range gsmExt {
+		extDecode[b] = r
+	}
+}
+
+// encodeGSM returns the unpacked GSM 03.38 encoding of s, or false if s contains characters outside of the
+// GSM alphabet.
+func encodeGSM(s string) ([]byte, bool) {
+	data := make([]byte, 0, len(s))
+	for _, r := range s {
+		if b, ok := gsmEncode[r]; ok {
+			data = append(data, b)
+			continue
+		}
+		if b, ok := gsmExt[r]; ok {
+			data = append(data, 0x1b, b)
+			continue
+		}
+		return nil, false
+	}
+
+	return data, true
+}
+
+// EncodeText encodes s using the GSM default alphabet if possible, falling back to UCS-2.
+func EncodeText(s string) (coding byte, data []byte) {
+	if data, ok := encodeGSM(s); ok {
+		return CodingDefault, data
+	}
+
+	units := utf16.Encode([]rune(s))
+	data = make([]byte, 0, len(units)*2)
+	for _, u := range units {
+		data = append(data, byte(u>>8), byte(u))
+	}
+
+	return CodingUCS2, data
+}
+
+// DecodeText decodes message content with the given data coding scheme.
+func DecodeText(coding byte, data []byte) string {
+	switch coding {
+	case CodingUCS2:
+		units := make([]uint16, len(data)/2)
+		for i := range units {
+			units[i] = uint16(data[i*2])<<8 | uint16(data[i*2+1])
+		}
+		return string(utf16.Decode(units))
+	case CodingASCII:
+		return string(data)
+	case CodingLatin1:
+		var buf strings.Builder
+		for _, b := range data {
+			buf.WriteRune(rune(b))
+		}
+		return buf.String()
+	}
+
+	var buf strings.Builder
+	for i := 0; i < len(data); i++ {
+		b := data[i] & 0x7f
+		if b == 0x1b && i+1 < len(data) {
+			i++
+			if r, ok := extDecode[data[i]]; ok {
+				buf.WriteRune(r)
+				continue
+			}
+			// unknown extension characters are displayed as a space
+			buf.WriteRune(' ')
+			continue
+		}
+		buf.WriteRune(gsmDecode[b])
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,27 @@
+package smpp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeText(t *testing.T) {
+	assert.Len(t, gsmDecode, 128)
+
+	check := func(s string, coding byte, n int) {
+		t.Helper()
+		c, data := EncodeText(s)
+		assert.Equal(t, coding, c, s)
+		assert.Len(t, data, n, s)
+		assert.Equal(t, s, DecodeText(c, data), s)
+	}
+
+	check("Alert #1: disk full @ db-01", CodingDefault, 27)
+	check("Ünïcödé £5", CodingUCS2, 20) // ï and ö are not in the GSM alphabet
+	check("Ñandu £5 {€}", CodingDefault, 15)
+	check("Alert #2: 日本", CodingUCS2, 24)
+	check("fire 🔥", CodingUCS2, 14)
+
+	assert.Equal(t, "café", DecodeText(CodingLatin1, []byte{'c', 'a', 'f', 0xe9}))
+}
@@ -42,6 +42,15 @@ var statusTempl = template.Must(template.New("alertStatusSMS").Parse(`{{.AppName
 
 	{{.LogEntry}}`))
 
+var digestTempl = template.Must(template.New("digestSMS").Parse(`{{.AppName}}: {{.Summary}}
+{{range .Items}}
+- {{.}}{{end}}
+{{- if .More}}
+...and {{.More}} more{{end}}`))
+
+// maxDigestSMSItems is the maximum number of items listed in a digest SMS.
+const maxDigestSMSItems = 5
+
 const gsmAlphabet = "@∆ 0¡P¿p£!1AQaq$Φ\"2BRbr¥Γ#3CScsèΛ¤4DTdtéΩ%5EUeuùΠ&6FVfvìΨ'7GWgwòΣ(8HXhxÇΘ)9IYiy\n Ξ *:JZjzØ+;KÄkäøÆ,<LÖlö\ræ-=MÑmñÅß.>NÜnüåÉ/?O§oà"
 
 var gsmChr = make(map[rune]bool, len(gsmAlphabet))
@@ -261,3 +270,39 @@ func renderAlertBundleMessage(appName string, a notification.AlertBundle, link s
 
 	return result, nil
 }
+
+// renderDigestMessage will render an SMS message for a Quiet Hours Digest.
+//
+// Non-GSM characters will be replaced with '?' and item summaries will be
+// truncated (as needed) to use the minimum number of message segments.
+func renderDigestMessage(appName string, d notification.QuietHoursDigest) (string, error) {
+	var buf bytes.Buffer
+	var data struct {
+		AppName string
+		Summary string
+		Items   []string
+		More    int
+	}
+	data.AppName = appName
+	data.Summary = d.Summary()
+
+	items := d.Items
+	if len(items) > maxDigestSMSItems {
+		data.More = len(items) - maxDigestSMSItems
+		items = items[:maxDigestSMSItems]
+	}
+	summaries := make([]string, len(items))
+	for i, item := range items {
+		summaries[i] = item.Summary
+	}
+
+	return renderMinGSMSegments(summaries, func(inputs []string) (string, error) {
+		buf.Reset()
+		data.Items = inputs
+		err := digestTempl.Execute(&buf, data)
+		if err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	})
+}
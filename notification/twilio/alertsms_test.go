@@ -1,6 +1,7 @@
 package twilio
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"testing"
@@ -189,3 +190,35 @@ func TestSMS_RenderAlertStatus(t *testing.T) {
 	Some log entry`,
 	)
 }
+
+func TestSMS_RenderDigest(t *testing.T) {
+	check := func(name string, d notification.QuietHoursDigest, exp string) {
+		t.Run(name, func(t *testing.T) {
+			res, err := renderDigestMessage("TestApp", d)
+			resultCheck(t, exp, res, err)
+		})
+	}
+
+	check("single",
+		notification.QuietHoursDigest{Items: []notification.DigestItem{{Summary: "Alert #1: Testing (Closed by Bob)"}}},
+		`TestApp: 1 notification was held during quiet hours.
+
+- Alert #1: Testing (Closed by Bob)`,
+	)
+
+	items := make([]notification.DigestItem, 7)
+	for i := range items {
+		items[i].Summary = fmt.Sprintf("Item %d", i+1)
+	}
+	check("more",
+		notification.QuietHoursDigest{Items: items},
+		`TestApp: 7 notifications were held during quiet hours.
+
+- Item 1
+- Item 2
+- Item 3
+- Item 4
+- Item 5
+...and 2 more`,
+	)
+}
@@ -0,0 +1,367 @@
+package twilio
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+)
+
+var (
+	lastReplyRx  = regexp.MustCompile(`^'?\s*(c|close|a|e|ack[a-z]*)\s*'?$`)
+	shortReplyRx = regexp.MustCompile(`^'?\s*([0-9]+)\s*(c|a|e)\s*'?$`)
+	alertReplyRx = regexp.MustCompile(`^'?\s*(c|close|e|a|ack[a-z]*)\s*#?\s*([0-9]+)\s*'?$`)
+
+	svcReplyRx = regexp.MustCompile(`^'?\s*([0-9]+)\s*(cc|aa)\s*'?$`)
+)
+
+// SMSReplies implements two-way SMS: rendering messages with reply codes, and processing replies and START/STOP
+// requests. It does not depend on how messages are delivered, so SMS providers other than Twilio share the same
+// reply codes and behavior.
+type SMSReplies struct {
+	b *dbSMS
+	r notification.Receiver
+
+	limit *replyLimiter
+
+	destType string
+}
+
+// NewSMSReplies creates a new SMSReplies for SMS destinations of the given type, which must use FieldPhoneNumber.
+func NewSMSReplies(ctx context.Context, db *sql.DB, destType string) (*SMSReplies, error) {
+	b, err := newDB(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SMSReplies{
+		b:        b,
+		limit:    newReplyLimiter(),
+		destType: destType,
+	}, nil
+}
+
+// SetReceiver sets the notification.Receiver for replies.
+func (s *SMSReplies) SetReceiver(r notification.Receiver) { s.r = r }
+
+func (s *SMSReplies) dest(number string) gadb.DestV1 {
+	return gadb.NewDestV1(s.destType, FieldPhoneNumber, number)
+}
+
+// Sent should be called after a message is sent to a number, it resets the limit of passive replies.
+func (s *SMSReplies) Sent(number string) { s.limit.Reset(number) }
+
+// RenderMessage returns the text of a message sent to the given number. If twoWay is set, alert messages will
+// include a reply code.
+func (s *SMSReplies) RenderMessage(ctx context.Context, number string, msg notification.Message, twoWay bool) (string, error) {
+	cfg := config.FromContext(ctx)
+
+	makeSMSCode := func(alertID int, serviceID string) int {
+		if !twoWay {
+			return 0
+		}
+
+		code, err := s.b.insertDB(ctx, number, msg.MsgID(), alertID, serviceID)
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "insert alert id for SMS callback -- sending 1-way SMS as fallback"))
+			return 0
+		}
+
+		return code
+	}
+
+	switch t := msg.(type) {
+	case notification.AlertStatus:
+		return renderAlertStatusMessage(cfg.ApplicationName(), t)
+	case notification.AlertBundle:
+		var link string
+		if canContainURL(ctx, number) {
+			link = cfg.CallbackURL(fmt.Sprintf("/services/%s/alerts", t.ServiceID))
+		}
+
+		return renderAlertBundleMessage(cfg.ApplicationName(), t, link, makeSMSCode(0, t.ServiceID))
+	case notification.Alert:
+		var link string
+		if canContainURL(ctx, number) {
+			link = cfg.CallbackURL(fmt.Sprintf("/alerts/%d", t.AlertID))
+		}
+
+		return renderAlertMessage(cfg.ApplicationName(), t, link, makeSMSCode(t.AlertID, ""))
+	case notification.Test:
+		return fmt.Sprintf("%s: Test message.", cfg.ApplicationName()), nil
+	case notification.Verification:
+		return fmt.Sprintf("%s: Verification code: %s", cfg.ApplicationName(), t.Code), nil
+	case notification.QuietHoursDigest:
+		return renderDigestMessage(cfg.ApplicationName(), t)
+	case notification.OverrideRequest:
+		message := fmt.Sprintf("%s: %s", cfg.ApplicationName(), t.Summary())
+		if canContainURL(ctx, number) {
+			message += " " + cfg.CallbackURL(fmt.Sprintf("/schedules/%s/overrides", t.ScheduleID))
+		}
+		return message, nil
+	}
+
+	return "", errors.Errorf("unhandled message type %T", msg)
+}
+
+// isStopMessage checks the body of the message against single-word matches
+// i.e. "stop" will unsubscribe, however "please stop" will not.
+func isStopMessage(body string) bool {
+	switch strings.ToLower(body) {
+	case "stop", "stopall", "unsubscribe", "cancel", "end", "quit":
+		return true
+	}
+
+	return false
+}
+
+// isStartMessage checks the body of the message against single-word matches
+// i.e. "start" will resubscribe, however "please start" will not.
+func isStartMessage(body string) bool {
+	switch strings.ToLower(body) {
+	case "start", "yes", "unstop":
+		return true
+	}
+
+	return false
+}
+
+// HandleReply processes an SMS received from a number, and returns the response that should be sent back, if any.
+// If twoWay is false, reply codes are not processed.
+func (s *SMSReplies) HandleReply(ctx context.Context, from, body string, twoWay bool) string {
+	ctx = log.WithField(ctx, "Number", from)
+
+	respond := func(isPassive bool, msg string) string {
+		if !isPassive {
+			// always reset if an action was taken
+			s.limit.Reset(from)
+		}
+
+		if s.limit.ShouldDrop(from) {
+			log.Debugf(ctx, "SMS passive reply limit reached for %s, not replying.", from)
+			return ""
+		}
+
+		if isPassive {
+			valid, err := s.r.IsKnownDest(ctx, s.dest(from))
+			if err != nil {
+				log.Log(ctx, fmt.Errorf("check if known SMS number: %w", err))
+			} else if !valid {
+				// don't respond if the number is not known
+				return ""
+			}
+			s.limit.RecordPassiveReply(from)
+		}
+
+		return msg
+	}
+
+	var err error
+	retryOpts := []retry.Option{
+		retry.Log(ctx),
+		retry.Limit(10),
+		retry.FibBackoff(time.Second),
+	}
+
+	// handle start and stop codes from user
+	if isStartMessage(body) {
+		err := retry.DoTemporaryError(func(int) error { return s.r.Start(ctx, s.dest(from)) }, retryOpts...)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("process START message: %w", err))
+		}
+		return ""
+	}
+	if isStopMessage(body) {
+		err := retry.DoTemporaryError(func(int) error { return s.r.Stop(ctx, s.dest(from)) }, retryOpts...)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("process STOP message: %w", err))
+		}
+		return ""
+	}
+
+	if !twoWay {
+		return respond(true, "Response codes are currently disabled. Visit the dashboard to manage alerts.")
+	}
+
+	body = strings.TrimSpace(body)
+	body = strings.ToLower(body)
+	var lookupFn func() (*codeInfo, error)
+	var result notification.Result
+	var snoozeDur time.Duration
+	var isSvc bool
+	if m := lastReplyRx.FindStringSubmatch(body); len(m) == 2 {
+		if strings.HasPrefix(m[1], "a") {
+			result = notification.ResultAcknowledge
+		} else if strings.HasPrefix(m[1], "e") {
+			result = notification.ResultEscalate
+		} else {
+			result = notification.ResultResolve
+		}
+		lookupFn = func() (*codeInfo, error) { return s.b.LookupByCode(ctx, from, 0) }
+	} else if m := shortReplyRx.FindStringSubmatch(body); len(m) == 3 {
+		if strings.HasPrefix(m[2], "a") {
+			result = notification.ResultAcknowledge
+		} else if strings.HasPrefix(m[2], "e") {
+			result = notification.ResultEscalate
+		} else {
+			result = notification.ResultResolve
+		}
+		code, err := strconv.Atoi(m[1])
+		if err != nil {
+			log.Debug(ctx, errors.Wrap(err, "parse code"))
+		} else {
+			ctx = log.WithField(ctx, "Code", code)
+			lookupFn = func() (*codeInfo, error) { return s.b.LookupByCode(ctx, from, code) }
+		}
+	} else if m := alertReplyRx.FindStringSubmatch(body); len(m) == 3 {
+		if strings.HasPrefix(m[1], "a") {
+			result = notification.ResultAcknowledge
+		} else if strings.HasPrefix(m[1], "e") {
+			result = notification.ResultEscalate
+		} else {
+			result = notification.ResultResolve
+		}
+		alertID, err := strconv.Atoi(m[2])
+		if err != nil {
+			log.Debug(ctx, errors.Wrap(err, "parse alertID"))
+		} else {
+			ctx = log.WithField(ctx, "AlertID", alertID)
+			lookupFn = func() (*codeInfo, error) { return s.b.LookupByAlertID(ctx, from, alertID) }
+		}
+	} else if m := svcReplyRx.FindStringSubmatch(body); len(m) == 3 {
+		isSvc = true
+		if strings.HasPrefix(m[2], "a") {
+			result = notification.ResultAcknowledge
+		} else if strings.HasPrefix(m[2], "e") {
+			result = notification.ResultEscalate
+		} else {
+			result = notification.ResultResolve
+		}
+		code, err := strconv.Atoi(m[1])
+		if err != nil {
+			log.Debug(ctx, errors.Wrap(err, "parse code"))
+		} else {
+			ctx = log.WithField(ctx, "Code", code)
+			lookupFn = func() (*codeInfo, error) { return s.b.LookupSvcByCode(ctx, from, code) }
+		}
+	} else if m := snoozeReplyRx.FindStringSubmatch(body); len(m) == 4 {
+		result = notification.ResultSnooze
+		snoozeDur, err = parseSnoozeDuration(m[2], m[3])
+		if err != nil {
+			return respond(true, "Sorry, but that isn't a snooze duration GoAlert understood. Use m, h, or d (e.g., 'snooze 2h').")
+		}
+		var code int
+		if m[1] != "" {
+			code, err = strconv.Atoi(m[1])
+		}
+		if err != nil {
+			log.Debug(ctx, errors.Wrap(err, "parse code"))
+		} else {
+			ctx = log.WithField(ctx, "Code", code)
+			lookupFn = func() (*codeInfo, error) { return s.b.LookupByCode(ctx, from, code) }
+		}
+	}
+
+	if lookupFn == nil {
+		ctx = log.WithField(ctx, "SMSBody", body)
+		log.Debug(ctx, errors.Wrap(err, "parse alert action"))
+		return respond(true, "Sorry, but that isn't a request GoAlert understood. Visit the Web UI for more information. To unsubscribe, reply with STOP.")
+	}
+
+	var prefix string
+	switch result {
+	case notification.ResultAcknowledge:
+		prefix = "Acknowledged"
+	case notification.ResultEscalate:
+		prefix = "Escalation requested"
+	case notification.ResultSnooze:
+		prefix = "Snoozed"
+	default:
+		prefix = "Closed"
+	}
+
+	var nonSystemErr bool
+	var info *codeInfo
+	err = retry.DoTemporaryError(func(int) error {
+		info, err = lookupFn()
+		if err != nil {
+			return errors.Wrap(err, "lookup code")
+		}
+
+		if result == notification.ResultSnooze {
+			err = s.r.ReceiveSnooze(ctx, info.CallbackID, snoozeDur)
+		} else {
+			err = s.r.Receive(ctx, info.CallbackID, result)
+		}
+		if err != nil {
+			return fmt.Errorf("process notification response: %w", err)
+		}
+		return nil
+	}, retryOpts...)
+
+	if errors.Is(err, sql.ErrNoRows) || (isSvc && info.ServiceName == "") || (!isSvc && info.AlertID == 0) {
+		return respond(true, "Unknown reply code for this action. Visit the dashboard to manage alerts.")
+	}
+
+	if alert.IsAckPending(err) {
+		return respond(false, fmt.Sprintf("Recorded acknowledgement for alert #%d, more acknowledgements are required", alert.AlertID(err)))
+	}
+
+	msg := "System error. Visit the dashboard to manage alerts."
+	if alert.IsAlreadyClosed(err) {
+		nonSystemErr = true
+		msg = fmt.Sprintf("Alert #%d already closed", alert.AlertID(err))
+	} else if alert.IsAlreadyAcknowledged(err) {
+		nonSystemErr = true
+		msg = fmt.Sprintf("Alert #%d already acknowledged", alert.AlertID(err))
+	} else if validation.IsClientError(err) {
+		return respond(true, "Error: "+stderrors.Unwrap(err).Error())
+	}
+
+	if nonSystemErr {
+		var e alert.LogEntryFetcher
+		// alert store returns the special error struct, twilio checks if it's special, and if so, pulls the log entry
+		if errors.As(err, &e) {
+			// we pass a 'sudo' context to give permission
+			permission.SudoContext(ctx, func(sCtx context.Context) {
+				entry, err := e.LogEntry(sCtx)
+				if err != nil {
+					log.Log(sCtx, errors.Wrap(err, "fetch log entry"))
+				} else {
+					msg += "\n\n" + entry.String(ctx)
+				}
+			})
+		} else {
+			log.Log(ctx, errors.Wrap(err, "process notification response"))
+		}
+		return respond(true, msg)
+	}
+
+	if err != nil {
+		log.Log(ctx, err)
+		return respond(true, msg)
+	}
+
+	if info.ServiceName != "" {
+		return respond(false, fmt.Sprintf("%s all alerts for service '%s'", prefix, info.ServiceName))
+	}
+	if result == notification.ResultSnooze {
+		return respond(false, fmt.Sprintf("%s alert #%d for %s", prefix, info.AlertID, snoozeString(snoozeDur)))
+	}
+
+	return respond(false, fmt.Sprintf("%s alert #%d", prefix, info.AlertID))
+}
@@ -0,0 +1,59 @@
+package twilio
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/target/goalert/validation"
+)
+
+// snoozeReplyRx matches snooze replies, with an optional code, like `snooze 1h`, `1s 30m`, or `2 snooze 1d`.
+var snoozeReplyRx = regexp.MustCompile(`^'?\s*([0-9]+)?\s*(?:s|snooze)\s*(?:([0-9]+)\s*([a-z]*))?\s*'?$`)
+
+// defaultSnooze is used when a snooze reply does not include a duration.
+const defaultSnooze = time.Hour
+
+// parseSnoozeDuration will parse the amount and unit of a snooze reply. A missing
+// unit is treated as minutes, and a missing amount results in defaultSnooze.
+func parseSnoozeDuration(amount, unit string) (time.Duration, error) {
+	if amount == "" {
+		return defaultSnooze, nil
+	}
+
+	n, err := strconv.Atoi(amount)
+	if err != nil {
+		return 0, validation.NewFieldError("Duration", "invalid snooze duration")
+	}
+
+	switch unit {
+	case "", "m", "min", "mins", "minute", "minutes":
+		return time.Duration(n) * time.Minute, nil
+	case "h", "hr", "hrs", "hour", "hours":
+		return time.Duration(n) * time.Hour, nil
+	case "d", "day", "days":
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	return 0, validation.NewFieldErrorf("Duration", "unknown snooze unit '%s', use m, h, or d", unit)
+}
+
+// snoozeString returns a human-readable snooze duration (e.g., `2 hours`).
+func snoozeString(d time.Duration) string {
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return "1 " + unit
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+
+	switch {
+	case d%(24*time.Hour) == 0:
+		return plural(int(d/(24*time.Hour)), "day")
+	case d%time.Hour == 0:
+		return plural(int(d/time.Hour), "hour")
+	}
+
+	return plural(int(d/time.Minute), "minute")
+}
@@ -0,0 +1,44 @@
+package twilio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnoozeReply(t *testing.T) {
+	check := func(body, code string, exp time.Duration) {
+		t.Helper()
+		m := snoozeReplyRx.FindStringSubmatch(body)
+		require.Len(t, m, 4, body)
+		assert.Equal(t, code, m[1], body)
+
+		dur, err := parseSnoozeDuration(m[2], m[3])
+		require.NoError(t, err, body)
+		assert.Equal(t, exp, dur, body)
+	}
+
+	check("snooze 1h", "", time.Hour)
+	check("snooze", "", defaultSnooze)
+	check("1s 30m", "1", 30*time.Minute)
+	check("'12s2h'", "12", 2*time.Hour)
+	check("3 snooze 1 day", "3", 24*time.Hour)
+	check("s 45", "", 45*time.Minute)
+
+	for _, body := range []string{"1a", "snooze me", "1c 1h", "stop"} {
+		assert.Nil(t, snoozeReplyRx.FindStringSubmatch(body), body)
+	}
+
+	m := snoozeReplyRx.FindStringSubmatch("snooze 1w")
+	require.Len(t, m, 4)
+	_, err := parseSnoozeDuration(m[2], m[3])
+	assert.Error(t, err)
+}
+
+func TestSnoozeString(t *testing.T) {
+	assert.Equal(t, "1 hour", snoozeString(time.Hour))
+	assert.Equal(t, "90 minutes", snoozeString(90*time.Minute))
+	assert.Equal(t, "2 days", snoozeString(48*time.Hour))
+}
@@ -76,6 +76,10 @@ func NewVoiceDest(number string) gadb.DestV1 {
 }
 
 func voiceErrorMessage(ctx context.Context, err error) (string, error) {
+	if alert.IsAckPending(err) {
+		return "Acknowledgement recorded. More acknowledgements are required.", nil
+	}
+
 	var e alert.LogEntryFetcher
 	if errors.As(err, &e) {
 		// we pass a 'sudo' context to give permission
@@ -625,6 +629,10 @@ func buildMessage(prefix string, msg notification.Message) (message string, err
 		message = fmt.Sprintf("%s with a status update for alert '%s'. %s", prefix, t.Summary, message)
 	case notification.Test:
 		message = fmt.Sprintf("%s with a test message.", prefix)
+	case notification.OverrideRequest:
+		message = fmt.Sprintf("%s with an override request. %s Visit the schedule to accept or decline.", prefix, t.Summary())
+	case notification.QuietHoursDigest:
+		message = fmt.Sprintf("%s with a quiet hours digest. %s", prefix, t.Summary())
 	case notification.Verification:
 		message = fmt.Sprintf(
 			"%s with your %d-digit verification code. The code is: %s. Again, your %d-digit verification code is: %s.",
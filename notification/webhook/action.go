@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+)
+
+// ActionURLTTL is how long action URLs are valid after a notification is sent.
+const ActionURLTTL = 24 * time.Hour
+
+const (
+	actionAck      = "ack"
+	actionClose    = "close"
+	actionEscalate = "escalate"
+
+	actionIssuer   = "goalert"
+	actionAudience = "webhook-action"
+)
+
+// POSTDataActions contains signed URLs that can be used to respond to a notification with a POST request.
+type POSTDataActions struct {
+	AcknowledgeURL string
+	CloseURL       string
+	EscalateURL    string `json:",omitempty"`
+	ExpiresAt      time.Time
+}
+
+var _ notification.ReceiverSetter = &Sender{}
+
+// SetReceiver sets the notification.Receiver for action callbacks.
+func (s *Sender) SetReceiver(r notification.Receiver) { s.r = r }
+
+func (s *Sender) actionURL(cfg config.Config, callbackID, action string, now, expires time.Time) (string, error) {
+	var c jwt.RegisteredClaims
+	c.ID = callbackID
+	c.Subject = action
+	c.Audience = jwt.ClaimStrings{actionAudience}
+	c.Issuer = actionIssuer
+	c.NotBefore = jwt.NewNumericDate(now.Add(-2 * time.Minute))
+	c.ExpiresAt = jwt.NewNumericDate(expires)
+	c.IssuedAt = jwt.NewNumericDate(now)
+
+	token, err := s.actionKeys.SignJWT(c)
+	if err != nil {
+		return "", err
+	}
+
+	return cfg.CallbackURL("/api/v2/webhook/action", url.Values{"token": {token}}), nil
+}
+
+// actions returns the action URLs for a message, or nil if they are disabled. Escalation is only supported for
+// individual alerts.
+func (s *Sender) actions(ctx context.Context, callbackID string, escalate bool) (*POSTDataActions, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.Webhook.ActionURLs || s.actionKeys == nil || s.r == nil {
+		return nil, nil
+	}
+
+	now := time.Now()
+	a := &POSTDataActions{ExpiresAt: now.Add(ActionURLTTL).Truncate(time.Second)}
+
+	var err error
+	a.AcknowledgeURL, err = s.actionURL(cfg, callbackID, actionAck, now, a.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	a.CloseURL, err = s.actionURL(cfg, callbackID, actionClose, now, a.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if escalate {
+		a.EscalateURL, err = s.actionURL(cfg, callbackID, actionEscalate, now, a.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return a, nil
+}
+
+// ServeAction will process a request to a signed action URL.
+func (s *Sender) ServeAction(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+	if !cfg.Webhook.ActionURLs || s.actionKeys == nil || s.r == nil {
+		http.Error(w, "not enabled", http.StatusNotFound)
+		return
+	}
+
+	var c jwt.RegisteredClaims
+	_, err := s.actionKeys.VerifyJWT(req.URL.Query().Get("token"), &c, actionIssuer, actionAudience)
+	if err != nil {
+		log.Debug(ctx, err)
+		http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+		return
+	}
+
+	var res notification.Result
+	switch c.Subject {
+	case actionAck:
+		res = notification.ResultAcknowledge
+	case actionClose:
+		res = notification.ResultResolve
+	case actionEscalate:
+		res = notification.ResultEscalate
+	default:
+		errutil.HTTPError(ctx, w, errors.New("unknown action"))
+		return
+	}
+
+	err = s.r.Receive(ctx, c.ID, res)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
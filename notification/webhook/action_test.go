@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/keyring"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/nfymsg"
+)
+
+// hmacKeyring is a keyring.Keyring that signs JWTs with a static key.
+type hmacKeyring struct{ keyring.Keyring }
+
+func (hmacKeyring) SignJWT(c jwt.Claims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte("secret"))
+}
+
+func (hmacKeyring) VerifyJWT(s string, c jwt.Claims, iss, aud string) (bool, error) {
+	_, err := jwt.ParseWithClaims(s, c, func(*jwt.Token) (any, error) { return []byte("secret"), nil },
+		jwt.WithIssuer(iss), jwt.WithAudience(aud))
+	return err == nil, err
+}
+
+type receiver struct {
+	notification.Receiver
+	callbackID string
+	result     notification.Result
+}
+
+func (r *receiver) Receive(ctx context.Context, callbackID string, result notification.Result) error {
+	r.callbackID = callbackID
+	r.result = result
+	return nil
+}
+
+func TestSender_ServeAction(t *testing.T) {
+	var cfg config.Config
+	cfg.General.PublicURL = "http://goalert.example.com"
+	cfg.Webhook.ActionURLs = true
+	ctx := cfg.Context(context.Background())
+
+	var recv receiver
+	s := NewSender(ctx, Config{Client: http.DefaultClient, ActionKeys: hmacKeyring{}})
+	s.SetReceiver(&recv)
+	srv, reqCh := testServer(t, http.StatusOK)
+
+	const callbackID = "6e5c5a29-9d4c-4b8c-8d2d-6b1b6f0a5a8e"
+	_, err := s.SendMessage(ctx, notification.Alert{Base: nfymsg.Base{ID: callbackID, Dest: NewWebhookDest(srv.URL)}, AlertID: 1})
+	require.NoError(t, err)
+
+	var payload POSTDataAlert
+	require.NoError(t, json.Unmarshal([]byte((<-reqCh).body), &payload))
+	require.NotNil(t, payload.Actions)
+
+	serve := func(actionURL string) int {
+		t.Helper()
+		u, err := url.Parse(actionURL)
+		require.NoError(t, err)
+		assert.Equal(t, "/api/v2/webhook/action", u.Path)
+
+		req := httptest.NewRequest("POST", u.RequestURI(), nil).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		s.ServeAction(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, serve(payload.Actions.EscalateURL))
+	assert.Equal(t, callbackID, recv.callbackID)
+	assert.Equal(t, notification.ResultEscalate, recv.result)
+
+	assert.Equal(t, http.StatusNoContent, serve(payload.Actions.CloseURL))
+	assert.Equal(t, notification.ResultResolve, recv.result)
+
+	assert.Equal(t, http.StatusUnauthorized, serve("/api/v2/webhook/action?token=invalid"))
+}
@@ -2,7 +2,10 @@ package webhook
 
 import (
 	"context"
+	"fmt"
 	"net/url"
+	"strings"
+	"unicode"
 
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/gadb"
@@ -12,11 +15,15 @@ import (
 )
 
 const (
-	DestTypeWebhook  = "builtin-webhook"
-	FieldWebhookURL  = "webhook_url"
-	ParamBody        = "body"
-	ParamContentType = "content_type"
-	FallbackIconURL  = "builtin://webhook"
+	DestTypeWebhook    = "builtin-webhook"
+	FieldWebhookURL    = "webhook_url"
+	FieldSigningSecret = "signing_secret"
+	FieldBearerToken   = "bearer_token"
+	FieldHeaders       = "headers"
+	FieldBodyTemplate  = "body_template"
+	ParamBody          = "body"
+	ParamContentType   = "content_type"
+	FallbackIconURL    = "builtin://webhook"
 )
 
 func NewWebhookDest(url string) gadb.DestV1 {
@@ -47,6 +54,31 @@ func (Sender) TypeInfo(ctx context.Context) (*nfydest.TypeInfo, error) {
 			Hint:               "Webhook Documentation",
 			HintURL:            "/docs#webhooks",
 			SupportsValidation: true,
+		}, {
+			FieldID:   FieldSigningSecret,
+			Label:     "Signing Secret (optional)",
+			InputType: "password",
+			Hint:      "Requests will be signed with HMAC-SHA256, and error responses will fail the message. Stored encrypted.",
+			HintURL:   "/docs#webhooks",
+		}, {
+			FieldID:   FieldBearerToken,
+			Label:     "Bearer Token (optional)",
+			InputType: "password",
+			Hint:      "Sent in the Authorization header. Stored encrypted.",
+		}, {
+			FieldID:            FieldHeaders,
+			Label:              "Custom Headers (optional)",
+			PlaceholderText:    "X-Example: value",
+			InputType:          "textarea",
+			Hint:               "One 'Name: Value' header per line.",
+			SupportsValidation: true,
+		}, {
+			FieldID:            FieldBodyTemplate,
+			Label:              "Body Template (optional)",
+			InputType:          "textarea",
+			Hint:               "Go template used instead of the default JSON body.",
+			HintURL:            "/docs#webhooks",
+			SupportsValidation: true,
 		}},
 		DynamicParams: []nfydest.DynamicParamConfig{
 			{
@@ -77,11 +109,88 @@ func (s *Sender) ValidateField(ctx context.Context, fieldID, value string) error
 		}
 
 		return nil
+	case FieldSigningSecret:
+		return s.validateSecret(value, MinSigningSecretLen)
+	case FieldBearerToken:
+		return s.validateSecret(value, 1)
+	case FieldHeaders:
+		_, err := parseHeaders(value)
+		return err
+	case FieldBodyTemplate:
+		err := validate.Text(FieldBodyTemplate, value, 1, MaxBodyTemplateLen)
+		if err != nil {
+			return err
+		}
+		_, err = parseBodyTemplate(value)
+		return err
 	}
 
 	return validation.NewGenericError("unknown field ID")
 }
 
+// validateSecret will validate a secret value, which may be empty, or already encrypted. An encrypted value is
+// only accepted by NormalizeDest for the destination it was encrypted for.
+func (s *Sender) validateSecret(value string, min int) error {
+	if value == "" {
+		return nil
+	}
+	if isEncrypted(value) {
+		_, err := s.decrypt(value)
+		if err != nil {
+			return validation.NewGenericError("unable to decrypt existing secret")
+		}
+		return nil
+	}
+	if len(value) < min {
+		return validation.NewGenericError(fmt.Sprintf("must be at least %d characters", min))
+	}
+	if len(value) > MaxSecretLen {
+		return validation.NewGenericError(fmt.Sprintf("cannot exceed %d characters", MaxSecretLen))
+	}
+	if strings.ContainsFunc(value, unicode.IsSpace) {
+		return validation.NewGenericError("must not contain spaces")
+	}
+
+	return nil
+}
+
+var _ nfydest.DestNormalizer = (*Sender)(nil)
+
+// NormalizeDest will encrypt secrets and remove empty optional fields so that webhooks without them are
+// stored the same as before they existed.
+//
+// Secrets that are already encrypted are kept only if the rest of the destination is unchanged; otherwise
+// they must be provided again, in plain text.
+func (s *Sender) NormalizeDest(ctx context.Context, dest gadb.DestV1) (gadb.DestV1, error) {
+	for _, field := range []string{FieldSigningSecret, FieldBearerToken, FieldHeaders, FieldBodyTemplate} {
+		if dest.Args[field] == "" {
+			delete(dest.Args, field)
+		}
+	}
+
+	for _, field := range []string{FieldSigningSecret, FieldBearerToken} {
+		value := dest.Args[field]
+		if value == "" {
+			continue
+		}
+		if isEncrypted(value) {
+			_, err := s.decryptSecret(dest.Arg, value)
+			if err != nil {
+				return dest, &nfydest.DestArgError{FieldID: field, Err: validation.NewGenericError("must be entered again")}
+			}
+			continue
+		}
+
+		enc, err := s.encryptSecret(dest.Arg, value)
+		if err != nil {
+			return dest, fmt.Errorf("encrypt %s: %w", field, err)
+		}
+		dest.Args[field] = enc
+	}
+
+	return dest, nil
+}
+
 func (s *Sender) DisplayInfo(ctx context.Context, args map[string]string) (*nfydest.DisplayInfo, error) {
 	if args == nil {
 		args = make(map[string]string)
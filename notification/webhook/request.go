@@ -0,0 +1,188 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/target/goalert/validation"
+)
+
+const (
+	// MinSigningSecretLen is the minimum length of a signing secret.
+	MinSigningSecretLen = 16
+
+	// MaxSecretLen is the maximum length of a signing secret or bearer token.
+	MaxSecretLen = 4096
+
+	// MaxHeaders is the maximum number of custom headers.
+	MaxHeaders = 20
+
+	// MaxBodyTemplateLen is the maximum length of a body template.
+	MaxBodyTemplateLen = 16 * 1024
+
+	// HeaderSignature contains the hex-encoded HMAC-SHA256 signature of the request, prefixed with `sha256=`.
+	HeaderSignature = "X-Webhook-Signature"
+
+	// HeaderTimestamp contains the unix timestamp the request was signed at.
+	HeaderTimestamp = "X-Webhook-Timestamp"
+
+	secretLabel = "WEBHOOK SECRET"
+)
+
+var (
+	headerNameRx = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+.^_`|~-]+$")
+
+	// reservedHeaders are set by the sender and cannot be overridden.
+	reservedHeaders = []string{"Host", "Content-Length", "Transfer-Encoding", "Connection", HeaderSignature, HeaderTimestamp}
+)
+
+func isEncrypted(value string) bool { return strings.HasPrefix(value, "-----BEGIN ") }
+
+// decrypt will return the plain-text value of an encrypted secret.
+func (s *Sender) decrypt(value string) (string, error) {
+	data, label, err := s.keys.Decrypt([]byte(value))
+	if err != nil {
+		return "", err
+	}
+	if label != secretLabel {
+		return "", fmt.Errorf("unexpected label '%s'", label)
+	}
+
+	return string(data), nil
+}
+
+// secretBinding returns a digest of the destination fields other than the secrets. It is encrypted along
+// with a secret, so an encrypted secret can't be copied to another destination (e.g., a different URL or
+// body template) to have it sent, or signed for, somewhere else.
+func secretBinding(arg func(fieldID string) string) []byte {
+	h := sha256.New()
+	for _, field := range []string{FieldWebhookURL, FieldHeaders, FieldBodyTemplate} {
+		val := arg(field)
+		fmt.Fprintf(h, "%d:%s,", len(val), val)
+	}
+
+	return h.Sum(nil)
+}
+
+// encryptSecret will encrypt a secret for the destination with the given fields.
+func (s *Sender) encryptSecret(arg func(fieldID string) string, value string) (string, error) {
+	enc, err := s.keys.Encrypt(secretLabel, append(secretBinding(arg), value...))
+	if err != nil {
+		return "", err
+	}
+
+	return string(enc), nil
+}
+
+// decryptSecret will return the plain-text value of a secret, which must have been encrypted for the
+// destination with the given fields.
+func (s *Sender) decryptSecret(arg func(fieldID string) string, value string) (string, error) {
+	data, err := s.decrypt(value)
+	if err != nil {
+		return "", err
+	}
+
+	binding := secretBinding(arg)
+	if len(data) < len(binding) || !hmac.Equal([]byte(data[:len(binding)]), binding) {
+		return "", errors.New("encrypted for a different destination")
+	}
+
+	return data[len(binding):], nil
+}
+
+// parseHeaders will parse custom headers, one `Name: Value` pair per line.
+func parseHeaders(value string) (http.Header, error) {
+	h := make(http.Header)
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, val, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, validation.NewFieldError(FieldHeaders, fmt.Sprintf("invalid header '%s', must be in the format 'Name: Value'", line))
+		}
+		name = strings.TrimSpace(name)
+		val = strings.TrimSpace(val)
+		if !headerNameRx.MatchString(name) {
+			return nil, validation.NewFieldError(FieldHeaders, fmt.Sprintf("invalid header name '%s'", name))
+		}
+		if strings.ContainsFunc(val, func(r rune) bool { return r != '\t' && unicode.IsControl(r) }) {
+			return nil, validation.NewFieldError(FieldHeaders, fmt.Sprintf("invalid value for header '%s'", name))
+		}
+
+		name = textproto.CanonicalMIMEHeaderKey(name)
+		for _, r := range reservedHeaders {
+			if name == r {
+				return nil, validation.NewFieldError(FieldHeaders, fmt.Sprintf("header '%s' cannot be set", name))
+			}
+		}
+		h.Add(name, val)
+	}
+	if len(h) > MaxHeaders {
+		return nil, validation.NewFieldError(FieldHeaders, fmt.Sprintf("cannot have more than %d headers", MaxHeaders))
+	}
+
+	return h, nil
+}
+
+var templateFuncs = template.FuncMap{
+	// json will encode a value as JSON, so it can be safely embedded in a JSON body.
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+func parseBodyTemplate(value string) (*template.Template, error) {
+	tmpl, err := template.New("body").Funcs(templateFuncs).Parse(value)
+	if err != nil {
+		return nil, validation.NewFieldError(FieldBodyTemplate, err.Error())
+	}
+
+	return tmpl, nil
+}
+
+// renderBody will render the body template with the payload of a message.
+func renderBody(value string, payload any) ([]byte, error) {
+	tmpl, err := parseBodyTemplate(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// signature returns the hex-encoded HMAC-SHA256 of the timestamp and body, separated by a period.
+func signature(secret string, ts time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(ts.Unix(), 10) + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// sign will add signature headers to the request.
+func sign(req *http.Request, secret string, ts time.Time, body []byte) {
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts.Unix(), 10))
+	req.Header.Set(HeaderSignature, "sha256="+signature(secret, ts, body))
+}
@@ -9,12 +9,28 @@ import (
 	"time"
 
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notification/nfydest"
 )
 
 type Sender struct {
 	Client *http.Client
+
+	keys       keyring.Keys
+	actionKeys keyring.Keyring
+	r          notification.Receiver
+}
+
+// Config contains the dependencies of a Sender.
+type Config struct {
+	Client *http.Client
+
+	// Keys are used to encrypt signing secrets and bearer tokens.
+	Keys keyring.Keys
+
+	// ActionKeys, if set, are used to sign action URLs.
+	ActionKeys keyring.Keyring
 }
 
 // POSTDataAlert represents fields in outgoing alert notification.
@@ -27,6 +43,8 @@ type POSTDataAlert struct {
 	ServiceID   string
 	ServiceName string
 	Meta        map[string]string
+	Priority    int              `json:",omitempty"`
+	Actions     *POSTDataActions `json:",omitempty"`
 }
 
 // POSTDataAlertBundle represents fields in outgoing alert bundle notification.
@@ -36,6 +54,7 @@ type POSTDataAlertBundle struct {
 	ServiceID   string
 	ServiceName string
 	Count       int
+	Actions     *POSTDataActions `json:",omitempty"`
 }
 
 // POSTDataAlertStatus represents fields in outgoing alert status notification.
@@ -79,15 +98,60 @@ type POSTDataOnCallNotification struct {
 	ScheduleURL  string
 }
 
+// POSTDataOverrideRequest represents fields in outgoing override request notification.
+type POSTDataOverrideRequest struct {
+	AppName        string
+	Type           string
+	RequestID      string
+	ScheduleID     string
+	ScheduleName   string
+	RequesterName  string
+	AddUserName    string
+	RemoveUserName string
+	Start          time.Time
+	End            time.Time
+	Summary        string
+	URL            string
+}
+
+// POSTDataCoverageGap represents fields in outgoing schedule coverage gap notification.
+type POSTDataCoverageGap struct {
+	AppName      string
+	Type         string
+	ScheduleID   string
+	ScheduleName string
+	ScheduleURL  string
+	Start        time.Time
+	End          time.Time
+}
+
+// POSTDataDigestItem represents a single notification held during quiet hours.
+type POSTDataDigestItem struct {
+	Time    time.Time
+	Summary string
+	URL     string
+}
+
+// POSTDataQuietHoursDigest represents fields in outgoing quiet hours digest notification.
+type POSTDataQuietHoursDigest struct {
+	AppName string
+	Type    string
+	Summary string
+	Items   []POSTDataDigestItem
+}
+
 // POSTDataTest represents fields in outgoing test notification.
 type POSTDataTest struct {
 	AppName string
 	Type    string
 }
 
-func NewSender(ctx context.Context, client *http.Client) *Sender {
+// NewSender will create a new Sender.
+func NewSender(ctx context.Context, cfg Config) *Sender {
 	return &Sender{
-		Client: client,
+		Client:     cfg.Client,
+		keys:       cfg.Keys,
+		actionKeys: cfg.ActionKeys,
 	}
 }
 
@@ -96,6 +160,120 @@ var _ nfydest.MessageSender = &Sender{}
 // Send will send an alert for the provided message type
 func (s *Sender) SendMessage(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
 	cfg := config.FromContext(ctx)
+
+	webURL := msg.DestArg(FieldWebhookURL)
+	if !cfg.ValidWebhookURL(webURL) {
+		// fail permanently if the URL is not currently valid/allowed
+		return &notification.SentMessage{
+			State:        notification.StateFailedPerm,
+			StateDetails: "invalid or not allowed URL",
+		}, nil
+	}
+
+	var data []byte
+	contentType := "application/json"
+	if m, ok := msg.(notification.SignalMessage); ok {
+		data = []byte(m.Param(ParamBody))
+		if ct := m.Param(ParamContentType); ct != "" {
+			contentType = ct
+		}
+	} else {
+		payload, err := s.payload(ctx, msg)
+		if err != nil {
+			return nil, err
+		}
+
+		if tmpl := msg.DestArg(FieldBodyTemplate); tmpl != "" {
+			data, err = renderBody(tmpl, payload)
+			if err != nil {
+				return &notification.SentMessage{
+					State:        notification.StateFailedPerm,
+					StateDetails: "render body template: " + err.Error(),
+				}, nil
+			}
+		} else {
+			data, err = json.Marshal(payload)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	headers, err := parseHeaders(msg.DestArg(FieldHeaders))
+	if err != nil {
+		return &notification.SentMessage{
+			State:        notification.StateFailedPerm,
+			StateDetails: err.Error(),
+		}, nil
+	}
+
+	var secret, token string
+	if v := msg.DestArg(FieldSigningSecret); v != "" {
+		secret, err = s.decryptSecret(msg.DestArg, v)
+		if err != nil {
+			return &notification.SentMessage{
+				State:        notification.StateFailedPerm,
+				StateDetails: "decrypt signing secret: " + err.Error(),
+			}, nil
+		}
+	}
+	if v := msg.DestArg(FieldBearerToken); v != "" {
+		token, err = s.decryptSecret(msg.DestArg, v)
+		if err != nil {
+			return &notification.SentMessage{
+				State:        notification.StateFailedPerm,
+				StateDetails: "decrypt bearer token: " + err.Error(),
+			}, nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", webURL, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if secret != "" {
+		sign(req, secret, time.Now(), data)
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if secret == "" {
+		// the response status of unsigned webhooks is ignored, as existing receivers may not return a success status
+		return &notification.SentMessage{State: notification.StateSent}, nil
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return &notification.SentMessage{State: notification.StateFailedTemp, StateDetails: resp.Status}, nil
+	case resp.StatusCode >= 400:
+		return &notification.SentMessage{State: notification.StateFailedPerm, StateDetails: resp.Status}, nil
+	}
+
+	return &notification.SentMessage{State: notification.StateSent}, nil
+}
+
+// payload returns the default payload for a message, which is also the data passed to body templates.
+func (s *Sender) payload(ctx context.Context, msg notification.Message) (any, error) {
+	cfg := config.FromContext(ctx)
 	var payload interface{}
 	switch m := msg.(type) {
 	case notification.Test:
@@ -110,6 +288,10 @@ func (s *Sender) SendMessage(ctx context.Context, msg notification.Message) (*no
 			Code:    m.Code,
 		}
 	case notification.Alert:
+		actions, err := s.actions(ctx, m.MsgID(), true)
+		if err != nil {
+			return nil, err
+		}
 		payload = POSTDataAlert{
 			AppName:     cfg.ApplicationName(),
 			Type:        "Alert",
@@ -119,14 +301,21 @@ func (s *Sender) SendMessage(ctx context.Context, msg notification.Message) (*no
 			ServiceID:   m.ServiceID,
 			ServiceName: m.ServiceName,
 			Meta:        m.Meta,
+			Priority:    m.Priority,
+			Actions:     actions,
 		}
 	case notification.AlertBundle:
+		actions, err := s.actions(ctx, m.MsgID(), false)
+		if err != nil {
+			return nil, err
+		}
 		payload = POSTDataAlertBundle{
 			AppName:     cfg.ApplicationName(),
 			Type:        "AlertBundle",
 			ServiceID:   m.ServiceID,
 			ServiceName: m.ServiceName,
 			Count:       m.Count,
+			Actions:     actions,
 		}
 	case notification.AlertStatus:
 		payload = POSTDataAlertStatus{
@@ -150,38 +339,45 @@ func (s *Sender) SendMessage(ctx context.Context, msg notification.Message) (*no
 			ScheduleName: m.ScheduleName,
 			ScheduleURL:  m.ScheduleURL,
 		}
+	case notification.ScheduleCoverageGap:
+		payload = POSTDataCoverageGap{
+			AppName:      cfg.ApplicationName(),
+			Type:         "ScheduleCoverageGap",
+			ScheduleID:   m.ScheduleID,
+			ScheduleName: m.ScheduleName,
+			ScheduleURL:  m.ScheduleURL,
+			Start:        m.Start,
+			End:          m.End,
+		}
+	case notification.OverrideRequest:
+		payload = POSTDataOverrideRequest{
+			AppName:        cfg.ApplicationName(),
+			Type:           "OverrideRequest",
+			RequestID:      m.RequestID,
+			ScheduleID:     m.ScheduleID,
+			ScheduleName:   m.ScheduleName,
+			RequesterName:  m.RequesterName,
+			AddUserName:    m.AddUserName,
+			RemoveUserName: m.RemoveUserName,
+			Start:          m.Start,
+			End:            m.End,
+			Summary:        m.Summary(),
+			URL:            cfg.CallbackURL("/schedules/" + m.ScheduleID + "/overrides"),
+		}
+	case notification.QuietHoursDigest:
+		items := make([]POSTDataDigestItem, len(m.Items))
+		for i, item := range m.Items {
+			items[i] = POSTDataDigestItem(item)
+		}
+		payload = POSTDataQuietHoursDigest{
+			AppName: cfg.ApplicationName(),
+			Type:    "QuietHoursDigest",
+			Summary: m.Summary(),
+			Items:   items,
+		}
 	default:
 		return nil, fmt.Errorf("message type '%T' not supported", m)
 	}
 
-	data, err := json.Marshal(payload)
-	if err != nil {
-		return nil, err
-	}
-
-	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
-	defer cancel()
-
-	webURL := msg.DestArg(FieldWebhookURL)
-	if !cfg.ValidWebhookURL(webURL) {
-		// fail permanently if the URL is not currently valid/allowed
-		return &notification.SentMessage{
-			State:        notification.StateFailedPerm,
-			StateDetails: "invalid or not allowed URL",
-		}, nil
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "POST", webURL, bytes.NewReader(data))
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Add("Content-Type", "application/json")
-
-	_, err = http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	return &notification.SentMessage{State: notification.StateSent}, nil
+	return payload, nil
 }
@@ -0,0 +1,159 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/keyring"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/nfydest"
+	"github.com/target/goalert/notification/nfymsg"
+)
+
+type request struct {
+	header http.Header
+	body   string
+}
+
+func testServer(t *testing.T, status int) (*httptest.Server, <-chan request) {
+	t.Helper()
+	ch := make(chan request, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		ch <- request{header: r.Header, body: string(data)}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, ch
+}
+
+func testContext() context.Context {
+	var cfg config.Config
+	cfg.General.ApplicationName = "GoAlert"
+	return cfg.Context(context.Background())
+}
+
+func TestSender_SendMessage(t *testing.T) {
+	ctx := testContext()
+	s := NewSender(ctx, Config{Client: http.DefaultClient, Keys: keyring.Keys{[]byte("test-key")}})
+	srv, reqCh := testServer(t, http.StatusOK)
+
+	dest, err := s.NormalizeDest(ctx, gadb.DestV1{Type: DestTypeWebhook, Args: map[string]string{
+		FieldWebhookURL:    srv.URL,
+		FieldSigningSecret: "0123456789abcdef",
+		FieldBearerToken:   "tok",
+		FieldHeaders:       "X-Foo: bar\nContent-Type: text/plain",
+		FieldBodyTemplate:  `{{.Type}} #{{.AlertID}}: {{json .Summary}}`,
+	}})
+	require.NoError(t, err)
+	assert.True(t, isEncrypted(dest.Args[FieldSigningSecret]), "signing secret should be encrypted")
+	assert.True(t, isEncrypted(dest.Args[FieldBearerToken]), "bearer token should be encrypted")
+
+	again, err := s.NormalizeDest(ctx, dest)
+	require.NoError(t, err)
+	assert.Equal(t, dest, again, "normalize should be idempotent")
+
+	// encrypted secrets can't be copied to another destination
+	other := gadb.NewDestV1(DestTypeWebhook, FieldWebhookURL, srv.URL+"/other", FieldBearerToken, dest.Args[FieldBearerToken])
+	_, err = s.NormalizeDest(ctx, other)
+	var argErr *nfydest.DestArgError
+	require.ErrorAs(t, err, &argErr)
+	assert.Equal(t, FieldBearerToken, argErr.FieldID)
+	res, err := s.SendMessage(ctx, notification.Test{Base: nfymsg.Base{Dest: other}})
+	require.NoError(t, err)
+	assert.Equal(t, notification.StateFailedPerm, res.State)
+
+	// or used with a different body template
+	other = gadb.DestV1{Type: DestTypeWebhook, Args: maps.Clone(dest.Args)}
+	other.Args[FieldBodyTemplate] = `{{.Summary}}`
+	_, err = s.NormalizeDest(ctx, other)
+	require.ErrorAs(t, err, &argErr)
+
+	res, err = s.SendMessage(ctx, notification.Alert{Base: nfymsg.Base{Dest: dest}, AlertID: 123, Summary: `a "quoted" summary`})
+	require.NoError(t, err)
+	assert.Equal(t, notification.StateSent, res.State)
+
+	req := <-reqCh
+	assert.Equal(t, `Alert #123: "a \"quoted\" summary"`, req.body)
+	assert.Equal(t, "bar", req.header.Get("X-Foo"))
+	assert.Equal(t, "text/plain", req.header.Get("Content-Type"))
+	assert.Equal(t, "Bearer tok", req.header.Get("Authorization"))
+
+	ts, err := strconv.ParseInt(req.header.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, "sha256="+signature("0123456789abcdef", time.Unix(ts, 0), []byte(req.body)), req.header.Get(HeaderSignature))
+}
+
+func TestSender_SendMessage_Signal(t *testing.T) {
+	ctx := testContext()
+	s := NewSender(ctx, Config{Client: http.DefaultClient})
+	srv, reqCh := testServer(t, http.StatusOK)
+
+	res, err := s.SendMessage(ctx, notification.SignalMessage{
+		Base:   nfymsg.Base{Dest: NewWebhookDest(srv.URL)},
+		Params: map[string]string{ParamBody: "hello", ParamContentType: "text/plain"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, notification.StateSent, res.State)
+
+	req := <-reqCh
+	assert.Equal(t, "hello", req.body)
+	assert.Equal(t, "text/plain", req.header.Get("Content-Type"))
+	assert.Empty(t, req.header.Get(HeaderSignature))
+}
+
+func TestSender_SendMessage_Status(t *testing.T) {
+	ctx := testContext()
+	s := NewSender(ctx, Config{Client: http.DefaultClient, Keys: keyring.Keys{[]byte("test-key")}})
+
+	check := func(status int, signed bool, exp notification.State) {
+		t.Helper()
+		srv, _ := testServer(t, status)
+		dest := NewWebhookDest(srv.URL)
+		if signed {
+			var err error
+			dest.Args[FieldSigningSecret] = "0123456789abcdef"
+			dest, err = s.NormalizeDest(ctx, dest)
+			require.NoError(t, err)
+		}
+		res, err := s.SendMessage(ctx, notification.Test{Base: nfymsg.Base{Dest: dest}})
+		require.NoError(t, err)
+		assert.Equal(t, exp, res.State, "status %d (signed: %t)", status, signed)
+	}
+
+	check(http.StatusNoContent, true, notification.StateSent)
+	check(http.StatusUnauthorized, true, notification.StateFailedPerm)
+	check(http.StatusTooManyRequests, true, notification.StateFailedTemp)
+	check(http.StatusBadGateway, true, notification.StateFailedTemp)
+
+	// only signed webhooks check the response status
+	check(http.StatusUnauthorized, false, notification.StateSent)
+	check(http.StatusBadGateway, false, notification.StateSent)
+}
+
+func TestParseHeaders(t *testing.T) {
+	h, err := parseHeaders("x-foo: bar\n\n  X-Foo:baz  \r\nAuthorization: Basic abc")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bar", "baz"}, h.Values("X-Foo"))
+	assert.Equal(t, "Basic abc", h.Get("Authorization"))
+
+	_, err = parseHeaders("no colon")
+	assert.Error(t, err)
+	_, err = parseHeaders("bad name: value")
+	assert.Error(t, err)
+	_, err = parseHeaders("Host: example.com")
+	assert.Error(t, err)
+	_, err = parseHeaders("X-Webhook-Signature: fake")
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package webpush
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+)
+
+// ActionURLTTL is how long notification buttons work after a notification is sent.
+const ActionURLTTL = 24 * time.Hour
+
+const (
+	actionAck   = "ack"
+	actionClose = "close"
+
+	actionIssuer   = "goalert"
+	actionAudience = "webpush-action"
+)
+
+var actionTitles = map[string]string{
+	actionAck:   "Acknowledge",
+	actionClose: "Close",
+}
+
+func (s *Sender) actionURL(cfg config.Config, callbackID, action string, now time.Time) (string, error) {
+	var c jwt.RegisteredClaims
+	c.ID = callbackID
+	c.Subject = action
+	c.Audience = jwt.ClaimStrings{actionAudience}
+	c.Issuer = actionIssuer
+	c.NotBefore = jwt.NewNumericDate(now.Add(-2 * time.Minute))
+	c.ExpiresAt = jwt.NewNumericDate(now.Add(ActionURLTTL))
+	c.IssuedAt = jwt.NewNumericDate(now)
+
+	token, err := s.cfg.Keys.SignJWT(c)
+	if err != nil {
+		return "", err
+	}
+
+	return cfg.CallbackURL("/api/v2/webpush/action", url.Values{"token": {token}}), nil
+}
+
+// actions returns notification buttons for the given actions, or nil if actions can't be processed.
+func (s *Sender) actions(cfg config.Config, callbackID string, actions ...string) ([]Action, error) {
+	if s.r == nil || callbackID == "" {
+		return nil, nil
+	}
+
+	now := time.Now()
+	result := make([]Action, 0, len(actions))
+	for _, a := range actions {
+		u, err := s.actionURL(cfg, callbackID, a, now)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, Action{Action: a, Title: actionTitles[a], URL: u})
+	}
+
+	return result, nil
+}
+
+// ServeAction will process a request to a signed action URL, made by the service worker when a notification
+// button is clicked.
+func (s *Sender) ServeAction(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+	if !cfg.WebPush.Enable || s.r == nil {
+		http.Error(w, "not enabled", http.StatusNotFound)
+		return
+	}
+
+	var c jwt.RegisteredClaims
+	_, err := s.cfg.Keys.VerifyJWT(req.URL.Query().Get("token"), &c, actionIssuer, actionAudience)
+	if err != nil {
+		log.Debug(ctx, err)
+		http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+		return
+	}
+
+	var res notification.Result
+	switch c.Subject {
+	case actionAck:
+		res = notification.ResultAcknowledge
+	case actionClose:
+		res = notification.ResultResolve
+	default:
+		errutil.HTTPError(ctx, w, errors.New("unknown action"))
+		return
+	}
+
+	err = s.r.Receive(ctx, c.ID, res)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,99 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// recordSize is the aes128gcm record size, payloads are always sent as a single record.
+const recordSize = 4096
+
+// maxPayloadLen is the largest plaintext that fits in a single record, after the header, padding delimiter,
+// and authentication tag.
+const maxPayloadLen = recordSize - 16 - 4 - 1 - 65 - 1 - 16
+
+// encrypt will encrypt a push message payload for the user agent's public key and auth secret, as described by
+// RFC 8291 using the aes128gcm content coding from RFC 8188.
+//
+// The returned body includes the content coding header.
+func encrypt(uaPub *ecdh.PublicKey, authSecret, plaintext []byte) ([]byte, error) {
+	if len(plaintext) > maxPayloadLen {
+		return nil, errors.New("payload too large")
+	}
+
+	asPriv, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	_, err = io.ReadFull(rand.Reader, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	return encryptWith(asPriv, salt, uaPub, authSecret, plaintext)
+}
+
+// encryptWith performs encryption with an explicit application server key pair and salt.
+func encryptWith(asPriv *ecdh.PrivateKey, salt []byte, uaPub *ecdh.PublicKey, authSecret, plaintext []byte) ([]byte, error) {
+	ecdhSecret, err := asPriv.ECDH(uaPub)
+	if err != nil {
+		return nil, err
+	}
+	asPub := asPriv.PublicKey().Bytes()
+
+	// RFC 8291 Section 3.3: combine the shared secret with the auth secret
+	prkKey, err := hkdf.Extract(sha256.New, ecdhSecret, authSecret)
+	if err != nil {
+		return nil, err
+	}
+	keyInfo := "WebPush: info\x00" + string(uaPub.Bytes()) + string(asPub)
+	ikm, err := hkdf.Expand(sha256.New, prkKey, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	// RFC 8188 Section 2.2 & 2.3: derive the content encryption key and nonce
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// header: salt (16) | record size (4) | key ID length (1) | key ID (application server public key)
+	body := make([]byte, 0, 16+4+1+len(asPub)+len(plaintext)+1+gcm.Overhead())
+	body = append(body, salt...)
+	body = binary.BigEndian.AppendUint32(body, recordSize)
+	body = append(body, byte(len(asPub)))
+	body = append(body, asPub...)
+
+	// the last (and only) record is terminated by a 0x02 padding delimiter
+	record := make([]byte, 0, len(plaintext)+1)
+	record = append(record, plaintext...)
+	record = append(record, 2)
+
+	return gcm.Seal(body, nonce, record, nil), nil
+}
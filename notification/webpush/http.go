@@ -0,0 +1,95 @@
+package webpush
+
+import (
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/errutil"
+)
+
+//go:embed sw.js
+var serviceWorker []byte
+
+// ServeWorker serves the service worker script that displays push messages. It is registered from the same
+// path, so its scope does not overlap with the UI.
+func (s *Sender) ServeWorker(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	_, _ = w.Write(serviceWorker)
+}
+
+// ServeKey serves the VAPID public key browsers need to subscribe (the applicationServerKey).
+func (s *Sender) ServeKey(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if !config.FromContext(ctx).WebPush.Enable {
+		http.Error(w, "not enabled", http.StatusNotFound)
+		return
+	}
+
+	key, err := s.ApplicationServerKey()
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(key))
+}
+
+// ServeSubscription registers a renewed subscription for the current user, replacing the old subscription of
+// their contact method. Browsers may replace a subscription at any time (e.g., on expiration), the service worker
+// will call this so the contact method keeps working without being re-created.
+func (s *Sender) ServeSubscription(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if !config.FromContext(ctx).WebPush.Enable {
+		http.Error(w, "not enabled", http.StatusNotFound)
+		return
+	}
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	var body struct {
+		OldSubscription Subscription
+		Subscription    Subscription
+	}
+	err = json.NewDecoder(http.MaxBytesReader(w, req.Body, 8192)).Decode(&body)
+	if err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// the verification of the contact method carries over, so it must stay with the same push service
+	if endpointHost(body.OldSubscription.Endpoint) != endpointHost(body.Subscription.Endpoint) {
+		http.Error(w, "subscription must use the same push service", http.StatusBadRequest)
+		return
+	}
+
+	id, err := s.cfg.CMStore.ReplaceDest(ctx, s.cfg.DB, permission.UserID(ctx), NewDest(body.OldSubscription), NewDest(body.Subscription))
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "no contact method found for subscription", http.StatusNotFound)
+		return
+	}
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct{ ContactMethodID string }{ContactMethodID: id.String()})
+}
+
+// endpointHost returns the host of a subscription endpoint, or an empty string if it is invalid.
+func endpointHost(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+
+	return u.Hostname()
+}
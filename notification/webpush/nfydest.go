@@ -0,0 +1,166 @@
+package webpush
+
+import (
+	"context"
+	"crypto/ecdh"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/notification/nfydest"
+	"github.com/target/goalert/validation"
+)
+
+const (
+	DestTypeWebPush   = "builtin-webpush"
+	FieldSubscription = "webpush_subscription"
+	FallbackIconURL   = "builtin://webpush"
+)
+
+// Subscription is a browser push subscription, as returned by `PushSubscription.toJSON()`.
+type Subscription struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256DH string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// NewDest returns a new web push destination for the given subscription.
+func NewDest(sub Subscription) gadb.DestV1 {
+	data, _ := json.Marshal(sub)
+	return gadb.NewDestV1(DestTypeWebPush, FieldSubscription, string(data))
+}
+
+// decodeKey decodes a subscription key, browsers use unpadded base64url but padding is tolerated.
+func decodeKey(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// parseSubscription will parse and validate a subscription, returning the user agent public key and auth secret.
+func parseSubscription(value string) (sub Subscription, uaPub *ecdh.PublicKey, authSecret []byte, err error) {
+	err = json.Unmarshal([]byte(value), &sub)
+	if err != nil {
+		return sub, nil, nil, validation.NewGenericError("invalid subscription JSON")
+	}
+
+	u, err := url.Parse(sub.Endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return sub, nil, nil, validation.NewGenericError("endpoint must be an https URL")
+	}
+	if !isPushService(u.Hostname()) {
+		return sub, nil, nil, validation.NewGenericError("endpoint must be a known push service")
+	}
+
+	data, err := decodeKey(sub.Keys.P256DH)
+	if err != nil {
+		return sub, nil, nil, validation.NewGenericError("p256dh key must be base64url encoded")
+	}
+	uaPub, err = ecdh.P256().NewPublicKey(data)
+	if err != nil {
+		return sub, nil, nil, validation.NewGenericError("p256dh key must be a P-256 public key")
+	}
+
+	authSecret, err = decodeKey(sub.Keys.Auth)
+	if err != nil || len(authSecret) != 16 {
+		return sub, nil, nil, validation.NewGenericError("auth secret must be 16 base64url encoded bytes")
+	}
+
+	return sub, uaPub, authSecret, nil
+}
+
+var _ nfydest.Provider = (*Sender)(nil)
+
+func (s *Sender) ID() string { return DestTypeWebPush }
+
+func (s *Sender) TypeInfo(ctx context.Context) (*nfydest.TypeInfo, error) {
+	cfg := config.FromContext(ctx)
+	return &nfydest.TypeInfo{
+		Type:                       DestTypeWebPush,
+		Name:                       "Browser Push",
+		Enabled:                    cfg.WebPush.Enable,
+		SupportsAlertNotifications: true,
+		SupportsUserVerification:   true,
+		SupportsStatusUpdates:      true,
+		UserVerificationRequired:   true,
+		RequiredFields: []nfydest.FieldConfig{{
+			FieldID:            FieldSubscription,
+			Label:              "This Browser",
+			InputType:          "webpush",
+			Hint:               "Allow notifications to subscribe this browser or installed app.",
+			SupportsValidation: true,
+		}},
+	}, nil
+}
+
+func (s *Sender) ValidateField(ctx context.Context, fieldID, value string) error {
+	switch fieldID {
+	case FieldSubscription:
+		_, _, _, err := parseSubscription(value)
+		return err
+	}
+
+	return validation.NewGenericError("unknown field ID")
+}
+
+var _ nfydest.DestNormalizer = (*Sender)(nil)
+
+// NormalizeDest will re-encode the subscription so that the same subscription is always stored identically,
+// regardless of key order or extra fields (e.g., expirationTime) provided by the browser.
+func (s *Sender) NormalizeDest(ctx context.Context, dest gadb.DestV1) (gadb.DestV1, error) {
+	if dest.Type != DestTypeWebPush {
+		return dest, nil
+	}
+
+	sub, _, _, err := parseSubscription(dest.Arg(FieldSubscription))
+	if err != nil {
+		return dest, err
+	}
+
+	return NewDest(sub), nil
+}
+
+// pushServiceNames maps push service hosts to the browser that uses them.
+var pushServiceNames = map[string]string{
+	"fcm.googleapis.com":                "Chrome",
+	"updates.push.services.mozilla.com": "Firefox",
+	"web.push.apple.com":                "Safari",
+}
+
+// isPushService returns true if host is a known push service. Endpoints are provided by the browser, so
+// messages are only ever sent to the push services of supported browsers.
+func isPushService(host string) bool {
+	if _, ok := pushServiceNames[host]; ok {
+		return true
+	}
+
+	return strings.HasSuffix(host, ".notify.windows.com")
+}
+
+func (s *Sender) DisplayInfo(ctx context.Context, args map[string]string) (*nfydest.DisplayInfo, error) {
+	if args == nil {
+		args = make(map[string]string)
+	}
+
+	sub, _, _, err := parseSubscription(args[FieldSubscription])
+	if err != nil {
+		return nil, err
+	}
+	u, _ := url.Parse(sub.Endpoint) // validated by parseSubscription
+
+	text := u.Hostname()
+	if name, ok := pushServiceNames[text]; ok {
+		text = name
+	} else if strings.HasSuffix(text, ".notify.windows.com") {
+		text = "Edge"
+	}
+
+	return &nfydest.DisplayInfo{
+		IconURL:     FallbackIconURL,
+		IconAltText: "Browser Push",
+		Text:        text,
+	}, nil
+}
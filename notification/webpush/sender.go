@@ -0,0 +1,260 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/nfydest"
+	"github.com/target/goalert/user/contactmethod"
+	"github.com/target/goalert/util/log"
+)
+
+// Keyring is used for the VAPID key pair and to sign action URLs. It must use P-256 keys and should not be
+// rotated, as existing subscriptions are bound to the public key.
+type Keyring interface {
+	SignJWT(jwt.Claims) (string, error)
+	VerifyJWT(token string, c jwt.Claims, iss, aud string) (bool, error)
+	PublicKey() (*ecdsa.PublicKey, error)
+}
+
+// Config contains the dependencies of a Sender.
+type Config struct {
+	Client *http.Client
+
+	Keys Keyring
+
+	// DB and CMStore are used to renew subscriptions.
+	DB      *sql.DB
+	CMStore *contactmethod.Store
+}
+
+// Sender sends notifications to browsers through their push service.
+type Sender struct {
+	cfg Config
+	r   notification.Receiver
+}
+
+// Payload is the decrypted content of a push message, it is displayed by the service worker.
+type Payload struct {
+	Title string
+	Body  string
+
+	// Tag replaces any existing notification with the same tag (e.g., status updates for an alert).
+	Tag string `json:",omitempty"`
+
+	// URL is opened when the notification is clicked.
+	URL string `json:",omitempty"`
+
+	RequireInteraction bool     `json:",omitempty"`
+	Actions            []Action `json:",omitempty"`
+}
+
+// Action is a notification button, that will POST to a signed action URL when clicked.
+type Action struct {
+	Action string
+	Title  string
+	URL    string
+}
+
+const (
+	alertTTL  = time.Hour
+	statusTTL = 24 * time.Hour
+)
+
+// NewSender will create a new Sender.
+func NewSender(ctx context.Context, cfg Config) (*Sender, error) {
+	pub, err := cfg.Keys.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	if pub.Curve != elliptic.P256() {
+		return nil, errors.New("web push requires a P-256 keyring")
+	}
+
+	return &Sender{cfg: cfg}, nil
+}
+
+var _ notification.ReceiverSetter = (*Sender)(nil)
+
+// SetReceiver sets the notification.Receiver for actions and expired subscriptions.
+func (s *Sender) SetReceiver(r notification.Receiver) { s.r = r }
+
+// ApplicationServerKey returns the VAPID public key, as an uncompressed point encoded with unpadded base64url.
+func (s *Sender) ApplicationServerKey() (string, error) {
+	pub, err := s.cfg.Keys.PublicKey()
+	if err != nil {
+		return "", err
+	}
+	key, err := pub.ECDH()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(key.Bytes()), nil
+}
+
+// vapidAuth returns the Authorization header value for a push service endpoint, as described by RFC 8292.
+func (s *Sender) vapidAuth(cfg config.Config, endpoint string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	sub := cfg.WebPush.Subject
+	if sub == "" {
+		sub = cfg.PublicURL()
+	}
+
+	tok, err := s.cfg.Keys.SignJWT(jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": now.Add(12 * time.Hour).Unix(),
+		"sub": sub,
+	})
+	if err != nil {
+		return "", err
+	}
+	key, err := s.ApplicationServerKey()
+	if err != nil {
+		return "", err
+	}
+
+	return "vapid t=" + tok + ", k=" + key, nil
+}
+
+var _ nfydest.MessageSender = (*Sender)(nil)
+
+// SendMessage will encrypt and send a message to the push service of the subscription.
+func (s *Sender) SendMessage(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+
+	sub, uaPub, authSecret, err := parseSubscription(msg.DestArg(FieldSubscription))
+	if err != nil {
+		return &notification.SentMessage{State: notification.StateFailedPerm, StateDetails: err.Error()}, nil
+	}
+
+	p, ttl, urgent, err := s.payload(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	body, err := encrypt(uaPub, authSecret, data)
+	if err != nil {
+		return nil, err
+	}
+	authz, err := s.vapidAuth(cfg, sub.Endpoint, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", authz)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(ttl.Seconds())))
+	if urgent {
+		req.Header.Set("Urgency", "high")
+	}
+	if p.Tag != "" {
+		// undelivered messages with the same topic are replaced by the push service
+		req.Header.Set("Topic", p.Tag)
+	}
+
+	client := s.cfg.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		// the subscription expired or the user revoked permission, it will never work again
+		if s.r != nil {
+			err = s.r.Stop(ctx, gadb.NewDestV1(DestTypeWebPush, FieldSubscription, msg.DestArg(FieldSubscription)))
+			if err != nil {
+				log.Log(ctx, fmt.Errorf("disable expired web push subscription: %w", err))
+			}
+		}
+		return &notification.SentMessage{State: notification.StateFailedPerm, StateDetails: "subscription expired: " + resp.Status}, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return &notification.SentMessage{State: notification.StateFailedTemp, StateDetails: resp.Status}, nil
+	case resp.StatusCode >= 400:
+		return &notification.SentMessage{State: notification.StateFailedPerm, StateDetails: resp.Status}, nil
+	}
+
+	return &notification.SentMessage{ExternalID: resp.Header.Get("Location"), State: notification.StateSent}, nil
+}
+
+// payload returns the notification to display for a message, how long the push service should keep it if the
+// browser is offline, and if it is urgent.
+func (s *Sender) payload(ctx context.Context, msg notification.Message) (p Payload, ttl time.Duration, urgent bool, err error) {
+	cfg := config.FromContext(ctx)
+	switch m := msg.(type) {
+	case notification.Test:
+		p.Title = "Test Message"
+		p.Body = fmt.Sprintf("This is a test message from %s.", cfg.ApplicationName())
+		return p, alertTTL, false, nil
+	case notification.Verification:
+		p.Title = "Verification Code"
+		p.Body = fmt.Sprintf("Your %s verification code is: %s", cfg.ApplicationName(), m.Code)
+		return p, alertTTL, false, nil
+	case notification.Alert:
+		p.Title = fmt.Sprintf("Alert #%d: %s", m.AlertID, m.Summary)
+		p.Body = m.ServiceName
+		p.Tag = fmt.Sprintf("alert-%d", m.AlertID)
+		p.URL = cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID))
+		p.RequireInteraction = true
+		p.Actions, err = s.actions(cfg, m.MsgID(), actionAck, actionClose)
+		return p, alertTTL, true, err
+	case notification.AlertBundle:
+		p.Title = fmt.Sprintf("%s: %d unacknowledged alerts", m.ServiceName, m.Count)
+		p.Tag = "service-" + m.ServiceID
+		p.URL = cfg.CallbackURL("/services/" + m.ServiceID + "/alerts")
+		p.RequireInteraction = true
+		p.Actions, err = s.actions(cfg, m.MsgID(), actionAck, actionClose)
+		return p, alertTTL, true, err
+	case notification.AlertStatus:
+		p.Title = fmt.Sprintf("Alert #%d: %s", m.AlertID, m.Summary)
+		p.Body = m.LogEntry
+		p.Tag = fmt.Sprintf("alert-%d", m.AlertID)
+		p.URL = cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID))
+		if m.NewAlertState == notification.AlertStateAcknowledged {
+			p.Actions, err = s.actions(cfg, m.OriginalStatus.ID, actionClose)
+		}
+		return p, statusTTL, false, err
+	case notification.QuietHoursDigest:
+		p.Title = "Quiet Hours Digest"
+		p.Body = m.Summary()
+		p.Tag = "quiet-hours-digest"
+		p.URL = cfg.CallbackURL("/profile")
+		return p, statusTTL, false, nil
+	}
+
+	return p, 0, false, errors.New("message type not supported")
+}
@@ -0,0 +1,257 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/auth"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/nfymsg"
+	"github.com/target/goalert/permission"
+)
+
+type testKeyring struct{ key *ecdsa.PrivateKey }
+
+func (k *testKeyring) SignJWT(c jwt.Claims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodES256, c).SignedString(k.key)
+}
+
+func (k *testKeyring) VerifyJWT(token string, c jwt.Claims, iss, aud string) (bool, error) {
+	_, err := jwt.ParseWithClaims(token, c, func(*jwt.Token) (any, error) { return &k.key.PublicKey, nil },
+		jwt.WithValidMethods([]string{"ES256"}), jwt.WithIssuer(iss), jwt.WithAudience(aud))
+	return err == nil, err
+}
+
+func (k *testKeyring) PublicKey() (*ecdsa.PublicKey, error) { return &k.key.PublicKey, nil }
+
+type receiver struct {
+	notification.Receiver
+
+	stopped    []gadb.DestV1
+	callbackID string
+	result     notification.Result
+}
+
+func (r *receiver) Stop(ctx context.Context, dest gadb.DestV1) error {
+	r.stopped = append(r.stopped, dest)
+	return nil
+}
+
+func (r *receiver) Receive(ctx context.Context, callbackID string, result notification.Result) error {
+	r.callbackID, r.result = callbackID, result
+	return nil
+}
+
+// browser is a push subscription, with the private keys needed to decrypt messages.
+type browser struct {
+	priv *ecdh.PrivateKey
+	auth []byte
+}
+
+func newBrowser(t *testing.T) *browser {
+	t.Helper()
+	priv, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	b := &browser{priv: priv, auth: make([]byte, 16)}
+	_, err = rand.Read(b.auth)
+	require.NoError(t, err)
+	return b
+}
+
+func (b *browser) subscription(endpoint string) Subscription {
+	var sub Subscription
+	sub.Endpoint = endpoint
+	sub.Keys.P256DH = base64.RawURLEncoding.EncodeToString(b.priv.PublicKey().Bytes())
+	sub.Keys.Auth = base64.RawURLEncoding.EncodeToString(b.auth)
+	return sub
+}
+
+// decrypt reverses RFC 8291 encryption as a browser would.
+func (b *browser) decrypt(t *testing.T, body []byte) Payload {
+	t.Helper()
+	require.Greater(t, len(body), 21)
+	salt, idLen := body[:16], int(body[20])
+	asPubData, ct := body[21:21+idLen], body[21+idLen:]
+
+	asPub, err := ecdh.P256().NewPublicKey(asPubData)
+	require.NoError(t, err)
+	secret, err := b.priv.ECDH(asPub)
+	require.NoError(t, err)
+	prkKey, err := hkdf.Extract(sha256.New, secret, b.auth)
+	require.NoError(t, err)
+	ikm, err := hkdf.Expand(sha256.New, prkKey, "WebPush: info\x00"+string(b.priv.PublicKey().Bytes())+string(asPubData), 32)
+	require.NoError(t, err)
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	require.NoError(t, err)
+	cek, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	require.NoError(t, err)
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(cek)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	data, err := gcm.Open(nil, nonce, ct, nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(2), data[len(data)-1], "last record delimiter")
+
+	var p Payload
+	require.NoError(t, json.Unmarshal(data[:len(data)-1], &p))
+	return p
+}
+
+func TestSender_SendMessage(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	keys := &testKeyring{key: key}
+
+	var reqs []*http.Request
+	var bodies [][]byte
+	push := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		reqs, bodies = append(reqs, req), append(bodies, body)
+		if req.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		w.Header().Set("Location", "https://push.example.com/m/1")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer push.Close()
+	pushURL, err := url.Parse(push.URL)
+	require.NoError(t, err)
+	pushServiceNames[pushURL.Hostname()] = "Test"
+	defer delete(pushServiceNames, pushURL.Hostname())
+
+	var cfg config.Config
+	cfg.General.PublicURL = "http://goalert.example.com"
+	cfg.WebPush.Enable = true
+	cfg.WebPush.Subject = "mailto:admin@example.com"
+	ctx := cfg.Context(context.Background())
+
+	s, err := NewSender(ctx, Config{Client: push.Client(), Keys: keys})
+	require.NoError(t, err)
+	var recv receiver
+	s.SetReceiver(&recv)
+
+	b := newBrowser(t)
+	dest := NewDest(b.subscription(push.URL + "/sub"))
+	res, err := s.SendMessage(ctx, notification.Alert{Base: nfymsg.Base{ID: "cb1", Dest: dest}, AlertID: 3, Summary: "disk full", ServiceName: "db"})
+	require.NoError(t, err)
+	assert.Equal(t, notification.StateSent, res.State)
+	assert.Equal(t, "https://push.example.com/m/1", res.ExternalID)
+
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "aes128gcm", reqs[0].Header.Get("Content-Encoding"))
+	assert.Equal(t, "high", reqs[0].Header.Get("Urgency"))
+	assert.Equal(t, "alert-3", reqs[0].Header.Get("Topic"))
+	assert.NotEmpty(t, reqs[0].Header.Get("TTL"))
+
+	// RFC 8292: the token must be signed by the key the browser subscribed with
+	tok, k, ok := strings.Cut(strings.TrimPrefix(reqs[0].Header.Get("Authorization"), "vapid t="), ", k=")
+	require.True(t, ok)
+	appKey, err := s.ApplicationServerKey()
+	require.NoError(t, err)
+	assert.Equal(t, appKey, k)
+	var claims jwt.MapClaims
+	_, err = jwt.ParseWithClaims(tok, &claims, func(*jwt.Token) (any, error) { return &key.PublicKey, nil }, jwt.WithAudience(push.URL))
+	require.NoError(t, err)
+	assert.Equal(t, "mailto:admin@example.com", claims["sub"])
+
+	p := b.decrypt(t, bodies[0])
+	assert.Equal(t, "Alert #3: disk full", p.Title)
+	assert.Equal(t, "http://goalert.example.com/alerts/3", p.URL)
+	require.Len(t, p.Actions, 2)
+	assert.Equal(t, actionAck, p.Actions[0].Action)
+
+	// clicking a notification button, through the auth middleware
+	u, err := url.Parse(p.Actions[1].URL)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v2/webpush/action", u.Path)
+	var authH auth.Handler
+	rec := httptest.NewRecorder()
+	authH.WrapHandler(http.HandlerFunc(s.ServeAction)).ServeHTTP(rec, httptest.NewRequest("POST", p.Actions[1].URL, nil).WithContext(ctx))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "cb1", recv.callbackID)
+	assert.Equal(t, notification.ResultResolve, recv.result)
+
+	rec = httptest.NewRecorder()
+	s.ServeAction(rec, httptest.NewRequest("POST", "/api/v2/webpush/action?token=invalid", nil).WithContext(ctx))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// expired subscriptions are disabled
+	gone := NewDest(b.subscription(push.URL + "/gone"))
+	res, err = s.SendMessage(ctx, notification.Test{Base: nfymsg.Base{ID: "cb2", Dest: gone}})
+	require.NoError(t, err)
+	assert.Equal(t, notification.StateFailedPerm, res.State)
+	assert.Equal(t, []gadb.DestV1{gone}, recv.stopped)
+}
+
+func TestSender_NormalizeDest(t *testing.T) {
+	var cfg config.Config
+	cfg.WebPush.Enable = true
+	ctx := cfg.Context(context.Background())
+	var s Sender
+
+	b := newBrowser(t)
+	sub := b.subscription("https://fcm.googleapis.com/fcm/send/abc")
+	raw := `{"keys":{"auth":"` + sub.Keys.Auth + `","p256dh":"` + sub.Keys.P256DH + `"},"expirationTime":null,"endpoint":"` + sub.Endpoint + `"}`
+
+	dest, err := s.NormalizeDest(ctx, gadb.NewDestV1(DestTypeWebPush, FieldSubscription, raw))
+	require.NoError(t, err)
+	assert.Equal(t, NewDest(sub), dest)
+
+	info, err := s.DisplayInfo(ctx, dest.Args)
+	require.NoError(t, err)
+	assert.Equal(t, "Chrome", info.Text)
+
+	sub.Endpoint = "https://push.example.com/abc"
+	assert.Error(t, s.ValidateField(ctx, FieldSubscription, NewDest(sub).Arg(FieldSubscription)), "endpoint must be a known push service")
+	sub.Endpoint = "https://wns2-bl2p.notify.windows.com/w/?token=abc"
+	assert.NoError(t, s.ValidateField(ctx, FieldSubscription, NewDest(sub).Arg(FieldSubscription)))
+	sub.Endpoint = "http://fcm.googleapis.com/fcm/send/abc"
+	assert.Error(t, s.ValidateField(ctx, FieldSubscription, NewDest(sub).Arg(FieldSubscription)), "endpoint must use https")
+	assert.Error(t, s.ValidateField(ctx, FieldSubscription, `{"endpoint":"https://push.example.com/abc"}`), "keys are required")
+}
+
+func TestSender_ServeSubscription(t *testing.T) {
+	var cfg config.Config
+	cfg.WebPush.Enable = true
+	ctx := cfg.Context(context.Background())
+	ctx = permission.UserContext(ctx, "00000000-0000-0000-0000-000000000001", permission.RoleUser)
+	var s Sender
+
+	b := newBrowser(t)
+	data, err := json.Marshal(struct{ OldSubscription, Subscription Subscription }{
+		OldSubscription: b.subscription("https://fcm.googleapis.com/fcm/send/abc"),
+		Subscription:    b.subscription("https://updates.push.services.mozilla.com/wpush/v2/abc"),
+	})
+	require.NoError(t, err)
+
+	// a verified contact method must not be moved to another push service
+	rec := httptest.NewRecorder()
+	s.ServeSubscription(rec, httptest.NewRequest("POST", "/api/v2/webpush/subscription", bytes.NewReader(data)).WithContext(ctx))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,64 @@
+// Service worker for GoAlert browser push notifications.
+//
+// Push messages are JSON encoded webpush.Payload objects. Notification buttons POST
+// to a signed action URL, clicking the notification itself opens the related page.
+
+self.addEventListener('push', (event) => {
+  const data = event.data ? event.data.json() : {}
+  const actions = data.Actions || []
+
+  event.waitUntil(
+    self.registration.showNotification(data.Title || 'GoAlert', {
+      body: data.Body || '',
+      tag: data.Tag || undefined,
+      renotify: !!data.Tag,
+      requireInteraction: !!data.RequireInteraction,
+      actions: actions.map((a) => ({ action: a.Action, title: a.Title })),
+      data: { url: data.URL, actions },
+    }),
+  )
+})
+
+self.addEventListener('notificationclick', (event) => {
+  const { url, actions = [] } = event.notification.data || {}
+  event.notification.close()
+
+  const action = actions.find((a) => a.Action === event.action)
+  if (action) {
+    event.waitUntil(
+      fetch(action.URL, { method: 'POST' }).then((res) => {
+        if (res.ok) return
+        return self.registration.showNotification(`${action.Title} failed`, {
+          body: res.status === 401 ? 'The notification has expired.' : res.statusText,
+          data: { url },
+        })
+      }),
+    )
+    return
+  }
+
+  if (url) event.waitUntil(self.clients.openWindow(url))
+})
+
+self.addEventListener('pushsubscriptionchange', (event) => {
+  const old = event.oldSubscription
+  if (!old) return // without the old subscription, the contact method can't be identified
+
+  const renew = event.newSubscription
+    ? Promise.resolve(event.newSubscription)
+    : self.registration.pushManager.subscribe(old.options)
+
+  event.waitUntil(
+    renew.then((sub) =>
+      fetch('subscription', {
+        method: 'POST',
+        credentials: 'same-origin',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({
+          OldSubscription: old.toJSON(),
+          Subscription: sub.toJSON(),
+        }),
+      }),
+    ),
+  )
+})
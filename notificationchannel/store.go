@@ -91,6 +91,10 @@ func (s *Store) MapDestToID(ctx context.Context, tx gadb.DBTX, d gadb.DestV1) (u
 	if err != nil {
 		return uuid.UUID{}, err
 	}
+	d, err = s.reg.NormalizeDest(ctx, d)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
 	info, err := s.reg.DisplayInfo(ctx, d)
 	if err != nil {
 		return uuid.UUID{}, err
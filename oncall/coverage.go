@@ -0,0 +1,57 @@
+package oncall
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// A CoverageGap is a period of time when no one is on call for a schedule.
+type CoverageGap struct {
+	Start time.Time
+	End   time.Time
+}
+
+// CoverageGaps returns the periods between start and end that are not covered by any of the provided shifts.
+func CoverageGaps(shifts []Shift, start, end time.Time) []CoverageGap {
+	sorted := make([]Shift, len(shifts))
+	copy(sorted, shifts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	var gaps []CoverageGap
+	covered := start
+	for _, s := range sorted {
+		if !covered.Before(end) {
+			break
+		}
+		if s.Start.After(covered) {
+			gaps = append(gaps, CoverageGap{Start: covered, End: minTime(s.Start, end)})
+		}
+		if s.End.After(covered) {
+			covered = s.End
+		}
+	}
+	if covered.Before(end) {
+		gaps = append(gaps, CoverageGap{Start: covered, End: end})
+	}
+
+	return gaps
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// CoverageGapsBySchedule will return the periods between start and end where no one is on call for the given schedule.
+func (s *Store) CoverageGapsBySchedule(ctx context.Context, scheduleID string, start, end time.Time) ([]CoverageGap, error) {
+	shifts, err := s.HistoryBySchedule(ctx, scheduleID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// shifts are calculated at minute resolution
+	return CoverageGaps(shifts, start.Truncate(time.Minute), end.Truncate(time.Minute)), nil
+}
@@ -0,0 +1,33 @@
+package oncall
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoverageGaps(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return start.Add(time.Duration(h) * time.Hour) }
+	end := at(24)
+
+	assert.Equal(t, []CoverageGap{{Start: start, End: end}}, CoverageGaps(nil, start, end), "no shifts")
+
+	assert.Empty(t, CoverageGaps([]Shift{
+		{UserID: "a", Start: at(-1), End: at(12)},
+		{UserID: "b", Start: at(12), End: at(25)},
+	}, start, end), "fully covered")
+
+	assert.Equal(t, []CoverageGap{
+		{Start: start, End: at(2)},
+		{Start: at(8), End: at(9)},
+		{Start: at(20), End: end},
+	}, CoverageGaps([]Shift{
+		// out of order and overlapping
+		{UserID: "b", Start: at(4), End: at(8)},
+		{UserID: "a", Start: at(2), End: at(6)},
+		{UserID: "c", Start: at(9), End: at(20)},
+		{UserID: "d", Start: at(10), End: at(12)},
+	}, start, end), "gaps")
+}
@@ -43,11 +43,7 @@ func (rCalc *RulesCalculator) Process(int64) int64 {
 
 	rCalc.userIDs = rCalc.userIDs[:0]
 	for _, r := range rCalc.rules {
-		id := r.ActiveUser()
-		if id == "" {
-			continue
-		}
-		rCalc.userIDs = append(rCalc.userIDs, id)
+		rCalc.userIDs = append(rCalc.userIDs, r.ActiveUsers()...)
 	}
 
 	return 0
@@ -1,6 +1,7 @@
 package oncall
 
 import (
+	"slices"
 	"time"
 
 	"github.com/target/goalert/assignment"
@@ -14,7 +15,7 @@ type SingleRuleCalculator struct {
 	rot     *UserCalculator
 	loc     *time.Location
 	rule    ResolvedRule
-	userID  string
+	userIDs []string
 	changed bool
 }
 
@@ -36,15 +37,22 @@ func (t *TimeIterator) NewSingleRuleCalculator(loc *time.Location, rule Resolved
 		return true
 	}
 
+	// setSpan will only set the parts of a span allowed by the rule's holiday filter
+	setSpan := func(start, end time.Time) {
+		for _, span := range rule.HolidaySpans(start.In(loc), end, rule.Holidays) {
+			calc.act.SetSpan(span[0], span[1])
+		}
+	}
+
 	if rule.AlwaysActive() {
 		// always active so just add one span for the entire duration +1 step
-		calc.act.SetSpan(t.Start(), t.End().Add(t.Step()))
+		setSpan(t.Start(), t.End().Add(t.Step()))
 	} else if !rule.NeverActive() {
 		cur := rule.StartTime(t.Start().In(loc))
 		// loop through rule active times
 		for cur.Before(t.End()) && limit() {
 			end := rule.EndTime(cur)
-			calc.act.SetSpan(cur, end)
+			setSpan(cur, end)
 			cur = rule.StartTime(end)
 		}
 	}
@@ -62,8 +70,9 @@ func (t *TimeIterator) NewSingleRuleCalculator(loc *time.Location, rule Resolved
 			cur := t.Start().In(loc)
 			// loop through rotations
 			for cur.Before(t.End()) && limit() {
-				userID := rule.Rotation.UserID(cur)
-				calc.rot.SetSpan(rule.Rotation.CurrentStart, rule.Rotation.CurrentEnd, userID)
+				for _, userID := range rule.Rotation.UserIDs(cur) {
+					calc.rot.SetSpan(rule.Rotation.CurrentStart, rule.Rotation.CurrentEnd, userID)
+				}
 				cur = rule.Rotation.CurrentEnd
 			}
 		}
@@ -77,21 +86,18 @@ func (t *TimeIterator) NewSingleRuleCalculator(loc *time.Location, rule Resolved
 
 // Process implements the SubIterator.Process method.
 func (rCalc *SingleRuleCalculator) Process(int64) int64 {
-	var newUserID string
+	var newUserIDs []string
 	if rCalc.act.Active() {
 		if rCalc.rot != nil {
-			usrs := rCalc.rot.ActiveUsers()
-			if len(usrs) > 0 {
-				// rotation will only ever have 1 active user
-				newUserID = usrs[0]
-			}
+			// rotations may have multiple active participants
+			newUserIDs = rCalc.rot.ActiveUsers()
 		} else if rCalc.rule.Target.TargetType() == assignment.TargetTypeUser {
-			newUserID = rCalc.rule.Target.TargetID()
+			newUserIDs = []string{rCalc.rule.Target.TargetID()}
 		}
 	}
 
-	rCalc.changed = rCalc.userID != newUserID
-	rCalc.userID = newUserID
+	rCalc.changed = !slices.Equal(rCalc.userIDs, newUserIDs)
+	rCalc.userIDs = append(rCalc.userIDs[:0], newUserIDs...)
 
 	return 0
 }
@@ -99,8 +105,18 @@ func (rCalc *SingleRuleCalculator) Process(int64) int64 {
 // Done implements the SubIterator.Done method.
 func (rCalc *SingleRuleCalculator) Done() {}
 
-// ActiveUser returns the currently active UserID or an empty string.
-func (rCalc *SingleRuleCalculator) ActiveUser() string { return rCalc.userID }
+// ActiveUser returns the first currently active UserID or an empty string.
+func (rCalc *SingleRuleCalculator) ActiveUser() string {
+	if len(rCalc.userIDs) == 0 {
+		return ""
+	}
+
+	return rCalc.userIDs[0]
+}
+
+// ActiveUsers returns all currently active UserIDs. It is only valid until the following Next() call
+// and should not be modified.
+func (rCalc *SingleRuleCalculator) ActiveUsers() []string { return rCalc.userIDs }
 
 // Changed will return true if the ActiveUser has changed this tick.
 func (rCalc *SingleRuleCalculator) Changed() bool { return rCalc.changed }
@@ -60,4 +60,33 @@ func TestSingleRuleCalculator(t *testing.T) {
 			},
 		},
 	)
+	check("holiday excluded",
+		[]result{
+			{Time: start},
+			{Time: end},
+		},
+		oncall.ResolvedRule{
+			Rule: rule.Rule{
+				WeekdayFilter: timeutil.EveryDay(),
+				Target:        assignment.UserTarget("foo"),
+				HolidayFilter: rule.HolidayFilterExclude,
+			},
+			Holidays: rule.NewHolidaySet(start),
+		},
+	)
+
+	check("holiday only",
+		[]result{
+			{Time: start, Value: "foo"},
+			{Time: end, Value: "foo"},
+		},
+		oncall.ResolvedRule{
+			Rule: rule.Rule{
+				WeekdayFilter: timeutil.EveryDay(),
+				Target:        assignment.UserTarget("foo"),
+				HolidayFilter: rule.HolidayFilterOnly,
+			},
+			Holidays: rule.NewHolidaySet(start),
+		},
+	)
 }
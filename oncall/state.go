@@ -14,6 +14,9 @@ import (
 type ResolvedRule struct {
 	rule.Rule
 	Rotation *ResolvedRotation
+
+	// Holidays are the holidays of the schedule, used with the HolidayFilter of the rule.
+	Holidays rule.HolidaySet
 }
 type ResolvedRotation struct {
 	rotation.Rotation
@@ -21,6 +24,9 @@ type ResolvedRotation struct {
 	CurrentStart time.Time
 	CurrentEnd   time.Time
 	Users        []string
+
+	// Shadows is the shadow flag of each participant in Users.
+	Shadows []bool
 }
 
 type state struct {
@@ -40,6 +46,18 @@ func (r *ResolvedRotation) UserID(t time.Time) string {
 		return r.Users[0]
 	}
 
+	if r.Type == rotation.TypeFollowTheSun {
+		// handoffs are determined by participant windows rather than shift length
+		r.CurrentStart = r.StartTime(t)
+		r.CurrentEnd = r.EndTime(t)
+		if idx := r.ActiveIndex(t); idx >= 0 && idx < len(r.Users) {
+			r.CurrentIndex = idx
+		}
+
+		// without any windows, the current participant remains on call
+		return r.Users[r.CurrentIndex%len(r.Users)]
+	}
+
 	if r.CurrentStart.IsZero() {
 		r.CurrentStart = r.StartTime(t)
 	}
@@ -53,15 +71,16 @@ func (r *ResolvedRotation) UserID(t time.Time) string {
 		return r.Users[r.CurrentIndex]
 	}
 
+	shadows := r.shadows()
 	for !t.Before(r.CurrentEnd) {
 		r.CurrentStart = r.CurrentEnd
 		r.CurrentEnd = r.EndTime(r.CurrentStart)
-		r.CurrentIndex++
+		r.CurrentIndex = rotation.NextPosition(r.CurrentIndex, shadows)
 	}
 	for t.Before(r.CurrentStart) {
 		r.CurrentEnd = r.CurrentStart
 		r.CurrentStart = r.StartTime(r.CurrentStart.Add(-1))
-		r.CurrentIndex--
+		r.CurrentIndex = rotation.PrevPosition(r.CurrentIndex, shadows)
 	}
 	r.CurrentIndex %= len(r.Users)
 	if r.CurrentIndex < 0 {
@@ -70,8 +89,37 @@ func (r *ResolvedRotation) UserID(t time.Time) string {
 
 	return r.Users[r.CurrentIndex]
 }
+
+// shadows returns the shadow flag of each participant, treating missing values as false.
+func (r *ResolvedRotation) shadows() []bool {
+	if len(r.Shadows) == len(r.Users) {
+		return r.Shadows
+	}
+
+	return make([]bool, len(r.Users))
+}
+
+// UserIDs returns all users on call at t, including additional active and shadow participants. It
+// updates CurrentStart and CurrentEnd in the same way as UserID.
+func (r *ResolvedRotation) UserIDs(t time.Time) []string {
+	id := r.UserID(t)
+	if id == "" {
+		return nil
+	}
+	if len(r.Users) == 1 {
+		return []string{id}
+	}
+
+	positions := rotation.ActivePositions(r.CurrentIndex%len(r.Users), r.ActiveCount, r.shadows())
+	result := make([]string, 0, len(positions))
+	for _, p := range positions {
+		result = append(result, r.Users[p])
+	}
+
+	return result
+}
 func (r ResolvedRule) UserID(t time.Time) string {
-	if !r.IsActive(t) {
+	if !r.IsActiveOn(t, r.Holidays) {
 		return ""
 	}
 	switch r.Target.TargetType() {
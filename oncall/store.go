@@ -12,9 +12,11 @@ import (
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/util/timeutil"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -54,7 +56,9 @@ type Store struct {
 	schedOnCall *sql.Stmt
 	schedTZ     *sql.Stmt
 	schedRot    *sql.Stmt
+	rotInfo     *sql.Stmt
 	rotParts    *sql.Stmt
+	holidays    *sql.Stmt
 
 	ruleStore  *rule.Store
 	schedStore *schedule.Store
@@ -113,6 +117,14 @@ func NewStore(ctx context.Context, db *sql.DB, ruleStore *rule.Store, schedStore
 				(end_time isnull or (end_time - start_time) > '1 minute'::interval)
 		`),
 		schedTZ: p.P(`select time_zone, now() from schedules where id = $1`),
+		holidays: p.P(`
+			select distinct d.date
+			from schedule_holiday_calendars sched
+			join holiday_calendar_dates d on d.calendar_id = sched.calendar_id
+			where
+				sched.schedule_id = $1 and
+				d.date between $2::date - 1 and $3::date + 1
+		`),
 		schedRot: p.P(`
 			select distinct
 				rot.id,
@@ -120,6 +132,7 @@ func NewStore(ctx context.Context, db *sql.DB, ruleStore *rule.Store, schedStore
 				rot.start_time,
 				rot.shift_length,
 				rot.time_zone,
+				rot.active_count,
 				state.position,
 				state.shift_start
 			from schedule_rules rule
@@ -127,10 +140,28 @@ func NewStore(ctx context.Context, db *sql.DB, ruleStore *rule.Store, schedStore
 			join rotation_state state on state.rotation_id = rule.tgt_rotation_id
 			where rule.schedule_id = $1 and rule.tgt_rotation_id notnull
 		`),
+		rotInfo: p.P(`
+			select
+				rot.id,
+				rot.type,
+				rot.start_time,
+				rot.shift_length,
+				rot.time_zone,
+				rot.active_count,
+				state.position,
+				state.shift_start
+			from rotations rot
+			join rotation_state state on state.rotation_id = rot.id
+			where rot.id = $1
+		`),
 		rotParts: p.P(`
 			select
 				rotation_id,
-				user_id
+				user_id,
+				window_start,
+				window_end,
+				window_time_zone,
+				shadow
 			from rotation_participants
 			where rotation_id = any($1)
 			order by
@@ -238,36 +269,24 @@ func (s *Store) HistoryBySchedule(ctx context.Context, scheduleID string, start,
 		return nil, errors.Wrap(err, "lookup schedule rotations")
 	}
 	defer rows.Close()
-	rots := make(map[string]*ResolvedRotation)
-	var rotIDs []string
-	for rows.Next() {
-		var rot ResolvedRotation
-		var rotTZ string
-		err = rows.Scan(&rot.ID, &rot.Type, &rot.Start, &rot.ShiftLength, &rotTZ, &rot.CurrentIndex, &rot.CurrentStart)
-		if err != nil {
-			return nil, errors.Wrap(err, "scan rotation info")
-		}
-		loc, err := util.LoadLocation(rotTZ)
-		if err != nil {
-			return nil, errors.Wrap(err, "load time zone info")
-		}
-		rot.Start = rot.Start.In(loc)
-		rots[rot.ID] = &rot
-		rotIDs = append(rotIDs, rot.ID)
+	rots, err := s.loadRotations(ctx, tx, rows)
+	if err != nil {
+		return nil, err
 	}
 
-	rows, err = tx.StmtContext(ctx, s.rotParts).QueryContext(ctx, sqlutil.UUIDArray(rotIDs))
+	rows, err = tx.StmtContext(ctx, s.holidays).QueryContext(ctx, scheduleID, start, end)
 	if err != nil {
-		return nil, errors.Wrap(err, "lookup rotation participants")
+		return nil, errors.Wrap(err, "lookup holidays")
 	}
 	defer rows.Close()
+	holidays := make(rule.HolidaySet)
 	for rows.Next() {
-		var rotID, userID string
-		err = rows.Scan(&rotID, &userID)
+		var date time.Time
+		err = rows.Scan(&date)
 		if err != nil {
-			return nil, errors.Wrap(err, "scan rotation participant info")
+			return nil, errors.Wrap(err, "scan holiday")
 		}
-		rots[rotID].Users = append(rots[rotID].Users, userID)
+		holidays.Add(date)
 	}
 
 	rawRules, err := s.ruleStore.FindAllTx(ctx, tx, scheduleID)
@@ -281,9 +300,10 @@ func (s *Store) HistoryBySchedule(ctx context.Context, scheduleID string, start,
 			rules = append(rules, ResolvedRule{
 				Rule:     r,
 				Rotation: rots[r.Target.TargetID()],
+				Holidays: holidays,
 			})
 		} else {
-			rules = append(rules, ResolvedRule{Rule: r})
+			rules = append(rules, ResolvedRule{Rule: r, Holidays: holidays})
 		}
 	}
 
@@ -350,3 +370,120 @@ func (s *Store) HistoryBySchedule(ctx context.Context, scheduleID string, start,
 
 	return st.CalculateShifts(start, end), nil
 }
+
+// loadRotations will scan rotation info from rows and load the participants of each rotation.
+func (s *Store) loadRotations(ctx context.Context, tx *sql.Tx, rows *sql.Rows) (map[string]*ResolvedRotation, error) {
+	defer rows.Close()
+
+	rots := make(map[string]*ResolvedRotation)
+	var rotIDs []string
+	for rows.Next() {
+		var rot ResolvedRotation
+		var rotTZ string
+		err := rows.Scan(&rot.ID, &rot.Type, &rot.Start, &rot.ShiftLength, &rotTZ, &rot.ActiveCount, &rot.CurrentIndex, &rot.CurrentStart)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan rotation info")
+		}
+		loc, err := util.LoadLocation(rotTZ)
+		if err != nil {
+			return nil, errors.Wrap(err, "load time zone info")
+		}
+		rot.Start = rot.Start.In(loc)
+		rots[rot.ID] = &rot
+		rotIDs = append(rotIDs, rot.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "read rotation info")
+	}
+
+	rows, err := tx.StmtContext(ctx, s.rotParts).QueryContext(ctx, sqlutil.UUIDArray(rotIDs))
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup rotation participants")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rotID, userID string
+		var winStart, winEnd sql.Null[timeutil.Clock]
+		var winTZ sql.NullString
+		var shadow bool
+		err = rows.Scan(&rotID, &userID, &winStart, &winEnd, &winTZ, &shadow)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan rotation participant info")
+		}
+		rot := rots[rotID]
+		rot.Users = append(rot.Users, userID)
+		rot.Shadows = append(rot.Shadows, shadow)
+		if rot.Type != rotation.TypeFollowTheSun {
+			continue
+		}
+
+		var w *rotation.ParticipantWindow
+		if winTZ.Valid {
+			loc, err := util.LoadLocation(winTZ.String)
+			if err != nil {
+				return nil, errors.Wrap(err, "load participant window time zone")
+			}
+			w = &rotation.ParticipantWindow{Start: winStart.V, End: winEnd.V, TimeZone: loc}
+		}
+		rot.Windows = append(rot.Windows, w)
+	}
+
+	return rots, nil
+}
+
+// ShiftsByRotation will return the projected shifts of a rotation's participants between start and end.
+// Shifts are calculated from the current state of the rotation, so shifts before the current one are not
+// included; the current shift is included in full if it started within 30 days of start.
+func (s *Store) ShiftsByRotation(ctx context.Context, rotationID string, start, end time.Time) ([]Shift, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("RotationID", rotationID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{
+		ReadOnly:  true,
+		Isolation: sql.LevelRepeatableRead,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "begin transaction")
+	}
+	defer sqlutil.Rollback(ctx, "oncall: fetch rotation shifts", tx)
+
+	rows, err := tx.StmtContext(ctx, s.rotInfo).QueryContext(ctx, rotationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup rotation")
+	}
+	rots, err := s.loadRotations(ctx, tx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(err, "commit tx")
+	}
+
+	rot := rots[rotationID]
+	if rot == nil {
+		// no participants
+		return nil, nil
+	}
+	if rot.CurrentStart.After(start.AddDate(0, 0, -30)) {
+		// include the full current shift
+		start = rot.CurrentStart
+	}
+
+	st := state{
+		rules: []ResolvedRule{{
+			Rule:     *rule.NewAlwaysActive("", assignment.RotationTarget(rotationID)),
+			Rotation: rot,
+		}},
+		loc: rot.Start.Location(),
+	}
+
+	return st.CalculateShifts(start, end), nil
+}
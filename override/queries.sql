@@ -53,3 +53,109 @@ ORDER BY
     o.id
 LIMIT 150;
 
+
+-- name: OverrideRequestCreate :exec
+INSERT INTO override_requests(id, schedule_id, requester_user_id, target_user_id, add_user_id, remove_user_id, start_time, end_time)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: OverrideRequestNotify :exec
+-- OverrideRequestNotify queues a notification of the request to the target user, for each contact method with an immediate notification rule.
+INSERT INTO outgoing_messages(message_type, contact_method_id, user_id, override_request_id)
+SELECT
+    'override_request_notification',
+    cm.id,
+    cm.user_id,
+    req.id
+FROM
+    override_requests req
+    JOIN user_contact_methods cm ON cm.user_id = req.target_user_id
+        AND NOT cm.disabled
+WHERE
+    req.id = $1
+    AND EXISTS (
+        SELECT
+        FROM
+            user_notification_rules nr
+        WHERE
+            nr.contact_method_id = cm.id
+            AND nr.delay_minutes = 0);
+
+-- name: OverrideRequestFindOne :one
+SELECT
+    id,
+    schedule_id,
+    requester_user_id,
+    target_user_id,
+    add_user_id,
+    remove_user_id,
+    start_time,
+    end_time,
+    status,
+    created_at,
+    override_id
+FROM
+    override_requests
+WHERE
+    id = $1;
+
+-- name: OverrideRequestFindOneForUpdate :one
+SELECT
+    id,
+    schedule_id,
+    requester_user_id,
+    target_user_id,
+    add_user_id,
+    remove_user_id,
+    start_time,
+    end_time,
+    status,
+    created_at,
+    override_id
+FROM
+    override_requests
+WHERE
+    id = $1
+FOR UPDATE;
+
+-- name: OverrideRequestSearch :many
+SELECT
+    id,
+    schedule_id,
+    requester_user_id,
+    target_user_id,
+    add_user_id,
+    remove_user_id,
+    start_time,
+    end_time,
+    status,
+    created_at,
+    override_id
+FROM
+    override_requests
+WHERE (sqlc.narg(target_user_id)::uuid ISNULL
+    OR target_user_id = @target_user_id)
+AND (sqlc.narg(requester_user_id)::uuid ISNULL
+    OR requester_user_id = @requester_user_id)
+AND (sqlc.narg(schedule_id)::uuid ISNULL
+    OR schedule_id = @schedule_id)
+AND (NOT @pending_only::bool
+    OR (status = 'pending'
+        AND end_time > now()))
+ORDER BY
+    start_time,
+    id
+LIMIT 150;
+
+-- name: OverrideRequestSetStatus :exec
+UPDATE
+    override_requests
+SET
+    status = $2,
+    override_id = $3,
+    responded_at = now()
+WHERE
+    id = $1;
+
+-- name: OverrideRequestDelete :exec
+DELETE FROM override_requests
+WHERE id = $1;
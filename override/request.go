@@ -0,0 +1,110 @@
+package override
+
+import (
+	"time"
+
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// RequestStatus indicates the state of an override request.
+type RequestStatus string
+
+// Override request statuses
+const (
+	RequestStatusPending  RequestStatus = "pending"
+	RequestStatusAccepted RequestStatus = "accepted"
+	RequestStatusDeclined RequestStatus = "declined"
+	RequestStatusExpired  RequestStatus = "expired"
+)
+
+// A Request asks another user to accept an override, e.g., to cover a shift. Once accepted,
+// the UserOverride is created.
+type Request struct {
+	ID         string
+	ScheduleID string
+
+	// RequesterID is the user that created the request.
+	RequesterID string
+
+	// TargetUserID is the user that must accept the request. It must be either the
+	// AddUserID or RemoveUserID.
+	TargetUserID string
+
+	AddUserID    string
+	RemoveUserID string
+	Start        time.Time
+	End          time.Time
+
+	Status    RequestStatus
+	CreatedAt time.Time
+
+	// OverrideID is the ID of the override created when the request was accepted.
+	OverrideID string
+}
+
+// Override returns the UserOverride that accepting the request will create.
+func (r Request) Override() UserOverride {
+	return UserOverride{
+		AddUserID:    r.AddUserID,
+		RemoveUserID: r.RemoveUserID,
+		Start:        r.Start,
+		End:          r.End,
+		Target:       assignment.ScheduleTarget(r.ScheduleID),
+	}
+}
+
+// Normalize will validate fields and return a normalized copy.
+func (r Request) Normalize() (*Request, error) {
+	_, err := r.Override().Normalize()
+	err = validate.Many(err,
+		validate.UUID("RequesterID", r.RequesterID),
+		validate.UUID("TargetUserID", r.TargetUserID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.TargetUserID != r.AddUserID && r.TargetUserID != r.RemoveUserID {
+		return nil, validation.NewFieldError("TargetUserID", "must be the added or removed user")
+	}
+	if r.TargetUserID == r.RequesterID {
+		return nil, validation.NewFieldError("TargetUserID", "cannot be the requester")
+	}
+	if r.AddUserID == r.RemoveUserID {
+		return nil, validation.NewFieldError("AddUserID", "cannot be the same as RemoveUserID")
+	}
+
+	return &r, nil
+}
+
+func requestFromRow(row gadb.OverrideRequestFindOneRow, now time.Time) Request {
+	r := Request{
+		ID:           row.ID.String(),
+		ScheduleID:   row.ScheduleID.String(),
+		RequesterID:  row.RequesterUserID.String(),
+		TargetUserID: row.TargetUserID.String(),
+		Start:        row.StartTime,
+		End:          row.EndTime,
+		Status:       RequestStatus(row.Status),
+		CreatedAt:    row.CreatedAt,
+	}
+	if row.AddUserID.Valid {
+		r.AddUserID = row.AddUserID.UUID.String()
+	}
+	if row.RemoveUserID.Valid {
+		r.RemoveUserID = row.RemoveUserID.UUID.String()
+	}
+	if row.OverrideID.Valid {
+		r.OverrideID = row.OverrideID.UUID.String()
+	}
+
+	// Pending requests are only marked expired periodically, so check the end time as well.
+	if r.Status == RequestStatusPending && !r.End.After(now) {
+		r.Status = RequestStatusExpired
+	}
+
+	return r
+}
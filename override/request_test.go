@@ -0,0 +1,58 @@
+package override
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/gadb"
+)
+
+func TestRequest_Normalize(t *testing.T) {
+	requester, add, remove := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	valid := Request{
+		ScheduleID:   uuid.NewString(),
+		RequesterID:  requester,
+		TargetUserID: add,
+		AddUserID:    add,
+		RemoveUserID: remove,
+		Start:        time.Now(),
+		End:          time.Now().Add(time.Hour),
+	}
+
+	_, err := valid.Normalize()
+	require.NoError(t, err)
+
+	check := func(desc string, fn func(r *Request)) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			r := valid
+			fn(&r)
+			_, err := r.Normalize()
+			assert.Error(t, err)
+		})
+	}
+
+	check("target not affected", func(r *Request) { r.TargetUserID = uuid.NewString() })
+	check("target is requester", func(r *Request) { r.RequesterID = add })
+	check("add equals remove", func(r *Request) { r.RemoveUserID = add })
+	check("end before start", func(r *Request) { r.End = r.Start.Add(-time.Minute) })
+}
+
+func TestRequestFromRow_Expired(t *testing.T) {
+	now := time.Now()
+	row := gadb.OverrideRequestFindOneRow{
+		Status:  gadb.EnumOverrideRequestStatusPending,
+		EndTime: now.Add(-time.Minute),
+	}
+	assert.Equal(t, RequestStatusExpired, requestFromRow(row, now).Status)
+
+	row.EndTime = now.Add(time.Minute)
+	assert.Equal(t, RequestStatusPending, requestFromRow(row, now).Status)
+
+	row.Status = gadb.EnumOverrideRequestStatusAccepted
+	row.EndTime = now.Add(-time.Minute)
+	assert.Equal(t, RequestStatusAccepted, requestFromRow(row, now).Status)
+}
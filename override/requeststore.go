@@ -0,0 +1,231 @@
+package override
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// RequestSearchOptions allow filtering override requests. At least one of the user or schedule filters must be set.
+type RequestSearchOptions struct {
+	TargetUserID    string
+	RequesterUserID string
+	ScheduleID      string
+
+	// PendingOnly will only return requests that are pending and have not yet expired.
+	PendingOnly bool
+}
+
+func nullUUID(id string) uuid.NullUUID {
+	if id == "" {
+		return uuid.NullUUID{}
+	}
+
+	return uuid.NullUUID{UUID: uuid.MustParse(id), Valid: true}
+}
+
+// CreateRequestTx will create a new override request from the current user, and notify the target user.
+func (s *Store) CreateRequestTx(ctx context.Context, tx *sql.Tx, r *Request) (*Request, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	r.RequesterID = permission.UserID(ctx)
+	n, err := r.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	if !n.End.After(time.Now()) {
+		return nil, validation.NewFieldError("End", "must be in the future")
+	}
+
+	n.ID = uuid.NewString()
+	n.Status = RequestStatusPending
+	db := gadb.New(tx)
+	err = db.OverrideRequestCreate(ctx, gadb.OverrideRequestCreateParams{
+		ID:              uuid.MustParse(n.ID),
+		ScheduleID:      uuid.MustParse(n.ScheduleID),
+		RequesterUserID: uuid.MustParse(n.RequesterID),
+		TargetUserID:    uuid.MustParse(n.TargetUserID),
+		AddUserID:       nullUUID(n.AddUserID),
+		RemoveUserID:    nullUUID(n.RemoveUserID),
+		StartTime:       n.Start,
+		EndTime:         n.End,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.OverrideRequestNotify(ctx, uuid.MustParse(n.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// FindOneRequest will return the override request with the given ID.
+func (s *Store) FindOneRequest(ctx context.Context, id string) (*Request, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("RequestID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := gadb.New(s.db).OverrideRequestFindOne(ctx, uuid.MustParse(id))
+	if err != nil {
+		return nil, err
+	}
+
+	r := requestFromRow(row, time.Now())
+	return &r, nil
+}
+
+// SearchRequests will return override requests matching the provided options, ordered by start time.
+func (s *Store) SearchRequests(ctx context.Context, opts RequestSearchOptions) ([]Request, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	if opts.TargetUserID == "" && opts.RequesterUserID == "" && opts.ScheduleID == "" {
+		return nil, validation.NewFieldError("TargetUserID", "a user or schedule filter is required")
+	}
+
+	var errs []error
+	if opts.TargetUserID != "" {
+		errs = append(errs, validate.UUID("TargetUserID", opts.TargetUserID))
+	}
+	if opts.RequesterUserID != "" {
+		errs = append(errs, validate.UUID("RequesterUserID", opts.RequesterUserID))
+	}
+	if opts.ScheduleID != "" {
+		errs = append(errs, validate.UUID("ScheduleID", opts.ScheduleID))
+	}
+	err = validate.Many(errs...)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gadb.New(s.db).OverrideRequestSearch(ctx, gadb.OverrideRequestSearchParams{
+		TargetUserID:    nullUUID(opts.TargetUserID),
+		RequesterUserID: nullUUID(opts.RequesterUserID),
+		ScheduleID:      nullUUID(opts.ScheduleID),
+		PendingOnly:     opts.PendingOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]Request, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, requestFromRow(gadb.OverrideRequestFindOneRow(row), now))
+	}
+
+	return result, nil
+}
+
+// pendingRequestForUpdate will lock and return the pending request, ensuring the current user is the target user.
+func (s *Store) pendingRequestForUpdate(ctx context.Context, tx *sql.Tx, id string) (*Request, error) {
+	err := validate.UUID("RequestID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := gadb.New(tx).OverrideRequestFindOneForUpdate(ctx, uuid.MustParse(id))
+	if err != nil {
+		return nil, err
+	}
+	r := requestFromRow(gadb.OverrideRequestFindOneRow(row), time.Now())
+
+	err = permission.LimitCheckAny(ctx, permission.MatchUser(r.TargetUserID))
+	if err != nil {
+		return nil, err
+	}
+	if r.Status != RequestStatusPending {
+		return nil, validation.NewGenericError("request is already " + string(r.Status))
+	}
+
+	return &r, nil
+}
+
+// AcceptRequestTx will accept a pending request for the current user, creating the override.
+func (s *Store) AcceptRequestTx(ctx context.Context, tx *sql.Tx, id string) (*Request, error) {
+	r, err := s.pendingRequestForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	o := r.Override()
+	if o.Start.Before(time.Now()) {
+		// the request may be accepted after it starts, covering the remainder
+		o.Start = time.Now()
+	}
+	created, err := s.CreateUserOverrideTx(ctx, tx, &o)
+	if err != nil {
+		return nil, err
+	}
+
+	err = gadb.New(tx).OverrideRequestSetStatus(ctx, gadb.OverrideRequestSetStatusParams{
+		ID:         uuid.MustParse(r.ID),
+		Status:     gadb.EnumOverrideRequestStatusAccepted,
+		OverrideID: nullUUID(created.ID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.Status = RequestStatusAccepted
+	r.OverrideID = created.ID
+	return r, nil
+}
+
+// DeclineRequestTx will decline a pending request for the current user.
+func (s *Store) DeclineRequestTx(ctx context.Context, tx *sql.Tx, id string) (*Request, error) {
+	r, err := s.pendingRequestForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = gadb.New(tx).OverrideRequestSetStatus(ctx, gadb.OverrideRequestSetStatusParams{
+		ID:     uuid.MustParse(r.ID),
+		Status: gadb.EnumOverrideRequestStatusDeclined,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.Status = RequestStatusDeclined
+	return r, nil
+}
+
+// DeleteRequestTx will delete (cancel) a request. Only the requester or an admin may delete a request.
+func (s *Store) DeleteRequestTx(ctx context.Context, tx *sql.Tx, id string) error {
+	err := validate.UUID("RequestID", id)
+	if err != nil {
+		return err
+	}
+
+	db := gadb.New(tx)
+	row, err := db.OverrideRequestFindOneForUpdate(ctx, uuid.MustParse(id))
+	if err != nil {
+		return err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(row.RequesterUserID.String()))
+	if err != nil {
+		return err
+	}
+
+	return db.OverrideRequestDelete(ctx, row.ID)
+}
@@ -91,6 +91,8 @@ type postBody struct {
 	CommonLabels struct {
 		Instance  string
 		AlertName string `json:"alertname"`
+		Severity  string
+		Priority  string
 	}
 
 	CommonAnnotations struct {
@@ -102,6 +104,8 @@ type postBodyAlert struct {
 	Labels struct {
 		AlertName string
 		Instance  string
+		Severity  string
+		Priority  string
 	}
 	Annotations struct {
 		Summary string
@@ -153,6 +157,30 @@ func (b postBody) Summary() string {
 	return b.CommonLabels.AlertName + " " + strings.Join(instances, ",")
 }
 
+// Priority returns the priority from the common labels, falling back to the
+// most urgent priority of any individual alert.
+func (b postBody) Priority() alert.Priority {
+	p := alert.PriorityFromLabels(map[string]string{
+		"priority": b.CommonLabels.Priority,
+		"severity": b.CommonLabels.Severity,
+	})
+	if p.IsSet() {
+		return p
+	}
+
+	for _, a := range b.Alerts {
+		ap := alert.PriorityFromLabels(map[string]string{
+			"priority": a.Labels.Priority,
+			"severity": a.Labels.Severity,
+		})
+		if ap.IsSet() && (!p.IsSet() || ap < p) {
+			p = ap
+		}
+	}
+
+	return p
+}
+
 func (b postBody) Details(payload string) string {
 	var s strings.Builder
 	if b.ExternalURL != "" {
@@ -220,6 +248,15 @@ func PrometheusAlertmanagerEventsAPI(aDB *alert.Store, intDB *integrationkey.Sto
 			data = buf.Bytes()
 		}
 
+		prio, err := alert.ParsePriority(r.FormValue("priority"))
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from prometheus alertmanager: %v", err)
+			return
+		}
+		if !prio.IsSet() {
+			prio = body.Priority()
+		}
+
 		summary := validate.SanitizeText(body.Summary(), alert.MaxSummaryLength)
 		msg := &alert.Alert{
 			Summary:   summary,
@@ -228,6 +265,7 @@ func PrometheusAlertmanagerEventsAPI(aDB *alert.Store, intDB *integrationkey.Sto
 			Source:    alert.SourcePrometheusAlertmanager,
 			ServiceID: serviceID,
 			Dedup:     alert.NewUserDedup(summary),
+			Priority:  prio,
 		}
 
 		err = retry.DoTemporaryError(func(int) error {
@@ -0,0 +1,38 @@
+-- name: QuietHoursFindOneByUser :one
+SELECT
+    *
+FROM
+    quiet_hours
+WHERE
+    user_id = $1;
+
+-- name: QuietHoursFindOneByService :one
+SELECT
+    *
+FROM
+    quiet_hours
+WHERE
+    service_id = $1;
+
+-- name: QuietHoursSetUser :exec
+INSERT INTO quiet_hours(id, user_id, time_zone, start_time, end_time)
+    VALUES (@id, @user_id, @time_zone, @start_time, @end_time)
+ON CONFLICT (user_id)
+    DO UPDATE SET
+        time_zone = excluded.time_zone, start_time = excluded.start_time, end_time = excluded.end_time;
+
+-- name: QuietHoursSetService :exec
+INSERT INTO quiet_hours(id, service_id, time_zone, start_time, end_time)
+    VALUES (@id, @service_id, @time_zone, @start_time, @end_time)
+ON CONFLICT (service_id)
+    DO UPDATE SET
+        time_zone = excluded.time_zone, start_time = excluded.start_time, end_time = excluded.end_time;
+
+-- name: QuietHoursDeleteUser :exec
+DELETE FROM quiet_hours
+WHERE user_id = $1;
+
+-- name: QuietHoursDeleteService :exec
+DELETE FROM quiet_hours
+WHERE service_id = $1;
+
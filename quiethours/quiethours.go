@@ -0,0 +1,61 @@
+// Package quiethours manages quiet hours for users and services, during which non-urgent notifications
+// are held and later delivered as a digest.
+package quiethours
+
+import (
+	"time"
+
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
+)
+
+// QuietHours is a daily period of time during which non-urgent notifications (status updates and on-call
+// notifications) are held.
+type QuietHours struct {
+	// Exactly one of UserID or ServiceID is set.
+	UserID    string
+	ServiceID string
+
+	TimeZone *time.Location
+
+	// Start and End are the clock times, in TimeZone, that quiet hours begin and end each day. If End is before
+	// Start, quiet hours span midnight.
+	Start, End timeutil.Clock
+}
+
+// Normalize will validate and return a normalized copy of the QuietHours.
+func (q QuietHours) Normalize() (*QuietHours, error) {
+	if q.TimeZone == nil {
+		return nil, validation.NewFieldError("TimeZone", "must be specified")
+	}
+	if q.Start == q.End {
+		return nil, validation.NewFieldError("End", "must be different from start")
+	}
+
+	q.Start = timeutil.NewClock(q.Start.Hour(), q.Start.Minute())
+	q.End = timeutil.NewClock(q.End.Hour(), q.End.Minute())
+	return &q, nil
+}
+
+// Until returns the end of the quiet period that t is in. It returns false if t is not during quiet hours.
+func (q QuietHours) Until(t time.Time) (time.Time, bool) {
+	t = t.In(q.TimeZone)
+	c := timeutil.NewClockFromTime(t)
+
+	if q.Start < q.End {
+		if c < q.Start || c >= q.End {
+			return time.Time{}, false
+		}
+		return q.End.FirstOfDay(t), true
+	}
+
+	// spans midnight
+	switch {
+	case c >= q.Start:
+		return q.End.FirstOfDay(t.AddDate(0, 0, 1)), true
+	case c < q.End:
+		return q.End.FirstOfDay(t), true
+	}
+
+	return time.Time{}, false
+}
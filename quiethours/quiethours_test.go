@@ -0,0 +1,39 @@
+package quiethours
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/util/timeutil"
+)
+
+func TestQuietHours_Until(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(desc string, q QuietHours, at time.Time, expEnd time.Time, expOK bool) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			end, ok := q.Until(at)
+			assert.Equal(t, expOK, ok)
+			assert.True(t, expEnd.Equal(end), "expected %s; got %s", expEnd, end)
+		})
+	}
+
+	day := QuietHours{TimeZone: loc, Start: timeutil.NewClock(12, 0), End: timeutil.NewClock(13, 0)}
+	check("day, before", day, time.Date(2020, 1, 1, 11, 59, 0, 0, loc), time.Time{}, false)
+	check("day, start", day, time.Date(2020, 1, 1, 12, 0, 0, 0, loc), time.Date(2020, 1, 1, 13, 0, 0, 0, loc), true)
+	check("day, end", day, time.Date(2020, 1, 1, 13, 0, 0, 0, loc), time.Time{}, false)
+
+	night := QuietHours{TimeZone: loc, Start: timeutil.NewClock(22, 0), End: timeutil.NewClock(7, 0)}
+	check("night, evening", night, time.Date(2020, 1, 1, 23, 0, 0, 0, loc), time.Date(2020, 1, 2, 7, 0, 0, 0, loc), true)
+	check("night, morning", night, time.Date(2020, 1, 2, 6, 0, 0, 0, loc), time.Date(2020, 1, 2, 7, 0, 0, 0, loc), true)
+	check("night, day", night, time.Date(2020, 1, 2, 12, 0, 0, 0, loc), time.Time{}, false)
+	check("night, other zone", night, time.Date(2020, 1, 2, 5, 0, 0, 0, time.UTC), time.Date(2020, 1, 2, 7, 0, 0, 0, loc), true)
+
+	// DST starts at 2AM on March 8, 2020
+	check("night, DST", night, time.Date(2020, 3, 7, 23, 0, 0, 0, loc), time.Date(2020, 3, 8, 7, 0, 0, 0, loc), true)
+}
@@ -0,0 +1,159 @@
+package quiethours
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store manages quiet hours of users and services.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore will create a new Store.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	return &Store{db: db}, nil
+}
+
+func (s *Store) dbtx(tx *sql.Tx) *gadb.Queries {
+	db := gadb.New(s.db)
+	if tx == nil {
+		return db
+	}
+
+	return db.WithTx(tx)
+}
+
+// FromDB converts a database row to QuietHours.
+func FromDB(row gadb.QuietHour) (*QuietHours, error) {
+	loc, err := util.LoadLocation(row.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("load time zone: %w", err)
+	}
+
+	q := &QuietHours{
+		TimeZone: loc,
+		Start:    row.StartTime,
+		End:      row.EndTime,
+	}
+	if row.UserID.Valid {
+		q.UserID = row.UserID.UUID.String()
+	}
+	if row.ServiceID.Valid {
+		q.ServiceID = row.ServiceID.UUID.String()
+	}
+
+	return q, nil
+}
+
+func fromRow(row gadb.QuietHour, err error) (*QuietHours, error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return FromDB(row)
+}
+
+// FindOneByUser will return the quiet hours of a user, or nil if they have none.
+func (s *Store) FindOneByUser(ctx context.Context, userID string) (*QuietHours, error) {
+	err := validate.UUID("UserID", userID)
+	if err != nil {
+		return nil, err
+	}
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	return fromRow(s.dbtx(nil).QuietHoursFindOneByUser(ctx, uuid.NullUUID{UUID: uuid.MustParse(userID), Valid: true}))
+}
+
+// FindOneByService will return the quiet hours of a service, or nil if it has none.
+func (s *Store) FindOneByService(ctx context.Context, serviceID string) (*QuietHours, error) {
+	err := validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return nil, err
+	}
+	err = permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromRow(s.dbtx(nil).QuietHoursFindOneByService(ctx, uuid.NullUUID{UUID: uuid.MustParse(serviceID), Valid: true}))
+}
+
+// SetUserTx will set the quiet hours of a user. If q is nil, quiet hours are removed. Notifications already
+// held are delivered as a digest when the current quiet period would have ended.
+func (s *Store) SetUserTx(ctx context.Context, tx *sql.Tx, userID string, q *QuietHours) error {
+	err := validate.UUID("UserID", userID)
+	if err != nil {
+		return err
+	}
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return err
+	}
+
+	id := uuid.NullUUID{UUID: uuid.MustParse(userID), Valid: true}
+	if q == nil {
+		return s.dbtx(tx).QuietHoursDeleteUser(ctx, id)
+	}
+
+	n, err := q.Normalize()
+	if err != nil {
+		return err
+	}
+
+	err = s.dbtx(tx).QuietHoursSetUser(ctx, gadb.QuietHoursSetUserParams{
+		ID:        uuid.New(),
+		UserID:    id,
+		TimeZone:  n.TimeZone.String(),
+		StartTime: n.Start,
+		EndTime:   n.End,
+	})
+	return errutil.MapDBError(err)
+}
+
+// SetServiceTx will set the quiet hours of a service. If q is nil, quiet hours are removed. Notifications
+// already held are delivered as a digest when the current quiet period would have ended.
+func (s *Store) SetServiceTx(ctx context.Context, tx *sql.Tx, serviceID string, q *QuietHours) error {
+	err := validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return err
+	}
+	err = permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	id := uuid.NullUUID{UUID: uuid.MustParse(serviceID), Valid: true}
+	if q == nil {
+		return s.dbtx(tx).QuietHoursDeleteService(ctx, id)
+	}
+
+	n, err := q.Normalize()
+	if err != nil {
+		return err
+	}
+
+	err = s.dbtx(tx).QuietHoursSetService(ctx, gadb.QuietHoursSetServiceParams{
+		ID:        uuid.New(),
+		ServiceID: id,
+		TimeZone:  n.TimeZone.String(),
+		StartTime: n.Start,
+		EndTime:   n.End,
+	})
+	return errutil.MapDBError(err)
+}
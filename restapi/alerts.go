@@ -0,0 +1,167 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Alert is an alert for a service.
+type Alert struct {
+	ID            int        `json:"id"`
+	Status        string     `json:"status" enum:"triggered,active,closed" doc:"Current status; active means acknowledged."`
+	Summary       string     `json:"summary"`
+	Details       string     `json:"details"`
+	Source        string     `json:"source"`
+	ServiceID     string     `json:"serviceId"`
+	Priority      string     `json:"priority,omitempty" doc:"Priority from P1 (most urgent) to P5, if provided."`
+	CreatedAt     time.Time  `json:"createdAt"`
+	SnoozedUntil  *time.Time `json:"snoozedUntil,omitempty"`
+	FlappingUntil *time.Time `json:"flappingUntil,omitempty"`
+}
+
+func newAlert(a alert.Alert) Alert {
+	res := Alert{
+		ID:        a.ID,
+		Status:    string(a.Status),
+		Summary:   a.Summary,
+		Details:   a.Details,
+		Source:    string(a.Source),
+		ServiceID: a.ServiceID,
+		Priority:  a.Priority.String(),
+		CreatedAt: a.CreatedAt,
+	}
+	if !a.SnoozedUntil.IsZero() {
+		res.SnoozedUntil = &a.SnoozedUntil
+	}
+	if !a.FlappingUntil.IsZero() {
+		res.FlappingUntil = &a.FlappingUntil
+	}
+
+	return res
+}
+
+func (h *Handler) alertRoutes() {
+	addRoute(h, "GET", "/alerts", "Alerts", "List alerts, most recent first.", []queryParam{
+		{Name: "search", Type: "string", Desc: "Filter by summary, ID, or service name."},
+		{Name: "status", Type: "string", Desc: "Comma-separated list of statuses to include (triggered, active, closed)."},
+		{Name: "serviceId", Type: "string", Desc: "Only include alerts for this service."},
+		limitParam, afterParam,
+	}, h.listAlerts)
+	addRoute(h, "GET", "/alerts/{id}", "Alerts", "Get an alert.", nil, h.getAlert)
+	addAction(h, "/alerts/{id}/acknowledge", "Alerts", "Acknowledge an alert.", h.alertAction(func(ctx context.Context, id int) error {
+		return h.c.AlertStore.UpdateStatus(ctx, id, alert.StatusActive)
+	}))
+	addAction(h, "/alerts/{id}/close", "Alerts", "Close an alert.", h.alertAction(func(ctx context.Context, id int) error {
+		return h.c.AlertStore.UpdateStatus(ctx, id, alert.StatusClosed)
+	}))
+	addAction(h, "/alerts/{id}/escalate", "Alerts", "Escalate an alert to the next step.", h.alertAction(func(ctx context.Context, id int) error {
+		_, err := h.c.AlertStore.EscalateMany(ctx, []int{id})
+		return err
+	}))
+}
+
+func alertID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		return 0, validation.NewFieldError("id", "must be an integer")
+	}
+
+	return id, nil
+}
+
+func (h *Handler) listAlerts(ctx context.Context, r *http.Request, _ *empty) (*List[Alert], error) {
+	limit, err := listLimit(r)
+	if err != nil {
+		return nil, err
+	}
+
+	q := r.URL.Query()
+	var opts alert.SearchOptions
+	opts.Search = q.Get("search")
+	if status := q.Get("status"); status != "" {
+		for _, s := range strings.Split(status, ",") {
+			st := alert.Status(s)
+			err = validate.OneOf("status", st, alert.StatusTriggered, alert.StatusActive, alert.StatusClosed)
+			if err != nil {
+				return nil, err
+			}
+			opts.Status = append(opts.Status, st)
+		}
+	}
+	if svcID := q.Get("serviceId"); svcID != "" {
+		opts.ServiceFilter.Valid = true
+		opts.ServiceFilter.IDs = []string{svcID}
+	}
+	if after := q.Get("after"); after != "" {
+		err = search.ParseCursor(after, &opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	opts.Limit = limit + 1
+
+	alerts, err := h.c.AlertStore.Search(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var res List[Alert]
+	if len(alerts) > limit {
+		alerts = alerts[:limit]
+		last := alerts[len(alerts)-1]
+		opts.After.ID = last.ID
+		opts.After.Status = last.Status
+		opts.After.Created = last.CreatedAt
+		res.Next, err = search.Cursor(opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res.Items = make([]Alert, 0, len(alerts))
+	for _, a := range alerts {
+		res.Items = append(res.Items, newAlert(a))
+	}
+
+	return &res, nil
+}
+
+func (h *Handler) getAlert(ctx context.Context, r *http.Request, _ *empty) (*Alert, error) {
+	id, err := alertID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := h.c.AlertStore.FindOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newAlert(*a)
+	return &res, nil
+}
+
+// alertAction returns a handler that performs fn on the alert and responds with its updated state.
+func (h *Handler) alertAction(fn func(ctx context.Context, id int) error) func(context.Context, *http.Request, *empty) (*Alert, error) {
+	return func(ctx context.Context, r *http.Request, _ *empty) (*Alert, error) {
+		id, err := alertID(r)
+		if err != nil {
+			return nil, err
+		}
+
+		err = fn(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		return h.getAlert(ctx, r, nil)
+	}
+}
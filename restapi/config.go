@@ -0,0 +1,26 @@
+package restapi
+
+import (
+	"database/sql"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/escalation"
+	"github.com/target/goalert/override"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/user"
+)
+
+// Config contains the values needed to implement the REST API handler.
+type Config struct {
+	DB *sql.DB
+
+	AlertStore    *alert.Store
+	ServiceStore  *service.Store
+	PolicyStore   *escalation.Store
+	ScheduleStore *schedule.Store
+	RotationStore *rotation.Store
+	OverrideStore *override.Store
+	UserStore     *user.Store
+}
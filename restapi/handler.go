@@ -0,0 +1,242 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+)
+
+// BasePath is the path prefix for all REST API routes.
+const BasePath = "/api/rest/v1"
+
+// maxBody is the maximum size of a request body.
+const maxBody = 1 << 20
+
+// Handler serves the REST API.
+type Handler struct {
+	c      Config
+	routes []route
+	spec   openAPI
+}
+
+// empty is used for routes without a request or response body.
+type empty struct{}
+
+type queryParam struct {
+	Name string
+	Type string
+	Desc string
+}
+
+type route struct {
+	Method  string
+	Path    string
+	Tag     string
+	Summary string
+	Query   []queryParam
+
+	Req    reflect.Type
+	Resp   reflect.Type
+	Status int
+
+	serve http.HandlerFunc
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(c Config) *Handler {
+	h := &Handler{c: c}
+
+	h.serviceRoutes()
+	h.policyRoutes()
+	h.scheduleRoutes()
+	h.rotationRoutes()
+	h.overrideRoutes()
+	h.alertRoutes()
+	h.userRoutes()
+
+	return h
+}
+
+// Register will register all REST API routes, including the OpenAPI document, with the given mux.
+func (h *Handler) Register(mux *http.ServeMux) {
+	for _, r := range h.routes {
+		mux.HandleFunc(r.Method+" "+BasePath+r.Path, r.serve)
+	}
+	mux.HandleFunc("GET "+BasePath+"/openapi.json", h.ServeOpenAPI)
+}
+
+// addRoute registers a route with a typed request and response body. The request body is
+// decoded from JSON unless Req is empty, and the response is encoded as JSON unless Resp is empty.
+//
+// Successful POST requests respond with 201 Created, and routes without a response body with 204 No Content.
+func addRoute[Req, Resp any](h *Handler, method, path, tag, summary string, query []queryParam, fn func(ctx context.Context, r *http.Request, body *Req) (*Resp, error)) {
+	status := http.StatusOK
+	switch {
+	case reflect.TypeFor[Resp]() == reflect.TypeFor[empty]():
+		status = http.StatusNoContent
+	case method == http.MethodPost:
+		status = http.StatusCreated
+	}
+
+	addRouteStatus(h, method, path, tag, summary, query, status, fn)
+}
+
+// addAction registers a POST route that performs an action on an existing resource, responding with 200 OK.
+func addAction[Resp any](h *Handler, path, tag, summary string, fn func(ctx context.Context, r *http.Request, body *empty) (*Resp, error)) {
+	addRouteStatus(h, http.MethodPost, path, tag, summary, nil, http.StatusOK, fn)
+}
+
+func addRouteStatus[Req, Resp any](h *Handler, method, path, tag, summary string, query []queryParam, status int, fn func(ctx context.Context, r *http.Request, body *Req) (*Resp, error)) {
+	reqType := reflect.TypeFor[Req]()
+	respType := reflect.TypeFor[Resp]()
+
+	h.routes = append(h.routes, route{
+		Method:  method,
+		Path:    path,
+		Tag:     tag,
+		Summary: summary,
+		Query:   query,
+		Req:     reqType,
+		Resp:    respType,
+		Status:  status,
+		serve: func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			var body Req
+			if method == http.MethodPost {
+				// POST is the only method that can be sent cross-site without a CORS preflight;
+				// requiring JSON prevents form submissions from using session cookies.
+				ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+				if ct != "application/json" {
+					writeError(ctx, w, validation.NewGenericError("Content-Type must be application/json"))
+					return
+				}
+			}
+			if reqType != reflect.TypeFor[empty]() {
+				dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBody))
+				dec.DisallowUnknownFields()
+				err := dec.Decode(&body)
+				if err != nil {
+					writeError(ctx, w, decodeError(err))
+					return
+				}
+			}
+
+			resp, err := fn(ctx, r, &body)
+			if err != nil {
+				writeError(ctx, w, err)
+				return
+			}
+
+			if status == http.StatusNoContent {
+				w.WriteHeader(status)
+				return
+			}
+			writeJSON(w, status, resp)
+		},
+	})
+}
+
+func decodeError(err error) error {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return err
+	}
+
+	return validation.NewGenericError("invalid request body: " + err.Error())
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// Error is the response body for all failed requests.
+type Error struct {
+	Error string `json:"error"`
+}
+
+// errorWriter captures the status and message written by errutil.HTTPError.
+type errorWriter struct {
+	h      http.Header
+	status int
+	msg    []byte
+}
+
+func (e *errorWriter) Header() http.Header         { return e.h }
+func (e *errorWriter) WriteHeader(status int)      { e.status = status }
+func (e *errorWriter) Write(p []byte) (int, error) { e.msg = append(e.msg, p...); return len(p), nil }
+
+// writeError will respond with a JSON error, using the same status codes as errutil.HTTPError.
+func writeError(ctx context.Context, w http.ResponseWriter, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		writeJSON(w, http.StatusNotFound, Error{Error: "not found"})
+		return
+	}
+
+	ew := &errorWriter{h: make(http.Header)}
+	errutil.HTTPError(ctx, ew, err)
+	msg := string(ew.msg)
+	if len(msg) > 0 && msg[len(msg)-1] == '\n' {
+		msg = msg[:len(msg)-1]
+	}
+	writeJSON(w, ew.status, Error{Error: msg})
+}
+
+// withTx will run fn in a transaction, committing if it returns nil.
+func (h *Handler) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := h.c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer sqlutil.Rollback(ctx, "restapi", tx)
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// limitParam is the standard `limit` query parameter for list routes.
+var limitParam = queryParam{Name: "limit", Type: "integer", Desc: "Maximum number of results to return (1-150, default 50)."}
+
+// afterParam is the standard `after` query parameter for list routes.
+var afterParam = queryParam{Name: "after", Type: "string", Desc: "Cursor from the `next` field of a previous response."}
+
+// searchParam is the standard `search` query parameter for list routes.
+var searchParam = queryParam{Name: "search", Type: "string", Desc: "Filter results by name."}
+
+// listLimit returns the validated `limit` query parameter.
+func listLimit(r *http.Request) (int, error) {
+	v := r.URL.Query().Get("limit")
+	if v == "" {
+		return 50, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 || n > 150 {
+		return 0, validation.NewFieldError("limit", "must be between 1 and 150")
+	}
+
+	return n, nil
+}
+
+// List is a page of results.
+type List[T any] struct {
+	Items []T `json:"items"`
+
+	// Next is a cursor for the next page of results, if any.
+	Next string `json:"next,omitempty" doc:"Cursor for the next page of results, if any."`
+}
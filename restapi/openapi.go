@@ -0,0 +1,227 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/target/goalert/version"
+)
+
+var pathParamRx = regexp.MustCompile(`\{([a-zA-Z]+)\}`)
+
+// openAPI is the cached OpenAPI document, generated on first use.
+type openAPI struct {
+	once sync.Once
+	data []byte
+	err  error
+}
+
+// ServeOpenAPI serves the OpenAPI 3 document describing the REST API.
+func (h *Handler) ServeOpenAPI(w http.ResponseWriter, req *http.Request) {
+	h.spec.once.Do(func() {
+		h.spec.data, h.spec.err = json.MarshalIndent(h.OpenAPI(), "", "  ")
+	})
+	if h.spec.err != nil {
+		writeError(req.Context(), w, h.spec.err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(h.spec.data)
+}
+
+// OpenAPI returns the OpenAPI 3 document describing the REST API, generated from the registered routes.
+func (h *Handler) OpenAPI() map[string]any {
+	g := &schemaGen{schemas: make(map[string]any)}
+
+	paths := make(map[string]map[string]any)
+	for _, r := range h.routes {
+		path := BasePath + r.Path
+		if paths[path] == nil {
+			paths[path] = make(map[string]any)
+		}
+
+		var params []any
+		for _, m := range pathParamRx.FindAllStringSubmatch(r.Path, -1) {
+			params = append(params, map[string]any{
+				"name":     m[1],
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]any{"type": "string"},
+			})
+		}
+		for _, q := range r.Query {
+			params = append(params, map[string]any{
+				"name":        q.Name,
+				"in":          "query",
+				"description": q.Desc,
+				"schema":      map[string]any{"type": q.Type},
+			})
+		}
+
+		errResp := map[string]any{
+			"description": "Error",
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": g.schema(reflect.TypeFor[Error]())},
+			},
+		}
+		resp := map[string]any{"description": http.StatusText(r.Status)}
+		if r.Resp != reflect.TypeFor[empty]() {
+			resp["content"] = map[string]any{
+				"application/json": map[string]any{"schema": g.schema(r.Resp)},
+			}
+		}
+
+		op := map[string]any{
+			"operationId": operationID(r),
+			"summary":     r.Summary,
+			"tags":        []string{r.Tag},
+			"responses": map[string]any{
+				strconv.Itoa(r.Status): resp,
+				"default":              errResp,
+			},
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+		if r.Req != reflect.TypeFor[empty]() {
+			op["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": g.schema(r.Req)},
+				},
+			}
+		}
+
+		paths[path][strings.ToLower(r.Method)] = op
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "GoAlert REST API",
+			"version":     version.GitVersion(),
+			"description": "Requests are authenticated with an admin API key that has REST access enabled, sent as a bearer token. POST requests must use the application/json content type.",
+		},
+		"servers":  []any{map[string]any{"url": "/"}},
+		"security": []any{map[string]any{"bearerAuth": []string{}}},
+		"paths":    paths,
+		"components": map[string]any{
+			"schemas": g.schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+}
+
+// operationID returns a unique ID for the route, e.g., `getServicesId`.
+func operationID(r route) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(r.Method))
+	for _, part := range strings.FieldsFunc(r.Path, func(c rune) bool { return c == '/' || c == '-' || c == '{' || c == '}' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+
+	return b.String()
+}
+
+// schemaGen generates JSON schemas for Go types, collecting named structs as components.
+type schemaGen struct {
+	schemas map[string]any
+}
+
+// schemaName returns the component name of a struct type, e.g., `ServiceList` for List[Service].
+func schemaName(t reflect.Type) string {
+	name := t.Name()
+	if base, arg, ok := strings.Cut(name, "["); ok {
+		arg = strings.TrimSuffix(arg, "]")
+		arg = arg[strings.LastIndex(arg, ".")+1:]
+		return arg + base
+	}
+
+	return name
+}
+
+func (g *schemaGen) schema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == reflect.TypeFor[time.Time]():
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.String:
+		return map[string]any{"type": "string"}
+	case t.Kind() == reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return map[string]any{"type": "number"}
+	case t.Kind() == reflect.Slice:
+		return map[string]any{"type": "array", "items": g.schema(t.Elem())}
+	case t.Kind() == reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case t.Kind() == reflect.Struct:
+		name := schemaName(t)
+		if _, ok := g.schemas[name]; !ok {
+			g.schemas[name] = nil // placeholder for recursive types
+			g.schemas[name] = g.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+
+	panic("restapi: unsupported type in OpenAPI schema: " + t.String())
+}
+
+func (g *schemaGen) structSchema(t reflect.Type) map[string]any {
+	props := make(map[string]any)
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		s := g.schema(f.Type)
+		if doc := f.Tag.Get("doc"); doc != "" || f.Tag.Get("enum") != "" {
+			// sibling keywords are not allowed next to $ref in OpenAPI 3.0
+			if _, isRef := s["$ref"]; isRef {
+				s = map[string]any{"allOf": []any{s}}
+			}
+			if doc != "" {
+				s["description"] = doc
+			}
+			if enum := f.Tag.Get("enum"); enum != "" {
+				s["enum"] = strings.Split(enum, ",")
+			}
+		}
+		props[name] = s
+
+		if !strings.Contains(opts, "omitempty") && f.Type.Kind() != reflect.Pointer {
+			required = append(required, name)
+		}
+	}
+
+	s := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+
+	return s
+}
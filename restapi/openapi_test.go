@@ -0,0 +1,58 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_OpenAPI(t *testing.T) {
+	h := NewHandler(Config{})
+
+	mux := http.NewServeMux()
+	// should not conflict with the catch-all API routes
+	mux.Handle("GET /api/", http.NotFoundHandler())
+	mux.Handle("POST /api/", http.NotFoundHandler())
+	h.Register(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", BasePath+"/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var doc struct {
+		Paths      map[string]map[string]struct{ OperationID string }
+		Components struct{ Schemas map[string]any }
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+
+	ids := make(map[string]bool)
+	for _, r := range h.routes {
+		op, ok := doc.Paths[BasePath+r.Path][strings.ToLower(r.Method)]
+		require.Truef(t, ok, "missing %s %s", r.Method, r.Path)
+		assert.Falsef(t, ids[op.OperationID], "duplicate operationId %s", op.OperationID)
+		ids[op.OperationID] = true
+	}
+
+	assert.Contains(t, doc.Components.Schemas, "ServiceList")
+	assert.Contains(t, doc.Components.Schemas, "EscalationPolicyStep")
+	assert.Contains(t, doc.Components.Schemas, "Error")
+}
+
+func TestHandler_RequireJSON(t *testing.T) {
+	mux := http.NewServeMux()
+	NewHandler(Config{}).Register(mux)
+
+	req := httptest.NewRequest("POST", BasePath+"/services", strings.NewReader("name=foo"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "Content-Type must be application/json")
+}
@@ -0,0 +1,145 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/override"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/validation"
+)
+
+// Override is a temporary change to who is on call for a schedule.
+type Override struct {
+	ID           string    `json:"id"`
+	ScheduleID   string    `json:"scheduleId"`
+	AddUserID    string    `json:"addUserId,omitempty" doc:"User added to the schedule, if any."`
+	RemoveUserID string    `json:"removeUserId,omitempty" doc:"User removed from the schedule, if any."`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+}
+
+// OverrideInput is used to create an override. One or both of addUserId and removeUserId must be set.
+type OverrideInput struct {
+	AddUserID    string    `json:"addUserId,omitempty"`
+	RemoveUserID string    `json:"removeUserId,omitempty"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+}
+
+func newOverride(o override.UserOverride) Override {
+	return Override{
+		ID:           o.ID,
+		ScheduleID:   o.Target.TargetID(),
+		AddUserID:    o.AddUserID,
+		RemoveUserID: o.RemoveUserID,
+		Start:        o.Start,
+		End:          o.End,
+	}
+}
+
+func (h *Handler) overrideRoutes() {
+	addRoute(h, "GET", "/schedules/{id}/overrides", "Overrides", "List overrides for a schedule.", []queryParam{
+		{Name: "start", Type: "string", Desc: "Only include overrides ending after this time (RFC 3339)."},
+		{Name: "end", Type: "string", Desc: "Only include overrides starting before this time (RFC 3339)."},
+		limitParam, afterParam,
+	}, h.listOverrides)
+	addRoute(h, "POST", "/schedules/{id}/overrides", "Overrides", "Create an override for a schedule.", nil, h.createOverride)
+	addRoute(h, "DELETE", "/overrides/{id}", "Overrides", "Delete an override.", nil, h.deleteOverride)
+}
+
+// queryTime parses an optional RFC 3339 query parameter.
+func queryTime(r *http.Request, name string) (time.Time, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, validation.NewFieldError(name, "must be an RFC 3339 timestamp")
+	}
+
+	return t, nil
+}
+
+func (h *Handler) listOverrides(ctx context.Context, r *http.Request, _ *empty) (*List[Override], error) {
+	limit, err := listLimit(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts override.SearchOptions
+	opts.Start, err = queryTime(r, "start")
+	if err != nil {
+		return nil, err
+	}
+	opts.End, err = queryTime(r, "end")
+	if err != nil {
+		return nil, err
+	}
+	if after := r.URL.Query().Get("after"); after != "" {
+		err = search.ParseCursor(after, &opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	opts.ScheduleID = r.PathValue("id")
+	opts.Limit = limit + 1
+
+	overrides, err := h.c.OverrideStore.Search(ctx, h.c.DB, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var res List[Override]
+	if len(overrides) > limit {
+		overrides = overrides[:limit]
+		opts.After.ID = overrides[len(overrides)-1].ID
+		res.Next, err = search.Cursor(opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res.Items = make([]Override, 0, len(overrides))
+	for _, o := range overrides {
+		res.Items = append(res.Items, newOverride(o))
+	}
+
+	return &res, nil
+}
+
+func (h *Handler) createOverride(ctx context.Context, r *http.Request, in *OverrideInput) (*Override, error) {
+	var created *override.UserOverride
+	err := h.withTx(ctx, func(tx *sql.Tx) (err error) {
+		created, err = h.c.OverrideStore.CreateUserOverrideTx(ctx, tx, &override.UserOverride{
+			AddUserID:    in.AddUserID,
+			RemoveUserID: in.RemoveUserID,
+			Start:        in.Start,
+			End:          in.End,
+			Target:       assignment.ScheduleTarget(r.PathValue("id")),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newOverride(*created)
+	return &res, nil
+}
+
+func (h *Handler) deleteOverride(ctx context.Context, r *http.Request, _ *empty) (*empty, error) {
+	return nil, h.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := h.c.OverrideStore.FindOneUserOverrideTx(ctx, tx, r.PathValue("id"), true)
+		if err != nil {
+			return err
+		}
+
+		return h.c.OverrideStore.DeleteUserOverrideTx(ctx, tx, r.PathValue("id"))
+	})
+}
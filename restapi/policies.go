@@ -0,0 +1,274 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/escalation"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/validation"
+)
+
+// EscalationPolicy is an escalation policy, including its steps.
+type EscalationPolicy struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Repeat      int                    `json:"repeat" doc:"Number of times to repeat the policy after the last step."`
+	Steps       []EscalationPolicyStep `json:"steps,omitempty" doc:"Only included when fetching a single policy."`
+}
+
+// EscalationPolicyInput is used to create or update an escalation policy. Omitted fields are left unchanged on update.
+type EscalationPolicyInput struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Repeat      *int    `json:"repeat,omitempty"`
+}
+
+// EscalationPolicyStep is a single step of an escalation policy.
+type EscalationPolicyStep struct {
+	ID                string        `json:"id"`
+	StepNumber        int           `json:"stepNumber"`
+	DelayMinutes      int           `json:"delayMinutes"`
+	Mode              string        `json:"mode" enum:"all,round_robin,ack_count"`
+	RoundRobinMinutes int           `json:"roundRobinMinutes,omitempty"`
+	AckCount          int           `json:"ackCount,omitempty"`
+	Actions           []Destination `json:"actions"`
+}
+
+// EscalationPolicyStepInput is used to add a step to an escalation policy.
+type EscalationPolicyStepInput struct {
+	DelayMinutes      int           `json:"delayMinutes"`
+	Mode              string        `json:"mode,omitempty" enum:"all,round_robin,ack_count"`
+	RoundRobinMinutes int           `json:"roundRobinMinutes,omitempty"`
+	AckCount          int           `json:"ackCount,omitempty"`
+	Actions           []Destination `json:"actions"`
+}
+
+// Destination is the target of an escalation step, e.g., a user, rotation, schedule, or webhook.
+type Destination struct {
+	Type string            `json:"type" doc:"Destination type, e.g., builtin-user, builtin-rotation, builtin-schedule."`
+	Args map[string]string `json:"args"`
+}
+
+func newEscalationPolicy(p escalation.Policy) EscalationPolicy {
+	return EscalationPolicy{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Repeat:      p.Repeat,
+	}
+}
+
+func (in EscalationPolicyInput) apply(p *escalation.Policy) {
+	if in.Name != nil {
+		p.Name = *in.Name
+	}
+	if in.Description != nil {
+		p.Description = *in.Description
+	}
+	if in.Repeat != nil {
+		p.Repeat = *in.Repeat
+	}
+}
+
+func (h *Handler) policyRoutes() {
+	addRoute(h, "GET", "/escalation-policies", "Escalation Policies", "List escalation policies.", []queryParam{searchParam, limitParam, afterParam}, h.listPolicies)
+	addRoute(h, "POST", "/escalation-policies", "Escalation Policies", "Create an escalation policy.", nil, h.createPolicy)
+	addRoute(h, "GET", "/escalation-policies/{id}", "Escalation Policies", "Get an escalation policy, including its steps.", nil, h.getPolicy)
+	addRoute(h, "PATCH", "/escalation-policies/{id}", "Escalation Policies", "Update an escalation policy.", nil, h.updatePolicy)
+	addRoute(h, "DELETE", "/escalation-policies/{id}", "Escalation Policies", "Delete an escalation policy.", nil, h.deletePolicy)
+	addRoute(h, "POST", "/escalation-policies/{id}/steps", "Escalation Policies", "Add a step to the end of an escalation policy.", nil, h.createPolicyStep)
+	addRoute(h, "DELETE", "/escalation-policies/{id}/steps/{stepID}", "Escalation Policies", "Delete an escalation policy step.", nil, h.deletePolicyStep)
+}
+
+func (h *Handler) listPolicies(ctx context.Context, r *http.Request, _ *empty) (*List[EscalationPolicy], error) {
+	limit, err := listLimit(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts escalation.SearchOptions
+	opts.Search = r.URL.Query().Get("search")
+	if after := r.URL.Query().Get("after"); after != "" {
+		err = search.ParseCursor(after, &opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	opts.Limit = limit + 1
+
+	pols, err := h.c.PolicyStore.Search(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var res List[EscalationPolicy]
+	if len(pols) > limit {
+		pols = pols[:limit]
+		last := pols[len(pols)-1]
+		opts.After.Name = last.Name
+		opts.After.IsFavorite = last.IsUserFavorite()
+		res.Next, err = search.Cursor(opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res.Items = make([]EscalationPolicy, 0, len(pols))
+	for _, p := range pols {
+		res.Items = append(res.Items, newEscalationPolicy(p))
+	}
+
+	return &res, nil
+}
+
+func (h *Handler) getPolicy(ctx context.Context, r *http.Request, _ *empty) (*EscalationPolicy, error) {
+	p, err := h.c.PolicyStore.FindOnePolicyTx(ctx, nil, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := h.c.PolicyStore.FindAllSteps(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newEscalationPolicy(*p)
+	res.Steps = make([]EscalationPolicyStep, 0, len(steps))
+	for _, s := range steps {
+		step, err := h.newPolicyStep(ctx, h.c.DB, s)
+		if err != nil {
+			return nil, err
+		}
+		res.Steps = append(res.Steps, *step)
+	}
+
+	return &res, nil
+}
+
+func (h *Handler) newPolicyStep(ctx context.Context, db gadb.DBTX, s escalation.Step) (*EscalationPolicyStep, error) {
+	actions, err := h.c.PolicyStore.FindAllStepActionsTx(ctx, db, s.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	step := &EscalationPolicyStep{
+		ID:                s.ID.String(),
+		StepNumber:        s.StepNumber,
+		DelayMinutes:      s.DelayMinutes,
+		Mode:              string(s.Mode),
+		RoundRobinMinutes: s.RoundRobinMinutes,
+		AckCount:          s.AckCount,
+		Actions:           make([]Destination, 0, len(actions)),
+	}
+	for _, a := range actions {
+		step.Actions = append(step.Actions, Destination{Type: a.Type, Args: a.Args})
+	}
+
+	return step, nil
+}
+
+func (h *Handler) createPolicy(ctx context.Context, _ *http.Request, in *EscalationPolicyInput) (*EscalationPolicy, error) {
+	var p escalation.Policy
+	in.apply(&p)
+
+	var created *escalation.Policy
+	err := h.withTx(ctx, func(tx *sql.Tx) (err error) {
+		created, err = h.c.PolicyStore.CreatePolicyTx(ctx, tx, &p)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newEscalationPolicy(*created)
+	return &res, nil
+}
+
+func (h *Handler) updatePolicy(ctx context.Context, r *http.Request, in *EscalationPolicyInput) (*EscalationPolicy, error) {
+	var p *escalation.Policy
+	err := h.withTx(ctx, func(tx *sql.Tx) (err error) {
+		p, err = h.c.PolicyStore.FindOnePolicyForUpdateTx(ctx, tx, r.PathValue("id"))
+		if err != nil {
+			return err
+		}
+
+		in.apply(p)
+		return h.c.PolicyStore.UpdatePolicyTx(ctx, tx, p)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newEscalationPolicy(*p)
+	return &res, nil
+}
+
+func (h *Handler) deletePolicy(ctx context.Context, r *http.Request, _ *empty) (*empty, error) {
+	return nil, h.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := h.c.PolicyStore.FindOnePolicyForUpdateTx(ctx, tx, r.PathValue("id"))
+		if err != nil {
+			return err
+		}
+
+		return h.c.PolicyStore.DeleteManyPoliciesTx(ctx, tx, []string{r.PathValue("id")})
+	})
+}
+
+func (h *Handler) createPolicyStep(ctx context.Context, r *http.Request, in *EscalationPolicyStepInput) (*EscalationPolicyStep, error) {
+	var res *EscalationPolicyStep
+	err := h.withTx(ctx, func(tx *sql.Tx) error {
+		step, err := h.c.PolicyStore.CreateStepTx(ctx, tx, &escalation.Step{
+			PolicyID:     r.PathValue("id"),
+			DelayMinutes: in.DelayMinutes,
+			ModeConfig: escalation.ModeConfig{
+				Mode:              escalation.StepMode(in.Mode),
+				RoundRobinMinutes: in.RoundRobinMinutes,
+				AckCount:          in.AckCount,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, a := range in.Actions {
+			err = h.c.PolicyStore.AddStepActionTx(ctx, tx, step.ID, gadb.DestV1{Type: a.Type, Args: a.Args})
+			if err != nil {
+				return validation.AddPrefix("actions["+strconv.Itoa(i)+"].", err)
+			}
+		}
+
+		res, err = h.newPolicyStep(ctx, tx, *step)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (h *Handler) deletePolicyStep(ctx context.Context, r *http.Request, _ *empty) (*empty, error) {
+	stepID, err := uuid.Parse(r.PathValue("stepID"))
+	if err != nil {
+		return nil, validation.NewFieldError("stepID", "must be a valid UUID")
+	}
+
+	return nil, h.withTx(ctx, func(tx *sql.Tx) error {
+		step, err := h.c.PolicyStore.FindOneStepForUpdateTx(ctx, tx, stepID.String())
+		if err != nil {
+			return err
+		}
+		if step.PolicyID != r.PathValue("id") {
+			return sql.ErrNoRows
+		}
+
+		_, err = h.c.PolicyStore.DeleteStepTx(ctx, tx, stepID)
+		return err
+	})
+}
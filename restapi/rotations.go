@@ -0,0 +1,231 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/validation"
+)
+
+// Rotation is a rotation of users taking turns being on call.
+type Rotation struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Type        string    `json:"type" enum:"monthly,weekly,daily,hourly,follow_the_sun"`
+	ShiftLength int       `json:"shiftLength" doc:"Number of type units (e.g., hours or days) per shift."`
+	Start       time.Time `json:"start" doc:"Time of the first handoff."`
+	TimeZone    string    `json:"timeZone" doc:"IANA time zone name, e.g., America/Chicago."`
+	ActiveCount int       `json:"activeCount" doc:"Number of participants on call at the same time."`
+	UserIDs     []string  `json:"userIds,omitempty" doc:"Participants in rotation order, only included when fetching a single rotation."`
+}
+
+// RotationInput is used to create or update a rotation. Omitted fields are left unchanged on update.
+type RotationInput struct {
+	Name        *string    `json:"name,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Type        *string    `json:"type,omitempty" enum:"monthly,weekly,daily,hourly,follow_the_sun"`
+	ShiftLength *int       `json:"shiftLength,omitempty"`
+	Start       *time.Time `json:"start,omitempty"`
+	TimeZone    *string    `json:"timeZone,omitempty"`
+	ActiveCount *int       `json:"activeCount,omitempty"`
+	UserIDs     *[]string  `json:"userIds,omitempty" doc:"Replaces all participants, in rotation order."`
+}
+
+func newRotation(r rotation.Rotation) Rotation {
+	return Rotation{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Type:        string(r.Type),
+		ShiftLength: r.ShiftLength,
+		Start:       r.Start,
+		TimeZone:    r.Start.Location().String(),
+		ActiveCount: r.ActiveCount,
+	}
+}
+
+func (in RotationInput) apply(r *rotation.Rotation) error {
+	if in.Name != nil {
+		r.Name = *in.Name
+	}
+	if in.Description != nil {
+		r.Description = *in.Description
+	}
+	if in.Type != nil {
+		r.Type = rotation.Type(*in.Type)
+	}
+	if in.ActiveCount != nil {
+		r.ActiveCount = *in.ActiveCount
+	}
+	if in.ShiftLength != nil {
+		r.ShiftLength = *in.ShiftLength
+	}
+	if in.Start != nil {
+		r.Start = *in.Start
+	}
+	if in.TimeZone != nil {
+		loc, err := loadTimeZone(*in.TimeZone)
+		if err != nil {
+			return err
+		}
+		r.Start = r.Start.In(loc)
+	} else if in.Start != nil && r.ID != "" {
+		// keep the existing time zone when only the start time changes
+		r.Start = in.Start.In(r.Start.Location())
+	}
+
+	return nil
+}
+
+func (h *Handler) rotationRoutes() {
+	addRoute(h, "GET", "/rotations", "Rotations", "List rotations.", []queryParam{searchParam, limitParam, afterParam}, h.listRotations)
+	addRoute(h, "POST", "/rotations", "Rotations", "Create a rotation.", nil, h.createRotation)
+	addRoute(h, "GET", "/rotations/{id}", "Rotations", "Get a rotation, including its participants.", nil, h.getRotation)
+	addRoute(h, "PATCH", "/rotations/{id}", "Rotations", "Update a rotation.", nil, h.updateRotation)
+	addRoute(h, "DELETE", "/rotations/{id}", "Rotations", "Delete a rotation.", nil, h.deleteRotation)
+}
+
+func (h *Handler) listRotations(ctx context.Context, r *http.Request, _ *empty) (*List[Rotation], error) {
+	limit, err := listLimit(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts rotation.SearchOptions
+	opts.Search = r.URL.Query().Get("search")
+	if after := r.URL.Query().Get("after"); after != "" {
+		err = search.ParseCursor(after, &opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	opts.Limit = limit + 1
+
+	rots, err := h.c.RotationStore.Search(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var res List[Rotation]
+	if len(rots) > limit {
+		rots = rots[:limit]
+		last := rots[len(rots)-1]
+		opts.After.Name = last.Name
+		opts.After.IsFavorite = last.IsUserFavorite()
+		res.Next, err = search.Cursor(opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res.Items = make([]Rotation, 0, len(rots))
+	for _, r := range rots {
+		res.Items = append(res.Items, newRotation(r))
+	}
+
+	return &res, nil
+}
+
+func (h *Handler) getRotation(ctx context.Context, r *http.Request, _ *empty) (*Rotation, error) {
+	rot, err := h.c.RotationStore.FindRotation(ctx, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := h.c.RotationStore.FindAllParticipants(ctx, rot.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newRotation(*rot)
+	res.UserIDs = make([]string, 0, len(parts))
+	for _, p := range parts {
+		res.UserIDs = append(res.UserIDs, p.Target.TargetID())
+	}
+
+	return &res, nil
+}
+
+func (h *Handler) createRotation(ctx context.Context, _ *http.Request, in *RotationInput) (*Rotation, error) {
+	var rot rotation.Rotation
+	if in.TimeZone == nil {
+		// require the time zone on create rather than silently using the offset of `start`
+		return nil, validation.NewFieldError("timeZone", "is required")
+	}
+	err := in.apply(&rot)
+	if err != nil {
+		return nil, err
+	}
+
+	var created *rotation.Rotation
+	err = h.withTx(ctx, func(tx *sql.Tx) (err error) {
+		created, err = h.c.RotationStore.CreateRotationTx(ctx, tx, &rot)
+		if err != nil {
+			return err
+		}
+		if in.UserIDs == nil {
+			return nil
+		}
+
+		return h.c.RotationStore.AddRotationUsersTx(ctx, tx, created.ID, *in.UserIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newRotation(*created)
+	if in.UserIDs != nil {
+		res.UserIDs = *in.UserIDs
+	}
+	return &res, nil
+}
+
+func (h *Handler) updateRotation(ctx context.Context, r *http.Request, in *RotationInput) (*Rotation, error) {
+	var rot *rotation.Rotation
+	err := h.withTx(ctx, func(tx *sql.Tx) (err error) {
+		rot, err = h.c.RotationStore.FindRotationForUpdateTx(ctx, tx, r.PathValue("id"))
+		if err != nil {
+			return err
+		}
+
+		err = in.apply(rot)
+		if err != nil {
+			return err
+		}
+
+		err = h.c.RotationStore.UpdateRotationTx(ctx, tx, rot)
+		if err != nil {
+			return err
+		}
+		if in.UserIDs == nil {
+			return nil
+		}
+
+		return h.c.RotationStore.SetParticipantsTx(ctx, tx, rot.ID, *in.UserIDs, true)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newRotation(*rot)
+	if in.UserIDs != nil {
+		res.UserIDs = *in.UserIDs
+	}
+	return &res, nil
+}
+
+func (h *Handler) deleteRotation(ctx context.Context, r *http.Request, _ *empty) (*empty, error) {
+	return nil, h.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := h.c.RotationStore.FindRotationForUpdateTx(ctx, tx, r.PathValue("id"))
+		if err != nil {
+			return err
+		}
+
+		return h.c.RotationStore.DeleteManyTx(ctx, tx, []string{r.PathValue("id")})
+	})
+}
@@ -0,0 +1,178 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+)
+
+// Schedule is an on-call schedule.
+type Schedule struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	TimeZone    string `json:"timeZone" doc:"IANA time zone name, e.g., America/Chicago."`
+}
+
+// ScheduleInput is used to create or update a schedule. Omitted fields are left unchanged on update.
+type ScheduleInput struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	TimeZone    *string `json:"timeZone,omitempty"`
+}
+
+func newSchedule(s schedule.Schedule) Schedule {
+	return Schedule{
+		ID:          s.ID,
+		Name:        s.Name,
+		Description: s.Description,
+		TimeZone:    s.TimeZone.String(),
+	}
+}
+
+func (in ScheduleInput) apply(s *schedule.Schedule) error {
+	if in.Name != nil {
+		s.Name = *in.Name
+	}
+	if in.Description != nil {
+		s.Description = *in.Description
+	}
+	if in.TimeZone != nil {
+		loc, err := loadTimeZone(*in.TimeZone)
+		if err != nil {
+			return err
+		}
+		s.TimeZone = loc
+	}
+
+	return nil
+}
+
+// loadTimeZone loads the named location, returning a field error for the timeZone field if invalid.
+func loadTimeZone(name string) (*time.Location, error) {
+	loc, err := util.LoadLocation(name)
+	if err != nil {
+		return nil, validation.NewFieldError("timeZone", err.Error())
+	}
+
+	return loc, nil
+}
+
+func (h *Handler) scheduleRoutes() {
+	addRoute(h, "GET", "/schedules", "Schedules", "List schedules.", []queryParam{searchParam, limitParam, afterParam}, h.listSchedules)
+	addRoute(h, "POST", "/schedules", "Schedules", "Create a schedule.", nil, h.createSchedule)
+	addRoute(h, "GET", "/schedules/{id}", "Schedules", "Get a schedule.", nil, h.getSchedule)
+	addRoute(h, "PATCH", "/schedules/{id}", "Schedules", "Update a schedule.", nil, h.updateSchedule)
+	addRoute(h, "DELETE", "/schedules/{id}", "Schedules", "Delete a schedule.", nil, h.deleteSchedule)
+}
+
+func (h *Handler) listSchedules(ctx context.Context, r *http.Request, _ *empty) (*List[Schedule], error) {
+	limit, err := listLimit(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts schedule.SearchOptions
+	opts.Search = r.URL.Query().Get("search")
+	if after := r.URL.Query().Get("after"); after != "" {
+		err = search.ParseCursor(after, &opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	opts.Limit = limit + 1
+
+	scheds, err := h.c.ScheduleStore.Search(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var res List[Schedule]
+	if len(scheds) > limit {
+		scheds = scheds[:limit]
+		last := scheds[len(scheds)-1]
+		opts.After.Name = last.Name
+		opts.After.IsFavorite = last.IsUserFavorite()
+		res.Next, err = search.Cursor(opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res.Items = make([]Schedule, 0, len(scheds))
+	for _, s := range scheds {
+		res.Items = append(res.Items, newSchedule(s))
+	}
+
+	return &res, nil
+}
+
+func (h *Handler) getSchedule(ctx context.Context, r *http.Request, _ *empty) (*Schedule, error) {
+	s, err := h.c.ScheduleStore.FindOne(ctx, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	res := newSchedule(*s)
+	return &res, nil
+}
+
+func (h *Handler) createSchedule(ctx context.Context, _ *http.Request, in *ScheduleInput) (*Schedule, error) {
+	var s schedule.Schedule
+	err := in.apply(&s)
+	if err != nil {
+		return nil, err
+	}
+
+	var created *schedule.Schedule
+	err = h.withTx(ctx, func(tx *sql.Tx) (err error) {
+		created, err = h.c.ScheduleStore.CreateScheduleTx(ctx, tx, &s)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newSchedule(*created)
+	return &res, nil
+}
+
+func (h *Handler) updateSchedule(ctx context.Context, r *http.Request, in *ScheduleInput) (*Schedule, error) {
+	var s *schedule.Schedule
+	err := h.withTx(ctx, func(tx *sql.Tx) (err error) {
+		s, err = h.c.ScheduleStore.FindOneForUpdate(ctx, tx, r.PathValue("id"))
+		if err != nil {
+			return err
+		}
+
+		err = in.apply(s)
+		if err != nil {
+			return err
+		}
+
+		return h.c.ScheduleStore.UpdateTx(ctx, tx, s)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newSchedule(*s)
+	return &res, nil
+}
+
+func (h *Handler) deleteSchedule(ctx context.Context, r *http.Request, _ *empty) (*empty, error) {
+	return nil, h.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := h.c.ScheduleStore.FindOneForUpdate(ctx, tx, r.PathValue("id"))
+		if err != nil {
+			return err
+		}
+
+		return h.c.ScheduleStore.DeleteManyTx(ctx, tx, []string{r.PathValue("id")})
+	})
+}
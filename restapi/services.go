@@ -0,0 +1,181 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/service"
+)
+
+// Service is a service that alerts are created for.
+type Service struct {
+	ID                   string     `json:"id" doc:"Unique identifier of the service."`
+	Name                 string     `json:"name"`
+	Description          string     `json:"description"`
+	EscalationPolicyID   string     `json:"escalationPolicyId"`
+	MaintenanceExpiresAt *time.Time `json:"maintenanceExpiresAt,omitempty" doc:"Set while the service is in maintenance mode."`
+	ReopenWindowMinutes  int        `json:"reopenWindowMinutes" doc:"Minutes after close that a duplicate alert re-opens the original."`
+	FlapThreshold        int        `json:"flapThreshold" doc:"Number of open/close cycles within the flap window before an alert is considered flapping."`
+	FlapWindowMinutes    int        `json:"flapWindowMinutes"`
+}
+
+// ServiceInput is used to create or update a service. Omitted fields are left unchanged on update.
+type ServiceInput struct {
+	Name                 *string    `json:"name,omitempty"`
+	Description          *string    `json:"description,omitempty"`
+	EscalationPolicyID   *string    `json:"escalationPolicyId,omitempty"`
+	MaintenanceExpiresAt *time.Time `json:"maintenanceExpiresAt,omitempty" doc:"Update only; a time in the past ends maintenance mode."`
+	ReopenWindowMinutes  *int       `json:"reopenWindowMinutes,omitempty"`
+	FlapThreshold        *int       `json:"flapThreshold,omitempty"`
+	FlapWindowMinutes    *int       `json:"flapWindowMinutes,omitempty"`
+}
+
+func newService(s service.Service) Service {
+	svc := Service{
+		ID:                  s.ID,
+		Name:                s.Name,
+		Description:         s.Description,
+		EscalationPolicyID:  s.EscalationPolicyID,
+		ReopenWindowMinutes: int(s.ReopenWindow / time.Minute),
+		FlapThreshold:       s.FlapThreshold,
+		FlapWindowMinutes:   int(s.FlapWindow / time.Minute),
+	}
+	if s.MaintenanceExpiresAt.After(time.Now()) {
+		svc.MaintenanceExpiresAt = &s.MaintenanceExpiresAt
+	}
+	return svc
+}
+
+func (in ServiceInput) apply(s *service.Service) {
+	if in.Name != nil {
+		s.Name = *in.Name
+	}
+	if in.Description != nil {
+		s.Description = *in.Description
+	}
+	if in.EscalationPolicyID != nil {
+		s.EscalationPolicyID = *in.EscalationPolicyID
+	}
+	if in.MaintenanceExpiresAt != nil {
+		s.MaintenanceExpiresAt = *in.MaintenanceExpiresAt
+	}
+	if in.ReopenWindowMinutes != nil {
+		s.ReopenWindow = time.Duration(*in.ReopenWindowMinutes) * time.Minute
+	}
+	if in.FlapThreshold != nil {
+		s.FlapThreshold = *in.FlapThreshold
+	}
+	if in.FlapWindowMinutes != nil {
+		s.FlapWindow = time.Duration(*in.FlapWindowMinutes) * time.Minute
+	}
+}
+
+func (h *Handler) serviceRoutes() {
+	addRoute(h, "GET", "/services", "Services", "List services.", []queryParam{searchParam, limitParam, afterParam}, h.listServices)
+	addRoute(h, "POST", "/services", "Services", "Create a service.", nil, h.createService)
+	addRoute(h, "GET", "/services/{id}", "Services", "Get a service.", nil, h.getService)
+	addRoute(h, "PATCH", "/services/{id}", "Services", "Update a service.", nil, h.updateService)
+	addRoute(h, "DELETE", "/services/{id}", "Services", "Delete a service.", nil, h.deleteService)
+}
+
+func (h *Handler) listServices(ctx context.Context, r *http.Request, _ *empty) (*List[Service], error) {
+	limit, err := listLimit(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts service.SearchOptions
+	opts.Search = r.URL.Query().Get("search")
+	if after := r.URL.Query().Get("after"); after != "" {
+		err = search.ParseCursor(after, &opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	opts.Limit = limit + 1
+
+	svcs, err := h.c.ServiceStore.Search(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var res List[Service]
+	if len(svcs) > limit {
+		svcs = svcs[:limit]
+		last := svcs[len(svcs)-1]
+		opts.After.Name = last.Name
+		opts.After.IsFavorite = last.IsUserFavorite()
+		res.Next, err = search.Cursor(opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res.Items = make([]Service, 0, len(svcs))
+	for _, s := range svcs {
+		res.Items = append(res.Items, newService(s))
+	}
+
+	return &res, nil
+}
+
+func (h *Handler) getService(ctx context.Context, r *http.Request, _ *empty) (*Service, error) {
+	s, err := h.c.ServiceStore.FindOne(ctx, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	res := newService(*s)
+	return &res, nil
+}
+
+func (h *Handler) createService(ctx context.Context, _ *http.Request, in *ServiceInput) (*Service, error) {
+	var s service.Service
+	in.apply(&s)
+	s.MaintenanceExpiresAt = time.Time{}
+
+	var created *service.Service
+	err := h.withTx(ctx, func(tx *sql.Tx) (err error) {
+		created, err = h.c.ServiceStore.CreateServiceTx(ctx, tx, &s)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newService(*created)
+	return &res, nil
+}
+
+func (h *Handler) updateService(ctx context.Context, r *http.Request, in *ServiceInput) (*Service, error) {
+	var s *service.Service
+	err := h.withTx(ctx, func(tx *sql.Tx) (err error) {
+		s, err = h.c.ServiceStore.FindOneForUpdate(ctx, tx, r.PathValue("id"))
+		if err != nil {
+			return err
+		}
+
+		in.apply(s)
+		return h.c.ServiceStore.UpdateTx(ctx, tx, s)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := newService(*s)
+	return &res, nil
+}
+
+func (h *Handler) deleteService(ctx context.Context, r *http.Request, _ *empty) (*empty, error) {
+	return nil, h.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := h.c.ServiceStore.FindOneForUpdate(ctx, tx, r.PathValue("id"))
+		if err != nil {
+			return err
+		}
+
+		return h.c.ServiceStore.DeleteManyTx(ctx, tx, []string{r.PathValue("id")})
+	})
+}
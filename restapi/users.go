@@ -0,0 +1,82 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/user"
+)
+
+// User is a GoAlert user.
+type User struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Role  string `json:"role" enum:"admin,user,unknown"`
+}
+
+func newUser(u user.User) User {
+	return User{
+		ID:    u.ID,
+		Name:  u.Name,
+		Email: u.Email,
+		Role:  string(u.Role),
+	}
+}
+
+func (h *Handler) userRoutes() {
+	addRoute(h, "GET", "/users", "Users", "List users.", []queryParam{searchParam, limitParam, afterParam}, h.listUsers)
+	addRoute(h, "GET", "/users/{id}", "Users", "Get a user.", nil, h.getUser)
+}
+
+func (h *Handler) listUsers(ctx context.Context, r *http.Request, _ *empty) (*List[User], error) {
+	limit, err := listLimit(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts user.SearchOptions
+	opts.Search = r.URL.Query().Get("search")
+	if after := r.URL.Query().Get("after"); after != "" {
+		err = search.ParseCursor(after, &opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	opts.Limit = limit + 1
+
+	users, err := h.c.UserStore.Search(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var res List[User]
+	if len(users) > limit {
+		users = users[:limit]
+		last := users[len(users)-1]
+		opts.After.Name = last.Name
+		opts.After.IsFavorite = last.IsUserFavorite()
+		res.Next, err = search.Cursor(opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res.Items = make([]User, 0, len(users))
+	for _, u := range users {
+		res.Items = append(res.Items, newUser(u))
+	}
+
+	return &res, nil
+}
+
+func (h *Handler) getUser(ctx context.Context, r *http.Request, _ *empty) (*User, error) {
+	u, err := h.c.UserStore.FindOne(ctx, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	res := newUser(*u)
+	return &res, nil
+}
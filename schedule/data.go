@@ -9,8 +9,9 @@ import (
 // Data contains configuration for a single schedule.
 type Data struct {
 	V1 struct {
-		TemporarySchedules      []TemporarySchedule
-		OnCallNotificationRules []OnCallNotificationRule
+		TemporarySchedules         []TemporarySchedule
+		OnCallNotificationRules    []OnCallNotificationRule
+		TemporaryScheduleTemplates []TemporaryScheduleTemplate
 	}
 }
 
@@ -0,0 +1,65 @@
+package rotation
+
+// ActivePositions returns the positions of all participants that are on call when the rotation is at pos.
+//
+// Starting at pos, the next count non-shadow participants are on call, along with any shadow participants
+// that directly follow one of them. Shadow participants are on call alongside the participant they
+// follow, but never take a shift of their own.
+func ActivePositions(pos, count int, shadow []bool) []int {
+	n := len(shadow)
+	if n == 0 || pos < 0 || pos >= n {
+		return nil
+	}
+	if count < 1 {
+		count = 1
+	}
+
+	var result []int
+	var primaries int
+	for i := 0; i < n; i++ {
+		idx := (pos + i) % n
+		if !shadow[idx] {
+			if primaries == count {
+				break
+			}
+			primaries++
+		}
+		result = append(result, idx)
+	}
+
+	return result
+}
+
+// NextPosition returns the position of the next non-shadow participant after pos. If all participants
+// are shadows, the next position is returned.
+func NextPosition(pos int, shadow []bool) int {
+	n := len(shadow)
+	if n == 0 {
+		return 0
+	}
+	for i := 1; i <= n; i++ {
+		idx := (pos + i) % n
+		if !shadow[idx] {
+			return idx
+		}
+	}
+
+	return (pos + 1) % n
+}
+
+// PrevPosition returns the position of the previous non-shadow participant before pos. If all
+// participants are shadows, the previous position is returned.
+func PrevPosition(pos int, shadow []bool) int {
+	n := len(shadow)
+	if n == 0 {
+		return 0
+	}
+	for i := 1; i <= n; i++ {
+		idx := ((pos-i)%n + n) % n
+		if !shadow[idx] {
+			return idx
+		}
+	}
+
+	return ((pos-1)%n + n) % n
+}
@@ -0,0 +1,36 @@
+package rotation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActivePositions(t *testing.T) {
+	check := func(desc string, pos, count int, shadow []bool, exp []int) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			assert.Equal(t, exp, ActivePositions(pos, count, shadow))
+		})
+	}
+
+	check("single", 1, 1, []bool{false, false, false}, []int{1})
+	check("pair", 1, 2, []bool{false, false, false}, []int{1, 2})
+	check("pair wrap", 2, 2, []bool{false, false, false}, []int{2, 0})
+	check("count exceeds participants", 0, 5, []bool{false, false}, []int{0, 1})
+	check("shadow follows", 0, 1, []bool{false, true, false}, []int{0, 1})
+	check("shadow not active", 2, 1, []bool{false, true, false}, []int{2})
+	check("pair with shadow", 0, 2, []bool{false, true, false, false}, []int{0, 1, 2})
+	check("out of range", 3, 1, []bool{false}, []int(nil))
+}
+
+func TestNextPosition(t *testing.T) {
+	shadow := []bool{false, true, true, false}
+	assert.Equal(t, 3, NextPosition(0, shadow))
+	assert.Equal(t, 0, NextPosition(3, shadow))
+	assert.Equal(t, 0, PrevPosition(3, shadow))
+	assert.Equal(t, 3, PrevPosition(0, shadow))
+
+	assert.Equal(t, 1, NextPosition(0, []bool{true, true}), "all shadows")
+	assert.Equal(t, 1, PrevPosition(0, []bool{true, true}), "all shadows")
+}
@@ -0,0 +1,142 @@
+package rotation
+
+import (
+	"sort"
+	"time"
+
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// A ParticipantWindow is the local time-of-day a participant covers in a follow-the-sun rotation,
+// e.g., 09:00 to 17:00 in Australia/Sydney. If End is before Start, the window spans midnight.
+type ParticipantWindow struct {
+	Start    timeutil.Clock
+	End      timeutil.Clock
+	TimeZone *time.Location
+}
+
+// Normalize will validate the window and return a normalized copy.
+func (w ParticipantWindow) Normalize() (*ParticipantWindow, error) {
+	if w.TimeZone == nil {
+		return nil, validation.NewFieldError("TimeZone", "must be specified")
+	}
+	err := validate.Many(
+		validate.Range("Start", int(time.Duration(w.Start)/time.Minute), 0, 24*60-1),
+		validate.Range("End", int(time.Duration(w.End)/time.Minute), 0, 24*60-1),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if w.Start == w.End {
+		return nil, validation.NewFieldError("End", "must be different from Start")
+	}
+
+	return &w, nil
+}
+
+// contains returns true if t falls within the window.
+func (w ParticipantWindow) contains(t time.Time) bool {
+	c := timeutil.NewClockFromTime(t.In(w.TimeZone))
+	if w.Start < w.End {
+		return c >= w.Start && c < w.End
+	}
+
+	return c >= w.Start || c < w.End
+}
+
+// at returns the instant the clock value occurs on the local date of t, offset by days.
+func (w ParticipantWindow) at(t time.Time, c timeutil.Clock, days int) time.Time {
+	t = t.In(w.TimeZone)
+	return time.Date(t.Year(), t.Month(), t.Day()+days, c.Hour(), c.Minute(), 0, 0, w.TimeZone)
+}
+
+// lastEnd returns the most recent time the window ended, at or before t.
+func (w ParticipantWindow) lastEnd(t time.Time) time.Time {
+	end := w.at(t, w.End, 0)
+	if end.After(t) {
+		end = w.at(t, w.End, -1)
+	}
+
+	return end
+}
+
+// ActiveIndex returns the position of the participant that is on call at t for a follow-the-sun
+// rotation, or -1 if no participant has a window.
+//
+// The first participant (by position) whose window contains t is on call. If no window contains t,
+// the participant whose window ended most recently remains on call until the next window starts.
+func (r Rotation) ActiveIndex(t time.Time) int {
+	for i, w := range r.Windows {
+		if w != nil && w.contains(t) {
+			return i
+		}
+	}
+
+	idx := -1
+	var latest time.Time
+	for i, w := range r.Windows {
+		if w == nil {
+			continue
+		}
+		end := w.lastEnd(t)
+		if idx == -1 || end.After(latest) {
+			idx, latest = i, end
+		}
+	}
+
+	return idx
+}
+
+// windowBoundaries returns the sorted start and end times of all windows on the days surrounding t.
+func (r Rotation) windowBoundaries(t time.Time) []time.Time {
+	var result []time.Time
+	for _, w := range r.Windows {
+		if w == nil {
+			continue
+		}
+		for d := -2; d <= 2; d++ {
+			result = append(result, w.at(t, w.Start, d), w.at(t, w.End, d))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+
+	return result
+}
+
+// followTheSunStartTime returns the most recent handoff at or before t.
+func (r Rotation) followTheSunStartTime(t time.Time) time.Time {
+	t = t.Truncate(time.Minute)
+	cur := r.ActiveIndex(t)
+	b := r.windowBoundaries(t)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i].After(t) {
+			continue
+		}
+		if r.ActiveIndex(b[i].Add(-time.Minute)) != cur {
+			return b[i].In(r.Start.Location())
+		}
+	}
+
+	// single participant or no windows, so there is no handoff
+	return t.Add(-24 * time.Hour).In(r.Start.Location())
+}
+
+// followTheSunEndTime returns the next handoff after t.
+func (r Rotation) followTheSunEndTime(t time.Time) time.Time {
+	t = t.Truncate(time.Minute)
+	cur := r.ActiveIndex(t)
+	b := r.windowBoundaries(t)
+	for _, bt := range b {
+		if !bt.After(t) {
+			continue
+		}
+		if r.ActiveIndex(bt) != cur {
+			return bt.In(r.Start.Location())
+		}
+	}
+
+	// single participant or no windows, so there is no handoff
+	return t.Add(24 * time.Hour).In(r.Start.Location())
+}
@@ -0,0 +1,73 @@
+package rotation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/util/timeutil"
+)
+
+func TestRotation_FollowTheSun(t *testing.T) {
+	syd, err := time.LoadLocation("Australia/Sydney")
+	require.NoError(t, err)
+	lon, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+
+	nineToFive := func(loc *time.Location) *ParticipantWindow {
+		return &ParticipantWindow{
+			Start:    timeutil.NewClock(9, 0),
+			End:      timeutil.NewClock(17, 0),
+			TimeZone: loc,
+		}
+	}
+
+	rot := Rotation{
+		Type:    TypeFollowTheSun,
+		Start:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Windows: []*ParticipantWindow{nineToFive(syd), nineToFive(lon)},
+	}
+
+	// Sydney is UTC+11 and London is UTC+0 in January.
+	check := func(desc string, at time.Time, expIdx int, expStart, expEnd time.Time) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			assert.Equal(t, expIdx, rot.ActiveIndex(at), "active index")
+			assert.Equal(t, expStart.UTC(), rot.StartTime(at).UTC(), "start time")
+			assert.Equal(t, expEnd.UTC(), rot.EndTime(at).UTC(), "end time")
+		})
+	}
+
+	utc := func(d, h int) time.Time { return time.Date(2024, 1, d, h, 0, 0, 0, time.UTC) }
+
+	check("sydney window", utc(10, 0), 0, utc(9, 22), utc(10, 9))
+	check("london window", utc(10, 12), 1, utc(10, 9), utc(10, 22))
+	check("london after hours", utc(10, 19), 1, utc(10, 9), utc(10, 22))
+	check("sydney next day", utc(10, 23), 0, utc(10, 22), utc(11, 9))
+
+	t.Run("single participant", func(t *testing.T) {
+		rot := rot
+		rot.Windows = []*ParticipantWindow{nineToFive(lon)}
+		at := utc(10, 12)
+		assert.Equal(t, 0, rot.ActiveIndex(at))
+		assert.Equal(t, at.Add(24*time.Hour), rot.EndTime(at).UTC())
+	})
+
+	t.Run("no windows", func(t *testing.T) {
+		rot := rot
+		rot.Windows = []*ParticipantWindow{nil, nil}
+		assert.Equal(t, -1, rot.ActiveIndex(utc(10, 12)))
+	})
+}
+
+func TestParticipantWindow_Normalize(t *testing.T) {
+	_, err := ParticipantWindow{Start: timeutil.NewClock(9, 0), End: timeutil.NewClock(17, 0)}.Normalize()
+	assert.Error(t, err, "missing time zone")
+
+	_, err = ParticipantWindow{Start: timeutil.NewClock(9, 0), End: timeutil.NewClock(9, 0), TimeZone: time.UTC}.Normalize()
+	assert.Error(t, err, "empty window")
+
+	_, err = ParticipantWindow{Start: timeutil.NewClock(22, 0), End: timeutil.NewClock(6, 0), TimeZone: time.UTC}.Normalize()
+	assert.NoError(t, err, "overnight window")
+}
@@ -10,6 +10,39 @@ type Participant struct {
 	Position   int    `json:"position"`
 	RotationID string `json:"rotation_id"`
 	Target     assignment.Target
+
+	// Window is the time-of-day the participant covers in a follow-the-sun rotation, if set.
+	Window *ParticipantWindow
+
+	// Shadow indicates the participant is on call alongside the participant before them, receiving
+	// notifications without counting toward acknowledgement.
+	Shadow bool
+}
+
+// ParticipantShadows returns the shadow flag of each participant, by position.
+func ParticipantShadows(parts []Participant) []bool {
+	result := make([]bool, len(parts))
+	for _, p := range parts {
+		if p.Position < 0 || p.Position >= len(result) {
+			continue
+		}
+		result[p.Position] = p.Shadow
+	}
+
+	return result
+}
+
+// ParticipantWindows returns the window of each participant, by position.
+func ParticipantWindows(parts []Participant) []*ParticipantWindow {
+	result := make([]*ParticipantWindow, len(parts))
+	for _, p := range parts {
+		if p.Position < 0 || p.Position >= len(result) {
+			continue
+		}
+		result[p.Position] = p.Window
+	}
+
+	return result
 }
 
 func (p Participant) Normalize() (*Participant, error) {
@@ -17,6 +17,14 @@ type Rotation struct {
 	Start          time.Time `json:"start"`
 	ShiftLength    int       `json:"shift_length"`
 	isUserFavorite bool
+
+	// ActiveCount is the number of (non-shadow) participants that are on call at the same time. They
+	// advance together, one position per shift.
+	ActiveCount int `json:"active_count"`
+
+	// Windows are the participant windows, by position, for follow-the-sun rotations. They are
+	// stored with each participant, and must be loaded separately.
+	Windows []*ParticipantWindow `json:"-"`
 }
 
 func (r Rotation) IsUserFavorite() bool {
@@ -32,7 +40,7 @@ func (r Rotation) shiftClock() timeutil.Clock {
 	case TypeWeekly:
 		return timeutil.NewClock(r.ShiftLength*24*7, 0)
 	default:
-		// monthly is handled separately
+		// monthly and follow-the-sun are handled separately
 		panic("unexpected rotation type")
 	}
 }
@@ -102,6 +110,9 @@ func (r Rotation) StartTime(t time.Time) time.Time {
 	if r.Type == TypeMonthly {
 		return r.monthStartTime(t, 1)
 	}
+	if r.Type == TypeFollowTheSun {
+		return r.followTheSunStartTime(t)
+	}
 
 	shiftClockLen := r.shiftClock()
 	rem := timeutil.ClockDiff(r.Start, t) % shiftClockLen
@@ -127,6 +138,9 @@ func (r Rotation) EndTime(t time.Time) time.Time {
 	if r.Type == TypeMonthly {
 		return r.monthEndTime(t, 1)
 	}
+	if r.Type == TypeFollowTheSun {
+		return r.followTheSunEndTime(t)
+	}
 
 	shiftClockLen := r.shiftClock()
 	rem := timeutil.ClockDiff(r.Start, t) % shiftClockLen
@@ -143,6 +157,9 @@ func (r Rotation) Normalize() (*Rotation, error) {
 		// default to 1
 		r.ShiftLength = 1
 	}
+	if r.ActiveCount == 0 {
+		r.ActiveCount = 1
+	}
 	r.Start = r.Start.Truncate(time.Minute)
 
 	if r.Start.Location() == nil {
@@ -151,7 +168,8 @@ func (r Rotation) Normalize() (*Rotation, error) {
 	err := validate.Many(
 		validate.IDName("Name", r.Name),
 		validate.Range("ShiftLength", r.ShiftLength, 1, 9000),
-		validate.OneOf("Type", r.Type, TypeMonthly, TypeWeekly, TypeDaily, TypeHourly),
+		validate.Range("ActiveCount", r.ActiveCount, 1, 10),
+		validate.OneOf("Type", r.Type, TypeMonthly, TypeWeekly, TypeDaily, TypeHourly, TypeFollowTheSun),
 		validate.Text("Description", r.Description, 1, 255),
 	)
 	if err != nil {
@@ -49,6 +49,7 @@ var searchTemplate = template.Must(template.New("search").Funcs(search.Helpers()
 		rot.start_time, 
 		rot.shift_length, 
 		rot.time_zone, 
+		rot.active_count,
 		fav IS DISTINCT FROM NULL
 	FROM rotations rot
 	{{if not .FavoritesOnly }}LEFT {{end}}JOIN user_favorites fav ON rot.id = fav.tgt_rotation_id AND {{if .FavoritesUserID}}fav.user_id = :favUserID{{else}}false{{end}}
@@ -153,7 +154,7 @@ func (s *Store) Search(ctx context.Context, opts *SearchOptions) ([]Rotation, er
 	var r Rotation
 	var tz string
 	for rows.Next() {
-		err = rows.Scan(&r.ID, &r.Name, &r.Description, &r.Type, &r.Start, &r.ShiftLength, &tz, &r.isUserFavorite)
+		err = rows.Scan(&r.ID, &r.Name, &r.Description, &r.Type, &r.Start, &r.ShiftLength, &tz, &r.ActiveCount, &r.isUserFavorite)
 		if err != nil {
 			return nil, err
 		}
@@ -11,6 +11,7 @@ import (
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/util/timeutil"
 	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
@@ -31,6 +32,8 @@ type Store struct {
 	findAllParticipants *sql.Stmt
 	addParticipant      *sql.Stmt
 	findParticipant     *sql.Stmt
+	setWindow           *sql.Stmt
+	setShadow           *sql.Stmt
 
 	state    *sql.Stmt
 	rmState  *sql.Stmt
@@ -51,14 +54,14 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 
 		lockPart: p.P(`lock rotation_participants, rotation_state in exclusive mode`),
 
-		createRotation: p.P(`INSERT INTO rotations (id, name, description, type, start_time, shift_length, time_zone) VALUES ($1, $2, $3, $4, $5, $6, $7)`),
+		createRotation: p.P(`INSERT INTO rotations (id, name, description, type, start_time, shift_length, time_zone, active_count) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`),
 		updateRotation: p.P(`
 			WITH set_shift_start AS (
 				UPDATE rotation_state
 				SET shift_start = now()
 				WHERE rotation_id = $1
 			)
-			UPDATE rotations SET name = $2, description = $3, type = $4, start_time = $5, shift_length = $6, time_zone = $7 WHERE id = $1
+			UPDATE rotations SET name = $2, description = $3, type = $4, start_time = $5, shift_length = $6, time_zone = $7, active_count = $8 WHERE id = $1
 		`),
 		findRotation: p.P(`
 			SELECT 
@@ -69,13 +72,14 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				r.start_time, 
 				r.shift_length, 
 				r.time_zone, 
+				r.active_count,
 				fav IS DISTINCT FROM NULL 
 			FROM rotations r 
 			LEFT JOIN user_favorites fav ON fav.tgt_rotation_id = r.id 
 			AND fav.user_id = $2 
 			WHERE r.id = $1
 		`),
-		findRotationForUpdate: p.P(`SELECT id, name, description, type, start_time, shift_length, time_zone FROM rotations WHERE id = $1 FOR UPDATE`),
+		findRotationForUpdate: p.P(`SELECT id, name, description, type, start_time, shift_length, time_zone, active_count FROM rotations WHERE id = $1 FOR UPDATE`),
 		deleteRotation:        p.P(`DELETE FROM rotations WHERE id = ANY($1)`),
 
 		findMany: p.P(`
@@ -87,6 +91,7 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				r.start_time, 
 				r.shift_length, 
 				r.time_zone,
+				r.active_count,
 				fav IS DISTINCT FROM NULL 
 			FROM rotations r 
 			LEFT JOIN user_favorites fav ON fav.tgt_rotation_id = r.id 
@@ -105,7 +110,14 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			RETURNING position
 		`),
 
-		findAllParticipants: p.P(`SELECT id, rotation_id, position, user_id FROM rotation_participants WHERE rotation_id = $1 ORDER BY position`),
+		findAllParticipants: p.P(`SELECT id, rotation_id, position, user_id, window_start, window_end, window_time_zone, shadow FROM rotation_participants WHERE rotation_id = $1 ORDER BY position`),
+
+		setWindow: p.P(`
+			UPDATE rotation_participants
+			SET window_start = $3, window_end = $4, window_time_zone = $5
+			WHERE rotation_id = $1 AND position = $2
+		`),
+		setShadow: p.P(`UPDATE rotation_participants SET shadow = $3 WHERE rotation_id = $1 AND position = $2 AND shadow != $3`),
 
 		findParticipant: p.P(`SELECT rotation_id, position, user_id FROM rotation_participants WHERE id = $1`),
 
@@ -189,7 +201,7 @@ func (s *Store) CreateRotationTx(ctx context.Context, tx *sql.Tx, r *Rotation) (
 
 	n.ID = uuid.New().String()
 
-	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Type, n.Start, n.ShiftLength, n.Start.Location().String())
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Type, n.Start, n.ShiftLength, n.Start.Location().String(), n.ActiveCount)
 	if err != nil {
 		return nil, err
 	}
@@ -216,7 +228,7 @@ func (s *Store) UpdateRotationTx(ctx context.Context, tx *sql.Tx, r *Rotation) e
 		stmt = tx.StmtContext(ctx, stmt)
 	}
 
-	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Type, n.Start, n.ShiftLength, n.Start.Location().String())
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Type, n.Start, n.ShiftLength, n.Start.Location().String(), n.ActiveCount)
 	return err
 }
 
@@ -243,7 +255,7 @@ func (s *Store) FindMany(ctx context.Context, ids []string) ([]Rotation, error)
 	var tz string
 	result := make([]Rotation, 0, len(ids))
 	for rows.Next() {
-		err = rows.Scan(&r.ID, &r.Name, &r.Description, &r.Type, &r.Start, &r.ShiftLength, &tz, &r.isUserFavorite)
+		err = rows.Scan(&r.ID, &r.Name, &r.Description, &r.Type, &r.Start, &r.ShiftLength, &tz, &r.ActiveCount, &r.isUserFavorite)
 		if err != nil {
 			return nil, err
 		}
@@ -271,7 +283,7 @@ func (s *Store) FindRotation(ctx context.Context, id string) (*Rotation, error)
 	row := s.findRotation.QueryRowContext(ctx, id, permission.UserNullUUID(ctx))
 	var r Rotation
 	var tz string
-	err = row.Scan(&r.ID, &r.Name, &r.Description, &r.Type, &r.Start, &r.ShiftLength, &tz, &r.isUserFavorite)
+	err = row.Scan(&r.ID, &r.Name, &r.Description, &r.Type, &r.Start, &r.ShiftLength, &tz, &r.ActiveCount, &r.isUserFavorite)
 	if err != nil {
 		return nil, err
 	}
@@ -328,7 +340,7 @@ func (s *Store) FindRotationForUpdateTx(ctx context.Context, tx *sql.Tx, rotatio
 	row := stmt.QueryRowContext(ctx, rotationID)
 	var r Rotation
 	var tz string
-	err = row.Scan(&r.ID, &r.Name, &r.Description, &r.Type, &r.Start, &r.ShiftLength, &tz)
+	err = row.Scan(&r.ID, &r.Name, &r.Description, &r.Type, &r.Start, &r.ShiftLength, &tz, &r.ActiveCount)
 	if err != nil {
 		return nil, err
 	}
@@ -378,10 +390,11 @@ func (s *Store) FindAllParticipantsTx(ctx context.Context, tx *sql.Tx, rotationI
 	defer rows.Close()
 
 	var p Participant
-	var userID sql.NullString
+	var userID, tz sql.NullString
+	var winStart, winEnd sql.Null[timeutil.Clock]
 	var res []Participant
 	for rows.Next() {
-		err = rows.Scan(&p.ID, &p.RotationID, &p.Position, &userID)
+		err = rows.Scan(&p.ID, &p.RotationID, &p.Position, &userID, &winStart, &winEnd, &tz, &p.Shadow)
 		if err != nil {
 			return nil, err
 		}
@@ -390,6 +403,14 @@ func (s *Store) FindAllParticipantsTx(ctx context.Context, tx *sql.Tx, rotationI
 		} else {
 			p.Target = nil
 		}
+		p.Window = nil
+		if tz.Valid {
+			loc, err := util.LoadLocation(tz.String)
+			if err != nil {
+				return nil, errors.Wrap(err, "load participant window time zone")
+			}
+			p.Window = &ParticipantWindow{Start: winStart.V, End: winEnd.V, TimeZone: loc}
+		}
 		res = append(res, p)
 	}
 
@@ -503,6 +524,80 @@ func (s *Store) AddRotationUsersTx(ctx context.Context, tx *sql.Tx, rotationID s
 	return err
 }
 
+// SetParticipantWindowsTx will set the follow-the-sun window of each participant, by position. A nil
+// window will clear it.
+func (s *Store) SetParticipantWindowsTx(ctx context.Context, tx *sql.Tx, rotationID string, windows []*ParticipantWindow) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.Many(
+		validate.UUID("RotationID", rotationID),
+		validate.Range("Windows", len(windows), 0, 50),
+	)
+	if err != nil {
+		return err
+	}
+
+	for i, w := range windows {
+		if w == nil {
+			continue
+		}
+		windows[i], err = w.Normalize()
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.withTxLock(ctx, tx, func(tx *sql.Tx) error {
+		stmt := tx.StmtContext(ctx, s.setWindow)
+		for i, w := range windows {
+			var start, end sql.Null[timeutil.Clock]
+			var tz sql.NullString
+			if w != nil {
+				start = sql.Null[timeutil.Clock]{V: w.Start, Valid: true}
+				end = sql.Null[timeutil.Clock]{V: w.End, Valid: true}
+				tz = sql.NullString{String: w.TimeZone.String(), Valid: true}
+			}
+			_, err := stmt.ExecContext(ctx, rotationID, i, start, end, tz)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// SetParticipantShadowsTx will set the shadow flag of each participant, by position.
+func (s *Store) SetParticipantShadowsTx(ctx context.Context, tx *sql.Tx, rotationID string, shadows []bool) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.Many(
+		validate.UUID("RotationID", rotationID),
+		validate.Range("Shadows", len(shadows), 0, 50),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.withTxLock(ctx, tx, func(tx *sql.Tx) error {
+		stmt := tx.StmtContext(ctx, s.setShadow)
+		for i, shadow := range shadows {
+			_, err := stmt.ExecContext(ctx, rotationID, i, shadow)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
 func (s *Store) DeleteRotationParticipantsTx(ctx context.Context, tx *sql.Tx, partIDs []string) error {
 	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
 	if err != nil {
@@ -555,3 +650,79 @@ func (s *Store) DeleteStateTx(ctx context.Context, tx *sql.Tx, rotationID string
 		return err
 	})
 }
+
+// SetParticipantsTx will replace the participants of a rotation with the given users, in order, updating
+// existing participants in place where possible.
+//
+// If updateActive is true and the active participant is removed, the first participant becomes active.
+func (s *Store) SetParticipantsTx(ctx context.Context, tx *sql.Tx, rotationID string, userIDs []string, updateActive bool) error {
+	// Get current participants
+	currentParticipants, err := s.FindAllParticipantsTx(ctx, tx, rotationID)
+	if err != nil {
+		return err
+	}
+
+	var participantIDsToRemove []string
+
+	for i, c := range currentParticipants {
+		if i >= len(userIDs) {
+			participantIDsToRemove = append(participantIDsToRemove, c.ID)
+			continue
+		}
+
+		if c.Target.TargetID() == userIDs[i] {
+			// nothing to update
+			continue
+		}
+
+		// Update
+		err = s.UpdateParticipantUserIDTx(ctx, tx, c.ID, userIDs[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(userIDs) > len(currentParticipants) {
+		// Add users
+		err = s.AddRotationUsersTx(ctx, tx, rotationID, userIDs[len(currentParticipants):])
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(participantIDsToRemove) == 0 {
+		return nil
+	}
+
+	if len(userIDs) == 0 {
+		// Delete rotation state if all users are going to be deleted as per new input
+		err = s.DeleteStateTx(ctx, tx, rotationID)
+		if err != nil {
+			return err
+		}
+	} else if updateActive {
+		// get current active participant
+		state, err := s.StateTx(ctx, tx, rotationID)
+		if errors.Is(err, ErrNoState) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// if currently active user is going to be deleted
+		// then set to first user before we actually delete any users
+		if state.Position >= len(userIDs) {
+			err = s.SetActiveIndexTx(ctx, tx, rotationID, 0)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = s.DeleteRotationParticipantsTx(ctx, tx, participantIDsToRemove)
+	if err != nil {
+		return err
+	}
+	return nil
+}
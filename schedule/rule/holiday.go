@@ -0,0 +1,127 @@
+package rule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/target/goalert/validation"
+)
+
+// HolidayFilter determines how a rule is affected by the holidays of its schedule.
+type HolidayFilter string
+
+const (
+	// HolidayFilterAny will ignore holidays.
+	HolidayFilterAny HolidayFilter = "any"
+
+	// HolidayFilterExclude will make the rule inactive on holidays.
+	HolidayFilterExclude HolidayFilter = "exclude"
+
+	// HolidayFilterOnly will make the rule active only on holidays.
+	HolidayFilterOnly HolidayFilter = "only"
+)
+
+// Scan handles reading a HolidayFilter from the DB.
+func (f *HolidayFilter) Scan(value interface{}) error {
+	switch t := value.(type) {
+	case []byte:
+		*f = HolidayFilter(t)
+	case string:
+		*f = HolidayFilter(t)
+	default:
+		return fmt.Errorf("could not process unknown type for HolidayFilter: %T", t)
+	}
+
+	return nil
+}
+
+func (f HolidayFilter) validate() error {
+	switch f {
+	case HolidayFilterAny, HolidayFilterExclude, HolidayFilterOnly:
+		return nil
+	}
+
+	return validation.NewFieldError("HolidayFilter", "unknown holiday filter")
+}
+
+// A HolidaySet is a set of calendar dates that are holidays. Dates are stored as midnight UTC.
+type HolidaySet map[time.Time]struct{}
+
+// NewHolidaySet will create a HolidaySet from the year, month, and day of each date.
+func NewHolidaySet(dates ...time.Time) HolidaySet {
+	h := make(HolidaySet, len(dates))
+	for _, d := range dates {
+		h.Add(d)
+	}
+
+	return h
+}
+
+// Add will add the date of t to the set.
+func (h HolidaySet) Add(t time.Time) {
+	y, m, d := t.Date()
+	h[time.Date(y, m, d, 0, 0, 0, 0, time.UTC)] = struct{}{}
+}
+
+// Contains returns true if the date of t, in the location of t, is a holiday.
+func (h HolidaySet) Contains(t time.Time) bool {
+	if len(h) == 0 {
+		return false
+	}
+	y, m, d := t.Date()
+	_, ok := h[time.Date(y, m, d, 0, 0, 0, 0, time.UTC)]
+	return ok
+}
+
+// HolidayActive returns true if the holiday filter allows the rule to be active at t. The date of t,
+// in the location of t, is used to check for a holiday.
+func (r Rule) HolidayActive(t time.Time, h HolidaySet) bool {
+	switch r.HolidayFilter {
+	case HolidayFilterExclude:
+		return !h.Contains(t)
+	case HolidayFilterOnly:
+		return h.Contains(t)
+	}
+
+	return true
+}
+
+// IsActiveOn works like IsActive, but also applies the rule's holiday filter.
+func (r Rule) IsActiveOn(t time.Time, h HolidaySet) bool {
+	return r.HolidayActive(t, h) && r.IsActive(t)
+}
+
+// HolidaySpans will split the span between start and end into the parts where the holiday filter
+// allows the rule to be active. Days are determined in the location of start.
+func (r Rule) HolidaySpans(start, end time.Time, h HolidaySet) [][2]time.Time {
+	if r.HolidayFilter == "" || r.HolidayFilter == HolidayFilterAny {
+		return [][2]time.Time{{start, end}}
+	}
+
+	var result [][2]time.Time
+	var spanStart time.Time
+	cur := start
+	for cur.Before(end) {
+		y, m, d := cur.Date()
+		next := time.Date(y, m, d+1, 0, 0, 0, 0, cur.Location())
+		if next.After(end) {
+			next = end
+		}
+
+		active := r.HolidayActive(cur, h)
+		switch {
+		case active && spanStart.IsZero():
+			spanStart = cur
+		case !active && !spanStart.IsZero():
+			result = append(result, [2]time.Time{spanStart, cur})
+			spanStart = time.Time{}
+		}
+
+		cur = next
+	}
+	if !spanStart.IsZero() {
+		result = append(result, [2]time.Time{spanStart, end})
+	}
+
+	return result
+}
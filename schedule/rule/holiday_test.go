@@ -0,0 +1,55 @@
+package rule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/util/timeutil"
+)
+
+func TestRule_IsActiveOn(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// stored as UTC dates, but checked against the local date
+	h := NewHolidaySet(time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC))
+
+	r := Rule{
+		Start:         timeutil.NewClock(9, 0),
+		End:           timeutil.NewClock(17, 0),
+		WeekdayFilter: timeutil.EveryDay(),
+	}
+	christmas := time.Date(2024, 12, 25, 10, 0, 0, 0, loc)
+	dayAfter := time.Date(2024, 12, 26, 10, 0, 0, 0, loc)
+
+	assert.True(t, r.IsActiveOn(christmas, h), "no filter")
+
+	r.HolidayFilter = HolidayFilterExclude
+	assert.False(t, r.IsActiveOn(christmas, h))
+	assert.True(t, r.IsActiveOn(dayAfter, h))
+	assert.True(t, r.IsActiveOn(christmas, nil), "no holidays")
+
+	r.HolidayFilter = HolidayFilterOnly
+	assert.True(t, r.IsActiveOn(christmas, h))
+	assert.False(t, r.IsActiveOn(dayAfter, h))
+	assert.False(t, r.IsActiveOn(christmas.Add(8*time.Hour), h), "rule inactive")
+}
+
+func TestRule_HolidaySpans(t *testing.T) {
+	h := NewHolidaySet(time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC))
+	start := time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 12, 27, 0, 0, 0, 0, time.UTC)
+	christmas := time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)
+	dayAfter := christmas.AddDate(0, 0, 1)
+
+	r := Rule{HolidayFilter: HolidayFilterAny}
+	assert.Equal(t, [][2]time.Time{{start, end}}, r.HolidaySpans(start, end, h))
+
+	r.HolidayFilter = HolidayFilterExclude
+	assert.Equal(t, [][2]time.Time{{start, christmas}, {dayAfter, end}}, r.HolidaySpans(start, end, h))
+
+	r.HolidayFilter = HolidayFilterOnly
+	assert.Equal(t, [][2]time.Time{{christmas, dayAfter}}, r.HolidaySpans(start, end, h))
+}
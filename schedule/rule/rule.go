@@ -19,6 +19,9 @@ type Rule struct {
 	End       timeutil.Clock `json:"end"`
 	CreatedAt time.Time      `json:"created_at"`
 	Target    assignment.Target
+
+	// HolidayFilter determines if the rule is active on holidays.
+	HolidayFilter HolidayFilter `json:"holiday_filter"`
 }
 
 func NewAlwaysActive(scheduleID string, tgt assignment.Target) *Rule {
@@ -26,6 +29,7 @@ func NewAlwaysActive(scheduleID string, tgt assignment.Target) *Rule {
 		WeekdayFilter: timeutil.EveryDay(),
 		ScheduleID:    scheduleID,
 		Target:        tgt,
+		HolidayFilter: HolidayFilterAny,
 	}
 }
 
@@ -34,6 +38,13 @@ func (r Rule) Normalize() (*Rule, error) {
 	if err != nil {
 		return nil, err
 	}
+	if r.HolidayFilter == "" {
+		r.HolidayFilter = HolidayFilterAny
+	}
+	err = r.HolidayFilter.validate()
+	if err != nil {
+		return nil, err
+	}
 	r.Start = timeutil.Clock(time.Duration(r.Start).Truncate(time.Minute))
 	r.End = timeutil.Clock(time.Duration(r.End).Truncate(time.Minute))
 	return &r, nil
@@ -54,7 +65,7 @@ func (r *Rule) scanFrom(s scanner) error {
 		&r.End,
 	}
 	var usr, rot sql.NullString
-	f = append(f, &usr, &rot)
+	f = append(f, &usr, &rot, &r.HolidayFilter)
 	err := s.Scan(f...)
 	if err != nil {
 		return err
@@ -88,7 +99,7 @@ func (r Rule) readFields() []interface{} {
 		rot.Valid = true
 		rot.String = r.Target.TargetID()
 	}
-	return append(f, usr, rot)
+	return append(f, usr, rot, r.HolidayFilter)
 }
 
 // StartTime will return the next time the rule would be active.
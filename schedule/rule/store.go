@@ -44,8 +44,9 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				start_time,
 				end_time,
 				tgt_user_id,
-				tgt_rotation_id
-			) values ($1, $2, ($3::Bool[])[1], ($3::Bool[])[2], ($3::Bool[])[3], ($3::Bool[])[4], ($3::Bool[])[5], ($3::Bool[])[6], ($3::Bool[])[7], $4, $5, $6, $7)
+				tgt_rotation_id,
+				holiday_filter
+			) values ($1, $2, ($3::Bool[])[1], ($3::Bool[])[2], ($3::Bool[])[3], ($3::Bool[])[4], ($3::Bool[])[5], ($3::Bool[])[6], ($3::Bool[])[7], $4, $5, $6, $7, $8)
 		`),
 		update: p.P(`
 			update schedule_rules
@@ -61,7 +62,8 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				start_time = $4,
 				end_time = $5,
 				tgt_user_id = $6,
-				tgt_rotation_id = $7
+				tgt_rotation_id = $7,
+				holiday_filter = $8
 			where id = $1
 		`),
 		delete: p.P(`delete from schedule_rules where id = any($1)`),
@@ -82,7 +84,8 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				start_time,
 				end_time,
 				tgt_user_id,
-				tgt_rotation_id
+				tgt_rotation_id,
+				holiday_filter
 			from schedule_rules
 			where schedule_id = $1
 			order by created_at, id
@@ -103,7 +106,8 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 				start_time,
 				end_time,
 				tgt_user_id,
-				tgt_rotation_id
+				tgt_rotation_id,
+				holiday_filter
 			from schedule_rules
 			where schedule_id = $1 AND (tgt_user_id = $2 OR tgt_rotation_id = $3)
 			order by created_at, id
@@ -0,0 +1,116 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+)
+
+// TemporaryScheduleTemplates will return the templates for the provided scheduleID.
+func (store *Store) TemporaryScheduleTemplates(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID) ([]TemporaryScheduleTemplate, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := store.scheduleData(ctx, tx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return data.V1.TemporaryScheduleTemplates, nil
+}
+
+// SetTemporaryScheduleTemplate will create or update a template for the schedule. A new ID is assigned if
+// tmpl.ID is not set.
+func (store *Store) SetTemporaryScheduleTemplate(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID, tmpl TemporaryScheduleTemplate) (*TemporaryScheduleTemplate, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	check, err := store.usr.UserExists(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newTmpl, err := tmpl.Normalize(check)
+	if err != nil {
+		return nil, err
+	}
+
+	err = store.updateScheduleData(ctx, tx, scheduleID, func(data *Data) error {
+		data.V1.TemporaryScheduleTemplates, err = setTemplate(data.V1.TemporaryScheduleTemplates, *newTmpl)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newTmpl, nil
+}
+
+// DeleteTemporaryScheduleTemplate will remove a template from the schedule. Temporary schedules previously
+// created from it are not changed.
+func (store *Store) DeleteTemporaryScheduleTemplate(ctx context.Context, tx *sql.Tx, scheduleID, templateID uuid.UUID) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	return store.updateScheduleData(ctx, tx, scheduleID, func(data *Data) error {
+		result := data.V1.TemporaryScheduleTemplates[:0]
+		for _, t := range data.V1.TemporaryScheduleTemplates {
+			if t.ID == templateID {
+				continue
+			}
+			result = append(result, t)
+		}
+		data.V1.TemporaryScheduleTemplates = result
+		return nil
+	})
+}
+
+// ApplyTemporaryScheduleTemplate will set a TemporarySchedule from the template, starting on the day of t
+// in the schedule's time zone. Existing temporary schedules during that time are replaced.
+func (store *Store) ApplyTemporaryScheduleTemplate(ctx context.Context, tx *sql.Tx, scheduleID, templateID uuid.UUID, t time.Time) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	scheds, err := store.FindManyTx(ctx, tx, []string{scheduleID.String()})
+	if err != nil {
+		return err
+	}
+	if len(scheds) == 0 {
+		return validation.NewFieldError("ScheduleID", "not found")
+	}
+
+	check, err := store.usr.UserExists(ctx)
+	if err != nil {
+		return err
+	}
+
+	return store.updateScheduleData(ctx, tx, scheduleID, func(data *Data) error {
+		for _, tmpl := range data.V1.TemporaryScheduleTemplates {
+			if tmpl.ID != templateID {
+				continue
+			}
+
+			newTemp, err := tmpl.Apply(t.In(scheds[0].TimeZone)).Normalize(check)
+			if err != nil {
+				return err
+			}
+
+			data.V1.TemporarySchedules = setFixedShifts(data.V1.TemporarySchedules, *newTemp)
+			return nil
+		}
+
+		return validation.NewFieldError("TemplateID", "not found")
+	})
+}
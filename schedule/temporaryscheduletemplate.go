@@ -0,0 +1,211 @@
+package schedule
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+const (
+	// TemplatesPerScheduleLimit is the maximum number of temporary schedule templates a schedule can have.
+	TemplatesPerScheduleLimit = 25
+
+	// TemplateMaxDays is the maximum length of a temporary schedule template.
+	TemplateMaxDays = 90
+
+	// TemplateLookahead is how far ahead of time recurring templates are applied to a schedule.
+	TemplateLookahead = 30 * 24 * time.Hour
+)
+
+// A TemporaryScheduleTemplate is a named, reusable set of shifts that can be applied to a schedule as a
+// TemporarySchedule. Shift times are relative to the day the template is applied to.
+type TemporaryScheduleTemplate struct {
+	ID   uuid.UUID
+	Name string
+
+	// Days is the length of the template, starting at midnight (in the schedule's time zone) of the day
+	// it is applied to.
+	Days int
+
+	Shifts []TemplateShift
+
+	// Recurrence, if set, will cause the template to be applied automatically every year.
+	Recurrence *TemplateRecurrence
+
+	// LastApplied is the start of the most recent occurrence applied automatically.
+	LastApplied time.Time
+}
+
+// A TemplateShift is an on-call user with start and end times relative to the start of a template.
+type TemplateShift struct {
+	UserID     string
+	Start, End ShiftOffset
+}
+
+// A ShiftOffset is a number of days after the start of a template, and the time of day.
+type ShiftOffset struct {
+	Day  int
+	Time timeutil.Clock
+}
+
+// TemplateRecurrence is the yearly start date of a recurring template.
+type TemplateRecurrence struct {
+	Month time.Month
+	Day   int
+}
+
+// At returns the time of the offset from the given start of a template.
+func (o ShiftOffset) At(start time.Time) time.Time {
+	y, m, d := start.Date()
+	return time.Date(y, m, d+o.Day, o.Time.Hour(), o.Time.Minute(), 0, 0, start.Location())
+}
+
+// Apply returns the TemporarySchedule for the template starting on the given day. The year, month, and day
+// of t are used, in the location of t.
+func (tmpl TemporaryScheduleTemplate) Apply(t time.Time) TemporarySchedule {
+	y, m, d := t.Date()
+	temp := TemporarySchedule{
+		Start:  time.Date(y, m, d, 0, 0, 0, 0, t.Location()),
+		End:    time.Date(y, m, d+tmpl.Days, 0, 0, 0, 0, t.Location()),
+		Shifts: make([]FixedShift, 0, len(tmpl.Shifts)),
+	}
+	for _, s := range tmpl.Shifts {
+		temp.Shifts = append(temp.Shifts, FixedShift{
+			UserID: s.UserID,
+			Start:  s.Start.At(temp.Start),
+			End:    s.End.At(temp.Start),
+		})
+	}
+
+	return temp
+}
+
+// NextOccurrence returns the start of the first occurrence of a recurring template that has not ended
+// by t. The zero value is returned if the template does not recur.
+func (tmpl TemporaryScheduleTemplate) NextOccurrence(t time.Time, loc *time.Location) time.Time {
+	if tmpl.Recurrence == nil {
+		return time.Time{}
+	}
+
+	t = t.In(loc)
+	for y := t.Year() - 1; ; y++ {
+		start := time.Date(y, tmpl.Recurrence.Month, tmpl.Recurrence.Day, 0, 0, 0, 0, loc)
+		if time.Date(y, tmpl.Recurrence.Month, tmpl.Recurrence.Day+tmpl.Days, 0, 0, 0, 0, loc).After(t) {
+			return start
+		}
+	}
+}
+
+// Normalize will validate the template and assign a new ID if one is not set.
+func (tmpl TemporaryScheduleTemplate) Normalize(checkUser user.ExistanceChecker) (*TemporaryScheduleTemplate, error) {
+	if tmpl.ID == uuid.Nil {
+		tmpl.ID = uuid.New()
+	}
+	tmpl.Shifts = slices.Clone(tmpl.Shifts)
+
+	err := validate.Many(
+		validate.IDName("Name", tmpl.Name),
+		validate.Range("Days", tmpl.Days, 1, TemplateMaxDays),
+		tmpl.Recurrence.validate(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, s := range tmpl.Shifts {
+		prefix := fmt.Sprintf("Shifts[%d].", i)
+		err = validate.Many(
+			validate.Range(prefix+"Start.Day", s.Start.Day, 0, tmpl.Days),
+			validate.Range(prefix+"End.Day", s.End.Day, 0, tmpl.Days),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// validate shift times against a day without DST transitions
+	err = tmpl.Apply(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).validateShifts(checkUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tmpl, nil
+}
+
+func (r *TemplateRecurrence) validate() error {
+	if r == nil {
+		return nil
+	}
+
+	err := validate.Range("Recurrence.Month", int(r.Month), 1, 12)
+	if err != nil {
+		return err
+	}
+
+	// use a non-leap year so the date exists every year
+	lastDay := time.Date(2001, r.Month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return validate.Range("Recurrence.Day", r.Day, 1, lastDay)
+}
+
+// setTemplate will add or replace the template with the same ID.
+func setTemplate(templates []TemporaryScheduleTemplate, tmpl TemporaryScheduleTemplate) ([]TemporaryScheduleTemplate, error) {
+	for i, t := range templates {
+		if t.ID != tmpl.ID {
+			continue
+		}
+		if tmpl.Recurrence != nil && t.Recurrence != nil && *tmpl.Recurrence == *t.Recurrence {
+			// keep track of applied occurrences if the recurrence is unchanged
+			tmpl.LastApplied = t.LastApplied
+		}
+		templates = slices.Clone(templates)
+		templates[i] = tmpl
+		return templates, nil
+	}
+
+	if len(templates) >= TemplatesPerScheduleLimit {
+		return nil, validation.NewFieldError("Templates", fmt.Sprintf("must not have more than %d templates", TemplatesPerScheduleLimit))
+	}
+
+	return append(slices.Clone(templates), tmpl), nil
+}
+
+// MaterializeTemplates will apply the next occurrence of each recurring template if it starts within
+// TemplateLookahead of now and has not already been applied. Existing temporary schedules during an
+// occurrence are replaced. It returns true if data was modified.
+func (data *Data) MaterializeTemplates(now time.Time, loc *time.Location) bool {
+	if data == nil {
+		return false
+	}
+
+	var changed bool
+	templates := slices.Clone(data.V1.TemporaryScheduleTemplates)
+	for i, tmpl := range templates {
+		if tmpl.Recurrence == nil {
+			continue
+		}
+		start := tmpl.NextOccurrence(now, loc)
+		if !start.After(tmpl.LastApplied) || start.After(now.Add(TemplateLookahead)) {
+			continue
+		}
+
+		templates[i].LastApplied = start
+		changed = true
+
+		temp := tmpl.Apply(start).TrimStart(now.Truncate(time.Minute))
+		if temp.Start.IsZero() {
+			continue
+		}
+		data.V1.TemporarySchedules = setFixedShifts(data.V1.TemporarySchedules, temp)
+	}
+	if changed {
+		data.V1.TemporaryScheduleTemplates = templates
+	}
+
+	return changed
+}
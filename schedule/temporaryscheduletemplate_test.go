@@ -0,0 +1,107 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/util/timeutil"
+)
+
+func TestTemporaryScheduleTemplate_Apply(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	tmpl := TemporaryScheduleTemplate{
+		Days: 2,
+		Shifts: []TemplateShift{
+			{UserID: "a", Start: ShiftOffset{Day: 0, Time: timeutil.NewClock(0, 0)}, End: ShiftOffset{Day: 0, Time: timeutil.NewClock(12, 0)}},
+			{UserID: "b", Start: ShiftOffset{Day: 0, Time: timeutil.NewClock(12, 0)}, End: ShiftOffset{Day: 2, Time: timeutil.NewClock(0, 0)}},
+		},
+	}
+
+	// DST ends Nov 3rd, shifts keep their local times
+	temp := tmpl.Apply(time.Date(2024, 11, 2, 15, 30, 0, 0, loc))
+	assert.Equal(t, time.Date(2024, 11, 2, 0, 0, 0, 0, loc), temp.Start)
+	assert.Equal(t, time.Date(2024, 11, 4, 0, 0, 0, 0, loc), temp.End)
+	assert.Equal(t, []FixedShift{
+		{UserID: "a", Start: time.Date(2024, 11, 2, 0, 0, 0, 0, loc), End: time.Date(2024, 11, 2, 12, 0, 0, 0, loc)},
+		{UserID: "b", Start: time.Date(2024, 11, 2, 12, 0, 0, 0, loc), End: time.Date(2024, 11, 4, 0, 0, 0, 0, loc)},
+	}, temp.Shifts)
+}
+
+func TestTemporaryScheduleTemplate_NextOccurrence(t *testing.T) {
+	tmpl := TemporaryScheduleTemplate{Days: 10, Recurrence: &TemplateRecurrence{Month: time.December, Day: 24}}
+
+	assert.Equal(t,
+		time.Date(2023, 12, 24, 0, 0, 0, 0, time.UTC),
+		tmpl.NextOccurrence(time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC), time.UTC),
+		"in progress across new year",
+	)
+	assert.Equal(t,
+		time.Date(2024, 12, 24, 0, 0, 0, 0, time.UTC),
+		tmpl.NextOccurrence(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.UTC),
+	)
+
+	assert.True(t, TemporaryScheduleTemplate{Days: 1}.NextOccurrence(time.Now(), time.UTC).IsZero())
+}
+
+func TestTemporaryScheduleTemplate_Normalize(t *testing.T) {
+	valid := TemporaryScheduleTemplate{
+		Name: "Holidays",
+		Days: 1,
+		Shifts: []TemplateShift{
+			{UserID: "3f9c9b5c-3f2e-4b6e-8d5a-2f1e0c7b6a51", End: ShiftOffset{Day: 1}},
+		},
+		Recurrence: &TemplateRecurrence{Month: time.December, Day: 24},
+	}
+	tmpl, err := valid.Normalize(nil)
+	require.NoError(t, err)
+	assert.NotZero(t, tmpl.ID)
+
+	check := func(desc string, fn func(*TemporaryScheduleTemplate)) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			tmpl := valid
+			tmpl.Shifts = []TemplateShift{valid.Shifts[0]}
+			fn(&tmpl)
+			_, err := tmpl.Normalize(nil)
+			assert.Error(t, err)
+		})
+	}
+	check("no days", func(tmpl *TemporaryScheduleTemplate) { tmpl.Days = 0 })
+	check("shift after end", func(tmpl *TemporaryScheduleTemplate) { tmpl.Shifts[0].End.Day = 2 })
+	check("shift end before start", func(tmpl *TemporaryScheduleTemplate) {
+		tmpl.Shifts[0].Start.Time = timeutil.NewClock(12, 0)
+		tmpl.Shifts[0].End.Day = 0
+	})
+	check("leap day", func(tmpl *TemporaryScheduleTemplate) {
+		tmpl.Recurrence = &TemplateRecurrence{Month: time.February, Day: 29}
+	})
+}
+
+func TestData_MaterializeTemplates(t *testing.T) {
+	var data Data
+	data.V1.TemporaryScheduleTemplates = []TemporaryScheduleTemplate{{
+		Days:       10,
+		Shifts:     []TemplateShift{{UserID: "a", End: ShiftOffset{Day: 10}}},
+		Recurrence: &TemplateRecurrence{Month: time.December, Day: 24},
+	}}
+	occurrence := time.Date(2024, 12, 24, 0, 0, 0, 0, time.UTC)
+
+	assert.False(t, data.MaterializeTemplates(time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC), time.UTC), "too far ahead")
+	assert.Empty(t, data.V1.TemporarySchedules)
+
+	now := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	require.True(t, data.MaterializeTemplates(now, time.UTC))
+	assert.Equal(t, occurrence, data.V1.TemporaryScheduleTemplates[0].LastApplied)
+	require.Len(t, data.V1.TemporarySchedules, 1)
+	assert.Equal(t, occurrence, data.V1.TemporarySchedules[0].Start)
+	assert.Equal(t, occurrence.AddDate(0, 0, 10), data.V1.TemporarySchedules[0].End)
+
+	// cleared by a user, should not be re-applied
+	data.V1.TemporarySchedules = nil
+	assert.False(t, data.MaterializeTemplates(now.Add(time.Hour), time.UTC))
+	assert.Empty(t, data.V1.TemporarySchedules)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/search"
@@ -52,7 +53,10 @@ var searchTemplate = template.Must(template.New("search").Funcs(search.Helpers()
 		svc.description,
 		svc.escalation_policy_id,
 		fav IS DISTINCT FROM NULL,
-		svc.maintenance_expires_at
+		svc.maintenance_expires_at,
+		svc.reopen_window_minutes,
+		svc.flap_threshold,
+		svc.flap_window_minutes
 	FROM services svc
 	{{if not .FavoritesOnly }}LEFT {{end}}JOIN user_favorites fav ON svc.id = fav.tgt_service_id AND {{if .FavoritesUserID}}fav.user_id = :favUserID{{else}}false{{end}}
 	{{if and .IntegrationKey}}
@@ -244,11 +248,14 @@ func (s *Store) Search(ctx context.Context, opts *SearchOptions) ([]Service, err
 	for rows.Next() {
 		var s Service
 		var maintExpiresAt sql.NullTime
-		err = rows.Scan(&s.ID, &s.Name, &s.Description, &s.EscalationPolicyID, &s.isUserFavorite, &maintExpiresAt)
+		var reopenMin, flapMin int
+		err = rows.Scan(&s.ID, &s.Name, &s.Description, &s.EscalationPolicyID, &s.isUserFavorite, &maintExpiresAt, &reopenMin, &s.FlapThreshold, &flapMin)
 		if err != nil {
 			return nil, err
 		}
 		s.MaintenanceExpiresAt = maintExpiresAt.Time
+		s.ReopenWindow = time.Duration(reopenMin) * time.Minute
+		s.FlapWindow = time.Duration(flapMin) * time.Minute
 
 		result = append(result, s)
 	}
@@ -3,6 +3,7 @@ package service
 import (
 	"time"
 
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -13,12 +14,24 @@ type Service struct {
 	EscalationPolicyID   string
 	MaintenanceExpiresAt time.Time
 
+	// ReopenWindow is how long after an alert is closed that a new occurrence (with the same dedup key)
+	// will re-open it instead of creating a new alert. Zero disables re-opening.
+	ReopenWindow time.Duration
+
+	// FlapThreshold is the number of open/close cycles within FlapWindow after which an alert is
+	// considered flapping, and notifications are held. Zero disables flap detection.
+	FlapThreshold int
+	FlapWindow    time.Duration
+
 	epName         string
 	isUserFavorite bool
 }
 
 const MaxDetailsLength = 6 * 1024 // 6KiB
 
+// MaxAlertWindow is the maximum duration of the reopen and flap windows.
+const MaxAlertWindow = 24 * time.Hour
+
 func (s Service) EscalationPolicyName() string {
 	return s.epName
 }
@@ -42,10 +55,18 @@ func (s Service) Normalize() (*Service, error) {
 		validate.Text("Description", s.Description, 1, MaxDetailsLength),
 		validate.UUID("EscalationPolicyID", s.EscalationPolicyID),
 		validate.Duration("MaintenanceExpiresAt", dur, 0, 24*time.Hour+5*time.Minute),
+		validate.Duration("ReopenWindow", s.ReopenWindow, 0, MaxAlertWindow),
+		validate.Range("FlapThreshold", s.FlapThreshold, 0, 100),
+		validate.Duration("FlapWindow", s.FlapWindow, 0, MaxAlertWindow),
 	)
 	if err != nil {
 		return nil, err
 	}
+	if s.FlapThreshold > 0 && s.FlapWindow < time.Minute {
+		return nil, validation.NewFieldError("FlapWindow", "must be at least 1 minute when flap detection is enabled")
+	}
+	s.ReopenWindow = s.ReopenWindow.Truncate(time.Minute)
+	s.FlapWindow = s.FlapWindow.Truncate(time.Minute)
 
 	return &s, nil
 }
@@ -2,6 +2,7 @@ package service
 
 import (
 	"testing"
+	"time"
 )
 
 func TestService_Normalize(t *testing.T) {
@@ -23,9 +24,14 @@ func TestService_Normalize(t *testing.T) {
 
 	valid := []Service{
 		{Name: "Sample Service", Description: "Sample Service", EscalationPolicyID: "A035FD3C-73C8-4F72-BECD-36B027AE1374"},
+		{Name: "Sample Service", EscalationPolicyID: "A035FD3C-73C8-4F72-BECD-36B027AE1374", ReopenWindow: time.Hour},
+		{Name: "Sample Service", EscalationPolicyID: "A035FD3C-73C8-4F72-BECD-36B027AE1374", FlapThreshold: 3, FlapWindow: 30 * time.Minute},
 	}
 	invalid := []Service{
 		{},
+		{Name: "Sample Service", EscalationPolicyID: "A035FD3C-73C8-4F72-BECD-36B027AE1374", ReopenWindow: 25 * time.Hour},
+		{Name: "Sample Service", EscalationPolicyID: "A035FD3C-73C8-4F72-BECD-36B027AE1374", FlapThreshold: 3},
+		{Name: "Sample Service", EscalationPolicyID: "A035FD3C-73C8-4F72-BECD-36B027AE1374", FlapThreshold: -1},
 	}
 	for _, s := range valid {
 		test(true, s)
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util"
@@ -37,7 +38,10 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			s.escalation_policy_id,
 			e.name,
 			fav	is distinct from null,
-			s.maintenance_expires_at
+			s.maintenance_expires_at,
+			s.reopen_window_minutes,
+			s.flap_threshold,
+			s.flap_window_minutes
 		FROM
 			services s
 		JOIN escalation_policies e ON e.id = s.escalation_policy_id
@@ -50,7 +54,10 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			s.id,
 			s.name,
 			s.description,
-			s.escalation_policy_id
+			s.escalation_policy_id,
+			s.reopen_window_minutes,
+			s.flap_threshold,
+			s.flap_window_minutes
 		FROM services s
 		WHERE s.id = $1
 		FOR UPDATE
@@ -63,7 +70,10 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			s.escalation_policy_id,
 			e.name,
 			fav	is distinct from null,
-			s.maintenance_expires_at
+			s.maintenance_expires_at,
+			s.reopen_window_minutes,
+			s.flap_threshold,
+			s.flap_window_minutes
 		FROM
 			services s
 		JOIN escalation_policies e ON e.id = s.escalation_policy_id
@@ -80,7 +90,10 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			s.escalation_policy_id,
 			e.name,
 			false,
-			s.maintenance_expires_at
+			s.maintenance_expires_at,
+			s.reopen_window_minutes,
+			s.flap_threshold,
+			s.flap_window_minutes
 		FROM
 			services s,
 			escalation_policies e
@@ -88,8 +101,8 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			e.id = $1 AND
 			e.id = s.escalation_policy_id
 	`)
-	s.insert = p(`INSERT INTO services (id,name,description,escalation_policy_id) VALUES ($1,$2,$3,$4)`)
-	s.update = p(`UPDATE services SET name = $2, description = $3, escalation_policy_id = $4, maintenance_expires_at = $5 WHERE id = $1`)
+	s.insert = p(`INSERT INTO services (id,name,description,escalation_policy_id,reopen_window_minutes,flap_threshold,flap_window_minutes) VALUES ($1,$2,$3,$4,$5,$6,$7)`)
+	s.update = p(`UPDATE services SET name = $2, description = $3, escalation_policy_id = $4, maintenance_expires_at = $5, reopen_window_minutes = $6, flap_threshold = $7, flap_window_minutes = $8 WHERE id = $1`)
 	s.delete = p(`DELETE FROM services WHERE id = any($1)`)
 
 	return s, prep.Err
@@ -105,10 +118,13 @@ func (s *Store) FindOneForUpdate(ctx context.Context, tx *sql.Tx, id string) (*S
 		return nil, err
 	}
 	var svc Service
-	err = tx.StmtContext(ctx, s.findOneUp).QueryRowContext(ctx, id).Scan(&svc.ID, &svc.Name, &svc.Description, &svc.EscalationPolicyID)
+	var reopenMin, flapMin int
+	err = tx.StmtContext(ctx, s.findOneUp).QueryRowContext(ctx, id).Scan(&svc.ID, &svc.Name, &svc.Description, &svc.EscalationPolicyID, &reopenMin, &svc.FlapThreshold, &flapMin)
 	if err != nil {
 		return nil, err
 	}
+	svc.ReopenWindow = time.Duration(reopenMin) * time.Minute
+	svc.FlapWindow = time.Duration(flapMin) * time.Minute
 	return &svc, nil
 }
 
@@ -150,7 +166,7 @@ func (s *Store) CreateServiceTx(ctx context.Context, tx *sql.Tx, svc *Service) (
 	if tx != nil {
 		stmt = tx.Stmt(stmt)
 	}
-	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.EscalationPolicyID)
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.EscalationPolicyID, int(n.ReopenWindow/time.Minute), n.FlapThreshold, int(n.FlapWindow/time.Minute))
 	if err != nil {
 		return nil, err
 	}
@@ -203,7 +219,7 @@ func (s *Store) UpdateTx(ctx context.Context, tx *sql.Tx, svc *Service) error {
 		Valid: !n.MaintenanceExpiresAt.IsZero(),
 	}
 
-	_, err = wrap(tx, s.update).ExecContext(ctx, n.ID, n.Name, n.Description, n.EscalationPolicyID, mExp)
+	_, err = wrap(tx, s.update).ExecContext(ctx, n.ID, n.Name, n.Description, n.EscalationPolicyID, mExp, int(n.ReopenWindow/time.Minute), n.FlapThreshold, int(n.FlapWindow/time.Minute))
 	return err
 }
 
@@ -248,11 +264,14 @@ func (s *Store) FindOne(ctx context.Context, id string) (*Service, error) {
 
 func scanFrom(s *Service, f func(args ...interface{}) error) error {
 	var maintExpiresAt sql.NullTime
-	err := f(&s.ID, &s.Name, &s.Description, &s.EscalationPolicyID, &s.epName, &s.isUserFavorite, &maintExpiresAt)
+	var reopenMin, flapMin int
+	err := f(&s.ID, &s.Name, &s.Description, &s.EscalationPolicyID, &s.epName, &s.isUserFavorite, &maintExpiresAt, &reopenMin, &s.FlapThreshold, &flapMin)
 	if err != nil {
 		return err
 	}
 	s.MaintenanceExpiresAt = maintExpiresAt.Time
+	s.ReopenWindow = time.Duration(reopenMin) * time.Minute
+	s.FlapWindow = time.Duration(flapMin) * time.Minute
 	return nil
 }
 
@@ -55,10 +55,24 @@ func Site24x7ToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.Han
 			"State":   g.Status,
 		})
 
+		prio, err := alert.ParsePriority(r.FormValue("priority"))
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from site24x7: %v", err)
+			return
+		}
+
 		var site24x7State alert.Status
+		var statusPrio alert.Priority
 		switch g.Status {
-		case "DOWN", "CRITICAL", "TROUBLE":
+		case "DOWN":
+			site24x7State = alert.StatusTriggered
+			statusPrio = alert.PriorityP1
+		case "CRITICAL":
 			site24x7State = alert.StatusTriggered
+			statusPrio = alert.PriorityP2
+		case "TROUBLE":
+			site24x7State = alert.StatusTriggered
+			statusPrio = alert.PriorityP3
 		case "UP":
 			site24x7State = alert.StatusClosed
 		default:
@@ -66,6 +80,9 @@ func Site24x7ToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.Han
 			http.Error(w, "invalid state", http.StatusBadRequest)
 			return
 		}
+		if !prio.IsSet() {
+			prio = statusPrio
+		}
 
 		var urlStr string
 		if validate.AbsoluteURL("MONITOR_DASHBOARD_LINK", g.MonitorDashboardURL) == nil {
@@ -81,6 +98,7 @@ func Site24x7ToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.Han
 			Source:    alert.SourceSite24x7,
 			ServiceID: serviceID,
 			Dedup:     alert.NewUserDedup(r.FormValue("dedup")),
+			Priority:  prio,
 		}
 
 		err = retry.DoTemporaryError(func(int) error {
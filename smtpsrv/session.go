@@ -156,6 +156,7 @@ func (s *Session) Data(r io.Reader) error {
 	if s.dedup != "" {
 		dedup = alert.NewUserDedup(s.dedup)
 	}
+	prio := emailPriority(email.Headers.ExtraHeaders)
 
 	for _, authCtx := range s.authCtx {
 		newAlert := &alert.Alert{
@@ -165,6 +166,7 @@ func (s *Session) Data(r io.Reader) error {
 			Status:    alert.StatusTriggered,
 			Source:    alert.SourceEmail,
 			Dedup:     dedup,
+			Priority:  prio,
 		}
 
 		err = retry.DoTemporaryError(func(_ int) error {
@@ -195,3 +197,20 @@ func (s *Session) Reset() {
 
 // Logout is called when the client requests to log out.
 func (s *Session) Logout() error { return nil }
+
+// emailPriority returns the alert priority from the X-Priority header (e.g., `1 (Highest)`),
+// or PriorityNone if it is missing or invalid.
+func emailPriority(headers map[string][]string) alert.Priority {
+	vals := headers["X-Priority"]
+	if len(vals) == 0 {
+		return alert.PriorityNone
+	}
+
+	val, _, _ := strings.Cut(strings.TrimSpace(vals[0]), " ")
+	p, err := alert.ParsePriority(val)
+	if err != nil {
+		return alert.PriorityNone
+	}
+
+	return p
+}
@@ -0,0 +1,99 @@
+package smoke
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestEscalationNoActiveStep ensures that a policy where no step is currently active still escalates
+// in the normal step order, and that the fallback is recorded in the alert log.
+func TestEscalationNoActiveStep(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "u1"}}, 'bob', 'joe'),
+		({{uuid "u2"}}, 'ben', 'frank');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "c1"}}, {{uuid "u1"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "c2"}}, {{uuid "u2"}}, 'personal', 'SMS', {{phone "2"}});
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "u1"}}, {{uuid "c1"}}, 0),
+		({{uuid "u2"}}, {{uuid "c2"}}, 0);
+
+	insert into escalation_policies (id, name, repeat)
+	values
+		({{uuid "eid"}}, 'esc policy', 0);
+
+	-- no weekdays selected, so neither step is ever active
+	insert into escalation_policy_steps (id, escalation_policy_id, delay, step_number, active_weekdays, active_start, active_end, active_time_zone, is_active)
+	values
+		({{uuid "es1"}}, {{uuid "eid"}}, 1, 0, '{f,f,f,f,f,f,f}', '00:00', '00:00', 'UTC', false),
+		({{uuid "es2"}}, {{uuid "eid"}}, 1, 1, '{f,f,f,f,f,f,f}', '00:00', '00:00', 'UTC', false);
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "es1"}}, {{uuid "u1"}}),
+		({{uuid "es2"}}, {{uuid "u2"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (id, service_id, summary)
+	values
+		(1, {{uuid "sid"}}, 'testing');
+`
+	h := harness.NewHarness(t, sql, "ep-no-active-step")
+	defer h.Close()
+
+	tw := h.Twilio(t)
+	tw.Device(h.Phone("1")).ExpectSMS("testing")
+	tw.WaitAndAssert()
+
+	h.FastForward(time.Minute)
+	tw.Device(h.Phone("2")).ExpectSMS("testing")
+	tw.WaitAndAssert()
+
+	resp := h.GraphQLQueryT(t, fmt.Sprintf(`
+		query {
+			alert(id: %d) {
+				recentEvents(input: { limit: 15 }) {
+					nodes {
+						message
+					}
+				}
+			}
+		}
+	`, 1))
+	require.Empty(t, resp.Errors, "GraphQL errors")
+
+	var res struct {
+		Alert struct {
+			RecentEvents struct {
+				Nodes []struct {
+					Message string
+				}
+			}
+		}
+	}
+	err := json.Unmarshal(resp.Data, &res)
+	require.NoError(t, err)
+
+	var found bool
+	for _, n := range res.Alert.RecentEvents.Nodes {
+		if strings.HasPrefix(n.Message, "Escalated to step #2 (no active step)") {
+			found = true
+		}
+	}
+	assert.True(t, found, "expected escalation to step #2 to be logged with no active step")
+}
@@ -0,0 +1,78 @@
+package smoke
+
+import (
+	"testing"
+	"time"
+
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestEscalationRoundRobin ensures that a round-robin step notifies on-call users one at a time, and starts
+// over with the first user after the last one has been notified.
+func TestEscalationRoundRobin(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "u1"}}, 'bob', 'joe'),
+		({{uuid "u2"}}, 'ben', 'frank'),
+		({{uuid "u3"}}, 'beth', 'sue');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "c1"}}, {{uuid "u1"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "c2"}}, {{uuid "u2"}}, 'personal', 'SMS', {{phone "2"}}),
+		({{uuid "c3"}}, {{uuid "u3"}}, 'personal', 'SMS', {{phone "3"}});
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "u1"}}, {{uuid "c1"}}, 0),
+		({{uuid "u2"}}, {{uuid "c2"}}, 0),
+		({{uuid "u3"}}, {{uuid "c3"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id, delay, mode, round_robin_minutes)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}}, 60, 'round_robin', 1);
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "u1"}}),
+		({{uuid "esid"}}, {{uuid "u2"}}),
+		({{uuid "esid"}}, {{uuid "u3"}});
+
+	-- users are notified in the order they came on call
+	insert into ep_step_on_call_users (ep_step_id, user_id, start_time)
+	values
+		({{uuid "esid"}}, {{uuid "u1"}}, now() - '3 minutes'::interval),
+		({{uuid "esid"}}, {{uuid "u2"}}, now() - '2 minutes'::interval),
+		({{uuid "esid"}}, {{uuid "u3"}}, now() - '1 minute'::interval);
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (service_id, summary)
+	values
+		({{uuid "sid"}}, 'testing');
+`
+	h := harness.NewHarness(t, sql, "ep-round-robin-wrap")
+	defer h.Close()
+
+	tw := h.Twilio(t)
+	tw.Device(h.Phone("1")).ExpectSMS("testing")
+	tw.WaitAndAssert()
+
+	h.FastForward(time.Minute)
+	tw.Device(h.Phone("2")).ExpectSMS("testing")
+	tw.WaitAndAssert()
+
+	h.FastForward(time.Minute)
+	tw.Device(h.Phone("3")).ExpectSMS("testing")
+	tw.WaitAndAssert()
+
+	// starts over with the first user
+	h.FastForward(time.Minute)
+	tw.Device(h.Phone("1")).ExpectSMS("testing")
+	tw.WaitAndAssert()
+}
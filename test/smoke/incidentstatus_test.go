@@ -0,0 +1,149 @@
+package smoke
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestIncidentStatus ensures that acknowledging or closing an incident applies the same status to all
+// of its open alerts, and that notifications for alerts attached to an acknowledged incident are skipped
+// with the reason recorded in the alert log.
+func TestIncidentStatus(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "uid"}}, 'bob', 'joe');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "uid"}}, 'personal', 'SMS', {{phone "1"}});
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "uid"}}, {{uuid "cm1"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy'),
+		({{uuid "empty"}}, 'no steps');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "uid"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'notifying service'),
+		({{uuid "quiet"}}, {{uuid "empty"}}, 'quiet service');
+
+	insert into alerts (id, service_id, summary, status)
+	values
+		(1, {{uuid "quiet"}}, 'first', 'triggered'),
+		(2, {{uuid "quiet"}}, 'second', 'triggered'),
+		(3, {{uuid "quiet"}}, 'already closed', 'closed'),
+		(4, {{uuid "sid"}}, 'held by incident', 'triggered');
+
+	insert into incidents (id, summary, status)
+	values
+		(1, 'outage', 'triggered'),
+		(2, 'acked outage', 'active');
+	insert into incident_alerts (alert_id, incident_id)
+	values
+		(1, 1),
+		(2, 1),
+		(3, 1),
+		(4, 2);
+`
+	h := harness.NewHarness(t, sql, "incidents")
+	defer h.Close()
+
+	doQL := func(t *testing.T, query string, res interface{}) {
+		t.Helper()
+		resp := h.GraphQLQueryT(t, query)
+		require.Empty(t, resp.Errors, "GraphQL errors")
+		if res == nil {
+			return
+		}
+		err := json.Unmarshal(resp.Data, res)
+		require.NoError(t, err)
+	}
+
+	type incidentState struct {
+		Incident struct {
+			Status string
+			Alerts []struct {
+				ID     int
+				Status string
+			}
+		}
+	}
+	checkStatus := func(t *testing.T, incStatus string, alertStatus map[int]string) {
+		t.Helper()
+		var res incidentState
+		doQL(t, `query { incident(id: 1) { status, alerts { id, status } } }`, &res)
+		assert.Equal(t, incStatus, res.Incident.Status, "incident status")
+
+		got := make(map[int]string)
+		for _, a := range res.Incident.Alerts {
+			got[a.ID] = a.Status
+		}
+		assert.Equal(t, alertStatus, got, "alert status")
+	}
+
+	setStatus := func(t *testing.T, status string) {
+		t.Helper()
+		doQL(t, fmt.Sprintf(`mutation { updateIncidentStatus(input: { id: 1, newStatus: %s }) { id } }`, status), nil)
+	}
+
+	setStatus(t, "StatusAcknowledged")
+	checkStatus(t, "StatusAcknowledged", map[int]string{
+		1: "StatusAcknowledged",
+		2: "StatusAcknowledged",
+		3: "StatusClosed",
+	})
+
+	setStatus(t, "StatusClosed")
+	checkStatus(t, "StatusClosed", map[int]string{
+		1: "StatusClosed",
+		2: "StatusClosed",
+		3: "StatusClosed",
+	})
+
+	// closed incidents can't be updated
+	resp := h.GraphQLQueryT(t, `mutation { updateIncidentStatus(input: { id: 1, newStatus: StatusAcknowledged }) { id } }`)
+	assert.NotEmpty(t, resp.Errors, "expected error updating a closed incident")
+
+	// alert 4 is attached to an acknowledged incident, so no SMS is expected
+	h.Trigger()
+	h.Twilio(t).WaitAndAssert()
+
+	var logs struct {
+		Alert struct {
+			RecentEvents struct {
+				Nodes []struct {
+					Message string
+					State   struct {
+						Details string
+					}
+				}
+			}
+		}
+	}
+	doQL(t, `query { alert(id: 4) { recentEvents(input: { limit: 15 }) { nodes { message, state { details } } } } }`, &logs)
+
+	var found bool
+	for _, n := range logs.Alert.RecentEvents.Nodes {
+		if strings.Contains(n.State.Details, "incident acknowledged") {
+			found = true
+		}
+	}
+	assert.True(t, found, "expected skipped notification to be logged")
+}
@@ -0,0 +1,95 @@
+package smoke
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestMaintenanceWindowHold ensures that notifications held during a maintenance window are sent once the
+// window ends, and that alerts created during a window with the drop end action are closed and logged.
+func TestMaintenanceWindowHold(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "uid"}}, 'bob', 'joe');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "c1"}}, {{uuid "uid"}}, 'first', 'SMS', {{phone "1"}}),
+		({{uuid "c2"}}, {{uuid "uid"}}, 'second', 'SMS', {{phone "2"}});
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "uid"}}, {{uuid "c1"}}, 0),
+		({{uuid "uid"}}, {{uuid "c2"}}, 3);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy'),
+		({{uuid "empty"}}, 'no steps');
+	insert into escalation_policy_steps (id, escalation_policy_id, delay)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}}, 60);
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "uid"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'held service'),
+		({{uuid "drop"}}, {{uuid "empty"}}, 'dropped service');
+
+	insert into service_maintenance_windows (id, service_id, start_time, end_time, end_action, reason)
+	values
+		({{uuid "w1"}}, {{uuid "sid"}}, now() + '1 minute'::interval, now() + '11 minutes'::interval, 'escalate', ''),
+		({{uuid "w2"}}, {{uuid "drop"}}, now() - '1 minute'::interval, now() + '11 minutes'::interval, 'drop', 'deploy');
+
+	insert into alerts (id, service_id, summary)
+	values
+		(1, {{uuid "sid"}}, 'testing'),
+		(2, {{uuid "drop"}}, 'noise');
+`
+	h := harness.NewHarness(t, sql, "maintenance-windows")
+	defer h.Close()
+
+	tw := h.Twilio(t)
+	tw.Device(h.Phone("1")).ExpectSMS("testing")
+	tw.WaitAndAssert()
+
+	// the second notification rule is reached during the window, so it is held
+	h.FastForward(3 * time.Minute)
+	tw.WaitAndAssert()
+
+	// and sent once the window ends
+	h.FastForward(10 * time.Minute)
+	tw.Device(h.Phone("2")).ExpectSMS("testing")
+	tw.WaitAndAssert()
+
+	resp := h.GraphQLQueryT(t, `query { alert(id: 2) { status, recentEvents(input: { limit: 15 }) { nodes { message } } } }`)
+	require.Empty(t, resp.Errors, "GraphQL errors")
+
+	var res struct {
+		Alert struct {
+			Status       string
+			RecentEvents struct {
+				Nodes []struct {
+					Message string
+				}
+			}
+		}
+	}
+	err := json.Unmarshal(resp.Data, &res)
+	require.NoError(t, err)
+	assert.Equal(t, "StatusClosed", res.Alert.Status)
+
+	var msgs []string
+	for _, n := range res.Alert.RecentEvents.Nodes {
+		msgs = append(msgs, n.Message)
+	}
+	assert.Contains(t, msgs, "Closed at end of maintenance window (deploy)")
+}
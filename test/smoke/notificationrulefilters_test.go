@@ -0,0 +1,68 @@
+package smoke
+
+import (
+	"testing"
+	"time"
+
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestNotificationRuleFilters ensures that notification rules scoped to alert priorities or a service label
+// only notify for matching alerts.
+func TestNotificationRuleFilters(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "uid"}}, 'bob', 'joe');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "c1"}}, {{uuid "uid"}}, 'priority', 'SMS', {{phone "1"}}),
+		({{uuid "c2"}}, {{uuid "uid"}}, 'label', 'SMS', {{phone "2"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes, priorities)
+	values
+		({{uuid "uid"}}, {{uuid "c1"}}, 0, '{1,2}');
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes, service_label_key, service_label_value)
+	values
+		({{uuid "uid"}}, {{uuid "c2"}}, 0, 'team', 'db');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "uid"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "db"}}, {{uuid "eid"}}, 'db service'),
+		({{uuid "web"}}, {{uuid "eid"}}, 'web service');
+	insert into labels (tgt_service_id, key, value)
+	values
+		({{uuid "db"}}, 'team', 'db'),
+		({{uuid "web"}}, 'team', 'web');
+
+	insert into alerts (service_id, summary, priority)
+	values
+		({{uuid "db"}}, 'db-low', 4),
+		({{uuid "web"}}, 'web-high', 1);
+`
+
+	h := harness.NewHarness(t, sql, "notification-rule-filters")
+	defer h.Close()
+
+	tw := h.Twilio(t)
+
+	// each rule only matches one of the alerts
+	tw.Device(h.Phone("1")).ExpectSMS("web-high")
+	tw.Device(h.Phone("2")).ExpectSMS("db-low")
+
+	// past the per-CM rate limit, so a non-matching alert would have been sent by now
+	h.FastForward(5 * time.Minute)
+	tw.WaitAndAssert()
+}
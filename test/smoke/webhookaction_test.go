@@ -0,0 +1,90 @@
+package smoke
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/notification/webhook"
+	"github.com/target/goalert/test/smoke/harness"
+)
+
+// TestWebhookAction ensures that the signed action URLs included in webhook notifications can be used to
+// acknowledge an alert through the full HTTP handler chain.
+func TestWebhookAction(t *testing.T) {
+	t.Parallel()
+
+	ch := make(chan webhook.POSTDataAlert, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		var alert webhook.POSTDataAlert
+		if !assert.NoError(t, json.Unmarshal(data, &alert)) {
+			return
+		}
+		ch <- alert
+	}))
+	defer ts.Close()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'WEBHOOK', '` + ts.URL + `');
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+	h := harness.NewHarness(t, sql, "webhook-user-contact-method-type")
+	defer h.Close()
+
+	h.SetConfigValue("Webhook.ActionURLs", "true")
+	a := h.CreateAlert(h.UUID("sid"), "testing")
+
+	alert := <-ch
+	require.NotNil(t, alert.Actions, "action URLs")
+
+	// send the request to the backend, using the path and signed token of the action URL
+	u, err := url.Parse(alert.Actions.AcknowledgeURL)
+	require.NoError(t, err)
+	base, err := url.Parse(h.URL())
+	require.NoError(t, err)
+	u.Scheme, u.Host = base.Scheme, base.Host
+
+	resp, err := http.Post(u.String(), "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode, "action response")
+
+	res := h.GraphQLQuery2(fmt.Sprintf("{alert(id: %d){status}}", a.ID()))
+	require.Empty(t, res.Errors)
+	var data struct{ Alert struct{ Status string } }
+	require.NoError(t, json.Unmarshal(res.Data, &data))
+	assert.Equal(t, "StatusAcknowledged", data.Alert.Status)
+}
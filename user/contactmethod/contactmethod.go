@@ -59,6 +59,10 @@ func (c ContactMethod) Normalize(ctx context.Context, reg *nfydest.Registry) (*C
 	if err != nil {
 		return nil, err
 	}
+	c.Dest, err = reg.NormalizeDest(ctx, c.Dest)
+	if err != nil {
+		return nil, err
+	}
 
 	return &c, nil
 }
@@ -84,3 +84,14 @@ SET
 WHERE
     dest = $1;
 
+
+-- name: ContactMethodReplaceDest :one
+UPDATE
+    user_contact_methods
+SET
+    dest = @new_dest
+WHERE
+    user_id = @user_id
+    AND dest = @old_dest
+RETURNING
+    id;
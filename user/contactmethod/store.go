@@ -253,6 +253,50 @@ func (s *Store) Update(ctx context.Context, dbtx gadb.DBTX, c *ContactMethod) er
 	return err
 }
 
+// ReplaceDest will replace the destination of one of the user's contact methods, keeping its notification rules
+// and verification status. It is used when a destination is renewed on the user's behalf (e.g., a browser push
+// subscription changing), so callers must ensure newDest reaches the same recipient as oldDest. sql.ErrNoRows is
+// returned if the user has no contact method for oldDest.
+func (s *Store) ReplaceDest(ctx context.Context, dbtx gadb.DBTX, userID string, oldDest, newDest gadb.DestV1) (uuid.UUID, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.MatchUser(userID))
+	if err != nil {
+		return uuid.Nil, err
+	}
+	uid, err := validate.ParseUUID("UserID", userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if oldDest.Type != newDest.Type {
+		return uuid.Nil, validation.NewFieldError("Dest.Type", "cannot change type of contact method")
+	}
+
+	err = s.reg.ValidateDest(ctx, newDest)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	oldDest, err = s.reg.NormalizeDest(ctx, oldDest)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	newDest, err = s.reg.NormalizeDest(ctx, newDest)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	id, err := gadb.New(dbtx).ContactMethodReplaceDest(ctx, gadb.ContactMethodReplaceDestParams{
+		UserID:  uid,
+		OldDest: gadb.NullDestV1{Valid: true, DestV1: oldDest},
+		NewDest: gadb.NullDestV1{Valid: true, DestV1: newDest},
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	log.Logf(log.WithFields(ctx, log.Fields{"contactMethodID": id}), "Contact method destination replaced.")
+
+	return id, nil
+}
+
 // FindMany will fetch all contact methods matching the given ids.
 func (s *Store) FindMany(ctx context.Context, dbtx gadb.DBTX, ids []string) ([]ContactMethod, error) {
 	uids, err := validate.ParseManyUUID("ContactMethodID", ids, 50)
@@ -1,7 +1,12 @@
 package notificationrule
 
 import (
+	"slices"
+	"strconv"
+
 	"github.com/google/uuid"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -10,6 +15,15 @@ type NotificationRule struct {
 	UserID          string    `json:"-"`
 	DelayMinutes    int       `json:"delay"`
 	ContactMethodID uuid.UUID `json:"contact_method_id"`
+
+	// Priorities, if set, restricts the rule to alerts with one of the provided priorities.
+	Priorities []alert.Priority `json:"priorities,omitempty"`
+
+	// ServiceLabelKey, if set, restricts the rule to alerts from services with the label.
+	ServiceLabelKey string `json:"service_label_key,omitempty"`
+
+	// ServiceLabelValue, if set, additionally requires the service label to have the provided value.
+	ServiceLabelValue string `json:"service_label_value,omitempty"`
 }
 
 func validateDelay(d int) error {
@@ -17,7 +31,27 @@ func validateDelay(d int) error {
 }
 
 func (n NotificationRule) Normalize(update bool) (*NotificationRule, error) {
-	err := validateDelay(n.DelayMinutes)
+	err := validate.Many(
+		validateDelay(n.DelayMinutes),
+		validate.Range("Priorities", len(n.Priorities), 0, int(alert.PriorityP5)+1),
+	)
+	for i, p := range n.Priorities {
+		err = validate.Many(err, validate.Range("Priorities["+strconv.Itoa(i)+"]", int(p), int(alert.PriorityNone), int(alert.PriorityP5)))
+	}
+	if n.ServiceLabelKey != "" {
+		err = validate.Many(err, validate.LabelKey("ServiceLabelKey", n.ServiceLabelKey))
+	}
+	if n.ServiceLabelValue != "" {
+		if n.ServiceLabelKey == "" {
+			err = validate.Many(err, validation.NewFieldError("ServiceLabelValue", "requires ServiceLabelKey to be set"))
+		}
+		err = validate.Many(err, validate.LabelValue("ServiceLabelValue", n.ServiceLabelValue))
+	}
+
+	// sorted so that equivalent filters are considered duplicates
+	n.Priorities = slices.Clone(n.Priorities)
+	slices.Sort(n.Priorities)
+	n.Priorities = slices.Compact(n.Priorities)
 
 	if !update {
 		err = validate.Many(
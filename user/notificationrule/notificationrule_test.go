@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/target/goalert/alert"
 )
 
 func TestNotificationRule_Normalize(t *testing.T) {
@@ -25,9 +26,14 @@ func TestNotificationRule_Normalize(t *testing.T) {
 
 	valid := []NotificationRule{
 		{DelayMinutes: 5, ContactMethodID: uuid.MustParse("ececacc0-4764-012d-7bfb-002500d5dece"), UserID: "bcefacc0-4764-012d-7bfb-002500d5decb"},
+		{DelayMinutes: 0, Priorities: []alert.Priority{alert.PriorityP1}, UserID: "bcefacc0-4764-012d-7bfb-002500d5decb"},
+		{DelayMinutes: 0, ServiceLabelKey: "example.com/team", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb"},
+		{DelayMinutes: 0, ServiceLabelKey: "example.com/team", ServiceLabelValue: "database", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb"},
 	}
 	invalid := []NotificationRule{
 		{},
+		{Priorities: []alert.Priority{6}, UserID: "bcefacc0-4764-012d-7bfb-002500d5decb"},
+		{ServiceLabelValue: "database", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb"},
 	}
 	for _, nr := range valid {
 		test(true, nr)
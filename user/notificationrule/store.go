@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/target/goalert/alert"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/sqlutil"
@@ -27,8 +28,15 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 	p := prep.P
 	s := &Store{db: db}
 
-	s.insert = p("INSERT INTO user_notification_rules (id,user_id,delay_minutes,contact_method_id) VALUES ($1,$2,$3,$4)")
-	s.findAll = p("SELECT id,user_id,delay_minutes,contact_method_id FROM user_notification_rules WHERE user_id = $1")
+	s.insert = p(`
+		INSERT INTO user_notification_rules (id,user_id,delay_minutes,contact_method_id,priorities,service_label_key,service_label_value)
+		VALUES ($1,$2,$3,$4,nullif($5::int[], '{}'),nullif($6, ''),nullif($7, ''))
+	`)
+	s.findAll = p(`
+		SELECT id,user_id,delay_minutes,contact_method_id,priorities,coalesce(service_label_key, ''),coalesce(service_label_value, '')
+		FROM user_notification_rules
+		WHERE user_id = $1
+	`)
 	s.delete = p("DELETE FROM user_notification_rules WHERE id = any($1)")
 	s.lookupUserID = p("SELECT user_id FROM user_notification_rules WHERE id = any($1)")
 
@@ -56,7 +64,12 @@ func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, n *NotificationRule) (
 
 	n.ID = uuid.New().String()
 
-	_, err = wrapTx(ctx, tx, s.insert).ExecContext(ctx, n.ID, n.UserID, n.DelayMinutes, n.ContactMethodID)
+	prio := make(sqlutil.IntArray, len(n.Priorities))
+	for i, p := range n.Priorities {
+		prio[i] = int(p)
+	}
+
+	_, err = wrapTx(ctx, tx, s.insert).ExecContext(ctx, n.ID, n.UserID, n.DelayMinutes, n.ContactMethodID, prio, n.ServiceLabelKey, n.ServiceLabelValue)
 	if err != nil {
 		return nil, err
 	}
@@ -138,10 +151,14 @@ func (s *Store) FindAll(ctx context.Context, userID string) ([]NotificationRule,
 	notificationrules := []NotificationRule{}
 	for rows.Next() {
 		var n NotificationRule
-		err = rows.Scan(&n.ID, &n.UserID, &n.DelayMinutes, &n.ContactMethodID)
+		var prio sqlutil.IntArray
+		err = rows.Scan(&n.ID, &n.UserID, &n.DelayMinutes, &n.ContactMethodID, &prio, &n.ServiceLabelKey, &n.ServiceLabelValue)
 		if err != nil {
 			return nil, err
 		}
+		for _, p := range prio {
+			n.Priorities = append(n.Priorities, alert.Priority(p))
+		}
 		notificationrules = append(notificationrules, n)
 	}
 
@@ -41,6 +41,10 @@ func MapDBError(err error) error {
 			return validation.NewFieldError("UserID", "user does not exist")
 		case "auth_basic_users_user_id_fkey":
 			return validation.NewFieldError("UserID", "user does not exist")
+		case "incident_alerts_alert_id_fkey":
+			return validation.NewFieldError("AlertIDs", "alert does not exist")
+		case "incident_alerts_incident_id_fkey":
+			return validation.NewFieldError("IncidentID", "incident does not exist")
 		}
 	case "23505": // unique constraint
 		if dbErr.ConstraintName == "idx_int_key_name_svc_ext" {
@@ -0,0 +1,146 @@
+package icalutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// MaxFileSize is the maximum size of an iCalendar file that will be fetched.
+const MaxFileSize = 5 * 1024 * 1024
+
+var (
+	// ErrTooLarge is returned by Fetch if the file is larger than MaxFileSize.
+	ErrTooLarge = errors.New("calendar file is too large")
+
+	// ErrAddressNotAllowed is returned by clients from NewPublicClient when connecting to a non-public address.
+	ErrAddressNotAllowed = errors.New("address is not allowed")
+)
+
+// NewPublicClient returns an HTTP client that will only connect to public addresses. Connections
+// (including redirects) to any other address (see IsPublicAddr) are rejected with ErrAddressNotAllowed.
+//
+// It should be used to fetch URLs provided by users.
+func NewPublicClient() *http.Client {
+	d := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addr, err := netip.ParseAddrPort(address)
+			if err != nil || !IsPublicAddr(addr.Addr()) {
+				return ErrAddressNotAllowed
+			}
+			return nil
+		},
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would make the connection on our behalf, bypassing the address check
+	t.Proxy = nil
+	t.DialContext = d.DialContext
+
+	return &http.Client{Transport: t}
+}
+
+// nonPublicPrefixes are the address ranges that are not reachable on the public internet, or that may
+// be translated to one that isn't (e.g., NAT64 and 6to4).
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this" network
+	netip.MustParsePrefix("10.0.0.0/8"),      // private
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT
+	netip.MustParsePrefix("127.0.0.0/8"),     // loopback
+	netip.MustParsePrefix("169.254.0.0/16"),  // link-local
+	netip.MustParsePrefix("172.16.0.0/12"),   // private
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("192.88.99.0/24"),  // 6to4 relay anycast
+	netip.MustParsePrefix("192.168.0.0/16"),  // private
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("224.0.0.0/4"),     // multicast
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved, including broadcast
+
+	netip.MustParsePrefix("::/128"),         // unspecified
+	netip.MustParsePrefix("::1/128"),        // loopback
+	netip.MustParsePrefix("::/96"),          // IPv4-compatible (deprecated)
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64
+	netip.MustParsePrefix("64:ff9b:1::/48"), // local-use NAT64
+	netip.MustParsePrefix("100::/64"),       // discard-only
+	netip.MustParsePrefix("2001::/32"),      // Teredo
+	netip.MustParsePrefix("2001:db8::/32"),  // documentation
+	netip.MustParsePrefix("2002::/16"),      // 6to4
+	netip.MustParsePrefix("fc00::/7"),       // unique local
+	netip.MustParsePrefix("fe80::/10"),      // link-local
+	netip.MustParsePrefix("fec0::/10"),      // site-local (deprecated)
+	netip.MustParsePrefix("ff00::/8"),       // multicast
+}
+
+// IsPublicAddr returns true if addr is a public unicast address. IPv4-mapped IPv6 addresses are
+// checked as the IPv4 address they contain.
+func IsPublicAddr(addr netip.Addr) bool {
+	if !addr.IsValid() {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Fetch will download an iCalendar file from the given URL.
+func Fetch(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/calendar")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxFileSize {
+		return nil, ErrTooLarge
+	}
+
+	return data, nil
+}
+
+// FetchErrorMessage returns a description of an error from Fetch that is safe to show to users. Network
+// errors are replaced with a generic message, so details of the network (e.g., addresses) are not exposed.
+func FetchErrorMessage(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, ErrAddressNotAllowed):
+		return "address is not allowed"
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "request timed out"
+	case errors.As(err, &netErr):
+		return "request failed"
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,77 @@
+package icalutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/cal.ics":
+			_, _ = w.Write([]byte(testCal))
+		case "/large.ics":
+			_, _ = w.Write([]byte(strings.Repeat("x", MaxFileSize+1)))
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer srv.Close()
+
+	data, err := Fetch(context.Background(), srv.Client(), srv.URL+"/cal.ics")
+	require.NoError(t, err)
+	assert.Equal(t, testCal, string(data))
+
+	_, err = Fetch(context.Background(), srv.Client(), srv.URL+"/large.ics")
+	assert.ErrorIs(t, err, ErrTooLarge)
+
+	_, err = Fetch(context.Background(), srv.Client(), srv.URL+"/missing.ics")
+	assert.EqualError(t, err, "unexpected status 404 Not Found")
+
+	// the test server is on a loopback address
+	_, err = Fetch(context.Background(), NewPublicClient(), srv.URL+"/cal.ics")
+	assert.ErrorIs(t, err, ErrAddressNotAllowed)
+	assert.Equal(t, "address is not allowed", FetchErrorMessage(err))
+
+	_, err = Fetch(context.Background(), srv.Client(), "http://"+srv.Listener.Addr().String()+"0/cal.ics")
+	require.Error(t, err)
+	assert.Equal(t, "request failed", FetchErrorMessage(err))
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	check := func(addr string, expected bool) {
+		t.Helper()
+		assert.Equal(t, expected, IsPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+
+	check("8.8.8.8", true)
+	check("2001:4860:4860::8888", true)
+	check("::ffff:8.8.8.8", true)
+
+	check("127.0.0.1", false)
+	check("::1", false)
+	check("10.1.2.3", false)
+	check("172.16.0.1", false)
+	check("192.168.0.1", false)
+	check("169.254.169.254", false)
+	check("100.64.0.1", false)
+	check("0.1.2.3", false)
+	check("198.18.0.1", false)
+	check("255.255.255.255", false)
+	check("fe80::1", false)
+	check("fd00::1", false)
+	check("::", false)
+	check("::ffff:127.0.0.1", false)
+	check("::ffff:169.254.169.254", false)
+	check("64:ff9b::a9fe:a9fe", false)
+	check("2002:7f00:1::", false)
+
+	assert.False(t, IsPublicAddr(netip.Addr{}), "zero value")
+}
@@ -0,0 +1,240 @@
+// Package icalutil provides minimal parsing of iCalendar (RFC 5545) files for importing events.
+package icalutil
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/util"
+)
+
+// MaxEvents is the maximum number of events that will be read from a single file.
+const MaxEvents = 10000
+
+// An Event is a VEVENT component of an iCalendar file.
+type Event struct {
+	UID     string
+	Summary string
+
+	// Start and End are the time range of the event. End is exclusive.
+	Start, End time.Time
+
+	// AllDay is true if the event was specified as dates rather than times. Start and End will be midnight UTC.
+	AllDay bool
+
+	// RRule is the recurrence rule of the event, if any.
+	RRule string
+
+	// BusyStatus is the value of the X-MICROSOFT-CDO-BUSYSTATUS property (e.g., FREE, BUSY, or OOF), if set.
+	BusyStatus string
+}
+
+type property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Parse will read all events from an iCalendar file. Times without a time zone (floating times) are
+// interpreted as UTC.
+func Parse(r io.Reader) ([]Event, error) {
+	props, err := readProperties(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var cur *Event
+	var hasEnd bool
+	var dur time.Duration
+	var depth int
+	for _, p := range props {
+		switch p.Name {
+		case "BEGIN":
+			if strings.EqualFold(p.Value, "VEVENT") {
+				if cur != nil {
+					return nil, errors.New("nested VEVENT")
+				}
+				cur = &Event{}
+				hasEnd = false
+				dur = 0
+				continue
+			}
+			if cur != nil {
+				// skip properties of nested components (e.g., VALARM)
+				depth++
+			}
+			continue
+		case "END":
+			if cur == nil {
+				continue
+			}
+			if depth > 0 {
+				depth--
+				continue
+			}
+			if !strings.EqualFold(p.Value, "VEVENT") {
+				return nil, fmt.Errorf("unexpected END:%s", p.Value)
+			}
+			if cur.Start.IsZero() {
+				return nil, fmt.Errorf("event '%s' is missing DTSTART", cur.UID)
+			}
+			if !hasEnd {
+				switch {
+				case dur > 0:
+					cur.End = cur.Start.Add(dur)
+				case cur.AllDay:
+					cur.End = cur.Start.AddDate(0, 0, 1)
+				default:
+					cur.End = cur.Start
+				}
+			}
+			if cur.End.Before(cur.Start) {
+				return nil, fmt.Errorf("event '%s' ends before it starts", cur.UID)
+			}
+			if len(events) >= MaxEvents {
+				return nil, fmt.Errorf("too many events (max %d)", MaxEvents)
+			}
+			events = append(events, *cur)
+			cur = nil
+			continue
+		}
+		if cur == nil || depth > 0 {
+			continue
+		}
+
+		switch p.Name {
+		case "UID":
+			cur.UID = p.Value
+		case "SUMMARY":
+			cur.Summary = unescapeText(p.Value)
+		case "RRULE":
+			cur.RRule = p.Value
+		case "X-MICROSOFT-CDO-BUSYSTATUS":
+			cur.BusyStatus = strings.ToUpper(p.Value)
+		case "DTSTART":
+			cur.Start, cur.AllDay, err = parseTime(p)
+			if err != nil {
+				return nil, fmt.Errorf("parse DTSTART: %w", err)
+			}
+		case "DTEND":
+			cur.End, _, err = parseTime(p)
+			if err != nil {
+				return nil, fmt.Errorf("parse DTEND: %w", err)
+			}
+			hasEnd = true
+		case "DURATION":
+			dur, err = ParseDuration(p.Value)
+			if err != nil {
+				return nil, fmt.Errorf("parse DURATION: %w", err)
+			}
+		}
+	}
+	if cur != nil {
+		return nil, errors.New("unterminated VEVENT")
+	}
+
+	return events, nil
+}
+
+// readProperties will read and unfold all content lines.
+func readProperties(r io.Reader) ([]property, error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, errors.New("not an iCalendar file")
+	}
+
+	props := make([]property, 0, len(lines))
+	for i, line := range lines {
+		p, err := parseProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		props = append(props, p)
+	}
+
+	return props, nil
+}
+
+func parseProperty(line string) (property, error) {
+	// find the value separator, ignoring colons in quoted parameter values
+	var quoted bool
+	sep := -1
+	for i, c := range line {
+		if c == '"' {
+			quoted = !quoted
+		}
+		if c == ':' && !quoted {
+			sep = i
+			break
+		}
+	}
+	if sep == -1 {
+		return property{}, errors.New("missing ':'")
+	}
+
+	parts := strings.Split(line[:sep], ";")
+	p := property{
+		Name:  strings.ToUpper(parts[0]),
+		Value: line[sep+1:],
+	}
+	for _, param := range parts[1:] {
+		name, val, ok := strings.Cut(param, "=")
+		if !ok {
+			continue
+		}
+		if p.Params == nil {
+			p.Params = make(map[string]string)
+		}
+		p.Params[strings.ToUpper(name)] = strings.Trim(val, `"`)
+	}
+
+	return p, nil
+}
+
+func parseTime(p property) (t time.Time, isDate bool, err error) {
+	if strings.EqualFold(p.Params["VALUE"], "DATE") || len(p.Value) == 8 {
+		t, err = time.Parse("20060102", p.Value)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(p.Value, "Z") {
+		t, err = time.Parse("20060102T150405Z", p.Value)
+		return t, false, err
+	}
+
+	loc := time.UTC
+	if tzid := p.Params["TZID"]; tzid != "" {
+		loc, err = util.LoadLocation(tzid)
+		if err != nil {
+			return t, false, fmt.Errorf("unknown time zone '%s'", tzid)
+		}
+	}
+	t, err = time.ParseInLocation("20060102T150405", p.Value, loc)
+	return t, false, err
+}
+
+var textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, `;`, `\,`, `,`, `\n`, "\n", `\N`, "\n")
+
+func unescapeText(s string) string { return textUnescaper.Replace(s) }
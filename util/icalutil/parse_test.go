@@ -0,0 +1,102 @@
+package icalutil
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCal = `BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VEVENT
+UID:new-year
+DTSTART;VALUE=DATE:20240101
+SUMMARY:New Year's
+ Day
+RRULE:FREQ=YEARLY
+END:VEVENT
+BEGIN:VEVENT
+UID:pto
+DTSTART;TZID=America/Chicago:20240304T090000
+DURATION:PT8H
+SUMMARY:Out of office\, back Tuesday
+X-MICROSOFT-CDO-BUSYSTATUS:oof
+BEGIN:VALARM
+TRIGGER:-PT15M
+END:VALARM
+END:VEVENT
+BEGIN:VEVENT
+UID:standup
+DTSTART:20240304T150000Z
+DTEND:20240304T151500Z
+RRULE:FREQ=WEEKLY;BYDAY=MO,WE;COUNT=3
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestParse(t *testing.T) {
+	events, err := Parse(strings.NewReader(strings.ReplaceAll(testCal, "\n", "\r\n")))
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, Event{
+		UID:     "new-year",
+		Summary: "New Year'sDay",
+		Start:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		AllDay:  true,
+		RRule:   "FREQ=YEARLY",
+	}, events[0])
+
+	chi, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+	assert.Equal(t, "Out of office, back Tuesday", events[1].Summary)
+	assert.True(t, events[1].Start.Equal(time.Date(2024, 3, 4, 9, 0, 0, 0, chi)))
+	assert.Equal(t, 8*time.Hour, events[1].End.Sub(events[1].Start))
+	assert.Equal(t, "OOF", events[1].BusyStatus)
+
+	_, err = Parse(strings.NewReader("hello"))
+	assert.Error(t, err)
+}
+
+func TestEvent_Expand(t *testing.T) {
+	events, err := Parse(strings.NewReader(testCal))
+	require.NoError(t, err)
+
+	occ, err := events[0].Expand(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, occ, 3)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), occ[2].Start)
+	assert.Equal(t, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), occ[2].End)
+
+	occ, err = events[2].Expand(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	var starts []time.Time
+	for _, o := range occ {
+		starts = append(starts, o.Start)
+	}
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 3, 4, 15, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 6, 15, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 11, 15, 0, 0, 0, time.UTC),
+	}, starts)
+
+	_, err = Event{Start: time.Now(), RRule: "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH"}.Expand(time.Now().AddDate(1, 0, 0))
+	assert.ErrorIs(t, err, ErrUnsupportedRRule)
+}
+
+func TestParseDuration(t *testing.T) {
+	d, err := ParseDuration("P1DT2H30M")
+	require.NoError(t, err)
+	assert.Equal(t, 26*time.Hour+30*time.Minute, d)
+
+	d, err = ParseDuration("P2W")
+	require.NoError(t, err)
+	assert.Equal(t, 14*24*time.Hour, d)
+
+	_, err = ParseDuration("PT")
+	assert.Error(t, err)
+}
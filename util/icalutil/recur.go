@@ -0,0 +1,209 @@
+package icalutil
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxOccurrences is the maximum number of occurrences Expand will return for a single event.
+const MaxOccurrences = 1000
+
+// ErrUnsupportedRRule is returned when a recurrence rule uses features that are not supported.
+var ErrUnsupportedRRule = errors.New("unsupported recurrence rule")
+
+var durRx = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// ParseDuration will parse an iCalendar duration value (e.g., P1DT2H).
+func ParseDuration(s string) (time.Duration, error) {
+	m := durRx.FindStringSubmatch(s)
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("invalid duration '%s'", s)
+	}
+
+	var dur time.Duration
+	for i, unit := range []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return 0, err
+		}
+		dur += time.Duration(n) * unit
+	}
+	if m[1] == "-" {
+		dur = -dur
+	}
+
+	return dur, nil
+}
+
+type rrule struct {
+	Freq     string
+	Interval int
+	Count    int
+	Until    time.Time
+	ByDay    []time.Weekday
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+func parseRRule(s string, loc *time.Location) (*rrule, error) {
+	r := rrule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		name, val, _ := strings.Cut(part, "=")
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			r.Freq = strings.ToUpper(val)
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(val)
+			if err == nil && r.Interval < 1 {
+				err = errors.New("must be positive")
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(val)
+		case "UNTIL":
+			p := property{Value: val}
+			if !strings.HasSuffix(val, "Z") && loc != time.UTC {
+				p.Params = map[string]string{"TZID": loc.String()}
+			}
+			r.Until, _, err = parseTime(p)
+		case "BYDAY":
+			for _, d := range strings.Split(val, ",") {
+				wd, ok := weekdays[strings.ToUpper(d)]
+				if !ok {
+					return nil, fmt.Errorf("%w: BYDAY=%s", ErrUnsupportedRRule, val)
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		case "WKST":
+			// only affects weekly rules with an interval, which is approximated
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedRRule, name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+
+	switch r.Freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+	default:
+		return nil, fmt.Errorf("%w: FREQ=%s", ErrUnsupportedRRule, r.Freq)
+	}
+	if len(r.ByDay) > 0 && r.Freq != "WEEKLY" {
+		return nil, fmt.Errorf("%w: BYDAY with FREQ=%s", ErrUnsupportedRRule, r.Freq)
+	}
+
+	return &r, nil
+}
+
+// Expand will return each occurrence of the event that starts before the provided time. Events without
+// a recurrence rule are returned as-is. Only FREQ, INTERVAL, COUNT, UNTIL, and weekly BYDAY rule parts
+// are supported; ErrUnsupportedRRule is returned for anything else.
+func (e Event) Expand(before time.Time) ([]Event, error) {
+	if e.RRule == "" {
+		if !e.Start.Before(before) {
+			return nil, nil
+		}
+		return []Event{e}, nil
+	}
+
+	r, err := parseRRule(e.RRule, e.Start.Location())
+	if err != nil {
+		return nil, err
+	}
+
+	dur := e.End.Sub(e.Start)
+	var result []Event
+	add := func(start time.Time) bool {
+		if !start.Before(before) || (!r.Until.IsZero() && start.After(r.Until)) {
+			return false
+		}
+		if r.Count > 0 && len(result) >= r.Count {
+			return false
+		}
+		if len(result) >= MaxOccurrences {
+			return false
+		}
+		occ := e
+		occ.RRule = ""
+		occ.Start = start
+		occ.End = start.Add(dur)
+		result = append(result, occ)
+		return true
+	}
+
+	y, m, d := e.Start.Date()
+	hh, mm, ss := e.Start.Clock()
+	loc := e.Start.Location()
+	for i := 0; i < MaxOccurrences*100; i++ {
+		n := i * r.Interval
+		switch r.Freq {
+		case "DAILY":
+			if !add(time.Date(y, m, d+n, hh, mm, ss, 0, loc)) {
+				return result, nil
+			}
+		case "MONTHLY":
+			t := time.Date(y, m+time.Month(n), d, hh, mm, ss, 0, loc)
+			if t.Day() != d {
+				// skip months without the day
+				continue
+			}
+			if !add(t) {
+				return result, nil
+			}
+		case "YEARLY":
+			t := time.Date(y+n, m, d, hh, mm, ss, 0, loc)
+			if t.Month() != m {
+				// skip Feb 29th on non-leap years
+				continue
+			}
+			if !add(t) {
+				return result, nil
+			}
+		case "WEEKLY":
+			weekStart := time.Date(y, m, d-int(e.Start.Weekday())+7*n, hh, mm, ss, 0, loc)
+			days := r.ByDay
+			if len(days) == 0 {
+				days = []time.Weekday{e.Start.Weekday()}
+			}
+			for wd := time.Sunday; wd <= time.Saturday; wd++ {
+				if !containsWeekday(days, wd) {
+					continue
+				}
+				t := weekStart.AddDate(0, 0, int(wd))
+				if t.Before(e.Start) {
+					continue
+				}
+				if !add(t) {
+					return result, nil
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func containsWeekday(days []time.Weekday, wd time.Weekday) bool {
+	for _, d := range days {
+		if d == wd {
+			return true
+		}
+	}
+	return false
+}
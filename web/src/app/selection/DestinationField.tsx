@@ -3,6 +3,7 @@ import { DestinationType, StringMap } from '../../schema'
 import DestinationInputDirect from './DestinationInputDirect'
 import { useDestinationType } from '../util/RequireConfig'
 import DestinationSearchSelect from './DestinationSearchSelect'
+import DestinationInputWebPush from './DestinationInputWebPush'
 import { Grid } from '@mui/material'
 import { DestFieldValueError } from '../util/errtypes'
 
@@ -71,6 +72,19 @@ export default function DestinationField(
             </Grid>
           )
 
+        if (field.inputType === 'webpush')
+          return (
+            <Grid key={field.fieldID} item xs={12} sm={12} md={12}>
+              <DestinationInputWebPush
+                {...field}
+                value={fieldValue}
+                disabled={props.disabled || !dest.enabled}
+                onChange={(val) => handleChange(val)}
+                error={fieldErrMsg}
+              />
+            </Grid>
+          )
+
         return (
           <Grid key={field.fieldID} item xs={12} sm={12} md={12}>
             <DestinationInputDirect
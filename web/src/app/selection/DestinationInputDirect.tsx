@@ -122,7 +122,9 @@ export default function DestinationInputDirect(
       name={props.fieldID}
       disabled={props.disabled}
       InputProps={iprops}
-      type={props.inputType}
+      multiline={props.inputType === 'textarea'}
+      minRows={props.inputType === 'textarea' ? 3 : undefined}
+      type={props.inputType === 'textarea' ? undefined : props.inputType}
       placeholder={props.placeholderText}
       label={props.label}
       helperText={
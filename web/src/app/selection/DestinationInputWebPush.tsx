@@ -0,0 +1,111 @@
+import React, { useState } from 'react'
+import { Button, FormControl, FormHelperText, FormLabel } from '@mui/material'
+import { Check } from '@mui/icons-material'
+import { DestinationFieldConfig } from '../../schema'
+import { HelperText } from '../forms'
+import { pathPrefix } from '../env'
+
+export type DestinationInputWebPushProps = Partial<DestinationFieldConfig> & {
+  value: string
+  onChange?: (value: string) => void
+
+  disabled?: boolean
+  error?: string
+}
+
+const apiBase = pathPrefix + '/api/v2/webpush/'
+
+// decodeKey converts an unpadded base64url key to bytes.
+function decodeKey(key: string): Uint8Array {
+  const b64 = key.replace(/-/g, '+').replace(/_/g, '/')
+  const raw = atob(b64 + '='.repeat((4 - (b64.length % 4)) % 4))
+  return Uint8Array.from(raw, (c) => c.charCodeAt(0))
+}
+
+function activated(reg: ServiceWorkerRegistration): Promise<void> {
+  const sw = reg.installing || reg.waiting
+  if (reg.active || !sw) return Promise.resolve()
+
+  return new Promise((resolve) => {
+    sw.addEventListener('statechange', () => {
+      if (sw.state === 'activated') resolve()
+    })
+  })
+}
+
+// subscribe registers the push service worker and returns the JSON encoded
+// push subscription of this browser.
+async function subscribe(): Promise<string> {
+  if (!('serviceWorker' in navigator) || !('PushManager' in window)) {
+    throw new Error('Push notifications are not supported by this browser.')
+  }
+
+  const perm = await Notification.requestPermission()
+  if (perm !== 'granted') {
+    throw new Error('Notification permission was not granted.')
+  }
+
+  const res = await fetch(apiBase + 'key')
+  if (!res.ok) throw new Error('Failed to fetch push key: ' + res.statusText)
+  const key = await res.text()
+
+  const reg = await navigator.serviceWorker.register(apiBase + 'sw.js', {
+    scope: apiBase,
+  })
+  await activated(reg)
+
+  const sub =
+    (await reg.pushManager.getSubscription()) ||
+    (await reg.pushManager.subscribe({
+      userVisibleOnly: true,
+      applicationServerKey: decodeKey(key),
+    }))
+
+  return JSON.stringify(sub.toJSON())
+}
+
+/**
+ * DestinationInputWebPush subscribes the current browser to push
+ * notifications, the resulting subscription is the field value.
+ *
+ * You should almost never use this component directly. Instead, use
+ * DestinationField, which will select the correct component based on the
+ * destination type.
+ */
+export default function DestinationInputWebPush(
+  props: DestinationInputWebPushProps,
+): JSX.Element {
+  const [loading, setLoading] = useState(false)
+  const [subErr, setSubErr] = useState('')
+
+  function handleClick(): void {
+    setLoading(true)
+    setSubErr('')
+    subscribe()
+      .then((value) => props.onChange?.(value))
+      .catch((err) => setSubErr(err.message))
+      .finally(() => setLoading(false))
+  }
+
+  return (
+    <FormControl fullWidth error={!!(props.error || subErr)}>
+      <FormLabel>{props.label}</FormLabel>
+      <Button
+        variant='outlined'
+        sx={{ mt: 1, alignSelf: 'flex-start' }}
+        disabled={props.disabled || loading}
+        onClick={handleClick}
+        startIcon={props.value ? <Check /> : undefined}
+      >
+        {props.value ? 'Notifications Enabled' : 'Enable Notifications'}
+      </Button>
+      <FormHelperText>
+        <HelperText
+          error={props.error || subErr}
+          hint={props.hint}
+          hintURL={props.hintURL}
+        />
+      </FormHelperText>
+    </FormControl>
+  )
+}
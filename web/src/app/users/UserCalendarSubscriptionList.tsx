@@ -23,10 +23,19 @@ export const calendarSubscriptionsQuery = gql`
         id
         name
         reminderMinutes
+        type
         scheduleID
         schedule {
           name
         }
+        rotationID
+        rotation {
+          name
+        }
+        serviceID
+        service {
+          name
+        }
         lastAccess
         disabled
       }
@@ -34,6 +43,32 @@ export const calendarSubscriptionsQuery = gql`
   }
 `
 
+// subTarget returns the name and URL of the schedule, rotation, or service of a subscription.
+function subTarget(sub: UserCalendarSubscription): {
+  name: string
+  url: string | null
+} {
+  switch (sub.type) {
+    case 'rotation':
+      return {
+        name: sub.rotation?.name ?? '',
+        url: `/rotations/${sub.rotationID}`,
+      }
+    case 'serviceAlerts':
+      return {
+        name: sub.service?.name ?? '',
+        url: `/services/${sub.serviceID}`,
+      }
+    case 'userSchedules':
+      return { name: 'All My Schedules', url: null }
+  }
+
+  return {
+    name: sub.schedule?.name ?? '',
+    url: `/schedules/${sub.scheduleID}`,
+  }
+}
+
 export default function UserCalendarSubscriptionList(props: {
   userID: string
 }): JSX.Element {
@@ -59,12 +94,12 @@ export default function UserCalendarSubscriptionList(props: {
   if (error) return <GenericError error={error.message} />
   if (!_.get(data, 'user.id')) return <ObjectNotFound />
 
-  // sort by schedule names, then subscription names
+  // sort by target names, then subscription names
   const subs: UserCalendarSubscription[] = data.user.calendarSubscriptions
     .slice()
     .sort((a: UserCalendarSubscription, b: UserCalendarSubscription) => {
-      if ((a?.schedule?.name ?? '') < (b?.schedule?.name ?? '')) return -1
-      if ((a?.schedule?.name ?? '') > (b?.schedule?.name ?? '')) return 1
+      if (subTarget(a).name < subTarget(b).name) return -1
+      if (subTarget(a).name > subTarget(b).name) return 1
 
       if (a.name > b.name) return 1
       if (a.name < b.name) return -1
@@ -90,19 +125,21 @@ export default function UserCalendarSubscriptionList(props: {
     )
   }
 
-  // push schedule names as subheaders now that the array is sorted
+  // push target names as subheaders now that the array is sorted
   subs.forEach((sub: UserCalendarSubscription) => {
-    if (!subheaderDict[sub?.schedule?.name ?? '']) {
-      subheaderDict[sub?.schedule?.name ?? ''] = true
+    const target = subTarget(sub)
+    if (!subheaderDict[target.name]) {
+      subheaderDict[target.name] = true
       items.push(
-        <CompListItemNav
-          subText={sub.schedule?.name}
-          url={`/schedules/${sub.scheduleID}`}
-        />,
+        target.url ? (
+          <CompListItemNav subText={target.name} url={target.url} />
+        ) : (
+          <CompListItemText subText={target.name} />
+        ),
       )
     }
 
-    // push subscriptions under relevant schedule subheaders
+    // push subscriptions under relevant subheaders
     items.push(
       <CompListItemText
         title={sub.name}
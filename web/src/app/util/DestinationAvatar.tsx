@@ -8,6 +8,8 @@ import {
   Today as ScheduleIcon,
   Webhook as WebhookIcon,
   Email,
+  Groups as TeamsIcon,
+  NotificationsActive as WebPushIcon,
 } from '@mui/icons-material'
 
 const builtInIcons: { [key: string]: React.ReactNode } = {
@@ -16,6 +18,8 @@ const builtInIcons: { [key: string]: React.ReactNode } = {
   'builtin://schedule': <ScheduleIcon />,
   'builtin://webhook': <WebhookIcon />,
   'builtin://email': <Email />,
+  'builtin://msteams': <TeamsIcon />,
+  'builtin://webpush': <WebPushIcon />,
 }
 
 export type DestinationAvatarProps = {
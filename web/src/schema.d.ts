@@ -14,15 +14,19 @@ export interface Alert {
   alertID: number
   createdAt: ISOTimestamp
   details: string
+  flappingUntil?: null | ISOTimestamp
   id: string
+  incident?: null | Incident
   meta?: null | AlertMetadata[]
   metaValue: string
   metrics?: null | AlertMetric
   noiseReason?: null | string
   pendingNotifications: AlertPendingNotification[]
+  priority: number
   recentEvents: AlertLogEntryConnection
   service?: null | Service
   serviceID: string
+  snoozedUntil?: null | ISOTimestamp
   state?: null | AlertState
   status: AlertStatus
   summary: string
@@ -89,6 +93,7 @@ export interface AlertSearchOptions {
   closedBefore?: null | ISOTimestamp
   createdBefore?: null | ISOTimestamp
   favoritesOnly?: null | boolean
+  filterByPriority?: null | number[]
   filterByServiceID?: null | string[]
   filterByStatus?: null | AlertStatus[]
   first?: null | number
@@ -126,6 +131,12 @@ export interface AlertsByStatus {
   unacked: number
 }
 
+export interface ApplyTemporaryScheduleTemplateInput {
+  id: string
+  scheduleID: string
+  start: ISOTimestamp
+}
+
 export interface AuthSubject {
   providerID: string
   subjectID: string
@@ -154,6 +165,12 @@ export interface CalcRotationHandoffTimesInput {
   timeZone: string
 }
 
+export type CalendarSubscriptionType =
+  | 'rotation'
+  | 'schedule'
+  | 'serviceAlerts'
+  | 'userSchedules'
+
 export interface Clause {
   field: ExprIdentifier
   negate: boolean
@@ -227,6 +244,7 @@ export interface CreateAlertInput {
   dedup?: null | string
   details?: null | string
   meta?: null | AlertMetadataInput[]
+  priority?: null | number
   sanitize?: null | boolean
   serviceID: string
   summary: string
@@ -247,11 +265,15 @@ export interface CreateEscalationPolicyInput {
 }
 
 export interface CreateEscalationPolicyStepInput {
+  ackCount?: null | number
   actions?: null | DestinationInput[]
+  activeHours?: null | StepActiveHoursInput
   delayMinutes: number
   escalationPolicyID?: null | string
+  mode?: null | EscalationStepMode
   newRotation?: null | CreateRotationInput
   newSchedule?: null | CreateScheduleInput
+  roundRobinMinutes?: null | number
   targets?: null | TargetInput[]
 }
 
@@ -260,6 +282,7 @@ export interface CreateGQLAPIKeyInput {
   expiresAt: ISOTimestamp
   name: string
   query: string
+  rest?: null | boolean
   role: UserRole
 }
 
@@ -271,6 +294,18 @@ export interface CreateHeartbeatMonitorInput {
   timeoutMinutes: number
 }
 
+export interface CreateHolidayCalendarInput {
+  ics?: null | string
+  name: string
+  sourceURL?: null | string
+}
+
+export interface CreateIncidentInput {
+  alertIDs?: null | number[]
+  details?: null | string
+  summary: string
+}
+
 export interface CreateIntegrationKeyInput {
   externalSystemName?: null | string
   name: string
@@ -278,10 +313,34 @@ export interface CreateIntegrationKeyInput {
   type: IntegrationKeyType
 }
 
+export interface CreateMaintenanceWindowInput {
+  end: ISOTimestamp
+  endAction?: null | MaintenanceWindowEndAction
+  labelKey?: null | string
+  labelValue?: null | string
+  reason?: null | string
+  repeat?: null | MaintenanceWindowRepeat
+  serviceID?: null | string
+  start: ISOTimestamp
+  timeZone?: null | string
+}
+
+export interface CreateOverrideRequestInput {
+  addUserID?: null | string
+  end: ISOTimestamp
+  removeUserID?: null | string
+  scheduleID: string
+  start: ISOTimestamp
+  targetUserID: string
+}
+
 export interface CreateRotationInput {
+  activeCount?: null | number
   description?: null | string
   favorite?: null | boolean
   name: string
+  participantWindows?: null | RotationParticipantWindowInput[]
+  shadows?: null | boolean[]
   shiftLength?: null | number
   start: ISOTimestamp
   timeZone: string
@@ -302,11 +361,23 @@ export interface CreateServiceInput {
   description?: null | string
   escalationPolicyID?: null | string
   favorite?: null | boolean
+  flapThreshold?: null | number
+  flapWindowMinutes?: null | number
   labels?: null | SetLabelInput[]
   name: string
   newEscalationPolicy?: null | CreateEscalationPolicyInput
   newHeartbeatMonitors?: null | CreateHeartbeatMonitorInput[]
   newIntegrationKeys?: null | CreateIntegrationKeyInput[]
+  reopenWindowMinutes?: null | number
+}
+
+export interface CreateUserAvailabilityFeedInput {
+  keyword?: null | string
+  name: string
+  substituteUserID?: null | string
+  timeZone: string
+  url: string
+  userID: string
 }
 
 export interface CreateUserCalendarSubscriptionInput {
@@ -314,7 +385,9 @@ export interface CreateUserCalendarSubscriptionInput {
   fullSchedule?: null | boolean
   name: string
   reminderMinutes?: null | number[]
-  scheduleID: string
+  rotationID?: null | string
+  scheduleID?: null | string
+  serviceID?: null | string
 }
 
 export interface CreateUserContactMethodInput {
@@ -339,6 +412,9 @@ export interface CreateUserInput {
 export interface CreateUserNotificationRuleInput {
   contactMethodID?: null | string
   delayMinutes: number
+  priorities?: null | number[]
+  serviceLabelKey?: null | string
+  serviceLabelValue?: null | string
   userID?: null | string
 }
 
@@ -410,6 +486,11 @@ export interface DebugSendSMSInput {
   to: string
 }
 
+export interface DeleteTemporaryScheduleTemplateInput {
+  id: string
+  scheduleID: string
+}
+
 export interface Destination {
   args: StringMap
   displayInfo: InlineDisplayInfo
@@ -520,14 +601,20 @@ export interface EscalationPolicySearchOptions {
 }
 
 export interface EscalationPolicyStep {
+  ackCount: number
   actions: Destination[]
+  activeHours?: null | StepActiveHours
   delayMinutes: number
   escalationPolicy?: null | EscalationPolicy
   id: string
+  mode: EscalationStepMode
+  roundRobinMinutes: number
   stepNumber: number
   targets: Target[]
 }
 
+export type EscalationStepMode = 'ack_count' | 'all' | 'round_robin'
+
 export interface Expr {
   conditionToExpr: string
   exprToCondition: Condition
@@ -584,6 +671,7 @@ export interface GQLAPIKey {
   lastUsed?: null | GQLAPIKeyUsage
   name: string
   query: string
+  rest: boolean
   role: UserRole
   updatedAt: ISOTimestamp
   updatedBy?: null | User
@@ -609,6 +697,21 @@ export interface HeartbeatMonitor {
 
 export type HeartbeatMonitorState = 'healthy' | 'inactive' | 'unhealthy'
 
+export interface Holiday {
+  date: string
+  name: string
+}
+
+export interface HolidayCalendar {
+  holidays: Holiday[]
+  id: string
+  importedAt: ISOTimestamp
+  name: string
+  sourceURL: string
+}
+
+export type HolidayFilter = 'any' | 'exclude' | 'only'
+
 export type ID = string
 
 export type ISODuration = string
@@ -617,6 +720,38 @@ export type ISORInterval = string
 
 export type ISOTimestamp = string
 
+export interface ImportHolidayCalendarInput {
+  ics?: null | string
+  id: string
+}
+
+export interface Incident {
+  alerts: Alert[]
+  createdAt: ISOTimestamp
+  details: string
+  id: number
+  status: AlertStatus
+  summary: string
+  timeline: AlertLogEntryConnection
+}
+
+export interface IncidentAlertsInput {
+  alertIDs: number[]
+  incidentID: number
+}
+
+export interface IncidentConnection {
+  nodes: Incident[]
+  pageInfo: PageInfo
+}
+
+export interface IncidentSearchOptions {
+  after?: null | string
+  first?: null | number
+  omitClosed?: null | boolean
+  search?: null | string
+}
+
 export type InlineDisplayInfo =
   | DestinationDisplayInfo
   | DestinationDisplayInfoError
@@ -729,6 +864,28 @@ export interface LinkAccountInfo {
   userDetails: string
 }
 
+export interface MaintenanceWindow {
+  createdAt: ISOTimestamp
+  createdBy?: null | User
+  end: ISOTimestamp
+  endAction: MaintenanceWindowEndAction
+  id: string
+  isActive: boolean
+  labelKey?: null | string
+  labelValue: string
+  nextEnd?: null | ISOTimestamp
+  nextStart?: null | ISOTimestamp
+  reason: string
+  repeat: MaintenanceWindowRepeat
+  serviceID?: null | string
+  start: ISOTimestamp
+  timeZone: string
+}
+
+export type MaintenanceWindowEndAction = 'drop' | 'escalate'
+
+export type MaintenanceWindowRepeat = 'daily' | 'none' | 'weekly'
+
 export interface MessageLogConnection {
   nodes: DebugMessage[]
   pageInfo: PageInfo
@@ -755,7 +912,10 @@ export interface MessageStatusHistory {
 }
 
 export interface Mutation {
+  acceptOverrideRequest: OverrideRequest
   addAuthSubject: boolean
+  addIncidentAlerts: boolean
+  applyTemporaryScheduleTemplate: boolean
   clearTemporarySchedules: boolean
   closeMatchingAlert: boolean
   createAlert?: null | Alert
@@ -764,35 +924,52 @@ export interface Mutation {
   createEscalationPolicyStep?: null | EscalationPolicyStep
   createGQLAPIKey: CreatedGQLAPIKey
   createHeartbeatMonitor?: null | HeartbeatMonitor
+  createHolidayCalendar: HolidayCalendar
+  createIncident: Incident
   createIntegrationKey?: null | IntegrationKey
+  createMaintenanceWindow: MaintenanceWindow
+  createOverrideRequest: OverrideRequest
   createRotation?: null | Rotation
   createSchedule?: null | Schedule
   createService?: null | Service
   createUser?: null | User
+  createUserAvailabilityFeed: UserAvailabilityFeed
   createUserCalendarSubscription: UserCalendarSubscription
   createUserContactMethod?: null | UserContactMethod
   createUserNotificationRule?: null | UserNotificationRule
   createUserOverride?: null | UserOverride
   debugCarrierInfo: DebugCarrierInfo
   debugSendSMS?: null | DebugSendSMSInfo
+  declineOverrideRequest: OverrideRequest
   deleteAll: boolean
   deleteAuthSubject: boolean
   deleteGQLAPIKey: boolean
+  deleteHolidayCalendar: boolean
+  deleteMaintenanceWindow: boolean
+  deleteOverrideRequest: boolean
   deleteSecondaryToken: boolean
+  deleteTemporaryScheduleTemplate: boolean
+  deleteUserAvailabilityFeed: boolean
   endAllAuthSessionsByCurrentUser: boolean
   escalateAlerts?: null | Alert[]
   generateKeyToken: string
+  importHolidayCalendar: boolean
   linkAccount: boolean
   promoteSecondaryToken: boolean
   reEncryptKeyringsAndConfig: boolean
+  removeIncidentAlerts: boolean
   sendContactMethodVerification: boolean
   setAlertNoiseReason: boolean
   setConfig: boolean
   setFavorite: boolean
   setLabel: boolean
+  setQuietHours: boolean
+  setScheduleHolidayCalendars: boolean
   setScheduleOnCallNotificationRules: boolean
+  setServiceAlertRules: boolean
   setSystemLimits: boolean
   setTemporarySchedule: boolean
+  setTemporaryScheduleTemplate: TemporaryScheduleTemplate
   swoAction: boolean
   testContactMethod: boolean
   updateAlerts?: null | Alert[]
@@ -802,12 +979,17 @@ export interface Mutation {
   updateEscalationPolicyStep: boolean
   updateGQLAPIKey: boolean
   updateHeartbeatMonitor: boolean
+  updateHolidayCalendar: boolean
+  updateIncident: boolean
+  updateIncidentStatus: Incident
   updateKeyConfig: boolean
+  updateMaintenanceWindow: boolean
   updateRotation: boolean
   updateSchedule: boolean
   updateScheduleTarget: boolean
   updateService: boolean
   updateUser: boolean
+  updateUserAvailabilityFeed: boolean
   updateUserCalendarSubscription: boolean
   updateUserContactMethod: boolean
   updateUserOverride: boolean
@@ -867,6 +1049,33 @@ export interface OnCallShift {
   userID: string
 }
 
+export interface OverrideRequest {
+  addUser?: null | User
+  createdAt: ISOTimestamp
+  end: ISOTimestamp
+  id: string
+  override?: null | UserOverride
+  removeUser?: null | User
+  requester?: null | User
+  schedule?: null | Schedule
+  start: ISOTimestamp
+  status: OverrideRequestStatus
+  targetUser?: null | User
+}
+
+export interface OverrideRequestSearchOptions {
+  pendingOnly?: null | boolean
+  requesterUserID?: null | string
+  scheduleID?: null | string
+  targetUserID?: null | string
+}
+
+export type OverrideRequestStatus =
+  | 'accepted'
+  | 'declined'
+  | 'expired'
+  | 'pending'
+
 export interface PageInfo {
   endCursor?: null | string
   hasNextPage: boolean
@@ -905,6 +1114,10 @@ export interface Query {
   generateSlackAppManifest: string
   gqlAPIKeys: GQLAPIKey[]
   heartbeatMonitor?: null | HeartbeatMonitor
+  holidayCalendar?: null | HolidayCalendar
+  holidayCalendars: HolidayCalendar[]
+  incident?: null | Incident
+  incidents: IncidentConnection
   integrationKey?: null | IntegrationKey
   integrationKeyTypes: IntegrationKeyTypeInfo[]
   integrationKeys: IntegrationKeyConnection
@@ -912,8 +1125,11 @@ export interface Query {
   labelValues: StringConnection
   labels: LabelConnection
   linkAccountInfo?: null | LinkAccountInfo
+  maintenanceWindows: MaintenanceWindow[]
   messageLogs: MessageLogConnection
   messageStatusHistory: MessageStatusHistory[]
+  overrideRequest?: null | OverrideRequest
+  overrideRequests: OverrideRequest[]
   phoneNumberInfo?: null | PhoneNumberInfo
   rotation?: null | Rotation
   rotations: RotationConnection
@@ -934,15 +1150,32 @@ export interface Query {
   userOverride?: null | UserOverride
   userOverrides: UserOverrideConnection
   users: UserConnection
+  workloadReport: WorkloadReport
+}
+
+export interface QuietHours {
+  end: ClockTime
+  start: ClockTime
+  timeZone: string
+}
+
+export interface QuietHoursInput {
+  end: ClockTime
+  start: ClockTime
+  timeZone: string
 }
 
 export interface Rotation {
+  activeCount: number
   activeUserIndex: number
+  activeUserIndexes: number[]
   description: string
   id: string
   isFavorite: boolean
   name: string
   nextHandoffTimes: ISOTimestamp[]
+  participantWindows: RotationParticipantWindow[]
+  shadows: boolean[]
   shiftLength: number
   start: ISOTimestamp
   timeZone: string
@@ -956,6 +1189,18 @@ export interface RotationConnection {
   pageInfo: PageInfo
 }
 
+export interface RotationParticipantWindow {
+  end: ClockTime
+  start: ClockTime
+  timeZone: string
+}
+
+export interface RotationParticipantWindowInput {
+  end: ClockTime
+  start: ClockTime
+  timeZone: string
+}
+
 export interface RotationSearchOptions {
   after?: null | string
   favoritesFirst?: null | boolean
@@ -965,7 +1210,12 @@ export interface RotationSearchOptions {
   search?: null | string
 }
 
-export type RotationType = 'daily' | 'hourly' | 'monthly' | 'weekly'
+export type RotationType =
+  | 'daily'
+  | 'follow_the_sun'
+  | 'hourly'
+  | 'monthly'
+  | 'weekly'
 
 export type SWOAction = 'execute' | 'reset'
 
@@ -1006,14 +1256,18 @@ export interface SWOStatus {
 
 export interface Schedule {
   assignedTo: Target[]
+  coverageGaps: ScheduleCoverageGap[]
   description: string
+  holidayCalendars: HolidayCalendar[]
   id: string
   isFavorite: boolean
   name: string
+  notices: Notice[]
   onCallNotificationRules: OnCallNotificationRule[]
   shifts: OnCallShift[]
   target?: null | ScheduleTarget
   targets: ScheduleTarget[]
+  temporaryScheduleTemplates: TemporaryScheduleTemplate[]
   temporarySchedules: TemporarySchedule[]
   timeZone: string
 }
@@ -1023,8 +1277,14 @@ export interface ScheduleConnection {
   pageInfo: PageInfo
 }
 
+export interface ScheduleCoverageGap {
+  end: ISOTimestamp
+  start: ISOTimestamp
+}
+
 export interface ScheduleRule {
   end: ClockTime
+  holidayFilter: HolidayFilter
   id: string
   scheduleID: string
   start: ClockTime
@@ -1034,6 +1294,7 @@ export interface ScheduleRule {
 
 export interface ScheduleRuleInput {
   end?: null | ClockTime
+  holidayFilter?: null | HolidayFilter
   id?: null | string
   start?: null | ClockTime
   weekdayFilter?: null | WeekdayFilter
@@ -1066,21 +1327,47 @@ export interface SendContactMethodVerificationInput {
 }
 
 export interface Service {
+  alertRules: ServiceAlertRule[]
   alertStats: AlertStats
   alertsByStatus: AlertsByStatus
   description: string
   escalationPolicy?: null | EscalationPolicy
   escalationPolicyID: string
+  flapThreshold: number
+  flapWindowMinutes: number
   heartbeatMonitors: HeartbeatMonitor[]
   id: string
   integrationKeys: IntegrationKey[]
   isFavorite: boolean
   labels: Label[]
   maintenanceExpiresAt?: null | ISOTimestamp
+  maintenanceWindows: MaintenanceWindow[]
   name: string
   notices: Notice[]
   onCallUsers: ServiceOnCallUser[]
+  quietHours?: null | QuietHours
   recentEvents: AlertLogEntryConnection
+  reopenWindowMinutes: number
+}
+
+export interface ServiceAlertRule {
+  conditionExpr: ExprBooleanExpression
+  continueAfterMatch: boolean
+  description: string
+  drop: boolean
+  id: string
+  name: string
+  set: ExprStringMap
+}
+
+export interface ServiceAlertRuleInput {
+  conditionExpr: ExprBooleanExpression
+  continueAfterMatch: boolean
+  description: string
+  drop: boolean
+  id?: null | string
+  name: string
+  set: ExprStringMap
 }
 
 export interface ServiceAlertStatsOptions {
@@ -1126,6 +1413,17 @@ export interface SetLabelInput {
   value: string
 }
 
+export interface SetQuietHoursInput {
+  quietHours?: null | QuietHoursInput
+  serviceID?: null | string
+  userID?: null | string
+}
+
+export interface SetScheduleHolidayCalendarsInput {
+  calendarIDs: string[]
+  scheduleID: string
+}
+
 export interface SetScheduleOnCallNotificationRulesInput {
   rules: OnCallNotificationRuleInput[]
   scheduleID: string
@@ -1137,6 +1435,11 @@ export interface SetScheduleShiftInput {
   userID: string
 }
 
+export interface SetServiceAlertRulesInput {
+  rules: ServiceAlertRuleInput[]
+  serviceID: string
+}
+
 export interface SetTemporaryScheduleInput {
   clearEnd?: null | ISOTimestamp
   clearStart?: null | ISOTimestamp
@@ -1146,6 +1449,15 @@ export interface SetTemporaryScheduleInput {
   start: ISOTimestamp
 }
 
+export interface SetTemporaryScheduleTemplateInput {
+  days: number
+  id?: null | string
+  name: string
+  recurrence?: null | TemporaryScheduleTemplateRecurrenceInput
+  scheduleID: string
+  shifts: TemporaryScheduleTemplateShiftInput[]
+}
+
 export interface SlackChannel {
   id: string
   name: string
@@ -1188,6 +1500,21 @@ export type StatusUpdateState =
   | 'ENABLED'
   | 'ENABLED_FORCED'
 
+export interface StepActiveHours {
+  end: ClockTime
+  isActive: boolean
+  start: ClockTime
+  timeZone: string
+  weekdayFilter: WeekdayFilter
+}
+
+export interface StepActiveHoursInput {
+  end: ClockTime
+  start: ClockTime
+  timeZone: string
+  weekdayFilter: WeekdayFilter
+}
+
 export type String = string
 
 export interface StringConnection {
@@ -1258,6 +1585,48 @@ export interface TemporarySchedule {
   start: ISOTimestamp
 }
 
+export interface TemporaryScheduleTemplate {
+  days: number
+  id: string
+  lastApplied?: null | ISOTimestamp
+  name: string
+  recurrence?: null | TemporaryScheduleTemplateRecurrence
+  shifts: TemporaryScheduleTemplateShift[]
+}
+
+export interface TemporaryScheduleTemplateOffset {
+  day: number
+  time: ClockTime
+}
+
+export interface TemporaryScheduleTemplateOffsetInput {
+  day: number
+  time: ClockTime
+}
+
+export interface TemporaryScheduleTemplateRecurrence {
+  day: number
+  month: number
+}
+
+export interface TemporaryScheduleTemplateRecurrenceInput {
+  day: number
+  month: number
+}
+
+export interface TemporaryScheduleTemplateShift {
+  end: TemporaryScheduleTemplateOffset
+  start: TemporaryScheduleTemplateOffset
+  user?: null | User
+  userID: string
+}
+
+export interface TemporaryScheduleTemplateShiftInput {
+  end: TemporaryScheduleTemplateOffsetInput
+  start: TemporaryScheduleTemplateOffsetInput
+  userID: string
+}
+
 export interface TimeSeriesBucket {
   count: number
   end: ISOTimestamp
@@ -1300,6 +1669,7 @@ export interface UpdateAlertsInput {
   alertIDs: number[]
   newStatus?: null | AlertStatus
   noiseReason?: null | string
+  snoozeMinutes?: null | number
 }
 
 export interface UpdateBasicAuthInput {
@@ -1317,9 +1687,13 @@ export interface UpdateEscalationPolicyInput {
 }
 
 export interface UpdateEscalationPolicyStepInput {
+  ackCount?: null | number
   actions?: null | DestinationInput[]
+  activeHours?: null | StepActiveHoursInput
   delayMinutes?: null | number
   id: string
+  mode?: null | EscalationStepMode
+  roundRobinMinutes?: null | number
   targets?: null | TargetInput[]
 }
 
@@ -1337,6 +1711,23 @@ export interface UpdateHeartbeatMonitorInput {
   timeoutMinutes?: null | number
 }
 
+export interface UpdateHolidayCalendarInput {
+  id: string
+  name?: null | string
+  sourceURL?: null | string
+}
+
+export interface UpdateIncidentInput {
+  details?: null | string
+  id: number
+  summary?: null | string
+}
+
+export interface UpdateIncidentStatusInput {
+  id: number
+  newStatus: AlertStatus
+}
+
 export interface UpdateKeyConfigInput {
   defaultActions?: null | ActionInput[]
   deleteRule?: null | string
@@ -1347,11 +1738,24 @@ export interface UpdateKeyConfigInput {
   setRuleOrder?: null | string[]
 }
 
+export interface UpdateMaintenanceWindowInput {
+  end?: null | ISOTimestamp
+  endAction?: null | MaintenanceWindowEndAction
+  id: string
+  reason?: null | string
+  repeat?: null | MaintenanceWindowRepeat
+  start?: null | ISOTimestamp
+  timeZone?: null | string
+}
+
 export interface UpdateRotationInput {
+  activeCount?: null | number
   activeUserIndex?: null | number
   description?: null | string
   id: string
   name?: null | string
+  participantWindows?: null | RotationParticipantWindowInput[]
+  shadows?: null | boolean[]
   shiftLength?: null | number
   start?: null | ISOTimestamp
   timeZone?: null | string
@@ -1369,9 +1773,21 @@ export interface UpdateScheduleInput {
 export interface UpdateServiceInput {
   description?: null | string
   escalationPolicyID?: null | string
+  flapThreshold?: null | number
+  flapWindowMinutes?: null | number
   id: string
   maintenanceExpiresAt?: null | ISOTimestamp
   name?: null | string
+  reopenWindowMinutes?: null | number
+}
+
+export interface UpdateUserAvailabilityFeedInput {
+  id: string
+  keyword?: null | string
+  name?: null | string
+  substituteUserID?: null | string
+  timeZone?: null | string
+  url?: null | string
 }
 
 export interface UpdateUserCalendarSubscriptionInput {
@@ -1408,6 +1824,7 @@ export interface UpdateUserOverrideInput {
 export interface User {
   assignedSchedules: Schedule[]
   authSubjects: AuthSubject[]
+  availabilityFeeds: UserAvailabilityFeed[]
   calendarSubscriptions: UserCalendarSubscription[]
   contactMethods: UserContactMethod[]
   email: string
@@ -1417,11 +1834,25 @@ export interface User {
   notificationRules: UserNotificationRule[]
   onCallOverview: OnCallOverview
   onCallSteps: EscalationPolicyStep[]
+  quietHours?: null | QuietHours
   role: UserRole
   sessions: UserSession[]
   statusUpdateContactMethodID: string
 }
 
+export interface UserAvailabilityFeed {
+  id: string
+  keyword: string
+  lastError: string
+  lastSyncAt?: null | ISOTimestamp
+  name: string
+  periods: UserUnavailabilityPeriod[]
+  substituteUser?: null | User
+  timeZone: string
+  url: string
+  userID: string
+}
+
 export interface UserCalendarSubscription {
   disabled: boolean
   fullSchedule: boolean
@@ -1429,8 +1860,13 @@ export interface UserCalendarSubscription {
   lastAccess: ISOTimestamp
   name: string
   reminderMinutes: number[]
+  rotation?: null | Rotation
+  rotationID?: null | string
   schedule?: null | Schedule
-  scheduleID: string
+  scheduleID?: null | string
+  service?: null | Service
+  serviceID?: null | string
+  type: CalendarSubscriptionType
   url?: null | string
 }
 
@@ -1459,6 +1895,9 @@ export interface UserNotificationRule {
   contactMethodID: string
   delayMinutes: number
   id: string
+  priorities: number[]
+  serviceLabelKey: string
+  serviceLabelValue: string
 }
 
 export interface UserOverride {
@@ -1511,6 +1950,23 @@ export interface UserSession {
   userAgent: string
 }
 
+export interface UserUnavailabilityPeriod {
+  end: ISOTimestamp
+  start: ISOTimestamp
+  summary: string
+}
+
+export interface UserWorkload {
+  acknowledged: number
+  nightInterruptions: number
+  offHoursHours: Float
+  onCallHours: Float
+  pages: number
+  user?: null | User
+  userID: string
+  weekendHours: Float
+}
+
 export interface VerifyContactMethodInput {
   code: number
   contactMethodID: string
@@ -1526,6 +1982,22 @@ export type WeekdayFilter = [
   boolean,
 ]
 
+export interface WorkloadReport {
+  end: ISOTimestamp
+  start: ISOTimestamp
+  timeZone: string
+  users: UserWorkload[]
+}
+
+export interface WorkloadReportInput {
+  end: ISOTimestamp
+  rotationID?: null | string
+  scheduleID?: null | string
+  start: ISOTimestamp
+  timeZone?: null | string
+  userID?: null | string
+}
+
 export interface __Directive {
   args: __InputValue[]
   description?: null | string
@@ -1624,6 +2096,7 @@ type ConfigID =
   | 'General.DisableLabelCreation'
   | 'General.DisableCalendarSubscriptions'
   | 'Services.RequiredLabels'
+  | 'Schedules.CoverageGapNoticeHours'
   | 'Maintenance.AlertCleanupDays'
   | 'Maintenance.AlertAutoCloseDays'
   | 'Maintenance.AutoCloseAckedAlerts'
@@ -1657,6 +2130,12 @@ type ConfigID =
   | 'Slack.AccessToken'
   | 'Slack.SigningSecret'
   | 'Slack.InteractiveMessages'
+  | 'MSTeams.Enable'
+  | 'MSTeams.AppID'
+  | 'MSTeams.AppSecret'
+  | 'MSTeams.TenantID'
+  | 'MSTeams.ServiceURL'
+  | 'MSTeams.InteractiveMessages'
   | 'Twilio.Enable'
   | 'Twilio.VoiceName'
   | 'Twilio.VoiceLanguage'
@@ -1669,6 +2148,15 @@ type ConfigID =
   | 'Twilio.DisableTwoWaySMS'
   | 'Twilio.SMSCarrierLookup'
   | 'Twilio.SMSFromNumberOverride'
+  | 'SMPP.Enable'
+  | 'SMPP.Address'
+  | 'SMPP.EnableTLS'
+  | 'SMPP.SkipVerify'
+  | 'SMPP.SystemID'
+  | 'SMPP.Password'
+  | 'SMPP.SystemType'
+  | 'SMPP.SourceAddr'
+  | 'SMPP.DisableTwoWaySMS'
   | 'SMTP.Enable'
   | 'SMTP.From'
   | 'SMTP.Address'
@@ -1678,5 +2166,8 @@ type ConfigID =
   | 'SMTP.Password'
   | 'Webhook.Enable'
   | 'Webhook.AllowedURLs'
+  | 'Webhook.ActionURLs'
+  | 'WebPush.Enable'
+  | 'WebPush.Subject'
   | 'Feedback.Enable'
   | 'Feedback.OverrideURL'
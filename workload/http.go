@@ -0,0 +1,98 @@
+package workload
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/validation"
+)
+
+// csvHeader is the header row of the CSV export.
+var csvHeader = []string{
+	"user_id",
+	"user_name",
+	"on_call_hours",
+	"off_hours_hours",
+	"weekend_hours",
+	"pages",
+	"night_interruptions",
+	"acknowledged",
+}
+
+// csvSafe will prefix a value that would be interpreted as a formula by spreadsheet applications (e.g., a user
+// named "=HYPERLINK(...)") with a single quote, so it is displayed as text instead.
+func csvSafe(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
+
+// ServeCSV will export a workload report as CSV.
+//
+// The report is selected with the `scheduleID`, `rotationID`, or `userID` query parameter, over the
+// range given by `start` and `end` (RFC 3339). The optional `timeZone` parameter is used for off-hours,
+// weekend, and night calculations.
+func (s *Store) ServeCSV(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	q := req.URL.Query()
+
+	opts := Options{
+		ScheduleID: q.Get("scheduleID"),
+		RotationID: q.Get("rotationID"),
+		UserID:     q.Get("userID"),
+	}
+
+	var err error
+	opts.Start, err = time.Parse(time.RFC3339, q.Get("start"))
+	if err != nil {
+		errutil.HTTPError(ctx, w, validation.NewFieldError("start", "must be an RFC 3339 timestamp"))
+		return
+	}
+	opts.End, err = time.Parse(time.RFC3339, q.Get("end"))
+	if err != nil {
+		errutil.HTTPError(ctx, w, validation.NewFieldError("end", "must be an RFC 3339 timestamp"))
+		return
+	}
+	if tz := q.Get("timeZone"); tz != "" {
+		opts.TimeZone, err = util.LoadLocation(tz)
+		if err != nil {
+			errutil.HTTPError(ctx, w, validation.NewFieldError("timeZone", err.Error()))
+			return
+		}
+	}
+
+	r, err := s.Report(ctx, opts)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	names, err := s.UserNames(ctx, r)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="workload.csv"`)
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write(csvHeader)
+	for _, u := range r.Users {
+		_ = cw.Write([]string{
+			u.UserID,
+			csvSafe(names[u.UserID]),
+			strconv.FormatFloat(u.OnCallHours, 'f', 2, 64),
+			strconv.FormatFloat(u.OffHoursHours, 'f', 2, 64),
+			strconv.FormatFloat(u.WeekendHours, 'f', 2, 64),
+			strconv.Itoa(u.Pages),
+			strconv.Itoa(u.NightInterruptions),
+			strconv.Itoa(u.Acknowledged),
+		})
+	}
+	cw.Flush()
+}
@@ -0,0 +1,54 @@
+-- name: Workload_UserShifts :many
+-- Returns the schedule shifts of a user that overlap the given time range.
+SELECT
+    user_id,
+    start_time,
+    end_time
+FROM
+    schedule_on_call_users
+WHERE
+    user_id = @user_id
+    AND start_time < @end_time::timestamptz
+    AND (end_time ISNULL
+        OR end_time > @start_time::timestamptz);
+
+-- name: Workload_Pages :many
+-- Returns the time each user was first notified of each alert within the given time range.
+SELECT
+    user_id::uuid AS user_id,
+    alert_id::bigint AS alert_id,
+    min(coalesce(sent_at, last_status_at))::timestamptz AS notified_at
+FROM
+    outgoing_messages
+WHERE
+    message_type = 'alert_notification'
+    AND user_id = ANY (@user_ids::uuid[])
+    AND last_status NOT IN ('pending', 'sending', 'failed')
+    AND coalesce(sent_at, last_status_at) >= @start_time::timestamptz
+    AND coalesce(sent_at, last_status_at) < @end_time::timestamptz
+GROUP BY
+    user_id,
+    alert_id;
+
+-- name: Workload_Acks :many
+-- Returns alert acknowledgements made by the given users within the given time range.
+SELECT
+    sub_user_id::uuid AS user_id,
+    timestamp::timestamptz AS acked_at
+FROM
+    alert_logs
+WHERE
+    event = 'acknowledged'
+    AND sub_user_id = ANY (@user_ids::uuid[])
+    AND timestamp >= @start_time::timestamptz
+    AND timestamp < @end_time::timestamptz;
+
+-- name: Workload_UserNames :many
+-- Returns the names of the given users.
+SELECT
+    id,
+    name
+FROM
+    users
+WHERE
+    id = ANY (@user_ids::uuid[]);
@@ -0,0 +1,206 @@
+package workload
+
+import (
+	"sort"
+	"time"
+
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/util/timeutil"
+)
+
+var (
+	// businessStart and businessEnd are the local times of day, on weekdays, outside of which on-call
+	// time is counted as off-hours.
+	businessStart = timeutil.NewClock(9, 0)
+	businessEnd   = timeutil.NewClock(17, 0)
+
+	// nightStart and nightEnd are the local times of day during which a page is counted as a night
+	// interruption.
+	nightStart = timeutil.NewClock(22, 0)
+	nightEnd   = timeutil.NewClock(7, 0)
+)
+
+// A Report is the on-call workload of each user over a time range.
+type Report struct {
+	Start    time.Time
+	End      time.Time
+	TimeZone *time.Location
+
+	Users []UserWorkload
+}
+
+// UserWorkload is the on-call workload of a single user.
+type UserWorkload struct {
+	UserID string
+
+	// OnCallHours is the total number of hours on call.
+	OnCallHours float64
+
+	// OffHoursHours is the number of hours on call on weekdays, outside of business hours.
+	OffHoursHours float64
+
+	// WeekendHours is the number of hours on call on Saturday or Sunday.
+	WeekendHours float64
+
+	// Pages is the number of alerts the user was notified about.
+	Pages int
+
+	// NightInterruptions is the number of alerts the user was first notified about at night.
+	NightInterruptions int
+
+	// Acknowledged is the number of alerts acknowledged by the user.
+	Acknowledged int
+}
+
+// event is a page or acknowledgement by a user.
+type event struct {
+	UserID string
+	Time   time.Time
+}
+
+// calcReport builds a report from the given shifts and events. If onlyOnCall is set, events are only
+// counted when they occur during one of the user's shifts.
+func calcReport(start, end time.Time, loc *time.Location, shifts []oncall.Shift, pages, acks []event, onlyOnCall bool) *Report {
+	byUser := make(map[string][]oncall.Shift)
+	for _, s := range shifts {
+		if s.End.IsZero() || s.End.After(end) {
+			s.End = end
+		}
+		if s.Start.Before(start) {
+			s.Start = start
+		}
+		if !s.End.After(s.Start) {
+			continue
+		}
+		byUser[s.UserID] = append(byUser[s.UserID], s)
+	}
+	for id, s := range byUser {
+		byUser[id] = mergeShifts(s)
+	}
+
+	users := make(map[string]*UserWorkload)
+	getUser := func(id string) *UserWorkload {
+		u := users[id]
+		if u == nil {
+			u = &UserWorkload{UserID: id}
+			users[id] = u
+		}
+		return u
+	}
+
+	for id, shifts := range byUser {
+		u := getUser(id)
+		var onCall, offHours, weekend time.Duration
+		for _, s := range shifts {
+			on, off, wk := splitHours(s.Start, s.End, loc)
+			onCall += on
+			offHours += off
+			weekend += wk
+		}
+		u.OnCallHours = onCall.Hours()
+		u.OffHoursHours = offHours.Hours()
+		u.WeekendHours = weekend.Hours()
+	}
+
+	counted := func(e event) bool {
+		if !onlyOnCall {
+			return true
+		}
+		for _, s := range byUser[e.UserID] {
+			if !e.Time.Before(s.Start) && e.Time.Before(s.End) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, e := range pages {
+		if !counted(e) {
+			continue
+		}
+		u := getUser(e.UserID)
+		u.Pages++
+		if isNight(e.Time.In(loc)) {
+			u.NightInterruptions++
+		}
+	}
+	for _, e := range acks {
+		if !counted(e) {
+			continue
+		}
+		getUser(e.UserID).Acknowledged++
+	}
+
+	r := &Report{Start: start, End: end, TimeZone: loc, Users: make([]UserWorkload, 0, len(users))}
+	for _, u := range users {
+		r.Users = append(r.Users, *u)
+	}
+	sort.Slice(r.Users, func(i, j int) bool { return r.Users[i].UserID < r.Users[j].UserID })
+
+	return r
+}
+
+// mergeShifts combines overlapping shifts of the same user, so time is not counted twice.
+func mergeShifts(shifts []oncall.Shift) []oncall.Shift {
+	sort.Slice(shifts, func(i, j int) bool { return shifts[i].Start.Before(shifts[j].Start) })
+
+	result := shifts[:0]
+	for _, s := range shifts {
+		if len(result) > 0 && !s.Start.After(result[len(result)-1].End) {
+			last := &result[len(result)-1]
+			if s.End.After(last.End) {
+				last.End = s.End
+			}
+			continue
+		}
+		result = append(result, s)
+	}
+
+	return result
+}
+
+// splitHours returns the total, off-hours, and weekend duration between start and end in loc.
+func splitHours(start, end time.Time, loc *time.Location) (total, offHours, weekend time.Duration) {
+	t := start
+	for t.Before(end) {
+		local := t.In(loc)
+		day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+		// next boundary is the start or end of business hours, or the next day
+		next := day.AddDate(0, 0, 1)
+		for _, c := range []timeutil.Clock{businessEnd, businessStart} {
+			b := time.Date(day.Year(), day.Month(), day.Day(), c.Hour(), c.Minute(), 0, 0, loc)
+			if b.After(t) && b.Before(next) {
+				next = b
+			}
+		}
+		if next.After(end) {
+			next = end
+		}
+
+		dur := next.Sub(t)
+		total += dur
+		switch {
+		case local.Weekday() == time.Saturday || local.Weekday() == time.Sunday:
+			weekend += dur
+		case isOffHours(local):
+			offHours += dur
+		}
+
+		t = next
+	}
+
+	return total, offHours, weekend
+}
+
+// isOffHours returns true if the local time t is outside of business hours.
+func isOffHours(t time.Time) bool {
+	c := timeutil.NewClockFromTime(t)
+	return c < businessStart || c >= businessEnd
+}
+
+// isNight returns true if the local time t is during the night.
+func isNight(t time.Time) bool {
+	c := timeutil.NewClockFromTime(t)
+	return c >= nightStart || c < nightEnd
+}
@@ -0,0 +1,73 @@
+package workload
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/oncall"
+)
+
+func TestSplitHours(t *testing.T) {
+	loc := time.UTC
+
+	// Friday 2024-01-05 12:00 to Saturday 12:00
+	total, off, weekend := splitHours(
+		time.Date(2024, 1, 5, 12, 0, 0, 0, loc),
+		time.Date(2024, 1, 6, 12, 0, 0, 0, loc),
+		loc,
+	)
+	assert.Equal(t, 24*time.Hour, total)
+	assert.Equal(t, 7*time.Hour, off, "17:00 to midnight Friday")
+	assert.Equal(t, 12*time.Hour, weekend)
+}
+
+func TestCalcReport(t *testing.T) {
+	loc := time.UTC
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, loc) // Monday
+	end := start.AddDate(0, 0, 7)
+
+	shifts := []oncall.Shift{
+		{UserID: "a", Start: start.Add(-time.Hour), End: start.Add(24 * time.Hour)},
+		{UserID: "a", Start: start.Add(12 * time.Hour), End: start.Add(36 * time.Hour)}, // overlaps
+		{UserID: "b", Start: start.Add(36 * time.Hour)},                                 // still on call
+	}
+	pages := []event{
+		{UserID: "a", Time: start.Add(2 * time.Hour)},  // night
+		{UserID: "a", Time: start.Add(12 * time.Hour)}, // day
+		{UserID: "a", Time: start.Add(48 * time.Hour)}, // not on call
+		{UserID: "b", Time: start.Add(50 * time.Hour)},
+	}
+	acks := []event{
+		{UserID: "a", Time: start.Add(12 * time.Hour)},
+	}
+
+	r := calcReport(start, end, loc, shifts, pages, acks, true)
+	require.Len(t, r.Users, 2)
+
+	a, b := r.Users[0], r.Users[1]
+	assert.Equal(t, "a", a.UserID)
+	assert.Equal(t, 36.0, a.OnCallHours, "overlapping shifts are merged and clamped")
+	assert.Equal(t, 2, a.Pages)
+	assert.Equal(t, 1, a.NightInterruptions)
+	assert.Equal(t, 1, a.Acknowledged)
+
+	assert.Equal(t, "b", b.UserID)
+	assert.Equal(t, 132.0, b.OnCallHours)
+	assert.Equal(t, 48.0, b.WeekendHours)
+	assert.Equal(t, 1, b.Pages)
+
+	r = calcReport(start, end, loc, shifts, pages, acks, false)
+	assert.Equal(t, 3, r.Users[0].Pages, "all pages counted")
+}
+
+func TestCSVSafe(t *testing.T) {
+	assert.Equal(t, "Bob", csvSafe("Bob"))
+	assert.Equal(t, "", csvSafe(""))
+	assert.Equal(t, "'=HYPERLINK(\"http://example.com\")", csvSafe("=HYPERLINK(\"http://example.com\")"))
+	assert.Equal(t, "'+1", csvSafe("+1"))
+	assert.Equal(t, "'-1", csvSafe("-1"))
+	assert.Equal(t, "'@SUM(A1)", csvSafe("@SUM(A1)"))
+	assert.Equal(t, "'\tBob", csvSafe("\tBob"))
+}
@@ -0,0 +1,261 @@
+package workload
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/gadb"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxDays is the maximum number of days a report can cover.
+const MaxDays = 366
+
+// Store generates on-call workload reports.
+type Store struct {
+	db  *sql.DB
+	oc  *oncall.Store
+	rot *rotation.Store
+}
+
+// NewStore will create a new Store.
+func NewStore(ctx context.Context, db *sql.DB, oc *oncall.Store, rot *rotation.Store) (*Store, error) {
+	return &Store{db: db, oc: oc, rot: rot}, nil
+}
+
+// Options determine the scope and time range of a report. Exactly one of ScheduleID, RotationID, or
+// UserID must be set.
+type Options struct {
+	ScheduleID string
+	RotationID string
+	UserID     string
+
+	Start time.Time
+	End   time.Time
+
+	// TimeZone is used to determine off-hours, weekends, and night interruptions. Defaults to UTC.
+	TimeZone *time.Location
+}
+
+func (o Options) validate() error {
+	var n int
+	for _, id := range []string{o.ScheduleID, o.RotationID, o.UserID} {
+		if id != "" {
+			n++
+		}
+	}
+	if n != 1 {
+		return validation.NewFieldError("ScheduleID", "exactly one of schedule, rotation, or user must be specified")
+	}
+	if !o.End.After(o.Start) {
+		return validation.NewFieldError("End", "must be after Start")
+	}
+	if o.End.Sub(o.Start) > MaxDays*24*time.Hour {
+		return validation.NewFieldError("End", fmt.Sprintf("must be within %d days of Start", MaxDays))
+	}
+
+	switch {
+	case o.ScheduleID != "":
+		return validate.UUID("ScheduleID", o.ScheduleID)
+	case o.RotationID != "":
+		return validate.UUID("RotationID", o.RotationID)
+	}
+
+	return validate.UUID("UserID", o.UserID)
+}
+
+// Report will calculate the on-call workload for the given options.
+//
+// For schedules and rotations, pages and acknowledgements are only counted while the user was on call
+// for it. Rotation shifts are calculated from the current rotation configuration, and do not reflect
+// past changes to it. For users, shifts from all schedules are combined, and all pages and
+// acknowledgements are counted.
+//
+// Pages are limited to the retention of outgoing message records.
+func (s *Store) Report(ctx context.Context, opts Options) (*Report, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = opts.validate()
+	if err != nil {
+		return nil, err
+	}
+	if opts.TimeZone == nil {
+		opts.TimeZone = time.UTC
+	}
+
+	// only report on history
+	if now := time.Now(); opts.End.After(now) {
+		opts.End = now
+	}
+	if !opts.End.After(opts.Start) {
+		return &Report{Start: opts.Start, End: opts.Start, TimeZone: opts.TimeZone, Users: []UserWorkload{}}, nil
+	}
+
+	var shifts []oncall.Shift
+	switch {
+	case opts.ScheduleID != "":
+		shifts, err = s.oc.HistoryBySchedule(ctx, opts.ScheduleID, opts.Start, opts.End)
+	case opts.RotationID != "":
+		shifts, err = s.rotationShifts(ctx, opts.RotationID, opts.Start, opts.End)
+	default:
+		shifts, err = s.userShifts(ctx, opts.UserID, opts.Start, opts.End)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make(map[uuid.UUID]struct{})
+	if opts.UserID != "" {
+		userIDs[uuid.MustParse(opts.UserID)] = struct{}{}
+	}
+	for _, s := range shifts {
+		id, err := uuid.Parse(s.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("parse user ID: %w", err)
+		}
+		userIDs[id] = struct{}{}
+	}
+	ids := make([]uuid.UUID, 0, len(userIDs))
+	for id := range userIDs {
+		ids = append(ids, id)
+	}
+
+	db := gadb.New(s.db)
+	pageRows, err := db.Workload_Pages(ctx, gadb.Workload_PagesParams{
+		UserIds:   ids,
+		StartTime: opts.Start,
+		EndTime:   opts.End,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lookup pages: %w", err)
+	}
+	pages := make([]event, 0, len(pageRows))
+	for _, r := range pageRows {
+		pages = append(pages, event{UserID: r.UserID.String(), Time: r.NotifiedAt})
+	}
+
+	ackRows, err := db.Workload_Acks(ctx, gadb.Workload_AcksParams{
+		UserIds:   ids,
+		StartTime: opts.Start,
+		EndTime:   opts.End,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lookup acknowledgements: %w", err)
+	}
+	acks := make([]event, 0, len(ackRows))
+	for _, r := range ackRows {
+		acks = append(acks, event{UserID: r.UserID.String(), Time: r.AckedAt})
+	}
+
+	return calcReport(opts.Start, opts.End, opts.TimeZone, shifts, pages, acks, opts.UserID == ""), nil
+}
+
+// userShifts returns the shifts of a user across all schedules.
+func (s *Store) userShifts(ctx context.Context, userID string, start, end time.Time) ([]oncall.Shift, error) {
+	rows, err := gadb.New(s.db).Workload_UserShifts(ctx, gadb.Workload_UserShiftsParams{
+		UserID:    uuid.MustParse(userID),
+		StartTime: start,
+		EndTime:   end,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lookup user shifts: %w", err)
+	}
+
+	shifts := make([]oncall.Shift, 0, len(rows))
+	for _, r := range rows {
+		shifts = append(shifts, oncall.Shift{
+			UserID: r.UserID.String(),
+			Start:  r.StartTime,
+			End:    r.EndTime.Time,
+		})
+	}
+
+	return shifts, nil
+}
+
+// rotationShifts calculates the shifts of a rotation from its current configuration and state.
+func (s *Store) rotationShifts(ctx context.Context, rotationID string, start, end time.Time) ([]oncall.Shift, error) {
+	rot, err := s.rot.FindRotation(ctx, rotationID)
+	if err != nil {
+		return nil, err
+	}
+	state, err := s.rot.State(ctx, rotationID)
+	if errors.Is(err, rotation.ErrNoState) {
+		// no participants
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	parts, err := s.rot.FindAllParticipants(ctx, rotationID)
+	if err != nil {
+		return nil, err
+	}
+
+	rr := oncall.ResolvedRotation{
+		Rotation:     *rot,
+		CurrentIndex: state.Position,
+		CurrentStart: state.ShiftStart,
+		Shadows:      rotation.ParticipantShadows(parts),
+	}
+	rr.Windows = rotation.ParticipantWindows(parts)
+	for _, p := range parts {
+		rr.Users = append(rr.Users, p.Target.TargetID())
+	}
+	if len(rr.Users) == 1 {
+		return []oncall.Shift{{UserID: rr.Users[0], Start: start, End: end}}, nil
+	}
+
+	var shifts []oncall.Shift
+	cur := start.In(rot.Start.Location())
+	for i := 0; cur.Before(end); i++ {
+		if i > 100000 {
+			return nil, errors.New("too many rotation shifts")
+		}
+		for _, id := range rr.UserIDs(cur) {
+			shifts = append(shifts, oncall.Shift{UserID: id, Start: rr.CurrentStart, End: rr.CurrentEnd})
+		}
+		cur = rr.CurrentEnd
+	}
+
+	return shifts, nil
+}
+
+// UserNames returns the name of each user in the report.
+func (s *Store) UserNames(ctx context.Context, r *Report) (map[string]string, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(r.Users))
+	for _, u := range r.Users {
+		id, err := uuid.Parse(u.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("parse user ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	rows, err := gadb.New(s.db).Workload_UserNames(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("lookup user names: %w", err)
+	}
+
+	names := make(map[string]string, len(rows))
+	for _, row := range rows {
+		names[row.ID.String()] = row.Name
+	}
+
+	return names, nil
+}